@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/pingcap/errors"
+)
+
+// backoffType is a kind of failure retried with exponential backoff, from base doubling up to cap.
+type backoffType struct {
+	name string
+	base time.Duration
+	cap  time.Duration
+}
+
+var (
+	// the cached route of a region is stale, the new one is usually ready at once
+	boRegionMiss = backoffType{name: "regionMiss", base: 2 * time.Millisecond, cap: 500 * time.Millisecond}
+	// a store can't be reached
+	boStoreRPC = backoffType{name: "storeRPC", base: 100 * time.Millisecond, cap: 2 * time.Second}
+	// the region is electing a leader or the command is stale
+	boNotLeader = backoffType{name: "notLeader", base: 2 * time.Millisecond, cap: 500 * time.Millisecond}
+	// the region is fast-failed by the store, it keeps failing for a while
+	boRegionUnavailable = backoffType{name: "regionUnavailable", base: 100 * time.Millisecond, cap: 2 * time.Second}
+	// the raft log of the region is over its quota until the store compacts it
+	boRaftLogQuota = backoffType{name: "raftLogQuota", base: 100 * time.Millisecond, cap: 2 * time.Second}
+	// the leader of the region drops the proposals until its uncommitted raft log is committed
+	boServerBusy = backoffType{name: "serverBusy", base: 50 * time.Millisecond, cap: 2 * time.Second}
+	// a key is locked by another transaction
+	boTxnLock = backoffType{name: "txnLock", base: 200 * time.Millisecond, cap: 3 * time.Second}
+)
+
+// Backoffer sleeps between the retries of a request. Each kind of failure backs off on its own, and the request
+// fails once the total sleep exceeds maxSleep or the context is done.
+type Backoffer struct {
+	ctx      context.Context
+	maxSleep time.Duration
+	total    time.Duration
+	attempts map[string]int
+	errors   []error
+}
+
+// NewBackoffer creates a Backoffer for a request, which sleeps at most maxSleep in total.
+func NewBackoffer(ctx context.Context, maxSleep time.Duration) *Backoffer {
+	return &Backoffer{ctx: ctx, maxSleep: maxSleep, attempts: make(map[string]int)}
+}
+
+// Context returns the context of the request.
+func (b *Backoffer) Context() context.Context {
+	return b.ctx
+}
+
+// Backoff sleeps before retrying a request failed with err. It returns an error if the request should give up.
+func (b *Backoffer) Backoff(tp backoffType, err error) error {
+	b.errors = append(b.errors, err)
+	if b.total >= b.maxSleep {
+		return errors.Errorf("backoff exceeds %v, errors: %v", b.maxSleep, b.errors)
+	}
+	sleep := tp.base << uint(b.attempts[tp.name])
+	if sleep > tp.cap || sleep <= 0 {
+		sleep = tp.cap
+	}
+	b.attempts[tp.name]++
+	// equal jitter, so the clients retrying the same failure spread out
+	sleep = sleep/2 + time.Duration(rand.Int63n(int64(sleep/2)+1))
+	if b.total+sleep > b.maxSleep {
+		sleep = b.maxSleep - b.total
+	}
+	select {
+	case <-time.After(sleep):
+	case <-b.ctx.Done():
+		return errors.WithStack(b.ctx.Err())
+	}
+	b.total += sleep
+	return nil
+}
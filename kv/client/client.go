@@ -0,0 +1,163 @@
+// Package client is a Go client of TinyKV. It finds the scheduler leader, routes the requests to the leaders of
+// the regions through a cache of the regions, and retries them on region errors with backoff, so applications use
+// the raw and transactional APIs by keys instead of wiring the gRPC stubs of the stores.
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	pd "github.com/pingcap-incubator/tinykv/scheduler/client"
+	"github.com/pingcap/errors"
+)
+
+const (
+	// defaultMaxBackoff is the total time a request sleeps between its retries before it fails.
+	defaultMaxBackoff = 20 * time.Second
+	// defaultRPCTimeout is the timeout of a single request to a store.
+	defaultRPCTimeout = 10 * time.Second
+	// physicalShiftBits is the number of bits of the logical part of a timestamp.
+	physicalShiftBits = 18
+)
+
+// Client is a client of a TinyKV cluster, it's safe for concurrent use.
+type Client struct {
+	scheduler   pd.Client
+	regionCache *RegionCache
+	sender      *regionRequestSender
+	// MaxBackoff is the total time a request sleeps between its retries before it fails.
+	MaxBackoff time.Duration
+}
+
+// NewClient creates a client of the cluster whose scheduler is at the addresses.
+func NewClient(schedulerAddrs []string) (*Client, error) {
+	return NewClientWithSecurity(schedulerAddrs, pd.SecurityOption{})
+}
+
+// NewClientWithSecurity creates a client of the cluster whose scheduler is at the addresses, connecting to the
+// scheduler with the security options, e.g. the token of the client when the scheduler has auth roles. The client
+// needs the operator role for the timestamps of the transactions.
+func NewClientWithSecurity(schedulerAddrs []string, security pd.SecurityOption) (*Client, error) {
+	scheduler, err := pd.NewClient(schedulerAddrs, security)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return NewClientWithScheduler(scheduler), nil
+}
+
+// NewClientWithScheduler creates a client with a scheduler client, which is closed with the client.
+func NewClientWithScheduler(scheduler pd.Client) *Client {
+	regionCache := NewRegionCache(scheduler)
+	return &Client{
+		scheduler:   scheduler,
+		regionCache: regionCache,
+		sender: &regionRequestSender{
+			regionCache: regionCache,
+			conns:       newConnPool(),
+			timeout:     defaultRPCTimeout,
+		},
+		MaxBackoff: defaultMaxBackoff,
+	}
+}
+
+func (c *Client) Close() {
+	c.sender.conns.close()
+	c.scheduler.Close()
+}
+
+// RegionCache returns the cache of the regions of the client.
+func (c *Client) RegionCache() *RegionCache {
+	return c.regionCache
+}
+
+func (c *Client) newBackoffer(ctx context.Context) *Backoffer {
+	return NewBackoffer(ctx, c.MaxBackoff)
+}
+
+// GetTS returns a new timestamp from the scheduler, for the start or commit version of a transaction.
+func (c *Client) GetTS(ctx context.Context) (uint64, error) {
+	physical, logical, err := c.scheduler.GetTS(ctx)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	return uint64(physical)<<physicalShiftBits + uint64(logical), nil
+}
+
+// sendKeyReq sends a request on the key to the region containing it, locating the key again when the region
+// changed.
+func (c *Client) sendKeyReq(bo *Backoffer, key []byte, do rpcFunc) error {
+	_, err := c.sendKeyReqInRegion(bo, key, do)
+	return err
+}
+
+// sendKeyReqInRegion is sendKeyReq returning the region the request succeeded in.
+func (c *Client) sendKeyReqInRegion(bo *Backoffer, key []byte, do rpcFunc) (*Region, error) {
+	for {
+		region, err := c.regionCache.LocateKey(bo.ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		regionErr, err := c.sender.sendReq(bo, region, do)
+		if err != nil {
+			return nil, err
+		}
+		if regionErr == nil {
+			return region, nil
+		}
+	}
+}
+
+// sendBatchReq sends a request on the keys to each of their regions, the keys of a region are sent in one request
+// by do. It locates the keys of a region again when the region changed.
+func (c *Client) sendBatchReq(bo *Backoffer, keys [][]byte, do func(keys [][]byte) rpcFunc) error {
+	groups, _, err := c.regionCache.GroupKeysByRegion(bo.ctx, keys)
+	if err != nil {
+		return err
+	}
+	for regionID, keys := range groups {
+		region, err := c.regionCache.LocateRegionByID(bo.ctx, regionID)
+		if err != nil {
+			return err
+		}
+		regionErr, err := c.sender.sendReq(bo, region, do(keys))
+		if err != nil {
+			return err
+		}
+		if regionErr != nil {
+			if err := c.sendBatchReq(bo, keys, do); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// keyError is a KeyError returned by a store.
+type keyError struct {
+	*kvrpcpb.KeyError
+}
+
+func (e *keyError) Error() string {
+	return e.KeyError.String()
+}
+
+// LockedError is returned when a key is locked by another transaction.
+type LockedError struct {
+	Lock *kvrpcpb.LockInfo
+}
+
+func (e *LockedError) Error() string {
+	return "key is locked: " + e.Lock.String()
+}
+
+// extractKeyError converts a KeyError returned by a store to an error.
+func extractKeyError(keyErr *kvrpcpb.KeyError) error {
+	if keyErr == nil {
+		return nil
+	}
+	if keyErr.GetLocked() != nil {
+		return &LockedError{Lock: keyErr.GetLocked()}
+	}
+	return &keyError{KeyError: keyErr}
+}
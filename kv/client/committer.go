@@ -0,0 +1,222 @@
+package client
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pingcap-incubator/tinykv/log"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/errorpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/tinykvpb"
+	"github.com/pingcap/errors"
+)
+
+// twoPhaseCommitter commits the mutations of a transaction. The mutations are sorted by key, the first key is the
+// primary key.
+type twoPhaseCommitter struct {
+	client    *Client
+	startTS   uint64
+	commitTS  uint64
+	lockTTL   uint64
+	mutations map[string]*kvrpcpb.Mutation
+	keys      [][]byte
+	primary   []byte
+}
+
+func newTwoPhaseCommitter(client *Client, startTS, lockTTL uint64, mutations []*kvrpcpb.Mutation) *twoPhaseCommitter {
+	c := &twoPhaseCommitter{
+		client:    client,
+		startTS:   startTS,
+		lockTTL:   lockTTL,
+		mutations: make(map[string]*kvrpcpb.Mutation, len(mutations)),
+		keys:      make([][]byte, 0, len(mutations)),
+		primary:   mutations[0].Key,
+	}
+	for _, m := range mutations {
+		c.mutations[string(m.Key)] = m
+		c.keys = append(c.keys, m.Key)
+	}
+	return c
+}
+
+func (c *twoPhaseCommitter) execute(ctx context.Context) error {
+	if err := c.forEachRegion(ctx, c.keys, c.prewriteKeys); err != nil {
+		log.Debugf("prewrite of txn %d failed: %v", c.startTS, err)
+		c.rollback(ctx)
+		return err
+	}
+	commitTS, err := c.client.GetTS(ctx)
+	if err != nil {
+		c.rollback(ctx)
+		return err
+	}
+	c.commitTS = commitTS
+
+	// the transaction is committed once the primary key is
+	if err := c.commitKeys(c.client.newBackoffer(ctx), [][]byte{c.primary}); err != nil {
+		if _, ok := errors.Cause(err).(*keyError); ok {
+			// the primary lock is gone, e.g. it expired and was rolled back by another transaction
+			c.rollback(ctx)
+			return err
+		}
+		return errors.Wrap(ErrCommitUndetermined, err.Error())
+	}
+	if len(c.keys) > 1 {
+		// a secondary key failed to commit is committed by whoever reads it
+		if err := c.forEachRegion(ctx, c.keys[1:], c.commitKeys); err != nil {
+			log.Warnf("commit secondary keys of txn %d failed: %v", c.startTS, err)
+		}
+	}
+	return nil
+}
+
+// forEachRegion groups the keys by region and calls f for each region in parallel. It returns the first error.
+func (c *twoPhaseCommitter) forEachRegion(ctx context.Context, keys [][]byte, f func(bo *Backoffer, keys [][]byte) error) error {
+	groups, _, err := c.client.regionCache.GroupKeysByRegion(ctx, keys)
+	if err != nil {
+		return err
+	}
+	if len(groups) == 1 {
+		return f(c.client.newBackoffer(ctx), keys)
+	}
+	var wg sync.WaitGroup
+	errCh := make(chan error, len(groups))
+	for _, group := range groups {
+		wg.Add(1)
+		go func(keys [][]byte) {
+			defer wg.Done()
+			errCh <- f(c.client.newBackoffer(ctx), keys)
+		}(group)
+	}
+	wg.Wait()
+	close(errCh)
+	for err := range errCh {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// prewriteKeys prewrites the keys, resolving the locks of other transactions on them.
+func (c *twoPhaseCommitter) prewriteKeys(bo *Backoffer, keys [][]byte) error {
+	for {
+		var mu sync.Mutex
+		var locks []*kvrpcpb.LockInfo
+		var keyErr error
+		err := c.client.sendBatchReq(bo, keys, func(keys [][]byte) rpcFunc {
+			mutations := make([]*kvrpcpb.Mutation, 0, len(keys))
+			for _, key := range keys {
+				mutations = append(mutations, c.mutations[string(key)])
+			}
+			return func(ctx context.Context, client tinykvpb.TinyKvClient, reqCtx *kvrpcpb.Context) (*errorpb.Error, error) {
+				resp, err := client.KvPrewrite(ctx, &kvrpcpb.PrewriteRequest{
+					Context:      reqCtx,
+					Mutations:    mutations,
+					PrimaryLock:  c.primary,
+					StartVersion: c.startTS,
+					LockTtl:      c.lockTTL,
+				})
+				if err != nil {
+					return nil, err
+				}
+				if resp.RegionError != nil {
+					return resp.RegionError, nil
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				for _, e := range resp.Errors {
+					err := extractKeyError(e)
+					if locked, ok := err.(*LockedError); ok {
+						locks = append(locks, locked.Lock)
+					} else if keyErr == nil {
+						keyErr = err
+					}
+				}
+				return nil, nil
+			}
+		})
+		if err != nil {
+			return err
+		}
+		if keyErr != nil {
+			return keyErr
+		}
+		if len(locks) == 0 {
+			return nil
+		}
+		// prewrite again after the locks are resolved, the keys already locked by this transaction are locked again
+		if err := c.client.resolveLocks(bo, locks); err != nil {
+			return err
+		}
+	}
+}
+
+// commitKeys commits the keys at the commit ts.
+func (c *twoPhaseCommitter) commitKeys(bo *Backoffer, keys [][]byte) error {
+	var mu sync.Mutex
+	var keyErr error
+	err := c.client.sendBatchReq(bo, keys, func(keys [][]byte) rpcFunc {
+		return func(ctx context.Context, client tinykvpb.TinyKvClient, reqCtx *kvrpcpb.Context) (*errorpb.Error, error) {
+			resp, err := client.KvCommit(ctx, &kvrpcpb.CommitRequest{
+				Context:       reqCtx,
+				StartVersion:  c.startTS,
+				Keys:          keys,
+				CommitVersion: c.commitTS,
+			})
+			if err != nil {
+				return nil, err
+			}
+			if resp.RegionError != nil {
+				return resp.RegionError, nil
+			}
+			if resp.Error != nil {
+				mu.Lock()
+				keyErr = extractKeyError(resp.Error)
+				mu.Unlock()
+			}
+			return nil, nil
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return keyErr
+}
+
+// rollback rolls back the keys of the transaction, the locks it fails to roll back expire and are rolled back by
+// other transactions.
+func (c *twoPhaseCommitter) rollback(ctx context.Context) {
+	err := c.forEachRegion(ctx, c.keys, func(bo *Backoffer, keys [][]byte) error {
+		var mu sync.Mutex
+		var keyErr error
+		err := c.client.sendBatchReq(bo, keys, func(keys [][]byte) rpcFunc {
+			return func(ctx context.Context, client tinykvpb.TinyKvClient, reqCtx *kvrpcpb.Context) (*errorpb.Error, error) {
+				resp, err := client.KvBatchRollback(ctx, &kvrpcpb.BatchRollbackRequest{
+					Context:      reqCtx,
+					StartVersion: c.startTS,
+					Keys:         keys,
+				})
+				if err != nil {
+					return nil, err
+				}
+				if resp.RegionError != nil {
+					return resp.RegionError, nil
+				}
+				if resp.Error != nil {
+					mu.Lock()
+					keyErr = extractKeyError(resp.Error)
+					mu.Unlock()
+				}
+				return nil, nil
+			}
+		})
+		if err != nil {
+			return err
+		}
+		return keyErr
+	})
+	if err != nil {
+		log.Warnf("rollback of txn %d failed: %v", c.startTS, err)
+	}
+}
@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/errorpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/tinykvpb"
+	"github.com/pingcap/errors"
+)
+
+// resolveLockPollInterval is the interval to poll the background task resolving the locks of a large transaction.
+const resolveLockPollInterval = 100 * time.Millisecond
+
+// resolveLock checks the transaction of the lock at its primary key, and commits or rolls back the locks of the
+// transaction in the region of the lock once the transaction is committed or rolled back. A transaction whose
+// primary lock has expired is rolled back by the check. It returns false if the transaction is still alive, the
+// caller should back off and retry.
+func (c *Client) resolveLock(bo *Backoffer, lock *kvrpcpb.LockInfo) (bool, error) {
+	currentTS, err := c.GetTS(bo.ctx)
+	if err != nil {
+		return false, err
+	}
+	var status *kvrpcpb.CheckTxnStatusResponse
+	err = c.sendKeyReq(bo, lock.PrimaryLock, func(ctx context.Context, client tinykvpb.TinyKvClient, reqCtx *kvrpcpb.Context) (*errorpb.Error, error) {
+		resp, err := client.KvCheckTxnStatus(ctx, &kvrpcpb.CheckTxnStatusRequest{
+			Context:    reqCtx,
+			PrimaryKey: lock.PrimaryLock,
+			LockTs:     lock.LockVersion,
+			CurrentTs:  currentTS,
+		})
+		if err != nil {
+			return nil, err
+		}
+		status = resp
+		return resp.RegionError, nil
+	})
+	if err != nil {
+		return false, err
+	}
+	if status.LockTtl > 0 {
+		return false, nil
+	}
+
+	var keyErr *kvrpcpb.KeyError
+	var async bool
+	err = c.sendKeyReq(bo, lock.Key, func(ctx context.Context, client tinykvpb.TinyKvClient, reqCtx *kvrpcpb.Context) (*errorpb.Error, error) {
+		resp, err := client.KvResolveLock(ctx, &kvrpcpb.ResolveLockRequest{
+			Context:       reqCtx,
+			StartVersion:  lock.LockVersion,
+			CommitVersion: status.CommitVersion,
+		})
+		if err != nil {
+			return nil, err
+		}
+		keyErr, async = resp.Error, resp.Async
+		return resp.RegionError, nil
+	})
+	if err != nil {
+		return false, err
+	}
+	if err := extractKeyError(keyErr); err != nil {
+		return false, err
+	}
+	if async {
+		return c.waitResolveLock(bo, lock)
+	}
+	return true, nil
+}
+
+// waitResolveLock waits for the background task resolving the locks of the transaction of a large transaction in
+// the region of the lock.
+func (c *Client) waitResolveLock(bo *Backoffer, lock *kvrpcpb.LockInfo) (bool, error) {
+	for {
+		var status *kvrpcpb.ResolveLockStatusResponse
+		err := c.sendKeyReq(bo, lock.Key, func(ctx context.Context, client tinykvpb.TinyKvClient, reqCtx *kvrpcpb.Context) (*errorpb.Error, error) {
+			resp, err := client.KvResolveLockStatus(ctx, &kvrpcpb.ResolveLockStatusRequest{
+				Context:      reqCtx,
+				StartVersion: lock.LockVersion,
+			})
+			if err != nil {
+				return nil, err
+			}
+			status = resp
+			return resp.RegionError, nil
+		})
+		if err != nil {
+			return false, err
+		}
+		switch status.State {
+		case kvrpcpb.ResolveLockState_Finished:
+			return true, nil
+		case kvrpcpb.ResolveLockState_Failed:
+			return false, extractKeyError(status.Error)
+		case kvrpcpb.ResolveLockState_NoTask:
+			// the task was lost, e.g. the leader changed, the lock is checked again on the retry
+			return false, nil
+		}
+		select {
+		case <-time.After(resolveLockPollInterval):
+		case <-bo.ctx.Done():
+			return false, errors.WithStack(bo.ctx.Err())
+		}
+	}
+}
+
+// resolveLocks resolves the locks blocking a request, it backs off if any of their transactions is still alive.
+func (c *Client) resolveLocks(bo *Backoffer, locks []*kvrpcpb.LockInfo) error {
+	for _, lock := range locks {
+		resolved, err := c.resolveLock(bo, lock)
+		if err != nil {
+			return err
+		}
+		if !resolved {
+			return bo.Backoff(boTxnLock, &LockedError{Lock: lock})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package client
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/errorpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/tinykvpb"
+	"github.com/pingcap/errors"
+)
+
+// RawGet returns the value of the key in the column family, nil if it doesn't exist.
+func (c *Client) RawGet(ctx context.Context, cf string, key []byte) ([]byte, error) {
+	var value []byte
+	err := c.sendKeyReq(c.newBackoffer(ctx), key, func(ctx context.Context, client tinykvpb.TinyKvClient, reqCtx *kvrpcpb.Context) (*errorpb.Error, error) {
+		resp, err := client.RawGet(ctx, &kvrpcpb.RawGetRequest{Context: reqCtx, Key: key, Cf: cf})
+		if err != nil {
+			return nil, err
+		}
+		if resp.RegionError == nil && resp.Error != "" {
+			return nil, errors.New(resp.Error)
+		}
+		if !resp.NotFound {
+			value = resp.Value
+		}
+		return resp.RegionError, nil
+	})
+	return value, err
+}
+
+// RawPut sets the value of the key in the column family.
+func (c *Client) RawPut(ctx context.Context, cf string, key, value []byte) error {
+	return c.sendKeyReq(c.newBackoffer(ctx), key, func(ctx context.Context, client tinykvpb.TinyKvClient, reqCtx *kvrpcpb.Context) (*errorpb.Error, error) {
+		resp, err := client.RawPut(ctx, &kvrpcpb.RawPutRequest{Context: reqCtx, Key: key, Value: value, Cf: cf})
+		if err != nil {
+			return nil, err
+		}
+		if resp.RegionError == nil && resp.Error != "" {
+			return nil, errors.New(resp.Error)
+		}
+		return resp.RegionError, nil
+	})
+}
+
+// RawDelete deletes the key in the column family.
+func (c *Client) RawDelete(ctx context.Context, cf string, key []byte) error {
+	return c.sendKeyReq(c.newBackoffer(ctx), key, func(ctx context.Context, client tinykvpb.TinyKvClient, reqCtx *kvrpcpb.Context) (*errorpb.Error, error) {
+		resp, err := client.RawDelete(ctx, &kvrpcpb.RawDeleteRequest{Context: reqCtx, Key: key, Cf: cf})
+		if err != nil {
+			return nil, err
+		}
+		if resp.RegionError == nil && resp.Error != "" {
+			return nil, errors.New(resp.Error)
+		}
+		return resp.RegionError, nil
+	})
+}
+
+// RawScan returns at most limit pairs of the column family in [startKey, endKey), in key order. An empty endKey
+// scans to the end of the keys. The scan goes across the regions of the range.
+func (c *Client) RawScan(ctx context.Context, cf string, startKey, endKey []byte, limit int) ([]*kvrpcpb.KvPair, error) {
+	bo := c.newBackoffer(ctx)
+	var pairs []*kvrpcpb.KvPair
+	for len(pairs) < limit {
+		var batch []*kvrpcpb.KvPair
+		region, err := c.sendKeyReqInRegion(bo, startKey, func(ctx context.Context, client tinykvpb.TinyKvClient, reqCtx *kvrpcpb.Context) (*errorpb.Error, error) {
+			resp, err := client.RawScan(ctx, &kvrpcpb.RawScanRequest{
+				Context:  reqCtx,
+				StartKey: startKey,
+				Limit:    uint32(limit - len(pairs)),
+				Cf:       cf,
+			})
+			if err != nil {
+				return nil, err
+			}
+			if resp.RegionError == nil && resp.Error != "" {
+				return nil, errors.New(resp.Error)
+			}
+			batch = resp.Kvs
+			return resp.RegionError, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, pair := range batch {
+			if len(endKey) > 0 && bytes.Compare(pair.Key, endKey) >= 0 {
+				return pairs, nil
+			}
+			pairs = append(pairs, pair)
+		}
+		// the reads are bounded by the region, so the region is exhausted if the limit isn't reached, go on in the
+		// next region
+		startKey = region.Meta().GetEndKey()
+		if len(startKey) == 0 || (len(endKey) > 0 && bytes.Compare(startKey, endKey) >= 0) {
+			break
+		}
+	}
+	return pairs, nil
+}
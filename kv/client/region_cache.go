@@ -0,0 +1,302 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"sync"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	pd "github.com/pingcap-incubator/tinykv/scheduler/client"
+	"github.com/pingcap-incubator/tinykv/scheduler/pkg/btree"
+	"github.com/pingcap/errors"
+)
+
+const btreeDegree = 32
+
+// Region is a cached region and the peer believed to be its leader. It's immutable, a change makes a new one.
+type Region struct {
+	meta *metapb.Region
+	// the peer requests are sent to, it's the first peer if the leader is unknown
+	leader *metapb.Peer
+}
+
+func newRegion(meta *metapb.Region, leader *metapb.Peer) *Region {
+	if leader == nil || leader.GetId() == 0 {
+		leader = nil
+		if len(meta.GetPeers()) > 0 {
+			leader = meta.GetPeers()[0]
+		}
+	}
+	return &Region{meta: meta, leader: leader}
+}
+
+func (r *Region) ID() uint64 {
+	return r.meta.GetId()
+}
+
+func (r *Region) Meta() *metapb.Region {
+	return r.meta
+}
+
+func (r *Region) Leader() *metapb.Peer {
+	return r.leader
+}
+
+// Contains returns true if the key is in the range of the region.
+func (r *Region) Contains(key []byte) bool {
+	return bytes.Compare(key, r.meta.GetStartKey()) >= 0 &&
+		(len(r.meta.GetEndKey()) == 0 || bytes.Compare(key, r.meta.GetEndKey()) < 0)
+}
+
+// withPeer returns the region sending its requests to the peer, nil if the peer isn't in the region.
+func (r *Region) withPeer(peer *metapb.Peer) *Region {
+	for _, p := range r.meta.GetPeers() {
+		if p.GetId() == peer.GetId() && p.GetStoreId() == peer.GetStoreId() {
+			return &Region{meta: r.meta, leader: p}
+		}
+	}
+	return nil
+}
+
+// nextPeer returns the region sending its requests to the peer after the current one, to try another store when
+// the current one can't be reached.
+func (r *Region) nextPeer() *Region {
+	peers := r.meta.GetPeers()
+	for i, p := range peers {
+		if p.GetId() == r.leader.GetId() {
+			return &Region{meta: r.meta, leader: peers[(i+1)%len(peers)]}
+		}
+	}
+	return r
+}
+
+type regionItem struct {
+	region *Region
+}
+
+// Less returns true if the region start key is less than the other.
+func (r *regionItem) Less(other btree.Item) bool {
+	return bytes.Compare(r.region.meta.GetStartKey(), other.(*regionItem).region.meta.GetStartKey()) < 0
+}
+
+// RegionCache caches the routes of the regions and the addresses of the stores, which are loaded from the
+// scheduler on a miss. The requests update it with the region errors they get, so the cache follows splits, merges
+// and leader changes without asking the scheduler on every request.
+type RegionCache struct {
+	scheduler pd.Client
+
+	mu struct {
+		sync.RWMutex
+		regions map[uint64]*Region
+		// the regions ordered by start key, for locating keys
+		sorted *btree.BTree
+	}
+	storeMu struct {
+		sync.RWMutex
+		addrs map[uint64]string
+	}
+}
+
+func NewRegionCache(scheduler pd.Client) *RegionCache {
+	c := &RegionCache{scheduler: scheduler}
+	c.mu.regions = make(map[uint64]*Region)
+	c.mu.sorted = btree.New(btreeDegree)
+	c.storeMu.addrs = make(map[uint64]string)
+	return c
+}
+
+// LocateKey returns the region containing the key.
+func (c *RegionCache) LocateKey(ctx context.Context, key []byte) (*Region, error) {
+	if region := c.searchCachedRegion(key); region != nil {
+		return region, nil
+	}
+	meta, leader, err := c.scheduler.GetRegion(ctx, key)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if meta == nil {
+		return nil, errors.Errorf("region of key %q isn't found", key)
+	}
+	region := newRegion(meta, leader)
+	c.insertRegion(region)
+	return region, nil
+}
+
+// LocateRegionByID returns the region with the id.
+func (c *RegionCache) LocateRegionByID(ctx context.Context, regionID uint64) (*Region, error) {
+	c.mu.RLock()
+	region := c.mu.regions[regionID]
+	c.mu.RUnlock()
+	if region != nil {
+		return region, nil
+	}
+	meta, leader, err := c.scheduler.GetRegionByID(ctx, regionID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if meta == nil {
+		return nil, errors.Errorf("region %d isn't found", regionID)
+	}
+	region = newRegion(meta, leader)
+	c.insertRegion(region)
+	return region, nil
+}
+
+// GroupKeysByRegion groups the keys by their regions, keeping the order of the keys in each group. It also
+// returns the region of the first key.
+func (c *RegionCache) GroupKeysByRegion(ctx context.Context, keys [][]byte) (map[uint64][][]byte, uint64, error) {
+	groups := make(map[uint64][][]byte)
+	var first uint64
+	var region *Region
+	for i, key := range keys {
+		if region == nil || !region.Contains(key) {
+			var err error
+			region, err = c.LocateKey(ctx, key)
+			if err != nil {
+				return nil, 0, err
+			}
+		}
+		if i == 0 {
+			first = region.ID()
+		}
+		groups[region.ID()] = append(groups[region.ID()], key)
+	}
+	return groups, first, nil
+}
+
+func (c *RegionCache) searchCachedRegion(key []byte) *Region {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var region *Region
+	pivot := &regionItem{region: &Region{meta: &metapb.Region{StartKey: key}}}
+	c.mu.sorted.DescendLessOrEqual(pivot, func(item btree.Item) bool {
+		region = item.(*regionItem).region
+		return false
+	})
+	if region != nil && region.Contains(key) {
+		return region
+	}
+	return nil
+}
+
+// insertRegion caches the region, dropping the cached regions overlapping with it.
+func (c *RegionCache) insertRegion(region *Region) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.removeOverlapsLocked(region.meta)
+	c.mu.regions[region.ID()] = region
+	c.mu.sorted.ReplaceOrInsert(&regionItem{region: region})
+}
+
+func (c *RegionCache) removeOverlapsLocked(meta *metapb.Region) {
+	var overlaps []*Region
+	pivot := &regionItem{region: &Region{meta: meta}}
+	c.mu.sorted.DescendLessOrEqual(pivot, func(item btree.Item) bool {
+		region := item.(*regionItem).region
+		end := region.meta.GetEndKey()
+		if len(end) == 0 || bytes.Compare(end, meta.GetStartKey()) > 0 {
+			overlaps = append(overlaps, region)
+		}
+		return false
+	})
+	c.mu.sorted.AscendGreaterOrEqual(pivot, func(item btree.Item) bool {
+		region := item.(*regionItem).region
+		if len(meta.GetEndKey()) > 0 && bytes.Compare(region.meta.GetStartKey(), meta.GetEndKey()) >= 0 {
+			return false
+		}
+		overlaps = append(overlaps, region)
+		return true
+	})
+	if old, ok := c.mu.regions[meta.GetId()]; ok {
+		overlaps = append(overlaps, old)
+	}
+	for _, region := range overlaps {
+		c.removeLocked(region)
+	}
+}
+
+func (c *RegionCache) removeLocked(region *Region) {
+	if c.mu.regions[region.ID()] == region {
+		delete(c.mu.regions, region.ID())
+	}
+	if item := c.mu.sorted.Get(&regionItem{region: region}); item != nil && item.(*regionItem).region == region {
+		c.mu.sorted.Delete(item)
+	}
+}
+
+// InvalidateRegion drops the region from the cache, it's loaded again on the next use.
+func (c *RegionCache) InvalidateRegion(regionID uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if region, ok := c.mu.regions[regionID]; ok {
+		c.removeLocked(region)
+	}
+}
+
+// UpdateLeader sends the requests of the region to the leader. The region is dropped if the leader isn't one of
+// its peers, which means the cached region is stale.
+func (c *RegionCache) UpdateLeader(regionID uint64, leader *metapb.Peer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	region, ok := c.mu.regions[regionID]
+	if !ok {
+		return
+	}
+	updated := region.withPeer(leader)
+	if updated == nil {
+		c.removeLocked(region)
+		return
+	}
+	c.replaceLocked(region, updated)
+}
+
+// switchPeer sends the requests of the region to its next peer, after its current peer can't be reached.
+func (c *RegionCache) switchPeer(regionID uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if region, ok := c.mu.regions[regionID]; ok {
+		c.replaceLocked(region, region.nextPeer())
+	}
+}
+
+func (c *RegionCache) replaceLocked(old, region *Region) {
+	c.mu.regions[region.ID()] = region
+	c.mu.sorted.Delete(&regionItem{region: old})
+	c.mu.sorted.ReplaceOrInsert(&regionItem{region: region})
+}
+
+// OnEpochNotMatch replaces the stale region with the current regions of its range returned by the store.
+func (c *RegionCache) OnEpochNotMatch(regionID uint64, current []*metapb.Region) {
+	c.InvalidateRegion(regionID)
+	for _, meta := range current {
+		c.insertRegion(newRegion(meta, nil))
+	}
+}
+
+// GetStoreAddr returns the address of the store.
+func (c *RegionCache) GetStoreAddr(ctx context.Context, storeID uint64) (string, error) {
+	c.storeMu.RLock()
+	addr, ok := c.storeMu.addrs[storeID]
+	c.storeMu.RUnlock()
+	if ok {
+		return addr, nil
+	}
+	store, err := c.scheduler.GetStore(ctx, storeID)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	if store == nil || store.GetState() == metapb.StoreState_Tombstone {
+		return "", errors.Errorf("store %d isn't found", storeID)
+	}
+	c.storeMu.Lock()
+	c.storeMu.addrs[storeID] = store.GetAddress()
+	c.storeMu.Unlock()
+	return store.GetAddress(), nil
+}
+
+// InvalidateStore drops the address of the store, it's loaded again on the next use.
+func (c *RegionCache) InvalidateStore(storeID uint64) {
+	c.storeMu.Lock()
+	defer c.storeMu.Unlock()
+	delete(c.storeMu.addrs, storeID)
+}
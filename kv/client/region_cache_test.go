@@ -0,0 +1,161 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	pd "github.com/pingcap-incubator/tinykv/scheduler/client"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockScheduler serves the regions and stores from memory.
+type mockScheduler struct {
+	pd.Client
+	regions []*metapb.Region
+	stores  map[uint64]*metapb.Store
+	// the number of region lookups
+	lookups int
+}
+
+func (m *mockScheduler) GetRegion(ctx context.Context, key []byte) (*metapb.Region, *metapb.Peer, error) {
+	m.lookups++
+	for _, region := range m.regions {
+		if bytes.Compare(key, region.StartKey) >= 0 && (len(region.EndKey) == 0 || bytes.Compare(key, region.EndKey) < 0) {
+			return region, region.Peers[0], nil
+		}
+	}
+	return nil, nil, nil
+}
+
+func (m *mockScheduler) GetRegionByID(ctx context.Context, regionID uint64) (*metapb.Region, *metapb.Peer, error) {
+	m.lookups++
+	for _, region := range m.regions {
+		if region.Id == regionID {
+			return region, region.Peers[0], nil
+		}
+	}
+	return nil, nil, nil
+}
+
+func (m *mockScheduler) GetStore(ctx context.Context, storeID uint64) (*metapb.Store, error) {
+	return m.stores[storeID], nil
+}
+
+func newTestRegion(id uint64, start, end string, version uint64, storeIDs ...uint64) *metapb.Region {
+	region := &metapb.Region{
+		Id:          id,
+		StartKey:    []byte(start),
+		EndKey:      []byte(end),
+		RegionEpoch: &metapb.RegionEpoch{Version: version, ConfVer: 1},
+	}
+	for _, storeID := range storeIDs {
+		region.Peers = append(region.Peers, &metapb.Peer{Id: id*10 + storeID, StoreId: storeID})
+	}
+	return region
+}
+
+func TestRegionCacheLocate(t *testing.T) {
+	scheduler := &mockScheduler{regions: []*metapb.Region{
+		newTestRegion(1, "", "m", 1, 1, 2),
+		newTestRegion(2, "m", "", 1, 2, 3),
+	}}
+	cache := NewRegionCache(scheduler)
+	ctx := context.Background()
+
+	region, err := cache.LocateKey(ctx, []byte("a"))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), region.ID())
+	region, err = cache.LocateKey(ctx, []byte("m"))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), region.ID())
+	// cached
+	_, err = cache.LocateKey(ctx, []byte("z"))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, scheduler.lookups)
+
+	groups, first, err := cache.GroupKeysByRegion(ctx, [][]byte{[]byte("x"), []byte("b"), []byte("c"), []byte("y")})
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), first)
+	assert.Equal(t, [][]byte{[]byte("b"), []byte("c")}, groups[1])
+	assert.Equal(t, [][]byte{[]byte("x"), []byte("y")}, groups[2])
+
+	// region 1 is split at "f", the store returns the regions in its range
+	scheduler.regions = []*metapb.Region{
+		newTestRegion(1, "f", "m", 2, 1, 2),
+		newTestRegion(3, "", "f", 2, 1, 2),
+		newTestRegion(2, "m", "", 1, 2, 3),
+	}
+	cache.OnEpochNotMatch(1, scheduler.regions[:2])
+	region, err = cache.LocateKey(ctx, []byte("a"))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), region.ID())
+	region, err = cache.LocateKey(ctx, []byte("g"))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), region.ID())
+	assert.Equal(t, 2, scheduler.lookups)
+
+	// the regions are merged back, the new region replaces the cached ones overlapping with it
+	scheduler.regions = []*metapb.Region{newTestRegion(3, "", "m", 3, 1, 2), newTestRegion(2, "m", "", 1, 2, 3)}
+	cache.InvalidateRegion(3)
+	region, err = cache.LocateKey(ctx, []byte("a"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("m"), region.Meta().EndKey)
+	region, err = cache.LocateKey(ctx, []byte("g"))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), region.ID())
+	assert.Equal(t, 3, scheduler.lookups)
+}
+
+func TestRegionCacheLeader(t *testing.T) {
+	scheduler := &mockScheduler{
+		regions: []*metapb.Region{newTestRegion(1, "", "", 1, 1, 2, 3)},
+		stores:  map[uint64]*metapb.Store{2: {Id: 2, Address: "store2"}},
+	}
+	cache := NewRegionCache(scheduler)
+	ctx := context.Background()
+
+	region, err := cache.LocateKey(ctx, []byte("a"))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), region.Leader().StoreId)
+
+	cache.UpdateLeader(1, &metapb.Peer{Id: 12, StoreId: 2})
+	region, err = cache.LocateKey(ctx, []byte("a"))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), region.Leader().StoreId)
+	addr, err := cache.GetStoreAddr(ctx, region.Leader().StoreId)
+	assert.Nil(t, err)
+	assert.Equal(t, "store2", addr)
+
+	cache.switchPeer(1)
+	region, err = cache.LocateKey(ctx, []byte("a"))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), region.Leader().StoreId)
+	_, err = cache.GetStoreAddr(ctx, 3)
+	assert.NotNil(t, err)
+
+	// a leader out of the cached peers means the cached region is stale
+	cache.UpdateLeader(1, &metapb.Peer{Id: 14, StoreId: 4})
+	_, err = cache.LocateKey(ctx, []byte("a"))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, scheduler.lookups)
+}
+
+func TestBackoffer(t *testing.T) {
+	bo := NewBackoffer(context.Background(), 50*time.Millisecond)
+	start := time.Now()
+	var err error
+	for i := 0; i < 100 && err == nil; i++ {
+		err = bo.Backoff(boRegionMiss, assert.AnError)
+	}
+	assert.NotNil(t, err)
+	assert.True(t, time.Since(start) >= 50*time.Millisecond)
+	assert.True(t, time.Since(start) < time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	bo = NewBackoffer(ctx, time.Second)
+	assert.NotNil(t, bo.Backoff(boTxnLock, assert.AnError))
+}
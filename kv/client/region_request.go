@@ -0,0 +1,159 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/log"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/errorpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/tinykvpb"
+	"github.com/pingcap/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+// rpcFunc sends a request with the context to a store, it returns the region error of the response.
+type rpcFunc func(ctx context.Context, client tinykvpb.TinyKvClient, reqCtx *kvrpcpb.Context) (*errorpb.Error, error)
+
+// connPool keeps a connection to each store.
+type connPool struct {
+	sync.Mutex
+	conns map[string]*grpc.ClientConn
+}
+
+func newConnPool() *connPool {
+	return &connPool{conns: make(map[string]*grpc.ClientConn)}
+}
+
+func (p *connPool) get(addr string) (tinykvpb.TinyKvClient, error) {
+	p.Lock()
+	defer p.Unlock()
+	if cc, ok := p.conns[addr]; ok {
+		return tinykvpb.NewTinyKvClient(cc), nil
+	}
+	cc, err := grpc.Dial(addr, grpc.WithInsecure(),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:    3 * time.Second,
+			Timeout: 60 * time.Second,
+		}))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	p.conns[addr] = cc
+	return tinykvpb.NewTinyKvClient(cc), nil
+}
+
+func (p *connPool) close() {
+	p.Lock()
+	defer p.Unlock()
+	for addr, cc := range p.conns {
+		cc.Close()
+		delete(p.conns, addr)
+	}
+}
+
+// regionRequestSender sends requests to the leaders of regions.
+type regionRequestSender struct {
+	regionCache *RegionCache
+	conns       *connPool
+	timeout     time.Duration
+}
+
+// sendReq sends the request to the leader of the region. It retries the request on another peer if the leader
+// changed or can't be reached. If the range of the region changed it returns the region error, the cache has been
+// updated and the caller should locate the keys of the request again.
+func (s *regionRequestSender) sendReq(bo *Backoffer, region *Region, do rpcFunc) (*errorpb.Error, error) {
+	for {
+		client, err := s.getClient(bo.ctx, region)
+		if err != nil {
+			return nil, err
+		}
+		reqCtx := &kvrpcpb.Context{
+			RegionId:    region.ID(),
+			RegionEpoch: region.meta.GetRegionEpoch(),
+			Peer:        region.leader,
+		}
+		ctx, cancel := context.WithTimeout(bo.ctx, s.timeout)
+		regionErr, err := do(ctx, client, reqCtx)
+		cancel()
+		if err != nil {
+			if bo.ctx.Err() != nil {
+				return nil, errors.WithStack(bo.ctx.Err())
+			}
+			s.onSendFail(region)
+			if err := bo.Backoff(boStoreRPC, err); err != nil {
+				return nil, err
+			}
+		} else if regionErr == nil {
+			return nil, nil
+		} else if retry, err := s.onRegionError(bo, region, regionErr); err != nil || !retry {
+			return regionErr, err
+		}
+		if region, err = s.regionCache.LocateRegionByID(bo.ctx, region.ID()); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (s *regionRequestSender) getClient(ctx context.Context, region *Region) (tinykvpb.TinyKvClient, error) {
+	if region.leader == nil {
+		return nil, errors.Errorf("region %d has no peer", region.ID())
+	}
+	addr, err := s.regionCache.GetStoreAddr(ctx, region.leader.GetStoreId())
+	if err != nil {
+		return nil, err
+	}
+	return s.conns.get(addr)
+}
+
+// onSendFail switches the region to another peer, the store may be down or partitioned.
+func (s *regionRequestSender) onSendFail(region *Region) {
+	s.regionCache.InvalidateStore(region.leader.GetStoreId())
+	s.regionCache.switchPeer(region.ID())
+}
+
+// onRegionError updates the cache with the region error, and returns whether the request should be retried in the
+// same region.
+func (s *regionRequestSender) onRegionError(bo *Backoffer, region *Region, regionErr *errorpb.Error) (bool, error) {
+	err := errors.New(regionErr.String())
+	switch {
+	case regionErr.GetNotLeader() != nil:
+		leader := regionErr.GetNotLeader().GetLeader()
+		if leader != nil {
+			s.regionCache.UpdateLeader(region.ID(), leader)
+			// the leader is known, retry at once
+			return true, nil
+		}
+		// the region is electing a new leader
+		s.regionCache.switchPeer(region.ID())
+		return true, bo.Backoff(boNotLeader, err)
+	case regionErr.GetStaleCommand() != nil:
+		return true, bo.Backoff(boNotLeader, err)
+	case regionErr.GetStoreNotMatch() != nil:
+		s.regionCache.InvalidateStore(region.leader.GetStoreId())
+		s.regionCache.InvalidateRegion(region.ID())
+		return false, bo.Backoff(boRegionMiss, err)
+	case regionErr.GetEpochNotMatch() != nil:
+		s.regionCache.OnEpochNotMatch(region.ID(), regionErr.GetEpochNotMatch().GetCurrentRegions())
+		return false, nil
+	case regionErr.GetRegionUnavailable() != nil:
+		// the region is flapping, refresh its route and wait for it to settle
+		s.regionCache.InvalidateRegion(region.ID())
+		return false, bo.Backoff(boRegionUnavailable, err)
+	case regionErr.GetRaftEntryTooLarge() != nil:
+		return false, err
+	case regionErr.GetRaftLogQuotaExceeded() != nil:
+		// the leader is right, wait for it to compact the log
+		return true, bo.Backoff(boRaftLogQuota, err)
+	case regionErr.GetServerIsBusy() != nil:
+		// the leader is right, wait for its raft log to be committed
+		return true, bo.Backoff(boServerBusy, err)
+	default:
+		// RegionNotFound, KeyNotInRegion and the others, the cached region is stale
+		log.Debugf("region %d request failed with %v", region.ID(), regionErr)
+		s.regionCache.InvalidateRegion(region.ID())
+		return false, bo.Backoff(boRegionMiss, err)
+	}
+}
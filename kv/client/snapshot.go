@@ -0,0 +1,125 @@
+package client
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/errorpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/tinykvpb"
+)
+
+// Snapshot reads the transactional keys at a version. A read of a key locked by a transaction which may commit
+// before the version resolves the lock first, waiting for the transaction if it's still alive.
+type Snapshot struct {
+	client  *Client
+	version uint64
+}
+
+// GetSnapshot returns a snapshot of the transactional keys at the version.
+func (c *Client) GetSnapshot(version uint64) *Snapshot {
+	return &Snapshot{client: c, version: version}
+}
+
+func (s *Snapshot) Version() uint64 {
+	return s.version
+}
+
+// Get returns the value of the key at the version of the snapshot, nil if it doesn't exist.
+func (s *Snapshot) Get(ctx context.Context, key []byte) ([]byte, error) {
+	return s.read(ctx, key, false)
+}
+
+// getStaged is Get seeing the write of the key staged by the transaction started at the version of the snapshot.
+func (s *Snapshot) getStaged(ctx context.Context, key []byte) ([]byte, error) {
+	return s.read(ctx, key, true)
+}
+
+func (s *Snapshot) read(ctx context.Context, key []byte, readStaged bool) ([]byte, error) {
+	bo := s.client.newBackoffer(ctx)
+	for {
+		value, err := s.get(bo, key, readStaged)
+		if locked, ok := err.(*LockedError); ok {
+			if err := s.client.resolveLocks(bo, []*kvrpcpb.LockInfo{locked.Lock}); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		return value, err
+	}
+}
+
+func (s *Snapshot) get(bo *Backoffer, key []byte, readStaged bool) ([]byte, error) {
+	var value []byte
+	var keyErr *kvrpcpb.KeyError
+	err := s.client.sendKeyReq(bo, key, func(ctx context.Context, client tinykvpb.TinyKvClient, reqCtx *kvrpcpb.Context) (*errorpb.Error, error) {
+		resp, err := client.KvGet(ctx, &kvrpcpb.GetRequest{Context: reqCtx, Key: key, Version: s.version, ReadStaged: readStaged})
+		if err != nil {
+			return nil, err
+		}
+		keyErr = resp.Error
+		if !resp.NotFound {
+			value = resp.Value
+		}
+		return resp.RegionError, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := extractKeyError(keyErr); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// Scan returns at most limit pairs in [startKey, endKey) at the version of the snapshot, in key order. An empty
+// endKey scans to the end of the keys. The scan goes across the regions of the range.
+func (s *Snapshot) Scan(ctx context.Context, startKey, endKey []byte, limit int) ([]*kvrpcpb.KvPair, error) {
+	bo := s.client.newBackoffer(ctx)
+	var pairs []*kvrpcpb.KvPair
+scan:
+	for len(pairs) < limit {
+		var batch []*kvrpcpb.KvPair
+		region, err := s.client.sendKeyReqInRegion(bo, startKey, func(ctx context.Context, client tinykvpb.TinyKvClient, reqCtx *kvrpcpb.Context) (*errorpb.Error, error) {
+			resp, err := client.KvScan(ctx, &kvrpcpb.ScanRequest{
+				Context:  reqCtx,
+				StartKey: startKey,
+				Limit:    uint32(limit - len(pairs)),
+				Version:  s.version,
+			})
+			if err != nil {
+				return nil, err
+			}
+			batch = resp.Pairs
+			return resp.RegionError, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, pair := range batch {
+			if len(endKey) > 0 && bytes.Compare(pair.Key, endKey) >= 0 {
+				return pairs, nil
+			}
+			if err := extractKeyError(pair.Error); err != nil {
+				locked, ok := err.(*LockedError)
+				if !ok {
+					return nil, err
+				}
+				if err := s.client.resolveLocks(bo, []*kvrpcpb.LockInfo{locked.Lock}); err != nil {
+					return nil, err
+				}
+				// scan again from the locked key
+				startKey = locked.Lock.Key
+				continue scan
+			}
+			pairs = append(pairs, pair)
+		}
+		// the reads are bounded by the region, so the region is exhausted if the limit isn't reached, go on in the
+		// next region
+		startKey = region.Meta().GetEndKey()
+		if len(startKey) == 0 || (len(endKey) > 0 && bytes.Compare(startKey, endKey) >= 0) {
+			break
+		}
+	}
+	return pairs, nil
+}
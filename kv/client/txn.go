@@ -0,0 +1,203 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/errorpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/tinykvpb"
+	"github.com/pingcap/errors"
+)
+
+// defaultLockTTL is the TTL (ms) of the locks of a transaction, after which other transactions may roll it back.
+const defaultLockTTL = 3000
+
+var (
+	// ErrTxnDone is returned when a committed or rolled back transaction is used.
+	ErrTxnDone = errors.New("transaction is already committed or rolled back")
+	// ErrCommitUndetermined is returned when the commit of the primary key may or may not have succeeded, e.g. the
+	// connection broke after sending it. The application must check the data to know the result.
+	ErrCommitUndetermined = errors.New("result of the transaction commit is undetermined")
+)
+
+// Txn is an optimistic transaction. Its writes are buffered in memory until Commit, which writes them with two phase
+// commit: all the keys are prewritten, locking them with the first key as the primary lock, then the primary key is
+// committed, which commits the transaction, then the secondary keys are committed. A large transaction may Flush its
+// buffered writes to the stores on the way, only their keys are kept in memory then. A Txn isn't safe for concurrent
+// use.
+type Txn struct {
+	client   *Client
+	snapshot *Snapshot
+	// the buffered writes, a nil value deletes the key
+	writes map[string][]byte
+	// the keys whose writes are staged on the stores by Flush, a buffered write of a key overrides its staged one
+	staged map[string]struct{}
+	done   bool
+	// LockTTL is the TTL (ms) of the locks of the transaction.
+	LockTTL uint64
+}
+
+// Begin starts a transaction, reading at a new timestamp from the scheduler.
+func (c *Client) Begin(ctx context.Context) (*Txn, error) {
+	startTS, err := c.GetTS(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Txn{
+		client:   c,
+		snapshot: c.GetSnapshot(startTS),
+		writes:   make(map[string][]byte),
+		staged:   make(map[string]struct{}),
+		LockTTL:  defaultLockTTL,
+	}, nil
+}
+
+func (txn *Txn) StartTS() uint64 {
+	return txn.snapshot.Version()
+}
+
+// Get returns the value of the key, seeing the writes of the transaction. It returns nil if the key doesn't exist.
+func (txn *Txn) Get(ctx context.Context, key []byte) ([]byte, error) {
+	if txn.done {
+		return nil, ErrTxnDone
+	}
+	if value, ok := txn.writes[string(key)]; ok {
+		return value, nil
+	}
+	if _, ok := txn.staged[string(key)]; ok {
+		return txn.snapshot.getStaged(ctx, key)
+	}
+	return txn.snapshot.Get(ctx, key)
+}
+
+// Set buffers setting the value of the key.
+func (txn *Txn) Set(key, value []byte) error {
+	if txn.done {
+		return ErrTxnDone
+	}
+	if value == nil {
+		value = []byte{}
+	}
+	txn.writes[string(key)] = value
+	return nil
+}
+
+// Delete buffers deleting the key.
+func (txn *Txn) Delete(key []byte) error {
+	if txn.done {
+		return ErrTxnDone
+	}
+	txn.writes[string(key)] = nil
+	return nil
+}
+
+// Rollback discards the transaction. Nothing is written to the stores before Commit but the writes staged by Flush,
+// which the stores remove once they are stale.
+func (txn *Txn) Rollback() error {
+	if txn.done {
+		return ErrTxnDone
+	}
+	txn.done = true
+	return nil
+}
+
+// Commit commits the writes of the transaction. A write conflict with another transaction fails the commit, which
+// may be retried in a new transaction, see IsRetryable.
+func (txn *Txn) Commit(ctx context.Context) error {
+	if txn.done {
+		return ErrTxnDone
+	}
+	txn.done = true
+	mutations := txn.bufferedMutations()
+	for key := range txn.staged {
+		if _, ok := txn.writes[key]; !ok {
+			mutations = append(mutations, &kvrpcpb.Mutation{Op: kvrpcpb.Op_Staged, Key: []byte(key)})
+		}
+	}
+	if len(mutations) == 0 {
+		return nil
+	}
+	sort.Slice(mutations, func(i, j int) bool {
+		return bytes.Compare(mutations[i].Key, mutations[j].Key) < 0
+	})
+	return newTwoPhaseCommitter(txn.client, txn.StartTS(), txn.LockTTL, mutations).execute(ctx)
+}
+
+// Flush stages the buffered writes on the stores, keeping only their keys in memory. The staged writes are invisible
+// to other transactions until the transaction commits, which must be within the rollback retention of the stores,
+// after which they are removed.
+func (txn *Txn) Flush(ctx context.Context) error {
+	if txn.done {
+		return ErrTxnDone
+	}
+	mutations := txn.bufferedMutations()
+	if len(mutations) == 0 {
+		return nil
+	}
+	byKey := make(map[string]*kvrpcpb.Mutation, len(mutations))
+	keys := make([][]byte, 0, len(mutations))
+	for _, m := range mutations {
+		byKey[string(m.Key)] = m
+		keys = append(keys, m.Key)
+	}
+	var mu sync.Mutex
+	var keyErr error
+	err := txn.client.sendBatchReq(txn.client.newBackoffer(ctx), keys, func(keys [][]byte) rpcFunc {
+		mutations := make([]*kvrpcpb.Mutation, 0, len(keys))
+		for _, key := range keys {
+			mutations = append(mutations, byKey[string(key)])
+		}
+		return func(ctx context.Context, client tinykvpb.TinyKvClient, reqCtx *kvrpcpb.Context) (*errorpb.Error, error) {
+			resp, err := client.KvStage(ctx, &kvrpcpb.StageRequest{
+				Context:      reqCtx,
+				Mutations:    mutations,
+				StartVersion: txn.StartTS(),
+			})
+			if err != nil {
+				return nil, err
+			}
+			if resp.Error != nil {
+				mu.Lock()
+				keyErr = extractKeyError(resp.Error)
+				mu.Unlock()
+			}
+			return resp.RegionError, nil
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if keyErr != nil {
+		return keyErr
+	}
+	for key := range txn.writes {
+		txn.staged[key] = struct{}{}
+	}
+	txn.writes = make(map[string][]byte)
+	return nil
+}
+
+// bufferedMutations returns the mutations of the buffered writes.
+func (txn *Txn) bufferedMutations() []*kvrpcpb.Mutation {
+	mutations := make([]*kvrpcpb.Mutation, 0, len(txn.writes))
+	for key, value := range txn.writes {
+		mutation := &kvrpcpb.Mutation{Op: kvrpcpb.Op_Put, Key: []byte(key), Value: value}
+		if value == nil {
+			mutation.Op = kvrpcpb.Op_Del
+		}
+		mutations = append(mutations, mutation)
+	}
+	return mutations
+}
+
+// IsRetryable returns true if the transaction failed for a conflict with another transaction, it may succeed if it
+// is retried in a new transaction.
+func IsRetryable(err error) bool {
+	if keyErr, ok := errors.Cause(err).(*keyError); ok {
+		return keyErr.GetConflict() != nil || keyErr.GetRetryable() != ""
+	}
+	return false
+}
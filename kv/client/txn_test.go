@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/pingcap/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTxnBuffer(t *testing.T) {
+	txn := &Txn{snapshot: &Snapshot{version: 10}, writes: make(map[string][]byte), LockTTL: defaultLockTTL}
+	ctx := context.Background()
+	assert.Equal(t, uint64(10), txn.StartTS())
+
+	// the buffered writes are read without going to the stores
+	assert.Nil(t, txn.Set([]byte("a"), []byte("1")))
+	assert.Nil(t, txn.Set([]byte("b"), nil))
+	assert.Nil(t, txn.Delete([]byte("c")))
+	value, err := txn.Get(ctx, []byte("a"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("1"), value)
+	value, err = txn.Get(ctx, []byte("b"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{}, value)
+	value, err = txn.Get(ctx, []byte("c"))
+	assert.Nil(t, err)
+	assert.Nil(t, value)
+
+	assert.Nil(t, txn.Rollback())
+	assert.Equal(t, ErrTxnDone, txn.Set([]byte("a"), []byte("2")))
+	assert.Equal(t, ErrTxnDone, txn.Delete([]byte("a")))
+	assert.Equal(t, ErrTxnDone, txn.Commit(ctx))
+	_, err = txn.Get(ctx, []byte("a"))
+	assert.Equal(t, ErrTxnDone, err)
+
+	// committing nothing writes nothing
+	txn = &Txn{snapshot: &Snapshot{version: 10}, writes: make(map[string][]byte)}
+	assert.Nil(t, txn.Commit(ctx))
+	assert.Equal(t, ErrTxnDone, txn.Rollback())
+}
+
+func TestTwoPhaseCommitterPrimary(t *testing.T) {
+	mutations := []*kvrpcpb.Mutation{
+		{Op: kvrpcpb.Op_Put, Key: []byte("a"), Value: []byte("1")},
+		{Op: kvrpcpb.Op_Del, Key: []byte("b")},
+	}
+	c := newTwoPhaseCommitter(nil, 10, defaultLockTTL, mutations)
+	assert.Equal(t, []byte("a"), c.primary)
+	assert.Equal(t, [][]byte{[]byte("a"), []byte("b")}, c.keys)
+	assert.Equal(t, kvrpcpb.Op_Del, c.mutations["b"].Op)
+}
+
+func TestIsRetryable(t *testing.T) {
+	conflict := extractKeyError(&kvrpcpb.KeyError{Conflict: &kvrpcpb.WriteConflict{StartTs: 1, ConflictTs: 2}})
+	assert.True(t, IsRetryable(conflict))
+	assert.True(t, IsRetryable(errors.WithStack(conflict)))
+	assert.True(t, IsRetryable(extractKeyError(&kvrpcpb.KeyError{Retryable: "retry"})))
+	assert.False(t, IsRetryable(extractKeyError(&kvrpcpb.KeyError{Abort: "abort"})))
+	assert.False(t, IsRetryable(ErrCommitUndetermined))
+	assert.False(t, IsRetryable(nil))
+}
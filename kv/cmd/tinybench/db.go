@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+
+	"github.com/pingcap-incubator/tinykv/kv/client"
+	"github.com/pingcap-incubator/tinykv/kv/embed"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap/errors"
+)
+
+// db runs the operations of the workloads against an API of TinyKV.
+type db interface {
+	Read(ctx context.Context, key []byte) error
+	Update(ctx context.Context, key, value []byte) error
+	Insert(ctx context.Context, key, value []byte) error
+	Scan(ctx context.Context, startKey []byte, count int) error
+	ReadModifyWrite(ctx context.Context, key, value []byte) error
+	Close()
+}
+
+// rawDB uses the raw API in the default CF.
+type rawDB struct {
+	client *client.Client
+}
+
+func (db *rawDB) Read(ctx context.Context, key []byte) error {
+	_, err := db.client.RawGet(ctx, engine_util.CfDefault, key)
+	return err
+}
+
+func (db *rawDB) Update(ctx context.Context, key, value []byte) error {
+	return db.client.RawPut(ctx, engine_util.CfDefault, key, value)
+}
+
+func (db *rawDB) Insert(ctx context.Context, key, value []byte) error {
+	return db.client.RawPut(ctx, engine_util.CfDefault, key, value)
+}
+
+func (db *rawDB) Scan(ctx context.Context, startKey []byte, count int) error {
+	_, err := db.client.RawScan(ctx, engine_util.CfDefault, startKey, nil, count)
+	return err
+}
+
+// ReadModifyWrite reads then writes the key, the raw API has no isolation between them.
+func (db *rawDB) ReadModifyWrite(ctx context.Context, key, value []byte) error {
+	if _, err := db.client.RawGet(ctx, engine_util.CfDefault, key); err != nil {
+		return err
+	}
+	return db.client.RawPut(ctx, engine_util.CfDefault, key, value)
+}
+
+func (db *rawDB) Close() {
+	db.client.Close()
+}
+
+// txnDB runs each operation in its own transaction. A transaction failed by a conflict is counted as an error, it
+// isn't retried.
+type txnDB struct {
+	client *client.Client
+}
+
+func (db *txnDB) Read(ctx context.Context, key []byte) error {
+	ts, err := db.client.GetTS(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = db.client.GetSnapshot(ts).Get(ctx, key)
+	return err
+}
+
+func (db *txnDB) write(ctx context.Context, key, value []byte, read bool) error {
+	txn, err := db.client.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	if read {
+		if _, err := txn.Get(ctx, key); err != nil {
+			txn.Rollback()
+			return err
+		}
+	}
+	if err := txn.Set(key, value); err != nil {
+		return err
+	}
+	return txn.Commit(ctx)
+}
+
+func (db *txnDB) Update(ctx context.Context, key, value []byte) error {
+	return db.write(ctx, key, value, false)
+}
+
+func (db *txnDB) Insert(ctx context.Context, key, value []byte) error {
+	return db.write(ctx, key, value, false)
+}
+
+func (db *txnDB) Scan(ctx context.Context, startKey []byte, count int) error {
+	ts, err := db.client.GetTS(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = db.client.GetSnapshot(ts).Scan(ctx, startKey, nil, count)
+	return err
+}
+
+func (db *txnDB) ReadModifyWrite(ctx context.Context, key, value []byte) error {
+	return db.write(ctx, key, value, true)
+}
+
+func (db *txnDB) Close() {
+	db.client.Close()
+}
+
+// embedDB sends raft commands to an in-process embedded cluster, so the benchmark measures the raftstore without
+// the network and the gRPC server. It only supports the raw operations.
+type embedDB struct {
+	cluster *embed.Cluster
+}
+
+func newEmbedDB(stores int) (*embedDB, error) {
+	cluster, err := embed.NewBuilder(stores).Start()
+	if err != nil {
+		return nil, errors.Annotate(err, "start embedded cluster")
+	}
+	return &embedDB{cluster: cluster}, nil
+}
+
+func (db *embedDB) Read(ctx context.Context, key []byte) error {
+	_, err := db.cluster.Get(key)
+	return err
+}
+
+func (db *embedDB) Update(ctx context.Context, key, value []byte) error {
+	return db.cluster.Put(key, value)
+}
+
+func (db *embedDB) Insert(ctx context.Context, key, value []byte) error {
+	return db.cluster.Put(key, value)
+}
+
+func (db *embedDB) Scan(ctx context.Context, startKey []byte, count int) error {
+	_, err := db.cluster.Scan(startKey, count)
+	return err
+}
+
+func (db *embedDB) ReadModifyWrite(ctx context.Context, key, value []byte) error {
+	if _, err := db.cluster.Get(key); err != nil {
+		return err
+	}
+	return db.cluster.Put(key, value)
+}
+
+func (db *embedDB) Close() {
+	db.cluster.Shutdown()
+}
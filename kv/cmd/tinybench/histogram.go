@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// the bounds of the buckets grow by histogramGrowth from 1us, so a percentile is accurate to 5%
+	histogramGrowth  = 1.05
+	histogramBuckets = 400
+)
+
+// histogram records the latencies of an operation in buckets of exponentially growing bounds.
+type histogram struct {
+	mu      sync.Mutex
+	buckets [histogramBuckets]int64
+	count   int64
+	errors  int64
+	sum     time.Duration
+	min     time.Duration
+	max     time.Duration
+}
+
+func bucketOf(d time.Duration) int {
+	us := float64(d) / float64(time.Microsecond)
+	if us <= 1 {
+		return 0
+	}
+	idx := int(math.Ceil(math.Log(us) / math.Log(histogramGrowth)))
+	if idx >= histogramBuckets {
+		return histogramBuckets - 1
+	}
+	return idx
+}
+
+// bucketBound returns the upper bound of the bucket.
+func bucketBound(idx int) time.Duration {
+	return time.Duration(math.Pow(histogramGrowth, float64(idx)) * float64(time.Microsecond))
+}
+
+func (h *histogram) record(d time.Duration, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if err != nil {
+		h.errors++
+		return
+	}
+	h.buckets[bucketOf(d)]++
+	if h.count == 0 || d < h.min {
+		h.min = d
+	}
+	if d > h.max {
+		h.max = d
+	}
+	h.count++
+	h.sum += d
+}
+
+// snapshot returns a copy of the histogram which isn't changed by the following records.
+func (h *histogram) snapshot() *histogram {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s := &histogram{}
+	s.buckets = h.buckets
+	s.count, s.errors, s.sum, s.min, s.max = h.count, h.errors, h.sum, h.min, h.max
+	return s
+}
+
+// percentile returns the upper bound of the bucket the percentile falls in, capped by the max latency.
+func (h *histogram) percentile(p float64) time.Duration {
+	if h.count == 0 {
+		return 0
+	}
+	target := int64(math.Ceil(float64(h.count) * p / 100))
+	var seen int64
+	for idx, n := range h.buckets {
+		seen += n
+		if seen >= target {
+			if bound := bucketBound(idx); bound < h.max {
+				return bound
+			}
+			return h.max
+		}
+	}
+	return h.max
+}
+
+// summary is the result of an operation, it's also the JSON output of the benchmark.
+type summary struct {
+	Count  int64   `json:"count"`
+	Errors int64   `json:"errors"`
+	OPS    float64 `json:"ops"`
+	AvgUs  int64   `json:"avg_us"`
+	MinUs  int64   `json:"min_us"`
+	MaxUs  int64   `json:"max_us"`
+	P50Us  int64   `json:"p50_us"`
+	P95Us  int64   `json:"p95_us"`
+	P99Us  int64   `json:"p99_us"`
+	P999Us int64   `json:"p999_us"`
+}
+
+func (h *histogram) summary(elapsed time.Duration) summary {
+	s := summary{
+		Count:  h.count,
+		Errors: h.errors,
+		MinUs:  h.min.Microseconds(),
+		MaxUs:  h.max.Microseconds(),
+		P50Us:  h.percentile(50).Microseconds(),
+		P95Us:  h.percentile(95).Microseconds(),
+		P99Us:  h.percentile(99).Microseconds(),
+		P999Us: h.percentile(99.9).Microseconds(),
+	}
+	if h.count > 0 {
+		s.AvgUs = (h.sum / time.Duration(h.count)).Microseconds()
+	}
+	if elapsed > 0 {
+		s.OPS = float64(h.count) / elapsed.Seconds()
+	}
+	return s
+}
+
+func (s summary) String() string {
+	return fmt.Sprintf("Count: %d, OPS: %.1f, Avg(us): %d, Min(us): %d, Max(us): %d, 50th(us): %d, 95th(us): %d, 99th(us): %d, 99.9th(us): %d, Errors: %d",
+		s.Count, s.OPS, s.AvgUs, s.MinUs, s.MaxUs, s.P50Us, s.P95Us, s.P99Us, s.P999Us, s.Errors)
+}
@@ -0,0 +1,298 @@
+// Command tinybench runs the YCSB core workloads against TinyKV and reports the throughput and latency of each
+// operation. It runs against a cluster through the raw or the transactional API, or against an in-process embedded
+// cluster, which needs no deployment and is meant for tracking performance regressions in CI.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/kv/client"
+	"github.com/pingcap-incubator/tinykv/log"
+	pd "github.com/pingcap-incubator/tinykv/scheduler/client"
+)
+
+const usage = `Usage: tinybench (-scheduler <addrs> | -embedded <stores>) [flags]
+
+Workloads:
+  load    insert the records
+  a       50% read, 50% update
+  b       95% read, 5% update
+  c       100% read
+  d       95% read of the latest inserted records, 5% insert
+  e       95% short scan, 5% insert
+  f       50% read, 50% read-modify-write
+
+Workloads a to f read the records inserted by load, which runs first against an embedded cluster.
+`
+
+var (
+	schedulerAddrs = flag.String("scheduler", "", "comma separated addresses of the scheduler")
+	schedulerToken = flag.String("scheduler-token", "", "the token to authenticate to the scheduler, sent in plaintext")
+	embedded       = flag.Int("embedded", 0, "run against an in-process cluster of this many stores instead of -scheduler")
+	workloadName   = flag.String("workload", "a", "the workload to run")
+	api            = flag.String("api", "raw", "the API to use, raw or txn")
+	records        = flag.Int64("records", 10000, "the number of records")
+	operations     = flag.Int64("operations", 100000, "the number of operations to run, 0 for no limit")
+	duration       = flag.Duration("duration", 0, "the max time to run, 0 for no limit")
+	threads        = flag.Int("threads", 16, "the number of concurrent workers")
+	valueSize      = flag.Int("value-size", 100, "the size of the values in bytes")
+	maxScanLength  = flag.Int("max-scan-length", 100, "the max number of records a scan reads")
+	interval       = flag.Duration("report-interval", 10*time.Second, "the interval to report the progress, 0 to disable")
+	output         = flag.String("output", "", "write the result as JSON to the file")
+	logLevel       = flag.String("loglevel", "warn", "the level of log")
+)
+
+// result is the JSON output of a run.
+type result struct {
+	Workload   string             `json:"workload"`
+	API        string             `json:"api"`
+	Embedded   bool               `json:"embedded"`
+	Threads    int                `json:"threads"`
+	Records    int64              `json:"records"`
+	ElapsedSec float64            `json:"elapsed_sec"`
+	OPS        float64            `json:"ops"`
+	Operations map[string]summary `json:"operations"`
+}
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	log.SetLevelByString(*logLevel)
+	if (*schedulerAddrs == "") == (*embedded == 0) || *threads <= 0 || *records <= 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	var w *workload
+	if *workloadName != "load" {
+		var err error
+		if w, err = getWorkload(*workloadName); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+	}
+	db, err := openDB()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	code := bench(db, w)
+	db.Close()
+	os.Exit(code)
+}
+
+// bench loads the records if it's the load workload or the cluster is a new embedded one, then runs the workload.
+// It returns the exit code.
+func bench(db db, w *workload) int {
+	keys := newKeyspace(*records)
+	if w == nil || *embedded > 0 {
+		keys = newKeyspace(0)
+		fmt.Printf("loading %d records\n", *records)
+		res := load(db, keys)
+		if w == nil {
+			return report(res, *output)
+		}
+		report(res, "")
+	}
+	fmt.Printf("running workload %s\n", w.name)
+	return report(run(db, w, keys), *output)
+}
+
+func openDB() (db, error) {
+	if *embedded > 0 {
+		if *api != "raw" {
+			return nil, fmt.Errorf("the embedded cluster only supports the raw API")
+		}
+		return newEmbedDB(*embedded)
+	}
+	c, err := client.NewClientWithSecurity(strings.Split(*schedulerAddrs, ","), pd.SecurityOption{Token: *schedulerToken})
+	if err != nil {
+		return nil, err
+	}
+	switch *api {
+	case "raw":
+		return &rawDB{client: c}, nil
+	case "txn":
+		return &txnDB{client: c}, nil
+	}
+	c.Close()
+	return nil, fmt.Errorf("unknown API %q, it must be raw or txn", *api)
+}
+
+// runner runs the workers until they're done or the duration passes, reporting the progress periodically.
+type runner struct {
+	histograms [opCount]*histogram
+	start      time.Time
+}
+
+func newRunner() *runner {
+	b := &runner{start: time.Now()}
+	for i := range b.histograms {
+		b.histograms[i] = &histogram{}
+	}
+	return b
+}
+
+func (b *runner) do(op operation, f func() error) {
+	start := time.Now()
+	err := f()
+	b.histograms[op].record(time.Since(start), err)
+	if err != nil {
+		log.Debugf("%s failed: %v", op, err)
+	}
+}
+
+func (b *runner) run(worker func(ctx context.Context, id int)) *result {
+	ctx := context.Background()
+	if *duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *duration)
+		defer cancel()
+	}
+	done := make(chan struct{})
+	if *interval > 0 {
+		go func() {
+			ticker := time.NewTicker(*interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					b.print(os.Stdout)
+				case <-done:
+					return
+				}
+			}
+		}()
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < *threads; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			worker(ctx, id)
+		}(i)
+	}
+	wg.Wait()
+	close(done)
+	return b.result()
+}
+
+func (b *runner) result() *result {
+	elapsed := time.Since(b.start)
+	res := &result{
+		API:        *api,
+		Embedded:   *embedded > 0,
+		Threads:    *threads,
+		Records:    *records,
+		ElapsedSec: elapsed.Seconds(),
+		Operations: make(map[string]summary),
+	}
+	var total int64
+	for op, h := range b.histograms {
+		s := h.snapshot()
+		if s.count+s.errors == 0 {
+			continue
+		}
+		res.Operations[operation(op).String()] = s.summary(elapsed)
+		total += s.count
+	}
+	res.OPS = float64(total) / elapsed.Seconds()
+	return res
+}
+
+func (b *runner) print(f *os.File) {
+	res := b.result()
+	for op := operation(0); op < opCount; op++ {
+		if s, ok := res.Operations[op.String()]; ok {
+			fmt.Fprintf(f, "%-17s - Takes(s): %.1f, %s\n", op, res.ElapsedSec, s)
+		}
+	}
+}
+
+// load inserts the records with all the workers.
+func load(db db, keys *keyspace) *result {
+	b := newRunner()
+	res := b.run(func(ctx context.Context, id int) {
+		c := newChooser(time.Now().UnixNano()+int64(id), distUniform, keys)
+		for ctx.Err() == nil {
+			n := keys.nextInsert()
+			if n >= *records {
+				return
+			}
+			key, value := recordKey(n), c.value(*valueSize)
+			b.do(opInsert, func() error { return db.Insert(ctx, key, value) })
+			keys.insertDone(n)
+		}
+	})
+	res.Workload = "load"
+	return res
+}
+
+// run runs the operations of the workload.
+func run(db db, w *workload, keys *keyspace) *result {
+	b := newRunner()
+	var count int64
+	res := b.run(func(ctx context.Context, id int) {
+		c := newChooser(time.Now().UnixNano()+int64(id), w.distribution, keys)
+		for ctx.Err() == nil {
+			if *operations > 0 && atomic.AddInt64(&count, 1) > *operations {
+				return
+			}
+			op := c.operation(w)
+			switch op {
+			case opRead:
+				key := recordKey(c.next())
+				b.do(op, func() error { return db.Read(ctx, key) })
+			case opUpdate:
+				key, value := recordKey(c.next()), c.value(*valueSize)
+				b.do(op, func() error { return db.Update(ctx, key, value) })
+			case opInsert:
+				n := keys.nextInsert()
+				key, value := recordKey(n), c.value(*valueSize)
+				b.do(op, func() error { return db.Insert(ctx, key, value) })
+				keys.insertDone(n)
+			case opScan:
+				key, length := recordKey(c.next()), c.scanLength(*maxScanLength)
+				b.do(op, func() error { return db.Scan(ctx, key, length) })
+			case opReadModifyWrite:
+				key, value := recordKey(c.next()), c.value(*valueSize)
+				b.do(op, func() error { return db.ReadModifyWrite(ctx, key, value) })
+			}
+		}
+	})
+	res.Workload = w.name
+	return res
+}
+
+// report prints the result and writes it to the output file if it's not empty, it returns the exit code.
+func report(res *result, output string) int {
+	fmt.Printf("%s done in %.1fs, %.1f ops/s\n", res.Workload, res.ElapsedSec, res.OPS)
+	for op := operation(0); op < opCount; op++ {
+		if s, ok := res.Operations[op.String()]; ok {
+			fmt.Printf("%-17s - %s\n", op, s)
+		}
+	}
+	if output == "" {
+		return 0
+	}
+	data, err := json.MarshalIndent(res, "", "  ")
+	if err == nil {
+		err = ioutil.WriteFile(output, data, 0644)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "write output failed: %v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"sync/atomic"
+
+	"github.com/pingcap/errors"
+)
+
+type operation int
+
+const (
+	opRead operation = iota
+	opUpdate
+	opInsert
+	opScan
+	opReadModifyWrite
+	opCount
+)
+
+var operationNames = [opCount]string{"READ", "UPDATE", "INSERT", "SCAN", "READ_MODIFY_WRITE"}
+
+func (op operation) String() string {
+	return operationNames[op]
+}
+
+type distribution int
+
+const (
+	distUniform distribution = iota
+	// distZipfian picks a few keys much more often than the others
+	distZipfian
+	// distLatest picks the recently inserted keys more often
+	distLatest
+)
+
+// workload is the mix of the operations of a YCSB workload, the proportions sum to 1.
+type workload struct {
+	name         string
+	proportions  [opCount]float64
+	distribution distribution
+}
+
+// the core workloads of YCSB
+var workloads = map[string]*workload{
+	// update heavy, e.g. a session store recording the recent actions
+	"a": {name: "a", proportions: [opCount]float64{opRead: 0.5, opUpdate: 0.5}, distribution: distZipfian},
+	// read mostly, e.g. photo tagging
+	"b": {name: "b", proportions: [opCount]float64{opRead: 0.95, opUpdate: 0.05}, distribution: distZipfian},
+	// read only, e.g. a user profile cache
+	"c": {name: "c", proportions: [opCount]float64{opRead: 1}, distribution: distZipfian},
+	// read latest, e.g. user status updates
+	"d": {name: "d", proportions: [opCount]float64{opRead: 0.95, opInsert: 0.05}, distribution: distLatest},
+	// short ranges, e.g. threaded conversations
+	"e": {name: "e", proportions: [opCount]float64{opScan: 0.95, opInsert: 0.05}, distribution: distZipfian},
+	// read-modify-write, e.g. a user database
+	"f": {name: "f", proportions: [opCount]float64{opRead: 0.5, opReadModifyWrite: 0.5}, distribution: distZipfian},
+}
+
+func getWorkload(name string) (*workload, error) {
+	w, ok := workloads[name]
+	if !ok {
+		return nil, errors.Errorf("unknown workload %q, it must be one of load, a, b, c, d, e and f", name)
+	}
+	return w, nil
+}
+
+// keyspace generates the keys of the records. The records are numbered from 0, and the key of a record is the hash
+// of its number, so the inserted keys are spread over all the regions instead of appended to the last one.
+type keyspace struct {
+	// the number of records inserted, the records in [0, inserted) may be read
+	inserted int64
+	// the next record to insert
+	next int64
+}
+
+func newKeyspace(records int64) *keyspace {
+	return &keyspace{inserted: records, next: records}
+}
+
+func recordKey(n int64) []byte {
+	h := fnv.New64a()
+	var buf [8]byte
+	for i := range buf {
+		buf[i] = byte(n >> (8 * i))
+	}
+	h.Write(buf[:])
+	return []byte(fmt.Sprintf("user%020d", h.Sum64()))
+}
+
+// nextInsert returns the number of the next record to insert, it must be acknowledged by insertDone.
+func (k *keyspace) nextInsert() int64 {
+	return atomic.AddInt64(&k.next, 1) - 1
+}
+
+// insertDone makes the inserted record readable. Inserts finishing out of order are made readable once all the
+// records before them are inserted, which is approximate for failed inserts but keeps the readable range dense.
+func (k *keyspace) insertDone(n int64) {
+	for {
+		inserted := atomic.LoadInt64(&k.inserted)
+		if n < inserted {
+			return
+		}
+		if atomic.CompareAndSwapInt64(&k.inserted, inserted, n+1) {
+			return
+		}
+	}
+}
+
+// chooser picks the records to read and update, it's used by a single worker.
+type chooser struct {
+	rnd          *rand.Rand
+	distribution distribution
+	keys         *keyspace
+	zipf         *rand.Zipf
+	zipfMax      int64
+}
+
+func newChooser(seed int64, dist distribution, keys *keyspace) *chooser {
+	return &chooser{rnd: rand.New(rand.NewSource(seed)), distribution: dist, keys: keys}
+}
+
+// zipfian returns a number in [0, n) of zipfian distribution, 0 is the most popular. The generator is rebuilt when
+// n grows by inserts.
+func (c *chooser) zipfian(n int64) int64 {
+	if c.zipf == nil || c.zipfMax != n {
+		// YCSB uses a constant of 0.99, rand.Zipf requires a constant greater than 1
+		c.zipf = rand.NewZipf(c.rnd, 1.01, 1, uint64(n-1))
+		c.zipfMax = n
+	}
+	return int64(c.zipf.Uint64())
+}
+
+func (c *chooser) next() int64 {
+	n := atomic.LoadInt64(&c.keys.inserted)
+	if n <= 1 {
+		return 0
+	}
+	switch c.distribution {
+	case distZipfian:
+		// scramble the popular records over the key space, their keys are hashes so any fixed permutation works
+		return (c.zipfian(n) * 2654435761) % n
+	case distLatest:
+		return n - 1 - c.zipfian(n)
+	default:
+		return c.rnd.Int63n(n)
+	}
+}
+
+func (c *chooser) operation(w *workload) operation {
+	r := c.rnd.Float64()
+	for op, p := range w.proportions {
+		if r < p {
+			return operation(op)
+		}
+		r -= p
+	}
+	return opRead
+}
+
+func (c *chooser) value(size int) []byte {
+	value := make([]byte, size)
+	c.rnd.Read(value)
+	return value
+}
+
+// scanLength returns the number of records to scan, uniform in [1, max].
+func (c *chooser) scanLength(max int) int {
+	return 1 + c.rnd.Intn(max)
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pingcap-incubator/tinykv/kv/config"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/meta"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+)
+
+const usage = `Usage: tinykv-ctl -path <db path> [-kv-path <path>] [-raft-path <path>] <command> [flags]
+
+Commands:
+  raft-log    check the raft logs of the regions, and truncate torn tails with -repair
+
+The store must be stopped while the command runs.
+`
+
+var (
+	dbPath   = flag.String("path", "", "directory path of db")
+	kvPath   = flag.String("kv-path", "", "directory path of the kv engine, the kv subdirectory of path by default")
+	raftPath = flag.String("raft-path", "", "directory path of the raft engine, the raft subdirectory of path by default")
+	stdin    = bufio.NewReader(os.Stdin)
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if (*dbPath == "" && (*kvPath == "" || *raftPath == "")) || flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	switch flag.Arg(0) {
+	case "raft-log":
+		os.Exit(raftLog(flag.Args()[1:]))
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
+
+func openEngines() *engine_util.Engines {
+	conf := &config.Config{DBPath: *dbPath, KvPath: *kvPath, RaftPath: *raftPath}
+	kvPath, raftPath := conf.KvDir(), conf.RaftDir()
+	for _, path := range []string{kvPath, raftPath} {
+		if _, err := os.Stat(path); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+	}
+	return engine_util.NewEngines(engine_util.CreateDB(kvPath, false), engine_util.CreateDB(raftPath, true), kvPath, raftPath)
+}
+
+func raftLog(args []string) int {
+	fs := flag.NewFlagSet("raft-log", flag.ExitOnError)
+	regionID := fs.Uint64("region", 0, "only check the region, all regions if 0")
+	repair := fs.Bool("repair", false, "truncate the torn tails of the inconsistent regions")
+	yes := fs.Bool("yes", false, "repair without asking for confirmation")
+	fs.Parse(args)
+
+	engines := openEngines()
+	defer engines.Close()
+
+	var reports []*meta.RaftLogReport
+	if *regionID != 0 {
+		report, err := meta.CheckRaftLog(engines, *regionID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "check region %d failed: %v\n", *regionID, err)
+			return 1
+		}
+		reports = append(reports, report)
+	} else {
+		var err error
+		if reports, err = meta.CheckRaftLogs(engines); err != nil {
+			fmt.Fprintf(os.Stderr, "check raft logs failed: %v\n", err)
+			return 1
+		}
+	}
+
+	var inconsistent []*meta.RaftLogReport
+	for _, report := range reports {
+		if report.Consistent() {
+			fmt.Printf("region %d: ok, last index %d, last term %d\n", report.RegionID, report.LastIndex, report.LastTerm)
+			continue
+		}
+		inconsistent = append(inconsistent, report)
+		fmt.Printf("region %d: inconsistent, last consistent entry %d with term %d\n", report.RegionID, report.LastIndex, report.LastTerm)
+		for _, problem := range report.Problems {
+			fmt.Printf("  %s\n", problem)
+		}
+		if torn := report.TornIndexes(); len(torn) > 0 {
+			fmt.Printf("  torn entries: %v\n", torn)
+		}
+	}
+	fmt.Printf("%d regions checked, %d inconsistent\n", len(reports), len(inconsistent))
+	if len(inconsistent) == 0 {
+		return 0
+	}
+	if !*repair {
+		return 1
+	}
+
+	failed := false
+	for _, report := range inconsistent {
+		if !*yes && !confirm(fmt.Sprintf("truncate the raft log of region %d to entry %d?", report.RegionID, report.LastIndex)) {
+			fmt.Printf("region %d: skipped\n", report.RegionID)
+			failed = true
+			continue
+		}
+		if err := meta.RepairRaftLog(engines, report); err != nil {
+			fmt.Fprintf(os.Stderr, "region %d: repair failed: %v\n", report.RegionID, err)
+			failed = true
+			continue
+		}
+		fmt.Printf("region %d: truncated to entry %d\n", report.RegionID, report.LastIndex)
+	}
+	if failed {
+		return 1
+	}
+	return 0
+}
+
+func confirm(question string) bool {
+	fmt.Printf("%s [y/N] ", question)
+	answer, _ := stdin.ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
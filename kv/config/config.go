@@ -2,6 +2,10 @@ package config
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/pingcap-incubator/tinykv/log"
@@ -11,29 +15,262 @@ type Config struct {
 	StoreAddr     string
 	Raft          bool
 	SchedulerAddr string
-	LogLevel      string
+	// Token authenticating the store to the scheduler, if the scheduler
+	// authenticates its API clients. It needs the store role. The token is
+	// sent in plaintext, the store doesn't connect to the scheduler over TLS.
+	SchedulerToken string
+	LogLevel       string
 
 	DBPath string // Directory to store the data in. Should exist and be writable.
+	// Directories of the kv engine, the raft engine and the snapshots, which
+	// may be on different disks, e.g. the raft engine on a small fast disk.
+	// They default to the kv, raft and snap subdirectories of DBPath.
+	KvPath   string
+	RaftPath string
+	SnapPath string
 
 	// raft_base_tick_interval is a base tick interval (ms).
 	RaftBaseTickInterval     time.Duration
 	RaftHeartbeatTicks       int
 	RaftElectionTimeoutTicks int
+	// Max byte size of the entries carried by a single raft append message.
+	// It must be less than GrpcMaxMsgSize, otherwise the message can't be
+	// received by the peer.
+	RaftMaxSizePerMsg uint64
+	// Max number of in-flight raft append messages to a follower, the leader
+	// stops sending appends to a follower which doesn't acknowledge them.
+	// 0 means no limit.
+	RaftMaxInflightMsgs int
+	// Max byte size of a single raft entry, a larger proposal is rejected.
+	// RaftStorage splits a larger write batch into several proposals.
+	RaftEntryMaxSize uint64
+	// Max byte size of the uncommitted entries of a leader, new proposals are
+	// dropped once the limit is reached, e.g. when the quorum is slow.
+	RaftMaxUncommittedSize uint64
+	// Max byte size of the raft messages of a region received from the
+	// other stores and not yet handled by its peer. The raft stream
+	// receiving a message for a region over the limit isn't read until the
+	// peer catches up, so gRPC flow control slows the sender down. 0 means
+	// no limit.
+	RaftRecvMaxPendingSize uint64
+
+	// Require the ready loop to report the persistence of the raft hard state
+	// and entries before advancing a ready, see raft.Config.RequirePersistAck.
+	RaftRequirePersistAck bool
+	// How the writes to the raft engine are synced, one of SyncLogAlways,
+	// SyncLogBatched and SyncLogPeriodic.
+	RaftSyncLog string
+	// The interval of syncing the raft engine with SyncLogPeriodic.
+	RaftSyncLogInterval time.Duration
+	// Send the entries of a ready to the followers while the leader persists
+	// them, see raft.Config.ParallelAppend.
+	RaftParallelAppend bool
+	// Number of goroutines applying the committed raft entries, so a raft
+	// worker persists the next readies meanwhile, see raft.Config.AsyncApply.
+	// The entries of a region are always applied by the same goroutine. 0
+	// applies them in the raft worker after persisting the ready.
+	RaftApplyWorkers int
+	// Serve the read-only commands with the raft read index instead of
+	// proposing them to the log, see raft.RawNode.ReadIndex.
+	RaftReadIndex bool
+	// How long a leader serves the read-only commands locally after a quorum
+	// answers its heartbeats, 0 disables the lease. It must be shorter than
+	// the election timeout, by the max clock drift between the stores.
+	RaftLeaderLease time.Duration
+	// Whether an election starts with a pre-election, so a peer rejoining
+	// after a partition doesn't disrupt the leader, see raft.Config.PreVote.
+	RaftPreVote bool
+	// Whether the raft workers label their goroutines with the stage of the
+	// ready loop they are in, so CPU profiles can be broken down by stage.
+	RaftStoreProfileLabels bool
+
+	// Let a healthy follower generate and send the snapshot for a lagging peer
+	// instead of the leader, so that a leader under write load doesn't also bear
+	// the snapshot IO.
+	DelegateSnapshot bool
+
+	// Whether the peers of new replicas are only created by the CreatePeer
+	// RPC instead of by the first raft message to them. A created peer is
+	// uninitialized until it applies a snapshot from the leader, and it's
+	// destroyed if none arrives within PeerBootstrapTimeout, 0 waits forever.
+	ExplicitPeerCreation bool
+	PeerBootstrapTimeout time.Duration
+	// How long the data of a removed peer is kept on the store, so a peer of
+	// the region re-added meanwhile catches up from it by replaying the log
+	// instead of applying a full snapshot. 0 deletes the data at once. The
+	// data is never kept with MemoryLockCF, whose locks aren't persisted.
+	RemovedPeerRetention time.Duration
+
+	// Transactional writes on the keys with any of these prefixes append an
+	// audit record to the audit CF. An empty prefix audits all keys, and audit
+	// is disabled if no prefix is set.
+	AuditPrefixes []string
+
+	// Transactional reads of the keys with any of these prefixes use read
+	// committed instead of snapshot isolation, i.e. they are never blocked by
+	// locks. A request may also ask for read committed itself.
+	ReadCommittedPrefixes []string
+
+	// The keys with any of RawKeyPrefixes may only be accessed by the raw
+	// API, and the keys with any of TxnKeyPrefixes only by the transactional
+	// API, so the raw writes don't corrupt the MVCC data. With any prefix
+	// set, the raw API can't write the lock and write CFs either. The two
+	// keyspaces must not overlap.
+	RawKeyPrefixes []string
+	TxnKeyPrefixes []string
+
+	// How long a raw delete keeps the deleted value in a tombstone, which
+	// RawUndelete restores it from. 0 deletes the values at once.
+	RawSoftDeleteWindow time.Duration
+	// Interval to remove the expired raw tombstones from the regions the
+	// store leads, it must be greater than 0 if the soft delete is enabled.
+	RawTombstoneGCTickInterval time.Duration
+
+	// Max number of snapshots generated at the same time on a store, the
+	// rest are queued smallest region first.
+	SnapGenConcurrency int
+	// Max number of snapshots applied at the same time on a store, the rest
+	// are queued in FIFO order.
+	SnapApplyConcurrency int
+	// A leader backs off the snapshots of a follower which needs another one
+	// before catching up with the last, e.g. it keeps failing to apply them.
+	// The backoff starts at SnapResendBackoff and doubles with each snapshot
+	// up to SnapResendMaxBackoff, 0 disables it. An alarm is logged once a
+	// follower is sent SnapResendAlarmCount snapshots in a row.
+	SnapResendBackoff    time.Duration
+	SnapResendMaxBackoff time.Duration
+	SnapResendAlarmCount int
+	// Max bytes per second of the snapshots sent by a store in total, and by
+	// each snapshot stream, so the rebalancing traffic doesn't starve the
+	// raft messages. 0 is unlimited.
+	SnapSendRateLimit       uint64
+	SnapSendStreamRateLimit uint64
+	// The snapshots which are neither sent nor applied within SnapTTL of
+	// being written are deleted, e.g. the received snapshots a peer never
+	// applies. 0 keeps them until they are applied or compacted.
+	SnapTTL time.Duration
+
+	// Max byte size of the cached coprocessor responses, a cached response is
+	// reused until a write is applied to its region. 0 disables the cache.
+	CopCacheCapacity uint64
+
+	// Number of locks of a transaction in a region above which ResolveLock
+	// resolves them in the background. 0 always resolves them in the request.
+	AsyncResolveLockThreshold int
+
+	// Max number of transactional commands to a region whose writes are
+	// proposed together while the previous batch of the region is written.
+	// 0 writes each command on its own.
+	TxnWriteBatchSize int
+
+	// A region whose requests failed with timeouts, stale epochs or not
+	// leader errors at least RegionBreakerMinErrors times, and for at least
+	// RegionBreakerErrorRatio of its requests, within RegionBreakerWindow is
+	// fast-failed for RegionBreakerCooldown, so the clients refresh its
+	// route. 0 min errors disables the breaker, which is the default.
+	RegionBreakerMinErrors  int
+	RegionBreakerErrorRatio float64
+	RegionBreakerWindow     time.Duration
+	RegionBreakerCooldown   time.Duration
+
+	// Max offset of the local clock to the scheduler clock, measured on store
+	// heartbeats. Lease based reads are refused while the offset exceeds it.
+	MaxClockSkew time.Duration
+
+	// The store reports a heavy background compaction to the scheduler while
+	// the kv engine has at least this many level 0 tables, so that it's
+	// avoided as a target of new peers and leaders. 0 disables the report.
+	CompactionPendingL0Tables int
+
+	// Bits per key of the in-memory bloom filters over the keys in the write
+	// CF of each region, which let reads of missing keys skip the kv engine.
+	// 0 disables the filters.
+	KeyFilterBitsPerKey int
+
+	// Whether the proposals carry a checksum of the command, which is
+	// verified when the command is applied. It's off by default, as it
+	// changes the format of the entries and costs each proposal a hash.
+	RaftProposalChecksum bool
+
+	// Whether the lock CF is kept in memory, persisted only by checkpoints
+	// on log compaction and recovered from the raft log on restart.
+	MemoryLockCF bool
+
+	// Whether the locks of each region are indexed in memory by key and by
+	// start ts, so ScanLock and lock checks don't iterate the lock CF. It
+	// can't be used with MemoryLockCF, whose lock CF is in memory already.
+	LockIndex bool
+
+	// Whether the keys written to the transactional CFs are checked against
+	// the TinySQL key layout when they are applied. The violations are only
+	// counted and served by the KeyViolations debug RPC.
+	KeyLayoutCheck bool
+
+	// Max number of values cached by the change capture of each region, to
+	// look up the old values of its changes. 0 disables the change capture,
+	// see cdc.Capturer.
+	ChangeCaptureCacheSize int
+
+	// Capacity in bytes of the buffer of the latest committed entries of
+	// each region, entries replayed from it skip the raft engine. 0
+	// disables the buffer.
+	RaftReplayBufferSize uint64
+	// Capacity in bytes of the cache of the latest appended entries of each
+	// region, the entries sent to the followers from it skip the raft
+	// engine. 0 disables the cache.
+	RaftEntryCacheSize uint64
+
+	// A leader whose writes are persisted or applied slower than the
+	// threshold for the duration transfers the leadership to a caught up
+	// follower, e.g. for a degraded disk. 0 threshold disables the check,
+	// which is the default.
+	SlowLeaderLatencyThreshold time.Duration
+	SlowLeaderDuration         time.Duration
+
+	// Whether the FailPoint RPC may enable failpoints in the store, for
+	// integration tests injecting failures. Never enable it in production.
+	EnableFailPoints bool
 
 	// Interval to gc unnecessary raft log (ms).
 	RaftLogGCTickInterval time.Duration
 	// When entry count exceed this value, gc will be forced trigger.
 	RaftLogGcCountLimit uint64
+	// Max byte size of the raft log of a region not compacted yet, measured
+	// on the raft log gc tick. Once it's exceeded, e.g. for a slow apply, the
+	// leader compacts the log regardless of RaftLogGcCountLimit and rejects
+	// the writes with a retryable error until the log is compacted under the
+	// quota. 0 disables the quota.
+	RaftLogQuota uint64
+
+	// Interval to check the merge of a region which applied a PrepareMerge:
+	// each of its peers proposes the CommitMerge to the target peer on its
+	// store, and the leader rolls the merge back once the target changed.
+	MergeCheckTickInterval time.Duration
+
+	// Interval to remove the rollback records older than RollbackRetention
+	// from the write CF of the regions the store leads, apart from the MVCC
+	// GC, and the staged mutations never prewritten from the staging CF. 0
+	// disables the cleanup.
+	RollbackCleanupTickInterval time.Duration
+	// How long a rollback record is kept. It stops a late prewrite of the
+	// rolled back transaction, so it must be longer than the lock TTLs. A
+	// transaction must prewrite its staged mutations within it as well.
+	RollbackRetention time.Duration
 
 	// Interval (ms) to check region whether need to be split or not.
 	SplitRegionCheckTickInterval time.Duration
+	// A region is scanned by the split check again once about this many
+	// bytes were written to it since its last check. 0 uses an eighth of the
+	// split size.
+	RegionSplitCheckDiff uint64
 	// delay time before deleting a stale peer
 	SchedulerHeartbeatTickInterval      time.Duration
 	SchedulerStoreHeartbeatTickInterval time.Duration
 
 	// When region [a,e) size meets regionMaxSize, it will be split into
 	// several regions [a,b), [b,c), [c,d), [d,e). And the size of [a,b),
-	// [b,c), [c,d) will be regionSplitSize (maybe a little larger).
+	// [b,c), [c,d) will be regionSplitSize (maybe a little larger). 0
+	// regionSplitSize splits at half of regionMaxSize.
 	RegionMaxSize   uint64
 	RegionSplitSize uint64
 }
@@ -52,6 +289,168 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("election tick must be greater than heartbeat tick.")
 	}
 
+	if electionTimeout := c.RaftBaseTickInterval * time.Duration(c.RaftElectionTimeoutTicks); c.RaftLeaderLease >= electionTimeout {
+		return fmt.Errorf("raft leader lease %v must be shorter than the election timeout %v",
+			c.RaftLeaderLease, electionTimeout)
+	}
+
+	if c.RaftMaxSizePerMsg >= GrpcMaxMsgSize {
+		return fmt.Errorf("raft max size per message %d must be less than grpc max message size %d",
+			c.RaftMaxSizePerMsg, GrpcMaxMsgSize)
+	}
+
+	if c.RaftMaxInflightMsgs < 0 {
+		return fmt.Errorf("raft max inflight messages %d must not be negative", c.RaftMaxInflightMsgs)
+	}
+
+	switch c.RaftSyncLog {
+	case SyncLogAlways, SyncLogBatched:
+	case SyncLogPeriodic:
+		if c.RaftSyncLogInterval <= 0 {
+			return fmt.Errorf("raft sync log interval must be greater than 0 if the raft log is synced periodically")
+		}
+	default:
+		return fmt.Errorf("raft sync log %q must be one of %q, %q and %q",
+			c.RaftSyncLog, SyncLogAlways, SyncLogBatched, SyncLogPeriodic)
+	}
+
+	if c.RemovedPeerRetention < 0 {
+		return fmt.Errorf("removed peer retention %v must not be negative", c.RemovedPeerRetention)
+	}
+
+	if c.SnapResendBackoff < 0 || c.SnapResendMaxBackoff < c.SnapResendBackoff {
+		return fmt.Errorf("snapshot resend backoff %v must not be negative or longer than the max backoff %v",
+			c.SnapResendBackoff, c.SnapResendMaxBackoff)
+	}
+
+	if c.SnapTTL < 0 {
+		return fmt.Errorf("snapshot ttl %v must not be negative", c.SnapTTL)
+	}
+
+	if c.RaftApplyWorkers < 0 {
+		return fmt.Errorf("raft apply workers %d must not be negative", c.RaftApplyWorkers)
+	}
+
+	if c.RaftEntryMaxSize == 0 || c.RaftEntryMaxSize >= GrpcMaxMsgSize {
+		return fmt.Errorf("raft entry max size %d must be greater than 0 and less than grpc max message size %d",
+			c.RaftEntryMaxSize, GrpcMaxMsgSize)
+	}
+
+	if c.RaftLogQuota > 0 && c.RaftLogQuota < c.RaftEntryMaxSize {
+		return fmt.Errorf("raft log quota %d must not be less than raft entry max size %d",
+			c.RaftLogQuota, c.RaftEntryMaxSize)
+	}
+
+	if c.RegionSplitSize > c.RegionMaxSize {
+		return fmt.Errorf("region split size %d must not be greater than region max size %d",
+			c.RegionSplitSize, c.RegionMaxSize)
+	}
+
+	if c.RollbackCleanupTickInterval > 0 && c.RollbackRetention <= 0 {
+		return fmt.Errorf("rollback retention must be greater than 0 if the rollback cleanup is enabled")
+	}
+
+	if c.RawSoftDeleteWindow > 0 && c.RawTombstoneGCTickInterval <= 0 {
+		return fmt.Errorf("raw tombstone gc tick interval must be greater than 0 if the raw soft delete is enabled")
+	}
+
+	if c.RegionBreakerMinErrors > 0 && (c.RegionBreakerWindow <= 0 || c.RegionBreakerCooldown <= 0) {
+		return fmt.Errorf("region breaker window and cooldown must be greater than 0 if the breaker is enabled")
+	}
+
+	for _, raw := range c.RawKeyPrefixes {
+		for _, txn := range c.TxnKeyPrefixes {
+			if strings.HasPrefix(raw, txn) || strings.HasPrefix(txn, raw) {
+				return fmt.Errorf("raw key prefix %q and transactional key prefix %q overlap", raw, txn)
+			}
+		}
+	}
+
+	if c.LockIndex && c.MemoryLockCF {
+		return fmt.Errorf("lock index can't be enabled with memory lock CF")
+	}
+
+	dirs := c.DataDirs()
+	for i := range dirs {
+		for j := i + 1; j < len(dirs); j++ {
+			if filepath.Clean(dirs[i].Path) == filepath.Clean(dirs[j].Path) {
+				return fmt.Errorf("%s path and %s path must be different directories, both are %s",
+					dirs[i].Name, dirs[j].Name, dirs[i].Path)
+			}
+		}
+	}
+
+	return nil
+}
+
+// SplitSize returns the size of the regions a region over RegionMaxSize is split into, half of RegionMaxSize unless
+// RegionSplitSize is set.
+func (c *Config) SplitSize() uint64 {
+	if c.RegionSplitSize > 0 {
+		return c.RegionSplitSize
+	}
+	return c.RegionMaxSize / 2
+}
+
+// SplitCheckDiff returns the size written to a region since its last split check, over which it's checked again.
+func (c *Config) SplitCheckDiff() uint64 {
+	if c.RegionSplitCheckDiff > 0 {
+		return c.RegionSplitCheckDiff
+	}
+	return c.SplitSize() / 8
+}
+
+// DataDir is a directory the store keeps its data in.
+type DataDir struct {
+	// What's stored in the directory, "kv", "raft" or "snap".
+	Name string
+	Path string
+}
+
+func (c *Config) dataPath(path, name string) string {
+	if path != "" {
+		return path
+	}
+	return filepath.Join(c.DBPath, name)
+}
+
+// KvDir returns the directory of the kv engine.
+func (c *Config) KvDir() string {
+	return c.dataPath(c.KvPath, "kv")
+}
+
+// RaftDir returns the directory of the raft engine.
+func (c *Config) RaftDir() string {
+	return c.dataPath(c.RaftPath, "raft")
+}
+
+// SnapDir returns the directory of the snapshots.
+func (c *Config) SnapDir() string {
+	return c.dataPath(c.SnapPath, "snap")
+}
+
+// DataDirs returns the directories of the kv engine, the raft engine and the snapshots.
+func (c *Config) DataDirs() []DataDir {
+	return []DataDir{
+		{Name: "kv", Path: c.KvDir()},
+		{Name: "raft", Path: c.RaftDir()},
+		{Name: "snap", Path: c.SnapDir()},
+	}
+}
+
+// PrepareDataDirs creates the data directories if they don't exist, and checks they are writable.
+func (c *Config) PrepareDataDirs() error {
+	for _, dir := range c.DataDirs() {
+		if err := os.MkdirAll(dir.Path, os.ModePerm); err != nil {
+			return fmt.Errorf("create %s directory: %v", dir.Name, err)
+		}
+		f, err := ioutil.TempFile(dir.Path, ".check-")
+		if err != nil {
+			return fmt.Errorf("%s directory %s is not writable: %v", dir.Name, dir.Path, err)
+		}
+		f.Close()
+		os.Remove(f.Name())
+	}
 	return nil
 }
 
@@ -60,6 +459,21 @@ const (
 	MB uint64 = 1024 * 1024
 )
 
+// The policies of syncing the writes to the raft engine.
+const (
+	// SyncLogAlways syncs each write to the raft engine.
+	SyncLogAlways = "always"
+	// SyncLogBatched syncs the writes of a round of a raft worker at once, the
+	// messages and the advance of the readies which must be synced wait for it.
+	SyncLogBatched = "batched"
+	// SyncLogPeriodic syncs the raft engine every RaftSyncLogInterval, the
+	// writes since the last sync may be lost on a crash of the machine.
+	SyncLogPeriodic = "periodic"
+)
+
+// GrpcMaxMsgSize is the max size of a message the gRPC server can receive.
+const GrpcMaxMsgSize = 10 * MB
+
 func NewDefaultConfig() *Config {
 	return &Config{
 		SchedulerAddr:            "127.0.0.1:2379",
@@ -69,14 +483,52 @@ func NewDefaultConfig() *Config {
 		RaftBaseTickInterval:     1 * time.Second,
 		RaftHeartbeatTicks:       2,
 		RaftElectionTimeoutTicks: 10,
+		RaftMaxSizePerMsg:        1 * MB,
+		RaftMaxInflightMsgs:      256,
+		RaftEntryMaxSize:         8 * MB,
+		RaftMaxUncommittedSize:   128 * MB,
+		RaftRecvMaxPendingSize:   32 * MB,
+		RaftSyncLog:              SyncLogAlways,
+		RaftSyncLogInterval:      100 * time.Millisecond,
 		RaftLogGCTickInterval:    10 * time.Second,
 		// Assume the average size of entries is 1k.
 		RaftLogGcCountLimit:                 128000,
 		SplitRegionCheckTickInterval:        10 * time.Second,
+		RollbackCleanupTickInterval:         10 * time.Minute,
+		RawTombstoneGCTickInterval:          10 * time.Minute,
+		MergeCheckTickInterval:              2 * time.Second,
+		RollbackRetention:                   time.Hour,
 		SchedulerHeartbeatTickInterval:      100 * time.Millisecond,
 		SchedulerStoreHeartbeatTickInterval: 10 * time.Second,
 		RegionMaxSize:                       144 * MB,
-		RegionSplitSize:                     96 * MB,
+		SnapGenConcurrency:                  2,
+		SnapApplyConcurrency:                1,
+		SnapResendBackoff:                   10 * time.Second,
+		SnapResendMaxBackoff:                10 * time.Minute,
+		SnapResendAlarmCount:                5,
+		SnapSendRateLimit:                   100 * MB,
+		SnapTTL:                             4 * time.Hour,
+		TxnWriteBatchSize:                   64,
+		MaxClockSkew:                        500 * time.Millisecond,
+		CompactionPendingL0Tables:           10,
+		KeyFilterBitsPerKey:                 10,
+		RaftProposalChecksum:                false,
+		MemoryLockCF:                        false,
+		LockIndex:                           false,
+		KeyLayoutCheck:                      false,
+		ChangeCaptureCacheSize:              4096,
+		RaftReplayBufferSize:                1 * MB,
+		RaftEntryCacheSize:                  4 * MB,
+		SlowLeaderLatencyThreshold:          0,
+		SlowLeaderDuration:                  10 * time.Second,
+		CopCacheCapacity:                    64 * MB,
+		AsyncResolveLockThreshold:           4096,
+		RegionBreakerMinErrors:              0,
+		RegionBreakerErrorRatio:             0.5,
+		RegionBreakerWindow:                 10 * time.Second,
+		RegionBreakerCooldown:               time.Second,
+		PeerBootstrapTimeout:                time.Minute,
+		RemovedPeerRetention:                10 * time.Minute,
 		DBPath:                              "/tmp/badger",
 	}
 }
@@ -88,14 +540,33 @@ func NewTestConfig() *Config {
 		RaftBaseTickInterval:     50 * time.Millisecond,
 		RaftHeartbeatTicks:       2,
 		RaftElectionTimeoutTicks: 10,
+		RaftMaxSizePerMsg:        1 * MB,
+		RaftMaxInflightMsgs:      256,
+		RaftEntryMaxSize:         8 * MB,
+		RaftMaxUncommittedSize:   128 * MB,
+		RaftRecvMaxPendingSize:   32 * MB,
+		RaftSyncLog:              SyncLogAlways,
+		RaftSyncLogInterval:      100 * time.Millisecond,
 		RaftLogGCTickInterval:    50 * time.Millisecond,
 		// Assume the average size of entries is 1k.
 		RaftLogGcCountLimit:                 128000,
 		SplitRegionCheckTickInterval:        100 * time.Millisecond,
+		MergeCheckTickInterval:              100 * time.Millisecond,
 		SchedulerHeartbeatTickInterval:      100 * time.Millisecond,
 		SchedulerStoreHeartbeatTickInterval: 500 * time.Millisecond,
 		RegionMaxSize:                       144 * MB,
-		RegionSplitSize:                     96 * MB,
+		SnapGenConcurrency:                  2,
+		SnapApplyConcurrency:                1,
+		MaxClockSkew:                        500 * time.Millisecond,
+		CompactionPendingL0Tables:           10,
+		KeyFilterBitsPerKey:                 0,
+		MemoryLockCF:                        false,
+		LockIndex:                           false,
+		KeyLayoutCheck:                      false,
+		SlowLeaderLatencyThreshold:          0,
+		SlowLeaderDuration:                  10 * time.Second,
+		AsyncResolveLockThreshold:           0,
+		PeerBootstrapTimeout:                10 * time.Second,
 		DBPath:                              "/tmp/badger",
 	}
 }
@@ -0,0 +1,26 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitSize(t *testing.T) {
+	for _, cfg := range []*Config{NewDefaultConfig(), NewTestConfig()} {
+		// the regions are split at half of the max size by default
+		assert.Equal(t, cfg.RegionMaxSize/2, cfg.SplitSize())
+		assert.Equal(t, cfg.RegionMaxSize/16, cfg.SplitCheckDiff())
+		assert.Nil(t, cfg.Validate())
+	}
+
+	cfg := NewTestConfig()
+	cfg.RegionMaxSize = 800
+	cfg.RegionSplitSize = 500
+	assert.Equal(t, uint64(500), cfg.SplitSize())
+	assert.Equal(t, uint64(62), cfg.SplitCheckDiff())
+	cfg.RegionSplitCheckDiff = 10
+	assert.Equal(t, uint64(10), cfg.SplitCheckDiff())
+	cfg.RegionSplitSize = 900
+	assert.NotNil(t, cfg.Validate())
+}
@@ -0,0 +1,96 @@
+package coprocessor
+
+import (
+	"container/list"
+	"encoding/binary"
+	"sync"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/coprocessor"
+)
+
+// Cache is a size-bounded LRU cache of coprocessor responses. An entry is only valid for the applied index of the
+// region it was read at: any write applied to the region afterwards invalidates it.
+type Cache struct {
+	sync.Mutex
+	capacity uint64
+	size     uint64
+	lru      *list.List
+	entries  map[string]*list.Element
+}
+
+type cacheEntry struct {
+	key        string
+	applyIndex uint64
+	resp       *coprocessor.Response
+	size       uint64
+}
+
+// NewCache creates a cache holding at most capacity bytes of responses.
+func NewCache(capacity uint64) *Cache {
+	return &Cache{
+		capacity: capacity,
+		lru:      list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// CacheKey returns the key of a request in the cache, two requests with the same key read the same data from the
+// same region. The context other than the region id is ignored, since the applied index of the region already
+// covers any change of its epoch.
+func CacheKey(req *coprocessor.Request) string {
+	shadow := *req
+	shadow.Context = nil
+	data, err := shadow.Marshal()
+	if err != nil {
+		panic(err)
+	}
+	key := make([]byte, 8, 8+len(data))
+	binary.BigEndian.PutUint64(key, req.Context.GetRegionId())
+	return string(append(key, data...))
+}
+
+// Get returns the cached response for key if it was read at applyIndex, otherwise nil.
+func (c *Cache) Get(key string, applyIndex uint64) *coprocessor.Response {
+	c.Lock()
+	defer c.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	entry := elem.Value.(*cacheEntry)
+	if entry.applyIndex != applyIndex {
+		// The region has changed since, the entry is never valid again.
+		c.remove(elem)
+		return nil
+	}
+	c.lru.MoveToFront(elem)
+	return entry.resp
+}
+
+// Put caches resp for key as read at applyIndex. A response carrying any error or lock is not cached, since it
+// doesn't reflect the data of the region.
+func (c *Cache) Put(key string, applyIndex uint64, resp *coprocessor.Response) {
+	if resp == nil || resp.RegionError != nil || resp.Locked != nil || resp.OtherError != "" {
+		return
+	}
+	size := uint64(len(key) + resp.Size())
+	if size > c.capacity {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+	for c.size+size > c.capacity {
+		c.remove(c.lru.Back())
+	}
+	c.entries[key] = c.lru.PushFront(&cacheEntry{key: key, applyIndex: applyIndex, resp: resp, size: size})
+	c.size += size
+}
+
+func (c *Cache) remove(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*cacheEntry)
+	delete(c.entries, entry.key)
+	c.size -= entry.size
+}
@@ -0,0 +1,53 @@
+package coprocessor
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/coprocessor"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/errorpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheKey(t *testing.T) {
+	req := &coprocessor.Request{Context: &kvrpcpb.Context{RegionId: 1, Term: 2}, Tp: 103, Data: []byte{1}}
+	other := &coprocessor.Request{Context: &kvrpcpb.Context{RegionId: 1, Term: 3}, Tp: 103, Data: []byte{1}}
+	assert.Equal(t, CacheKey(req), CacheKey(other))
+	other.Context.RegionId = 2
+	assert.NotEqual(t, CacheKey(req), CacheKey(other))
+	other = &coprocessor.Request{Context: req.Context, Tp: 103, Data: []byte{2}}
+	assert.NotEqual(t, CacheKey(req), CacheKey(other))
+	assert.Equal(t, uint64(1), req.Context.RegionId)
+}
+
+func TestCache(t *testing.T) {
+	resp := func(n int) *coprocessor.Response { return &coprocessor.Response{Data: make([]byte, n)} }
+	size := uint64(len("a") + resp(10).Size())
+	c := NewCache(2 * size)
+
+	c.Put("a", 5, resp(10))
+	assert.NotNil(t, c.Get("a", 5))
+	// a stale entry is dropped
+	assert.Nil(t, c.Get("a", 6))
+	assert.Nil(t, c.Get("a", 5))
+
+	// errors are not cached
+	c.Put("a", 5, &coprocessor.Response{RegionError: &errorpb.Error{}})
+	c.Put("a", 5, &coprocessor.Response{OtherError: "error"})
+	assert.Nil(t, c.Get("a", 5))
+
+	// the least recently used entry is evicted
+	c.Put("a", 1, resp(10))
+	c.Put("b", 1, resp(10))
+	assert.NotNil(t, c.Get("a", 1))
+	c.Put("c", 1, resp(10))
+	assert.Nil(t, c.Get("b", 1))
+	assert.NotNil(t, c.Get("a", 1))
+	assert.NotNil(t, c.Get("c", 1))
+	assert.Equal(t, 2*size, c.size)
+
+	// a response larger than the cache is not cached
+	c.Put("d", 1, resp(100))
+	assert.Nil(t, c.Get("d", 1))
+	assert.NotNil(t, c.Get("a", 1))
+}
@@ -0,0 +1,344 @@
+// Package embed builds a TinyKV cluster of several stores running in one process, backed by real raftstores and
+// temporary data directories. It's meant for downstream projects writing their own integration tests.
+//
+//	cluster, err := embed.NewBuilder(3).Start()
+//	if err != nil {
+//		...
+//	}
+//	defer cluster.Shutdown()
+//	err = cluster.Put([]byte("k"), []byte("v"))
+package embed
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Connor1996/badger"
+	"github.com/pingcap-incubator/tinykv/kv/config"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/scheduler_client"
+	"github.com/pingcap-incubator/tinykv/kv/storage/raft_storage"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
+	"github.com/pingcap/errors"
+)
+
+// Builder configures an embedded cluster before it's started.
+type Builder struct {
+	count           int
+	cfg             *config.Config
+	schedulerClient scheduler_client.Client
+	dir             string
+}
+
+// NewBuilder returns a builder of a cluster with count stores. By default the cluster uses the test config and a
+// mock scheduler.
+func NewBuilder(count int) *Builder {
+	return &Builder{
+		count: count,
+		cfg:   config.NewTestConfig(),
+	}
+}
+
+// Config sets the config shared by all the stores, DBPath is overwritten for each store.
+func (b *Builder) Config(cfg *config.Config) *Builder {
+	b.cfg = cfg
+	return b
+}
+
+// SchedulerClient makes the cluster use the given scheduler, e.g. a client of a real scheduler created by
+// scheduler_client.NewClient. The scheduler must not have been bootstrapped.
+func (b *Builder) SchedulerClient(client scheduler_client.Client) *Builder {
+	b.schedulerClient = client
+	return b
+}
+
+// Dir sets the parent directory of the data directories of the stores, the system temporary directory is used by
+// default.
+func (b *Builder) Dir(dir string) *Builder {
+	b.dir = dir
+	return b
+}
+
+// Start creates the stores, bootstraps the cluster with one region replicated on all the stores, and starts the
+// stores.
+func (b *Builder) Start() (*Cluster, error) {
+	if b.count <= 0 {
+		return nil, errors.Errorf("invalid store count %d", b.count)
+	}
+	client := b.schedulerClient
+	if client == nil {
+		client = newMockScheduler(0, 1)
+	}
+	c := &Cluster{
+		cfg:             b.cfg,
+		schedulerClient: client,
+		nodes:           newNodes(client),
+		engines:         make(map[uint64]*engine_util.Engines),
+		dbPaths:         make(map[uint64]string),
+	}
+	if err := c.bootstrap(b.count, b.dir); err != nil {
+		c.Shutdown()
+		return nil, err
+	}
+	for storeID := range c.engines {
+		if err := c.StartStore(storeID); err != nil {
+			c.Shutdown()
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// Cluster is a running embedded cluster.
+type Cluster struct {
+	cfg             *config.Config
+	schedulerClient scheduler_client.Client
+	nodes           *nodes
+	engines         map[uint64]*engine_util.Engines
+	dbPaths         map[uint64]string
+}
+
+func (c *Cluster) bootstrap(count int, dir string) error {
+	ctx := context.TODO()
+	clusterID := c.schedulerClient.GetClusterID(ctx)
+	regionID, err := c.schedulerClient.AllocID(ctx)
+	if err != nil {
+		return err
+	}
+	firstRegion := &metapb.Region{
+		Id: regionID,
+		RegionEpoch: &metapb.RegionEpoch{
+			Version: raftstore.InitEpochVer,
+			ConfVer: raftstore.InitEpochConfVer,
+		},
+	}
+	var stores []*metapb.Store
+	for i := 0; i < count; i++ {
+		storeID, err := c.schedulerClient.AllocID(ctx)
+		if err != nil {
+			return err
+		}
+		peerID, err := c.schedulerClient.AllocID(ctx)
+		if err != nil {
+			return err
+		}
+		dbPath, err := ioutil.TempDir(dir, "tinykv-embed")
+		if err != nil {
+			return err
+		}
+		c.dbPaths[storeID] = dbPath
+		kvPath := filepath.Join(dbPath, "kv")
+		raftPath := filepath.Join(dbPath, "raft")
+		for _, path := range []string{kvPath, raftPath, filepath.Join(dbPath, "snap")} {
+			if err := os.MkdirAll(path, os.ModePerm); err != nil {
+				return err
+			}
+		}
+		raftDB := engine_util.CreateDBWithSync(raftPath, true, c.cfg.RaftSyncLog == config.SyncLogAlways)
+		kvDB := engine_util.CreateDB(kvPath, false)
+		engines := engine_util.NewEngines(kvDB, raftDB, kvPath, raftPath)
+		c.engines[storeID] = engines
+		if err := raftstore.BootstrapStore(engines, clusterID, storeID); err != nil {
+			return err
+		}
+		firstRegion.Peers = append(firstRegion.Peers, &metapb.Peer{Id: peerID, StoreId: storeID})
+		stores = append(stores, &metapb.Store{Id: storeID})
+	}
+	for _, engines := range c.engines {
+		if err := raftstore.PrepareBootstrapCluster(engines, firstRegion); err != nil {
+			return err
+		}
+	}
+	resp, err := c.schedulerClient.Bootstrap(ctx, stores[0])
+	if err != nil {
+		return err
+	}
+	if resp.Header != nil && resp.Header.Error != nil {
+		return errors.New(resp.Header.Error.String())
+	}
+	for _, store := range stores {
+		if err := c.schedulerClient.PutStore(ctx, store); err != nil {
+			return err
+		}
+		raftstore.ClearPrepareBootstrapState(c.engines[store.Id])
+	}
+	return nil
+}
+
+// StoreIDs returns the ids of all the stores, including the stopped ones.
+func (c *Cluster) StoreIDs() []uint64 {
+	storeIDs := make([]uint64, 0, len(c.engines))
+	for storeID := range c.engines {
+		storeIDs = append(storeIDs, storeID)
+	}
+	return storeIDs
+}
+
+// Engines returns the engines of the store, they can be read directly to check the local data of a store.
+func (c *Cluster) Engines(storeID uint64) *engine_util.Engines {
+	return c.engines[storeID]
+}
+
+// SchedulerClient returns the scheduler client used by the stores.
+func (c *Cluster) SchedulerClient() scheduler_client.Client {
+	return c.schedulerClient
+}
+
+// RunningStoreIDs returns the ids of the stores which are running.
+func (c *Cluster) RunningStoreIDs() []uint64 {
+	return c.nodes.storeIDs()
+}
+
+// AddFilter adds a filter of the raft messages between the stores, a message is dropped unless all the filters
+// deliver it.
+func (c *Cluster) AddFilter(filter Filter) {
+	c.nodes.addFilter(filter)
+}
+
+// ClearFilters removes all the filters added by AddFilter.
+func (c *Cluster) ClearFilters() {
+	c.nodes.clearFilters()
+}
+
+// StartStore starts a store which is stopped.
+func (c *Cluster) StartStore(storeID uint64) error {
+	engines, ok := c.engines[storeID]
+	if !ok {
+		return errors.Errorf("store %d not found", storeID)
+	}
+	cfg := *c.cfg
+	cfg.DBPath = c.dbPaths[storeID]
+	return c.nodes.runStore(&cfg, engines)
+}
+
+// StopStore stops a running store, its data is kept.
+func (c *Cluster) StopStore(storeID uint64) {
+	c.nodes.stopStore(storeID)
+}
+
+// Shutdown stops all the stores and removes their data.
+func (c *Cluster) Shutdown() {
+	for _, storeID := range c.nodes.storeIDs() {
+		c.nodes.stopStore(storeID)
+	}
+	for _, engines := range c.engines {
+		engines.Close()
+	}
+	for _, dbPath := range c.dbPaths {
+		os.RemoveAll(dbPath)
+	}
+}
+
+// Put writes a key in the default CF through the leader of the region the key belongs to.
+func (c *Cluster) Put(key, value []byte) error {
+	_, err := c.Request(key, []*raft_cmdpb.Request{{
+		CmdType: raft_cmdpb.CmdType_Put,
+		Put:     &raft_cmdpb.PutRequest{Cf: engine_util.CfDefault, Key: key, Value: value},
+	}}, 5*time.Second)
+	return err
+}
+
+// Get reads a key in the default CF through the leader of the region the key belongs to.
+func (c *Cluster) Get(key []byte) ([]byte, error) {
+	resp, err := c.Request(key, []*raft_cmdpb.Request{{
+		CmdType: raft_cmdpb.CmdType_Get,
+		Get:     &raft_cmdpb.GetRequest{Cf: engine_util.CfDefault, Key: key},
+	}}, 5*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Responses[0].Get.Value, nil
+}
+
+// Delete deletes a key in the default CF through the leader of the region the key belongs to.
+func (c *Cluster) Delete(key []byte) error {
+	_, err := c.Request(key, []*raft_cmdpb.Request{{
+		CmdType: raft_cmdpb.CmdType_Delete,
+		Delete:  &raft_cmdpb.DeleteRequest{Cf: engine_util.CfDefault, Key: key},
+	}}, 5*time.Second)
+	return err
+}
+
+// Scan reads at most limit pairs in the default CF from startKey, going across the regions. Each region is read from
+// a snapshot of its leader.
+func (c *Cluster) Scan(startKey []byte, limit int) ([]KvPair, error) {
+	var pairs []KvPair
+	key := startKey
+	for len(pairs) < limit {
+		resp, txn, err := c.request(key, []*raft_cmdpb.Request{{
+			CmdType: raft_cmdpb.CmdType_Snap,
+			Snap:    &raft_cmdpb.SnapRequest{},
+		}}, 5*time.Second)
+		if err != nil {
+			return nil, err
+		}
+		region := resp.Responses[0].GetSnap().Region
+		iter := raft_storage.NewRegionReader(txn, *region).IterCF(engine_util.CfDefault)
+		for iter.Seek(key); iter.Valid() && len(pairs) < limit; iter.Next() {
+			value, err := iter.Item().ValueCopy(nil)
+			if err != nil {
+				iter.Close()
+				txn.Discard()
+				return nil, err
+			}
+			pairs = append(pairs, KvPair{Key: iter.Item().KeyCopy(nil), Value: value})
+		}
+		iter.Close()
+		txn.Discard()
+		key = region.EndKey
+		if len(key) == 0 {
+			break
+		}
+	}
+	return pairs, nil
+}
+
+// KvPair is a pair returned by Scan.
+type KvPair struct {
+	Key   []byte
+	Value []byte
+}
+
+// Request sends the requests to the leader of the region the key belongs to, and retries on retryable errors until
+// timeout.
+func (c *Cluster) Request(key []byte, reqs []*raft_cmdpb.Request, timeout time.Duration) (*raft_cmdpb.RaftCmdResponse, error) {
+	resp, _, err := c.request(key, reqs, timeout)
+	return resp, err
+}
+
+func (c *Cluster) request(key []byte, reqs []*raft_cmdpb.Request, timeout time.Duration) (*raft_cmdpb.RaftCmdResponse, *badger.Txn, error) {
+	deadline := time.Now().Add(timeout)
+	var lastErr error
+	for time.Now().Before(deadline) {
+		region, leader, err := c.schedulerClient.GetRegion(context.TODO(), key)
+		if err != nil || region == nil || leader == nil {
+			lastErr = errors.Errorf("no leader for key %q", key)
+			time.Sleep(100 * time.Millisecond)
+			continue
+		}
+		req := &raft_cmdpb.RaftCmdRequest{
+			Header:   &raft_cmdpb.RaftRequestHeader{RegionId: region.Id, RegionEpoch: region.RegionEpoch, Peer: leader},
+			Requests: reqs,
+		}
+		resp, txn := c.nodes.call(leader.StoreId, req, time.Second)
+		if resp == nil {
+			lastErr = errors.Errorf("request to store %d timeout", leader.StoreId)
+			continue
+		}
+		if resp.Header.Error != nil {
+			lastErr = errors.New(resp.Header.Error.String())
+			time.Sleep(100 * time.Millisecond)
+			continue
+		}
+		if len(resp.Responses) != len(reqs) {
+			return nil, nil, errors.Errorf("responses count %d is not equal to requests count %d", len(resp.Responses), len(reqs))
+		}
+		return resp, txn, nil
+	}
+	return nil, nil, errors.Annotate(lastErr, "request timeout")
+}
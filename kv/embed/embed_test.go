@@ -0,0 +1,53 @@
+package embed
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvalidStoreCount(t *testing.T) {
+	_, err := NewBuilder(0).Start()
+	assert.NotNil(t, err)
+}
+
+func TestStartStop2B(t *testing.T) {
+	// Remove the skip once the raftstore of project 2B is implemented, the stores can't elect a leader before.
+	t.Skip("needs the raftstore of project 2B")
+
+	dir, err := ioutil.TempDir("", "tinykv-embed-test")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	cluster, err := NewBuilder(3).Dir(dir).Start()
+	assert.Nil(t, err)
+	storeIDs := cluster.StoreIDs()
+	assert.Len(t, storeIDs, 3)
+	assert.ElementsMatch(t, storeIDs, cluster.RunningStoreIDs())
+
+	assert.Nil(t, cluster.Put([]byte("k1"), []byte("v1")))
+	value, err := cluster.Get([]byte("k1"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("v1"), value)
+
+	// a stopped store keeps its data and catches up once it's started again
+	cluster.StopStore(storeIDs[0])
+	assert.Len(t, cluster.RunningStoreIDs(), 2)
+	assert.Nil(t, cluster.Put([]byte("k2"), []byte("v2")))
+	assert.Nil(t, cluster.StartStore(storeIDs[0]))
+	assert.Len(t, cluster.RunningStoreIDs(), 3)
+	assert.NotNil(t, cluster.StartStore(0))
+	assert.Nil(t, cluster.Delete([]byte("k1")))
+	pairs, err := cluster.Scan(nil, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []KvPair{{Key: []byte("k2"), Value: []byte("v2")}}, pairs)
+
+	cluster.Shutdown()
+	assert.Len(t, cluster.RunningStoreIDs(), 0)
+	// the data directories are removed
+	infos, err := ioutil.ReadDir(dir)
+	assert.Nil(t, err)
+	assert.Len(t, infos, 0)
+}
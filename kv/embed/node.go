@@ -0,0 +1,150 @@
+package embed
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/Connor1996/badger"
+	"github.com/pingcap-incubator/tinykv/kv/config"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/message"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/scheduler_client"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/snap"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
+	"github.com/pingcap/errors"
+)
+
+// Filter decides whether a raft message between the stores is delivered, it's used to simulate network failures.
+type Filter func(msg *raft_serverpb.RaftMessage) bool
+
+type store struct {
+	node    *raftstore.Node
+	router  message.RaftRouter
+	snapMgr *snap.SnapManager
+}
+
+// nodes runs the stores of a cluster and delivers the raft messages between them in memory.
+type nodes struct {
+	sync.RWMutex
+
+	schedulerClient scheduler_client.Client
+	stores          map[uint64]*store
+	filters         []Filter
+}
+
+func newNodes(schedulerClient scheduler_client.Client) *nodes {
+	return &nodes{
+		schedulerClient: schedulerClient,
+		stores:          make(map[uint64]*store),
+	}
+}
+
+func (n *nodes) runStore(cfg *config.Config, engines *engine_util.Engines) error {
+	router, system := raftstore.CreateRaftstore(cfg)
+	snapMgr := snap.NewSnapManager(cfg.SnapDir())
+	node := raftstore.NewNode(system, cfg, n.schedulerClient)
+	if err := node.Start(context.TODO(), engines, n, snapMgr); err != nil {
+		return err
+	}
+	n.Lock()
+	defer n.Unlock()
+	n.stores[node.GetStoreID()] = &store{node: node, router: router, snapMgr: snapMgr}
+	return nil
+}
+
+func (n *nodes) stopStore(storeID uint64) {
+	n.Lock()
+	s := n.stores[storeID]
+	delete(n.stores, storeID)
+	n.Unlock()
+	if s != nil {
+		s.node.Stop()
+	}
+}
+
+func (n *nodes) storeIDs() []uint64 {
+	n.RLock()
+	defer n.RUnlock()
+	storeIDs := make([]uint64, 0, len(n.stores))
+	for storeID := range n.stores {
+		storeIDs = append(storeIDs, storeID)
+	}
+	return storeIDs
+}
+
+func (n *nodes) addFilter(filter Filter) {
+	n.Lock()
+	defer n.Unlock()
+	n.filters = append(n.filters, filter)
+}
+
+func (n *nodes) clearFilters() {
+	n.Lock()
+	defer n.Unlock()
+	n.filters = nil
+}
+
+// Send implements raftstore.Transport, a snapshot is copied to the snapshot manager of the receiving store first.
+func (n *nodes) Send(msg *raft_serverpb.RaftMessage) error {
+	n.RLock()
+	defer n.RUnlock()
+	for _, filter := range n.filters {
+		if !filter(msg) {
+			return errors.Errorf("message %+v is dropped", msg)
+		}
+	}
+	from, to := n.stores[msg.GetFromPeer().GetStoreId()], n.stores[msg.GetToPeer().GetStoreId()]
+	if from == nil || to == nil {
+		return errors.Errorf("store %d or %d is stopped", msg.GetFromPeer().GetStoreId(), msg.GetToPeer().GetStoreId())
+	}
+	if msg.GetMessage().GetMsgType() == eraftpb.MessageType_MsgSnapshot {
+		if err := copySnapshot(from.snapMgr, to.snapMgr, msg.Message.Snapshot); err != nil {
+			return err
+		}
+	}
+	return to.router.SendRaftMessage(msg)
+}
+
+func copySnapshot(from, to *snap.SnapManager, snapshot *eraftpb.Snapshot) error {
+	key, err := snap.SnapKeyFromSnap(snapshot)
+	if err != nil {
+		return err
+	}
+	from.Register(key, snap.SnapEntrySending)
+	defer from.Deregister(key, snap.SnapEntrySending)
+	fromSnap, err := from.GetSnapshotForSending(key)
+	if err != nil {
+		return err
+	}
+	to.Register(key, snap.SnapEntryReceiving)
+	defer to.Deregister(key, snap.SnapEntryReceiving)
+	toSnap, err := to.GetSnapshotForReceiving(key, snapshot.GetData())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(toSnap, fromSnap); err != nil {
+		return err
+	}
+	return toSnap.Save()
+}
+
+// call sends the request to the store and waits for the response, it returns nil if the store is stopped or doesn't
+// respond within timeout.
+func (n *nodes) call(storeID uint64, req *raft_cmdpb.RaftCmdRequest, timeout time.Duration) (*raft_cmdpb.RaftCmdResponse, *badger.Txn) {
+	n.RLock()
+	s := n.stores[storeID]
+	n.RUnlock()
+	if s == nil {
+		return nil, nil
+	}
+	cb := message.NewCallback()
+	if err := s.router.SendRaftCommand(req, cb); err != nil {
+		return nil, nil
+	}
+	return cb.WaitRespWithTimeout(timeout), cb.Txn
+}
@@ -0,0 +1,206 @@
+package embed
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/btree"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/schedulerpb"
+	"github.com/pingcap/errors"
+)
+
+type regionItem struct {
+	region metapb.Region
+}
+
+// Less returns true if the region start key is less than the other.
+func (r *regionItem) Less(other btree.Item) bool {
+	return bytes.Compare(r.region.GetStartKey(), other.(*regionItem).region.GetStartKey()) < 0
+}
+
+func (r *regionItem) contains(key []byte) bool {
+	start, end := r.region.GetStartKey(), r.region.GetEndKey()
+	return bytes.Compare(key, start) >= 0 && (len(end) == 0 || bytes.Compare(key, end) < 0)
+}
+
+// mockScheduler is the scheduler used by a cluster which isn't given one. It allocates ids and keeps the regions and
+// leaders reported by the region heartbeats so requests can be routed, but never schedules anything.
+type mockScheduler struct {
+	sync.RWMutex
+
+	clusterID    uint64
+	baseID       uint64
+	bootstrapped bool
+	stores       map[uint64]*metapb.Store
+	regions      *btree.BTree            // startKey -> region
+	leaders      map[uint64]*metapb.Peer // regionID -> leader
+}
+
+func newMockScheduler(clusterID, baseID uint64) *mockScheduler {
+	return &mockScheduler{
+		clusterID: clusterID,
+		baseID:    baseID,
+		stores:    make(map[uint64]*metapb.Store),
+		regions:   btree.New(2),
+		leaders:   make(map[uint64]*metapb.Peer),
+	}
+}
+
+func (m *mockScheduler) GetClusterID(ctx context.Context) uint64 {
+	return m.clusterID
+}
+
+func (m *mockScheduler) AllocID(ctx context.Context) (uint64, error) {
+	m.Lock()
+	defer m.Unlock()
+	id := m.baseID
+	m.baseID++
+	return id, nil
+}
+
+func (m *mockScheduler) Bootstrap(ctx context.Context, store *metapb.Store) (*schedulerpb.BootstrapResponse, error) {
+	m.Lock()
+	defer m.Unlock()
+	resp := &schedulerpb.BootstrapResponse{Header: &schedulerpb.ResponseHeader{ClusterId: m.clusterID}}
+	if m.bootstrapped {
+		resp.Header.Error = &schedulerpb.Error{
+			Type:    schedulerpb.ErrorType_ALREADY_BOOTSTRAPPED,
+			Message: "cluster is already bootstrapped",
+		}
+		return resp, nil
+	}
+	m.stores[store.GetId()] = store
+	m.bootstrapped = true
+	return resp, nil
+}
+
+func (m *mockScheduler) IsBootstrapped(ctx context.Context) (bool, error) {
+	m.RLock()
+	defer m.RUnlock()
+	return m.bootstrapped, nil
+}
+
+func (m *mockScheduler) PutStore(ctx context.Context, store *metapb.Store) error {
+	m.Lock()
+	defer m.Unlock()
+	if !m.bootstrapped {
+		return errors.New("not bootstrapped")
+	}
+	m.stores[store.GetId()] = store
+	return nil
+}
+
+func (m *mockScheduler) GetStore(ctx context.Context, storeID uint64) (*metapb.Store, error) {
+	m.RLock()
+	defer m.RUnlock()
+	store, ok := m.stores[storeID]
+	if !ok {
+		return nil, errors.Errorf("store %d not found", storeID)
+	}
+	return store, nil
+}
+
+func (m *mockScheduler) GetRegion(ctx context.Context, key []byte) (*metapb.Region, *metapb.Peer, error) {
+	m.RLock()
+	defer m.RUnlock()
+	item := m.findRegion(key)
+	if item == nil {
+		return nil, nil, nil
+	}
+	region := item.region
+	return &region, m.leaders[region.Id], nil
+}
+
+func (m *mockScheduler) GetRegionByID(ctx context.Context, regionID uint64) (*metapb.Region, *metapb.Peer, error) {
+	m.RLock()
+	defer m.RUnlock()
+	var region *metapb.Region
+	m.regions.Ascend(func(i btree.Item) bool {
+		if r := i.(*regionItem).region; r.Id == regionID {
+			region = &r
+			return false
+		}
+		return true
+	})
+	if region == nil {
+		return nil, nil, nil
+	}
+	return region, m.leaders[regionID], nil
+}
+
+func (m *mockScheduler) AskSplit(ctx context.Context, region *metapb.Region) (*schedulerpb.AskSplitResponse, error) {
+	resp := &schedulerpb.AskSplitResponse{Header: &schedulerpb.ResponseHeader{ClusterId: m.clusterID}}
+	curRegion, _, _ := m.GetRegionByID(ctx, region.GetId())
+	if curRegion != nil && util.IsEpochStale(region.RegionEpoch, curRegion.RegionEpoch) {
+		return resp, errors.New("epoch is stale")
+	}
+	resp.NewRegionId, _ = m.AllocID(ctx)
+	for range region.GetPeers() {
+		id, _ := m.AllocID(ctx)
+		resp.NewPeerIds = append(resp.NewPeerIds, id)
+	}
+	return resp, nil
+}
+
+func (m *mockScheduler) StoreHeartbeat(ctx context.Context, stats *schedulerpb.StoreStats) (*schedulerpb.StoreHeartbeatResponse, error) {
+	return &schedulerpb.StoreHeartbeatResponse{
+		Header:        &schedulerpb.ResponseHeader{ClusterId: m.clusterID},
+		SchedulerTime: time.Now().UnixNano(),
+	}, nil
+}
+
+// RegionHeartbeat keeps the reported region unless a region it overlaps has a newer epoch, and replaces the regions
+// it overlaps, which are the stale halves of a split or merge.
+func (m *mockScheduler) RegionHeartbeat(req *schedulerpb.RegionHeartbeatRequest) error {
+	m.Lock()
+	defer m.Unlock()
+	region := req.GetRegion()
+	var overlaps []*regionItem
+	m.regions.Ascend(func(i btree.Item) bool {
+		item := i.(*regionItem)
+		if item.region.Id == region.Id || overlap(&item.region, region) {
+			overlaps = append(overlaps, item)
+		}
+		return true
+	})
+	for _, item := range overlaps {
+		if item.region.Id == region.Id && util.IsEpochStale(region.RegionEpoch, item.region.RegionEpoch) ||
+			item.region.Id != region.Id && region.RegionEpoch.Version <= item.region.RegionEpoch.Version {
+			return errors.New("epoch is stale")
+		}
+	}
+	for _, item := range overlaps {
+		m.regions.Delete(item)
+		delete(m.leaders, item.region.Id)
+	}
+	m.regions.ReplaceOrInsert(&regionItem{region: *region})
+	m.leaders[region.Id] = req.Leader
+	return nil
+}
+
+// SetRegionHeartbeatResponseHandler does nothing, there is no heartbeat response as nothing is scheduled.
+func (m *mockScheduler) SetRegionHeartbeatResponseHandler(storeID uint64, h func(*schedulerpb.RegionHeartbeatResponse)) {
+}
+
+func (m *mockScheduler) Close() {}
+
+func (m *mockScheduler) findRegion(key []byte) *regionItem {
+	var result *regionItem
+	m.regions.DescendLessOrEqual(&regionItem{region: metapb.Region{StartKey: key}}, func(i btree.Item) bool {
+		result = i.(*regionItem)
+		return false
+	})
+	if result == nil || !result.contains(key) {
+		return nil
+	}
+	return result
+}
+
+func overlap(a, b *metapb.Region) bool {
+	return (len(a.EndKey) == 0 || bytes.Compare(b.StartKey, a.EndKey) < 0) &&
+		(len(b.EndKey) == 0 || bytes.Compare(a.StartKey, b.EndKey) < 0)
+}
@@ -0,0 +1,42 @@
+package embed
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/schedulerpb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMockSchedulerRegionHeartbeat(t *testing.T) {
+	m := newMockScheduler(0, 1)
+	ctx := context.TODO()
+	heartbeat := func(id uint64, start, end string, version uint64) error {
+		return m.RegionHeartbeat(&schedulerpb.RegionHeartbeatRequest{
+			Region: &metapb.Region{Id: id, StartKey: []byte(start), EndKey: []byte(end),
+				RegionEpoch: &metapb.RegionEpoch{Version: version, ConfVer: 1}},
+			Leader: &metapb.Peer{Id: id + 100, StoreId: 1},
+		})
+	}
+
+	assert.Nil(t, heartbeat(1, "", "", 1))
+	region, leader, err := m.GetRegion(ctx, []byte("k"))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), region.Id)
+	assert.Equal(t, uint64(101), leader.Id)
+
+	// the split halves replace the region they overlap
+	assert.Nil(t, heartbeat(2, "", "m", 2))
+	assert.Nil(t, heartbeat(1, "m", "", 2))
+	region, _, _ = m.GetRegion(ctx, []byte("a"))
+	assert.Equal(t, uint64(2), region.Id)
+	region, _, _ = m.GetRegion(ctx, []byte("z"))
+	assert.Equal(t, uint64(1), region.Id)
+
+	// a stale report is rejected
+	assert.NotNil(t, heartbeat(1, "", "", 1))
+	assert.NotNil(t, heartbeat(3, "a", "b", 1))
+	region, _, _ = m.GetRegionByID(ctx, 2)
+	assert.Equal(t, []byte("m"), region.EndKey)
+}
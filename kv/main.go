@@ -2,6 +2,7 @@ package main
 
 import (
 	"flag"
+	"io/ioutil"
 	"net"
 	_ "net/http/pprof"
 	"os"
@@ -13,8 +14,10 @@ import (
 	"github.com/pingcap-incubator/tinykv/kv/config"
 	"github.com/pingcap-incubator/tinykv/kv/server"
 	"github.com/pingcap-incubator/tinykv/kv/storage"
+	"github.com/pingcap-incubator/tinykv/kv/storage/audit_storage"
 	"github.com/pingcap-incubator/tinykv/kv/storage/raft_storage"
 	"github.com/pingcap-incubator/tinykv/kv/storage/standalone_storage"
+	"github.com/pingcap-incubator/tinykv/kv/transaction/mvcc"
 	"github.com/pingcap-incubator/tinykv/log"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/tinykvpb"
 	"google.golang.org/grpc"
@@ -22,10 +25,16 @@ import (
 )
 
 var (
-	schedulerAddr = flag.String("scheduler", "", "scheduler address")
+	schedulerAddr = flag.String("scheduler", "", "scheduler addresses, separated by commas")
+	tokenFile     = flag.String("scheduler-token-file", "", "file of the token authenticating the store to the scheduler, sent in plaintext")
 	storeAddr     = flag.String("addr", "", "store address")
 	dbPath        = flag.String("path", "", "directory path of db")
+	kvPath        = flag.String("kv-path", "", "directory path of the kv engine, the kv subdirectory of path by default")
+	raftPath      = flag.String("raft-path", "", "directory path of the raft engine, the raft subdirectory of path by default")
+	snapPath      = flag.String("snap-path", "", "directory path of the snapshots, the snap subdirectory of path by default")
 	logLevel      = flag.String("loglevel", "", "the level of log")
+	syncLog       = flag.String("sync-log", "", "how the raft logs are synced: always, batched or periodic")
+	syncLogPeriod = flag.Duration("sync-log-interval", 0, "the interval of syncing the raft logs periodically")
 )
 
 func main() {
@@ -34,30 +43,85 @@ func main() {
 	if *schedulerAddr != "" {
 		conf.SchedulerAddr = *schedulerAddr
 	}
+	if *tokenFile != "" {
+		token, err := ioutil.ReadFile(*tokenFile)
+		if err != nil {
+			log.Fatalf("read scheduler token: %v", err)
+		}
+		conf.SchedulerToken = strings.TrimSpace(string(token))
+	}
 	if *storeAddr != "" {
 		conf.StoreAddr = *storeAddr
 	}
 	if *dbPath != "" {
 		conf.DBPath = *dbPath
 	}
+	if *kvPath != "" {
+		conf.KvPath = *kvPath
+	}
+	if *raftPath != "" {
+		conf.RaftPath = *raftPath
+	}
+	if *snapPath != "" {
+		conf.SnapPath = *snapPath
+	}
 	if *logLevel != "" {
 		conf.LogLevel = *logLevel
 	}
+	if *syncLog != "" {
+		conf.RaftSyncLog = *syncLog
+	}
+	if *syncLogPeriod != 0 {
+		conf.RaftSyncLogInterval = *syncLogPeriod
+	}
 
 	log.SetLevelByString(conf.LogLevel)
 	log.SetFlags(log.Ldate | log.Ltime | log.Lmicroseconds | log.Lshortfile)
 	log.Infof("Server started with conf %+v", conf)
 
 	var storage storage.Storage
+	var raftStorage *raft_storage.RaftStorage
 	if conf.Raft {
-		storage = raft_storage.NewRaftStorage(conf)
+		raftStorage = raft_storage.NewRaftStorage(conf)
+		storage = raftStorage
 	} else {
 		storage = standalone_storage.NewStandAloneStorage(conf)
 	}
+	if len(conf.AuditPrefixes) > 0 {
+		storage = audit_storage.NewAuditStorage(storage, conf)
+	}
 	if err := storage.Start(); err != nil {
 		log.Fatal(err)
 	}
 	server := server.NewServer(storage)
+	if raftStorage != nil {
+		server.KeyFilters = raftStorage.KeyFilters()
+		server.LockIndex = raftStorage.LockIndex()
+		server.KeyChecker = raftStorage.KeyChecker()
+		server.ReadyStats = raftStorage.ReadyStats()
+		if rawLatches := raftStorage.RawLatches(); rawLatches != nil {
+			server.Latches = rawLatches
+		}
+	}
+	server.AsyncResolveLockThreshold = conf.AsyncResolveLockThreshold
+	server.EnableFailPoints = conf.EnableFailPoints
+	server.RawSoftDeleteWindow = conf.RawSoftDeleteWindow
+	if conf.RegionBreakerMinErrors > 0 {
+		server.EnableRegionBreaker(conf.RegionBreakerMinErrors, conf.RegionBreakerErrorRatio,
+			conf.RegionBreakerWindow, conf.RegionBreakerCooldown)
+	}
+	if conf.TxnWriteBatchSize > 0 {
+		server.EnableWriteBatching(conf.TxnWriteBatchSize)
+	}
+	if conf.CopCacheCapacity > 0 {
+		server.EnableCopCache(conf.CopCacheCapacity)
+	}
+	if len(conf.ReadCommittedPrefixes) > 0 {
+		server.Isolation = mvcc.NewIsolationPolicy(toBytes(conf.ReadCommittedPrefixes))
+	}
+	if len(conf.RawKeyPrefixes) > 0 || len(conf.TxnKeyPrefixes) > 0 {
+		server.EnableKeyModeGuard(toBytes(conf.RawKeyPrefixes), toBytes(conf.TxnKeyPrefixes))
+	}
 
 	var alivePolicy = keepalive.EnforcementPolicy{
 		MinTime:             2 * time.Second, // If a client pings more than once every 2 seconds, terminate the connection
@@ -68,7 +132,9 @@ func main() {
 		grpc.KeepaliveEnforcementPolicy(alivePolicy),
 		grpc.InitialWindowSize(1<<30),
 		grpc.InitialConnWindowSize(1<<30),
-		grpc.MaxRecvMsgSize(10*1024*1024),
+		grpc.MaxRecvMsgSize(int(config.GrpcMaxMsgSize)),
+		grpc.StatsHandler(server.SlaStatsHandler()),
+		grpc.UnaryInterceptor(server.UnaryInterceptor),
 	)
 	tinykvpb.RegisterTinyKvServer(grpcServer, server)
 	listenAddr := conf.StoreAddr[strings.IndexByte(conf.StoreAddr, ':'):]
@@ -85,6 +151,14 @@ func main() {
 	log.Info("Server stopped.")
 }
 
+func toBytes(strs []string) [][]byte {
+	bs := make([][]byte, 0, len(strs))
+	for _, str := range strs {
+		bs = append(bs, []byte(str))
+	}
+	return bs
+}
+
 func handleSignal(grpcServer *grpc.Server) {
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh,
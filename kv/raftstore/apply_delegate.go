@@ -0,0 +1,61 @@
+package raftstore
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
+)
+
+// ApplyContext is the state a custom command is applied with.
+type ApplyContext struct {
+	Region *metapb.Region
+	// index and term of the entry of the command
+	Index uint64
+	Term  uint64
+	// The engines must only be read, writes go to KvWB, which is written along with the apply state of the entry.
+	Engines *engine_util.Engines
+	KvWB    *engine_util.WriteBatch
+}
+
+// ApplyDelegate applies the custom commands registered with its name, which lets embedders replicate their own
+// state machines through the raft groups of the store. It's called in the raftstore goroutines in log order, so it
+// must be deterministic, must not block, and must only write through ctx.KvWB. An error is returned to the proposer
+// and doesn't stop the region.
+type ApplyDelegate interface {
+	Apply(ctx *ApplyContext, data []byte) ([]byte, error)
+}
+
+// ApplyDelegateRegistry keeps the apply delegates of a store. Delegates must be registered before the store starts
+// and the same on all stores, or the replicas of a region diverge.
+type ApplyDelegateRegistry struct {
+	sync.RWMutex
+	delegates map[string]ApplyDelegate
+}
+
+func NewApplyDelegateRegistry() *ApplyDelegateRegistry {
+	return &ApplyDelegateRegistry{delegates: make(map[string]ApplyDelegate)}
+}
+
+func (r *ApplyDelegateRegistry) Register(name string, delegate ApplyDelegate) {
+	r.Lock()
+	defer r.Unlock()
+	r.delegates[name] = delegate
+}
+
+// apply applies the custom command with the delegate registered with its name.
+func (r *ApplyDelegateRegistry) apply(ctx *ApplyContext, req *raft_cmdpb.CustomRequest) (*raft_cmdpb.CustomResponse, error) {
+	r.RLock()
+	delegate, ok := r.delegates[req.GetName()]
+	r.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no apply delegate is registered for custom command %q", req.GetName())
+	}
+	data, err := delegate.Apply(ctx, req.GetData())
+	if err != nil {
+		return nil, err
+	}
+	return &raft_cmdpb.CustomResponse{Data: data}, nil
+}
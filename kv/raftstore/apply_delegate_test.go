@@ -0,0 +1,30 @@
+package raftstore
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
+	"github.com/stretchr/testify/assert"
+)
+
+type counterDelegate struct{}
+
+func (counterDelegate) Apply(ctx *ApplyContext, data []byte) ([]byte, error) {
+	ctx.KvWB.SetCF(engine_util.CfDefault, []byte("counter"), data)
+	return append([]byte("ok:"), data...), nil
+}
+
+func TestApplyDelegateRegistry(t *testing.T) {
+	r := NewApplyDelegateRegistry()
+	r.Register("counter", counterDelegate{})
+
+	ctx := &ApplyContext{KvWB: new(engine_util.WriteBatch)}
+	resp, err := r.apply(ctx, &raft_cmdpb.CustomRequest{Name: "counter", Data: []byte("1")})
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("ok:1"), resp.Data)
+	assert.Equal(t, 1, ctx.KvWB.Len())
+
+	_, err = r.apply(ctx, &raft_cmdpb.CustomRequest{Name: "unknown"})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,171 @@
+package raftstore
+
+import (
+	"sync"
+
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/cdc"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/keycheck"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/keyfilter"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/lockindex"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
+	"github.com/pingcap-incubator/tinykv/kv/util/failpoint"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
+)
+
+// ApplyObserver is called around the requests of the commands applied on a store, for features like metrics,
+// validation or change capture which need to see every applied request. PreApply is called before a request is
+// applied, an error rejects the request and is returned to the proposer. PostApply is called after the request is
+// applied successfully, before the write batch of the command is written. Like ApplyDelegate, observers are called
+// in the raftstore goroutines in log order, so they must be deterministic, must not block, and must only write
+// through ctx.KvWB.
+type ApplyObserver interface {
+	PreApply(ctx *ApplyContext, req *raft_cmdpb.Request) error
+	PostApply(ctx *ApplyContext, req *raft_cmdpb.Request, resp *raft_cmdpb.Response)
+}
+
+// ApplyObserverRegistry keeps the chain of apply observers of a store, each observing some types of requests.
+// Observers must be registered before the store starts and the same on all stores.
+type ApplyObserverRegistry struct {
+	sync.RWMutex
+	// the observers of each request type, in the order they are registered
+	observers map[raft_cmdpb.CmdType][]ApplyObserver
+}
+
+func NewApplyObserverRegistry() *ApplyObserverRegistry {
+	return &ApplyObserverRegistry{observers: make(map[raft_cmdpb.CmdType][]ApplyObserver)}
+}
+
+// Register adds the observer to the end of the chain of the request types, of all the types if none is given.
+func (r *ApplyObserverRegistry) Register(observer ApplyObserver, types ...raft_cmdpb.CmdType) {
+	r.Lock()
+	defer r.Unlock()
+	if len(types) == 0 {
+		for tp := range raft_cmdpb.CmdType_name {
+			types = append(types, raft_cmdpb.CmdType(tp))
+		}
+	}
+	for _, tp := range types {
+		r.observers[tp] = append(r.observers[tp], observer)
+	}
+}
+
+// chain appends the observers of other to the chains of r.
+func (r *ApplyObserverRegistry) chain(other *ApplyObserverRegistry) {
+	r.Lock()
+	defer r.Unlock()
+	other.RLock()
+	defer other.RUnlock()
+	for tp, observers := range other.observers {
+		r.observers[tp] = append(r.observers[tp], observers...)
+	}
+}
+
+// preApply calls the observers of the request in order, it stops at the first error and returns it.
+func (r *ApplyObserverRegistry) preApply(ctx *ApplyContext, req *raft_cmdpb.Request) error {
+	if err := failpoint.Inject("apply/pre-apply"); err != nil {
+		return err
+	}
+	r.RLock()
+	defer r.RUnlock()
+	for _, observer := range r.observers[req.GetCmdType()] {
+		if err := observer.PreApply(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// postApply calls the observers of the request in order.
+func (r *ApplyObserverRegistry) postApply(ctx *ApplyContext, req *raft_cmdpb.Request, resp *raft_cmdpb.Response) {
+	failpoint.Inject("apply/post-apply")
+	r.RLock()
+	defer r.RUnlock()
+	for _, observer := range r.observers[req.GetCmdType()] {
+		observer.PostApply(ctx, req, resp)
+	}
+}
+
+// keyRangeObserver rejects the requests on the keys out of the region, which are stale after a split or merge.
+type keyRangeObserver struct{}
+
+func (keyRangeObserver) PreApply(ctx *ApplyContext, req *raft_cmdpb.Request) error {
+	var key []byte
+	switch req.GetCmdType() {
+	case raft_cmdpb.CmdType_Get:
+		key = req.GetGet().GetKey()
+	case raft_cmdpb.CmdType_Put:
+		key = req.GetPut().GetKey()
+	case raft_cmdpb.CmdType_Delete:
+		key = req.GetDelete().GetKey()
+	default:
+		return nil
+	}
+	return util.CheckKeyInRegion(key, ctx.Region)
+}
+
+func (keyRangeObserver) PostApply(*ApplyContext, *raft_cmdpb.Request, *raft_cmdpb.Response) {}
+
+// keyFilterApplyObserver adds the keys of the applied puts to the key filters.
+type keyFilterApplyObserver struct {
+	filters *keyfilter.KeyFilters
+}
+
+func (o keyFilterApplyObserver) PreApply(*ApplyContext, *raft_cmdpb.Request) error {
+	return nil
+}
+
+func (o keyFilterApplyObserver) PostApply(ctx *ApplyContext, req *raft_cmdpb.Request, _ *raft_cmdpb.Response) {
+	put := req.GetPut()
+	o.filters.OnPut(ctx.Region.GetId(), put.GetCf(), put.GetKey())
+}
+
+// keyViolationsKept is the number of the latest key layout violations kept for the debug API.
+const keyViolationsKept = 128
+
+// keyCheckApplyObserver checks the keys of the applied puts against the TinySQL key layout.
+type keyCheckApplyObserver struct {
+	checker *keycheck.KeyChecker
+}
+
+func (o keyCheckApplyObserver) PreApply(*ApplyContext, *raft_cmdpb.Request) error {
+	return nil
+}
+
+func (o keyCheckApplyObserver) PostApply(ctx *ApplyContext, req *raft_cmdpb.Request, _ *raft_cmdpb.Response) {
+	put := req.GetPut()
+	o.checker.Check(ctx.Region.GetId(), put.GetCf(), put.GetKey())
+}
+
+// changeCaptureApplyObserver captures the applied puts of the regions with CDC enabled.
+type changeCaptureApplyObserver struct {
+	capturer *cdc.Capturer
+}
+
+func (o changeCaptureApplyObserver) PreApply(*ApplyContext, *raft_cmdpb.Request) error {
+	return nil
+}
+
+func (o changeCaptureApplyObserver) PostApply(ctx *ApplyContext, req *raft_cmdpb.Request, _ *raft_cmdpb.Response) {
+	put := req.GetPut()
+	o.capturer.OnPut(ctx.Region.GetId(), ctx.Index, put.GetCf(), put.GetKey(), put.GetValue())
+}
+
+// lockIndexApplyObserver applies the lock CF writes of the applied puts and deletes to the lock index.
+type lockIndexApplyObserver struct {
+	index *lockindex.LockIndex
+}
+
+func (o lockIndexApplyObserver) PreApply(*ApplyContext, *raft_cmdpb.Request) error {
+	return nil
+}
+
+func (o lockIndexApplyObserver) PostApply(ctx *ApplyContext, req *raft_cmdpb.Request, _ *raft_cmdpb.Response) {
+	switch req.GetCmdType() {
+	case raft_cmdpb.CmdType_Put:
+		put := req.GetPut()
+		o.index.OnPut(ctx.Region.GetId(), put.GetCf(), put.GetKey(), put.GetValue())
+	case raft_cmdpb.CmdType_Delete:
+		del := req.GetDelete()
+		o.index.OnDelete(ctx.Region.GetId(), del.GetCf(), del.GetKey())
+	}
+}
@@ -0,0 +1,52 @@
+package raftstore
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordObserver struct {
+	name    string
+	records *[]string
+}
+
+func (o recordObserver) PreApply(ctx *ApplyContext, req *raft_cmdpb.Request) error {
+	*o.records = append(*o.records, "pre "+o.name)
+	return nil
+}
+
+func (o recordObserver) PostApply(ctx *ApplyContext, req *raft_cmdpb.Request, resp *raft_cmdpb.Response) {
+	*o.records = append(*o.records, "post "+o.name)
+}
+
+func TestApplyObserverRegistry(t *testing.T) {
+	var records []string
+	r := NewApplyObserverRegistry()
+	r.Register(keyRangeObserver{}, raft_cmdpb.CmdType_Get, raft_cmdpb.CmdType_Put, raft_cmdpb.CmdType_Delete)
+	r.Register(recordObserver{name: "put", records: &records}, raft_cmdpb.CmdType_Put)
+	embedded := NewApplyObserverRegistry()
+	embedded.Register(recordObserver{name: "all", records: &records})
+	r.chain(embedded)
+
+	ctx := &ApplyContext{Region: &metapb.Region{Id: 1, StartKey: []byte("b"), EndKey: []byte("d")}}
+	put := &raft_cmdpb.Request{CmdType: raft_cmdpb.CmdType_Put, Put: &raft_cmdpb.PutRequest{Key: []byte("c")}}
+	assert.Nil(t, r.preApply(ctx, put))
+	r.postApply(ctx, put, &raft_cmdpb.Response{CmdType: raft_cmdpb.CmdType_Put})
+	assert.Equal(t, []string{"pre put", "pre all", "post put", "post all"}, records)
+
+	records = nil
+	snap := &raft_cmdpb.Request{CmdType: raft_cmdpb.CmdType_Snap}
+	assert.Nil(t, r.preApply(ctx, snap))
+	assert.Equal(t, []string{"pre all"}, records)
+
+	// a request out of the region is rejected before the other observers see it
+	records = nil
+	put.Put.Key = []byte("d")
+	err := r.preApply(ctx, put)
+	assert.IsType(t, &util.ErrKeyNotInRegion{}, err)
+	assert.Empty(t, records)
+}
@@ -0,0 +1,124 @@
+package raftstore
+
+import (
+	"sync"
+
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/message"
+)
+
+// applyTask applies the committed entries of a ready of a peer.
+type applyTask struct {
+	regionID uint64
+	peerID   uint64
+	// applies the entries and returns the index they are applied to, nil for the task marking the end of the tasks
+	// of a stopped peer
+	apply func() uint64
+}
+
+// applyWorker applies the committed entries of the peers in its own goroutines, so that a raft worker persists the
+// next readies meanwhile, see config.Config.RaftApplyWorkers. The tasks of a region are always run by the same
+// goroutine in the order they are scheduled. The index each task applies to is reported to its peer by a
+// MsgTypeApplied message, which advances the raft group with AdvanceApply. The tasks of a peer still queued when it's
+// destroyed are dropped, see stop.
+type applyWorker struct {
+	router *router
+	chs    []chan applyTask
+	// held by the goroutine of each channel while it applies a task
+	applying []sync.Mutex
+	// the stopped peers whose tasks are dropped
+	stoppedMu sync.Mutex
+	stopped   map[uint64]bool
+	closeCh   <-chan struct{}
+}
+
+func newApplyWorker(router *router, workers int, closeCh <-chan struct{}) *applyWorker {
+	w := &applyWorker{
+		router:   router,
+		chs:      make([]chan applyTask, workers),
+		applying: make([]sync.Mutex, workers),
+		stopped:  make(map[uint64]bool),
+		closeCh:  closeCh,
+	}
+	for i := range w.chs {
+		w.chs[i] = make(chan applyTask, 256)
+	}
+	return w
+}
+
+func (w *applyWorker) run(wg *sync.WaitGroup) {
+	for i := range w.chs {
+		wg.Add(1)
+		go w.loop(i, wg)
+	}
+}
+
+func (w *applyWorker) loop(i int, wg *sync.WaitGroup) {
+	defer wg.Done()
+	for {
+		select {
+		case <-w.closeCh:
+			return
+		case task := <-w.chs[i]:
+			if task.apply == nil {
+				// the tasks of the stopped peer scheduled before are all dropped
+				w.stoppedMu.Lock()
+				delete(w.stopped, task.peerID)
+				w.stoppedMu.Unlock()
+				continue
+			}
+			w.applying[i].Lock()
+			if w.isStopped(task.peerID) {
+				w.applying[i].Unlock()
+				continue
+			}
+			applied := task.apply()
+			w.applying[i].Unlock()
+			w.report(task.regionID, applied)
+		}
+	}
+}
+
+// report tells the peer the index its task applied to. It never blocks: the raft worker, which takes the peer
+// messages, may be blocked scheduling a task to this goroutine. If the peer messages are full, the report is sent by
+// another goroutine, it may then arrive after a later report of the peer, which makes it stale and dropped.
+func (w *applyWorker) report(regionID, applied uint64) {
+	msg := message.NewPeerMsg(message.MsgTypeApplied, regionID, applied)
+	// The error is ignored, the peer is destroyed if it's closed, and its tasks after this are dropped.
+	if err := w.router.trySend(regionID, msg); err != errPeerBusy {
+		return
+	}
+	go func() {
+		select {
+		case w.router.peerSender <- msg:
+		case <-w.closeCh:
+		}
+	}()
+}
+
+func (w *applyWorker) isStopped(peerID uint64) bool {
+	w.stoppedMu.Lock()
+	defer w.stoppedMu.Unlock()
+	return w.stopped[peerID]
+}
+
+// stop drops the tasks of the peer not applied yet, and returns once the task being applied, if any, is done. It's
+// called before the peer is destroyed, so none of its tasks writes the data of the region after it's cleaned up. The
+// task marking the end of the tasks of the peer is scheduled without holding the lock of the goroutine.
+func (w *applyWorker) stop(regionID, peerID uint64) {
+	w.stoppedMu.Lock()
+	w.stopped[peerID] = true
+	w.stoppedMu.Unlock()
+	i := regionID % uint64(len(w.chs))
+	w.applying[i].Lock()
+	w.applying[i].Unlock()
+	w.schedule(applyTask{regionID: regionID, peerID: peerID})
+}
+
+// schedule hands the task to the goroutine of its region. It blocks while the goroutine is busy with the earlier
+// tasks, which throttles the raft worker to the throughput of the apply.
+func (w *applyWorker) schedule(task applyTask) {
+	select {
+	case w.chs[task.regionID%uint64(len(w.chs))] <- task:
+	case <-w.closeCh:
+	}
+}
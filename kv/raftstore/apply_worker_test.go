@@ -0,0 +1,120 @@
+package raftstore
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/message"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyWorker(t *testing.T) {
+	router := newRouter(make(chan message.Msg, 16), nil)
+	for _, regionID := range []uint64{1, 2} {
+		router.peers.Store(regionID, &peerState{})
+	}
+	closeCh := make(chan struct{})
+	wg := new(sync.WaitGroup)
+	w := newApplyWorker(router, 2, closeCh)
+	w.run(wg)
+
+	// the tasks of a region are run in order, the tasks of the other region don't wait for them
+	var mu sync.Mutex
+	applied := make(map[uint64][]uint64)
+	block := make(chan struct{})
+	task := func(regionID, index uint64) applyTask {
+		return applyTask{regionID: regionID, apply: func() uint64 {
+			if regionID == 1 && index == 1 {
+				<-block
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			applied[regionID] = append(applied[regionID], index)
+			return index
+		}}
+	}
+	for i := uint64(1); i <= 3; i++ {
+		w.schedule(task(1, i))
+		w.schedule(task(2, i))
+	}
+	for i := uint64(1); i <= 3; i++ {
+		msg := <-router.peerSender
+		assert.Equal(t, message.Msg{Type: message.MsgTypeApplied, RegionID: 2, Data: i}, msg)
+	}
+	close(block)
+	for i := uint64(1); i <= 3; i++ {
+		msg := <-router.peerSender
+		assert.Equal(t, message.Msg{Type: message.MsgTypeApplied, RegionID: 1, Data: i}, msg)
+	}
+	assert.Equal(t, map[uint64][]uint64{1: {1, 2, 3}, 2: {1, 2, 3}}, applied)
+
+	// the tasks of a stopped peer still queued are dropped, a new peer of the region isn't affected
+	started := make(chan struct{})
+	block = make(chan struct{})
+	w.schedule(applyTask{regionID: 2, peerID: 1, apply: func() uint64 {
+		close(started)
+		<-block
+		return 4
+	}})
+	w.schedule(applyTask{regionID: 2, peerID: 1, apply: func() uint64 {
+		t.Errorf("the task of the stopped peer is applied")
+		return 5
+	}})
+	<-started
+	stopped := make(chan struct{})
+	go func() {
+		w.stop(2, 1)
+		close(stopped)
+	}()
+	for !w.isStopped(1) {
+		time.Sleep(time.Millisecond)
+	}
+	close(block)
+	<-stopped
+	w.schedule(applyTask{regionID: 2, peerID: 2, apply: func() uint64 { return 6 }})
+	assert.Equal(t, message.Msg{Type: message.MsgTypeApplied, RegionID: 2, Data: uint64(4)}, <-router.peerSender)
+	assert.Equal(t, message.Msg{Type: message.MsgTypeApplied, RegionID: 2, Data: uint64(6)}, <-router.peerSender)
+	assert.False(t, w.isStopped(1))
+
+	close(closeCh)
+	wg.Wait()
+	// scheduling after the store is closed doesn't block
+	w.schedule(task(1, 4))
+}
+
+func TestApplyWorkerReportNoBlock(t *testing.T) {
+	router := newRouter(make(chan message.Msg, 16), nil)
+	// the raft worker doesn't take the peer messages while it schedules the tasks
+	router.peerSender = make(chan message.Msg, 1)
+	router.peers.Store(uint64(1), &peerState{})
+	closeCh := make(chan struct{})
+	wg := new(sync.WaitGroup)
+	w := newApplyWorker(router, 1, closeCh)
+	w.chs[0] = make(chan applyTask, 1)
+	w.run(wg)
+
+	// the tasks are applied, and a stale peer of the region stopped, while the reports can't be taken
+	done := make(chan struct{})
+	go func() {
+		for i := uint64(1); i <= 4; i++ {
+			index := i
+			w.schedule(applyTask{regionID: 1, peerID: 1, apply: func() uint64 { return index }})
+		}
+		w.stop(1, 2)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the apply worker blocks on the reports")
+	}
+	var reported []uint64
+	for i := 0; i < 4; i++ {
+		reported = append(reported, (<-router.peerSender).Data.(uint64))
+	}
+	assert.ElementsMatch(t, []uint64{1, 2, 3, 4}, reported)
+
+	close(closeCh)
+	wg.Wait()
+}
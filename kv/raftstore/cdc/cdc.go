@@ -0,0 +1,342 @@
+package cdc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sync"
+
+	"github.com/Connor1996/badger"
+	"github.com/pingcap-incubator/tinykv/kv/transaction/mvcc"
+	"github.com/pingcap-incubator/tinykv/kv/util/codec"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/log"
+)
+
+type EventType int
+
+const (
+	// A key is prewritten, Value is the value of a put.
+	EventPrewrite EventType = iota
+	// A key is committed, Value is the committed value of a put.
+	EventCommit
+	// A prewrite is rolled back.
+	EventRollback
+	// The capture of the region is stopped since its data is changed by a split, merge or destroy, the consumer
+	// should enable it on the new regions.
+	EventStopped
+)
+
+func (t EventType) String() string {
+	switch t {
+	case EventPrewrite:
+		return "Prewrite"
+	case EventCommit:
+		return "Commit"
+	case EventRollback:
+		return "Rollback"
+	case EventStopped:
+		return "Stopped"
+	}
+	return "Unknown"
+}
+
+// Event is a change of a key of a region, captured when it's applied.
+type Event struct {
+	Type     EventType
+	RegionID uint64
+	// the index of the raft entry of the change
+	Index    uint64
+	Key      []byte
+	StartTS  uint64
+	CommitTS uint64
+	// the kind of the prewrite or the commit, a put or a delete
+	Kind  mvcc.WriteKind
+	Value []byte
+	// OldValue is the before-image of a prewrite or a commit, the latest value of the key committed before StartTS,
+	// nil if the key didn't exist.
+	OldValue []byte
+}
+
+// Sink receives the events of a region. It's called in the raftstore goroutines in log order, so it must not block.
+type Sink func(event *Event)
+
+// Capturer captures the changes of the regions CDC is enabled for when they're applied, with their old values, so
+// the consumers which need the before-images don't read them afterwards.
+//
+// The old value of a key is the value of its latest committed put before the start ts of the change. It's looked up
+// in the values committed since the capture is enabled first, since the writes of a command are only in the kv engine
+// once its write batch is written, then in the write and default CFs of the kv engine. The writes don't inline short
+// values, so the value of a put is always read from the default CF.
+//
+// All methods may be called on a nil Capturer, which means the capture is disabled.
+type Capturer struct {
+	sync.Mutex
+	kvDB    *badger.DB
+	regions map[uint64]*regionCapture
+	// the max number of values cached by each region
+	cacheSize int
+}
+
+// regionCapture is the capture of a region. The values of the prewrites and the committed values are cached until
+// they're evicted by newer ones, in FIFO order.
+type regionCapture struct {
+	sink Sink
+	// the prewrites by key and start ts
+	prewrites map[string]*prewrite
+	// the latest committed values by key
+	committed map[string]*committedValue
+	// the cached entries in the order they're added
+	queue []cacheEntry
+}
+
+type prewrite struct {
+	lock     *mvcc.Lock
+	value    []byte
+	hasValue bool
+	emitted  bool
+}
+
+type committedValue struct {
+	commitTS uint64
+	// nil for a delete
+	value []byte
+}
+
+type cacheEntry struct {
+	prewrite bool
+	key      string
+	ts       uint64
+}
+
+// NewCapturer creates a capturer of the changes in kvDB, each region caches at most cacheSize values.
+func NewCapturer(kvDB *badger.DB, cacheSize int) *Capturer {
+	return &Capturer{kvDB: kvDB, regions: make(map[uint64]*regionCapture), cacheSize: cacheSize}
+}
+
+// Enable starts capturing the changes of the region to the sink, replacing the sink of a region enabled already.
+func (c *Capturer) Enable(regionID uint64, sink Sink) {
+	if c == nil {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.regions[regionID] = &regionCapture{
+		sink:      sink,
+		prewrites: make(map[string]*prewrite),
+		committed: make(map[string]*committedValue),
+	}
+}
+
+// Disable stops capturing the changes of the region.
+func (c *Capturer) Disable(regionID uint64) {
+	if c == nil {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	delete(c.regions, regionID)
+}
+
+// Enabled tells whether the changes of the region are captured.
+func (c *Capturer) Enabled(regionID uint64) bool {
+	if c == nil {
+		return false
+	}
+	c.Lock()
+	defer c.Unlock()
+	_, ok := c.regions[regionID]
+	return ok
+}
+
+// Stop stops capturing the changes of the region, telling the sink by an EventStopped.
+func (c *Capturer) Stop(regionID uint64) {
+	if c == nil {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	if r, ok := c.regions[regionID]; ok {
+		delete(c.regions, regionID)
+		r.sink(&Event{Type: EventStopped, RegionID: regionID})
+	}
+}
+
+// OnPut captures a put applied at the index of the region. The key of the write and default CFs is encoded with the
+// timestamp of the write.
+func (c *Capturer) OnPut(regionID, index uint64, cf string, key, value []byte) {
+	if c == nil {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	r, ok := c.regions[regionID]
+	if !ok {
+		return
+	}
+	switch cf {
+	case engine_util.CfDefault:
+		userKey, ts, err := decodeKey(key)
+		if err != nil {
+			return
+		}
+		p := c.prewrite(r, userKey, ts)
+		p.value, p.hasValue = value, true
+		c.maybeEmitPrewrite(r, regionID, index, userKey, ts, p)
+	case engine_util.CfLock:
+		lock, err := mvcc.ParseLock(value)
+		if err != nil {
+			log.Warnf("[region %d] cdc failed to parse the lock of key %x: %v", regionID, key, err)
+			return
+		}
+		p := c.prewrite(r, key, lock.Ts)
+		p.lock = lock
+		c.maybeEmitPrewrite(r, regionID, index, key, lock.Ts, p)
+	case engine_util.CfWrite:
+		userKey, commitTS, err := decodeKey(key)
+		if err != nil {
+			return
+		}
+		write, err := mvcc.ParseWrite(value)
+		if err != nil || write == nil {
+			log.Warnf("[region %d] cdc failed to parse the write of key %x: %v", regionID, key, err)
+			return
+		}
+		c.onWrite(r, regionID, index, userKey, commitTS, write)
+	}
+}
+
+// prewrite returns the cached prewrite of the key at the start ts, adding it if there isn't one.
+func (c *Capturer) prewrite(r *regionCapture, key []byte, startTS uint64) *prewrite {
+	k := prewriteKey(key, startTS)
+	if p, ok := r.prewrites[k]; ok {
+		return p
+	}
+	p := new(prewrite)
+	r.prewrites[k] = p
+	c.cache(r, cacheEntry{prewrite: true, key: k, ts: startTS})
+	return p
+}
+
+// maybeEmitPrewrite emits the prewrite once both its lock and, for a put, its value are applied, in either order.
+func (c *Capturer) maybeEmitPrewrite(r *regionCapture, regionID, index uint64, key []byte, startTS uint64, p *prewrite) {
+	if p.emitted || p.lock == nil || (p.lock.Kind == mvcc.WriteKindPut && !p.hasValue) {
+		return
+	}
+	p.emitted = true
+	r.sink(&Event{
+		Type:     EventPrewrite,
+		RegionID: regionID,
+		Index:    index,
+		Key:      key,
+		StartTS:  startTS,
+		Kind:     p.lock.Kind,
+		Value:    p.value,
+		OldValue: c.oldValue(r, key, startTS),
+	})
+}
+
+func (c *Capturer) onWrite(r *regionCapture, regionID, index uint64, key []byte, commitTS uint64, write *mvcc.Write) {
+	event := &Event{RegionID: regionID, Index: index, Key: key, StartTS: write.StartTS, Kind: write.Kind}
+	if write.Kind == mvcc.WriteKindRollback {
+		delete(r.prewrites, prewriteKey(key, write.StartTS))
+		event.Type = EventRollback
+		r.sink(event)
+		return
+	}
+	event.Type = EventCommit
+	event.CommitTS = commitTS
+	event.OldValue = c.oldValue(r, key, write.StartTS)
+	if write.Kind == mvcc.WriteKindPut {
+		event.Value = c.value(r, key, write.StartTS)
+	}
+	delete(r.prewrites, prewriteKey(key, write.StartTS))
+	r.committed[string(key)] = &committedValue{commitTS: commitTS, value: event.Value}
+	c.cache(r, cacheEntry{key: string(key), ts: commitTS})
+	r.sink(event)
+}
+
+// value returns the value of the put of the key prewritten at the start ts.
+func (c *Capturer) value(r *regionCapture, key []byte, startTS uint64) []byte {
+	if p, ok := r.prewrites[prewriteKey(key, startTS)]; ok && p.hasValue {
+		return p.value
+	}
+	value, err := engine_util.GetCF(c.kvDB, engine_util.CfDefault, mvcc.EncodeKey(key, startTS))
+	if err != nil {
+		log.Warnf("cdc failed to read the value of key %x at %d: %v", key, startTS, err)
+		return nil
+	}
+	return value
+}
+
+// oldValue returns the value of the latest put of the key committed before the start ts, nil if there is none or
+// the key was deleted then.
+func (c *Capturer) oldValue(r *regionCapture, key []byte, startTS uint64) []byte {
+	if v, ok := r.committed[string(key)]; ok && v.commitTS < startTS {
+		return v.value
+	}
+	var value []byte
+	err := c.kvDB.View(func(txn *badger.Txn) error {
+		iter := engine_util.NewCFIterator(engine_util.CfWrite, txn)
+		defer iter.Close()
+		for iter.Seek(mvcc.EncodeKey(key, startTS-1)); iter.Valid(); iter.Next() {
+			item := iter.Item()
+			userKey, _, err := decodeKey(item.Key())
+			if err != nil || !bytes.Equal(userKey, key) {
+				return err
+			}
+			v, err := item.Value()
+			if err != nil {
+				return err
+			}
+			write, err := mvcc.ParseWrite(v)
+			if err != nil {
+				return err
+			}
+			switch write.Kind {
+			case mvcc.WriteKindRollback:
+				continue
+			case mvcc.WriteKindPut:
+				value, err = engine_util.GetCFFromTxn(txn, engine_util.CfDefault, mvcc.EncodeKey(key, write.StartTS))
+				return err
+			}
+			return nil
+		}
+		return nil
+	})
+	if err != nil {
+		log.Warnf("cdc failed to read the old value of key %x before %d: %v", key, startTS, err)
+		return nil
+	}
+	return value
+}
+
+// cache adds the entry to the queue of the region, evicting the oldest entries over the cache size.
+func (c *Capturer) cache(r *regionCapture, entry cacheEntry) {
+	r.queue = append(r.queue, entry)
+	for len(r.queue) > c.cacheSize {
+		old := r.queue[0]
+		r.queue = r.queue[1:]
+		if old.prewrite {
+			delete(r.prewrites, old.key)
+		} else if v, ok := r.committed[old.key]; ok && v.commitTS == old.ts {
+			delete(r.committed, old.key)
+		}
+	}
+}
+
+func prewriteKey(key []byte, startTS uint64) string {
+	return string(mvcc.EncodeKey(key, startTS))
+}
+
+// decodeKey splits a key of the write or default CF into the user key and the timestamp.
+func decodeKey(key []byte) ([]byte, uint64, error) {
+	left, userKey, err := codec.DecodeBytes(key)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(left) < 8 {
+		return nil, 0, fmt.Errorf("no timestamp in key %x", key)
+	}
+	return userKey, ^binary.BigEndian.Uint64(left), nil
+}
@@ -0,0 +1,96 @@
+package cdc
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
+	"github.com/pingcap-incubator/tinykv/kv/transaction/mvcc"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func lockValue(ts uint64, kind mvcc.WriteKind) []byte {
+	return (&mvcc.Lock{Primary: []byte("k"), Ts: ts, Ttl: 10, Kind: kind}).ToBytes()
+}
+
+func writeValue(startTS uint64, kind mvcc.WriteKind) []byte {
+	return (&mvcc.Write{StartTS: startTS, Kind: kind}).ToBytes()
+}
+
+func TestCapturer(t *testing.T) {
+	engines := util.NewTestEngines()
+	defer engines.Destroy()
+	key := []byte("k")
+	// v1 is committed at 10 before the capture is enabled
+	wb := new(engine_util.WriteBatch)
+	wb.SetCF(engine_util.CfDefault, mvcc.EncodeKey(key, 5), []byte("v1"))
+	wb.SetCF(engine_util.CfWrite, mvcc.EncodeKey(key, 10), writeValue(5, mvcc.WriteKindPut))
+	wb.SetCF(engine_util.CfWrite, mvcc.EncodeKey(key, 12), writeValue(11, mvcc.WriteKindRollback))
+	require.Nil(t, wb.WriteToDB(engines.Kv))
+
+	c := NewCapturer(engines.Kv, 16)
+	var events []*Event
+	c.OnPut(1, 1, engine_util.CfLock, key, lockValue(20, mvcc.WriteKindPut))
+	assert.Empty(t, events)
+	c.Enable(1, func(e *Event) { events = append(events, e) })
+
+	// the prewrite is emitted once both its lock and its value are applied
+	c.OnPut(1, 2, engine_util.CfLock, key, lockValue(20, mvcc.WriteKindPut))
+	assert.Empty(t, events)
+	c.OnPut(1, 2, engine_util.CfDefault, mvcc.EncodeKey(key, 20), []byte("v2"))
+	require.Len(t, events, 1)
+	assert.Equal(t, EventPrewrite, events[0].Type)
+	assert.Equal(t, []byte("v2"), events[0].Value)
+	assert.Equal(t, []byte("v1"), events[0].OldValue)
+
+	// the commit, whose value isn't in the engine
+	c.OnPut(1, 3, engine_util.CfWrite, mvcc.EncodeKey(key, 25), writeValue(20, mvcc.WriteKindPut))
+	require.Len(t, events, 2)
+	assert.Equal(t, EventCommit, events[1].Type)
+	assert.Equal(t, uint64(25), events[1].CommitTS)
+	assert.Equal(t, []byte("v2"), events[1].Value)
+	assert.Equal(t, []byte("v1"), events[1].OldValue)
+
+	// the old value of the next change is the committed one, which isn't in the engine either
+	c.OnPut(1, 4, engine_util.CfLock, key, lockValue(30, mvcc.WriteKindDelete))
+	require.Len(t, events, 3)
+	assert.Equal(t, mvcc.WriteKindDelete, events[2].Kind)
+	assert.Equal(t, []byte("v2"), events[2].OldValue)
+	c.OnPut(1, 5, engine_util.CfWrite, mvcc.EncodeKey(key, 30), writeValue(30, mvcc.WriteKindRollback))
+	require.Len(t, events, 4)
+	assert.Equal(t, EventRollback, events[3].Type)
+
+	// a region without the capture enabled isn't captured
+	c.OnPut(2, 1, engine_util.CfLock, key, lockValue(40, mvcc.WriteKindDelete))
+	assert.Len(t, events, 4)
+
+	c.Stop(1)
+	require.Len(t, events, 5)
+	assert.Equal(t, EventStopped, events[4].Type)
+	assert.False(t, c.Enabled(1))
+}
+
+func TestCapturerEviction(t *testing.T) {
+	engines := util.NewTestEngines()
+	defer engines.Destroy()
+	c := NewCapturer(engines.Kv, 2)
+	var events []*Event
+	c.Enable(1, func(e *Event) { events = append(events, e) })
+	for i, key := range []string{"a", "b", "c"} {
+		ts := uint64(10 * (i + 1))
+		c.OnPut(1, 1, engine_util.CfDefault, mvcc.EncodeKey([]byte(key), ts), []byte(key))
+	}
+	r := c.regions[1]
+	assert.Len(t, r.prewrites, 2)
+	assert.Len(t, r.queue, 2)
+	_, ok := r.prewrites[prewriteKey([]byte("a"), 10)]
+	assert.False(t, ok)
+
+	// an evicted value is read from the engine, where it's absent here
+	c.OnPut(1, 2, engine_util.CfWrite, mvcc.EncodeKey([]byte("a"), 15), writeValue(10, mvcc.WriteKindPut))
+	require.Len(t, events, 1)
+	assert.Nil(t, events[0].Value)
+	c.OnPut(1, 2, engine_util.CfWrite, mvcc.EncodeKey([]byte("c"), 35), writeValue(30, mvcc.WriteKindPut))
+	assert.Equal(t, []byte("c"), events[1].Value)
+}
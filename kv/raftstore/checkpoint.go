@@ -0,0 +1,340 @@
+package raftstore
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/Connor1996/badger"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/message"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/meta"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/runner"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/log"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	rspb "github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
+	"github.com/pingcap/errors"
+)
+
+// The data of a removed peer may be kept on the store for a while, see config.RemovedPeerRetention. A peer of the
+// region re-added meanwhile asks the leader to verify the checkpoint of the data, that is the index and the term it's
+// applied up to, and if the leader still has the log after it, the peer is initialized from the checkpoint and
+// catches up by replaying the log instead of applying a full snapshot.
+
+// checkpointCatchUpTimeout is how long a re-added peer waits for the leader to verify its checkpoint before falling
+// back to a snapshot.
+const checkpointCatchUpTimeout = 10 * time.Second
+
+// loadRegionCheckpoint returns the checkpoint of the data kept for the region, nil if there is none or it's expired.
+func loadRegionCheckpoint(db *badger.DB, regionID uint64) *rspb.RegionCheckpoint {
+	checkpoint := new(rspb.RegionCheckpoint)
+	if err := engine_util.GetMeta(db, meta.RegionCheckpointKey(regionID), checkpoint); err != nil {
+		if err != badger.ErrKeyNotFound {
+			log.Warnf("[region %d] failed to load the checkpoint: %v", regionID, err)
+		}
+		return nil
+	}
+	if time.Now().UnixNano() >= checkpoint.ExpireAt {
+		return nil
+	}
+	return checkpoint
+}
+
+// scanRegionCheckpoints returns the checkpoints of all the data kept on the store.
+func scanRegionCheckpoints(db *badger.DB) ([]*rspb.RegionCheckpoint, error) {
+	var checkpoints []*rspb.RegionCheckpoint
+	err := db.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+		for it.Seek(meta.RegionMetaMinKey); it.Valid(); it.Next() {
+			item := it.Item()
+			if bytes.Compare(item.Key(), meta.RegionMetaMaxKey) >= 0 {
+				break
+			}
+			_, suffix, err := meta.DecodeRegionMetaKey(item.Key())
+			if err != nil {
+				return err
+			}
+			if suffix != meta.RegionCheckpointSuffix {
+				continue
+			}
+			val, err := item.Value()
+			if err != nil {
+				return err
+			}
+			checkpoint := new(rspb.RegionCheckpoint)
+			if err := checkpoint.Unmarshal(val); err != nil {
+				return err
+			}
+			checkpoints = append(checkpoints, checkpoint)
+		}
+		return nil
+	})
+	return checkpoints, errors.WithStack(err)
+}
+
+// uncoveredRanges returns the parts of [start, end) not covered by the regions, which are sorted by their start keys.
+// An empty end key is the end of the key space.
+func uncoveredRanges(start, end []byte, regions []*metapb.Region) [][2][]byte {
+	var ranges [][2][]byte
+	for _, region := range regions {
+		if bytes.Compare(region.StartKey, start) > 0 {
+			gapEnd := region.StartKey
+			if len(end) != 0 && bytes.Compare(gapEnd, end) > 0 {
+				gapEnd = end
+			}
+			if bytes.Compare(start, gapEnd) < 0 {
+				ranges = append(ranges, [2][]byte{start, gapEnd})
+			}
+		}
+		if len(region.EndKey) == 0 {
+			return ranges
+		}
+		if bytes.Compare(region.EndKey, start) > 0 {
+			start = region.EndKey
+		}
+	}
+	if len(end) == 0 || bytes.Compare(start, end) < 0 {
+		ranges = append(ranges, [2][]byte{start, end})
+	}
+	return ranges
+}
+
+// verifyCheckpoint tells whether a peer of the region can catch up from the checkpoint by replaying the log after
+// it: the key range of the region is unchanged, and the log at the index has the term, so the data applied up to
+// the index is the same as the leader's.
+func verifyCheckpoint(region *metapb.Region, req *rspb.CheckpointCatchUp, term func(uint64) (uint64, error)) bool {
+	checkpointRegion := req.GetRegion()
+	if checkpointRegion.GetRegionEpoch().GetVersion() != region.GetRegionEpoch().GetVersion() ||
+		!bytes.Equal(checkpointRegion.GetStartKey(), region.GetStartKey()) ||
+		!bytes.Equal(checkpointRegion.GetEndKey(), region.GetEndKey()) {
+		return false
+	}
+	t, err := term(req.Index)
+	return err == nil && t == req.Term
+}
+
+// maybeKeepData writes the checkpoint of the peer about to be destroyed, so its data is kept for a while. It returns
+// false if the data isn't kept.
+func (d *peerMsgHandler) maybeKeepData() bool {
+	retention := d.ctx.cfg.RemovedPeerRetention
+	if retention == 0 || d.ctx.cfg.MemoryLockCF || !d.isInitialized() {
+		return false
+	}
+	applied := d.peerStorage.AppliedIndex()
+	term, err := d.peerStorage.Term(applied)
+	if err != nil {
+		log.Warnf("%s failed to get the term of the applied index %d, don't keep the data: %v", d.Tag, applied, err)
+		return false
+	}
+	kvWB := new(engine_util.WriteBatch)
+	kvWB.SetMeta(meta.RegionCheckpointKey(d.regionId), &rspb.RegionCheckpoint{
+		Region:       d.Region(),
+		AppliedIndex: applied,
+		AppliedTerm:  term,
+		ExpireAt:     time.Now().Add(retention).UnixNano(),
+	})
+	if err := kvWB.WriteToDB(d.ctx.engine.Kv); err != nil {
+		log.Warnf("%s failed to write the checkpoint, don't keep the data: %v", d.Tag, err)
+		return false
+	}
+	log.Infof("%s keeps the data applied up to index %d for %v", d.Tag, applied, retention)
+	return true
+}
+
+// waitCheckpointCatchUp asks the leader to verify the checkpoint of the uninitialized peer on the first message from
+// it, then drops the raft messages until the leader answers, so the leader doesn't generate a snapshot meanwhile.
+// The checkpoint is given up if the leader doesn't answer in time. It returns true if the message is dropped.
+func (d *peerMsgHandler) waitCheckpointCatchUp(msg *rspb.RaftMessage) bool {
+	if d.checkpoint == nil {
+		return false
+	}
+	if d.isInitialized() {
+		d.abandonCheckpoint()
+		return false
+	}
+	if d.checkpointDeadline.IsZero() {
+		switch msg.GetMessage().GetMsgType() {
+		case eraftpb.MessageType_MsgAppend, eraftpb.MessageType_MsgHeartbeat, eraftpb.MessageType_MsgSnapshot:
+		default:
+			// not from the leader
+			return false
+		}
+		req := &rspb.RaftMessage{
+			RegionId:    d.regionId,
+			FromPeer:    d.Meta,
+			ToPeer:      msg.FromPeer,
+			RegionEpoch: d.checkpoint.Region.RegionEpoch,
+			CheckpointCatchUp: &rspb.CheckpointCatchUp{
+				Index:  d.checkpoint.AppliedIndex,
+				Term:   d.checkpoint.AppliedTerm,
+				Region: d.checkpoint.Region,
+			},
+		}
+		if err := d.ctx.trans.Send(req); err != nil {
+			log.Warnf("%s failed to ask the leader to verify the checkpoint: %v", d.Tag, err)
+			d.abandonCheckpoint()
+			return false
+		}
+		log.Infof("%s asks leader %d to catch up from the checkpoint at index %d", d.Tag, msg.FromPeer.Id,
+			d.checkpoint.AppliedIndex)
+		d.checkpointDeadline = time.Now().Add(checkpointCatchUpTimeout)
+		return true
+	}
+	if time.Now().Before(d.checkpointDeadline) {
+		return true
+	}
+	log.Warnf("%s the leader didn't verify the checkpoint in %v, fall back to a snapshot", d.Tag, checkpointCatchUpTimeout)
+	d.abandonCheckpoint()
+	return false
+}
+
+// abandonCheckpoint gives up catching up from the checkpoint, the data is deleted by the next checkpoint GC except
+// the parts replaced by the snapshots applied meanwhile.
+func (d *peerMsgHandler) abandonCheckpoint() {
+	checkpoint := d.checkpoint
+	d.checkpoint = nil
+	checkpoint.ExpireAt = time.Now().UnixNano()
+	kvWB := new(engine_util.WriteBatch)
+	kvWB.SetMeta(meta.RegionCheckpointKey(d.regionId), checkpoint)
+	if err := kvWB.WriteToDB(d.ctx.engine.Kv); err != nil {
+		log.Warnf("%s failed to expire the checkpoint: %v", d.Tag, err)
+	}
+}
+
+// onCheckpointCatchUp verifies the checkpoint of a re-added peer on the leader, and on the peer initializes it from
+// the checkpoint once the leader accepts it.
+func (d *peerMsgHandler) onCheckpointCatchUp(msg *rspb.RaftMessage) {
+	catchUp := msg.CheckpointCatchUp
+	if catchUp.Response {
+		if d.checkpoint == nil || d.isInitialized() || catchUp.Index != d.checkpoint.AppliedIndex {
+			return
+		}
+		if !catchUp.Accepted {
+			log.Infof("%s leader %d rejects the checkpoint at index %d, fall back to a snapshot", d.Tag,
+				msg.FromPeer.Id, catchUp.Index)
+			d.abandonCheckpoint()
+			return
+		}
+		d.restoreCheckpoint(catchUp.Region)
+		return
+	}
+	if !d.IsLeader() {
+		return
+	}
+	region := d.Region()
+	accepted := verifyCheckpoint(region, catchUp, d.peerStorage.Term)
+	log.Infof("%s peer %d asks to catch up from the checkpoint at index %d term %d, accepted %v", d.Tag,
+		msg.FromPeer.Id, catchUp.Index, catchUp.Term, accepted)
+	resp := &rspb.RaftMessage{
+		RegionId: d.regionId,
+		FromPeer: d.Meta,
+		ToPeer:   msg.FromPeer,
+		RegionEpoch: &metapb.RegionEpoch{
+			ConfVer: region.RegionEpoch.ConfVer,
+			Version: region.RegionEpoch.Version,
+		},
+		CheckpointCatchUp: &rspb.CheckpointCatchUp{
+			Index:    catchUp.Index,
+			Term:     catchUp.Term,
+			Region:   region,
+			Response: true,
+			Accepted: accepted,
+		},
+	}
+	if err := d.ctx.trans.Send(resp); err != nil {
+		log.Warnf("%s failed to answer the checkpoint of peer %d: %v", d.Tag, msg.FromPeer.Id, err)
+	}
+}
+
+// restoreCheckpoint replaces the uninitialized peer with a peer of the region initialized from the checkpoint, whose
+// raft log is truncated at the checkpoint, so the leader replicates the log after it.
+func (d *peerMsgHandler) restoreCheckpoint(region *metapb.Region) {
+	checkpoint := d.checkpoint
+	if util.FindPeer(region, d.storeID()).GetId() != d.PeerId() {
+		log.Warnf("%s isn't in region %s, don't catch up from the checkpoint", d.Tag, region)
+		d.abandonCheckpoint()
+		return
+	}
+	storeMeta := d.ctx.storeMeta
+	storeMeta.Lock()
+	defer storeMeta.Unlock()
+	if overlaps := storeMeta.getOverlapRegions(region); len(overlaps) > 0 {
+		log.Warnf("%s region %s overlaps with %s, don't catch up from the checkpoint", d.Tag, region, overlaps[0])
+		d.abandonCheckpoint()
+		return
+	}
+	if current := loadRegionCheckpoint(d.ctx.engine.Kv, d.regionId); current == nil ||
+		current.AppliedIndex != checkpoint.AppliedIndex {
+		log.Warnf("%s the checkpoint is gone, fall back to a snapshot", d.Tag)
+		d.checkpoint = nil
+		return
+	}
+	log.Infof("%s catches up from the checkpoint at index %d term %d", d.Tag, checkpoint.AppliedIndex,
+		checkpoint.AppliedTerm)
+	regionID := d.regionId
+	if err := d.Wipe(d.ctx.engine); err != nil {
+		panic(errors.Errorf("%s wipe peer %v", d.Tag, err))
+	}
+	kvWB := new(engine_util.WriteBatch)
+	raftWB := new(engine_util.WriteBatch)
+	writeTruncatedState(kvWB, raftWB, region, checkpoint.AppliedIndex, checkpoint.AppliedTerm)
+	kvWB.DeleteMeta(meta.RegionCheckpointKey(regionID))
+	kvWB.MustWriteToDB(d.ctx.engine.Kv)
+	raftWB.MustWriteToDB(d.ctx.engine.Raft)
+	d.ctx.router.close(regionID)
+	d.stopped = true
+	d.stopAwaitingSnapshot()
+	delete(storeMeta.regions, regionID)
+
+	peer, err := createPeer(d.storeID(), d.ctx.cfg, d.ctx.regionTaskSender, d.ctx.engine, region)
+	if err != nil {
+		panic(errors.Errorf("%s create peer from the checkpoint %v", d.Tag, err))
+	}
+	storeMeta.regions[regionID] = peer.Region()
+	storeMeta.regionRanges.ReplaceOrInsert(&regionItem{region: peer.Region()})
+	d.ctx.router.register(peer)
+	_ = d.ctx.router.send(regionID, message.Msg{Type: message.MsgTypeStart})
+}
+
+func (d *storeWorker) onCheckpointGC() {
+	if err := d.gcCheckpoints(time.Now()); err != nil {
+		log.Errorf("handle checkpoint GC failed store_id %d, err %s", d.storeState.id, err)
+	}
+	d.ticker.scheduleStore(StoreTickCheckpointGC)
+}
+
+// gcCheckpoints deletes the data of the expired checkpoints, except the parts of the regions on the store. It waits
+// while a peer on the store is uninitialized, whose snapshot may be applied into the range of a checkpoint.
+func (d *storeWorker) gcCheckpoints(now time.Time) error {
+	checkpoints, err := scanRegionCheckpoints(d.ctx.engine.Kv)
+	if err != nil || len(checkpoints) == 0 {
+		return err
+	}
+	storeMeta := d.ctx.storeMeta
+	storeMeta.Lock()
+	defer storeMeta.Unlock()
+	for _, region := range storeMeta.regions {
+		if len(region.GetPeers()) == 0 {
+			return nil
+		}
+	}
+	kvWB := new(engine_util.WriteBatch)
+	for _, checkpoint := range checkpoints {
+		if now.UnixNano() < checkpoint.ExpireAt {
+			continue
+		}
+		region := checkpoint.Region
+		for _, r := range uncoveredRanges(region.StartKey, region.EndKey, storeMeta.getOverlapRegions(region)) {
+			d.ctx.regionTaskSender <- &runner.RegionTaskDestroy{
+				RegionId: region.Id,
+				StartKey: r[0],
+				EndKey:   r[1],
+			}
+		}
+		kvWB.DeleteMeta(meta.RegionCheckpointKey(region.Id))
+		log.Infof("store %d deletes the data kept for region %d", d.storeState.id, region.Id)
+	}
+	return kvWB.WriteToDB(d.ctx.engine.Kv)
+}
@@ -0,0 +1,86 @@
+package raftstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/meta"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	rspb "github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
+	"github.com/pingcap/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUncoveredRanges(t *testing.T) {
+	region := func(start, end string) *metapb.Region {
+		return &metapb.Region{StartKey: []byte(start), EndKey: []byte(end)}
+	}
+	key := func(k string) []byte {
+		if k == "" {
+			return nil
+		}
+		return []byte(k)
+	}
+	ranges := func(keys ...string) [][2][]byte {
+		var ranges [][2][]byte
+		for i := 0; i < len(keys); i += 2 {
+			ranges = append(ranges, [2][]byte{key(keys[i]), key(keys[i+1])})
+		}
+		return ranges
+	}
+	assert.Equal(t, ranges("b", "f"), uncoveredRanges([]byte("b"), []byte("f"), nil))
+	assert.Equal(t, ranges("b", "c", "d", "e"),
+		uncoveredRanges([]byte("b"), []byte("f"), []*metapb.Region{region("a", "b"), region("c", "d"), region("e", "g")}))
+	assert.Equal(t, ranges("", "a", "c", ""),
+		uncoveredRanges(nil, nil, []*metapb.Region{region("a", "c")}))
+	assert.Empty(t, uncoveredRanges([]byte("b"), nil, []*metapb.Region{region("a", "")}))
+}
+
+func TestVerifyCheckpoint(t *testing.T) {
+	region := &metapb.Region{StartKey: []byte("a"), EndKey: []byte("c"), RegionEpoch: &metapb.RegionEpoch{Version: 2, ConfVer: 5}}
+	term := func(index uint64) (uint64, error) {
+		if index < 10 {
+			return 0, errors.New("compacted")
+		}
+		return 6, nil
+	}
+	checkpointRegion := &metapb.Region{StartKey: []byte("a"), EndKey: []byte("c"), RegionEpoch: &metapb.RegionEpoch{Version: 2, ConfVer: 3}}
+	assert.True(t, verifyCheckpoint(region, &rspb.CheckpointCatchUp{Index: 12, Term: 6, Region: checkpointRegion}, term))
+	assert.False(t, verifyCheckpoint(region, &rspb.CheckpointCatchUp{Index: 12, Term: 5, Region: checkpointRegion}, term))
+	assert.False(t, verifyCheckpoint(region, &rspb.CheckpointCatchUp{Index: 8, Term: 6, Region: checkpointRegion}, term))
+	// the region is split or merged since
+	checkpointRegion = &metapb.Region{StartKey: []byte("a"), EndKey: []byte("b"), RegionEpoch: &metapb.RegionEpoch{Version: 1}}
+	assert.False(t, verifyCheckpoint(region, &rspb.CheckpointCatchUp{Index: 12, Term: 6, Region: checkpointRegion}, term))
+}
+
+func TestRegionCheckpoints(t *testing.T) {
+	engines := util.NewTestEngines()
+	defer engines.Destroy()
+	kvWB := new(engine_util.WriteBatch)
+	kvWB.SetMeta(meta.RegionCheckpointKey(2), &rspb.RegionCheckpoint{
+		Region:       &metapb.Region{Id: 2},
+		AppliedIndex: 10,
+		ExpireAt:     time.Now().Add(time.Hour).UnixNano(),
+	})
+	kvWB.SetMeta(meta.RegionCheckpointKey(3), &rspb.RegionCheckpoint{
+		Region:       &metapb.Region{Id: 3},
+		AppliedIndex: 20,
+		ExpireAt:     time.Now().Add(-time.Hour).UnixNano(),
+	})
+	meta.WriteRegionState(kvWB, &metapb.Region{Id: 4}, rspb.PeerState_Normal)
+	require.Nil(t, engines.WriteKV(kvWB))
+
+	checkpoints, err := scanRegionCheckpoints(engines.Kv)
+	require.Nil(t, err)
+	require.Len(t, checkpoints, 2)
+	assert.Equal(t, uint64(2), checkpoints[0].Region.Id)
+	assert.Equal(t, uint64(3), checkpoints[1].Region.Id)
+
+	assert.Equal(t, uint64(10), loadRegionCheckpoint(engines.Kv, 2).AppliedIndex)
+	// an expired checkpoint isn't caught up from
+	assert.Nil(t, loadRegionCheckpoint(engines.Kv, 3))
+	assert.Nil(t, loadRegionCheckpoint(engines.Kv, 4))
+}
@@ -0,0 +1,94 @@
+package raftstore
+
+import (
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+)
+
+// entryCache keeps the latest entries appended to the raft log of a peer in memory, so that sending them to the
+// followers only slightly behind doesn't read the raft engine for every append. Unlike the replay buffer, the entries
+// may not be committed yet, the cached entries overwritten by a conflicting append are replaced. The entries are kept
+// consecutive, the oldest are dropped once the entries exceed the capacity in bytes or the raft log is compacted.
+//
+// A nil entryCache caches nothing.
+type entryCache struct {
+	buf replayBuffer
+	// the reads of entries served by the cache and not, since the raft worker took them last
+	hits   uint64
+	misses uint64
+}
+
+func newEntryCache(capacity uint64) *entryCache {
+	if capacity == 0 {
+		return nil
+	}
+	return &entryCache{buf: replayBuffer{capacity: capacity}}
+}
+
+// append adds the entries appended to the raft log, replacing the cached ones from the index of the first on.
+func (c *entryCache) append(entries []eraftpb.Entry) {
+	if c == nil {
+		return
+	}
+	c.buf.append(entries)
+}
+
+// slice returns the entries in [low, high) if they are all cached, counting the read as a hit or a miss.
+func (c *entryCache) slice(low, high uint64) ([]eraftpb.Entry, bool) {
+	if c == nil {
+		return nil, false
+	}
+	ents, ok := c.buf.slice(low, high)
+	if ok {
+		c.hits++
+	} else {
+		c.misses++
+	}
+	return ents, ok
+}
+
+// term returns the term of the entry at the index if it's cached.
+func (c *entryCache) term(index uint64) (uint64, bool) {
+	if c == nil || len(c.buf.entries) == 0 {
+		return 0, false
+	}
+	first, last := c.buf.entries[0].Index, c.buf.entries[len(c.buf.entries)-1].Index
+	if index < first || index > last {
+		return 0, false
+	}
+	return c.buf.entries[index-first].Term, true
+}
+
+// compact drops the entries up to the index, which are compacted from the raft log.
+func (c *entryCache) compact(index uint64) {
+	if c == nil || len(c.buf.entries) == 0 || index < c.buf.entries[0].Index {
+		return
+	}
+	if index >= c.buf.entries[len(c.buf.entries)-1].Index {
+		c.buf.clear()
+		return
+	}
+	offset := int(index - c.buf.entries[0].Index + 1)
+	for i := 0; i < offset; i++ {
+		c.buf.size -= uint64(c.buf.entries[i].Size())
+		c.buf.entries[i] = eraftpb.Entry{}
+	}
+	c.buf.entries = c.buf.entries[offset:]
+}
+
+// clear drops all the entries, it's called when the log of the peer is replaced by a snapshot.
+func (c *entryCache) clear() {
+	if c == nil {
+		return
+	}
+	c.buf.clear()
+}
+
+// takeStats returns the hits and the misses of the cache since it was last called.
+func (c *entryCache) takeStats() (hits, misses uint64) {
+	if c == nil {
+		return 0, 0
+	}
+	hits, misses = c.hits, c.misses
+	c.hits, c.misses = 0, 0
+	return hits, misses
+}
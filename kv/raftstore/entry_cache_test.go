@@ -0,0 +1,61 @@
+package raftstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntryCache(t *testing.T) {
+	size := uint64(newReplayEntries(1, 2)[0].Size())
+	c := newEntryCache(10 * size)
+	c.append(newReplayEntries(1, 6))
+	ents, ok := c.slice(2, 6)
+	assert.True(t, ok)
+	assert.Equal(t, newReplayEntries(2, 6), ents)
+	_, ok = c.slice(2, 7)
+	assert.False(t, ok)
+	term, ok := c.term(5)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1), term)
+	_, ok = c.term(6)
+	assert.False(t, ok)
+
+	// the conflicting entries are replaced
+	conflict := newReplayEntries(4, 6)
+	for i := range conflict {
+		conflict[i].Term = 2
+	}
+	c.append(conflict)
+	ents, ok = c.slice(3, 6)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1), ents[0].Term)
+	assert.Equal(t, conflict, ents[1:])
+
+	// the compacted entries are dropped
+	c.compact(3)
+	_, ok = c.slice(3, 4)
+	assert.False(t, ok)
+	_, ok = c.slice(4, 6)
+	assert.True(t, ok)
+	assert.Equal(t, 2*size, c.buf.size)
+	c.compact(5)
+	_, ok = c.slice(5, 6)
+	assert.False(t, ok)
+	assert.Equal(t, uint64(0), c.buf.size)
+
+	hits, misses := c.takeStats()
+	assert.Equal(t, uint64(3), hits)
+	assert.Equal(t, uint64(3), misses)
+	hits, misses = c.takeStats()
+	assert.Equal(t, uint64(0), hits+misses)
+
+	var disabled *entryCache
+	disabled.append(newReplayEntries(1, 2))
+	disabled.compact(1)
+	_, ok = disabled.slice(1, 2)
+	assert.False(t, ok)
+	hits, misses = disabled.takeStats()
+	assert.Equal(t, uint64(0), hits+misses)
+	assert.Nil(t, newEntryCache(0))
+}
@@ -0,0 +1,177 @@
+package keycheck
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/pingcap-incubator/tinykv/kv/util/codec"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/log"
+	sqlcodec "github.com/pingcap/tidb/util/codec"
+)
+
+// The reasons a key breaks the TinySQL key layout.
+const (
+	// the key is shorter than t{table_id}_r or t{table_id}_i
+	ReasonShortKey = "short-key"
+	// the table ID is not followed by _r or _i
+	ReasonBadSeparator = "bad-separator"
+	// the table or index ID is not positive
+	ReasonBadID = "bad-id"
+	// a record key is not t{table_id}_r{handle}
+	ReasonBadRecordKey = "bad-record-key"
+	// the values of an index key are not a sequence of encoded datums
+	ReasonBadIndexValues = "bad-index-values"
+)
+
+// The TinySQL key layout: a record key is t{table_id}_r{handle} and an index key is t{table_id}_i{index_id}{values},
+// where the IDs and the handle are 8 bytes each.
+var (
+	tablePrefix     = []byte{'t'}
+	recordPrefixSep = []byte("_r")
+	indexPrefixSep  = []byte("_i")
+)
+
+const (
+	idLen        = 8
+	prefixLen    = 1 + idLen + 2
+	recordKeyLen = prefixLen + idLen
+)
+
+// Violation is a key written to a region which breaks the TinySQL key layout.
+type Violation struct {
+	RegionID uint64
+	CF       string
+	// the user key, without the timestamp of the write and default CFs
+	Key    []byte
+	Reason string
+}
+
+// KeyChecker checks the keys written to the transactional CFs against the TinySQL key layout, so a bug of the
+// encoder of the rows or indexes is caught when the key is written rather than when it's read by a query. Only the
+// keys with the table prefix are checked, other keys like the meta keys and the raw keys are let through. A key
+// which breaks the layout is still written, the violations are counted by reason and the latest ones are kept for
+// the debug API.
+//
+// All methods may be called on a nil KeyChecker, which means the check is disabled.
+type KeyChecker struct {
+	sync.Mutex
+	counts map[string]uint64
+	// the latest violations, recent[next] is the oldest once the buffer is full
+	recent []*Violation
+	next   int
+	size   int
+}
+
+// NewKeyChecker creates a checker which keeps the latest size violations.
+func NewKeyChecker(size int) *KeyChecker {
+	return &KeyChecker{
+		counts: make(map[string]uint64),
+		size:   size,
+	}
+}
+
+// Check checks a key written to the CF of the region, the key of the write and default CFs is encoded with the
+// timestamp of the write. It returns the violation found, if any.
+func (c *KeyChecker) Check(regionID uint64, cf string, key []byte) *Violation {
+	if c == nil {
+		return nil
+	}
+	userKey := key
+	if cf != engine_util.CfLock {
+		var err error
+		if _, userKey, err = codec.DecodeBytes(key); err != nil {
+			// not a transactional key
+			return nil
+		}
+	}
+	reason := CheckKey(userKey)
+	if reason == "" {
+		return nil
+	}
+	v := &Violation{RegionID: regionID, CF: cf, Key: userKey, Reason: reason}
+	c.record(v)
+	return v
+}
+
+func (c *KeyChecker) record(v *Violation) {
+	c.Lock()
+	defer c.Unlock()
+	c.counts[v.Reason]++
+	if c.counts[v.Reason] == 1 {
+		// later violations of the reason are only counted, not to flood the log on a broken encoder
+		log.Warnf("[region %d] key %q written to %s CF breaks the table key layout: %s", v.RegionID, v.Key, v.CF, v.Reason)
+	}
+	if c.size <= 0 {
+		return
+	}
+	if len(c.recent) < c.size {
+		c.recent = append(c.recent, v)
+		return
+	}
+	c.recent[c.next] = v
+	c.next = (c.next + 1) % c.size
+}
+
+// Counts returns the number of violations found of each reason.
+func (c *KeyChecker) Counts() map[string]uint64 {
+	if c == nil {
+		return nil
+	}
+	c.Lock()
+	defer c.Unlock()
+	counts := make(map[string]uint64, len(c.counts))
+	for reason, count := range c.counts {
+		counts[reason] = count
+	}
+	return counts
+}
+
+// Recent returns the latest violations, from the oldest to the newest.
+func (c *KeyChecker) Recent() []*Violation {
+	if c == nil {
+		return nil
+	}
+	c.Lock()
+	defer c.Unlock()
+	recent := make([]*Violation, 0, len(c.recent))
+	recent = append(recent, c.recent[c.next:]...)
+	return append(recent, c.recent[:c.next]...)
+}
+
+// CheckKey checks a user key against the TinySQL key layout, returning the reason it breaks the layout, or "" if
+// it doesn't or it's not a table key.
+func CheckKey(key []byte) string {
+	if !bytes.HasPrefix(key, tablePrefix) {
+		return ""
+	}
+	if len(key) < prefixLen {
+		return ReasonShortKey
+	}
+	if _, tableID, err := sqlcodec.DecodeInt(key[1 : 1+idLen]); err != nil || tableID <= 0 {
+		return ReasonBadID
+	}
+	sep := key[1+idLen : prefixLen]
+	switch {
+	case bytes.Equal(sep, recordPrefixSep):
+		if len(key) != recordKeyLen {
+			return ReasonBadRecordKey
+		}
+	case bytes.Equal(sep, indexPrefixSep):
+		if len(key) < prefixLen+idLen {
+			return ReasonShortKey
+		}
+		if _, indexID, err := sqlcodec.DecodeInt(key[prefixLen : prefixLen+idLen]); err != nil || indexID <= 0 {
+			return ReasonBadID
+		}
+		for values := key[prefixLen+idLen:]; len(values) > 0; {
+			var err error
+			if values, _, err = sqlcodec.DecodeOne(values); err != nil {
+				return ReasonBadIndexValues
+			}
+		}
+	default:
+		return ReasonBadSeparator
+	}
+	return ""
+}
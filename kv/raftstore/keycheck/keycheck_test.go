@@ -0,0 +1,77 @@
+package keycheck
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/kv/util/codec"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/types"
+	sqlcodec "github.com/pingcap/tidb/util/codec"
+	"github.com/stretchr/testify/require"
+)
+
+func recordKey(tableID, handle int64) []byte {
+	key := sqlcodec.EncodeInt(append([]byte(nil), tablePrefix...), tableID)
+	key = append(key, recordPrefixSep...)
+	return sqlcodec.EncodeInt(key, handle)
+}
+
+func indexKey(t *testing.T, tableID, indexID int64, values ...types.Datum) []byte {
+	key := sqlcodec.EncodeInt(append([]byte(nil), tablePrefix...), tableID)
+	key = append(key, indexPrefixSep...)
+	key = sqlcodec.EncodeInt(key, indexID)
+	key, err := sqlcodec.EncodeKey(new(stmtctx.StatementContext), key, values...)
+	require.Nil(t, err)
+	return key
+}
+
+func writeKey(key []byte) []byte {
+	return append(codec.EncodeBytes(key), 0, 0, 0, 0, 0, 0, 0, 1)
+}
+
+func TestCheckKey(t *testing.T) {
+	record := recordKey(1, 10)
+	index := indexKey(t, 1, 2, types.NewIntDatum(5), types.NewStringDatum("a"), types.NewIntDatum(10))
+
+	require.Equal(t, "", CheckKey([]byte("abc")))
+	require.Equal(t, "", CheckKey([]byte("m_meta")))
+	require.Equal(t, "", CheckKey(record))
+	require.Equal(t, "", CheckKey(index))
+
+	require.Equal(t, ReasonShortKey, CheckKey(record[:5]))
+	require.Equal(t, ReasonShortKey, CheckKey(index[:prefixLen+3]))
+	require.Equal(t, ReasonBadID, CheckKey(recordKey(0, 10)))
+	require.Equal(t, ReasonBadID, CheckKey(indexKey(t, 1, -1, types.NewIntDatum(5))))
+	badSep := append([]byte(nil), record...)
+	badSep[1+idLen+1] = 'x'
+	require.Equal(t, ReasonBadSeparator, CheckKey(badSep))
+	require.Equal(t, ReasonBadRecordKey, CheckKey(record[:recordKeyLen-1]))
+	require.Equal(t, ReasonBadRecordKey, CheckKey(append(record, 0)))
+	require.Equal(t, ReasonBadIndexValues, CheckKey(index[:len(index)-1]))
+	require.Equal(t, ReasonBadIndexValues, CheckKey(append(index, 0xff)))
+}
+
+func TestKeyChecker(t *testing.T) {
+	var disabled *KeyChecker
+	require.Nil(t, disabled.Check(1, engine_util.CfWrite, writeKey(recordKey(1, 10)[:5])))
+	require.Nil(t, disabled.Recent())
+
+	c := NewKeyChecker(2)
+	require.Nil(t, c.Check(1, engine_util.CfWrite, writeKey(recordKey(1, 10))))
+	require.Nil(t, c.Check(1, engine_util.CfLock, recordKey(1, 10)))
+	// a raw key isn't encoded
+	require.Nil(t, c.Check(1, engine_util.CfDefault, []byte("t1")))
+
+	short := recordKey(1, 10)[:5]
+	v := c.Check(1, engine_util.CfWrite, writeKey(short))
+	require.Equal(t, &Violation{RegionID: 1, CF: engine_util.CfWrite, Key: short, Reason: ReasonShortKey}, v)
+	c.Check(2, engine_util.CfLock, short)
+	c.Check(3, engine_util.CfDefault, writeKey(append(recordKey(1, 10), 0)))
+
+	require.Equal(t, map[string]uint64{ReasonShortKey: 2, ReasonBadRecordKey: 1}, c.Counts())
+	recent := c.Recent()
+	require.Len(t, recent, 2)
+	require.Equal(t, uint64(2), recent[0].RegionID)
+	require.Equal(t, uint64(3), recent[1].RegionID)
+}
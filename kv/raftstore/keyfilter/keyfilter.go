@@ -0,0 +1,155 @@
+package keyfilter
+
+import (
+	"sync"
+
+	"github.com/Connor1996/badger"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/meta"
+	"github.com/pingcap-incubator/tinykv/kv/util/bloom"
+	"github.com/pingcap-incubator/tinykv/kv/util/codec"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/log"
+	rspb "github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
+)
+
+// KeyFilters keeps a bloom filter over the user keys in the write CF of each region on the store, so a transactional
+// read of a missing key can return without touching badger.
+//
+// The filter of a region is built in the background on its first use, from a snapshot of the region's write CF. The
+// applier adds the keys it writes with OnPut before the writes are visible, so a filter never misses a key. A filter
+// is dropped with Invalidate when the data of the region changes other than by applying writes, e.g. on a merge or
+// a snapshot, and rebuilt on the next use.
+//
+// All methods may be called on a nil KeyFilters, which means the filters are disabled.
+type KeyFilters struct {
+	sync.Mutex
+	kvDB       *badger.DB
+	bitsPerKey int
+	regions    map[uint64]*regionFilter
+}
+
+// regionFilter is the filter of a region. pending keeps the keys written until the filter is built.
+type regionFilter struct {
+	sync.RWMutex
+	filter   *bloom.Filter
+	pending  [][]byte
+	building bool
+}
+
+func NewKeyFilters(kvDB *badger.DB, bitsPerKey int) *KeyFilters {
+	return &KeyFilters{
+		kvDB:       kvDB,
+		bitsPerKey: bitsPerKey,
+		regions:    make(map[uint64]*regionFilter),
+	}
+}
+
+// MayContain returns false if the write CF of the region surely has no version of the user key. Until the filter
+// of the region is built, it returns true.
+func (f *KeyFilters) MayContain(regionID uint64, key []byte) bool {
+	if f == nil {
+		return true
+	}
+	rf := f.get(regionID)
+	rf.RLock()
+	defer rf.RUnlock()
+	return rf.filter == nil || rf.filter.MayContain(key)
+}
+
+// OnPut adds the user key of a put to the write CF of the region. An apply observer calls it for every put
+// applied, before the put is written to the kv engine.
+func (f *KeyFilters) OnPut(regionID uint64, cf string, key []byte) {
+	if f == nil || cf != engine_util.CfWrite {
+		return
+	}
+	_, userKey, err := codec.DecodeBytes(key)
+	if err != nil {
+		return
+	}
+	rf := f.get(regionID)
+	rf.Lock()
+	defer rf.Unlock()
+	if rf.filter == nil {
+		rf.pending = append(rf.pending, userKey)
+		return
+	}
+	rf.filter.Add(userKey)
+}
+
+// Invalidate drops the filter of the region, it's rebuilt on the next use.
+func (f *KeyFilters) Invalidate(regionID uint64) {
+	if f == nil {
+		return
+	}
+	f.Lock()
+	defer f.Unlock()
+	delete(f.regions, regionID)
+}
+
+// get returns the filter of the region, starting to build it if it's not built.
+func (f *KeyFilters) get(regionID uint64) *regionFilter {
+	f.Lock()
+	defer f.Unlock()
+	rf, ok := f.regions[regionID]
+	if !ok {
+		rf = new(regionFilter)
+		f.regions[regionID] = rf
+	}
+	rf.Lock()
+	defer rf.Unlock()
+	if rf.filter == nil && !rf.building {
+		rf.building = true
+		go f.build(regionID, rf)
+	}
+	return rf
+}
+
+// build fills the filter of the region from a snapshot of its write CF. The keys put since rf is registered are
+// either in the snapshot or in rf.pending. The filter is sized for twice the keys, so it stays accurate while the
+// region grows.
+func (f *KeyFilters) build(regionID uint64, rf *regionFilter) {
+	regionState, err := meta.GetRegionLocalState(f.kvDB, regionID)
+	if err != nil || regionState.State == rspb.PeerState_Tombstone {
+		// the peer is not initialized yet, retry on the next use
+		rf.Lock()
+		rf.building = false
+		rf.Unlock()
+		return
+	}
+	region := regionState.Region
+
+	txn := f.kvDB.NewTransaction(false)
+	defer txn.Discard()
+	var keys [][]byte
+	iter := engine_util.NewCFIterator(engine_util.CfWrite, txn)
+	for iter.Seek(region.StartKey); iter.Valid(); iter.Next() {
+		item := iter.Item()
+		if engine_util.ExceedEndKey(item.Key(), region.EndKey) {
+			break
+		}
+		_, userKey, err := codec.DecodeBytes(item.KeyCopy(nil))
+		if err != nil {
+			continue
+		}
+		// versions of a key are adjacent
+		if len(keys) > 0 && string(keys[len(keys)-1]) == string(userKey) {
+			continue
+		}
+		keys = append(keys, userKey)
+	}
+	iter.Close()
+
+	rf.Lock()
+	defer rf.Unlock()
+	filter := bloom.New(2*(len(keys)+len(rf.pending)), f.bitsPerKey)
+	for _, key := range keys {
+		filter.Add(key)
+	}
+	for _, key := range rf.pending {
+		filter.Add(key)
+	}
+	rf.filter = filter
+	rf.pending = nil
+	rf.building = false
+	log.Debugf("built key filter of region %d with %d keys", regionID, len(keys))
+}
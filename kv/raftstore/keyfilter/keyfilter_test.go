@@ -0,0 +1,70 @@
+package keyfilter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/meta"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
+	"github.com/pingcap-incubator/tinykv/kv/util/codec"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	rspb "github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
+	"github.com/stretchr/testify/require"
+)
+
+func writeKey(key []byte, ts byte) []byte {
+	return append(codec.EncodeBytes(key), 0, 0, 0, 0, 0, 0, 0, ^ts)
+}
+
+func waitBuilt(f *KeyFilters, regionID uint64) {
+	for i := 0; i < 100; i++ {
+		rf := f.get(regionID)
+		rf.RLock()
+		built := rf.filter != nil
+		rf.RUnlock()
+		if built {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestKeyFilters(t *testing.T) {
+	engines := util.NewTestEngines()
+	defer engines.Destroy()
+
+	kvWB := new(engine_util.WriteBatch)
+	meta.WriteRegionState(kvWB, &metapb.Region{Id: 1, RegionEpoch: &metapb.RegionEpoch{}}, rspb.PeerState_Normal)
+	kvWB.SetCF(engine_util.CfWrite, writeKey([]byte("a"), 2), []byte{1})
+	kvWB.SetCF(engine_util.CfWrite, writeKey([]byte("a"), 1), []byte{1})
+	kvWB.SetCF(engine_util.CfWrite, writeKey([]byte("c"), 1), []byte{1})
+	require.Nil(t, engines.WriteKV(kvWB))
+
+	f := NewKeyFilters(engines.Kv, 10)
+	// a put before the filter is built is kept
+	f.OnPut(1, engine_util.CfWrite, writeKey([]byte("d"), 3))
+	f.OnPut(1, engine_util.CfLock, []byte("e"))
+	waitBuilt(f, 1)
+	require.True(t, f.MayContain(1, []byte("a")))
+	require.True(t, f.MayContain(1, []byte("c")))
+	require.True(t, f.MayContain(1, []byte("d")))
+	require.False(t, f.MayContain(1, []byte("b")))
+	require.False(t, f.MayContain(1, []byte("e")))
+
+	f.OnPut(1, engine_util.CfWrite, writeKey([]byte("b"), 3))
+	require.True(t, f.MayContain(1, []byte("b")))
+
+	// rebuilt from the engine after invalidated
+	f.Invalidate(1)
+	waitBuilt(f, 1)
+	require.True(t, f.MayContain(1, []byte("a")))
+	require.False(t, f.MayContain(1, []byte("b")))
+
+	// a region which is not on the store is never filtered
+	require.True(t, f.MayContain(2, []byte("b")))
+
+	var disabled *KeyFilters
+	disabled.OnPut(1, engine_util.CfWrite, writeKey([]byte("a"), 1))
+	require.True(t, disabled.MayContain(1, []byte("b")))
+}
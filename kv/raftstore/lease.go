@@ -0,0 +1,125 @@
+package raftstore
+
+import (
+	"sort"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+)
+
+// maxPendingHeartbeats bounds the send times of unanswered heartbeats kept per peer. A heartbeat sent while the
+// bound is reached isn't recorded, so a response to it is taken for the response to an earlier one, which only
+// shortens the lease.
+const maxPendingHeartbeats = 16
+
+// leaderLease is the time a leader is sure to stay the leader, so it can serve reads from its applied state without
+// a raft round trip. Once a quorum of the region answers heartbeats sent at t, no other peer can be elected before
+// t plus the election timeout, so the lease lasts until t plus maxLease, which is shorter than the election
+// timeout to cover the clock drift between the stores. The times are read from the monotonic clock.
+//
+// A heartbeat response doesn't tell which heartbeat it answers, so it's taken for the response to the oldest
+// unanswered heartbeat to the peer, which is never later than the one it answers.
+//
+// A nil leaderLease is never valid.
+type leaderLease struct {
+	maxLease time.Duration
+	// the term of the lease, the lease is reset when the term changes
+	term uint64
+	// the lease is valid before bound
+	bound time.Time
+	// the send times of the unanswered heartbeats to each peer, the oldest first
+	pending map[uint64][]time.Time
+	// the send time of the latest answered heartbeat to each peer
+	acked map[uint64]time.Time
+}
+
+func newLeaderLease(maxLease time.Duration) *leaderLease {
+	if maxLease <= 0 {
+		return nil
+	}
+	return &leaderLease{
+		maxLease: maxLease,
+		pending:  make(map[uint64][]time.Time),
+		acked:    make(map[uint64]time.Time),
+	}
+}
+
+// onHeartbeatsSent records the send time of the heartbeats in msgs, sent by the leader of term.
+func (l *leaderLease) onHeartbeatsSent(term uint64, msgs []eraftpb.Message, now time.Time) {
+	if l == nil {
+		return
+	}
+	for _, msg := range msgs {
+		if msg.MsgType != eraftpb.MessageType_MsgHeartbeat {
+			continue
+		}
+		if msg.Term != term {
+			continue
+		}
+		l.checkTerm(term)
+		if len(l.pending[msg.To]) < maxPendingHeartbeats {
+			l.pending[msg.To] = append(l.pending[msg.To], now)
+		}
+	}
+}
+
+// onHeartbeatResponse records a heartbeat response of the peer in term, then renews the lease if a quorum of the
+// voters answered heartbeats sent later than the lease was renewed for.
+func (l *leaderLease) onHeartbeatResponse(term, from uint64, voters []uint64, self uint64, now time.Time) {
+	if l == nil || term != l.term {
+		return
+	}
+	pending := l.pending[from]
+	if len(pending) == 0 {
+		return
+	}
+	l.acked[from] = pending[0]
+	l.pending[from] = pending[1:]
+	l.renew(voters, self, now)
+}
+
+func (l *leaderLease) renew(voters []uint64, self uint64, now time.Time) {
+	sendTimes := make([]time.Time, 0, len(voters))
+	for _, id := range voters {
+		if id == self {
+			sendTimes = append(sendTimes, now)
+		} else {
+			sendTimes = append(sendTimes, l.acked[id])
+		}
+	}
+	if len(sendTimes) == 0 {
+		return
+	}
+	sort.Slice(sendTimes, func(i, j int) bool { return sendTimes[i].After(sendTimes[j]) })
+	// the latest time a quorum answered heartbeats sent after
+	quorumTime := sendTimes[len(sendTimes)/2]
+	if quorumTime.IsZero() {
+		return
+	}
+	if bound := quorumTime.Add(l.maxLease); bound.After(l.bound) {
+		l.bound = bound
+	}
+}
+
+// valid tells whether the lease of term is valid at now.
+func (l *leaderLease) valid(term uint64, now time.Time) bool {
+	return l != nil && term == l.term && now.Before(l.bound)
+}
+
+// expire ends the lease, e.g. when the leadership may be transferred or the region is split, the heartbeats sent
+// before don't renew it.
+func (l *leaderLease) expire() {
+	if l == nil {
+		return
+	}
+	l.bound = time.Time{}
+	l.pending = make(map[uint64][]time.Time)
+	l.acked = make(map[uint64]time.Time)
+}
+
+func (l *leaderLease) checkTerm(term uint64) {
+	if term != l.term {
+		l.expire()
+		l.term = term
+	}
+}
@@ -0,0 +1,53 @@
+package raftstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeaderLease(t *testing.T) {
+	assert.Nil(t, newLeaderLease(0))
+	assert.False(t, (*leaderLease)(nil).valid(1, time.Now()))
+
+	l := newLeaderLease(time.Second)
+	voters := []uint64{1, 2, 3}
+	heartbeats := []eraftpb.Message{
+		{MsgType: eraftpb.MessageType_MsgHeartbeat, To: 2, Term: 5},
+		{MsgType: eraftpb.MessageType_MsgHeartbeat, To: 3, Term: 5},
+		{MsgType: eraftpb.MessageType_MsgAppend, To: 3, Term: 5},
+	}
+	t0 := time.Now()
+	l.onHeartbeatsSent(5, heartbeats, t0)
+	l.onHeartbeatsSent(5, heartbeats, t0.Add(100*time.Millisecond))
+	assert.False(t, l.valid(5, t0))
+
+	// the response is taken for the one to the oldest heartbeat
+	l.onHeartbeatResponse(5, 2, voters, 1, t0.Add(200*time.Millisecond))
+	assert.True(t, l.valid(5, t0.Add(900*time.Millisecond)))
+	assert.False(t, l.valid(5, t0.Add(time.Second)))
+	assert.False(t, l.valid(6, t0.Add(500*time.Millisecond)))
+	l.onHeartbeatResponse(5, 2, voters, 1, t0.Add(200*time.Millisecond))
+	assert.True(t, l.valid(5, t0.Add(1050*time.Millisecond)))
+	// no heartbeat is pending
+	l.onHeartbeatResponse(5, 2, voters, 1, t0.Add(2*time.Second))
+	assert.False(t, l.valid(5, t0.Add(1100*time.Millisecond)))
+	// a response of a stale term
+	l.onHeartbeatResponse(4, 3, voters, 1, t0.Add(2*time.Second))
+	assert.Equal(t, 2, len(l.pending[3]))
+
+	l.expire()
+	assert.False(t, l.valid(5, t0.Add(500*time.Millisecond)))
+	l.onHeartbeatResponse(5, 3, voters, 1, t0.Add(200*time.Millisecond))
+	assert.False(t, l.valid(5, t0.Add(500*time.Millisecond)))
+
+	// a new term resets the lease
+	l.onHeartbeatsSent(5, heartbeats, t0)
+	l.onHeartbeatResponse(5, 3, voters, 1, t0.Add(100*time.Millisecond))
+	assert.True(t, l.valid(5, t0.Add(500*time.Millisecond)))
+	l.onHeartbeatsSent(6, []eraftpb.Message{{MsgType: eraftpb.MessageType_MsgHeartbeat, To: 2, Term: 6}}, t0)
+	assert.False(t, l.valid(6, t0.Add(500*time.Millisecond)))
+	assert.False(t, l.valid(5, t0.Add(500*time.Millisecond)))
+}
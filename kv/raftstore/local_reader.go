@@ -0,0 +1,81 @@
+package raftstore
+
+import (
+	"time"
+
+	"github.com/Connor1996/badger"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/message"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
+)
+
+// readLocally answers a read-only command from the applied state of the leader, without a raft round trip, if the
+// leader lease is valid or the leader is the only peer of the region. The leader must have applied an entry of its
+// term, so the entries committed by the previous leaders are applied too. It returns false if the command must be
+// proposed instead.
+func (d *peerMsgHandler) readLocally(req *raft_cmdpb.RaftCmdRequest, cb *message.Callback) bool {
+	cmd, err := util.ParseCmd(req)
+	if err != nil {
+		return false
+	}
+	read, ok := cmd.(*util.ReadCmd)
+	if !ok || !d.IsLeader() {
+		return false
+	}
+	if d.lease == nil || (len(util.ConfStateFromRegion(d.Region()).Nodes) > 1 && !d.lease.valid(d.Term(), time.Now())) {
+		return false
+	}
+	if term, err := d.peerStorage.Term(d.peerStorage.AppliedIndex()); err != nil || term != d.Term() {
+		return false
+	}
+
+	resp := newCmdResp()
+	BindRespTerm(resp, d.Term())
+	region := d.Region()
+	for _, r := range read.Requests {
+		switch r.CmdType {
+		case raft_cmdpb.CmdType_Get:
+			value, err := d.getLocally(r.Get.Cf, r.Get.Key)
+			if err != nil {
+				if cb.Txn != nil {
+					cb.Txn.Discard()
+					cb.Txn = nil
+				}
+				cb.Done(ErrResp(err))
+				return true
+			}
+			resp.Responses = append(resp.Responses, &raft_cmdpb.Response{
+				CmdType: raft_cmdpb.CmdType_Get,
+				Get:     &raft_cmdpb.GetResponse{Value: value},
+			})
+		case raft_cmdpb.CmdType_Snap:
+			if cb.Txn == nil {
+				cb.Txn = d.ctx.engine.Kv.NewTransaction(false)
+				if d.ctx.lockTable != nil {
+					cb.Locks = d.ctx.lockTable.Snapshot(region.StartKey, region.EndKey)
+				}
+			}
+			resp.Responses = append(resp.Responses, &raft_cmdpb.Response{
+				CmdType: raft_cmdpb.CmdType_Snap,
+				Snap:    &raft_cmdpb.SnapResponse{Region: region},
+			})
+		}
+	}
+	cb.Done(resp)
+	return true
+}
+
+func (d *peerMsgHandler) getLocally(cf string, key []byte) ([]byte, error) {
+	if err := util.CheckKeyInRegion(key, d.Region()); err != nil {
+		return nil, err
+	}
+	if cf == engine_util.CfLock && d.ctx.lockTable != nil {
+		return d.ctx.lockTable.Snapshot(key, append(append([]byte(nil), key...), 0)).Get(key), nil
+	}
+	value, err := engine_util.GetCF(d.ctx.engine.Kv, cf, key)
+	if err == badger.ErrKeyNotFound {
+		return nil, nil
+	}
+	return value, err
+}
@@ -0,0 +1,260 @@
+package lockindex
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/Connor1996/badger"
+	"github.com/petar/GoLLRB/llrb"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/meta"
+	"github.com/pingcap-incubator/tinykv/kv/transaction/mvcc"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/log"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	rspb "github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
+)
+
+// LockIndex keeps an ordered index of the locks in the lock CF of each region on the store in memory, by key and by
+// start ts, so ScanLock, the resolved ts of a region and the lock checks of the 2PC are answered without iterating
+// the lock CF.
+//
+// Like the key filters, the index of a region is built in the background on its first use, from a snapshot of the
+// region's lock CF. The applier applies the lock CF writes to the index with OnPut and OnDelete before the writes are
+// visible, the writes applied while the index is built are replayed on it once it's built. An index is dropped with
+// Invalidate when the data of the region changes other than by applying writes, e.g. on a split or a snapshot, and
+// rebuilt on the next use, so after a restart all the indexes are rebuilt from the lock CF.
+//
+// The index is at the latest applied write of the region, it may be ahead of the snapshot a reader reads.
+//
+// All methods may be called on a nil LockIndex, which means the index is disabled.
+type LockIndex struct {
+	sync.Mutex
+	kvDB    *badger.DB
+	regions map[uint64]*regionIndex
+}
+
+// regionIndex is the index of a region. pending keeps the writes applied until the index is built.
+type regionIndex struct {
+	sync.RWMutex
+	byKey    *llrb.LLRB
+	byTs     *llrb.LLRB
+	pending  []lockItem
+	built    bool
+	building bool
+}
+
+// lockItem is a lock ordered by key, a nil lock is a deletion in pending.
+type lockItem struct {
+	key  []byte
+	lock *mvcc.Lock
+}
+
+func (it lockItem) Less(than llrb.Item) bool {
+	return bytes.Compare(it.key, than.(lockItem).key) < 0
+}
+
+// tsItem is a lock ordered by start ts then key.
+type tsItem struct {
+	ts  uint64
+	key []byte
+}
+
+func (it tsItem) Less(than llrb.Item) bool {
+	other := than.(tsItem)
+	if it.ts != other.ts {
+		return it.ts < other.ts
+	}
+	return bytes.Compare(it.key, other.key) < 0
+}
+
+func NewLockIndex(kvDB *badger.DB) *LockIndex {
+	return &LockIndex{
+		kvDB:    kvDB,
+		regions: make(map[uint64]*regionIndex),
+	}
+}
+
+// OnPut applies a put of the region to the index if it's in the lock CF.
+func (idx *LockIndex) OnPut(regionID uint64, cf string, key, value []byte) {
+	if idx == nil || cf != engine_util.CfLock {
+		return
+	}
+	lock, err := mvcc.ParseLock(value)
+	if err != nil {
+		log.Warnf("lock index of region %d skips invalid lock of key %q: %v", regionID, key, err)
+		return
+	}
+	idx.write(regionID, lockItem{key: key, lock: lock})
+}
+
+// OnDelete applies a delete of the region to the index if it's in the lock CF.
+func (idx *LockIndex) OnDelete(regionID uint64, cf string, key []byte) {
+	if idx == nil || cf != engine_util.CfLock {
+		return
+	}
+	idx.write(regionID, lockItem{key: key})
+}
+
+func (idx *LockIndex) write(regionID uint64, item lockItem) {
+	ri := idx.get(regionID)
+	ri.Lock()
+	defer ri.Unlock()
+	if !ri.built {
+		ri.pending = append(ri.pending, item)
+		return
+	}
+	ri.apply(item)
+}
+
+// apply puts the lock of the item, or deletes it if the lock is nil.
+func (ri *regionIndex) apply(item lockItem) {
+	if old := ri.byKey.Get(lockItem{key: item.key}); old != nil {
+		ri.byTs.Delete(tsItem{ts: old.(lockItem).lock.Ts, key: item.key})
+		ri.byKey.Delete(old)
+	}
+	if item.lock != nil {
+		ri.byKey.ReplaceOrInsert(item)
+		ri.byTs.ReplaceOrInsert(tsItem{ts: item.lock.Ts, key: item.key})
+	}
+}
+
+// Invalidate drops the index of the region, it's rebuilt on the next use.
+func (idx *LockIndex) Invalidate(regionID uint64) {
+	if idx == nil {
+		return
+	}
+	idx.Lock()
+	defer idx.Unlock()
+	delete(idx.regions, regionID)
+}
+
+// get returns the index of the region, starting to build it if it's not built.
+func (idx *LockIndex) get(regionID uint64) *regionIndex {
+	idx.Lock()
+	defer idx.Unlock()
+	ri, ok := idx.regions[regionID]
+	if !ok {
+		ri = &regionIndex{byKey: llrb.New(), byTs: llrb.New()}
+		idx.regions[regionID] = ri
+	}
+	ri.Lock()
+	defer ri.Unlock()
+	if !ri.built && !ri.building {
+		ri.building = true
+		go idx.build(regionID, ri)
+	}
+	return ri
+}
+
+// build fills the index of the region from a snapshot of its lock CF. The writes applied since ri is registered are
+// either in the snapshot or in ri.pending, replaying them in order on the snapshot gives the latest locks as every
+// write replaces or deletes a whole lock.
+func (idx *LockIndex) build(regionID uint64, ri *regionIndex) {
+	regionState, err := meta.GetRegionLocalState(idx.kvDB, regionID)
+	if err != nil || regionState.State == rspb.PeerState_Tombstone {
+		// the peer is not initialized yet, retry on the next use
+		ri.Lock()
+		ri.building = false
+		ri.Unlock()
+		return
+	}
+	region := regionState.Region
+
+	txn := idx.kvDB.NewTransaction(false)
+	defer txn.Discard()
+	var items []lockItem
+	iter := engine_util.NewCFIterator(engine_util.CfLock, txn)
+	for iter.Seek(region.StartKey); iter.Valid(); iter.Next() {
+		item := iter.Item()
+		if engine_util.ExceedEndKey(item.Key(), region.EndKey) {
+			break
+		}
+		value, err := item.Value()
+		if err != nil {
+			continue
+		}
+		lock, err := mvcc.ParseLock(value)
+		if err != nil {
+			continue
+		}
+		items = append(items, lockItem{key: item.KeyCopy(nil), lock: lock})
+	}
+	iter.Close()
+
+	ri.Lock()
+	defer ri.Unlock()
+	for _, item := range items {
+		ri.apply(item)
+	}
+	for _, item := range ri.pending {
+		ri.apply(item)
+	}
+	ri.pending = nil
+	ri.built = true
+	ri.building = false
+	log.Debugf("built lock index of region %d with %d locks", regionID, ri.byKey.Len())
+}
+
+// built returns the index of the region locked for reading, nil if it's not built yet. The caller must unlock it.
+func (idx *LockIndex) built(regionID uint64) *regionIndex {
+	if idx == nil {
+		return nil
+	}
+	ri := idx.get(regionID)
+	ri.RLock()
+	if !ri.built {
+		ri.RUnlock()
+		return nil
+	}
+	return ri
+}
+
+// GetLock returns the lock of the key in the region, nil if the key isn't locked. It returns false if the index
+// isn't built, the caller should read the lock CF instead.
+func (idx *LockIndex) GetLock(regionID uint64, key []byte) (*mvcc.Lock, bool) {
+	ri := idx.built(regionID)
+	if ri == nil {
+		return nil, false
+	}
+	defer ri.RUnlock()
+	if item := ri.byKey.Get(lockItem{key: key}); item != nil {
+		return item.(lockItem).lock, true
+	}
+	return nil, true
+}
+
+// ScanLocks returns the locks in [startKey, endKey) of the region whose start ts is not after maxTs, at most limit
+// of them unless limit is 0. It returns false if the index isn't built, the caller should read the lock CF instead.
+func (idx *LockIndex) ScanLocks(regionID uint64, maxTs uint64, startKey, endKey []byte, limit uint32) ([]*kvrpcpb.LockInfo, bool) {
+	ri := idx.built(regionID)
+	if ri == nil {
+		return nil, false
+	}
+	defer ri.RUnlock()
+	var infos []*kvrpcpb.LockInfo
+	ri.byKey.AscendGreaterOrEqual(lockItem{key: startKey}, func(i llrb.Item) bool {
+		item := i.(lockItem)
+		if engine_util.ExceedEndKey(item.key, endKey) {
+			return false
+		}
+		if item.lock.Ts <= maxTs {
+			infos = append(infos, item.lock.Info(item.key))
+		}
+		return limit == 0 || uint32(len(infos)) < limit
+	})
+	return infos, true
+}
+
+// MinLockTs returns the smallest start ts of the locks of the region, mvcc.TsMax if there's none. The resolved ts of
+// the region can't advance past it. It returns false if the index isn't built.
+func (idx *LockIndex) MinLockTs(regionID uint64) (uint64, bool) {
+	ri := idx.built(regionID)
+	if ri == nil {
+		return 0, false
+	}
+	defer ri.RUnlock()
+	if min := ri.byTs.Min(); min != nil {
+		return min.(tsItem).ts, true
+	}
+	return mvcc.TsMax, true
+}
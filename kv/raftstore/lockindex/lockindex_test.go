@@ -0,0 +1,94 @@
+package lockindex
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/meta"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
+	"github.com/pingcap-incubator/tinykv/kv/transaction/mvcc"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	rspb "github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
+	"github.com/stretchr/testify/require"
+)
+
+func lockValue(ts uint64) []byte {
+	return (&mvcc.Lock{Primary: []byte("p"), Ts: ts, Ttl: 10, Kind: mvcc.WriteKindPut}).ToBytes()
+}
+
+func waitBuilt(idx *LockIndex, regionID uint64) {
+	for i := 0; i < 100; i++ {
+		if _, ok := idx.MinLockTs(regionID); ok {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func scanKeys(t *testing.T, idx *LockIndex, maxTs uint64, startKey, endKey string, limit uint32) []string {
+	infos, ok := idx.ScanLocks(1, maxTs, []byte(startKey), []byte(endKey), limit)
+	require.True(t, ok)
+	var keys []string
+	for _, info := range infos {
+		keys = append(keys, string(info.Key))
+	}
+	return keys
+}
+
+func TestLockIndex(t *testing.T) {
+	engines := util.NewTestEngines()
+	defer engines.Destroy()
+
+	kvWB := new(engine_util.WriteBatch)
+	meta.WriteRegionState(kvWB, &metapb.Region{Id: 1, EndKey: []byte("x"), RegionEpoch: &metapb.RegionEpoch{}}, rspb.PeerState_Normal)
+	kvWB.SetCF(engine_util.CfLock, []byte("a"), lockValue(5))
+	kvWB.SetCF(engine_util.CfLock, []byte("c"), lockValue(3))
+	kvWB.SetCF(engine_util.CfLock, []byte("y"), lockValue(1))
+	require.Nil(t, engines.WriteKV(kvWB))
+
+	idx := NewLockIndex(engines.Kv)
+	// the writes before the index is built are replayed
+	idx.OnPut(1, engine_util.CfLock, []byte("d"), lockValue(7))
+	idx.OnDelete(1, engine_util.CfLock, []byte("a"))
+	idx.OnPut(1, engine_util.CfWrite, []byte("e"), []byte{1})
+	waitBuilt(idx, 1)
+
+	require.Equal(t, []string{"c", "d"}, scanKeys(t, idx, mvcc.TsMax, "", "", 0))
+	require.Equal(t, []string{"c"}, scanKeys(t, idx, 5, "", "", 0))
+	require.Equal(t, []string{"d"}, scanKeys(t, idx, mvcc.TsMax, "d", "", 0))
+	require.Equal(t, []string{"c"}, scanKeys(t, idx, mvcc.TsMax, "", "", 1))
+	minTs, ok := idx.MinLockTs(1)
+	require.True(t, ok)
+	require.Equal(t, uint64(3), minTs)
+
+	// a lock replaced by a newer transaction is reindexed by ts
+	idx.OnPut(1, engine_util.CfLock, []byte("c"), lockValue(9))
+	lock, ok := idx.GetLock(1, []byte("c"))
+	require.True(t, ok)
+	require.Equal(t, uint64(9), lock.Ts)
+	minTs, _ = idx.MinLockTs(1)
+	require.Equal(t, uint64(7), minTs)
+
+	idx.OnDelete(1, engine_util.CfLock, []byte("c"))
+	idx.OnDelete(1, engine_util.CfLock, []byte("d"))
+	lock, ok = idx.GetLock(1, []byte("c"))
+	require.True(t, ok)
+	require.Nil(t, lock)
+	minTs, _ = idx.MinLockTs(1)
+	require.Equal(t, mvcc.TsMax, minTs)
+
+	// rebuilt from the engine after invalidated
+	idx.Invalidate(1)
+	waitBuilt(idx, 1)
+	require.Equal(t, []string{"a", "c"}, scanKeys(t, idx, mvcc.TsMax, "", "", 0))
+
+	// a region which isn't initialized is never built
+	_, ok = idx.GetLock(2, []byte("a"))
+	require.False(t, ok)
+
+	var disabled *LockIndex
+	disabled.OnPut(1, engine_util.CfLock, []byte("a"), lockValue(1))
+	_, ok = disabled.ScanLocks(1, mvcc.TsMax, nil, nil, 0)
+	require.False(t, ok)
+}
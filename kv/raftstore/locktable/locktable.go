@@ -0,0 +1,325 @@
+package locktable
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	"github.com/Connor1996/badger"
+	"github.com/Connor1996/badger/y"
+	"github.com/petar/GoLLRB/llrb"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/meta"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/log"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
+	rspb "github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
+)
+
+// LockTable keeps the lock CF of the store in memory, so the conflict checks of the 2PC hot path don't touch badger.
+//
+// The applier writes its batches with Write, which applies the lock CF entries to the table instead of the kv engine.
+// The locks of a region are only persisted by Checkpoint, which writes them to the kv engine together with the
+// applied index they are at. The applier checkpoints a region when it applies a CompactLog, so the raft log after the
+// checkpoint is never truncated, and when it applies a split or merge, so the epoch of the region is the same for
+// all the entries after the checkpoint. On restart, Recover loads the checkpoints and replays the lock CF entries
+// of the raft log after them.
+type LockTable struct {
+	sync.RWMutex
+	kvDB  *badger.DB
+	locks *llrb.LLRB
+}
+
+func NewLockTable(kvDB *badger.DB) *LockTable {
+	return &LockTable{
+		kvDB:  kvDB,
+		locks: llrb.New(),
+	}
+}
+
+type lockItem struct {
+	key   []byte
+	value []byte
+}
+
+func (it lockItem) Less(than llrb.Item) bool {
+	return bytes.Compare(it.key, than.(lockItem).key) < 0
+}
+
+// Write writes the batch to the kv engine, except for the entries of the lock CF which are removed from the batch
+// and applied to the table. The writes are visible to Snapshot and NewTransaction at once.
+func (t *LockTable) Write(wb *engine_util.WriteBatch) error {
+	keys, values := wb.TakeCF(engine_util.CfLock)
+	t.Lock()
+	defer t.Unlock()
+	if err := wb.WriteToDB(t.kvDB); err != nil {
+		return err
+	}
+	for i, key := range keys {
+		t.apply(key, values[i])
+	}
+	return nil
+}
+
+// apply puts the lock, or deletes it if value is nil.
+func (t *LockTable) apply(key, value []byte) {
+	if value == nil {
+		t.locks.Delete(lockItem{key: key})
+		return
+	}
+	t.locks.ReplaceOrInsert(lockItem{key: key, value: value})
+}
+
+// Snapshot returns a copy of the locks in [startKey, endKey), which is not changed by later writes.
+func (t *LockTable) Snapshot(startKey, endKey []byte) *Snapshot {
+	t.RLock()
+	defer t.RUnlock()
+	return &Snapshot{items: t.scan(startKey, endKey)}
+}
+
+func (t *LockTable) scan(startKey, endKey []byte) []lockItem {
+	var items []lockItem
+	t.locks.AscendGreaterOrEqual(lockItem{key: startKey}, func(i llrb.Item) bool {
+		item := i.(lockItem)
+		if engine_util.ExceedEndKey(item.key, endKey) {
+			return false
+		}
+		items = append(items, item)
+		return true
+	})
+	return items
+}
+
+// Checkpoint persists the locks of the region, which are at the applied index, to the kv engine.
+func (t *LockTable) Checkpoint(region *metapb.Region, index uint64) error {
+	t.Lock()
+	defer t.Unlock()
+	return t.checkpoint(region, index)
+}
+
+func (t *LockTable) checkpoint(region *metapb.Region, index uint64) error {
+	wb := new(engine_util.WriteBatch)
+	txn := t.kvDB.NewTransaction(false)
+	iter := engine_util.NewCFIterator(engine_util.CfLock, txn)
+	for iter.Seek(region.StartKey); iter.Valid(); iter.Next() {
+		key := iter.Item().KeyCopy(nil)
+		if engine_util.ExceedEndKey(key, region.EndKey) {
+			break
+		}
+		if t.locks.Get(lockItem{key: key}) == nil {
+			wb.DeleteCF(engine_util.CfLock, key)
+		}
+	}
+	iter.Close()
+	txn.Discard()
+	for _, item := range t.scan(region.StartKey, region.EndKey) {
+		wb.SetCF(engine_util.CfLock, item.key, item.value)
+	}
+	if err := wb.SetMeta(meta.LockCheckpointKey(region.Id), &rspb.LockCheckpoint{Index: index}); err != nil {
+		return err
+	}
+	return wb.WriteToDB(t.kvDB)
+}
+
+// NewTransaction checkpoints the region at its applied index and returns a transaction of the kv engine whose lock
+// CF is the same as the table's, it's used to generate a snapshot of the region.
+func (t *LockTable) NewTransaction(regionID uint64) (*badger.Txn, error) {
+	t.Lock()
+	defer t.Unlock()
+	regionState, err := meta.GetRegionLocalState(t.kvDB, regionID)
+	if err != nil {
+		return nil, err
+	}
+	applyState, err := meta.GetApplyState(t.kvDB, regionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.checkpoint(regionState.Region, applyState.AppliedIndex); err != nil {
+		return nil, err
+	}
+	return t.kvDB.NewTransaction(false), nil
+}
+
+// Reload replaces the locks of the region with the lock CF of the kv engine, which is at the index. It's called
+// after a snapshot is applied to the region.
+func (t *LockTable) Reload(region *metapb.Region, index uint64) error {
+	t.Lock()
+	defer t.Unlock()
+	t.deleteRange(region.StartKey, region.EndKey)
+	t.load(region.StartKey, region.EndKey)
+	wb := new(engine_util.WriteBatch)
+	if err := wb.SetMeta(meta.LockCheckpointKey(region.Id), &rspb.LockCheckpoint{Index: index}); err != nil {
+		return err
+	}
+	return wb.WriteToDB(t.kvDB)
+}
+
+// DeleteRange drops the locks in [startKey, endKey), it's called when the data of the range is cleaned up.
+func (t *LockTable) DeleteRange(startKey, endKey []byte) {
+	t.Lock()
+	defer t.Unlock()
+	t.deleteRange(startKey, endKey)
+}
+
+func (t *LockTable) deleteRange(startKey, endKey []byte) {
+	for _, item := range t.scan(startKey, endKey) {
+		t.locks.Delete(item)
+	}
+}
+
+// load reads the locks in [startKey, endKey) from the kv engine.
+func (t *LockTable) load(startKey, endKey []byte) int {
+	txn := t.kvDB.NewTransaction(false)
+	defer txn.Discard()
+	iter := engine_util.NewCFIterator(engine_util.CfLock, txn)
+	defer iter.Close()
+	count := 0
+	for iter.Seek(startKey); iter.Valid(); iter.Next() {
+		item := iter.Item()
+		if engine_util.ExceedEndKey(item.Key(), endKey) {
+			break
+		}
+		value, err := item.ValueCopy(nil)
+		if err != nil {
+			continue
+		}
+		t.locks.ReplaceOrInsert(lockItem{key: item.KeyCopy(nil), value: value})
+		count++
+	}
+	return count
+}
+
+// Recover rebuilds the table on startup from the checkpoints of the regions and their raft logs. A region without
+// a checkpoint has its locks in the kv engine already, e.g. it was written with the lock CF on disk, so it's
+// checkpointed at its applied index.
+func (t *LockTable) Recover(raftDB *badger.DB, regions []*metapb.Region) error {
+	t.Lock()
+	defer t.Unlock()
+	loaded := t.load(nil, nil)
+	replayed := 0
+	for _, region := range regions {
+		applyState, err := meta.GetApplyState(t.kvDB, region.Id)
+		if err != nil {
+			return err
+		}
+		checkpoint := new(rspb.LockCheckpoint)
+		err = engine_util.GetMeta(t.kvDB, meta.LockCheckpointKey(region.Id), checkpoint)
+		if err == badger.ErrKeyNotFound {
+			if err := t.checkpoint(region, applyState.AppliedIndex); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		for idx := checkpoint.Index + 1; idx <= applyState.AppliedIndex; idx++ {
+			entry, err := meta.GetRaftEntry(raftDB, region.Id, idx)
+			if err != nil {
+				return err
+			}
+			replayed += t.replay(region, entry)
+		}
+	}
+	log.Infof("recovered lock table, %d locks loaded, %d lock writes replayed", loaded, replayed)
+	return nil
+}
+
+// replay applies the lock CF writes of the entry as the applier did, returning the count of them. The epoch of the
+// region is the same as when the entry was applied, so a request is skipped iff the applier skipped it.
+func (t *LockTable) replay(region *metapb.Region, entry *eraftpb.Entry) int {
+	if entry.EntryType != eraftpb.EntryType_EntryNormal || len(entry.Data) == 0 {
+		return 0
+	}
+	req, err := util.DecodeRaftCmd(entry.Data)
+	if err != nil || req.AdminRequest != nil || util.CheckRegionEpoch(req, region, false) != nil {
+		return 0
+	}
+	count := 0
+	for _, r := range req.Requests {
+		switch r.CmdType {
+		case raft_cmdpb.CmdType_Put:
+			if r.Put.Cf == engine_util.CfLock && util.CheckKeyInRegion(r.Put.Key, region) == nil {
+				t.apply(r.Put.Key, r.Put.Value)
+				count++
+			}
+		case raft_cmdpb.CmdType_Delete:
+			if r.Delete.Cf == engine_util.CfLock && util.CheckKeyInRegion(r.Delete.Key, region) == nil {
+				t.apply(r.Delete.Key, nil)
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// Snapshot is an immutable copy of the locks in a range, it's read as the lock CF of a region reader.
+type Snapshot struct {
+	items []lockItem
+}
+
+// Get returns the lock of the key, nil if there's none.
+func (s *Snapshot) Get(key []byte) []byte {
+	i := s.seek(key)
+	if i < len(s.items) && bytes.Equal(s.items[i].key, key) {
+		return s.items[i].value
+	}
+	return nil
+}
+
+func (s *Snapshot) seek(key []byte) int {
+	return sort.Search(len(s.items), func(i int) bool {
+		return bytes.Compare(s.items[i].key, key) >= 0
+	})
+}
+
+func (s *Snapshot) NewIterator() engine_util.DBIterator {
+	return &snapshotIterator{snap: s}
+}
+
+type snapshotIterator struct {
+	snap *Snapshot
+	pos  int
+}
+
+func (it *snapshotIterator) Item() engine_util.DBItem {
+	return snapshotItem(it.snap.items[it.pos])
+}
+
+func (it *snapshotIterator) Valid() bool {
+	return it.pos < len(it.snap.items)
+}
+
+func (it *snapshotIterator) Next() {
+	it.pos++
+}
+
+func (it *snapshotIterator) Seek(key []byte) {
+	it.pos = it.snap.seek(key)
+}
+
+func (it *snapshotIterator) Close() {}
+
+type snapshotItem lockItem
+
+func (it snapshotItem) Key() []byte {
+	return it.key
+}
+
+func (it snapshotItem) KeyCopy(dst []byte) []byte {
+	return y.SafeCopy(dst, it.key)
+}
+
+func (it snapshotItem) Value() ([]byte, error) {
+	return it.value, nil
+}
+
+func (it snapshotItem) ValueSize() int {
+	return len(it.value)
+}
+
+func (it snapshotItem) ValueCopy(dst []byte) ([]byte, error) {
+	return y.SafeCopy(dst, it.value), nil
+}
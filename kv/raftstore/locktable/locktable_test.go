@@ -0,0 +1,119 @@
+package locktable
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/meta"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
+	rspb "github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
+	"github.com/stretchr/testify/require"
+)
+
+func newRegion() *metapb.Region {
+	return &metapb.Region{Id: 1, RegionEpoch: &metapb.RegionEpoch{ConfVer: 1, Version: 1}}
+}
+
+func setApplied(t *testing.T, engines *engine_util.Engines, index uint64) {
+	kvWB := new(engine_util.WriteBatch)
+	require.Nil(t, kvWB.SetMeta(meta.ApplyStateKey(1), &rspb.RaftApplyState{AppliedIndex: index}))
+	require.Nil(t, engines.WriteKV(kvWB))
+}
+
+func appendEntry(t *testing.T, engines *engine_util.Engines, index uint64, version uint64, reqs ...*raft_cmdpb.Request) {
+	req := &raft_cmdpb.RaftCmdRequest{
+		Header:   &raft_cmdpb.RaftRequestHeader{RegionId: 1, RegionEpoch: &metapb.RegionEpoch{ConfVer: 1, Version: version}},
+		Requests: reqs,
+	}
+	data, err := util.EncodeRaftCmd(req, true)
+	require.Nil(t, err)
+	raftWB := new(engine_util.WriteBatch)
+	require.Nil(t, raftWB.SetMeta(meta.RaftLogKey(1, index), &eraftpb.Entry{Term: 1, Index: index, Data: data}))
+	require.Nil(t, engines.WriteRaft(raftWB))
+}
+
+func put(key, value string) *raft_cmdpb.Request {
+	return &raft_cmdpb.Request{
+		CmdType: raft_cmdpb.CmdType_Put,
+		Put:     &raft_cmdpb.PutRequest{Cf: engine_util.CfLock, Key: []byte(key), Value: []byte(value)},
+	}
+}
+
+func del(key string) *raft_cmdpb.Request {
+	return &raft_cmdpb.Request{
+		CmdType: raft_cmdpb.CmdType_Delete,
+		Delete:  &raft_cmdpb.DeleteRequest{Cf: engine_util.CfLock, Key: []byte(key)},
+	}
+}
+
+func TestLockTableWrite(t *testing.T) {
+	engines := util.NewTestEngines()
+	defer engines.Destroy()
+
+	table := NewLockTable(engines.Kv)
+	wb := new(engine_util.WriteBatch)
+	wb.SetCF(engine_util.CfLock, []byte("a"), []byte("1"))
+	wb.SetCF(engine_util.CfLock, []byte("b"), []byte("2"))
+	wb.SetCF(engine_util.CfDefault, []byte("a"), []byte("v"))
+	require.Nil(t, table.Write(wb))
+
+	// only the locks are kept in memory
+	_, err := engine_util.GetCF(engines.Kv, engine_util.CfLock, []byte("a"))
+	require.NotNil(t, err)
+	val, err := engine_util.GetCF(engines.Kv, engine_util.CfDefault, []byte("a"))
+	require.Nil(t, err)
+	require.Equal(t, []byte("v"), val)
+
+	snap := table.Snapshot(nil, nil)
+	wb = new(engine_util.WriteBatch)
+	wb.DeleteCF(engine_util.CfLock, []byte("a"))
+	require.Nil(t, table.Write(wb))
+	require.Equal(t, []byte("1"), snap.Get([]byte("a")))
+	require.Nil(t, table.Snapshot(nil, nil).Get([]byte("a")))
+
+	iter := snap.NewIterator()
+	iter.Seek([]byte("b"))
+	require.True(t, iter.Valid())
+	require.Equal(t, []byte("b"), iter.Item().Key())
+	iter.Next()
+	require.False(t, iter.Valid())
+
+	require.Nil(t, table.Checkpoint(newRegion(), 5))
+	_, err = engine_util.GetCF(engines.Kv, engine_util.CfLock, []byte("a"))
+	require.NotNil(t, err)
+	val, err = engine_util.GetCF(engines.Kv, engine_util.CfLock, []byte("b"))
+	require.Nil(t, err)
+	require.Equal(t, []byte("2"), val)
+}
+
+func TestLockTableRecover(t *testing.T) {
+	engines := util.NewTestEngines()
+	defer engines.Destroy()
+
+	region := newRegion()
+	table := NewLockTable(engines.Kv)
+	wb := new(engine_util.WriteBatch)
+	wb.SetCF(engine_util.CfLock, []byte("a"), []byte("1"))
+	require.Nil(t, table.Write(wb))
+	require.Nil(t, table.Checkpoint(region, 5))
+
+	appendEntry(t, engines, 6, 1, put("b", "2"), del("a"))
+	// rejected by the applier for the stale epoch
+	appendEntry(t, engines, 7, 0, put("c", "3"))
+	appendEntry(t, engines, 8, 1, put("d", "4"))
+	// not applied yet
+	appendEntry(t, engines, 9, 1, put("e", "5"))
+	setApplied(t, engines, 8)
+
+	table = NewLockTable(engines.Kv)
+	require.Nil(t, table.Recover(engines.Raft, []*metapb.Region{region}))
+	snap := table.Snapshot(nil, nil)
+	require.Nil(t, snap.Get([]byte("a")))
+	require.Equal(t, []byte("2"), snap.Get([]byte("b")))
+	require.Nil(t, snap.Get([]byte("c")))
+	require.Equal(t, []byte("4"), snap.Get([]byte("d")))
+	require.Nil(t, snap.Get([]byte("e")))
+}
@@ -0,0 +1,86 @@
+package raftstore
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/kv/config"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+)
+
+// logSyncer syncs the writes to the raft engine when it doesn't sync each write, see config.SyncLogBatched and
+// config.SyncLogPeriodic. A nil logSyncer means each write is synced.
+type logSyncer struct {
+	dir      string
+	periodic bool
+	interval time.Duration
+	// the start of the last sync, the value log files modified since are synced by the next one
+	lastSync time.Time
+	// the callbacks waiting for the next sync, only accessed by the raft worker
+	pending []func()
+}
+
+func newLogSyncer(cfg *config.Config, dir string) *logSyncer {
+	if cfg.RaftSyncLog == config.SyncLogAlways {
+		return nil
+	}
+	return &logSyncer{
+		dir:      dir,
+		periodic: cfg.RaftSyncLog == config.SyncLogPeriodic,
+		interval: cfg.RaftSyncLogInterval,
+	}
+}
+
+// afterSync calls fn once the writes to the raft engine so far are synced. It's called at once if each write is
+// synced or the raft engine is synced periodically, otherwise by the sync at the end of the round of the raft worker.
+func (s *logSyncer) afterSync(fn func()) {
+	if s == nil || s.periodic {
+		fn()
+		return
+	}
+	s.pending = append(s.pending, fn)
+}
+
+// sync syncs the raft engine if any callback waits for it, then calls them. The raft worker calls it at the end of
+// each round, so the readies of the round are synced at once.
+func (s *logSyncer) sync() {
+	if s == nil || len(s.pending) == 0 {
+		return
+	}
+	s.mustSync()
+	pending := s.pending
+	s.pending = nil
+	for _, fn := range pending {
+		fn()
+	}
+}
+
+// mustSync syncs the raft engine, the raftstore can't go on if the raft logs can't be persisted.
+func (s *logSyncer) mustSync() {
+	start := time.Now()
+	if err := engine_util.SyncDB(s.dir, s.lastSync); err != nil {
+		panic(err)
+	}
+	s.lastSync = start
+}
+
+// run syncs the raft engine every interval until closeCh is closed, if it's synced periodically.
+func (s *logSyncer) run(closeCh <-chan struct{}, wg *sync.WaitGroup) {
+	if s == nil || !s.periodic {
+		return
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-closeCh:
+				return
+			case <-ticker.C:
+				s.mustSync()
+			}
+		}
+	}()
+}
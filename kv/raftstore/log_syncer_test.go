@@ -0,0 +1,52 @@
+package raftstore
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/kv/config"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogSyncer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "log_syncer")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	db := engine_util.CreateDBWithSync(dir, true, false)
+	defer db.Close()
+
+	cfg := config.NewTestConfig()
+	assert.Nil(t, newLogSyncer(cfg, dir))
+	var called []int
+	// each write is synced
+	var s *logSyncer
+	s.afterSync(func() { called = append(called, 0) })
+	s.sync()
+	assert.Equal(t, []int{0}, called)
+
+	// the callbacks wait for the sync of the round
+	cfg.RaftSyncLog = config.SyncLogBatched
+	s = newLogSyncer(cfg, dir)
+	wb := new(engine_util.WriteBatch)
+	wb.SetCF(engine_util.CfDefault, []byte("k"), []byte("v"))
+	require.Nil(t, wb.WriteToDB(db))
+	s.afterSync(func() { called = append(called, 1) })
+	s.afterSync(func() { called = append(called, 2) })
+	assert.Equal(t, []int{0}, called)
+	s.sync()
+	assert.Equal(t, []int{0, 1, 2}, called)
+	assert.False(t, s.lastSync.IsZero())
+	lastSync := s.lastSync
+	// nothing waits, so the round isn't synced
+	s.sync()
+	assert.Equal(t, lastSync, s.lastSync)
+
+	// the raft engine is synced in the background
+	cfg.RaftSyncLog = config.SyncLogPeriodic
+	s = newLogSyncer(cfg, dir)
+	s.afterSync(func() { called = append(called, 3) })
+	assert.Equal(t, []int{0, 1, 2, 3}, called)
+}
@@ -0,0 +1,254 @@
+package raftstore
+
+import (
+	"fmt"
+
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/message"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/meta"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
+	"github.com/pingcap-incubator/tinykv/log"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
+	rspb "github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
+	"github.com/pingcap/errors"
+)
+
+// A region is merged into an adjacent target region in three steps. The leader of the source proposes a
+// PrepareMerge, after which the source serves no other command. Once it's applied, each source peer proposes the
+// CommitMerge to the target peer on its store, only the one to the target leader goes through, and each target peer
+// applying it takes over the range and the data of the source peer on its store, which is destroyed. The target peer
+// applies it only once the source peer applied its own entries up to the PrepareMerge. If the target changes before
+// it commits the merge, the source leader proposes a RollbackMerge and the source serves again.
+
+// mergeMaxLogGap is the max number of entries after the min index a PrepareMerge is proposed with, they're carried
+// by the CommitMerge.
+const mergeMaxLogGap = 128
+
+func (d *peerMsgHandler) onPrepareMerge(regionEpoch *metapb.RegionEpoch, target *metapb.Region, cb *message.Callback) {
+	minIndex, err := d.validatePrepareMerge(regionEpoch, target)
+	if err != nil {
+		log.Infof("%s can't merge into region %d: %v", d.Tag, target.GetId(), err)
+		cb.Done(ErrResp(err))
+		return
+	}
+	log.Infof("%s prepares to merge into region %d at min index %d", d.Tag, target.Id, minIndex)
+	req := newAdminRequest(d.regionId, d.Meta, &raft_cmdpb.AdminRequest{
+		CmdType:      raft_cmdpb.AdminCmdType_PrepareMerge,
+		PrepareMerge: &raft_cmdpb.PrepareMergeRequest{MinIndex: minIndex, Target: target},
+	})
+	req.Header.RegionEpoch = d.Region().RegionEpoch
+	d.proposeRaftCommand(req, cb)
+}
+
+// validatePrepareMerge checks the region can be merged into the target and returns the min index its peers have
+// replicated. The target peer on the store must be initialized and know the target at the same epoch, and all the
+// peers of the region must be close enough to the leader for the CommitMerge to carry the entries they lack.
+func (d *peerMsgHandler) validatePrepareMerge(epoch *metapb.RegionEpoch, target *metapb.Region) (uint64, error) {
+	if !d.IsLeader() {
+		return 0, &util.ErrNotLeader{RegionId: d.regionId, Leader: d.getPeerFromCache(d.LeaderId())}
+	}
+	region := d.Region()
+	if epoch.GetVersion() != region.RegionEpoch.Version || epoch.GetConfVer() != region.RegionEpoch.ConfVer {
+		return 0, &util.ErrEpochNotMatch{
+			Message: fmt.Sprintf("%s epoch changed %s != %s, retry later", d.Tag, region.RegionEpoch, epoch),
+			Regions: []*metapb.Region{region},
+		}
+	}
+	if d.pendingMerge != nil {
+		return 0, errors.Errorf("%s is merging into region %d already", d.Tag, d.pendingMerge.Target.GetId())
+	}
+	if err := util.CheckMergeTarget(region, target); err != nil {
+		return 0, err
+	}
+	meta := d.ctx.storeMeta
+	meta.RLock()
+	local := meta.regions[target.Id]
+	meta.RUnlock()
+	if local == nil || len(local.Peers) == 0 {
+		return 0, errors.Errorf("target region %d has no initialized peer on the store", target.Id)
+	}
+	if local.RegionEpoch.Version != target.RegionEpoch.GetVersion() ||
+		local.RegionEpoch.ConfVer != target.RegionEpoch.GetConfVer() {
+		return 0, errors.Errorf("target region %d is at epoch %s on the store, not %s", target.Id, local.RegionEpoch,
+			target.RegionEpoch)
+	}
+	if d.RaftGroup.Raft.PendingConfIndex > d.peerStorage.AppliedIndex() {
+		return 0, errors.Errorf("%s has a pending conf change", d.Tag)
+	}
+
+	status := d.RaftGroup.Status()
+	minIndex := d.peerStorage.AppliedIndex()
+	for _, p := range region.Peers {
+		pr, ok := status.Progress[p.Id]
+		if !ok {
+			return 0, errors.Errorf("%s has no progress of peer %d", d.Tag, p.Id)
+		}
+		if pr.Match < minIndex {
+			minIndex = pr.Match
+		}
+	}
+	firstIndex, err := d.peerStorage.FirstIndex()
+	if err != nil {
+		return 0, err
+	}
+	lastIndex, err := d.peerStorage.LastIndex()
+	if err != nil {
+		return 0, err
+	}
+	if minIndex+1 < firstIndex {
+		return 0, errors.Errorf("%s has a peer at index %d behind the compacted log at %d", d.Tag, minIndex, firstIndex)
+	}
+	if lastIndex-minIndex > mergeMaxLogGap {
+		return 0, errors.Errorf("%s has a peer at index %d too far behind the last index %d", d.Tag, minIndex, lastIndex)
+	}
+	return minIndex, nil
+}
+
+// onCheckMergeTick drives the merge of a region which applied a PrepareMerge: the peer proposes the CommitMerge to
+// the target peer on its store until the target merges the region, and the leader rolls the merge back if the
+// target changed otherwise.
+func (d *peerMsgHandler) onCheckMergeTick() {
+	d.ticker.schedule(PeerTickCheckMerge)
+	state := d.pendingMerge
+	if state == nil {
+		return
+	}
+	storeMeta := d.ctx.storeMeta
+	storeMeta.RLock()
+	target := storeMeta.regions[state.Target.GetId()]
+	storeMeta.RUnlock()
+	if target == nil {
+		// the target peer on the store may be created later, only the leader's target tells whether it changed
+		return
+	}
+	region := d.Region()
+	if util.IsMergedInto(region, state, target) {
+		// the target peer destroys the peer once it applies the CommitMerge
+		return
+	}
+	if target.RegionEpoch.Version != state.Target.RegionEpoch.GetVersion() ||
+		target.RegionEpoch.ConfVer != state.Target.RegionEpoch.GetConfVer() {
+		if target.RegionEpoch.Version < state.Target.RegionEpoch.GetVersion() || !d.IsLeader() {
+			// the target peer on the store is behind
+			return
+		}
+		// The target rejects the CommitMerge at another epoch, but it may have merged the region before it changed,
+		// e.g. it merged and then split, so its range doesn't tell. The target peer on the store is past the epoch,
+		// it would have written the region in the Tombstone state if it had merged it.
+		merged, err := d.isMergedByTarget()
+		if err != nil {
+			log.Errorf("%s failed to read the region state of the merge at %d: %v", d.Tag, state.Commit, err)
+			return
+		}
+		if merged {
+			return
+		}
+		log.Infof("%s rolls back the merge at %d, target region %d changed to epoch %s", d.Tag, state.Commit,
+			target.Id, target.RegionEpoch)
+		req := newAdminRequest(d.regionId, d.Meta, &raft_cmdpb.AdminRequest{
+			CmdType:       raft_cmdpb.AdminCmdType_RollbackMerge,
+			RollbackMerge: &raft_cmdpb.RollbackMergeRequest{Commit: state.Commit},
+		})
+		req.Header.RegionEpoch = region.RegionEpoch
+		d.proposeRaftCommand(req, nil)
+		return
+	}
+
+	entries, err := d.peerStorage.Entries(state.MinIndex+1, state.Commit+1)
+	if err != nil {
+		log.Errorf("%s failed to read the entries of the merge at %d: %v", d.Tag, state.Commit, err)
+		return
+	}
+	commit := &raft_cmdpb.CommitMergeRequest{Source: region, Commit: state.Commit}
+	for i := range entries {
+		commit.Entries = append(commit.Entries, &entries[i])
+	}
+	req := newAdminRequest(target.Id, util.FindPeer(target, d.storeID()), &raft_cmdpb.AdminRequest{
+		CmdType:     raft_cmdpb.AdminCmdType_CommitMerge,
+		CommitMerge: commit,
+	})
+	req.Header.RegionEpoch = state.Target.RegionEpoch
+	// the target peer rejects it unless it's the leader, the peer of the source on the leader's store commits it
+	_ = d.ctx.router.send(target.Id, message.NewPeerMsg(message.MsgTypeRaftCmd, target.Id, &message.MsgRaftCmd{
+		Request:  req,
+		Callback: message.NewCallback(),
+	}))
+}
+
+// isMergedByTarget returns true if the region is in the Tombstone state in the kv engine, which the target peer on the
+// store writes when it applies the CommitMerge. It's read after the target in the store meta, which is updated after
+// the CommitMerge is written.
+func (d *peerMsgHandler) isMergedByTarget() (bool, error) {
+	state, err := meta.GetRegionLocalState(d.ctx.engine.Kv, d.regionId)
+	if err != nil {
+		return false, err
+	}
+	return state.State == rspb.PeerState_Tombstone, nil
+}
+
+// mergeWait is the CommitMerge a target peer waits to apply until the source peer on the store applied its own
+// entries up to the merge. The committed entries from the CommitMerge on aren't advanced past meanwhile, so the raft
+// group hands them out again in the readies after.
+type mergeWait struct {
+	sourceID uint64
+	// the index of the CommitMerge
+	index uint64
+}
+
+// onCatchUpLogs makes the source peer apply its entries up to the merge the target peer on the store is about to
+// apply, it replies at once if it applied them already, e.g. it applied the PrepareMerge.
+func (d *peerMsgHandler) onCatchUpLogs(catchUp *message.MsgCatchUpLogs) {
+	if d.stopped {
+		return
+	}
+	d.catchUpLogs = catchUp
+	if d.peerStorage.AppliedIndex() >= catchUp.Merge.Commit {
+		d.onCaughtUp()
+	}
+}
+
+// onCaughtUp tells the target peer waiting for the source peer that it applied its entries up to the merge.
+func (d *peerMsgHandler) onCaughtUp() {
+	if d.catchUpLogs == nil {
+		return
+	}
+	targetID := d.catchUpLogs.TargetRegionID
+	d.catchUpLogs = nil
+	_ = d.ctx.router.send(targetID, message.NewPeerMsg(message.MsgTypeLogsUpToDate, targetID, d.regionId))
+}
+
+// onLogsUpToDate resumes the target peer once the source peer of its merge applied its entries up to the merge, the
+// next ready hands out the CommitMerge again and it's applied then.
+func (d *peerMsgHandler) onLogsUpToDate(sourceID uint64) {
+	if d.stopped || d.mergeWait == nil || d.mergeWait.sourceID != sourceID {
+		return
+	}
+	d.mergeWait = nil
+}
+
+// onMergeResult destroys the source peer once the target peer on the store applied the CommitMerge. The data of the
+// peer is kept, it belongs to the target now, and so does its range in the store meta unless the range ends before
+// the target.
+func (d *peerMsgHandler) onMergeResult(target *metapb.Region) {
+	if d.stopped {
+		return
+	}
+	log.Infof("%s is merged into region %d, destroy it", d.Tag, target.Id)
+	if d.ctx.applyWorker != nil {
+		d.ctx.applyWorker.stop(d.regionId, d.PeerId())
+	}
+	meta := d.ctx.storeMeta
+	meta.Lock()
+	defer meta.Unlock()
+	if err := d.Destroy(d.ctx.engine, true); err != nil {
+		panic(fmt.Sprintf("%s destroy merged peer %v", d.Tag, err))
+	}
+	d.ctx.router.close(d.regionId)
+	d.stopped = true
+	d.pendingMerge = nil
+	if item := meta.regionRanges.Get(&regionItem{region: d.Region()}); item != nil &&
+		item.(*regionItem).region.Id == d.regionId {
+		meta.regionRanges.Delete(item)
+	}
+	delete(meta.regions, d.regionId)
+}
@@ -4,13 +4,16 @@ import (
 	"time"
 
 	"github.com/Connor1996/badger"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/locktable"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
 )
 
 type Callback struct {
 	Resp *raft_cmdpb.RaftCmdResponse
 	Txn  *badger.Txn // used for GetSnap
-	done chan struct{}
+	// used for GetSnap when the lock CF is kept in memory, it's read in place of the lock CF of Txn
+	Locks *locktable.Snapshot
+	done  chan struct{}
 }
 
 func (cb *Callback) Done(resp *raft_cmdpb.RaftCmdResponse) {
@@ -30,6 +30,36 @@ const (
 	MsgTypeRegionApproximateSize MsgType = 6
 	// message to trigger gc generated snapshots
 	MsgTypeGcSnap MsgType = 7
+	// message to update region approximate keys
+	// it is sent by split checker
+	MsgTypeRegionApproximateKeys MsgType = 9
+	// message to report the index the committed entries of a peer are
+	// applied to by the apply worker
+	MsgTypeApplied MsgType = 10
+	// message to get the raft status of a peer, which is sent back to the
+	// channel in the message
+	MsgTypeRaftStatus MsgType = 11
+	// message to compact the raft log applied so far regardless of the raft
+	// log gc limits, the callback in the message is called once it's applied
+	MsgTypeCompactLog MsgType = 12
+	// message to check whether the region needs to be split regardless of
+	// the size changed since the last check, the callback in the message is
+	// called once the check is scheduled
+	MsgTypeSplitCheck MsgType = 13
+	// message to merge the region into an adjacent region, it proposes a
+	// PrepareMerge once the merge is validated
+	MsgTypeMergeRegion MsgType = 14
+	// message to the source peer of a merge once the target peer on the
+	// store applied the CommitMerge, the source peer destroys itself
+	MsgTypeMergeResult MsgType = 15
+	// message to the source peer of a merge from the target peer on the
+	// store which is about to apply the CommitMerge, the source peer applies
+	// its entries up to the merge first
+	MsgTypeCatchUpLogs MsgType = 16
+	// message to the target peer of a merge once the source peer on the
+	// store applied its entries up to the merge, the target peer applies the
+	// CommitMerge then
+	MsgTypeLogsUpToDate MsgType = 17
 
 	// message wraps a raft message to the peer not existing on the Store.
 	// It is due to region split or add peer conf change
@@ -38,6 +68,9 @@ const (
 	MsgTypeStoreTick MsgType = 106
 	// message to start the ticker of store
 	MsgTypeStoreStart MsgType = 107
+	// message to create an uninitialized peer on the store, which waits for
+	// a snapshot from the leader to be initialized
+	MsgTypeStoreCreatePeer MsgType = 108
 )
 
 type Msg struct {
@@ -63,6 +96,23 @@ type MsgRaftCmd struct {
 	Callback *Callback
 }
 
+type MsgCreatePeer struct {
+	Region   *metapb.Region
+	Peer     *metapb.Peer
+	Callback *Callback
+}
+
+type MsgMergeRegion struct {
+	RegionEpoch *metapb.RegionEpoch
+	Target      *metapb.Region
+	Callback    *Callback
+}
+
+type MsgCatchUpLogs struct {
+	TargetRegionID uint64
+	Merge          *raft_cmdpb.CommitMergeRequest
+}
+
 type MsgSplitRegion struct {
 	RegionEpoch *metapb.RegionEpoch
 	SplitKey    []byte
@@ -25,9 +25,13 @@ const (
 	RaftLogSuffix    byte = 0x01
 	RaftStateSuffix  byte = 0x02
 	ApplyStateSuffix byte = 0x03
+	HardStateSuffix  byte = 0x04
 
 	// For region meta
-	RegionStateSuffix byte = 0x01
+	RegionStateSuffix    byte = 0x01
+	LockCheckpointSuffix byte = 0x02
+	// the checkpoint of a removed peer whose data is kept, see rspb.RegionCheckpoint
+	RegionCheckpointSuffix byte = 0x03
 )
 
 var (
@@ -82,6 +86,12 @@ func ApplyStateKey(regionID uint64) []byte {
 	return makeRegionPrefix(regionID, ApplyStateSuffix)
 }
 
+// HardStateKey is the key of the hard state of a region, kept apart from the raft state so a vote or term change
+// is written without the entries.
+func HardStateKey(regionID uint64) []byte {
+	return makeRegionPrefix(regionID, HardStateSuffix)
+}
+
 func IsRaftStateKey(key []byte) bool {
 	return len(key) == 11 && key[0] == LocalPrefix && key[1] == RegionRaftPrefix
 }
@@ -114,6 +124,24 @@ func RegionStateKey(regionID uint64) []byte {
 	return key
 }
 
+func LockCheckpointKey(regionID uint64) []byte {
+	key := make([]byte, 11)
+	key[0] = LocalPrefix
+	key[1] = RegionMetaPrefix
+	binary.BigEndian.PutUint64(key[2:], regionID)
+	key[10] = LockCheckpointSuffix
+	return key
+}
+
+func RegionCheckpointKey(regionID uint64) []byte {
+	key := make([]byte, 11)
+	key[0] = LocalPrefix
+	key[1] = RegionMetaPrefix
+	binary.BigEndian.PutUint64(key[2:], regionID)
+	key[10] = RegionCheckpointSuffix
+	return key
+}
+
 /// RaftLogIndex gets the log index from raft log key generated by `raft_log_key`.
 func RaftLogIndex(key []byte) (uint64, error) {
 	if len(key) != RegionRaftLogLen {
@@ -0,0 +1,211 @@
+package meta
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/Connor1996/badger"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+	rspb "github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
+	"github.com/pingcap/errors"
+)
+
+// RaftLogReport is the result of checking the raft log of a region offline.
+type RaftLogReport struct {
+	RegionID   uint64
+	RaftState  *rspb.RaftLocalState
+	ApplyState *rspb.RaftApplyState
+
+	// The entries (truncated index, LastIndex] are consistent, LastTerm is the term of the last of them.
+	LastIndex uint64
+	LastTerm  uint64
+	// TornKeys are the keys of the entries after the consistent ones, left by a torn write.
+	TornKeys [][]byte
+	Problems []string
+}
+
+func (r *RaftLogReport) Consistent() bool {
+	return len(r.Problems) == 0
+}
+
+func (r *RaftLogReport) addProblem(format string, args ...interface{}) {
+	r.Problems = append(r.Problems, fmt.Sprintf(format, args...))
+}
+
+// CheckRaftLogs checks the raft logs of all the regions in the raft engine.
+func CheckRaftLogs(engines *engine_util.Engines) ([]*RaftLogReport, error) {
+	var regionIDs []uint64
+	err := engines.Raft.View(func(txn *badger.Txn) error {
+		iter := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer iter.Close()
+		prefix := []byte{LocalPrefix, RegionRaftPrefix}
+		for iter.Seek(prefix); iter.ValidForPrefix(prefix); {
+			key := iter.Item().Key()
+			if len(key) < RegionRaftPrefixLen {
+				return errors.Errorf("invalid raft key %v", key)
+			}
+			regionID := binary.BigEndian.Uint64(key[2:10])
+			regionIDs = append(regionIDs, regionID)
+			iter.Seek(RegionRaftPrefixKey(regionID + 1))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]*RaftLogReport, 0, len(regionIDs))
+	for _, regionID := range regionIDs {
+		report, err := CheckRaftLog(engines, regionID)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// CheckRaftLog checks the raft log of a region: the entries after the truncated index must have continuous indexes
+// and non-decreasing terms, and the raft state must agree with the last entry. The entries after the first
+// inconsistent one are reported as torn.
+func CheckRaftLog(engines *engine_util.Engines, regionID uint64) (*RaftLogReport, error) {
+	report := &RaftLogReport{RegionID: regionID}
+	raftState, err := GetRaftLocalState(engines.Raft, regionID)
+	if err != nil && err != badger.ErrKeyNotFound {
+		return nil, err
+	}
+	if err == badger.ErrKeyNotFound {
+		report.addProblem("raft state is missing")
+	} else {
+		report.RaftState = raftState
+	}
+	applyState, err := GetApplyState(engines.Kv, regionID)
+	if err != nil && err != badger.ErrKeyNotFound {
+		return nil, err
+	}
+	truncated := &rspb.RaftTruncatedState{}
+	if err == badger.ErrKeyNotFound {
+		report.addProblem("apply state is missing")
+	} else {
+		report.ApplyState = applyState
+		if applyState.TruncatedState != nil {
+			truncated = applyState.TruncatedState
+		}
+	}
+	report.LastIndex, report.LastTerm = truncated.Index, truncated.Term
+
+	err = engines.Raft.View(func(txn *badger.Txn) error {
+		iter := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer iter.Close()
+		prefix := makeRegionPrefix(regionID, RaftLogSuffix)
+		torn := false
+		for iter.Seek(RaftLogKey(regionID, truncated.Index+1)); iter.ValidForPrefix(prefix); iter.Next() {
+			item := iter.Item()
+			key := item.KeyCopy(nil)
+			if torn {
+				report.TornKeys = append(report.TornKeys, key)
+				continue
+			}
+			val, err := item.Value()
+			if err != nil {
+				return err
+			}
+			var entry eraftpb.Entry
+			index, err := RaftLogIndex(key)
+			if err == nil {
+				err = entry.Unmarshal(val)
+			}
+			switch {
+			case err != nil:
+				report.addProblem("entry after %d is corrupted: %v", report.LastIndex, err)
+			case entry.Index != index:
+				report.addProblem("entry %d is stored at index %d", entry.Index, index)
+			case entry.Index != report.LastIndex+1:
+				report.addProblem("entry %d follows entry %d", entry.Index, report.LastIndex)
+			case entry.Term < report.LastTerm:
+				report.addProblem("entry %d has term %d lower than the term %d of entry %d", entry.Index, entry.Term, report.LastTerm, report.LastIndex)
+			case report.RaftState != nil && raftState.LastIndex < entry.Index:
+				report.addProblem("entry %d is after the last index %d of the raft state", entry.Index, raftState.GetLastIndex())
+			default:
+				report.LastIndex, report.LastTerm = entry.Index, entry.Term
+				continue
+			}
+			torn = true
+			report.TornKeys = append(report.TornKeys, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if report.RaftState != nil {
+		hardState := raftState.GetHardState()
+		if raftState.LastIndex != report.LastIndex {
+			report.addProblem("raft state has last index %d, but the log ends at %d", raftState.LastIndex, report.LastIndex)
+		} else if raftState.LastTerm != report.LastTerm {
+			report.addProblem("raft state has last term %d, but the last entry has term %d", raftState.LastTerm, report.LastTerm)
+		}
+		if hardState.GetCommit() > report.LastIndex {
+			report.addProblem("hard state commits %d, but the log ends at %d", hardState.GetCommit(), report.LastIndex)
+		}
+		if hardState.GetTerm() < report.LastTerm {
+			report.addProblem("hard state has term %d lower than the last term %d", hardState.GetTerm(), report.LastTerm)
+		}
+		if report.ApplyState != nil && report.ApplyState.AppliedIndex > hardState.GetCommit() {
+			report.addProblem("applied index %d is after the commit index %d", report.ApplyState.AppliedIndex, hardState.GetCommit())
+		}
+	}
+	return report, nil
+}
+
+// RepairRaftLog truncates the raft log of the region to the last consistent entry of the report, and rewrites the
+// raft state to agree with it. It fails if the applied entries would be truncated, which needs the peer to be
+// rebuilt from a snapshot instead. Lowering the commit index forgets entries the peer may have acknowledged, so
+// it must only be done with the store stopped and after the operator confirms it.
+func RepairRaftLog(engines *engine_util.Engines, report *RaftLogReport) error {
+	if report.RaftState == nil || report.ApplyState == nil {
+		return errors.Errorf("region %d can't be repaired without its raft and apply states", report.RegionID)
+	}
+	if report.ApplyState.AppliedIndex > report.LastIndex {
+		return errors.Errorf("region %d has applied %d, after the last consistent entry %d",
+			report.RegionID, report.ApplyState.AppliedIndex, report.LastIndex)
+	}
+	raftWB := new(engine_util.WriteBatch)
+	for _, key := range report.TornKeys {
+		raftWB.DeleteMeta(key)
+	}
+	raftState := &rspb.RaftLocalState{
+		HardState: &eraftpb.HardState{},
+		LastIndex: report.LastIndex,
+		LastTerm:  report.LastTerm,
+	}
+	if hardState := report.RaftState.GetHardState(); hardState != nil {
+		*raftState.HardState = *hardState
+	}
+	if raftState.HardState.Commit > report.LastIndex {
+		raftState.HardState.Commit = report.LastIndex
+	}
+	if raftState.HardState.Commit < report.ApplyState.AppliedIndex {
+		raftState.HardState.Commit = report.ApplyState.AppliedIndex
+	}
+	if raftState.HardState.Term < report.LastTerm {
+		raftState.HardState.Term = report.LastTerm
+	}
+	if err := raftWB.SetMeta(RaftStateKey(report.RegionID), raftState); err != nil {
+		return err
+	}
+	WriteHardState(raftWB, report.RegionID, raftState.HardState)
+	return raftWB.WriteToDB(engines.Raft)
+}
+
+// TornIndexes returns the indexes of the torn entries, for printing.
+func (r *RaftLogReport) TornIndexes() []uint64 {
+	indexes := make([]uint64, 0, len(r.TornKeys))
+	for _, key := range r.TornKeys {
+		if index, err := RaftLogIndex(key); err == nil {
+			indexes = append(indexes, index)
+		}
+	}
+	return indexes
+}
@@ -0,0 +1,68 @@
+package meta_test
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/meta"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+	rspb "github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeRaftLog(t *testing.T, engines *engine_util.Engines, regionID uint64, entries []eraftpb.Entry, state *rspb.RaftLocalState, applied uint64) {
+	raftWB := new(engine_util.WriteBatch)
+	for i := range entries {
+		assert.Nil(t, raftWB.SetMeta(meta.RaftLogKey(regionID, entries[i].Index), &entries[i]))
+	}
+	assert.Nil(t, raftWB.SetMeta(meta.RaftStateKey(regionID), state))
+	assert.Nil(t, raftWB.WriteToDB(engines.Raft))
+	applyState := &rspb.RaftApplyState{
+		AppliedIndex:   applied,
+		TruncatedState: &rspb.RaftTruncatedState{Index: 5, Term: 5},
+	}
+	assert.Nil(t, engine_util.PutMeta(engines.Kv, meta.ApplyStateKey(regionID), applyState))
+}
+
+func TestCheckRaftLog(t *testing.T) {
+	engines := util.NewTestEngines()
+	defer engines.Destroy()
+
+	entries := []eraftpb.Entry{{Index: 6, Term: 5}, {Index: 7, Term: 6}, {Index: 8, Term: 6}}
+	writeRaftLog(t, engines, 1, entries, &rspb.RaftLocalState{
+		HardState: &eraftpb.HardState{Term: 6, Commit: 8}, LastIndex: 8, LastTerm: 6,
+	}, 7)
+	// entry 9 is torn, entry 10 was written but its raft state wasn't
+	entries = []eraftpb.Entry{{Index: 6, Term: 5}, {Index: 7, Term: 6}, {Index: 9, Term: 6}, {Index: 10, Term: 7}}
+	writeRaftLog(t, engines, 2, entries, &rspb.RaftLocalState{
+		HardState: &eraftpb.HardState{Term: 7, Commit: 9}, LastIndex: 10, LastTerm: 7,
+	}, 6)
+
+	reports, err := meta.CheckRaftLogs(engines)
+	assert.Nil(t, err)
+	assert.Len(t, reports, 2)
+	assert.True(t, reports[0].Consistent())
+	assert.Equal(t, uint64(8), reports[0].LastIndex)
+
+	report := reports[1]
+	assert.False(t, report.Consistent())
+	assert.Equal(t, uint64(7), report.LastIndex)
+	assert.Equal(t, uint64(6), report.LastTerm)
+	assert.Equal(t, []uint64{9, 10}, report.TornIndexes())
+
+	assert.Nil(t, meta.RepairRaftLog(engines, report))
+	report, err = meta.CheckRaftLog(engines, 2)
+	assert.Nil(t, err)
+	assert.True(t, report.Consistent(), "%v", report.Problems)
+	assert.Equal(t, uint64(7), report.RaftState.HardState.Commit)
+	assert.Equal(t, uint64(7), report.RaftState.HardState.Term)
+
+	// the applied entries can't be truncated
+	writeRaftLog(t, engines, 3, []eraftpb.Entry{{Index: 6, Term: 5}, {Index: 8, Term: 5}}, &rspb.RaftLocalState{
+		HardState: &eraftpb.HardState{Term: 5, Commit: 8}, LastIndex: 8, LastTerm: 5,
+	}, 8)
+	report, err = meta.CheckRaftLog(engines, 3)
+	assert.Nil(t, err)
+	assert.NotNil(t, meta.RepairRaftLog(engines, report))
+}
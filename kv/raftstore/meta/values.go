@@ -16,14 +16,59 @@ func GetRegionLocalState(db *badger.DB, regionId uint64) (*rspb.RegionLocalState
 	return regionLocalState, nil
 }
 
+// GetRaftLocalState returns the raft state of a region, with the newer of its hard state and the hard state record of
+// the region if there is one. The raft state is still written alone by some, e.g. on a snapshot or a log GC, so the
+// record may be stale.
 func GetRaftLocalState(db *badger.DB, regionId uint64) (*rspb.RaftLocalState, error) {
 	raftLocalState := new(rspb.RaftLocalState)
 	if err := engine_util.GetMeta(db, RaftStateKey(regionId), raftLocalState); err != nil {
 		return raftLocalState, err
 	}
+	hardState, err := GetHardState(db, regionId)
+	if err != nil && err != badger.ErrKeyNotFound {
+		return raftLocalState, err
+	}
+	if err == nil {
+		raftLocalState.HardState = newerHardState(raftLocalState.HardState, hardState)
+	}
 	return raftLocalState, nil
 }
 
+// newerHardState returns the hard state of the higher term, or of the same term with the higher commit and the vote
+// of either, as a peer votes once in a term.
+func newerHardState(a, b *eraftpb.HardState) *eraftpb.HardState {
+	if a == nil || a.Term < b.Term {
+		return b
+	}
+	if a.Term > b.Term {
+		return a
+	}
+	hardState := *a
+	if b.Commit > hardState.Commit {
+		hardState.Commit = b.Commit
+	}
+	if hardState.Vote == 0 {
+		hardState.Vote = b.Vote
+	}
+	return &hardState
+}
+
+// GetHardState returns the hard state record of a region. A region written before the hard state was kept in its
+// own record has none, its hard state is the one in its raft state then.
+func GetHardState(db *badger.DB, regionId uint64) (*eraftpb.HardState, error) {
+	hardState := new(eraftpb.HardState)
+	if err := engine_util.GetMeta(db, HardStateKey(regionId), hardState); err != nil {
+		return nil, err
+	}
+	return hardState, nil
+}
+
+// WriteHardState writes the hard state record of a region, which is read along with the hard state in its raft
+// state, see GetRaftLocalState. A ready changing only the hard state, e.g. a vote during an election, writes just this small record.
+func WriteHardState(raftWB *engine_util.WriteBatch, regionId uint64, hardState *eraftpb.HardState) {
+	raftWB.SetMeta(HardStateKey(regionId), hardState)
+}
+
 func GetApplyState(db *badger.DB, regionId uint64) (*rspb.RaftApplyState, error) {
 	applyState := new(rspb.RaftApplyState)
 	if err := engine_util.GetMeta(db, ApplyStateKey(regionId), applyState); err != nil {
@@ -40,6 +85,22 @@ func GetRaftEntry(db *badger.DB, regionId, idx uint64) (*eraftpb.Entry, error) {
 	return entry, nil
 }
 
+// GetRaftLogSize returns the approximate byte size of the raft log entries of the region from index on, as
+// estimated by the engine without reading the values.
+func GetRaftLogSize(db *badger.DB, regionId, index uint64) (uint64, error) {
+	var size uint64
+	err := db.View(func(txn *badger.Txn) error {
+		iter := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer iter.Close()
+		prefix := makeRegionPrefix(regionId, RaftLogSuffix)
+		for iter.Seek(RaftLogKey(regionId, index)); iter.ValidForPrefix(prefix); iter.Next() {
+			size += uint64(iter.Item().EstimatedSize())
+		}
+		return nil
+	})
+	return size, err
+}
+
 const (
 	// When we create a region peer, we should initialize its log term/index > 0,
 	// so that we can force the follower peer to sync the snapshot first.
@@ -91,6 +152,15 @@ func InitApplyState(kvEngine *badger.DB, region *metapb.Region) (*rspb.RaftApply
 	return applyState, nil
 }
 
+// WriteMergingRegionState writes the state of a region which applied a PrepareMerge.
+func WriteMergingRegionState(kvWB *engine_util.WriteBatch, region *metapb.Region, mergeState *rspb.MergeState) {
+	kvWB.SetMeta(RegionStateKey(region.Id), &rspb.RegionLocalState{
+		State:      rspb.PeerState_Merging,
+		Region:     region,
+		MergeState: mergeState,
+	})
+}
+
 func WriteRegionState(kvWB *engine_util.WriteBatch, region *metapb.Region, state rspb.PeerState) {
 	regionState := new(rspb.RegionLocalState)
 	regionState.State = state
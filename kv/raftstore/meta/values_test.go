@@ -0,0 +1,76 @@
+package meta_test
+
+import (
+	"testing"
+
+	"github.com/Connor1996/badger"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/meta"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+	rspb "github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHardState(t *testing.T) {
+	engines := util.NewTestEngines()
+	defer engines.Destroy()
+
+	// a raft state written before the hard state had its own record
+	assert.Nil(t, engine_util.PutMeta(engines.Raft, meta.RaftStateKey(1), &rspb.RaftLocalState{
+		HardState: &eraftpb.HardState{Term: 6, Commit: 8}, LastIndex: 8, LastTerm: 6,
+	}))
+	state, err := meta.GetRaftLocalState(engines.Raft, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, &eraftpb.HardState{Term: 6, Commit: 8}, state.HardState)
+	_, err = meta.GetHardState(engines.Raft, 1)
+	assert.Equal(t, badger.ErrKeyNotFound, err)
+
+	// a vote is written without the raft state
+	raftWB := new(engine_util.WriteBatch)
+	meta.WriteHardState(raftWB, 1, &eraftpb.HardState{Term: 7, Vote: 2, Commit: 8})
+	assert.Nil(t, raftWB.WriteToDB(engines.Raft))
+	state, err = meta.GetRaftLocalState(engines.Raft, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, &eraftpb.HardState{Term: 7, Vote: 2, Commit: 8}, state.HardState)
+	assert.Equal(t, uint64(8), state.LastIndex)
+
+	// the raft state is written alone with a newer hard state, e.g. by a snapshot
+	assert.Nil(t, engine_util.PutMeta(engines.Raft, meta.RaftStateKey(1), &rspb.RaftLocalState{
+		HardState: &eraftpb.HardState{Term: 8, Commit: 20}, LastIndex: 20, LastTerm: 8,
+	}))
+	state, err = meta.GetRaftLocalState(engines.Raft, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, &eraftpb.HardState{Term: 8, Commit: 20}, state.HardState)
+
+	// the same term, the higher commit wins and the vote is kept
+	raftWB = new(engine_util.WriteBatch)
+	meta.WriteHardState(raftWB, 1, &eraftpb.HardState{Term: 8, Vote: 3, Commit: 18})
+	assert.Nil(t, raftWB.WriteToDB(engines.Raft))
+	state, err = meta.GetRaftLocalState(engines.Raft, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, &eraftpb.HardState{Term: 8, Vote: 3, Commit: 20}, state.HardState)
+}
+
+func TestGetRaftLogSize(t *testing.T) {
+	engines := util.NewTestEngines()
+	defer engines.Destroy()
+
+	raftWB := new(engine_util.WriteBatch)
+	for i := uint64(1); i <= 4; i++ {
+		assert.Nil(t, raftWB.SetMeta(meta.RaftLogKey(1, i), &eraftpb.Entry{Index: i, Term: 1, Data: make([]byte, 100)}))
+	}
+	// the entries of the other regions aren't counted
+	assert.Nil(t, raftWB.SetMeta(meta.RaftLogKey(2, 1), &eraftpb.Entry{Index: 1, Term: 1, Data: make([]byte, 100)}))
+	assert.Nil(t, raftWB.WriteToDB(engines.Raft))
+
+	all, err := meta.GetRaftLogSize(engines.Raft, 1, 1)
+	assert.Nil(t, err)
+	assert.True(t, all >= 400, "size %d", all)
+	half, err := meta.GetRaftLogSize(engines.Raft, 1, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, all/2, half)
+	none, err := meta.GetRaftLogSize(engines.Raft, 1, 5)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), none)
+}
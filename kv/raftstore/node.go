@@ -50,6 +50,9 @@ func (n *Node) Start(ctx context.Context, engines *engine_util.Engines, trans Tr
 		return err
 	}
 	n.store.Id = storeID
+	if n.store.FencingToken, err = n.checkFencingToken(ctx, engines); err != nil {
+		return err
+	}
 
 	firstRegion, err := n.checkOrPrepareBootstrapCluster(ctx, engines, storeID)
 	if err != nil {
@@ -66,7 +69,7 @@ func (n *Node) Start(ctx context.Context, engines *engine_util.Engines, trans Tr
 
 	err = n.schedulerClient.PutStore(ctx, n.store)
 	if err != nil {
-		return err
+		return errors.Annotatef(err, "register store %d", storeID)
 	}
 	if err = n.startNode(engines, trans, snapMgr); err != nil {
 		return err
@@ -104,6 +107,30 @@ func (n *Node) bootstrapStore(ctx context.Context, engines *engine_util.Engines)
 	return storeID, err
 }
 
+// checkFencingToken returns the token the store registers to the scheduler with, a store bootstrapped without one gets
+// it from the cluster's ID allocator here. The token is persisted before the store registers with it, so the store
+// always comes back with the token the scheduler knows it by, while a store removed from the cluster, or another one
+// claiming its ID, is refused.
+func (n *Node) checkFencingToken(ctx context.Context, engines *engine_util.Engines) (uint64, error) {
+	ident := new(raft_serverpb.StoreIdent)
+	if err := engine_util.GetMeta(engines.Kv, meta.StoreIdentKey, ident); err != nil {
+		return 0, err
+	}
+	if ident.FencingToken != 0 {
+		return ident.FencingToken, nil
+	}
+	token, err := n.allocID(ctx)
+	if err != nil {
+		return 0, err
+	}
+	ident.FencingToken = token
+	if err := engine_util.PutMeta(engines.Kv, meta.StoreIdentKey, ident); err != nil {
+		return 0, err
+	}
+	log.Infof("store %d got fencing token %d", ident.StoreId, token)
+	return token, nil
+}
+
 func (n *Node) allocID(ctx context.Context) (uint64, error) {
 	return n.schedulerClient.AllocID(ctx)
 }
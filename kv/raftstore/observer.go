@@ -0,0 +1,104 @@
+package raftstore
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+)
+
+type RegionChangeEventType int
+
+const (
+	// A peer of the region is created on this store, including the peers loaded on start.
+	RegionChangeCreate RegionChangeEventType = iota
+	// The region is split, Region is the region after the split.
+	RegionChangeSplit
+	// The region is merged with its neighbour, Region is the region after the merge.
+	RegionChangeMerge
+	// The peer of the region on this store is destroyed.
+	RegionChangeDestroy
+	// The leader of the region is changed, see LeaderId.
+	RegionChangeLeader
+	// The conf version of the region epoch is changed by a conf change.
+	RegionChangeEpoch
+)
+
+func (t RegionChangeEventType) String() string {
+	switch t {
+	case RegionChangeCreate:
+		return "Create"
+	case RegionChangeSplit:
+		return "Split"
+	case RegionChangeMerge:
+		return "Merge"
+	case RegionChangeDestroy:
+		return "Destroy"
+	case RegionChangeLeader:
+		return "Leader"
+	case RegionChangeEpoch:
+		return "Epoch"
+	}
+	return "Unknown"
+}
+
+type RegionChangeEvent struct {
+	Type   RegionChangeEventType
+	Region *metapb.Region
+	// The current leader peer id, only set for RegionChangeLeader. 0 means the leader is unknown.
+	LeaderId uint64
+	// Whether the peer on this store is the leader, only set for RegionChangeLeader.
+	IsLeader bool
+}
+
+// RegionChangeObserver is notified of the region changes on a store. It's called in the raftstore goroutines, so
+// it must not block and must not modify the region.
+type RegionChangeObserver interface {
+	OnRegionChanged(event *RegionChangeEvent)
+}
+
+// RegionObserverRegistry keeps the observers of the region changes on a store. Components like a resolved ts
+// tracker subscribe to it instead of patching the applier.
+type RegionObserverRegistry struct {
+	sync.RWMutex
+	observers []RegionChangeObserver
+}
+
+func NewRegionObserverRegistry() *RegionObserverRegistry {
+	return &RegionObserverRegistry{}
+}
+
+func (r *RegionObserverRegistry) Register(observer RegionChangeObserver) {
+	r.Lock()
+	defer r.Unlock()
+	r.observers = append(r.observers, observer)
+}
+
+func (r *RegionObserverRegistry) notify(event *RegionChangeEvent) {
+	if r == nil {
+		return
+	}
+	r.RLock()
+	defer r.RUnlock()
+	for _, observer := range r.observers {
+		observer.OnRegionChanged(event)
+	}
+}
+
+// notifyRegionUpdate compares the region with its origin to tell whether it's split, merged or has a conf change.
+func (r *RegionObserverRegistry) notifyRegionUpdate(origin, region *metapb.Region) {
+	if origin == nil || origin.RegionEpoch == nil || region.RegionEpoch == nil {
+		return
+	}
+	if region.RegionEpoch.Version > origin.RegionEpoch.Version {
+		tp := RegionChangeSplit
+		if bytes.Compare(region.StartKey, origin.StartKey) < 0 ||
+			(len(origin.EndKey) != 0 && (len(region.EndKey) == 0 || bytes.Compare(region.EndKey, origin.EndKey) > 0)) {
+			tp = RegionChangeMerge
+		}
+		r.notify(&RegionChangeEvent{Type: tp, Region: region})
+	}
+	if region.RegionEpoch.ConfVer > origin.RegionEpoch.ConfVer {
+		r.notify(&RegionChangeEvent{Type: RegionChangeEpoch, Region: region})
+	}
+}
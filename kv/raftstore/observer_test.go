@@ -0,0 +1,65 @@
+package raftstore
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/message"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/stretchr/testify/assert"
+)
+
+type eventRecorder struct {
+	events []*RegionChangeEvent
+}
+
+func (r *eventRecorder) OnRegionChanged(event *RegionChangeEvent) {
+	r.events = append(r.events, event)
+}
+
+// take returns the types of the events recorded since the last call.
+func (r *eventRecorder) take() []RegionChangeEventType {
+	var types []RegionChangeEventType
+	for _, e := range r.events {
+		types = append(types, e.Type)
+	}
+	r.events = nil
+	return types
+}
+
+func TestRegionObserver(t *testing.T) {
+	recorder := new(eventRecorder)
+	observers := NewRegionObserverRegistry()
+	observers.Register(recorder)
+	router := newRouter(make(chan message.Msg, 16), observers)
+	meta := newStoreMeta(observers)
+
+	region := &metapb.Region{Id: 1, StartKey: []byte("a"), EndKey: []byte("z"),
+		RegionEpoch: &metapb.RegionEpoch{ConfVer: 1, Version: 1}}
+	p := &peer{regionId: region.Id, peerStorage: &PeerStorage{region: region}}
+	router.register(p)
+	assert.Equal(t, []RegionChangeEventType{RegionChangeCreate}, recorder.take())
+	meta.regions[region.Id] = region
+
+	// the region applied is compared with the current one of the peer
+	apply := func(startKey, endKey string, confVer, version uint64) *metapb.Region {
+		region := &metapb.Region{Id: 1, StartKey: []byte(startKey), EndKey: []byte(endKey),
+			RegionEpoch: &metapb.RegionEpoch{ConfVer: confVer, Version: version}}
+		meta.setRegion(region, p)
+		assert.Equal(t, region, p.Region())
+		return region
+	}
+	split := apply("a", "m", 1, 2)
+	assert.Equal(t, split, recorder.events[0].Region)
+	assert.Equal(t, []RegionChangeEventType{RegionChangeSplit}, recorder.take())
+	apply("a", "z", 1, 3)
+	assert.Equal(t, []RegionChangeEventType{RegionChangeMerge}, recorder.take())
+	apply("a", "z", 2, 3)
+	assert.Equal(t, []RegionChangeEventType{RegionChangeEpoch}, recorder.take())
+	// nothing changed
+	apply("a", "z", 2, 3)
+	assert.Nil(t, recorder.take())
+
+	router.close(region.Id)
+	assert.Equal(t, []RegionChangeEventType{RegionChangeDestroy}, recorder.take())
+	assert.Nil(t, router.get(region.Id))
+}
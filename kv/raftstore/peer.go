@@ -15,6 +15,7 @@ import (
 	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
 	rspb "github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/schedulerpb"
 	"github.com/pingcap-incubator/tinykv/raft"
 	"github.com/pingcap/errors"
 )
@@ -84,10 +85,23 @@ type peer struct {
 	// Record the callback of the proposals
 	// (Used in 2B)
 	proposals []*proposal
+	// The read-only commands served with the raft read index, if RaftReadIndex is set
+	pendingReads readIndexQueue
+	// The PrepareMerge the region applied, nil if it isn't merging. Only a RollbackMerge is proposed meanwhile.
+	pendingMerge *rspb.MergeState
+	// The merge the target peer on the store waits for the region to apply its entries up to, as a source
+	catchUpLogs *message.MsgCatchUpLogs
+	// The CommitMerge the peer waits to apply until the source is up to date, as a target
+	mergeWait *mergeWait
+	// The lease the leader serves the read-only commands locally in, nil if disabled
+	lease *leaderLease
 
 	// Index of last scheduled compacted raft log.
 	// (Used in 2C)
 	LastCompactedIdx uint64
+	// Approximate byte size of the raft log not compacted yet, measured on the raft log gc tick of the leader and
+	// increased by the writes proposed in between. Only tracked if the raft log quota is enabled.
+	raftLogSize uint64
 
 	// Cache the peers information from other stores
 	// when sending raft messages to other peers, it's used to get the store id of target peer
@@ -97,18 +111,43 @@ type peer struct {
 	// Remove them after they are not pending any more.
 	// (Used in 3B conf change)
 	PeersStartPendingTime map[uint64]time.Time
+	// The leader observed in the last ready loop, used to notify leader changes
+	lastLeaderId uint64
+	// Record the peers whose snapshot is generated and sent by a follower on
+	// behalf of the leader, it's cleared after the peer catches up.
+	snapDelegations map[uint64]time.Time
+	// Backs off the snapshots of the followers which keep needing them, nil if disabled
+	snapBackoff *snapBackoff
+	// The snapshots applied by the followers since the last region heartbeat,
+	// they are reported in the next one.
+	snapshotsApplied []*schedulerpb.SnapshotApplied
+	// Tells whether the writes of the peer have been slow for a while, nil if not checked
+	stall *util.StallDetector
+	// Whether the peer was created explicitly and waits for its first
+	// snapshot, it's destroyed if none is applied before bootstrapDeadline,
+	// unless the deadline is zero.
+	awaitingSnapshot  bool
+	bootstrapDeadline time.Time
+	// The checkpoint of the data kept from a removed peer of the region, an
+	// uninitialized peer may catch up from it until checkpointDeadline, zero
+	// until the leader is asked to verify it.
+	checkpoint         *rspb.RegionCheckpoint
+	checkpointDeadline time.Time
 	// Mark the peer as stopped, set when peer is destroyed
 	// (Used in 3B conf change)
 	stopped bool
 
 	// An inaccurate difference in region size since last reset.
-	// split checker is triggered when it exceeds the threshold, it makes split checker not scan the data very often
+	// split checker is triggered when it exceeds the threshold, it makes split checker not scan the data very often.
+	// Deletes add to it too, so the approximate size is updated after a large delete
 	// (Used in 3B split)
 	SizeDiffHint uint64
 	// Approximate size of the region.
 	// It's updated everytime the split checker scan the data
 	// (Used in 3B split)
 	ApproximateSize *uint64
+	// Approximate number of keys of the region, updated along with ApproximateSize.
+	ApproximateKeys *uint64
 }
 
 func NewPeer(storeId uint64, cfg *config.Config, engines *engine_util.Engines, region *metapb.Region, regionSched chan<- worker.Task,
@@ -122,15 +161,24 @@ func NewPeer(storeId uint64, cfg *config.Config, engines *engine_util.Engines, r
 	if err != nil {
 		return nil, err
 	}
+	ps.replay = newReplayBuffer(cfg.RaftReplayBufferSize)
+	ps.entryCache = newEntryCache(cfg.RaftEntryCacheSize)
 
 	appliedIndex := ps.AppliedIndex()
 
 	raftCfg := &raft.Config{
-		ID:            meta.GetId(),
-		ElectionTick:  cfg.RaftElectionTimeoutTicks,
-		HeartbeatTick: cfg.RaftHeartbeatTicks,
-		Applied:       appliedIndex,
-		Storage:       ps,
+		ID:                        meta.GetId(),
+		ElectionTick:              cfg.RaftElectionTimeoutTicks,
+		HeartbeatTick:             cfg.RaftHeartbeatTicks,
+		Applied:                   appliedIndex,
+		Storage:                   ps,
+		MaxSizePerMsg:             cfg.RaftMaxSizePerMsg,
+		MaxInflightMsgs:           cfg.RaftMaxInflightMsgs,
+		MaxUncommittedEntriesSize: cfg.RaftMaxUncommittedSize,
+		RequirePersistAck:         cfg.RaftRequirePersistAck,
+		AsyncApply:                cfg.RaftApplyWorkers > 0,
+		PreVote:                   cfg.RaftPreVote,
+		ParallelAppend:            cfg.RaftParallelAppend,
 	}
 
 	raftGroup, err := raft.NewRawNode(raftCfg)
@@ -144,6 +192,10 @@ func NewPeer(storeId uint64, cfg *config.Config, engines *engine_util.Engines, r
 		peerStorage:           ps,
 		peerCache:             make(map[uint64]*metapb.Peer),
 		PeersStartPendingTime: make(map[uint64]time.Time),
+		snapDelegations:       make(map[uint64]time.Time),
+		snapBackoff:           newSnapBackoff(cfg),
+		stall:                 util.NewStallDetector(cfg.SlowLeaderLatencyThreshold, cfg.SlowLeaderDuration),
+		lease:                 newLeaderLease(cfg.RaftLeaderLease),
 		Tag:                   tag,
 		ticker:                newTicker(region.GetId(), cfg),
 	}
@@ -198,17 +250,29 @@ func (p *peer) MaybeDestroy() bool {
 /// 2. Clear data;
 /// 3. Notify all pending requests.
 func (p *peer) Destroy(engine *engine_util.Engines, keepData bool) error {
+	return p.destroy(engine, keepData, true)
+}
+
+/// Clears all the data and state of the peer like `Destroy`, but doesn't leave
+/// a tombstone, so a peer with the same id can be created again.
+func (p *peer) Wipe(engine *engine_util.Engines) error {
+	return p.destroy(engine, false, false)
+}
+
+func (p *peer) destroy(engine *engine_util.Engines, keepData bool, tombstone bool) error {
 	start := time.Now()
 	region := p.Region()
 	log.Infof("%v begin to destroy", p.Tag)
 
-	// Set Tombstone state explicitly
 	kvWB := new(engine_util.WriteBatch)
 	raftWB := new(engine_util.WriteBatch)
 	if err := p.peerStorage.clearMeta(kvWB, raftWB); err != nil {
 		return err
 	}
-	meta.WriteRegionState(kvWB, region, rspb.PeerState_Tombstone)
+	if tombstone {
+		// Set Tombstone state explicitly
+		meta.WriteRegionState(kvWB, region, rspb.PeerState_Tombstone)
+	}
 	// write kv rocksdb first in case of restart happen between two write
 	if err := kvWB.WriteToDB(engine.Kv); err != nil {
 		return err
@@ -264,8 +328,23 @@ func (p *peer) IsLeader() bool {
 	return p.RaftGroup.Raft.State == raft.StateLeader
 }
 
+// GetRaftStatus returns the raft status of the peer, with the progresses of the other peers if it's the leader.
+func (p *peer) GetRaftStatus() raft.Status {
+	return p.RaftGroup.Status()
+}
+
+// Send sends the messages of a ready to the other peers. The append responses of the leader to itself aren't sent,
+// see stepSelfAppendResponses.
 func (p *peer) Send(trans Transport, msgs []eraftpb.Message) {
+	now := time.Now()
+	p.lease.onHeartbeatsSent(p.Term(), msgs, now)
 	for _, msg := range msgs {
+		if raft.IsSelfAppendResponse(msg) {
+			continue
+		}
+		if msg.MsgType == eraftpb.MessageType_MsgSnapshot {
+			p.snapBackoff.onSent(p.Tag, msg.To, now)
+		}
 		err := p.sendRaftMessage(msg, trans)
 		if err != nil {
 			log.Debugf("%v send message err: %v", p.Tag, err)
@@ -273,6 +352,34 @@ func (p *peer) Send(trans Transport, msgs []eraftpb.Message) {
 	}
 }
 
+// splitParallelAppends splits the messages of a ready into the appends of the leader, which may be sent before the
+// ready is persisted, see raft.Config.ParallelAppend, and the rest. The rest, e.g. the vote responses and the append
+// responses, tell the term, the vote or the entries of the ready, so they're sent only once it's persisted, or a peer
+// restarted before could vote twice in a term or lose the entries the leader counted.
+func splitParallelAppends(msgs []eraftpb.Message) (appends, rest []eraftpb.Message) {
+	for _, msg := range msgs {
+		if msg.MsgType == eraftpb.MessageType_MsgAppend {
+			appends = append(appends, msg)
+		} else {
+			rest = append(rest, msg)
+		}
+	}
+	return appends, rest
+}
+
+// stepSelfAppendResponses steps the append responses the leader sends to itself in the messages of a ready, once the
+// entries of the ready are persisted, so they count towards the commit. See raft.Config.ParallelAppend.
+func (p *peer) stepSelfAppendResponses(msgs []eraftpb.Message) {
+	for _, msg := range msgs {
+		if !raft.IsSelfAppendResponse(msg) {
+			continue
+		}
+		if err := p.RaftGroup.Step(msg); err != nil {
+			log.Warnf("%v failed to step the self append response of index %d: %v", p.Tag, msg.Index, err)
+		}
+	}
+}
+
 /// Collects all pending peers and update `peers_start_pending_time`.
 func (p *peer) CollectPendingPeers() []*metapb.Peer {
 	pendingPeers := make([]*metapb.Peer, 0, len(p.Region().GetPeers()))
@@ -326,6 +433,108 @@ func (p *peer) AnyNewPeerCatchUp(peerId uint64) bool {
 	return false
 }
 
+// snapDelegationTimeout is how long the leader waits for the snapshot sent by
+// a follower before generating the snapshot by itself.
+const snapDelegationTimeout = 30 * time.Second
+
+/// Asks the most up-to-date follower to generate and send the snapshot for the
+/// peers which have fallen behind the truncated index, each peer is delegated
+/// at most once until it catches up. A witness stores no data, so it neither
+/// sends nor is sent a snapshot of it.
+func (p *peer) MaybeDelegateSnapshots(trans Transport) {
+	truncatedIdx := p.peerStorage.truncatedIndex()
+	progress := p.RaftGroup.GetProgress()
+	var helper *metapb.Peer
+	var helperMatch uint64
+	for id, pr := range progress {
+		if id == p.PeerId() || pr.IsWitness || pr.Match < truncatedIdx || pr.Match <= helperMatch {
+			continue
+		}
+		if peer := p.getPeerFromCache(id); peer != nil {
+			helper, helperMatch = peer, pr.Match
+		}
+	}
+	var lagging []uint64
+	for id, pr := range progress {
+		if id == p.PeerId() || pr.IsWitness || pr.Match >= truncatedIdx {
+			delete(p.snapDelegations, id)
+			continue
+		}
+		lagging = append(lagging, id)
+		if _, ok := p.snapDelegations[id]; ok || helper == nil {
+			continue
+		}
+		target := p.getPeerFromCache(id)
+		if target == nil {
+			continue
+		}
+		msg := &rspb.RaftMessage{
+			RegionId: p.regionId,
+			FromPeer: p.Meta,
+			ToPeer:   helper,
+			RegionEpoch: &metapb.RegionEpoch{
+				ConfVer: p.Region().RegionEpoch.ConfVer,
+				Version: p.Region().RegionEpoch.Version,
+			},
+			SnapshotDelegation: &rspb.SnapshotDelegation{
+				Target: target,
+				Term:   p.Term(),
+			},
+		}
+		if err := trans.Send(msg); err != nil {
+			log.Warnf("%v failed to delegate snapshot of peer %d to %d: %v", p.Tag, id, helper.Id, err)
+			continue
+		}
+		log.Infof("%v delegate snapshot of peer %d to %d", p.Tag, id, helper.Id)
+		p.snapDelegations[id] = time.Now().Add(snapDelegationTimeout)
+	}
+	p.peerStorage.snapDelegatedUntil = snapDelegatedUntil(p.snapDelegations, lagging, time.Now())
+}
+
+// snapDelegatedUntil returns the time before which the leader shouldn't generate a snapshot for the lagging
+// followers, as each of them is sent one by a follower till then. It's zero if any of them isn't delegated or its
+// delegation timed out, the snapshot generated for it serves the others too.
+func snapDelegatedUntil(delegations map[uint64]time.Time, lagging []uint64, now time.Time) time.Time {
+	var until time.Time
+	for _, id := range lagging {
+		deadline, ok := delegations[id]
+		if !ok || !now.Before(deadline) {
+			return time.Time{}
+		}
+		if until.IsZero() || deadline.Before(until) {
+			until = deadline
+		}
+	}
+	return until
+}
+
+// UpdateSnapBackoff resets the snapshot backoff of the followers which have caught up, and keeps the leader from
+// generating a snapshot while all the lagging followers are backed off.
+func (p *peer) UpdateSnapBackoff() {
+	if p.snapBackoff == nil {
+		return
+	}
+	if !p.IsLeader() {
+		p.snapBackoff.followers = make(map[uint64]*followerSnapBackoff)
+		p.peerStorage.snapBackoffUntil = time.Time{}
+		return
+	}
+	truncatedIdx := p.peerStorage.truncatedIndex()
+	progress := p.RaftGroup.GetProgress()
+	for id := range p.snapBackoff.followers {
+		if pr, ok := progress[id]; !ok || pr.Match >= truncatedIdx {
+			p.snapBackoff.onCaughtUp(id)
+		}
+	}
+	var lagging []uint64
+	for id, pr := range progress {
+		if id != p.PeerId() && !pr.IsWitness && pr.Match < truncatedIdx {
+			lagging = append(lagging, id)
+		}
+	}
+	p.peerStorage.snapBackoffUntil = p.snapBackoff.until(lagging, time.Now())
+}
+
 func (p *peer) MaybeCampaign(parentIsLeader bool) bool {
 	// The peer campaigned when it was created, no need to do it again.
 	if len(p.Region().GetPeers()) <= 1 || !parentIsLeader {
@@ -349,14 +558,73 @@ func (p *peer) HeartbeatScheduler(ch chan<- worker.Task) {
 		return
 	}
 	ch <- &runner.SchedulerRegionHeartbeatTask{
-		Region:          clonedRegion,
-		Peer:            p.Meta,
-		PendingPeers:    p.CollectPendingPeers(),
-		ApproximateSize: p.ApproximateSize,
+		Region:           clonedRegion,
+		Peer:             p.Meta,
+		PendingPeers:     p.CollectPendingPeers(),
+		ApproximateSize:  p.ApproximateSize,
+		ApproximateKeys:  p.ApproximateKeys,
+		SnapshotsApplied: p.snapshotsApplied,
 	}
+	p.snapshotsApplied = nil
+}
+
+/// Reports the result of applying the snapshot to the leader, so that the
+/// leader resumes the replication after the snapshot, or retries with a fresh
+/// snapshot if err is not nil. applyDuration is the time from receiving the
+/// snapshot to finishing applying it, the leader reports it to the scheduler
+/// along with the snapshot.
+func (p *peer) ReportSnapshotStatus(trans Transport, snapMeta *eraftpb.SnapshotMetadata, applyDuration time.Duration, err error) {
+	if err != nil {
+		log.Warnf("%v failed to apply snapshot at index %d: %v", p.Tag, snapMeta.GetIndex(), err)
+	}
+	msg := eraftpb.Message{
+		MsgType: eraftpb.MessageType_MsgSnapStatus,
+		From:    p.PeerId(),
+		To:      p.LeaderId(),
+		Term:    p.Term(),
+		Index:   snapMeta.GetIndex(),
+		Reject:  err != nil,
+	}
+	sendMsg, err1 := p.newRaftMessage(msg)
+	if err1 == nil {
+		if err == nil {
+			sendMsg.SnapshotApplied = &rspb.SnapshotApplied{
+				Index:           snapMeta.GetIndex(),
+				Term:            snapMeta.GetTerm(),
+				ApplyDurationMs: uint64(applyDuration / time.Millisecond),
+			}
+		}
+		err1 = trans.Send(sendMsg)
+	}
+	if err1 != nil {
+		log.Debugf("%v send snapshot status err: %v", p.Tag, err1)
+	}
+}
+
+// onSnapshotApplied records the snapshot applied by the peer of the region, it's reported in the next region
+// heartbeat.
+func (p *peer) onSnapshotApplied(from *metapb.Peer, applied *rspb.SnapshotApplied) {
+	if !p.IsLeader() {
+		return
+	}
+	p.snapshotsApplied = append(p.snapshotsApplied, &schedulerpb.SnapshotApplied{
+		Peer:            from,
+		Index:           applied.Index,
+		Term:            applied.Term,
+		ApplyDurationMs: applied.ApplyDurationMs,
+	})
 }
 
 func (p *peer) sendRaftMessage(msg eraftpb.Message, trans Transport) error {
+	sendMsg, err := p.newRaftMessage(msg)
+	if err != nil {
+		return err
+	}
+	return trans.Send(sendMsg)
+}
+
+// newRaftMessage wraps the message to send to a peer of the region.
+func (p *peer) newRaftMessage(msg eraftpb.Message) (*rspb.RaftMessage, error) {
 	sendMsg := new(rspb.RaftMessage)
 	sendMsg.RegionId = p.regionId
 	// set current epoch
@@ -368,7 +636,7 @@ func (p *peer) sendRaftMessage(msg eraftpb.Message, trans Transport) error {
 	fromPeer := *p.Meta
 	toPeer := p.getPeerFromCache(msg.To)
 	if toPeer == nil {
-		return fmt.Errorf("failed to lookup recipient peer %v in region %v", msg.To, p.regionId)
+		return nil, fmt.Errorf("failed to lookup recipient peer %v in region %v", msg.To, p.regionId)
 	}
 	log.Debugf("%v, send raft msg %v from %v to %v", p.Tag, msg.MsgType, fromPeer, toPeer)
 
@@ -388,5 +656,5 @@ func (p *peer) sendRaftMessage(msg eraftpb.Message, trans Transport) error {
 		sendMsg.EndKey = append([]byte{}, p.Region().EndKey...)
 	}
 	sendMsg.Message = &msg
-	return trans.Send(sendMsg)
+	return sendMsg, nil
 }
@@ -2,17 +2,22 @@ package raftstore
 
 import (
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/Connor1996/badger/y"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/message"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/meta"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/runner"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/snap"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
+	"github.com/pingcap-incubator/tinykv/kv/transaction/mvcc"
 	"github.com/pingcap-incubator/tinykv/log"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
 	rspb "github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
+	"github.com/pingcap-incubator/tinykv/raft"
 	"github.com/pingcap-incubator/tinykv/scheduler/pkg/btree"
 	"github.com/pingcap/errors"
 )
@@ -24,11 +29,16 @@ const (
 	PeerTickRaftLogGC          PeerTick = 1
 	PeerTickSplitRegionCheck   PeerTick = 2
 	PeerTickSchedulerHeartbeat PeerTick = 3
+	PeerTickRollbackCleanup    PeerTick = 4
+	PeerTickCheckMerge         PeerTick = 5
+	PeerTickRawTombstoneGC     PeerTick = 6
 )
 
 type peerMsgHandler struct {
 	*peer
 	ctx *GlobalContext
+	// the stage timers of the raft worker, nil if the handler isn't run by one
+	stats *readyStats
 }
 
 func newPeerMsgHandler(peer *peer, ctx *GlobalContext) *peerMsgHandler {
@@ -42,9 +52,78 @@ func (d *peerMsgHandler) HandleRaftReady() {
 	if d.stopped {
 		return
 	}
+	d.maybeNotifyLeaderChange()
+	// NOTE: call d.ctx.applyObservers.preApply before applying each request of a command, a request it returns an
+	// error for isn't applied and the error is returned to the proposer. Call d.ctx.applyObservers.postApply after
+	// the request is applied and before the write batch is written to the kv engine.
+	// Decode the commands with util.DecodeRaftCmd, a util.ErrProposalChecksum means the entry is corrupted and must
+	// not be applied. Parse them with util.ParseCmd and apply each kind of util.Cmd by its own path, a
+	// util.WriteCmd in one write batch.
+	// Observe the time taken to persist and apply each ready with d.stall.Observe.
+	// Time the stages of handling the ready with d.stats.start and readyTimer.stop: stageCollectReady for
+	// d.RaftGroup.Ready, stageAppend for d.peerStorage.SaveReadyState, stageSend for d.Send, stageApply for the
+	// committed entries and stageAdvance for d.RaftGroup.Advance.
+	// Expire the leader lease with d.lease.expire when applying a split, the new regions elect their own leaders.
+	// Add util.WriteCmd.Size of each write command applied to d.SizeDiffHint, the split check tick scans the region
+	// once it's over d.ctx.cfg.SplitCheckDiff and asks the scheduler to split it if it's over RegionMaxSize.
+	// Apply a PrepareMerge with util.ApplyPrepareMerge, write the region in the Merging state with its merge state
+	// by meta.WriteMergingRegionState and set d.pendingMerge to it. Apply a RollbackMerge with
+	// util.ApplyRollbackMerge and clear d.pendingMerge. Before applying a CommitMerge to the target, the source peer
+	// on the store must have applied its own entries up to the Commit of the request, the target never applies them
+	// itself: if the apply state of the source in the kv engine is behind, send the source peer a
+	// MsgTypeCatchUpLogs with the request and set d.mergeWait to the source and the index of the CommitMerge. The
+	// entries from d.mergeWait.index on aren't applied while it's set, drop them from rd.CommittedEntries before
+	// advancing the ready, so the raft group hands them out again once the source replies with MsgTypeLogsUpToDate,
+	// see d.onLogsUpToDate. Apply the CommitMerge with util.ApplyCommitMerge: the merged region and the source in the
+	// Tombstone state are written in the same batch, and a MsgTypeMergeResult with the merged region is sent to the
+	// source peer, which destroys itself. A peer whose region state is Tombstone in the kv engine doesn't apply any
+	// more entries.
+	// If d.catchUpLogs is set, apply the entries of its request after the applied index up to its Commit, the
+	// PrepareMerge, before the committed entries of the ready, and call d.onCaughtUp once they're written.
+	// Apply a CmdType_Custom request with d.ctx.applyDelegates.apply.
+	// Apply a ConfChange entry from its context alone, with util.ParseConfChangeContext and util.ApplyConfChange.
+	// Add the committed entries of the ready to d.peerStorage.replay with append before applying them.
+	// If d.ctx.lockTable is not nil, write the kv write batch with d.ctx.lockTable.Write, set cb.Locks of a Snap
+	// command to d.ctx.lockTable.Snapshot of the region, and call d.ctx.lockTable.Checkpoint for the regions after
+	// applying a CompactLog, a split or a merge.
+	// Pass the read states of the ready to d.pendingReads.advance, and serve the reads of d.pendingReads.popApplied
+	// once the committed entries are applied, like a Get or Snap command applied at that index.
+	// If d.ctx.applyWorker is not nil, advance the ready with d.RaftGroup.AdvanceAppend once it's persisted and its
+	// messages are sent, and apply the committed entries in an applyTask of d.PeerId() scheduled by
+	// d.ctx.applyWorker.schedule, which returns the index of the last entry. The next readies of the peer may be
+	// handled meanwhile, and d.onApplied advances the raft group after the task. An admin command, i.e. a split, a conf change, a merge or
+	// a CompactLog, changes the region and the peers the raft worker works with, so the entries of a ready which
+	// holds one are never handed to the apply worker: apply them on the raft worker, once d.onApplied reports the
+	// tasks scheduled before, and advance the ready with d.RaftGroup.Advance.
+	// If d.ctx.logSyncer is not nil, the raft engine doesn't sync each write. The messages of a ready which MustSync
+	// are held until its writes are synced: don't send any of them after writing the ready, send them and advance
+	// the ready in a callback of d.ctx.logSyncer.afterSync, which is called once the round is synced. A ready which
+	// doesn't MustSync is sent and advanced at once.
+	// If d.ctx.cfg.RaftParallelAppend is set and the peer is the leader, send only the appends of
+	// splitParallelAppends of the messages of the ready with d.Send before persisting it, so the followers append the
+	// entries meanwhile. Once it's persisted, call d.stepSelfAppendResponses of the messages and send the rest of them,
+	// which must never go out before the hard state and the entries they tell of are durable.
+	// A witness, i.e. d.Meta.IsWitness, stores no data: it applies only the admin commands and the conf changes
+	// to its region, and doesn't write the data of the write commands or of a snapshot to the kv engine.
 	// Your Code Here (2B).
 }
 
+func (d *peerMsgHandler) maybeNotifyLeaderChange() {
+	leaderId := d.LeaderId()
+	if leaderId == d.lastLeaderId {
+		return
+	}
+	d.lastLeaderId = leaderId
+	d.pendingReads.clear(&util.ErrNotLeader{RegionId: d.regionId, Leader: d.getPeerFromCache(leaderId)}, d.Term())
+	d.lease.expire()
+	d.ctx.storeMeta.observers.notify(&RegionChangeEvent{
+		Type:     RegionChangeLeader,
+		Region:   d.Region(),
+		LeaderId: leaderId,
+		IsLeader: d.IsLeader(),
+	})
+}
+
 func (d *peerMsgHandler) HandleMsg(msg message.Msg) {
 	switch msg.Type {
 	case message.MsgTypeRaftMessage:
@@ -61,17 +140,50 @@ func (d *peerMsgHandler) HandleMsg(msg message.Msg) {
 		split := msg.Data.(*message.MsgSplitRegion)
 		log.Infof("%s on split with %v", d.Tag, split.SplitKey)
 		d.onPrepareSplitRegion(split.RegionEpoch, split.SplitKey, split.Callback)
+	case message.MsgTypeMergeRegion:
+		merge := msg.Data.(*message.MsgMergeRegion)
+		d.onPrepareMerge(merge.RegionEpoch, merge.Target, merge.Callback)
+	case message.MsgTypeMergeResult:
+		d.onMergeResult(msg.Data.(*metapb.Region))
+	case message.MsgTypeCatchUpLogs:
+		d.onCatchUpLogs(msg.Data.(*message.MsgCatchUpLogs))
+	case message.MsgTypeLogsUpToDate:
+		d.onLogsUpToDate(msg.Data.(uint64))
 	case message.MsgTypeRegionApproximateSize:
 		d.onApproximateRegionSize(msg.Data.(uint64))
+	case message.MsgTypeRegionApproximateKeys:
+		d.onApproximateRegionKeys(msg.Data.(uint64))
 	case message.MsgTypeGcSnap:
 		gcSnap := msg.Data.(*message.MsgGCSnap)
 		d.onGCSnap(gcSnap.Snaps)
 	case message.MsgTypeStart:
 		d.startTicker()
+	case message.MsgTypeApplied:
+		d.onApplied(msg.Data.(uint64))
+	case message.MsgTypeRaftStatus:
+		msg.Data.(chan<- raft.Status) <- d.GetRaftStatus()
+	case message.MsgTypeCompactLog:
+		d.onCompactLog(msg.Data.(*message.Callback))
+	case message.MsgTypeSplitCheck:
+		d.onSplitCheck(msg.Data.(*message.Callback))
+	}
+}
+
+// onApplied advances the raft group once the apply worker applies the committed entries up to the index. An index
+// at or below the applied index is stale, e.g. a snapshot was applied after the task, and dropped.
+func (d *peerMsgHandler) onApplied(applied uint64) {
+	if d.stopped || applied <= d.RaftGroup.BasicStatus().Applied {
+		return
 	}
+	d.RaftGroup.AdvanceApply(applied)
 }
 
 func (d *peerMsgHandler) preProposeRaftCommand(req *raft_cmdpb.RaftCmdRequest) error {
+	// Only a well-formed command is applied atomically.
+	cmd, err := util.ParseCmd(req)
+	if err != nil {
+		return err
+	}
 	// Check store_id, make sure that the msg is dispatched to the right place.
 	if err := util.CheckStoreID(req, d.storeID()); err != nil {
 		return err
@@ -92,7 +204,11 @@ func (d *peerMsgHandler) preProposeRaftCommand(req *raft_cmdpb.RaftCmdRequest) e
 	if err := util.CheckTerm(req, d.Term()); err != nil {
 		return err
 	}
-	err := util.CheckRegionEpoch(req, d.Region(), true)
+	// A merging region only rolls the merge back, its peers are destroyed once the target commits the merge.
+	if d.pendingMerge != nil && req.GetAdminRequest().GetCmdType() != raft_cmdpb.AdminCmdType_RollbackMerge {
+		return &util.ErrServerIsBusy{RegionId: regionID, Reason: "the region is merging"}
+	}
+	err = util.CheckRegionEpoch(req, d.Region(), true)
 	if errEpochNotMatching, ok := err.(*util.ErrEpochNotMatch); ok {
 		// Attach the region which might be split from the current region. But it doesn't
 		// matter if the region is not split from the current region. If the region meta
@@ -104,7 +220,22 @@ func (d *peerMsgHandler) preProposeRaftCommand(req *raft_cmdpb.RaftCmdRequest) e
 		}
 		return errEpochNotMatching
 	}
-	return err
+	if err != nil {
+		return err
+	}
+	size := uint64(req.Size())
+	if size > d.ctx.cfg.RaftEntryMaxSize {
+		return &util.ErrRaftEntryTooLarge{RegionId: regionID, EntrySize: size}
+	}
+	// Only the writes are held back by the quota, the admin commands like CompactLog must go through to free it.
+	if _, ok := cmd.(*util.WriteCmd); ok && d.ctx.cfg.RaftLogQuota > 0 {
+		quota := d.ctx.cfg.RaftLogQuota
+		if d.raftLogSize >= quota {
+			return &util.ErrRaftLogQuotaExceeded{RegionId: regionID, LogSize: d.raftLogSize, Quota: quota}
+		}
+		d.raftLogSize += size
+	}
+	return nil
 }
 
 func (d *peerMsgHandler) proposeRaftCommand(msg *raft_cmdpb.RaftCmdRequest, cb *message.Callback) {
@@ -113,14 +244,39 @@ func (d *peerMsgHandler) proposeRaftCommand(msg *raft_cmdpb.RaftCmdRequest, cb *
 		cb.Done(ErrResp(err))
 		return
 	}
+	// NOTE: encode the proposal with util.EncodeRaftCmd, with a checksum if d.ctx.cfg.RaftProposalChecksum is set.
+	// Propose a ChangePeer with the ConfChange built by util.NewConfChange.
+	// A request of only Get and Snap commands is answered by d.readLocally if it returns true. Otherwise, if
+	// d.ctx.cfg.RaftReadIndex is set, it isn't proposed but requests the read index with d.pendingReads.propose, a
+	// dropped request fails with util.ErrNotLeader.
+	// Call d.lease.expire before transferring the leadership.
+	// Fail a proposal the raft group returns an error for with d.proposeError of it.
 	// Your Code Here (2B).
 }
 
+// proposeError returns the error of a proposal the raft group failed to propose. A leader drops the proposals while
+// its uncommitted log is over Config.MaxUncommittedEntriesSize, so the proposer backs off until it's committed.
+func (d *peerMsgHandler) proposeError(err error) error {
+	if err != raft.ErrProposalDropped {
+		return err
+	}
+	if !d.IsLeader() {
+		return &util.ErrNotLeader{RegionId: d.regionId, Leader: d.getPeerFromCache(d.LeaderId())}
+	}
+	return &util.ErrServerIsBusy{RegionId: d.regionId, Reason: "the raft proposal is dropped"}
+}
+
 func (d *peerMsgHandler) onTick() {
 	if d.stopped {
 		return
 	}
 	d.ticker.tickClock()
+	if d.awaitingSnapshot {
+		d.checkBootstrap()
+		if d.stopped {
+			return
+		}
+	}
 	if d.ticker.isOnTick(PeerTickRaft) {
 		d.onRaftBaseTick()
 	}
@@ -133,6 +289,15 @@ func (d *peerMsgHandler) onTick() {
 	if d.ticker.isOnTick(PeerTickSplitRegionCheck) {
 		d.onSplitRegionCheckTick()
 	}
+	if d.ticker.isOnTick(PeerTickRollbackCleanup) {
+		d.onRollbackCleanupTick()
+	}
+	if d.ticker.isOnTick(PeerTickCheckMerge) {
+		d.onCheckMergeTick()
+	}
+	if d.ticker.isOnTick(PeerTickRawTombstoneGC) {
+		d.onRawTombstoneGCTick()
+	}
 	d.ctx.tickDriverSender <- d.regionId
 }
 
@@ -143,10 +308,37 @@ func (d *peerMsgHandler) startTicker() {
 	d.ticker.schedule(PeerTickRaftLogGC)
 	d.ticker.schedule(PeerTickSplitRegionCheck)
 	d.ticker.schedule(PeerTickSchedulerHeartbeat)
+	d.ticker.schedule(PeerTickRollbackCleanup)
+	d.ticker.schedule(PeerTickCheckMerge)
+	d.ticker.schedule(PeerTickRawTombstoneGC)
+}
+
+// checkBootstrap ends the wait of an explicitly created peer once a snapshot
+// initialized it, or destroys the peer if none did before its deadline.
+func (d *peerMsgHandler) checkBootstrap() {
+	if d.isInitialized() {
+		d.stopAwaitingSnapshot()
+		log.Infof("%s is initialized by a snapshot", d.Tag)
+		return
+	}
+	if d.bootstrapDeadline.IsZero() || time.Now().Before(d.bootstrapDeadline) ||
+		d.peerStorage.snapState.StateType == snap.SnapState_Applying {
+		return
+	}
+	log.Warnf("%s gets no snapshot within %v, destroy it", d.Tag, d.ctx.cfg.PeerBootstrapTimeout)
+	d.destroyPeer()
+}
+
+func (d *peerMsgHandler) stopAwaitingSnapshot() {
+	if d.awaitingSnapshot {
+		d.awaitingSnapshot = false
+		atomic.AddInt32(&d.ctx.bootstrappingPeers, -1)
+	}
 }
 
 func (d *peerMsgHandler) onRaftBaseTick() {
 	d.RaftGroup.Tick()
+	d.UpdateSnapBackoff()
 	d.ticker.schedule(PeerTickRaft)
 }
 
@@ -158,6 +350,7 @@ func (d *peerMsgHandler) ScheduleCompactLog(truncatedIndex uint64) {
 		EndIdx:     truncatedIndex + 1,
 	}
 	d.LastCompactedIdx = raftLogGCTask.EndIdx
+	d.peerStorage.entryCache.compact(truncatedIndex)
 	d.ctx.raftLogGCTaskSender <- raftLogGCTask
 }
 
@@ -175,9 +368,20 @@ func (d *peerMsgHandler) onRaftMsg(msg *rspb.RaftMessage) error {
 		d.handleGCPeerMsg(msg)
 		return nil
 	}
+	if msg.SnapshotDelegation != nil {
+		d.onSnapshotDelegation(msg)
+		return nil
+	}
+	if msg.CheckpointCatchUp != nil {
+		d.onCheckpointCatchUp(msg)
+		return nil
+	}
 	if d.checkMessage(msg) {
 		return nil
 	}
+	if d.waitCheckpointCatchUp(msg) {
+		return nil
+	}
 	key, err := d.checkSnapshot(msg)
 	if err != nil {
 		return err
@@ -195,10 +399,20 @@ func (d *peerMsgHandler) onRaftMsg(msg *rspb.RaftMessage) error {
 		return nil
 	}
 	d.insertPeerCache(msg.GetFromPeer())
+	if err := d.verifySnapshot(msg); err != nil {
+		return err
+	}
+	if msg.SnapshotApplied != nil {
+		d.onSnapshotApplied(msg.FromPeer, msg.SnapshotApplied)
+	}
 	err = d.RaftGroup.Step(*msg.GetMessage())
 	if err != nil {
 		return err
 	}
+	if msg.Message.MsgType == eraftpb.MessageType_MsgHeartbeatResponse && d.IsLeader() {
+		voters := util.ConfStateFromRegion(d.Region()).Nodes
+		d.lease.onHeartbeatResponse(msg.Message.Term, msg.Message.From, voters, d.PeerId(), time.Now())
+	}
 	if d.AnyNewPeerCatchUp(msg.FromPeer.Id) {
 		d.HeartbeatScheduler(d.ctx.schedulerTaskSender)
 	}
@@ -365,15 +579,51 @@ func (d *peerMsgHandler) checkSnapshot(msg *rspb.RaftMessage) (*snap.SnapKey, er
 	return nil, nil
 }
 
+// verifySnapshot checks the files of the snapshot in the message before it's applied. A corrupted snapshot is deleted
+// and its append is rejected, so the leader sends the snapshot again.
+func (d *peerMsgHandler) verifySnapshot(msg *rspb.RaftMessage) error {
+	snapshot := msg.GetMessage().GetSnapshot()
+	if snapshot == nil {
+		return nil
+	}
+	key := snap.SnapKeyFromRegionSnap(msg.RegionId, snapshot)
+	s, err := d.ctx.snapMgr.GetSnapshotForApplying(key)
+	if err != nil {
+		return err
+	}
+	err = s.Verify()
+	if err == nil {
+		return nil
+	}
+	log.Errorf("%s snapshot %s is corrupted, ask for it again: %v", d.Tag, key, err)
+	d.ctx.snapMgr.DeleteSnapshot(key, s, false)
+	reject := eraftpb.Message{
+		MsgType: eraftpb.MessageType_MsgAppendResponse,
+		To:      msg.Message.From,
+		From:    d.PeerId(),
+		Term:    msg.Message.Term,
+		Index:   snapshot.GetMetadata().GetIndex(),
+		LogTerm: snapshot.GetMetadata().GetTerm(),
+		Reject:  true,
+	}
+	if sendErr := d.sendRaftMessage(reject, d.ctx.trans); sendErr != nil {
+		log.Warnf("%s failed to reject corrupted snapshot %s: %v", d.Tag, key, sendErr)
+	}
+	return err
+}
+
 func (d *peerMsgHandler) destroyPeer() {
 	log.Infof("%s starts destroy", d.Tag)
 	regionID := d.regionId
+	if d.ctx.applyWorker != nil {
+		d.ctx.applyWorker.stop(regionID, d.PeerId())
+	}
 	// We can't destroy a peer which is applying snapshot.
 	meta := d.ctx.storeMeta
 	meta.Lock()
 	defer meta.Unlock()
 	isInitialized := d.isInitialized()
-	if err := d.Destroy(d.ctx.engine, false); err != nil {
+	if err := d.Destroy(d.ctx.engine, d.maybeKeepData()); err != nil {
 		// If not panic here, the peer will be recreated in the next restart,
 		// then it will be gc again. But if some overlap region is created
 		// before restarting, the gc action will delete the overlap region's
@@ -382,6 +632,7 @@ func (d *peerMsgHandler) destroyPeer() {
 	}
 	d.ctx.router.close(regionID)
 	d.stopped = true
+	d.stopAwaitingSnapshot()
 	if isInitialized && meta.regionRanges.Delete(&regionItem{region: d.Region()}) == nil {
 		panic(d.Tag + " meta corruption detected")
 	}
@@ -405,14 +656,25 @@ func (d *peerMsgHandler) findSiblingRegion() (result *metapb.Region) {
 
 func (d *peerMsgHandler) onRaftGCLogTick() {
 	d.ticker.schedule(PeerTickRaftLogGC)
-	if !d.IsLeader() {
+	// the entries after the min index of a PrepareMerge are carried by the CommitMerge
+	if !d.IsLeader() || d.pendingMerge != nil {
 		return
 	}
 
 	appliedIdx := d.peerStorage.AppliedIndex()
 	firstIdx, _ := d.peerStorage.FirstIndex()
+	overQuota := false
+	if quota := d.ctx.cfg.RaftLogQuota; quota > 0 {
+		size, err := meta.GetRaftLogSize(d.ctx.engine.Raft, d.regionId, firstIdx)
+		if err != nil {
+			log.Errorf("%s failed to get the raft log size: %v", d.Tag, err)
+		} else {
+			d.raftLogSize = size
+		}
+		overQuota = d.raftLogSize >= quota
+	}
 	var compactIdx uint64
-	if appliedIdx > firstIdx && appliedIdx-firstIdx >= d.ctx.cfg.RaftLogGcCountLimit {
+	if appliedIdx > firstIdx && (appliedIdx-firstIdx >= d.ctx.cfg.RaftLogGcCountLimit || overQuota) {
 		compactIdx = appliedIdx
 	} else {
 		return
@@ -437,6 +699,41 @@ func (d *peerMsgHandler) onRaftGCLogTick() {
 	d.proposeRaftCommand(request, nil)
 }
 
+// onCompactLog proposes a CompactLog of the raft log applied so far, regardless of RaftLogGcCountLimit and
+// RaftLogQuota. The callback is called once it's applied, or at once if there is nothing to compact.
+func (d *peerMsgHandler) onCompactLog(cb *message.Callback) {
+	if !d.IsLeader() {
+		cb.Done(ErrResp(&util.ErrNotLeader{RegionId: d.regionId, Leader: d.getPeerFromCache(d.LeaderId())}))
+		return
+	}
+	appliedIdx := d.peerStorage.AppliedIndex()
+	firstIdx, _ := d.peerStorage.FirstIndex()
+	if appliedIdx <= firstIdx {
+		cb.Done(newCmdResp())
+		return
+	}
+	compactIdx := appliedIdx - 1
+	term, err := d.RaftGroup.Raft.RaftLog.Term(compactIdx)
+	if err != nil {
+		cb.Done(ErrResp(err))
+		return
+	}
+	d.proposeRaftCommand(newCompactLogRequest(d.regionId, d.Meta, compactIdx, term), cb)
+}
+
+// onSplitCheck schedules a split check of the region, regardless of the size changed since the last one.
+func (d *peerMsgHandler) onSplitCheck(cb *message.Callback) {
+	if !d.IsLeader() {
+		cb.Done(ErrResp(&util.ErrNotLeader{RegionId: d.regionId, Leader: d.getPeerFromCache(d.LeaderId())}))
+		return
+	}
+	d.ctx.splitCheckTaskSender <- &runner.SplitCheckTask{
+		Region: d.Region(),
+	}
+	d.SizeDiffHint = 0
+	cb.Done(newCmdResp())
+}
+
 func (d *peerMsgHandler) onSplitRegionCheckTick() {
 	d.ticker.schedule(PeerTickSplitRegionCheck)
 	// To avoid frequent scan, we only add new scan tasks if all previous tasks
@@ -448,7 +745,7 @@ func (d *peerMsgHandler) onSplitRegionCheckTick() {
 	if !d.IsLeader() {
 		return
 	}
-	if d.ApproximateSize != nil && d.SizeDiffHint < d.ctx.cfg.RegionSplitSize/8 {
+	if d.ApproximateSize != nil && d.SizeDiffHint < d.ctx.cfg.SplitCheckDiff() {
 		return
 	}
 	d.ctx.splitCheckTaskSender <- &runner.SplitCheckTask{
@@ -457,6 +754,40 @@ func (d *peerMsgHandler) onSplitRegionCheckTick() {
 	d.SizeDiffHint = 0
 }
 
+func (d *peerMsgHandler) onRollbackCleanupTick() {
+	d.ticker.schedule(PeerTickRollbackCleanup)
+	// Clean up a region at a time, a region skipped is cleaned up on its next tick.
+	if len(d.ctx.rollbackCleanupTaskSender) > 0 {
+		return
+	}
+	if !d.IsLeader() {
+		return
+	}
+	safeTime := time.Now().Add(-d.ctx.cfg.RollbackRetention)
+	d.ctx.rollbackCleanupTaskSender <- &runner.RollbackCleanupTask{
+		Region: d.Region(),
+		Peer:   d.Meta,
+		SafeTs: mvcc.ComposeTs(uint64(safeTime.UnixNano() / int64(time.Millisecond))),
+	}
+}
+
+func (d *peerMsgHandler) onRawTombstoneGCTick() {
+	d.ticker.schedule(PeerTickRawTombstoneGC)
+	// Clean up a region at a time, a region skipped is cleaned up on its next tick.
+	if len(d.ctx.rawTombstoneGCTaskSender) > 0 {
+		return
+	}
+	if !d.IsLeader() {
+		return
+	}
+	// a tombstone expires by its own expire time, which the window was added to when it was written
+	d.ctx.rawTombstoneGCTaskSender <- &runner.RawTombstoneGCTask{
+		Region:   d.Region(),
+		Peer:     d.Meta,
+		SafeTime: uint64(time.Now().UnixNano() / int64(time.Millisecond)),
+	}
+}
+
 func (d *peerMsgHandler) onPrepareSplitRegion(regionEpoch *metapb.RegionEpoch, splitKey []byte, cb *message.Callback) {
 	if err := d.validateSplitRegion(regionEpoch, splitKey); err != nil {
 		cb.Done(ErrResp(err))
@@ -506,15 +837,92 @@ func (d *peerMsgHandler) validateSplitRegion(epoch *metapb.RegionEpoch, splitKey
 
 func (d *peerMsgHandler) onApproximateRegionSize(size uint64) {
 	d.ApproximateSize = &size
+	d.peerStorage.approximateSize = size
+}
+
+func (d *peerMsgHandler) onApproximateRegionKeys(keys uint64) {
+	d.ApproximateKeys = &keys
 }
 
 func (d *peerMsgHandler) onSchedulerHeartbeatTick() {
 	d.ticker.schedule(PeerTickSchedulerHeartbeat)
 
 	if !d.IsLeader() {
+		d.stall.Reset()
+		return
+	}
+	if d.maybeTransferSlowLeader() {
 		return
 	}
 	d.HeartbeatScheduler(d.ctx.schedulerTaskSender)
+	if d.ctx.cfg.DelegateSnapshot {
+		d.MaybeDelegateSnapshots(d.ctx.trans)
+	}
+}
+
+// maybeTransferSlowLeader transfers the leadership to a caught up follower if the writes of the leader have been
+// slow for a while, e.g. for a degraded disk, so that the writes to the region don't wait for it.
+func (d *peerMsgHandler) maybeTransferSlowLeader() bool {
+	if !d.stall.Stalled(time.Now()) {
+		return false
+	}
+	applied := d.peerStorage.AppliedIndex()
+	var target, targetMatch uint64
+	for id, progress := range d.RaftGroup.GetProgress() {
+		if id != d.PeerId() && !progress.IsLearner && !progress.IsWitness && progress.Match >= applied && progress.Match > targetMatch {
+			target, targetMatch = id, progress.Match
+		}
+	}
+	if target == 0 {
+		log.Warnf("%s writes are slow but no follower is caught up to take the leadership", d.Tag)
+		return false
+	}
+	log.Warnf("%s writes are slow, transfer leader to peer %d", d.Tag, target)
+	d.RaftGroup.TransferLeader(target)
+	d.stall.Reset()
+	atomic.AddUint32(&d.ctx.slowLeaderTransfers, 1)
+	return true
+}
+
+// onSnapshotDelegation generates a snapshot and sends it to the target peer in
+// the name of the leader which delegated it.
+func (d *peerMsgHandler) onSnapshotDelegation(msg *rspb.RaftMessage) {
+	delegation := msg.SnapshotDelegation
+	if d.IsLeader() || !d.isInitialized() || delegation.Term < d.Term() {
+		log.Infof("%s ignore stale snapshot delegation from %d", d.Tag, msg.FromPeer.Id)
+		return
+	}
+	leader, target := msg.FromPeer, delegation.Target
+	regionEpoch := msg.RegionEpoch
+	ch := make(chan *eraftpb.Snapshot, 1)
+	d.ctx.regionTaskSender <- &runner.RegionTaskGen{
+		RegionId: d.regionId,
+		Notifier: ch,
+		Size:     d.peerStorage.approximateSize,
+	}
+	trans, tag := d.ctx.trans, d.Tag
+	go func() {
+		s := <-ch
+		if s == nil {
+			return
+		}
+		err := trans.Send(&rspb.RaftMessage{
+			RegionId:    msg.RegionId,
+			FromPeer:    leader,
+			ToPeer:      target,
+			RegionEpoch: regionEpoch,
+			Message: &eraftpb.Message{
+				MsgType:  eraftpb.MessageType_MsgSnapshot,
+				From:     leader.Id,
+				To:       target.Id,
+				Term:     delegation.Term,
+				Snapshot: s,
+			},
+		})
+		if err != nil {
+			log.Warnf("%s failed to send delegated snapshot to %d: %v", tag, target.Id, err)
+		}
+	}()
 }
 
 func (d *peerMsgHandler) onGCSnap(snaps []snap.SnapKeyWithSending) {
@@ -531,12 +939,6 @@ func (d *peerMsgHandler) onGCSnap(snaps []snap.SnapKeyWithSending) {
 			if key.Term < compactedTerm || key.Index < compactedIdx {
 				log.Infof("%s snap file %s has been compacted, delete", d.Tag, key)
 				d.ctx.snapMgr.DeleteSnapshot(key, snap, false)
-			} else if fi, err1 := snap.Meta(); err1 == nil {
-				modTime := fi.ModTime()
-				if time.Since(modTime) > 4*time.Hour {
-					log.Infof("%s snap file %s has been expired, delete", d.Tag, key)
-					d.ctx.snapMgr.DeleteSnapshot(key, snap, false)
-				}
 			}
 		} else if key.Term <= compactedTerm &&
 			(key.Index < compactedIdx || key.Index == compactedIdx) {
@@ -551,23 +953,23 @@ func (d *peerMsgHandler) onGCSnap(snaps []snap.SnapKeyWithSending) {
 	}
 }
 
-func newAdminRequest(regionID uint64, peer *metapb.Peer) *raft_cmdpb.RaftCmdRequest {
-	return &raft_cmdpb.RaftCmdRequest{
+func newAdminRequest(regionID uint64, peer *metapb.Peer, req *raft_cmdpb.AdminRequest) *raft_cmdpb.RaftCmdRequest {
+	cmd := &util.AdminCmd{
 		Header: &raft_cmdpb.RaftRequestHeader{
 			RegionId: regionID,
 			Peer:     peer,
 		},
+		Request: req,
 	}
+	return cmd.RaftCmdRequest()
 }
 
 func newCompactLogRequest(regionID uint64, peer *metapb.Peer, compactIndex, compactTerm uint64) *raft_cmdpb.RaftCmdRequest {
-	req := newAdminRequest(regionID, peer)
-	req.AdminRequest = &raft_cmdpb.AdminRequest{
+	return newAdminRequest(regionID, peer, &raft_cmdpb.AdminRequest{
 		CmdType: raft_cmdpb.AdminCmdType_CompactLog,
 		CompactLog: &raft_cmdpb.CompactLogRequest{
 			CompactIndex: compactIndex,
 			CompactTerm:  compactTerm,
 		},
-	}
-	return req
+	})
 }
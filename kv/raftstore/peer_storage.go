@@ -44,6 +44,19 @@ type PeerStorage struct {
 	regionSched chan<- worker.Task
 	// generate snapshot tried count
 	snapTriedCnt int
+	// all the followers needing a snapshot are sent one by another follower
+	// on behalf of this peer until the time, see peer.snapDelegations.
+	snapDelegatedUntil time.Time
+	// all the followers needing a snapshot are backed off until the time, see snapBackoff.
+	snapBackoffUntil time.Time
+	// the latest committed entries, nil if disabled
+	replay *replayBuffer
+	// the latest appended entries, nil if disabled
+	entryCache *entryCache
+	// the approximate size of the region, 0 if unknown, which orders the generation of its snapshots
+	approximateSize uint64
+	// the write batch of the raft engine of a ready, reset after it's written so its buffers are reused
+	raftWB engine_util.WriteBatch
 	// Engine include two badger instance: Raft and Kv
 	Engines *engine_util.Engines
 	// Tag used for logging
@@ -90,6 +103,12 @@ func (ps *PeerStorage) Entries(low, high uint64) ([]eraftpb.Entry, error) {
 	if err := ps.checkRange(low, high); err != nil || low == high {
 		return nil, err
 	}
+	if ents, ok := ps.entryCache.slice(low, high); ok {
+		return ents, nil
+	}
+	if ents, ok := ps.replay.slice(low, high); ok {
+		return ents, nil
+	}
 	buf := make([]eraftpb.Entry, 0, high-low)
 	nextIndex := low
 	txn := ps.Engines.Raft.NewTransaction(false)
@@ -136,6 +155,9 @@ func (ps *PeerStorage) Term(idx uint64) (uint64, error) {
 	if ps.truncatedTerm() == ps.raftState.LastTerm || idx == ps.raftState.LastIndex {
 		return ps.raftState.LastTerm, nil
 	}
+	if term, ok := ps.entryCache.term(idx); ok {
+		return term, nil
+	}
 	var entry eraftpb.Entry
 	if err := engine_util.GetMeta(ps.Engines.Raft, meta.RaftLogKey(ps.region.Id, idx), &entry); err != nil {
 		return 0, err
@@ -153,6 +175,10 @@ func (ps *PeerStorage) FirstIndex() (uint64, error) {
 
 func (ps *PeerStorage) Snapshot() (eraftpb.Snapshot, error) {
 	var snapshot eraftpb.Snapshot
+	if now := time.Now(); ps.snapState.StateType != snap.SnapState_Generating &&
+		(now.Before(ps.snapDelegatedUntil) || now.Before(ps.snapBackoffUntil)) {
+		return snapshot, raft.ErrSnapshotTemporarilyUnavailable
+	}
 	if ps.snapState.StateType == snap.SnapState_Generating {
 		select {
 		case s := <-ps.snapState.Receiver:
@@ -190,6 +216,7 @@ func (ps *PeerStorage) Snapshot() (eraftpb.Snapshot, error) {
 	ps.regionSched <- &runner.RegionTaskGen{
 		RegionId: ps.region.GetId(),
 		Notifier: ch,
+		Size:     ps.approximateSize,
 	}
 	return snapshot, raft.ErrSnapshotTemporarilyUnavailable
 }
@@ -251,6 +278,8 @@ func (ps *PeerStorage) validateSnap(snap *eraftpb.Snapshot) bool {
 }
 
 func (ps *PeerStorage) clearMeta(kvWB, raftWB *engine_util.WriteBatch) error {
+	ps.replay.clear()
+	ps.entryCache.clear()
 	return ClearMeta(ps.Engines, kvWB, raftWB, ps.region.Id, ps.raftState.LastIndex)
 }
 
@@ -271,6 +300,7 @@ func ClearMeta(engines *engine_util.Engines, kvWB, raftWB *engine_util.WriteBatc
 	start := time.Now()
 	kvWB.DeleteMeta(meta.RegionStateKey(regionID))
 	kvWB.DeleteMeta(meta.ApplyStateKey(regionID))
+	kvWB.DeleteMeta(meta.LockCheckpointKey(regionID))
 
 	firstIndex := lastIndex + 1
 	beginLogKey := meta.RaftLogKey(regionID, 0)
@@ -295,6 +325,7 @@ func ClearMeta(engines *engine_util.Engines, kvWB, raftWB *engine_util.WriteBatc
 		raftWB.DeleteMeta(meta.RaftLogKey(regionID, i))
 	}
 	raftWB.DeleteMeta(meta.RaftStateKey(regionID))
+	raftWB.DeleteMeta(meta.HardStateKey(regionID))
 	log.Infof(
 		"[region %d] clear peer 1 meta key 1 apply key 1 raft key and %d raft logs, takes %v",
 		regionID,
@@ -307,6 +338,7 @@ func ClearMeta(engines *engine_util.Engines, kvWB, raftWB *engine_util.WriteBatc
 // Append the given entries to the raft log and update ps.raftState also delete log entries that will
 // never be committed
 func (ps *PeerStorage) Append(entries []eraftpb.Entry, raftWB *engine_util.WriteBatch) error {
+	// NOTE: add the entries to ps.entryCache with append, which replaces the cached entries they conflict with.
 	// Your Code Here (2B).
 	return nil
 }
@@ -322,14 +354,25 @@ func (ps *PeerStorage) ApplySnapshot(snapshot *eraftpb.Snapshot, kvWB *engine_ut
 	// Hint: things need to do here including: update peer storage state like raftState and applyState, etc,
 	// and send RegionTaskApply task to region worker through ps.regionSched, also remember call ps.clearMeta
 	// and ps.clearExtraData to delete stale data
+	// NOTE: the replay buffer and the entry cache are cleared by ps.clearMeta, the entries after the snapshot are
+	// cached again once they are appended and buffered once they are committed.
 	// Your Code Here (2C).
 	return nil, nil
 }
 
 // Save memory states to disk.
 // Do not modify ready in this function, this is a requirement to advance the ready object properly later.
+// The messages of the ready must be sent only after this returns, and `RawNode.ReportPersisted` should be called
+// then, see `ready.MustSync`. After a snapshot is applied, report the result to the leader by
+// `peer.ReportSnapshotStatus`, with the time from receiving the snapshot in the ready to the region worker
+// notifying it's applied.
 func (ps *PeerStorage) SaveReadyState(ready *raft.Ready) (*ApplySnapResult, error) {
 	// Hint: you may call `Append()` and `ApplySnapshot()` in this function
+	// NOTE: write a changed hard state to its own record with meta.WriteHardState, not in ps.raftState, which is only
+	// rewritten when entries are appended or a snapshot is applied. A ready with only a hard state, e.g. a vote, is
+	// then a single small write of the raft engine.
+	// NOTE: write the raft engine with &ps.raftWB and reset it after it's written, so the entries of the following
+	// readies are marshaled into the same buffers.
 	// Your Code Here (2B/2C).
 	return nil, nil
 }
@@ -0,0 +1,34 @@
+package raftstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitParallelAppends(t *testing.T) {
+	msgs := []eraftpb.Message{
+		{MsgType: eraftpb.MessageType_MsgAppend, From: 1, To: 2, Index: 5},
+		{MsgType: eraftpb.MessageType_MsgAppendResponse, From: 1, To: 1, Index: 6},
+		{MsgType: eraftpb.MessageType_MsgRequestVoteResponse, From: 1, To: 3},
+		{MsgType: eraftpb.MessageType_MsgAppend, From: 1, To: 3, Index: 5},
+		{MsgType: eraftpb.MessageType_MsgAppendResponse, From: 1, To: 2, Index: 6},
+	}
+	appends, rest := splitParallelAppends(msgs)
+	assert.Equal(t, []eraftpb.Message{msgs[0], msgs[3]}, appends)
+	// the responses wait for the ready to be persisted
+	assert.Equal(t, []eraftpb.Message{msgs[1], msgs[2], msgs[4]}, rest)
+}
+
+func TestSnapDelegatedUntil(t *testing.T) {
+	now := time.Unix(100, 0)
+	delegations := map[uint64]time.Time{2: now.Add(time.Second), 3: now.Add(time.Minute), 4: now}
+	assert.True(t, snapDelegatedUntil(delegations, nil, now).IsZero())
+	assert.Equal(t, now.Add(time.Second), snapDelegatedUntil(delegations, []uint64{2, 3}, now))
+	assert.Equal(t, now.Add(time.Minute), snapDelegatedUntil(delegations, []uint64{3}, now))
+	// a follower which isn't delegated, or whose delegation timed out, needs the leader's snapshot
+	assert.True(t, snapDelegatedUntil(delegations, []uint64{3, 5}, now).IsZero())
+	assert.True(t, snapDelegatedUntil(delegations, []uint64{3, 4}, now).IsZero())
+}
@@ -4,6 +4,8 @@ import (
 	"sync"
 
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/message"
+	"github.com/pingcap-incubator/tinykv/kv/util/failpoint"
+	rspb "github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
 )
 
 // raftWorker is responsible for run raft commands and apply raft logs.
@@ -15,15 +17,19 @@ type raftWorker struct {
 	// * raft inner messages from other peers sent by network
 	raftCh chan message.Msg
 	ctx    *GlobalContext
+	stats  *readyStats
 
 	closeCh <-chan struct{}
 }
 
-func newRaftWorker(ctx *GlobalContext, pm *router) *raftWorker {
+func newRaftWorker(ctx *GlobalContext, pm *router, id int) *raftWorker {
+	stats := newReadyStats(id, ctx.cfg.RaftStoreProfileLabels)
+	ctx.readyStats.workers = append(ctx.readyStats.workers, stats)
 	return &raftWorker{
 		raftCh: pm.peerSender,
 		ctx:    ctx,
 		pr:     pm,
+		stats:  stats,
 	}
 }
 
@@ -46,19 +52,37 @@ func (rw *raftWorker) run(closeCh <-chan struct{}, wg *sync.WaitGroup) {
 			msgs = append(msgs, <-rw.raftCh)
 		}
 		peerStateMap := make(map[uint64]*peerState)
+		timer := rw.stats.start(stageHandleMsgs)
 		for _, msg := range msgs {
 			peerState := rw.getPeerState(peerStateMap, msg.RegionID)
 			if peerState == nil {
 				continue
 			}
-			newPeerMsgHandler(peerState.peer, rw.ctx).HandleMsg(msg)
+			if msg.Type == message.MsgTypeRaftMessage {
+				peerState.takeRaftMessage(msg.Data.(*rspb.RaftMessage))
+			}
+			rw.newPeerMsgHandler(peerState.peer).HandleMsg(msg)
+		}
+		timer.stop()
+		if err := failpoint.Inject("raftstore/before-handle-ready"); err != nil {
+			// the readies are handled in a later round
+			continue
 		}
 		for _, peerState := range peerStateMap {
-			newPeerMsgHandler(peerState.peer, rw.ctx).HandleRaftReady()
+			rw.newPeerMsgHandler(peerState.peer).HandleRaftReady()
+			rw.stats.observeEntryCache(peerState.peer.peerStorage.entryCache.takeStats())
 		}
+		rw.ctx.logSyncer.sync()
+		rw.stats.finishRound()
 	}
 }
 
+func (rw *raftWorker) newPeerMsgHandler(peer *peer) *peerMsgHandler {
+	d := newPeerMsgHandler(peer, rw.ctx)
+	d.stats = rw.stats
+	return d
+}
+
 func (rw *raftWorker) getPeerState(peersMap map[uint64]*peerState, regionID uint64) *peerState {
 	peer, ok := peersMap[regionID]
 	if !ok {
@@ -8,15 +8,23 @@ import (
 	"github.com/Connor1996/badger"
 	"github.com/Connor1996/badger/y"
 	"github.com/pingcap-incubator/tinykv/kv/config"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/cdc"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/keycheck"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/keyfilter"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/lockindex"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/locktable"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/message"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/meta"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/runner"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/scheduler_client"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/snap"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
+	"github.com/pingcap-incubator/tinykv/kv/transaction/latches"
 	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
 	"github.com/pingcap-incubator/tinykv/kv/util/worker"
 	"github.com/pingcap-incubator/tinykv/log"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
 	rspb "github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
 	"github.com/pingcap-incubator/tinykv/scheduler/pkg/btree"
 	"github.com/pingcap/errors"
@@ -44,16 +52,20 @@ type storeMeta struct {
 	/// `MsgRequestVote` messages from newly split Regions shouldn't be dropped if there is no
 	/// such Region in this store now. So the messages are recorded temporarily and will be handled later.
 	pendingVotes []*rspb.RaftMessage
+	/// observers of split, merge and conf change
+	observers *RegionObserverRegistry
 }
 
-func newStoreMeta() *storeMeta {
+func newStoreMeta(observers *RegionObserverRegistry) *storeMeta {
 	return &storeMeta{
 		regionRanges: btree.New(2),
 		regions:      map[uint64]*metapb.Region{},
+		observers:    observers,
 	}
 }
 
 func (m *storeMeta) setRegion(region *metapb.Region, peer *peer) {
+	m.observers.notifyRegionUpdate(m.regions[region.Id], region)
 	m.regions[region.Id] = region
 	peer.SetRegion(region)
 }
@@ -96,8 +108,42 @@ type GlobalContext struct {
 	regionTaskSender     chan<- worker.Task
 	raftLogGCTaskSender  chan<- worker.Task
 	splitCheckTaskSender chan<- worker.Task
-	schedulerClient      scheduler_client.Client
-	tickDriverSender     chan uint64
+	// removes the stale rollback records of a region
+	rollbackCleanupTaskSender chan<- worker.Task
+	// removes the expired raw tombstones of a region
+	rawTombstoneGCTaskSender chan<- worker.Task
+	// deletes the stale snapshot files of the store
+	snapGCTaskSender chan<- worker.Task
+	schedulerClient           scheduler_client.Client
+	tickDriverSender          chan uint64
+	// offset of the scheduler clock, lease reads are only safe within the max clock skew
+	clockSkew *util.ClockSkew
+	// filters of the keys in the write CF of the regions, nil if disabled
+	keyFilters *keyfilter.KeyFilters
+	// the lock CF kept in memory, nil if it's in the kv engine
+	lockTable *locktable.LockTable
+	// index of the locks of the regions, nil if disabled
+	lockIndex *lockindex.LockIndex
+	// latches of the raw keys, shared by the raw writes and the raw tombstone GC, nil if the soft delete is disabled
+	rawLatches *latches.Latches
+	// checker of the keys written against the TinySQL key layout, nil if disabled
+	keyChecker *keycheck.KeyChecker
+	// capturer of the changes of the regions with CDC enabled, nil if disabled
+	changeCapturer *cdc.Capturer
+	// applies the committed entries out of the raft workers, nil if they are applied by the raft workers
+	applyWorker *applyWorker
+	// syncs the writes to the raft engine, nil if each write is synced
+	logSyncer *logSyncer
+	// appliers of the custom commands
+	applyDelegates *ApplyDelegateRegistry
+	// observers of the applied requests
+	applyObservers *ApplyObserverRegistry
+	// leaders transferred away for slow writes since the last store heartbeat, accessed atomically
+	slowLeaderTransfers uint32
+	// explicitly created peers waiting for their first snapshot, accessed atomically
+	bootstrappingPeers int32
+	// time spent in each stage of the loops of the raft workers
+	readyStats *ReadyStats
 }
 
 type Transport interface {
@@ -157,6 +203,9 @@ func (bs *Raftstore) loadPeers() ([]*peer, error) {
 			if err != nil {
 				return err
 			}
+			if localState.State == rspb.PeerState_Merging {
+				peer.pendingMerge = localState.MergeState
+			}
 			ctx.storeMeta.regionRanges.ReplaceOrInsert(&regionItem{region: region})
 			ctx.storeMeta.regions[regionID] = region
 			// No need to check duplicated here, because we use region id as the key
@@ -193,11 +242,14 @@ func (bs *Raftstore) clearStaleMeta(kvWB, raftWB *engine_util.WriteBatch, origin
 }
 
 type workers struct {
-	raftLogGCWorker  *worker.Worker
-	schedulerWorker  *worker.Worker
-	splitCheckWorker *worker.Worker
-	regionWorker     *worker.Worker
-	wg               *sync.WaitGroup
+	raftLogGCWorker       *worker.Worker
+	schedulerWorker       *worker.Worker
+	splitCheckWorker      *worker.Worker
+	rollbackCleanupWorker *worker.Worker
+	rawTombstoneGCWorker  *worker.Worker
+	regionWorker          *worker.Worker
+	snapGCWorker          *worker.Worker
+	wg                    *sync.WaitGroup
 }
 
 type Raftstore struct {
@@ -208,6 +260,113 @@ type Raftstore struct {
 	tickDriver *tickDriver
 	closeCh    chan struct{}
 	wg         *sync.WaitGroup
+	observers  *RegionObserverRegistry
+	delegates  *ApplyDelegateRegistry
+	// the apply observers registered by the embedder, run after the built-in ones
+	applyObservers *ApplyObserverRegistry
+}
+
+// RegionObservers returns the registry of the region change observers of the store.
+func (bs *Raftstore) RegionObservers() *RegionObserverRegistry {
+	return bs.observers
+}
+
+// ApplyDelegates returns the registry of the apply delegates of the custom commands of the store.
+func (bs *Raftstore) ApplyDelegates() *ApplyDelegateRegistry {
+	return bs.delegates
+}
+
+// ApplyObservers returns the registry of the observers of the requests applied on the store.
+func (bs *Raftstore) ApplyObservers() *ApplyObserverRegistry {
+	return bs.applyObservers
+}
+
+// KeyFilters returns the filters of the keys in the regions of the store, nil if they are disabled or the store is
+// not started.
+func (bs *Raftstore) KeyFilters() *keyfilter.KeyFilters {
+	if bs.ctx == nil {
+		return nil
+	}
+	return bs.ctx.keyFilters
+}
+
+// LockIndex returns the index of the locks in the regions of the store, nil if it's disabled or the store is not
+// started.
+func (bs *Raftstore) LockIndex() *lockindex.LockIndex {
+	if bs.ctx == nil {
+		return nil
+	}
+	return bs.ctx.lockIndex
+}
+
+// RawLatches returns the latches of the raw keys, which the raw writes must hold so the raw tombstone GC doesn't
+// remove a tombstone they write, nil if the raw soft delete is disabled or the store is not started.
+func (bs *Raftstore) RawLatches() *latches.Latches {
+	if bs.ctx == nil {
+		return nil
+	}
+	return bs.ctx.rawLatches
+}
+
+// KeyChecker returns the checker of the keys written to the store, nil if it's disabled or the store is not started.
+func (bs *Raftstore) KeyChecker() *keycheck.KeyChecker {
+	if bs.ctx == nil {
+		return nil
+	}
+	return bs.ctx.keyChecker
+}
+
+// ChangeCapturer returns the capturer of the changes of the regions with CDC enabled, nil if it's disabled or the
+// store is not started.
+func (bs *Raftstore) ChangeCapturer() *cdc.Capturer {
+	if bs.ctx == nil {
+		return nil
+	}
+	return bs.ctx.changeCapturer
+}
+
+// ReadyStats returns the time the raft workers spent in each stage of their loops, nil if the store is not started.
+func (bs *Raftstore) ReadyStats() *ReadyStats {
+	if bs.ctx == nil {
+		return nil
+	}
+	return bs.ctx.readyStats
+}
+
+// changeCaptureObserver stops the change capture of a region whose data is changed by a split, merge or destroy.
+type changeCaptureObserver struct {
+	capturer *cdc.Capturer
+}
+
+func (o changeCaptureObserver) OnRegionChanged(event *RegionChangeEvent) {
+	switch event.Type {
+	case RegionChangeSplit, RegionChangeMerge, RegionChangeDestroy:
+		o.capturer.Stop(event.Region.GetId())
+	}
+}
+
+// lockIndexObserver drops the lock index of a region whose data is changed by a split, merge or destroy.
+type lockIndexObserver struct {
+	index *lockindex.LockIndex
+}
+
+func (o lockIndexObserver) OnRegionChanged(event *RegionChangeEvent) {
+	switch event.Type {
+	case RegionChangeSplit, RegionChangeMerge, RegionChangeDestroy:
+		o.index.Invalidate(event.Region.GetId())
+	}
+}
+
+// keyFilterObserver drops the key filter of a region whose data is changed by a split, merge or destroy.
+type keyFilterObserver struct {
+	filters *keyfilter.KeyFilters
+}
+
+func (o keyFilterObserver) OnRegionChanged(event *RegionChangeEvent) {
+	switch event.Type {
+	case RegionChangeSplit, RegionChangeMerge, RegionChangeDestroy:
+		o.filters.Invalidate(event.Region.GetId())
+	}
 }
 
 func (bs *Raftstore) start(
@@ -228,31 +387,81 @@ func (bs *Raftstore) start(
 	}
 	wg := new(sync.WaitGroup)
 	bs.workers = &workers{
-		splitCheckWorker: worker.NewWorker("split-check", wg),
-		regionWorker:     worker.NewWorker("snapshot-worker", wg),
-		raftLogGCWorker:  worker.NewWorker("raft-gc-worker", wg),
-		schedulerWorker:  worker.NewWorker("scheduler-worker", wg),
-		wg:               wg,
+		splitCheckWorker:      worker.NewWorker("split-check", wg),
+		rollbackCleanupWorker: worker.NewWorker("rollback-cleanup", wg),
+		rawTombstoneGCWorker:  worker.NewWorker("raw-tombstone-gc", wg),
+		regionWorker:          worker.NewWorker("snapshot-worker", wg),
+		raftLogGCWorker:       worker.NewWorker("raft-gc-worker", wg),
+		schedulerWorker:       worker.NewWorker("scheduler-worker", wg),
+		snapGCWorker:          worker.NewWorker("snap-gc", wg),
+		wg:                    wg,
 	}
 	bs.ctx = &GlobalContext{
-		cfg:                  cfg,
-		engine:               engines,
-		store:                meta,
-		storeMeta:            newStoreMeta(),
-		snapMgr:              snapMgr,
-		router:               bs.router,
-		trans:                trans,
-		schedulerTaskSender:  bs.workers.schedulerWorker.Sender(),
-		regionTaskSender:     bs.workers.regionWorker.Sender(),
-		splitCheckTaskSender: bs.workers.splitCheckWorker.Sender(),
-		raftLogGCTaskSender:  bs.workers.raftLogGCWorker.Sender(),
-		schedulerClient:      schedulerClient,
-		tickDriverSender:     bs.tickDriver.newRegionCh,
+		cfg:                       cfg,
+		engine:                    engines,
+		store:                     meta,
+		storeMeta:                 newStoreMeta(bs.observers),
+		snapMgr:                   snapMgr,
+		router:                    bs.router,
+		trans:                     trans,
+		schedulerTaskSender:       bs.workers.schedulerWorker.Sender(),
+		regionTaskSender:          bs.workers.regionWorker.Sender(),
+		splitCheckTaskSender:      bs.workers.splitCheckWorker.Sender(),
+		rollbackCleanupTaskSender: bs.workers.rollbackCleanupWorker.Sender(),
+		rawTombstoneGCTaskSender:  bs.workers.rawTombstoneGCWorker.Sender(),
+		raftLogGCTaskSender:       bs.workers.raftLogGCWorker.Sender(),
+		snapGCTaskSender:          bs.workers.snapGCWorker.Sender(),
+		schedulerClient:           schedulerClient,
+		tickDriverSender:          bs.tickDriver.newRegionCh,
+		clockSkew:                 util.NewClockSkew(cfg.MaxClockSkew),
+		applyDelegates:            bs.delegates,
+		applyObservers:            NewApplyObserverRegistry(),
+		readyStats:                new(ReadyStats),
+	}
+	bs.ctx.applyObservers.Register(keyRangeObserver{},
+		raft_cmdpb.CmdType_Get, raft_cmdpb.CmdType_Put, raft_cmdpb.CmdType_Delete)
+	if cfg.KeyFilterBitsPerKey > 0 {
+		bs.ctx.keyFilters = keyfilter.NewKeyFilters(engines.Kv, cfg.KeyFilterBitsPerKey)
+		bs.observers.Register(keyFilterObserver{filters: bs.ctx.keyFilters})
+		bs.ctx.applyObservers.Register(keyFilterApplyObserver{filters: bs.ctx.keyFilters}, raft_cmdpb.CmdType_Put)
+	}
+	if cfg.LockIndex {
+		bs.ctx.lockIndex = lockindex.NewLockIndex(engines.Kv)
+		bs.observers.Register(lockIndexObserver{index: bs.ctx.lockIndex})
+		bs.ctx.applyObservers.Register(lockIndexApplyObserver{index: bs.ctx.lockIndex},
+			raft_cmdpb.CmdType_Put, raft_cmdpb.CmdType_Delete)
+	}
+	if cfg.RawSoftDeleteWindow > 0 {
+		bs.ctx.rawLatches = latches.NewLatches()
 	}
+	if cfg.KeyLayoutCheck {
+		bs.ctx.keyChecker = keycheck.NewKeyChecker(keyViolationsKept)
+		bs.ctx.applyObservers.Register(keyCheckApplyObserver{checker: bs.ctx.keyChecker}, raft_cmdpb.CmdType_Put)
+	}
+	if cfg.ChangeCaptureCacheSize > 0 {
+		bs.ctx.changeCapturer = cdc.NewCapturer(engines.Kv, cfg.ChangeCaptureCacheSize)
+		bs.observers.Register(changeCaptureObserver{capturer: bs.ctx.changeCapturer})
+		bs.ctx.applyObservers.Register(changeCaptureApplyObserver{capturer: bs.ctx.changeCapturer}, raft_cmdpb.CmdType_Put)
+	}
+	if cfg.RaftApplyWorkers > 0 {
+		bs.ctx.applyWorker = newApplyWorker(bs.router, cfg.RaftApplyWorkers, bs.closeCh)
+	}
+	bs.ctx.logSyncer = newLogSyncer(cfg, engines.RaftPath)
+	bs.ctx.applyObservers.chain(bs.applyObservers)
 	regionPeers, err := bs.loadPeers()
 	if err != nil {
 		return err
 	}
+	if cfg.MemoryLockCF {
+		bs.ctx.lockTable = locktable.NewLockTable(engines.Kv)
+		regions := make([]*metapb.Region, 0, len(regionPeers))
+		for _, peer := range regionPeers {
+			regions = append(regions, peer.Region())
+		}
+		if err := bs.ctx.lockTable.Recover(engines.Raft, regions); err != nil {
+			return err
+		}
+	}
 
 	for _, peer := range regionPeers {
 		bs.router.register(peer)
@@ -266,10 +475,14 @@ func (bs *Raftstore) startWorkers(peers []*peer) {
 	workers := bs.workers
 	router := bs.router
 	bs.wg.Add(2) // raftWorker, storeWorker
-	rw := newRaftWorker(ctx, router)
+	rw := newRaftWorker(ctx, router, 0)
 	go rw.run(bs.closeCh, bs.wg)
 	sw := newStoreWorker(ctx, bs.storeState)
 	go sw.run(bs.closeCh, bs.wg)
+	if ctx.applyWorker != nil {
+		ctx.applyWorker.run(bs.wg)
+	}
+	ctx.logSyncer.run(bs.closeCh, bs.wg)
 	router.sendStore(message.Msg{Type: message.MsgTypeStoreStart, Data: ctx.store})
 	for i := 0; i < len(peers); i++ {
 		regionID := peers[i].regionId
@@ -278,9 +491,12 @@ func (bs *Raftstore) startWorkers(peers []*peer) {
 	engines := ctx.engine
 	cfg := ctx.cfg
 	workers.splitCheckWorker.Start(runner.NewSplitCheckHandler(engines.Kv, NewRaftstoreRouter(router), cfg))
-	workers.regionWorker.Start(runner.NewRegionTaskHandler(engines, ctx.snapMgr))
+	workers.regionWorker.Start(runner.NewRegionTaskHandler(engines, ctx.snapMgr, cfg.SnapGenConcurrency, cfg.SnapApplyConcurrency, ctx.keyFilters, ctx.lockTable, ctx.lockIndex))
 	workers.raftLogGCWorker.Start(runner.NewRaftLogGCTaskHandler())
-	workers.schedulerWorker.Start(runner.NewSchedulerTaskHandler(ctx.store.Id, ctx.schedulerClient, NewRaftstoreRouter(router)))
+	workers.rollbackCleanupWorker.Start(runner.NewRollbackCleanupHandler(engines.Kv, NewRaftstoreRouter(router)))
+	workers.rawTombstoneGCWorker.Start(runner.NewRawTombstoneGCHandler(engines.Kv, NewRaftstoreRouter(router), ctx.rawLatches))
+	workers.schedulerWorker.Start(runner.NewSchedulerTaskHandler(ctx.store.Id, ctx.schedulerClient, NewRaftstoreRouter(router), ctx.clockSkew))
+	workers.snapGCWorker.Start(runner.NewSnapGCHandler(ctx.snapMgr, NewRaftstoreRouter(router), cfg.SnapTTL))
 	go bs.tickDriver.run()
 }
 
@@ -296,19 +512,26 @@ func (bs *Raftstore) shutDown() {
 	workers.splitCheckWorker.Stop()
 	workers.regionWorker.Stop()
 	workers.raftLogGCWorker.Stop()
+	workers.rollbackCleanupWorker.Stop()
+	workers.rawTombstoneGCWorker.Stop()
 	workers.schedulerWorker.Stop()
+	workers.snapGCWorker.Stop()
 	workers.wg.Wait()
 }
 
 func CreateRaftstore(cfg *config.Config) (*RaftstoreRouter, *Raftstore) {
 	storeSender, storeState := newStoreState(cfg)
-	router := newRouter(storeSender)
+	observers := NewRegionObserverRegistry()
+	router := newRouter(storeSender, observers)
 	raftstore := &Raftstore{
-		router:     router,
-		storeState: storeState,
-		tickDriver: newTickDriver(cfg.RaftBaseTickInterval, router, storeState.ticker),
-		closeCh:    make(chan struct{}),
-		wg:         new(sync.WaitGroup),
+		observers:      observers,
+		delegates:      NewApplyDelegateRegistry(),
+		applyObservers: NewApplyObserverRegistry(),
+		router:         router,
+		storeState:     storeState,
+		tickDriver:     newTickDriver(cfg.RaftBaseTickInterval, router, storeState.ticker),
+		closeCh:        make(chan struct{}),
+		wg:             new(sync.WaitGroup),
 	}
 	return NewRaftstoreRouter(router), raftstore
 }
@@ -0,0 +1,78 @@
+package raftstore
+
+import (
+	"encoding/binary"
+
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/message"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
+	"github.com/pingcap-incubator/tinykv/raft"
+)
+
+// pendingRead is a read-only command served with the raft read index, waiting for its read state and then for the
+// entries up to its read index to be applied.
+type pendingRead struct {
+	id    uint64
+	req   *raft_cmdpb.RaftCmdRequest
+	cb    *message.Callback
+	index uint64
+	// set once the read state of the request is returned
+	ready bool
+}
+
+// readIndexQueue keeps the read-only commands of a peer requesting the read index, in the order of the requests.
+// The context of a request is the id of its read.
+type readIndexQueue struct {
+	nextID uint64
+	reads  []*pendingRead
+}
+
+// propose requests the read index of a read-only command. It fails if the raft group drops the request, e.g. when
+// the leader is unknown.
+func (q *readIndexQueue) propose(rn *raft.RawNode, req *raft_cmdpb.RaftCmdRequest, cb *message.Callback) error {
+	q.nextID++
+	rctx := make([]byte, 8)
+	binary.BigEndian.PutUint64(rctx, q.nextID)
+	if err := rn.ReadIndex(rctx); err != nil {
+		return err
+	}
+	q.reads = append(q.reads, &pendingRead{id: q.nextID, req: req, cb: cb})
+	return nil
+}
+
+// advance records the read states of a ready. A read state answers its request and the earlier ones, which are
+// read at the same index then, since the read index never goes back.
+func (q *readIndexQueue) advance(states []raft.ReadState) {
+	for _, state := range states {
+		if len(state.RequestCtx) != 8 {
+			continue
+		}
+		id := binary.BigEndian.Uint64(state.RequestCtx)
+		for _, read := range q.reads {
+			if read.id > id {
+				break
+			}
+			if !read.ready {
+				read.ready, read.index = true, state.Index
+			}
+		}
+	}
+}
+
+// popApplied removes and returns the reads which can be served once the entries up to applied are applied.
+func (q *readIndexQueue) popApplied(applied uint64) []*pendingRead {
+	i := 0
+	for i < len(q.reads) && q.reads[i].ready && q.reads[i].index <= applied {
+		i++
+	}
+	reads := q.reads[:i:i]
+	q.reads = q.reads[i:]
+	return reads
+}
+
+// clear fails the pending reads with the error, e.g. when the leader changes and their read states may never come.
+func (q *readIndexQueue) clear(err error, term uint64) {
+	for _, read := range q.reads {
+		read.cb.Done(ErrRespWithTerm(err, term))
+	}
+	q.reads = nil
+}
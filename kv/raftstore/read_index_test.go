@@ -0,0 +1,47 @@
+package raftstore
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/message"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
+	"github.com/pingcap-incubator/tinykv/raft"
+	"github.com/stretchr/testify/assert"
+)
+
+func readState(id, index uint64) raft.ReadState {
+	rctx := make([]byte, 8)
+	binary.BigEndian.PutUint64(rctx, id)
+	return raft.ReadState{Index: index, RequestCtx: rctx}
+}
+
+func TestReadIndexQueue(t *testing.T) {
+	q := new(readIndexQueue)
+	for id := uint64(1); id <= 4; id++ {
+		q.reads = append(q.reads, &pendingRead{id: id})
+	}
+	q.nextID = 4
+
+	// the read state of a request answers the earlier ones too
+	q.advance([]raft.ReadState{readState(2, 10)})
+	assert.Empty(t, q.popApplied(9))
+	reads := q.popApplied(10)
+	assert.Len(t, reads, 2)
+	assert.Equal(t, uint64(1), reads[0].id)
+	assert.Equal(t, uint64(10), reads[1].index)
+
+	q.advance([]raft.ReadState{readState(4, 12), readState(3, 11)})
+	reads = q.popApplied(20)
+	assert.Len(t, reads, 2)
+	assert.Equal(t, uint64(12), reads[0].index)
+	assert.Empty(t, q.reads)
+
+	// the pending reads fail when the leader changes
+	cb := message.NewCallback()
+	q.reads = append(q.reads, &pendingRead{id: 5, cb: cb})
+	go q.clear(&util.ErrNotLeader{RegionId: 1}, 3)
+	resp := cb.WaitResp()
+	assert.NotNil(t, resp.Header.Error.NotLeader)
+	assert.Equal(t, uint64(3), resp.Header.CurrentTerm)
+}
@@ -0,0 +1,184 @@
+package raftstore
+
+import (
+	"context"
+	"runtime/pprof"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// readyStage is a stage of a round of the loop of a raft worker.
+type readyStage int
+
+const (
+	// handle the messages received in the round
+	stageHandleMsgs readyStage = iota
+	// collect the readies of the peers
+	stageCollectReady
+	// append the entries and the hard states to the raft engine
+	stageAppend
+	// send the raft messages
+	stageSend
+	// apply the committed entries
+	stageApply
+	// advance the raft groups
+	stageAdvance
+	numReadyStages
+)
+
+var readyStageNames = [numReadyStages]string{
+	"handle_msgs",
+	"collect_ready",
+	"append",
+	"send",
+	"apply",
+	"advance",
+}
+
+func (s readyStage) String() string {
+	return readyStageNames[s]
+}
+
+// ReadyStageStat is the time a raft worker spent in a stage of its loop.
+type ReadyStageStat struct {
+	Stage string
+	Count uint64
+	Total time.Duration
+	Max   time.Duration
+}
+
+// WorkerReadyStats is the time a raft worker spent in each stage of its loop, and the hits of the entry caches of its
+// peers.
+type WorkerReadyStats struct {
+	WorkerID int
+	Rounds   uint64
+	Stages   []ReadyStageStat
+	// the reads of raft entries of the peers of the worker served by their entry caches and not
+	EntryCacheHits   uint64
+	EntryCacheMisses uint64
+}
+
+type stageStat struct {
+	count int64
+	total int64
+	max   int64
+}
+
+// readyStats aggregates the time a raft worker spends in each stage of its loop. It's updated by the worker and read
+// by the debug RPC, so the counters are accessed atomically. If profile labels are enabled, the worker goroutine is
+// labeled with the stage it's in, so the samples of a CPU profile can be told apart by stage, e.g. with
+// `go tool pprof -tagfocus stage=apply`.
+type readyStats struct {
+	workerID int
+	rounds   int64
+	stages   [numReadyStages]stageStat
+	// the hits and the misses of the entry caches of the peers
+	entryCacheHits   int64
+	entryCacheMisses int64
+	// the label contexts of the worker and of each stage, nil if profile labels are disabled
+	workerLabels context.Context
+	stageLabels  [numReadyStages]context.Context
+}
+
+func newReadyStats(workerID int, profileLabels bool) *readyStats {
+	s := &readyStats{workerID: workerID}
+	if profileLabels {
+		s.workerLabels = pprof.WithLabels(context.Background(), pprof.Labels("raft_worker", strconv.Itoa(workerID)))
+		for stage := readyStage(0); stage < numReadyStages; stage++ {
+			s.stageLabels[stage] = pprof.WithLabels(s.workerLabels, pprof.Labels("stage", stage.String()))
+		}
+	}
+	return s
+}
+
+// readyTimer times a stage, started by readyStats.start.
+type readyTimer struct {
+	stats *readyStats
+	stage readyStage
+	start time.Time
+}
+
+// start starts timing a stage of the worker, which must be stopped before another stage starts. A nil readyStats
+// times nothing.
+func (s *readyStats) start(stage readyStage) readyTimer {
+	if s == nil {
+		return readyTimer{}
+	}
+	if s.workerLabels != nil {
+		pprof.SetGoroutineLabels(s.stageLabels[stage])
+	}
+	return readyTimer{stats: s, stage: stage, start: time.Now()}
+}
+
+// stop records the time since the stage started.
+func (t readyTimer) stop() {
+	if t.stats == nil {
+		return
+	}
+	t.stats.observe(t.stage, time.Since(t.start))
+	if t.stats.workerLabels != nil {
+		pprof.SetGoroutineLabels(t.stats.workerLabels)
+	}
+}
+
+func (s *readyStats) observe(stage readyStage, d time.Duration) {
+	stat := &s.stages[stage]
+	atomic.AddInt64(&stat.count, 1)
+	atomic.AddInt64(&stat.total, int64(d))
+	for {
+		max := atomic.LoadInt64(&stat.max)
+		if int64(d) <= max || atomic.CompareAndSwapInt64(&stat.max, max, int64(d)) {
+			return
+		}
+	}
+}
+
+// finishRound counts a round of the loop of the worker.
+func (s *readyStats) finishRound() {
+	if s != nil {
+		atomic.AddInt64(&s.rounds, 1)
+	}
+}
+
+// observeEntryCache adds the hits and the misses of the entry cache of a peer.
+func (s *readyStats) observeEntryCache(hits, misses uint64) {
+	if s != nil {
+		atomic.AddInt64(&s.entryCacheHits, int64(hits))
+		atomic.AddInt64(&s.entryCacheMisses, int64(misses))
+	}
+}
+
+func (s *readyStats) snapshot() WorkerReadyStats {
+	stats := WorkerReadyStats{
+		WorkerID:         s.workerID,
+		Rounds:           uint64(atomic.LoadInt64(&s.rounds)),
+		Stages:           make([]ReadyStageStat, 0, numReadyStages),
+		EntryCacheHits:   uint64(atomic.LoadInt64(&s.entryCacheHits)),
+		EntryCacheMisses: uint64(atomic.LoadInt64(&s.entryCacheMisses)),
+	}
+	for stage := readyStage(0); stage < numReadyStages; stage++ {
+		stat := &s.stages[stage]
+		stats.Stages = append(stats.Stages, ReadyStageStat{
+			Stage: stage.String(),
+			Count: uint64(atomic.LoadInt64(&stat.count)),
+			Total: time.Duration(atomic.LoadInt64(&stat.total)),
+			Max:   time.Duration(atomic.LoadInt64(&stat.max)),
+		})
+	}
+	return stats
+}
+
+// ReadyStats is the time the raft workers of a store spent in each stage of their loops since the store started.
+type ReadyStats struct {
+	workers []*readyStats
+}
+
+// Workers returns the stats of each raft worker.
+func (s *ReadyStats) Workers() []WorkerReadyStats {
+	stats := make([]WorkerReadyStats, 0, len(s.workers))
+	for _, w := range s.workers {
+		stats = append(stats, w.snapshot())
+	}
+	return stats
+}
@@ -0,0 +1,42 @@
+package raftstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadyStats(t *testing.T) {
+	// a handler run outside of a raft worker times nothing
+	var nilStats *readyStats
+	nilStats.start(stageApply).stop()
+	nilStats.finishRound()
+	nilStats.observeEntryCache(1, 1)
+
+	s := newReadyStats(3, true)
+	stats := &ReadyStats{workers: []*readyStats{s}}
+	timer := s.start(stageApply)
+	time.Sleep(time.Millisecond)
+	timer.stop()
+	s.observe(stageApply, 10*time.Millisecond)
+	s.observe(stageSend, time.Millisecond)
+	s.finishRound()
+	s.observeEntryCache(3, 1)
+
+	workers := stats.Workers()
+	assert.Equal(t, 1, len(workers))
+	assert.Equal(t, 3, workers[0].WorkerID)
+	assert.Equal(t, uint64(1), workers[0].Rounds)
+	assert.Equal(t, int(numReadyStages), len(workers[0].Stages))
+	apply := workers[0].Stages[stageApply]
+	assert.Equal(t, "apply", apply.Stage)
+	assert.Equal(t, uint64(2), apply.Count)
+	assert.True(t, apply.Total >= 11*time.Millisecond)
+	assert.Equal(t, 10*time.Millisecond, apply.Max)
+	assert.Equal(t, ReadyStageStat{Stage: "send", Count: 1, Total: time.Millisecond, Max: time.Millisecond},
+		workers[0].Stages[stageSend])
+	assert.Equal(t, uint64(0), workers[0].Stages[stageAdvance].Count)
+	assert.Equal(t, uint64(3), workers[0].EntryCacheHits)
+	assert.Equal(t, uint64(1), workers[0].EntryCacheMisses)
+}
@@ -0,0 +1,133 @@
+package raftstore
+
+import (
+	"bytes"
+
+	"github.com/Connor1996/badger"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/meta"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	rspb "github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
+	"github.com/pingcap/errors"
+)
+
+// importBatchSize is the max number of keys ImportRegion writes in a write batch.
+const importBatchSize = 1024
+
+// ExportRegion exports the state and the data of the region in the engines of a store into a blob, which
+// ImportRegion imports into the engines of another store. The states and the data are read in one transaction, so
+// they're consistent with each other, but the locks kept in memory aren't exported.
+func ExportRegion(engines *engine_util.Engines, regionID uint64) ([]byte, error) {
+	export := new(rspb.RegionExport)
+	err := engines.Kv.View(func(txn *badger.Txn) error {
+		export.RegionState = new(rspb.RegionLocalState)
+		if err := engine_util.GetMetaFromTxn(txn, meta.RegionStateKey(regionID), export.RegionState); err != nil {
+			return err
+		}
+		if export.RegionState.State != rspb.PeerState_Normal {
+			return errors.Errorf("region %d is %v", regionID, export.RegionState.State)
+		}
+		export.ApplyState = new(rspb.RaftApplyState)
+		if err := engine_util.GetMetaFromTxn(txn, meta.ApplyStateKey(regionID), export.ApplyState); err != nil {
+			return err
+		}
+		region := export.RegionState.Region
+		for _, cf := range engine_util.CFs {
+			data := &rspb.RegionExportCF{Cf: cf}
+			iter := engine_util.NewCFIterator(cf, txn)
+			for iter.Seek(region.StartKey); iter.Valid(); iter.Next() {
+				item := iter.Item()
+				if engine_util.ExceedEndKey(item.Key(), region.EndKey) {
+					break
+				}
+				value, err := item.ValueCopy(nil)
+				if err != nil {
+					iter.Close()
+					return err
+				}
+				data.Data = append(data.Data, &rspb.KeyValue{Key: item.KeyCopy(nil), Value: value})
+			}
+			iter.Close()
+			export.Cfs = append(export.Cfs, data)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	truncated := export.ApplyState.TruncatedState
+	if export.ApplyState.AppliedIndex == truncated.Index {
+		export.AppliedTerm = truncated.Term
+	} else {
+		entry, err := meta.GetRaftEntry(engines.Raft, regionID, export.ApplyState.AppliedIndex)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		export.AppliedTerm = entry.Term
+	}
+	return export.Marshal()
+}
+
+// ImportRegion imports the blob exported by ExportRegion as the region, which must cover the key range of the
+// exported region. The region is imported as if it had been truncated at the exported applied index, so the
+// same blob should be imported into all the peers of the region before they start. The key range of the region should
+// have no data.
+func ImportRegion(engines *engine_util.Engines, region *metapb.Region, blob []byte) error {
+	export := new(rspb.RegionExport)
+	if err := export.Unmarshal(blob); err != nil {
+		return errors.WithStack(err)
+	}
+	exported := export.RegionState.Region
+	if err := util.CheckKeyInRegion(exported.StartKey, region); err != nil {
+		return err
+	}
+	if len(region.EndKey) != 0 && (len(exported.EndKey) == 0 || bytes.Compare(exported.EndKey, region.EndKey) > 0) {
+		return errors.Errorf("region %d doesn't cover the exported region %d", region.Id, exported.Id)
+	}
+
+	// the log index and term of a region start at RaftInitLogIndex and RaftInitLogTerm
+	index, term := export.ApplyState.AppliedIndex, export.AppliedTerm
+	if index < meta.RaftInitLogIndex {
+		index = meta.RaftInitLogIndex
+	}
+	if term < meta.RaftInitLogTerm {
+		term = meta.RaftInitLogTerm
+	}
+
+	kvWB := new(engine_util.WriteBatch)
+	for _, data := range export.Cfs {
+		for _, kv := range data.Data {
+			kvWB.SetCF(data.Cf, kv.Key, kv.Value)
+			// a large region doesn't fit in a transaction of badger
+			if kvWB.Len() >= importBatchSize {
+				if err := engines.WriteKV(kvWB); err != nil {
+					return err
+				}
+				kvWB.Reset()
+			}
+		}
+	}
+	raftWB := new(engine_util.WriteBatch)
+	writeTruncatedState(kvWB, raftWB, region, index, term)
+	if err := engines.WriteKV(kvWB); err != nil {
+		return err
+	}
+	return engines.WriteRaft(raftWB)
+}
+
+// writeTruncatedState writes the states of a peer of the region whose data is applied up to the index, with the raft
+// log truncated at the index and the term.
+func writeTruncatedState(kvWB, raftWB *engine_util.WriteBatch, region *metapb.Region, index, term uint64) {
+	meta.WriteRegionState(kvWB, region, rspb.PeerState_Normal)
+	kvWB.SetMeta(meta.ApplyStateKey(region.Id), &rspb.RaftApplyState{
+		AppliedIndex:   index,
+		TruncatedState: &rspb.RaftTruncatedState{Index: index, Term: term},
+	})
+	raftWB.SetMeta(meta.RaftStateKey(region.Id), &rspb.RaftLocalState{
+		HardState: &eraftpb.HardState{Term: term, Commit: index},
+		LastIndex: index,
+		LastTerm:  term,
+	})
+}
@@ -0,0 +1,72 @@
+package raftstore
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/meta"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	rspb "github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportImportRegion(t *testing.T) {
+	src := util.NewTestEngines()
+	defer src.Destroy()
+	region := &metapb.Region{
+		Id:          2,
+		StartKey:    []byte("b"),
+		EndKey:      []byte("d"),
+		RegionEpoch: &metapb.RegionEpoch{Version: 3, ConfVer: 2},
+		Peers:       []*metapb.Peer{{Id: 3, StoreId: 1}},
+	}
+	kvWB := new(engine_util.WriteBatch)
+	meta.WriteRegionState(kvWB, region, rspb.PeerState_Normal)
+	kvWB.SetMeta(meta.ApplyStateKey(2), &rspb.RaftApplyState{
+		AppliedIndex:   12,
+		TruncatedState: &rspb.RaftTruncatedState{Index: 10, Term: 6},
+	})
+	kvWB.SetCF(engine_util.CfDefault, []byte("a"), []byte("outside"))
+	kvWB.SetCF(engine_util.CfDefault, []byte("b"), []byte("v1"))
+	kvWB.SetCF(engine_util.CfWrite, []byte("c"), []byte("v2"))
+	kvWB.SetCF(engine_util.CfLock, []byte("d"), []byte("outside"))
+	require.Nil(t, src.WriteKV(kvWB))
+	raftWB := new(engine_util.WriteBatch)
+	raftWB.SetMeta(meta.RaftLogKey(2, 12), &eraftpb.Entry{Index: 12, Term: 7})
+	require.Nil(t, src.WriteRaft(raftWB))
+
+	blob, err := ExportRegion(src, 2)
+	require.Nil(t, err)
+
+	dst := util.NewTestEngines()
+	defer dst.Destroy()
+	// the region to import into must cover the exported one
+	require.NotNil(t, ImportRegion(dst, &metapb.Region{Id: 1, StartKey: []byte("b"), EndKey: []byte("c")}, blob))
+	first := &metapb.Region{Id: 1, Peers: []*metapb.Peer{{Id: 1, StoreId: 2}}}
+	require.Nil(t, ImportRegion(dst, first, blob))
+
+	for cf, kvs := range map[string]map[string]string{
+		engine_util.CfDefault: {"a": "", "b": "v1"},
+		engine_util.CfWrite:   {"c": "v2"},
+		engine_util.CfLock:    {"d": ""},
+	} {
+		for key, value := range kvs {
+			val, _ := engine_util.GetCF(dst.Kv, cf, []byte(key))
+			require.Equal(t, value, string(val))
+		}
+	}
+	state, err := meta.GetRegionLocalState(dst.Kv, 1)
+	require.Nil(t, err)
+	require.Equal(t, first, state.Region)
+	applyState, err := meta.GetApplyState(dst.Kv, 1)
+	require.Nil(t, err)
+	require.Equal(t, uint64(12), applyState.AppliedIndex)
+	require.Equal(t, rspb.RaftTruncatedState{Index: 12, Term: 7}, *applyState.TruncatedState)
+	raftState, err := meta.GetRaftLocalState(dst.Raft, 1)
+	require.Nil(t, err)
+	require.Equal(t, uint64(12), raftState.LastIndex)
+	require.Equal(t, uint64(7), raftState.LastTerm)
+	require.Equal(t, eraftpb.HardState{Term: 7, Commit: 12}, *raftState.HardState)
+}
@@ -0,0 +1,79 @@
+package raftstore
+
+import (
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+)
+
+// replayBuffer keeps the latest committed entries of a region in memory, so that replaying them to the applier,
+// e.g. after a snapshot is applied or the peer is moved, doesn't read the raft engine. Committed entries never
+// change, so a buffered entry is always the same as the one in the raft engine. The buffer keeps consecutive
+// entries, the oldest are dropped once the entries exceed the capacity in bytes.
+//
+// A nil replayBuffer buffers nothing.
+type replayBuffer struct {
+	entries  []eraftpb.Entry
+	size     uint64
+	capacity uint64
+}
+
+func newReplayBuffer(capacity uint64) *replayBuffer {
+	if capacity == 0 {
+		return nil
+	}
+	return &replayBuffer{capacity: capacity}
+}
+
+// append adds the newly committed entries. Entries not following the buffered ones replace them.
+func (b *replayBuffer) append(entries []eraftpb.Entry) {
+	if b == nil || len(entries) == 0 {
+		return
+	}
+	if len(b.entries) > 0 {
+		last := b.entries[len(b.entries)-1].Index
+		first := entries[0].Index
+		if first <= last && first >= b.entries[0].Index {
+			// replayed entries, keep the buffered ones before them
+			b.truncate(first)
+		} else if first != last+1 {
+			b.clear()
+		}
+	}
+	for _, entry := range entries {
+		b.entries = append(b.entries, entry)
+		b.size += uint64(entry.Size())
+	}
+	for len(b.entries) > 1 && b.size > b.capacity {
+		b.size -= uint64(b.entries[0].Size())
+		b.entries[0] = eraftpb.Entry{}
+		b.entries = b.entries[1:]
+	}
+}
+
+// truncate drops the entries from the index on.
+func (b *replayBuffer) truncate(index uint64) {
+	offset := int(index - b.entries[0].Index)
+	for _, entry := range b.entries[offset:] {
+		b.size -= uint64(entry.Size())
+	}
+	b.entries = b.entries[:offset]
+}
+
+// slice returns the entries in [low, high) if they are all buffered.
+func (b *replayBuffer) slice(low, high uint64) ([]eraftpb.Entry, bool) {
+	if b == nil || len(b.entries) == 0 || low < b.entries[0].Index || high > b.entries[len(b.entries)-1].Index+1 {
+		return nil, false
+	}
+	offset := b.entries[0].Index
+	ents := make([]eraftpb.Entry, high-low)
+	copy(ents, b.entries[low-offset:high-offset])
+	return ents, true
+}
+
+// clear drops all the entries, it's called when the log of the peer is replaced by a snapshot.
+func (b *replayBuffer) clear() {
+	if b == nil {
+		return
+	}
+	b.entries = nil
+	b.size = 0
+}
@@ -0,0 +1,59 @@
+package raftstore
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+	"github.com/stretchr/testify/assert"
+)
+
+func newReplayEntries(low, high uint64) []eraftpb.Entry {
+	var ents []eraftpb.Entry
+	for i := low; i < high; i++ {
+		ents = append(ents, eraftpb.Entry{Term: 1, Index: i, Data: make([]byte, 100)})
+	}
+	return ents
+}
+
+func TestReplayBuffer(t *testing.T) {
+	size := uint64(newReplayEntries(1, 2)[0].Size())
+	b := newReplayBuffer(10 * size)
+	b.append(newReplayEntries(1, 6))
+	ents, ok := b.slice(2, 6)
+	assert.True(t, ok)
+	assert.Equal(t, newReplayEntries(2, 6), ents)
+	_, ok = b.slice(2, 7)
+	assert.False(t, ok)
+
+	// the oldest entries are dropped
+	b.append(newReplayEntries(6, 13))
+	_, ok = b.slice(2, 13)
+	assert.False(t, ok)
+	ents, ok = b.slice(3, 13)
+	assert.True(t, ok)
+	assert.Equal(t, newReplayEntries(3, 13), ents)
+
+	// replayed entries replace the buffered ones
+	b.append(newReplayEntries(10, 12))
+	_, ok = b.slice(12, 13)
+	assert.False(t, ok)
+	_, ok = b.slice(3, 12)
+	assert.True(t, ok)
+
+	// a gap clears the buffer
+	b.append(newReplayEntries(20, 21))
+	_, ok = b.slice(3, 4)
+	assert.False(t, ok)
+	_, ok = b.slice(20, 21)
+	assert.True(t, ok)
+
+	b.clear()
+	_, ok = b.slice(20, 21)
+	assert.False(t, ok)
+
+	var disabled *replayBuffer
+	disabled.append(newReplayEntries(1, 2))
+	_, ok = disabled.slice(1, 2)
+	assert.False(t, ok)
+	assert.Nil(t, newReplayBuffer(0))
+}
@@ -1,20 +1,31 @@
 package raftstore
 
 import (
+	"context"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/message"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
+	"github.com/pingcap-incubator/tinykv/raft"
 
 	"github.com/pingcap/errors"
 )
 
 // peerState contains the peer states that needs to run raft command and apply command.
 type peerState struct {
-	closed uint32
-	peer   *peer
+	// the byte size of the raft messages routed to the peer and not handled by it yet, accessed atomically
+	pendingRaftSize int64
+	closed          uint32
+	peer            *peer
+}
+
+// takeRaftMessage removes the raft message taken by the raft worker from the pending raft messages of the peer.
+func (ps *peerState) takeRaftMessage(msg *raft_serverpb.RaftMessage) {
+	atomic.AddInt64(&ps.pendingRaftSize, -int64(msg.Size()))
 }
 
 // router routes a message to a peer.
@@ -22,12 +33,14 @@ type router struct {
 	peers       sync.Map // regionID -> peerState
 	peerSender  chan message.Msg
 	storeSender chan<- message.Msg
+	observers   *RegionObserverRegistry
 }
 
-func newRouter(storeSender chan<- message.Msg) *router {
+func newRouter(storeSender chan<- message.Msg, observers *RegionObserverRegistry) *router {
 	pm := &router{
 		peerSender:  make(chan message.Msg, 40960),
 		storeSender: storeSender,
+		observers:   observers,
 	}
 	return pm
 }
@@ -46,6 +59,7 @@ func (pr *router) register(peer *peer) {
 		peer: peer,
 	}
 	pr.peers.Store(id, newPeer)
+	pr.observers.notify(&RegionChangeEvent{Type: RegionChangeCreate, Region: peer.Region()})
 }
 
 func (pr *router) close(regionID uint64) {
@@ -54,6 +68,7 @@ func (pr *router) close(regionID uint64) {
 		ps := v.(*peerState)
 		atomic.StoreUint32(&ps.closed, 1)
 		pr.peers.Delete(regionID)
+		pr.observers.notify(&RegionChangeEvent{Type: RegionChangeDestroy, Region: ps.peer.Region()})
 	}
 }
 
@@ -67,11 +82,53 @@ func (pr *router) send(regionID uint64, msg message.Msg) error {
 	return nil
 }
 
+// trySend is like send, but it fails with errPeerBusy instead of blocking if the peer messages are full.
+func (pr *router) trySend(regionID uint64, msg message.Msg) error {
+	msg.RegionID = regionID
+	p := pr.get(regionID)
+	if p == nil || atomic.LoadUint32(&p.closed) == 1 {
+		return errPeerNotFound
+	}
+	select {
+	case pr.peerSender <- msg:
+		return nil
+	default:
+		return errPeerBusy
+	}
+}
+
+// sendRaftMessage routes the raft message to the peer of the region, counting it in the pending raft messages of the
+// peer until the raft worker takes it.
+func (pr *router) sendRaftMessage(regionID uint64, msg *raft_serverpb.RaftMessage) error {
+	p := pr.get(regionID)
+	if p == nil || atomic.LoadUint32(&p.closed) == 1 {
+		return errPeerNotFound
+	}
+	atomic.AddInt64(&p.pendingRaftSize, int64(msg.Size()))
+	pr.peerSender <- message.NewPeerMsg(message.MsgTypeRaftMessage, regionID, msg)
+	return nil
+}
+
+// pendingRaftSize returns the byte size of the pending raft messages of the peer of the region, 0 if it doesn't exist.
+func (pr *router) pendingRaftSize(regionID uint64) int64 {
+	p := pr.get(regionID)
+	if p == nil {
+		return 0
+	}
+	return atomic.LoadInt64(&p.pendingRaftSize)
+}
+
 func (pr *router) sendStore(msg message.Msg) {
 	pr.storeSender <- msg
 }
 
-var errPeerNotFound = errors.New("peer not found")
+var (
+	errPeerNotFound = errors.New("peer not found")
+	errPeerBusy     = errors.New("peer is busy")
+)
+
+// raftMessagesWaitInterval is the interval WaitRaftMessages checks the pending raft messages of a region at.
+const raftMessagesWaitInterval = time.Millisecond
 
 type RaftstoreRouter struct {
 	router *router
@@ -87,13 +144,57 @@ func (r *RaftstoreRouter) Send(regionID uint64, msg message.Msg) error {
 
 func (r *RaftstoreRouter) SendRaftMessage(msg *raft_serverpb.RaftMessage) error {
 	regionID := msg.RegionId
-	if r.router.send(regionID, message.NewPeerMsg(message.MsgTypeRaftMessage, regionID, msg)) != nil {
+	if r.router.sendRaftMessage(regionID, msg) != nil {
 		r.router.sendStore(message.NewPeerMsg(message.MsgTypeStoreRaftMessage, regionID, msg))
 	}
 	return nil
 
 }
 
+// CompactLog asks the leader of the region on this store to compact the raft log applied so far, regardless of the
+// raft log gc limits. The callback is called once the CompactLog is applied.
+func (r *RaftstoreRouter) CompactLog(regionID uint64, cb *message.Callback) error {
+	return r.router.send(regionID, message.NewPeerMsg(message.MsgTypeCompactLog, regionID, cb))
+}
+
+// SplitCheck asks the leader of the region on this store to check whether the region needs to be split, regardless
+// of the size changed since the last check. The callback is called once the check is scheduled.
+func (r *RaftstoreRouter) SplitCheck(regionID uint64, cb *message.Callback) error {
+	return r.router.send(regionID, message.NewPeerMsg(message.MsgTypeSplitCheck, regionID, cb))
+}
+
+// WaitRaftMessages waits until the byte size of the raft messages of the region not handled by its peer yet is under
+// maxSize, so the raft stream receiving the messages stops reading from the stream while the peer is behind. 0 maxSize
+// means no limit.
+func (r *RaftstoreRouter) WaitRaftMessages(ctx context.Context, regionID uint64, maxSize uint64) error {
+	if maxSize == 0 {
+		return nil
+	}
+	for r.router.pendingRaftSize(regionID) >= int64(maxSize) {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(raftMessagesWaitInterval):
+		}
+	}
+	return nil
+}
+
+// CreatePeer asks the store to create the peer of the region, which stays
+// uninitialized until it applies a snapshot from the leader.
+func (r *RaftstoreRouter) CreatePeer(region *metapb.Region, peer *metapb.Peer, cb *message.Callback) {
+	r.router.sendStore(message.NewPeerMsg(message.MsgTypeStoreCreatePeer, region.GetId(), &message.MsgCreatePeer{
+		Region:   region,
+		Peer:     peer,
+		Callback: cb,
+	}))
+}
+
+// RaftStatus asks the peer of the region on this store to send its raft status to ch, which must be buffered.
+func (r *RaftstoreRouter) RaftStatus(regionID uint64, ch chan<- raft.Status) error {
+	return r.router.send(regionID, message.NewPeerMsg(message.MsgTypeRaftStatus, regionID, ch))
+}
+
 func (r *RaftstoreRouter) SendRaftCommand(req *raft_cmdpb.RaftCmdRequest, cb *message.Callback) error {
 	cmd := &message.MsgRaftCmd{
 		Request:  req,
@@ -0,0 +1,42 @@
+package raftstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/message"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+	rspb "github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitRaftMessages(t *testing.T) {
+	pr := newRouter(make(chan message.Msg, 16), nil)
+	ps := new(peerState)
+	pr.peers.Store(uint64(1), ps)
+	r := NewRaftstoreRouter(pr)
+
+	msg := &rspb.RaftMessage{RegionId: 1, Message: &eraftpb.Message{Entries: []*eraftpb.Entry{{Data: make([]byte, 100)}}}}
+	require.Nil(t, r.SendRaftMessage(msg))
+	size := uint64(msg.Size())
+	require.Equal(t, int64(size), pr.pendingRaftSize(1))
+	// no limit, or under the limit
+	require.Nil(t, r.WaitRaftMessages(context.Background(), 1, 0))
+	require.Nil(t, r.WaitRaftMessages(context.Background(), 1, size+1))
+	// the region without a peer has no pending messages
+	require.Nil(t, r.WaitRaftMessages(context.Background(), 2, 1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.Equal(t, context.DeadlineExceeded, r.WaitRaftMessages(ctx, 1, size))
+
+	done := make(chan error, 1)
+	go func() {
+		done <- r.WaitRaftMessages(context.Background(), 1, size)
+	}()
+	taken := <-pr.peerSender
+	ps.takeRaftMessage(taken.Data.(*rspb.RaftMessage))
+	require.Nil(t, <-done)
+	require.Equal(t, int64(0), pr.pendingRaftSize(1))
+}
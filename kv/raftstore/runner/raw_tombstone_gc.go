@@ -0,0 +1,92 @@
+package runner
+
+import (
+	"github.com/Connor1996/badger"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/message"
+	"github.com/pingcap-incubator/tinykv/kv/storage"
+	"github.com/pingcap-incubator/tinykv/kv/transaction/latches"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/kv/util/worker"
+	"github.com/pingcap-incubator/tinykv/log"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+)
+
+// RawTombstoneGCTask removes the raw tombstones of the region whose entries all expired before SafeTime in unix ms.
+type RawTombstoneGCTask struct {
+	Region   *metapb.Region
+	Peer     *metapb.Peer
+	SafeTime uint64
+}
+
+type rawTombstoneGCHandler struct {
+	cleaner *rollbackCleanupHandler
+	// the latches of the raw keys, held by the raw writes too
+	latches *latches.Latches
+}
+
+func NewRawTombstoneGCHandler(engine *badger.DB, router message.RaftRouter, latches *latches.Latches) *rawTombstoneGCHandler {
+	return &rawTombstoneGCHandler{
+		cleaner: NewRollbackCleanupHandler(engine, router),
+		latches: latches,
+	}
+}
+
+func (r *rawTombstoneGCHandler) Handle(t worker.Task) {
+	task, ok := t.(*RawTombstoneGCTask)
+	if !ok {
+		log.Error("unsupported worker.Task: %+v", t)
+		return
+	}
+	region := task.Region
+	var removed int
+	for startKey := region.StartKey; ; {
+		keys, next, err := r.cleaner.scan(engine_util.CfRawTombstone, storage.StaleRawTombstones, startKey, region.EndKey, task.SafeTime)
+		if err != nil {
+			log.Warnf("[region %d] scan stale raw tombstones failed: %v", region.Id, err)
+			return
+		}
+		if len(keys) > 0 {
+			n, err := r.remove(task, keys)
+			if err != nil {
+				log.Warnf("[region %d] remove stale raw tombstones failed: %v", region.Id, err)
+				return
+			}
+			removed += n
+		}
+		if next == nil {
+			break
+		}
+		startKey = next
+	}
+	if removed > 0 {
+		log.Infof("[region %d] removed %d stale raw tombstones", region.Id, removed)
+	}
+}
+
+// remove deletes the tombstones of the keys which are still stale and returns how many it deleted. The latches of
+// the keys are held from the check until the deletes are applied, so a tombstone a raw delete wrote since the scan
+// is kept.
+func (r *rawTombstoneGCHandler) remove(task *RawTombstoneGCTask, keys [][]byte) (int, error) {
+	r.latches.WaitForLatches(keys)
+	defer r.latches.ReleaseLatches(keys)
+	txn := r.cleaner.engine.NewTransaction(false)
+	stale := make([][]byte, 0, len(keys))
+	for _, key := range keys {
+		value, err := engine_util.GetCFFromTxn(txn, engine_util.CfRawTombstone, key)
+		if err == badger.ErrKeyNotFound {
+			continue
+		}
+		if err != nil {
+			txn.Discard()
+			return 0, err
+		}
+		if ok, err := storage.IsStaleRawTombstone(value, task.SafeTime); err == nil && ok {
+			stale = append(stale, key)
+		}
+	}
+	txn.Discard()
+	if len(stale) == 0 {
+		return 0, nil
+	}
+	return len(stale), r.cleaner.deleteKeys(task.Region, task.Peer, engine_util.CfRawTombstone, stale)
+}
@@ -1,16 +1,22 @@
 package runner
 
 import (
+	"container/heap"
 	"encoding/hex"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/Connor1996/badger"
 	"github.com/juju/errors"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/keyfilter"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/lockindex"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/locktable"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/meta"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/snap"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
 	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/kv/util/failpoint"
 	"github.com/pingcap-incubator/tinykv/kv/util/worker"
 	"github.com/pingcap-incubator/tinykv/log"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
@@ -26,6 +32,7 @@ import (
 type RegionTaskGen struct {
 	RegionId uint64                   // specify the region which the task is for.
 	Notifier chan<- *eraftpb.Snapshot // when it finishes snapshot generating, it notifies notifier.
+	Size     uint64                   // the approximate size of the region, 0 if unknown.
 }
 
 type RegionTaskApply struct {
@@ -43,15 +50,28 @@ type RegionTaskDestroy struct {
 }
 
 type regionTaskHandler struct {
-	ctx *snapContext
+	ctx        *snapContext
+	genQueue   *genQueue
+	applyQueue *applyQueue
 }
 
-func NewRegionTaskHandler(engines *engine_util.Engines, mgr *snap.SnapManager) *regionTaskHandler {
+// NewRegionTaskHandler creates the handler of region tasks, at most genLimit snapshots are generated at the same time,
+// the rest are queued smallest region first. At most applyLimit snapshots are applied at the same time, the rest are
+// queued in FIFO order. The key filters of the regions whose data is replaced or cleaned up are
+// dropped, so are their lock indexes. The lock table, if not nil, is checkpointed for generating snapshots and kept
+// in sync with the data.
+func NewRegionTaskHandler(engines *engine_util.Engines, mgr *snap.SnapManager, genLimit, applyLimit int,
+	keyFilters *keyfilter.KeyFilters, lockTable *locktable.LockTable, lockIndex *lockindex.LockIndex) *regionTaskHandler {
 	return &regionTaskHandler{
 		ctx: &snapContext{
-			engines: engines,
-			mgr:     mgr,
+			engines:    engines,
+			mgr:        mgr,
+			keyFilters: keyFilters,
+			lockTable:  lockTable,
+			lockIndex:  lockIndex,
 		},
+		genQueue:   newGenQueue(genLimit),
+		applyQueue: newApplyQueue(applyLimit),
 	}
 }
 
@@ -61,25 +81,209 @@ func (r *regionTaskHandler) Handle(t worker.Task) {
 		task := t.(*RegionTaskGen)
 		// It is safe for now to handle generating and applying snapshot concurrently,
 		// but it may not when merge is implemented.
-		r.ctx.handleGen(task.RegionId, task.Notifier)
+		r.genQueue.push(task, func(task *RegionTaskGen) {
+			r.ctx.handleGen(task.RegionId, task.Notifier)
+		})
 	case *RegionTaskApply:
 		task := t.(*RegionTaskApply)
-		r.ctx.handleApply(task.RegionId, task.Notifier, task.StartKey, task.EndKey, task.SnapMeta)
+		// Applying is done out of the worker goroutine, so that a queue of snapshots to apply doesn't block
+		// generating snapshots.
+		r.applyQueue.push(task.RegionId, func() {
+			r.ctx.handleApply(task.RegionId, task.Notifier, task.StartKey, task.EndKey, task.SnapMeta)
+		})
 	case *RegionTaskDestroy:
 		task := t.(*RegionTaskDestroy)
-		r.ctx.cleanUpRange(task.RegionId, task.StartKey, task.EndKey)
+		cleanUp := func() {
+			r.ctx.cleanUpRange(task.RegionId, task.StartKey, task.EndKey)
+		}
+		// A snapshot of the region queued or being applied would write its data back after the clean up, so the
+		// clean up waits for it.
+		if !r.applyQueue.pushIfPending(task.RegionId, cleanUp) {
+			cleanUp()
+		}
+	}
+}
+
+// applyQueue limits the number of snapshots applied at the same time on a store, so a store rejoining after a long
+// downtime doesn't thrash the disk by applying all the snapshots at once. The tasks of a region run one at a time in
+// the order they are queued, and the others in FIFO order.
+type applyQueue struct {
+	sync.Mutex
+	limit   int
+	running int
+	tasks   []queuedApply
+	// the number of the queued and running tasks of each region
+	regions map[uint64]int
+	// the regions with a running task
+	busy map[uint64]bool
+}
+
+type queuedApply struct {
+	regionID uint64
+	run      func()
+}
+
+func newApplyQueue(limit int) *applyQueue {
+	if limit <= 0 {
+		limit = 1
+	}
+	return &applyQueue{limit: limit, regions: make(map[uint64]int), busy: make(map[uint64]bool)}
+}
+
+// push queues the task of the region, and starts a goroutine to run the queued tasks if the limit is not reached.
+func (q *applyQueue) push(regionID uint64, run func()) {
+	q.Lock()
+	q.enqueue(regionID, run)
+	if q.running >= q.limit {
+		log.Infof("snapshot apply of region %d is queued, %d pending", regionID, len(q.tasks))
+		q.Unlock()
+		return
+	}
+	q.running++
+	q.Unlock()
+	go q.drain()
+}
+
+// pushIfPending queues the task of the region behind its other tasks and returns true, if it has any queued or
+// running. Otherwise the task isn't queued, it can run at once.
+func (q *applyQueue) pushIfPending(regionID uint64, run func()) bool {
+	q.Lock()
+	defer q.Unlock()
+	if q.regions[regionID] == 0 {
+		return false
+	}
+	// a goroutine runs it, as there is one while any task is queued
+	q.enqueue(regionID, run)
+	return true
+}
+
+func (q *applyQueue) enqueue(regionID uint64, run func()) {
+	q.tasks = append(q.tasks, queuedApply{regionID: regionID, run: run})
+	q.regions[regionID]++
+}
+
+// drain runs the queued tasks until there is none whose region isn't running another task.
+func (q *applyQueue) drain() {
+	for {
+		q.Lock()
+		i := 0
+		for i < len(q.tasks) && q.busy[q.tasks[i].regionID] {
+			i++
+		}
+		if i == len(q.tasks) {
+			q.running--
+			q.Unlock()
+			return
+		}
+		task := q.tasks[i]
+		q.tasks = append(q.tasks[:i], q.tasks[i+1:]...)
+		q.busy[task.regionID] = true
+		q.Unlock()
+
+		task.run()
+
+		q.Lock()
+		delete(q.busy, task.regionID)
+		if q.regions[task.regionID]--; q.regions[task.regionID] == 0 {
+			delete(q.regions, task.regionID)
+		}
+		q.Unlock()
+	}
+}
+
+// genQueue limits the number of snapshots generated at the same time on a store, so adding the replicas of many
+// regions at once, or the followers generating the snapshots delegated to them, doesn't overwhelm the disk. The queued
+// snapshots are generated smallest region first, so a small region doesn't wait for the large ones.
+type genQueue struct {
+	sync.Mutex
+	limit   int
+	running int
+	tasks   genTasks
+	// the number of the tasks pushed, ordering the tasks of the same size
+	seq uint64
+}
+
+type queuedGen struct {
+	task   *RegionTaskGen
+	seq    uint64
+	queued time.Time
+}
+
+// genTasks is a heap of the queued tasks, by the size of the region and then in FIFO order.
+type genTasks []queuedGen
+
+func (h genTasks) Len() int { return len(h) }
+func (h genTasks) Less(i, j int) bool {
+	if h[i].task.Size != h[j].task.Size {
+		return h[i].task.Size < h[j].task.Size
+	}
+	return h[i].seq < h[j].seq
+}
+func (h genTasks) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *genTasks) Push(x interface{}) { *h = append(*h, x.(queuedGen)) }
+func (h *genTasks) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = queuedGen{}
+	*h = old[:n-1]
+	return x
+}
+
+func newGenQueue(limit int) *genQueue {
+	if limit <= 0 {
+		limit = 1
+	}
+	return &genQueue{limit: limit}
+}
+
+// push queues the task, and starts a goroutine to run the queued tasks if the limit is not reached.
+func (q *genQueue) push(task *RegionTaskGen, run func(*RegionTaskGen)) {
+	q.Lock()
+	q.seq++
+	heap.Push(&q.tasks, queuedGen{task: task, seq: q.seq, queued: time.Now()})
+	if q.running >= q.limit {
+		log.Infof("snapshot generation of region %d with size %d is queued, %d pending", task.RegionId, task.Size, len(q.tasks))
+		q.Unlock()
+		return
+	}
+	q.running++
+	q.Unlock()
+	go q.drain(run)
+}
+
+func (q *genQueue) drain(run func(*RegionTaskGen)) {
+	for {
+		q.Lock()
+		if len(q.tasks) == 0 {
+			q.running--
+			q.Unlock()
+			return
+		}
+		gen := heap.Pop(&q.tasks).(queuedGen)
+		q.Unlock()
+		if wait := time.Since(gen.queued); wait > time.Second {
+			log.Infof("snapshot generation of region %d starts after waiting %v in the queue", gen.task.RegionId, wait)
+		}
+		run(gen.task)
 	}
 }
 
 type snapContext struct {
-	engines   *engine_util.Engines
-	batchSize uint64
-	mgr       *snap.SnapManager
+	engines    *engine_util.Engines
+	batchSize  uint64
+	mgr        *snap.SnapManager
+	keyFilters *keyfilter.KeyFilters
+	lockTable  *locktable.LockTable
+	lockIndex  *lockindex.LockIndex
 }
 
 // handleGen handles the task of generating snapshot of the Region.
 func (snapCtx *snapContext) handleGen(regionId uint64, notifier chan<- *eraftpb.Snapshot) {
-	snap, err := doSnapshot(snapCtx.engines, snapCtx.mgr, regionId)
+	snap, err := doSnapshot(snapCtx.engines, snapCtx.mgr, snapCtx.lockTable, regionId)
+	if err == nil {
+		err = failpoint.Inject("snapshot/generate")
+	}
 	if err != nil {
 		log.Errorf("failed to generate snapshot!!!, [regionId: %d, err : %v]", regionId, err)
 		notifier <- nil
@@ -91,6 +295,9 @@ func (snapCtx *snapContext) handleGen(regionId uint64, notifier chan<- *eraftpb.
 // applySnap applies snapshot data of the Region.
 func (snapCtx *snapContext) applySnap(regionId uint64, startKey, endKey []byte, snapMeta *eraftpb.SnapshotMetadata) error {
 	log.Infof("begin apply snap data. [regionId: %d]", regionId)
+	if err := failpoint.Inject("snapshot/apply"); err != nil {
+		return err
+	}
 
 	// cleanUpOriginData clear up the region data before applying snapshot
 	snapCtx.cleanUpRange(regionId, startKey, endKey)
@@ -103,6 +310,7 @@ func (snapCtx *snapContext) applySnap(regionId uint64, startKey, endKey []byte,
 	if err != nil {
 		return errors.New(fmt.Sprintf("missing snapshot file %s", err))
 	}
+	snapCtx.mgr.SetSnapSize(snapKey, snapshot.TotalSize())
 
 	t := time.Now()
 	applyOptions := snap.NewApplyOptions(snapCtx.engines.Kv, &metapb.Region{
@@ -113,6 +321,11 @@ func (snapCtx *snapContext) applySnap(regionId uint64, startKey, endKey []byte,
 	if err := snapshot.Apply(*applyOptions); err != nil {
 		return err
 	}
+	if snapCtx.lockTable != nil {
+		if err := snapCtx.lockTable.Reload(applyOptions.Region, snapMeta.Index); err != nil {
+			return err
+		}
+	}
 
 	log.Infof("applying new data. [regionId: %d, timeTakes: %v]", regionId, time.Now().Sub(t))
 	return nil
@@ -121,6 +334,9 @@ func (snapCtx *snapContext) applySnap(regionId uint64, startKey, endKey []byte,
 // handleApply tries to apply the snapshot of the specified Region. It calls `applySnap` to do the actual work.
 func (snapCtx *snapContext) handleApply(regionId uint64, notifier chan<- bool, startKey, endKey []byte, snapMeta *eraftpb.SnapshotMetadata) {
 	err := snapCtx.applySnap(regionId, startKey, endKey, snapMeta)
+	// the filter and the lock index may be rebuilt while the data is replaced
+	snapCtx.keyFilters.Invalidate(regionId)
+	snapCtx.lockIndex.Invalidate(regionId)
 	if err != nil {
 		notifier <- false
 		log.Fatalf("failed to apply snap!!!. err: %v", err)
@@ -130,6 +346,11 @@ func (snapCtx *snapContext) handleApply(regionId uint64, notifier chan<- bool, s
 
 // cleanUpRange cleans up the data within the range.
 func (snapCtx *snapContext) cleanUpRange(regionId uint64, startKey, endKey []byte) {
+	defer snapCtx.keyFilters.Invalidate(regionId)
+	defer snapCtx.lockIndex.Invalidate(regionId)
+	if snapCtx.lockTable != nil {
+		snapCtx.lockTable.DeleteRange(startKey, endKey)
+	}
 	if err := engine_util.DeleteRange(snapCtx.engines.Kv, startKey, endKey); err != nil {
 		log.Fatalf("failed to delete data in range, [regionId: %d, startKey: %s, endKey: %s, err: %v]", regionId,
 			hex.EncodeToString(startKey), hex.EncodeToString(endKey), err)
@@ -161,10 +382,19 @@ func getAppliedIdxTermForSnapshot(raft *badger.DB, kv *badger.Txn, regionId uint
 	return idx, term, nil
 }
 
-func doSnapshot(engines *engine_util.Engines, mgr *snap.SnapManager, regionId uint64) (*eraftpb.Snapshot, error) {
+func doSnapshot(engines *engine_util.Engines, mgr *snap.SnapManager, lockTable *locktable.LockTable, regionId uint64) (*eraftpb.Snapshot, error) {
 	log.Debugf("begin to generate a snapshot. [regionId: %d]", regionId)
 
-	txn := engines.Kv.NewTransaction(false)
+	var txn *badger.Txn
+	if lockTable != nil {
+		// the lock CF in the kv engine is only up to date after a checkpoint
+		var err error
+		if txn, err = lockTable.NewTransaction(regionId); err != nil {
+			return nil, err
+		}
+	} else {
+		txn = engines.Kv.NewTransaction(false)
+	}
 
 	index, term, err := getAppliedIdxTermForSnapshot(engines.Raft, txn, regionId)
 	if err != nil {
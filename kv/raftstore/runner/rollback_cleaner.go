@@ -0,0 +1,120 @@
+package runner
+
+import (
+	"errors"
+
+	"github.com/Connor1996/badger"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/message"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
+	"github.com/pingcap-incubator/tinykv/kv/transaction/mvcc"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/kv/util/worker"
+	"github.com/pingcap-incubator/tinykv/log"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
+)
+
+// rollbackCleanupBatchSize is the number of keys deleted by a proposal.
+const rollbackCleanupBatchSize = 1024
+
+// RollbackCleanupTask removes the rollback records of the transactions started before SafeTs from the write CF of
+// the region, independent of the MVCC GC, and the mutations they staged and never prewrote from the staging CF.
+type RollbackCleanupTask struct {
+	Region *metapb.Region
+	Peer   *metapb.Peer
+	SafeTs uint64
+}
+
+type rollbackCleanupHandler struct {
+	engine *badger.DB
+	router message.RaftRouter
+}
+
+func NewRollbackCleanupHandler(engine *badger.DB, router message.RaftRouter) *rollbackCleanupHandler {
+	return &rollbackCleanupHandler{
+		engine: engine,
+		router: router,
+	}
+}
+
+// staleScanner returns at most limit stale keys in [startKey, endKey) of the CF iter iterates, and the key to
+// continue from. The keys are stale before safe, a timestamp or a time depending on the CF.
+type staleScanner func(iter engine_util.DBIterator, startKey, endKey []byte, safe uint64, limit int) ([][]byte, []byte, error)
+
+func (r *rollbackCleanupHandler) Handle(t worker.Task) {
+	task, ok := t.(*RollbackCleanupTask)
+	if !ok {
+		log.Error("unsupported worker.Task: %+v", t)
+		return
+	}
+	if !r.clean(task, engine_util.CfWrite, mvcc.StaleRollbacks, task.SafeTs, "rollback records") {
+		return
+	}
+	r.clean(task, engine_util.CfStaging, mvcc.StaleStaged, task.SafeTs, "staged mutations")
+}
+
+// clean deletes the keys of the CF in the region stale before safe, it returns false if it failed.
+func (r *rollbackCleanupHandler) clean(task *RollbackCleanupTask, cf string, scanner staleScanner, safe uint64, what string) bool {
+	region := task.Region
+	var removed int
+	for startKey := region.StartKey; ; {
+		keys, next, err := r.scan(cf, scanner, startKey, region.EndKey, safe)
+		if err != nil {
+			log.Warnf("[region %d] scan stale %s failed: %v", region.Id, what, err)
+			return false
+		}
+		if len(keys) > 0 {
+			if err := r.deleteKeys(task.Region, task.Peer, cf, keys); err != nil {
+				log.Warnf("[region %d] remove stale %s failed: %v", region.Id, what, err)
+				return false
+			}
+			removed += len(keys)
+		}
+		if next == nil {
+			break
+		}
+		startKey = next
+	}
+	if removed > 0 {
+		log.Infof("[region %d] removed %d stale %s", region.Id, removed, what)
+	}
+	return true
+}
+
+func (r *rollbackCleanupHandler) scan(cf string, scanner staleScanner, startKey, endKey []byte, safe uint64) ([][]byte, []byte, error) {
+	txn := r.engine.NewTransaction(false)
+	defer txn.Discard()
+	it := engine_util.NewCFIterator(cf, txn)
+	defer it.Close()
+	return scanner(it, startKey, endKey, safe, rollbackCleanupBatchSize)
+}
+
+// deleteKeys proposes the deletes of the keys as a write command of the region and waits for it to be applied, so a
+// large cleanup doesn't flood the raft log. The command is rejected if the region changed since the task was sent.
+func (r *rollbackCleanupHandler) deleteKeys(region *metapb.Region, peer *metapb.Peer, cf string, keys [][]byte) error {
+	cmd := &util.WriteCmd{
+		Header: &raft_cmdpb.RaftRequestHeader{
+			RegionId:    region.Id,
+			Peer:        peer,
+			RegionEpoch: region.RegionEpoch,
+		},
+	}
+	for _, key := range keys {
+		cmd.Requests = append(cmd.Requests, &raft_cmdpb.Request{
+			CmdType: raft_cmdpb.CmdType_Delete,
+			Delete:  &raft_cmdpb.DeleteRequest{Cf: cf, Key: key},
+		})
+	}
+	cb := message.NewCallback()
+	if err := r.router.SendRaftCommand(cmd.RaftCmdRequest(), cb); err != nil {
+		return err
+	}
+	resp := cb.WaitResp()
+	if resp == nil {
+		return &util.ErrStaleCommand{}
+	}
+	if err := resp.GetHeader().GetError(); err != nil {
+		return errors.New(err.String())
+	}
+	return nil
+}
@@ -4,17 +4,23 @@ import (
 	"encoding/binary"
 	"io"
 	"io/ioutil"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/Connor1996/badger"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/message"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/meta"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/snap"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
+	"github.com/pingcap-incubator/tinykv/kv/storage"
+	"github.com/pingcap-incubator/tinykv/kv/transaction/latches"
+	"github.com/pingcap-incubator/tinykv/kv/transaction/mvcc"
 	"github.com/pingcap-incubator/tinykv/kv/util/codec"
 	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
 	"github.com/pingcap-incubator/tinykv/kv/util/worker"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
 	rspb "github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
@@ -276,3 +282,220 @@ func TestSplitCheck(t *testing.T) {
 	assert.True(t, ok)
 	assert.Equal(t, codec.EncodeBytes([]byte("k2")), split.SplitKey)
 }
+
+func TestSplitCheckSkipGarbage(t *testing.T) {
+	engines := util.NewTestEngines()
+	defer cleanUpTestEngineData(engines)
+	db := engines.Kv
+	taskResCh := make(chan message.Msg, 2)
+
+	runner := &splitCheckHandler{
+		engine:  db,
+		router:  &TaskResRouter{ch: taskResCh},
+		checker: newSizeSplitChecker(100, 50),
+	}
+
+	kvWb := new(engine_util.WriteBatch)
+	// k1@1 is overwritten by k1@2
+	kvWb.SetCF(engine_util.CfDefault, encodeKey([]byte("k1"), 1), []byte("entry"))
+	kvWb.SetCF(engine_util.CfWrite, encodeKey([]byte("k1"), 3), (&mvcc.Write{StartTS: 1, Kind: mvcc.WriteKindPut}).ToBytes())
+	kvWb.SetCF(engine_util.CfDefault, encodeKey([]byte("k1"), 2), []byte("entry"))
+	kvWb.SetCF(engine_util.CfWrite, encodeKey([]byte("k1"), 4), (&mvcc.Write{StartTS: 2, Kind: mvcc.WriteKindPut}).ToBytes())
+	kvWb.SetCF(engine_util.CfWrite, encodeKey([]byte("k1"), 5), (&mvcc.Write{StartTS: 5, Kind: mvcc.WriteKindRollback}).ToBytes())
+	// k2 is deleted
+	kvWb.SetCF(engine_util.CfDefault, encodeKey([]byte("k2"), 1), []byte("entry"))
+	kvWb.SetCF(engine_util.CfWrite, encodeKey([]byte("k2"), 2), (&mvcc.Write{StartTS: 1, Kind: mvcc.WriteKindPut}).ToBytes())
+	kvWb.SetCF(engine_util.CfWrite, encodeKey([]byte("k2"), 6), (&mvcc.Write{StartTS: 5, Kind: mvcc.WriteKindDelete}).ToBytes())
+	// k3@7 isn't committed yet
+	kvWb.SetCF(engine_util.CfDefault, encodeKey([]byte("k3"), 7), []byte("entry"))
+	kvWb.SetCF(engine_util.CfDefault, []byte("raw"), []byte("entry"))
+	kvWb.MustWriteToDB(db)
+
+	runner.Handle(&SplitCheckTask{Region: &metapb.Region{}})
+	msg := <-taskResCh
+	assert.Equal(t, message.MsgTypeRegionApproximateSize, msg.Type)
+	// the length of each encoded kv pair is 22, and of the raw one 8
+	assert.Equal(t, uint64(2*22+8), msg.Data)
+	msg = <-taskResCh
+	assert.Equal(t, message.MsgTypeRegionApproximateKeys, msg.Type)
+	assert.Equal(t, uint64(3), msg.Data)
+}
+
+// cmdRouter records the commands sent and responds to them with success.
+type cmdRouter struct {
+	TaskResRouter
+	cmds []*raft_cmdpb.RaftCmdRequest
+}
+
+func (r *cmdRouter) SendRaftCommand(req *raft_cmdpb.RaftCmdRequest, cb *message.Callback) error {
+	r.cmds = append(r.cmds, req)
+	cb.Done(&raft_cmdpb.RaftCmdResponse{Header: &raft_cmdpb.RaftResponseHeader{}})
+	return nil
+}
+
+func TestRollbackCleanup(t *testing.T) {
+	engines := util.NewTestEngines()
+	defer cleanUpTestEngineData(engines)
+	db := engines.Kv
+	router := &cmdRouter{}
+	runner := NewRollbackCleanupHandler(db, router)
+
+	kvWb := new(engine_util.WriteBatch)
+	kvWb.SetCF(engine_util.CfWrite, encodeKey([]byte("k1"), 3), (&mvcc.Write{StartTS: 3, Kind: mvcc.WriteKindRollback}).ToBytes())
+	kvWb.SetCF(engine_util.CfWrite, encodeKey([]byte("k1"), 2), (&mvcc.Write{StartTS: 1, Kind: mvcc.WriteKindPut}).ToBytes())
+	kvWb.SetCF(engine_util.CfWrite, encodeKey([]byte("k2"), 5), (&mvcc.Write{StartTS: 5, Kind: mvcc.WriteKindRollback}).ToBytes())
+	kvWb.SetCF(engine_util.CfWrite, encodeKey([]byte("k3"), 9), (&mvcc.Write{StartTS: 9, Kind: mvcc.WriteKindRollback}).ToBytes())
+	kvWb.SetCF(engine_util.CfStaging, encodeKey([]byte("k1"), 4), []byte{byte(kvrpcpb.Op_Put)})
+	kvWb.SetCF(engine_util.CfStaging, encodeKey([]byte("k2"), 9), []byte{byte(kvrpcpb.Op_Put)})
+	kvWb.MustWriteToDB(db)
+
+	region := &metapb.Region{Id: 1, RegionEpoch: &metapb.RegionEpoch{ConfVer: 1, Version: 1}}
+	runner.Handle(&RollbackCleanupTask{Region: region, Peer: &metapb.Peer{Id: 1, StoreId: 1}, SafeTs: 8})
+	assert.Len(t, router.cmds, 2)
+	deletes := func(i int, cf string) [][]byte {
+		cmd, err := util.ParseCmd(router.cmds[i])
+		assert.Nil(t, err)
+		write, ok := cmd.(*util.WriteCmd)
+		assert.True(t, ok)
+		assert.Equal(t, region.RegionEpoch, write.Header.RegionEpoch)
+		var keys [][]byte
+		for _, req := range write.Requests {
+			assert.Equal(t, cf, req.Delete.Cf)
+			keys = append(keys, req.Delete.Key)
+		}
+		return keys
+	}
+	assert.Equal(t, [][]byte{encodeKey([]byte("k1"), 3), encodeKey([]byte("k2"), 5)}, deletes(0, engine_util.CfWrite))
+	// the mutation staged at 9 is not stale yet
+	assert.Equal(t, [][]byte{encodeKey([]byte("k1"), 4)}, deletes(1, engine_util.CfStaging))
+
+}
+
+func TestRawTombstoneGC(t *testing.T) {
+	engines := util.NewTestEngines()
+	defer cleanUpTestEngineData(engines)
+	db := engines.Kv
+	router := &cmdRouter{}
+	runner := NewRawTombstoneGCHandler(db, router, latches.NewLatches())
+
+	mem := storage.NewMemStorage()
+	mem.Set(engine_util.CfDefault, []byte("r1"), []byte("v"))
+	mem.Set(engine_util.CfDefault, []byte("r2"), []byte("v"))
+	reader, _ := mem.Reader(nil)
+	kvWb := new(engine_util.WriteBatch)
+	for key, expire := range map[string]int64{"r1": 1000, "r2": 3000} {
+		modifies, err := storage.RawSoftDelete(reader, engine_util.CfDefault, []byte(key), time.Unix(0, expire*int64(time.Millisecond)))
+		assert.Nil(t, err)
+		kvWb.SetCF(engine_util.CfRawTombstone, []byte(key), modifies[1].Value())
+	}
+	kvWb.MustWriteToDB(db)
+	// a tombstone which can't be parsed is skipped
+	kvWb = new(engine_util.WriteBatch)
+	kvWb.SetCF(engine_util.CfRawTombstone, []byte("r3"), []byte{0xff})
+	kvWb.MustWriteToDB(db)
+
+	region := &metapb.Region{Id: 1, RegionEpoch: &metapb.RegionEpoch{ConfVer: 1, Version: 1}}
+	peer := &metapb.Peer{Id: 1, StoreId: 1}
+	runner.Handle(&RawTombstoneGCTask{Region: region, Peer: peer, SafeTime: 2000})
+	assert.Len(t, router.cmds, 1)
+	cmd, err := util.ParseCmd(router.cmds[0])
+	assert.Nil(t, err)
+	write := cmd.(*util.WriteCmd)
+	assert.Len(t, write.Requests, 1)
+	assert.Equal(t, engine_util.CfRawTombstone, write.Requests[0].Delete.Cf)
+	assert.Equal(t, []byte("r1"), write.Requests[0].Delete.Key)
+
+	// a tombstone written again since the scan isn't removed
+	router.cmds = nil
+	n, err := runner.remove(&RawTombstoneGCTask{Region: region, Peer: peer, SafeTime: 2000}, [][]byte{[]byte("r2")})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, n)
+	assert.Len(t, router.cmds, 0)
+}
+
+func TestApplyQueue(t *testing.T) {
+	for _, limit := range []int{1, 2} {
+		q := newApplyQueue(limit)
+		release := make(chan struct{})
+		done := make(chan struct{}, 5)
+		var mu sync.Mutex
+		var started []uint64
+		running, maxRunning := 0, 0
+		run := func(regionID uint64) {
+			mu.Lock()
+			started = append(started, regionID)
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			mu.Unlock()
+			<-release
+			mu.Lock()
+			running--
+			mu.Unlock()
+			done <- struct{}{}
+		}
+		for i := uint64(1); i <= 5; i++ {
+			regionID := i
+			q.push(regionID, func() { run(regionID) })
+		}
+		close(release)
+		for i := 0; i < 5; i++ {
+			<-done
+		}
+		assert.True(t, maxRunning <= limit)
+		if limit == 1 {
+			assert.Equal(t, []uint64{1, 2, 3, 4, 5}, started)
+		}
+	}
+}
+
+func TestApplyQueueSerializesRegion(t *testing.T) {
+	q := newApplyQueue(2)
+	// nothing pending, the clean up runs at once
+	assert.False(t, q.pushIfPending(1, func() {}))
+
+	release := make(chan struct{})
+	done := make(chan string, 3)
+	q.push(1, func() {
+		<-release
+		done <- "apply 1"
+	})
+	q.push(1, func() { done <- "apply 1 again" })
+	assert.True(t, q.pushIfPending(1, func() { done <- "clean up 1" }))
+	// the other regions aren't blocked
+	q.push(2, func() { done <- "apply 2" })
+	assert.Equal(t, "apply 2", <-done)
+
+	close(release)
+	assert.Equal(t, "apply 1", <-done)
+	assert.Equal(t, "apply 1 again", <-done)
+	assert.Equal(t, "clean up 1", <-done)
+}
+
+func TestGenQueue(t *testing.T) {
+	q := newGenQueue(1)
+	release := make(chan struct{})
+	running := make(chan struct{}, 6)
+	done := make(chan struct{}, 6)
+	var started []uint64
+	run := func(task *RegionTaskGen) {
+		started = append(started, task.RegionId)
+		running <- struct{}{}
+		<-release
+		done <- struct{}{}
+	}
+	sizes := []uint64{50, 40, 30, 10, 20, 10}
+	q.push(&RegionTaskGen{RegionId: 1, Size: sizes[0]}, run)
+	// the rest are queued while the first runs
+	<-running
+	for i, size := range sizes[1:] {
+		q.push(&RegionTaskGen{RegionId: uint64(i + 2), Size: size}, run)
+	}
+	close(release)
+	for range sizes {
+		<-done
+	}
+	// smallest region first, in FIFO order for the same size
+	assert.Equal(t, []uint64{1, 4, 6, 5, 3, 2}, started)
+}
@@ -2,14 +2,18 @@ package runner
 
 import (
 	"context"
+	"time"
 
 	"github.com/Connor1996/badger"
+	"github.com/pingcap-incubator/tinykv/kv/config"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/message"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/scheduler_client"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
 	"github.com/pingcap-incubator/tinykv/kv/util/worker"
 	"github.com/pingcap-incubator/tinykv/log"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/schedulerpb"
 	"github.com/shirou/gopsutil/disk"
 )
@@ -26,25 +30,34 @@ type SchedulerRegionHeartbeatTask struct {
 	Peer            *metapb.Peer
 	PendingPeers    []*metapb.Peer
 	ApproximateSize *uint64
+	ApproximateKeys *uint64
+	// the snapshots applied by the followers since the previous heartbeat
+	SnapshotsApplied []*schedulerpb.SnapshotApplied
 }
 
 type SchedulerStoreHeartbeatTask struct {
 	Stats  *schedulerpb.StoreStats
 	Engine *badger.DB
 	Path   string
+	// the data directories whose disk usage is reported
+	DataDirs []config.DataDir
+	// the number of level 0 tables from which the engine is considered busy compacting, 0 if not checked
+	CompactionPendingL0Tables int
 }
 
 type SchedulerTaskHandler struct {
 	storeID         uint64
 	SchedulerClient scheduler_client.Client
 	router          message.RaftRouter
+	clockSkew       *util.ClockSkew
 }
 
-func NewSchedulerTaskHandler(storeID uint64, SchedulerClient scheduler_client.Client, router message.RaftRouter) *SchedulerTaskHandler {
+func NewSchedulerTaskHandler(storeID uint64, SchedulerClient scheduler_client.Client, router message.RaftRouter, clockSkew *util.ClockSkew) *SchedulerTaskHandler {
 	return &SchedulerTaskHandler{
 		storeID:         storeID,
 		SchedulerClient: SchedulerClient,
 		router:          router,
+		clockSkew:       clockSkew,
 	}
 }
 
@@ -81,6 +94,12 @@ func (r *SchedulerTaskHandler) onRegionHeartbeatResponse(resp *schedulerpb.Regio
 				Peer: transferLeader.Peer,
 			},
 		}, message.NewCallback())
+	} else if merge := resp.GetMerge(); merge != nil {
+		_ = r.router.Send(resp.RegionId, message.NewPeerMsg(message.MsgTypeMergeRegion, resp.RegionId, &message.MsgMergeRegion{
+			RegionEpoch: resp.RegionEpoch,
+			Target:      merge.Target,
+			Callback:    message.NewCallback(),
+		}))
 	}
 }
 
@@ -103,16 +122,21 @@ func (r *SchedulerTaskHandler) onAskSplit(t *SchedulerAskSplitTask) {
 }
 
 func (r *SchedulerTaskHandler) onHeartbeat(t *SchedulerRegionHeartbeatTask) {
-	var size int64
+	var size, keys int64
 	if t.ApproximateSize != nil {
 		size = int64(*t.ApproximateSize)
 	}
+	if t.ApproximateKeys != nil {
+		keys = int64(*t.ApproximateKeys)
+	}
 
 	req := &schedulerpb.RegionHeartbeatRequest{
-		Region:          t.Region,
-		Leader:          t.Peer,
-		PendingPeers:    t.PendingPeers,
-		ApproximateSize: uint64(size),
+		Region:           t.Region,
+		Leader:           t.Peer,
+		PendingPeers:     t.PendingPeers,
+		ApproximateSize:  uint64(size),
+		ApproximateKeys:  uint64(keys),
+		SnapshotsApplied: t.SnapshotsApplied,
 	}
 	r.SchedulerClient.RegionHeartbeat(req)
 }
@@ -135,18 +159,77 @@ func (r *SchedulerTaskHandler) onStoreHeartbeat(t *SchedulerStoreHeartbeatTask)
 	t.Stats.Capacity = capacity
 	t.Stats.UsedSize = usedSize
 	t.Stats.Available = available
+	t.Stats.ClockOffset = int64(r.clockSkew.Offset())
+	for _, dir := range t.DataDirs {
+		stat, err := disk.Usage(dir.Path)
+		if err != nil {
+			log.Warnf("get disk usage of %s directory %s failed: %v", dir.Name, dir.Path, err)
+			continue
+		}
+		t.Stats.DiskStats = append(t.Stats.DiskStats, &schedulerpb.DiskStats{
+			Name:      dir.Name,
+			Path:      dir.Path,
+			Capacity:  stat.Total,
+			Available: stat.Free,
+		})
+	}
+	if t.CompactionPendingL0Tables > 0 {
+		t.Stats.IsCompacting = levelZeroTables(t.Engine) >= t.CompactionPendingL0Tables
+	}
 
-	r.SchedulerClient.StoreHeartbeat(context.TODO(), t.Stats)
+	sent := time.Now()
+	resp, err := r.SchedulerClient.StoreHeartbeat(context.TODO(), t.Stats)
+	if err != nil {
+		return
+	}
+	for _, stale := range resp.GetStalePeers() {
+		r.gcStalePeer(stale)
+	}
+	if resp.SchedulerTime == 0 {
+		return
+	}
+	offset := r.clockSkew.Observe(sent, time.Now(), resp.SchedulerTime)
+	if r.clockSkew.Exceeded() {
+		log.Errorf("store %d: clock skew to the scheduler %v exceeds the max clock skew, lease reads are disabled", r.storeID, offset)
+	}
+}
+
+// gcStalePeer sends a tombstone message to the peer the scheduler found stale, the peer destroys itself if its epoch
+// is older than the one in the message, otherwise it has caught up since and the message is ignored.
+func (r *SchedulerTaskHandler) gcStalePeer(stale *schedulerpb.StalePeer) {
+	log.Infof("store %d: scheduler reports peer %d of region %d is stale", r.storeID, stale.Peer.GetId(), stale.RegionId)
+	msg := &raft_serverpb.RaftMessage{
+		RegionId:    stale.RegionId,
+		FromPeer:    stale.Peer,
+		ToPeer:      stale.Peer,
+		RegionEpoch: stale.RegionEpoch,
+		IsTombstone: true,
+	}
+	if err := r.router.SendRaftMessage(msg); err != nil {
+		log.Debugf("store %d: send tombstone message to stale peer %d err: %v", r.storeID, stale.Peer.GetId(), err)
+	}
 }
 
 func (r *SchedulerTaskHandler) sendAdminRequest(regionID uint64, epoch *metapb.RegionEpoch, peer *metapb.Peer, req *raft_cmdpb.AdminRequest, callback *message.Callback) {
-	cmd := &raft_cmdpb.RaftCmdRequest{
+	cmd := &util.AdminCmd{
 		Header: &raft_cmdpb.RaftRequestHeader{
 			RegionId:    regionID,
 			Peer:        peer,
 			RegionEpoch: epoch,
 		},
-		AdminRequest: req,
+		Request: req,
+	}
+	r.router.SendRaftCommand(cmd.RaftCmdRequest(), callback)
+}
+
+// levelZeroTables returns the number of level 0 tables of the engine, they pile up when the compaction can't keep up
+// with the writes.
+func levelZeroTables(engine *badger.DB) int {
+	count := 0
+	for _, table := range engine.Tables() {
+		if table.Level == 0 {
+			count++
+		}
 	}
-	r.router.SendRaftCommand(cmd, callback)
+	return count
 }
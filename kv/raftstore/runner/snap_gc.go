@@ -0,0 +1,95 @@
+package runner
+
+import (
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/message"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/snap"
+	"github.com/pingcap-incubator/tinykv/kv/util/worker"
+	"github.com/pingcap-incubator/tinykv/log"
+)
+
+// orphanSnapFileGrace is how long a file which belongs to no snapshot is kept, so the files of a snapshot being
+// written aren't deleted before it's registered.
+const orphanSnapFileGrace = time.Minute
+
+// SnapGCTask deletes the stale snapshot files of the store.
+type SnapGCTask struct{}
+
+type snapGCHandler struct {
+	snapMgr *snap.SnapManager
+	router  message.RaftRouter
+	ttl     time.Duration
+}
+
+func NewSnapGCHandler(snapMgr *snap.SnapManager, router message.RaftRouter, ttl time.Duration) *snapGCHandler {
+	return &snapGCHandler{
+		snapMgr: snapMgr,
+		router:  router,
+		ttl:     ttl,
+	}
+}
+
+// Handle deletes the files which belong to no snapshot, the snapshots older than the ttl, and the snapshots of the
+// regions destroyed on the store. The snapshots of the other regions are sent to their peers, which delete the
+// snapshots applied or compacted.
+func (r *snapGCHandler) Handle(t worker.Task) {
+	if _, ok := t.(*SnapGCTask); !ok {
+		log.Errorf("unsupported worker.Task: %+v", t)
+		return
+	}
+	if _, err := r.snapMgr.DeleteOrphanFiles(orphanSnapFileGrace); err != nil {
+		log.Errorf("delete orphan snapshot files failed: %v", err)
+	}
+	keys, err := r.snapMgr.ListIdleSnap()
+	if err != nil {
+		log.Errorf("list idle snapshots failed: %v", err)
+		return
+	}
+	// the keys are sorted by region
+	var regionKeys []snap.SnapKeyWithSending
+	for i, key := range keys {
+		if r.expired(key) {
+			r.delete(key, "has been expired")
+		} else {
+			regionKeys = append(regionKeys, key)
+		}
+		if i+1 < len(keys) && keys[i+1].SnapKey.RegionID == key.SnapKey.RegionID || len(regionKeys) == 0 {
+			continue
+		}
+		regionID := key.SnapKey.RegionID
+		gcSnap := message.NewPeerMsg(message.MsgTypeGcSnap, regionID, &message.MsgGCSnap{Snaps: regionKeys})
+		if r.router.Send(regionID, gcSnap) != nil {
+			// the region has no peer on the store, it's destroyed
+			for _, key := range regionKeys {
+				r.delete(key, "belongs to a destroyed region")
+			}
+		}
+		regionKeys = nil
+	}
+}
+
+func (r *snapGCHandler) expired(key snap.SnapKeyWithSending) bool {
+	if r.ttl == 0 {
+		return false
+	}
+	modTime, err := r.snapMgr.ModTime(key)
+	return err == nil && time.Since(modTime) > r.ttl
+}
+
+func (r *snapGCHandler) delete(key snap.SnapKeyWithSending, reason string) {
+	var s snap.Snapshot
+	var err error
+	if key.IsSending {
+		s, err = r.snapMgr.GetSnapshotForSending(key.SnapKey)
+	} else {
+		s, err = r.snapMgr.GetSnapshotForApplying(key.SnapKey)
+	}
+	if err != nil {
+		log.Errorf("failed to load snapshot for %s: %v", key.SnapKey, err)
+		return
+	}
+	log.Infof("snap file %s %s, delete", key.SnapKey, reason)
+	// the snapshot may be registered to be sent or applied since it's listed
+	r.snapMgr.DeleteSnapshot(key.SnapKey, s, true)
+}
@@ -1,12 +1,16 @@
 package runner
 
 import (
+	"bytes"
+	"encoding/binary"
 	"encoding/hex"
+	"math"
 
 	"github.com/Connor1996/badger"
 	"github.com/pingcap-incubator/tinykv/kv/config"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/message"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
+	"github.com/pingcap-incubator/tinykv/kv/transaction/mvcc"
 	"github.com/pingcap-incubator/tinykv/kv/util/codec"
 	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
 	"github.com/pingcap-incubator/tinykv/kv/util/worker"
@@ -28,7 +32,7 @@ func NewSplitCheckHandler(engine *badger.DB, router message.RaftRouter, conf *co
 	runner := &splitCheckHandler{
 		engine:  engine,
 		router:  router,
-		checker: newSizeSplitChecker(conf.RegionMaxSize, conf.RegionSplitSize),
+		checker: newSizeSplitChecker(conf.RegionMaxSize, conf.SplitSize()),
 	}
 	return runner
 }
@@ -77,29 +81,99 @@ func (r *splitCheckHandler) splitCheck(regionID uint64, startKey, endKey []byte)
 	r.checker.reset()
 	it := engine_util.NewCFIterator(engine_util.CfDefault, txn)
 	defer it.Close()
+	garbage := newGarbageFilter(engine_util.NewCFIterator(engine_util.CfWrite, txn))
+	defer garbage.close()
+	scanned := true
 	for it.Seek(startKey); it.Valid(); it.Next() {
 		item := it.Item()
 		key := item.Key()
 		if engine_util.ExceedEndKey(key, endKey) {
-			// update region size
-			r.router.Send(regionID, message.Msg{
-				Type: message.MsgTypeRegionApproximateSize,
-				Data: r.checker.currentSize,
-			})
 			break
 		}
+		if garbage.isGarbage(key) {
+			continue
+		}
 		if r.checker.onKv(key, item) {
+			scanned = false
 			break
 		}
 	}
+	if scanned {
+		// update region size
+		r.router.Send(regionID, message.Msg{
+			Type: message.MsgTypeRegionApproximateSize,
+			Data: r.checker.currentSize,
+		})
+		r.router.Send(regionID, message.Msg{
+			Type: message.MsgTypeRegionApproximateKeys,
+			Data: r.checker.currentKeys,
+		})
+	}
 	return r.checker.getSplitKey()
 }
 
+// garbageFilter tells the values in the default CF which are garbage waiting for GC, i.e. superseded by a newer
+// committed write or deleted, so the approximate size is of the live data rather than of the bytes on disk, and
+// isn't far off right after a large delete. A value not committed yet is live, and so is a raw key.
+type garbageFilter struct {
+	writeIter engine_util.DBIterator
+	userKey   []byte
+	// the latest write of userKey which isn't a rollback, nil if none
+	latest *mvcc.Write
+}
+
+func newGarbageFilter(writeIter engine_util.DBIterator) *garbageFilter {
+	return &garbageFilter{writeIter: writeIter}
+}
+
+func (f *garbageFilter) isGarbage(key []byte) bool {
+	left, userKey, err := codec.DecodeBytes(key)
+	if err != nil || len(left) != 8 {
+		return false
+	}
+	ts := ^binary.BigEndian.Uint64(left)
+	if f.userKey == nil || !bytes.Equal(userKey, f.userKey) {
+		f.userKey = append(f.userKey[:0], userKey...)
+		f.latest = f.latestWrite(userKey)
+	}
+	if f.latest == nil || ts > f.latest.StartTS {
+		return false
+	}
+	return ts < f.latest.StartTS || f.latest.Kind != mvcc.WriteKindPut
+}
+
+func (f *garbageFilter) latestWrite(userKey []byte) *mvcc.Write {
+	for f.writeIter.Seek(mvcc.EncodeKey(userKey, math.MaxUint64)); f.writeIter.Valid(); f.writeIter.Next() {
+		item := f.writeIter.Item()
+		_, key, err := codec.DecodeBytes(item.Key())
+		if err != nil || !bytes.Equal(key, userKey) {
+			return nil
+		}
+		value, err := item.Value()
+		if err != nil {
+			return nil
+		}
+		write, err := mvcc.ParseWrite(value)
+		if err != nil || write == nil {
+			return nil
+		}
+		if write.Kind != mvcc.WriteKindRollback {
+			return write
+		}
+	}
+	return nil
+}
+
+func (f *garbageFilter) close() {
+	f.writeIter.Close()
+}
+
 type sizeSplitChecker struct {
 	maxSize   uint64
 	splitSize uint64
 
 	currentSize uint64
+	currentKeys uint64
 	splitKey    []byte
 }
 
@@ -112,6 +186,7 @@ func newSizeSplitChecker(maxSize, splitSize uint64) *sizeSplitChecker {
 
 func (checker *sizeSplitChecker) reset() {
 	checker.currentSize = 0
+	checker.currentKeys = 0
 	checker.splitKey = nil
 }
 
@@ -119,6 +194,7 @@ func (checker *sizeSplitChecker) onKv(key []byte, item engine_util.DBItem) bool
 	valueSize := uint64(item.ValueSize())
 	size := uint64(len(key)) + valueSize
 	checker.currentSize += size
+	checker.currentKeys++
 	if checker.currentSize > checker.splitSize && checker.splitKey == nil {
 		checker.splitKey = util.SafeCopy(key)
 	}
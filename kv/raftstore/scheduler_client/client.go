@@ -27,10 +27,15 @@ import (
 	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/schedulerpb"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Client is a Scheduler client.
 // It should not be used after calling Close().
+// The client is given the endpoints of several scheduler members, it finds
+// the leader among them and sends the requests to it. The endpoints are
+// probed periodically, so the client follows the leader as members restart.
 type Client interface {
 	GetClusterID(ctx context.Context) uint64
 	AllocID(ctx context.Context) (uint64, error)
@@ -41,7 +46,7 @@ type Client interface {
 	GetRegion(ctx context.Context, key []byte) (*metapb.Region, *metapb.Peer, error)
 	GetRegionByID(ctx context.Context, regionID uint64) (*metapb.Region, *metapb.Peer, error)
 	AskSplit(ctx context.Context, region *metapb.Region) (*schedulerpb.AskSplitResponse, error)
-	StoreHeartbeat(ctx context.Context, stats *schedulerpb.StoreStats) error
+	StoreHeartbeat(ctx context.Context, stats *schedulerpb.StoreStats) (*schedulerpb.StoreHeartbeatResponse, error)
 	RegionHeartbeat(*schedulerpb.RegionHeartbeatRequest) error
 	SetRegionHeartbeatResponseHandler(storeID uint64, h func(*schedulerpb.RegionHeartbeatResponse))
 	Close()
@@ -52,6 +57,8 @@ const (
 	retryInterval         = time.Second
 	maxInitClusterRetries = 100
 	maxRetryCount         = 10
+	// the interval of probing the health of each endpoint
+	healthCheckInterval = 10 * time.Second
 )
 
 var (
@@ -60,14 +67,23 @@ var (
 )
 
 type client struct {
-	urls      []string
+	// the endpoints the client is created with, which are always kept in
+	// connMu.urls, so a restarted member is found again even if it's missing
+	// from the members reported by the leader for a while
+	seeds     []string
 	clusterID uint64
 	tag       string
+	// authenticates the calls to the scheduler, empty if it doesn't authenticate them
+	token string
 
 	connMu struct {
 		sync.RWMutex
 		clientConns map[string]*grpc.ClientConn
 		leader      string
+		// the endpoints of the members to ask for the leader
+		urls []string
+		// the health of each endpoint, by the latest request to it
+		health map[string]*endpointHealth
 	}
 	checkLeaderCh chan struct{}
 
@@ -82,8 +98,28 @@ type client struct {
 	heartbeatHandler atomic.Value
 }
 
-// NewClient creates a Scheduler client.
-func NewClient(pdAddrs []string, tag string) (Client, error) {
+// tokenCredentials authenticates the calls to the scheduler by a bearer token. The connections to the scheduler are
+// not secured, so the token is sent in plaintext, and anyone able to watch the network between the store and the
+// scheduler can take it.
+type tokenCredentials string
+
+func (t tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+func (t tokenCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// endpointHealth is the result of the latest request to an endpoint.
+type endpointHealth struct {
+	healthy bool
+	updated time.Time
+	err     error
+}
+
+// NewClient creates a Scheduler client, the calls are authenticated by the token unless it's empty.
+func NewClient(pdAddrs []string, tag string, token string) (Client, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 	urls := make([]string, 0, len(pdAddrs))
 	for _, addr := range pdAddrs {
@@ -96,15 +132,18 @@ func NewClient(pdAddrs []string, tag string) (Client, error) {
 	log.Infof("[%s][scheduler] create scheduler client with endpoints %v", tag, urls)
 
 	c := &client{
-		urls:                     urls,
+		seeds:                    urls,
 		receiveRegionHeartbeatCh: make(chan *schedulerpb.RegionHeartbeatResponse, 1),
 		checkLeaderCh:            make(chan struct{}, 1),
 		ctx:                      ctx,
 		cancel:                   cancel,
 		tag:                      tag,
+		token:                    token,
 		regionCh:                 make(chan *schedulerpb.RegionHeartbeatRequest, 64),
 	}
 	c.connMu.clientConns = make(map[string]*grpc.ClientConn)
+	c.connMu.urls = urls
+	c.connMu.health = make(map[string]*endpointHealth)
 
 	var (
 		err     error
@@ -122,8 +161,9 @@ func NewClient(pdAddrs []string, tag string) (Client, error) {
 
 	c.clusterID = members.GetHeader().GetClusterId()
 	log.Infof("[%s][scheduler] init cluster id %v", tag, c.clusterID)
-	c.wg.Add(2)
+	c.wg.Add(3)
 	go c.checkLeaderLoop()
+	go c.healthCheckLoop()
 	go c.heartbeatStreamLoop()
 
 	return c, nil
@@ -158,11 +198,78 @@ func (c *client) checkLeaderLoop() {
 	}
 }
 
+// healthCheckLoop probes the endpoints periodically.
+func (c *client) healthCheckLoop() {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-c.ctx.Done():
+			return
+		}
+		c.probeEndpoints()
+	}
+}
+
+// probeEndpoints asks each endpoint for the members, and checks the leader
+// again if the leader doesn't respond or an endpoint knows another leader.
+func (c *client) probeEndpoints() {
+	leader := c.leaderAddr()
+	stale := false
+	for _, u := range c.endpoints() {
+		ctx, cancel := context.WithTimeout(c.ctx, schedulerTimeout)
+		members, err := c.getMembers(ctx, u)
+		cancel()
+		c.setHealth(u, err)
+		if err != nil {
+			if u == leader {
+				log.Warnf("[%s][scheduler] leader %s is unhealthy, err: %s", c.tag, u, err)
+				stale = true
+			}
+			continue
+		}
+		if urls := members.GetLeader().GetClientUrls(); len(urls) > 0 && urls[0] != leader {
+			stale = true
+		}
+	}
+	if stale {
+		c.schedulerUpdateLeader()
+	}
+}
+
+// endpoints returns the endpoints to ask for the leader, the healthy ones
+// first.
+func (c *client) endpoints() []string {
+	c.connMu.RLock()
+	defer c.connMu.RUnlock()
+	urls := make([]string, 0, len(c.connMu.urls))
+	var unhealthy []string
+	for _, u := range c.connMu.urls {
+		if h, ok := c.connMu.health[u]; ok && !h.healthy {
+			unhealthy = append(unhealthy, u)
+			continue
+		}
+		urls = append(urls, u)
+	}
+	return append(urls, unhealthy...)
+}
+
+func (c *client) setHealth(url string, err error) {
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+	c.connMu.health[url] = &endpointHealth{healthy: err == nil, updated: time.Now(), err: err}
+}
+
 func (c *client) updateLeader() (*schedulerpb.GetMembersResponse, error) {
-	for _, u := range c.urls {
+	urls := c.endpoints()
+	for _, u := range urls {
 		ctx, cancel := context.WithTimeout(c.ctx, schedulerTimeout)
 		members, err := c.getMembers(ctx, u)
 		cancel()
+		c.setHealth(u, err)
 		if err != nil || members.GetLeader() == nil || len(members.GetLeader().GetClientUrls()) == 0 {
 			select {
 			case <-c.ctx.Done():
@@ -175,18 +282,33 @@ func (c *client) updateLeader() (*schedulerpb.GetMembersResponse, error) {
 		c.updateURLs(members.GetMembers(), members.GetLeader())
 		return members, c.switchLeader(members.GetLeader().GetClientUrls())
 	}
-	return nil, errors.Errorf("failed to get leader from %v", c.urls)
+	return nil, errors.Errorf("failed to get leader from %v", urls)
 }
 
 func (c *client) updateURLs(members []*schedulerpb.Member, leader *schedulerpb.Member) {
-	urls := make([]string, 0, len(members))
+	urls := make([]string, 0, len(members)+len(c.seeds))
 	for _, m := range members {
 		if m.GetMemberId() == leader.GetMemberId() {
 			continue
 		}
 		urls = append(urls, m.GetClientUrls()...)
 	}
-	c.urls = append(urls, leader.GetClientUrls()...)
+	urls = append(urls, leader.GetClientUrls()...)
+	for _, seed := range c.seeds {
+		found := false
+		for _, u := range urls {
+			if u == seed {
+				found = true
+				break
+			}
+		}
+		if !found {
+			urls = append(urls, seed)
+		}
+	}
+	c.connMu.Lock()
+	c.connMu.urls = urls
+	c.connMu.Unlock()
 }
 
 func (c *client) switchLeader(addrs []string) error {
@@ -231,7 +353,11 @@ func (c *client) getOrCreateConn(addr string) (*grpc.ClientConn, error) {
 	if err != nil {
 		return nil, err
 	}
-	cc, err := grpc.Dial(u.Host, grpc.WithInsecure())
+	opts := []grpc.DialOption{grpc.WithInsecure()}
+	if c.token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(tokenCredentials(c.token)))
+	}
+	cc, err := grpc.Dial(u.Host, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -252,9 +378,29 @@ func (c *client) leaderClient() schedulerpb.SchedulerClient {
 	return schedulerpb.NewSchedulerClient(c.connMu.clientConns[c.connMu.leader])
 }
 
-func (c *client) doRequest(ctx context.Context, f func(context.Context, schedulerpb.SchedulerClient) error) error {
+func (c *client) leaderAddr() string {
+	c.connMu.RLock()
+	defer c.connMu.RUnlock()
+	return c.connMu.leader
+}
+
+// isNotLeader tells whether the request was refused by a member which isn't
+// the leader, so it wasn't processed.
+func isNotLeader(err error) bool {
+	s, ok := status.FromError(err)
+	return ok && s.Code() == codes.Unavailable && s.Message() == "not leader"
+}
+
+// doRequest sends a request to the leader, retrying it at most maxRetryCount
+// times. A request refused by a follower wasn't processed, so it's sent to the
+// new leader at once. After any other error the request may have been
+// processed, so it's only sent again, after retryInterval, if it's retryable,
+// i.e. sending it twice is harmless, e.g. an AllocID retried only skips some
+// ids.
+func (c *client) doRequest(ctx context.Context, retryable bool, f func(context.Context, schedulerpb.SchedulerClient) error) error {
 	var err error
 	for i := 0; i < maxRetryCount; i++ {
+		leader := c.leaderAddr()
 		ctx1, cancel := context.WithTimeout(ctx, schedulerTimeout)
 		err = f(ctx1, c.leaderClient())
 		cancel()
@@ -262,7 +408,16 @@ func (c *client) doRequest(ctx context.Context, f func(context.Context, schedule
 			return nil
 		}
 
-		c.schedulerUpdateLeader()
+		if isNotLeader(err) {
+			if _, err1 := c.updateLeader(); err1 == nil && c.leaderAddr() != leader {
+				continue
+			}
+		} else {
+			c.schedulerUpdateLeader()
+			if !retryable {
+				return err
+			}
+		}
 		select {
 		case <-time.After(retryInterval):
 			continue
@@ -382,7 +537,7 @@ func (c *client) GetClusterID(context.Context) uint64 {
 
 func (c *client) AllocID(ctx context.Context) (uint64, error) {
 	var resp *schedulerpb.AllocIDResponse
-	err := c.doRequest(ctx, func(ctx context.Context, client schedulerpb.SchedulerClient) error {
+	err := c.doRequest(ctx, true, func(ctx context.Context, client schedulerpb.SchedulerClient) error {
 		var err1 error
 		resp, err1 = client.AllocID(ctx, &schedulerpb.AllocIDRequest{
 			Header: c.requestHeader(),
@@ -396,7 +551,7 @@ func (c *client) AllocID(ctx context.Context) (uint64, error) {
 }
 
 func (c *client) Bootstrap(ctx context.Context, store *metapb.Store) (resp *schedulerpb.BootstrapResponse, err error) {
-	err = c.doRequest(ctx, func(ctx context.Context, client schedulerpb.SchedulerClient) error {
+	err = c.doRequest(ctx, false, func(ctx context.Context, client schedulerpb.SchedulerClient) error {
 		var err1 error
 		resp, err1 = client.Bootstrap(ctx, &schedulerpb.BootstrapRequest{
 			Header: c.requestHeader(),
@@ -409,7 +564,7 @@ func (c *client) Bootstrap(ctx context.Context, store *metapb.Store) (resp *sche
 
 func (c *client) IsBootstrapped(ctx context.Context) (bool, error) {
 	var resp *schedulerpb.IsBootstrappedResponse
-	err := c.doRequest(ctx, func(ctx context.Context, client schedulerpb.SchedulerClient) error {
+	err := c.doRequest(ctx, true, func(ctx context.Context, client schedulerpb.SchedulerClient) error {
 		var err1 error
 		resp, err1 = client.IsBootstrapped(ctx, &schedulerpb.IsBootstrappedRequest{Header: c.requestHeader()})
 		return err1
@@ -425,7 +580,7 @@ func (c *client) IsBootstrapped(ctx context.Context) (bool, error) {
 
 func (c *client) PutStore(ctx context.Context, store *metapb.Store) error {
 	var resp *schedulerpb.PutStoreResponse
-	err := c.doRequest(ctx, func(ctx context.Context, client schedulerpb.SchedulerClient) error {
+	err := c.doRequest(ctx, true, func(ctx context.Context, client schedulerpb.SchedulerClient) error {
 		var err1 error
 		resp, err1 = client.PutStore(ctx, &schedulerpb.PutStoreRequest{
 			Header: c.requestHeader(),
@@ -444,7 +599,7 @@ func (c *client) PutStore(ctx context.Context, store *metapb.Store) error {
 
 func (c *client) GetStore(ctx context.Context, storeID uint64) (*metapb.Store, error) {
 	var resp *schedulerpb.GetStoreResponse
-	err := c.doRequest(ctx, func(ctx context.Context, client schedulerpb.SchedulerClient) error {
+	err := c.doRequest(ctx, true, func(ctx context.Context, client schedulerpb.SchedulerClient) error {
 		var err1 error
 		resp, err1 = client.GetStore(ctx, &schedulerpb.GetStoreRequest{
 			Header:  c.requestHeader(),
@@ -463,7 +618,7 @@ func (c *client) GetStore(ctx context.Context, storeID uint64) (*metapb.Store, e
 
 func (c *client) GetRegion(ctx context.Context, key []byte) (*metapb.Region, *metapb.Peer, error) {
 	var resp *schedulerpb.GetRegionResponse
-	err := c.doRequest(ctx, func(ctx context.Context, client schedulerpb.SchedulerClient) error {
+	err := c.doRequest(ctx, true, func(ctx context.Context, client schedulerpb.SchedulerClient) error {
 		var err1 error
 		resp, err1 = client.GetRegion(ctx, &schedulerpb.GetRegionRequest{
 			Header:    c.requestHeader(),
@@ -482,7 +637,7 @@ func (c *client) GetRegion(ctx context.Context, key []byte) (*metapb.Region, *me
 
 func (c *client) GetRegionByID(ctx context.Context, regionID uint64) (*metapb.Region, *metapb.Peer, error) {
 	var resp *schedulerpb.GetRegionResponse
-	err := c.doRequest(ctx, func(ctx context.Context, client schedulerpb.SchedulerClient) error {
+	err := c.doRequest(ctx, true, func(ctx context.Context, client schedulerpb.SchedulerClient) error {
 		var err1 error
 		resp, err1 = client.GetRegionByID(ctx, &schedulerpb.GetRegionByIDRequest{
 			Header:   c.requestHeader(),
@@ -500,7 +655,7 @@ func (c *client) GetRegionByID(ctx context.Context, regionID uint64) (*metapb.Re
 }
 
 func (c *client) AskSplit(ctx context.Context, region *metapb.Region) (resp *schedulerpb.AskSplitResponse, err error) {
-	err = c.doRequest(ctx, func(ctx context.Context, client schedulerpb.SchedulerClient) error {
+	err = c.doRequest(ctx, true, func(ctx context.Context, client schedulerpb.SchedulerClient) error {
 		var err1 error
 		resp, err1 = client.AskSplit(ctx, &schedulerpb.AskSplitRequest{
 			Header: c.requestHeader(),
@@ -517,9 +672,9 @@ func (c *client) AskSplit(ctx context.Context, region *metapb.Region) (resp *sch
 	return resp, nil
 }
 
-func (c *client) StoreHeartbeat(ctx context.Context, stats *schedulerpb.StoreStats) error {
+func (c *client) StoreHeartbeat(ctx context.Context, stats *schedulerpb.StoreStats) (*schedulerpb.StoreHeartbeatResponse, error) {
 	var resp *schedulerpb.StoreHeartbeatResponse
-	err := c.doRequest(ctx, func(ctx context.Context, client schedulerpb.SchedulerClient) error {
+	err := c.doRequest(ctx, true, func(ctx context.Context, client schedulerpb.SchedulerClient) error {
 		var err1 error
 		resp, err1 = client.StoreHeartbeat(ctx, &schedulerpb.StoreHeartbeatRequest{
 			Header: c.requestHeader(),
@@ -528,12 +683,12 @@ func (c *client) StoreHeartbeat(ctx context.Context, stats *schedulerpb.StoreSta
 		return err1
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if herr := resp.Header.GetError(); herr != nil {
-		return errors.New(herr.String())
+		return nil, errors.New(herr.String())
 	}
-	return nil
+	return resp, nil
 }
 
 func (c *client) RegionHeartbeat(request *schedulerpb.RegionHeartbeatRequest) error {
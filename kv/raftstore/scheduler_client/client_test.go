@@ -0,0 +1,135 @@
+package scheduler_client
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/schedulerpb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mockCluster is a group of scheduler members, only the leader serves requests.
+type mockCluster struct {
+	leader  uint64
+	members []*schedulerpb.Member
+	servers []*grpc.Server
+}
+
+type mockMember struct {
+	schedulerpb.SchedulerServer
+	id         uint64
+	cluster    *mockCluster
+	bootstraps int32
+}
+
+func (m *mockMember) GetMembers(context.Context, *schedulerpb.GetMembersRequest) (*schedulerpb.GetMembersResponse, error) {
+	leader := atomic.LoadUint64(&m.cluster.leader)
+	return &schedulerpb.GetMembersResponse{
+		Header:  &schedulerpb.ResponseHeader{ClusterId: 1},
+		Members: m.cluster.members,
+		Leader:  m.cluster.members[leader-1],
+	}, nil
+}
+
+func (m *mockMember) AllocID(context.Context, *schedulerpb.AllocIDRequest) (*schedulerpb.AllocIDResponse, error) {
+	if atomic.LoadUint64(&m.cluster.leader) != m.id {
+		return nil, status.Errorf(codes.Unavailable, "not leader")
+	}
+	return &schedulerpb.AllocIDResponse{Header: new(schedulerpb.ResponseHeader), Id: m.id}, nil
+}
+
+func (m *mockMember) Bootstrap(context.Context, *schedulerpb.BootstrapRequest) (*schedulerpb.BootstrapResponse, error) {
+	atomic.AddInt32(&m.bootstraps, 1)
+	return nil, status.Errorf(codes.Unknown, "lost response")
+}
+
+func (m *mockMember) RegionHeartbeat(stream schedulerpb.Scheduler_RegionHeartbeatServer) error {
+	<-stream.Context().Done()
+	return nil
+}
+
+func newMockCluster(t *testing.T, n int) (*mockCluster, []*mockMember) {
+	cluster := &mockCluster{leader: 1}
+	var members []*mockMember
+	for i := 1; i <= n; i++ {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.Nil(t, err)
+		m := &mockMember{id: uint64(i), cluster: cluster}
+		server := grpc.NewServer()
+		schedulerpb.RegisterSchedulerServer(server, m)
+		go server.Serve(l)
+		cluster.servers = append(cluster.servers, server)
+		cluster.members = append(cluster.members, &schedulerpb.Member{MemberId: uint64(i), ClientUrls: []string{"http://" + l.Addr().String()}})
+		members = append(members, m)
+	}
+	t.Cleanup(func() {
+		for _, server := range cluster.servers {
+			server.Stop()
+		}
+	})
+	return cluster, members
+}
+
+func (c *mockCluster) addrs() []string {
+	var addrs []string
+	for _, m := range c.members {
+		addrs = append(addrs, m.ClientUrls[0][len("http://"):])
+	}
+	return addrs
+}
+
+func newTestClient(t *testing.T, cluster *mockCluster) *client {
+	c, err := NewClient(cluster.addrs(), "test", "")
+	require.Nil(t, err)
+	t.Cleanup(c.Close)
+	return c.(*client)
+}
+
+func TestClientFollowsLeader(t *testing.T) {
+	cluster, _ := newMockCluster(t, 2)
+	c := newTestClient(t, cluster)
+	id, err := c.AllocID(context.Background())
+	require.Nil(t, err)
+	assert.Equal(t, uint64(1), id)
+
+	// the request refused by the old leader is sent to the new one at once
+	atomic.StoreUint64(&cluster.leader, 2)
+	start := time.Now()
+	id, err = c.AllocID(context.Background())
+	require.Nil(t, err)
+	assert.Equal(t, uint64(2), id)
+	assert.True(t, time.Since(start) < retryInterval)
+	assert.Equal(t, cluster.members[1].ClientUrls[0], c.leaderAddr())
+}
+
+func TestClientNotRetryable(t *testing.T) {
+	cluster, members := newMockCluster(t, 1)
+	c := newTestClient(t, cluster)
+	_, err := c.Bootstrap(context.Background(), &metapb.Store{Id: 1})
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&members[0].bootstraps))
+}
+
+func TestClientProbeEndpoints(t *testing.T) {
+	cluster, _ := newMockCluster(t, 2)
+	c := newTestClient(t, cluster)
+	require.Equal(t, cluster.members[0].ClientUrls[0], c.leaderAddr())
+
+	// the leader stops, the probes find the new leader
+	cluster.servers[0].Stop()
+	atomic.StoreUint64(&cluster.leader, 2)
+	c.probeEndpoints()
+	urls := c.endpoints()
+	assert.Equal(t, cluster.members[0].ClientUrls[0], urls[len(urls)-1])
+	require.Eventually(t, func() bool {
+		return c.leaderAddr() == cluster.members[1].ClientUrls[0]
+	}, 5*time.Second, 10*time.Millisecond)
+}
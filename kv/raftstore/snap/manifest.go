@@ -0,0 +1,332 @@
+package snap
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/kv/util"
+	"github.com/pingcap-incubator/tinykv/kv/util/failpoint"
+	"github.com/pingcap-incubator/tinykv/log"
+	rspb "github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
+	"github.com/pingcap/errors"
+)
+
+const manifestFileName = "MANIFEST"
+
+// Manifest lists the valid snapshots of a snapshot directory with the sizes and checksums of their files. A snapshot
+// is added once all its files are written and removed before its files are deleted, and the manifest file is
+// replaced atomically on each change, so on startup the files of the snapshots which are not listed are known to be
+// partially written or partially deleted, whatever their names are.
+//
+// All methods may be called on a nil Manifest, which means the snapshots are not tracked.
+type Manifest struct {
+	sync.Mutex
+	dir   string
+	snaps map[SnapKeyWithSending]*rspb.SnapshotMeta
+}
+
+func newManifest(dir string) *Manifest {
+	return &Manifest{
+		dir:   dir,
+		snaps: make(map[SnapKeyWithSending]*rspb.SnapshotMeta),
+	}
+}
+
+// loadManifest reads the manifest of the directory, it returns false if there is no manifest file.
+func loadManifest(dir string) (*Manifest, bool, error) {
+	m := newManifest(dir)
+	data, err := ioutil.ReadFile(filepath.Join(dir, manifestFileName))
+	if os.IsNotExist(err) {
+		return m, false, nil
+	} else if err != nil {
+		return nil, false, errors.WithStack(err)
+	}
+	manifest := new(rspb.SnapshotManifest)
+	if err := manifest.Unmarshal(data); err != nil {
+		return nil, false, errors.WithStack(err)
+	}
+	for _, entry := range manifest.Snapshots {
+		key := SnapKeyWithSending{
+			SnapKey:   SnapKey{RegionID: entry.RegionId, Term: entry.Term, Index: entry.Index},
+			IsSending: entry.IsSending,
+		}
+		m.snaps[key] = entry.Meta
+	}
+	return m, true, nil
+}
+
+func (m *Manifest) add(key SnapKeyWithSending, meta *rspb.SnapshotMeta) error {
+	if m == nil {
+		return nil
+	}
+	m.Lock()
+	defer m.Unlock()
+	m.snaps[key] = meta
+	return m.save()
+}
+
+func (m *Manifest) remove(key SnapKeyWithSending) error {
+	if m == nil {
+		return nil
+	}
+	m.Lock()
+	defer m.Unlock()
+	if _, ok := m.snaps[key]; !ok {
+		return nil
+	}
+	delete(m.snaps, key)
+	return m.save()
+}
+
+// list returns the keys of the snapshots, sorted by region, term and index, the received snapshot of a key first.
+func (m *Manifest) list() []SnapKeyWithSending {
+	if m == nil {
+		return nil
+	}
+	m.Lock()
+	defer m.Unlock()
+	keys := make([]SnapKeyWithSending, 0, len(m.snaps))
+	for key := range m.snaps {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		keyI, keyJ := &keys[i].SnapKey, &keys[j].SnapKey
+		if keyI.RegionID == keyJ.RegionID {
+			if keyI.Term == keyJ.Term {
+				if keyI.Index == keyJ.Index {
+					return !keys[i].IsSending
+				}
+				return keyI.Index < keyJ.Index
+			}
+			return keyI.Term < keyJ.Term
+		}
+		return keyI.RegionID < keyJ.RegionID
+	})
+	return keys
+}
+
+// save replaces the manifest file with the current snapshots. The new manifest is synced to a temporary file before
+// it's renamed over the old one, so a crash leaves either of them.
+func (m *Manifest) save() error {
+	manifest := &rspb.SnapshotManifest{Snapshots: make([]*rspb.SnapshotManifestEntry, 0, len(m.snaps))}
+	for key, meta := range m.snaps {
+		manifest.Snapshots = append(manifest.Snapshots, &rspb.SnapshotManifestEntry{
+			RegionId:  key.SnapKey.RegionID,
+			Term:      key.SnapKey.Term,
+			Index:     key.SnapKey.Index,
+			IsSending: key.IsSending,
+			Meta:      meta,
+		})
+	}
+	data, err := manifest.Marshal()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	path := filepath.Join(m.dir, manifestFileName)
+	tmpPath := path + tmpFileSuffix
+	f, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	_, err = f.Write(data)
+	if err == nil && !failpoint.Skipped("snapshot/fsync") {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return errors.WithStack(err)
+	}
+	if failpoint.Skipped("snapshot/fsync") {
+		return nil
+	}
+	return syncDir(m.dir)
+}
+
+// reconcile drops the snapshots whose files are missing or have the wrong size, and deletes the files in the
+// directory which don't belong to a snapshot of the manifest. It returns the total size of the snapshots.
+func (m *Manifest) reconcile() (uint64, error) {
+	m.Lock()
+	defer m.Unlock()
+	valid := map[string]bool{manifestFileName: true}
+	var totalSize uint64
+	changed := false
+	for key, meta := range m.snaps {
+		files, size, err := m.checkFiles(key, meta)
+		if err != nil {
+			log.Warnf("drop snapshot %s from the manifest: %v", key.SnapKey, err)
+			delete(m.snaps, key)
+			changed = true
+			continue
+		}
+		for _, name := range files {
+			valid[name] = true
+		}
+		totalSize += size
+	}
+	fis, err := ioutil.ReadDir(m.dir)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	for _, fi := range fis {
+		if fi.IsDir() || valid[fi.Name()] {
+			continue
+		}
+		log.Infof("delete snapshot file %s which is not in the manifest", fi.Name())
+		if err := os.Remove(filepath.Join(m.dir, fi.Name())); err != nil {
+			return 0, errors.WithStack(err)
+		}
+	}
+	if changed {
+		if err := m.save(); err != nil {
+			return 0, err
+		}
+	}
+	return totalSize, nil
+}
+
+// orphanFiles returns the names of the files in the directory which belong to neither a snapshot of the manifest nor
+// a skipped one, and weren't modified since before, so the files of a snapshot being written are kept.
+func (m *Manifest) orphanFiles(skip func(key SnapKey) bool, before time.Time) ([]string, error) {
+	if m == nil {
+		return nil, nil
+	}
+	m.Lock()
+	defer m.Unlock()
+	fis, err := ioutil.ReadDir(m.dir)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	var names []string
+	for _, fi := range fis {
+		name := fi.Name()
+		if fi.IsDir() || strings.HasPrefix(name, manifestFileName) || !fi.ModTime().Before(before) {
+			continue
+		}
+		if key, err := snapFileKey(name); err == nil {
+			if _, ok := m.snaps[key]; ok || skip(key.SnapKey) {
+				continue
+			}
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// checkFiles checks the files of a snapshot exist with the sizes in its meta, returning their names and total size.
+func (m *Manifest) checkFiles(key SnapKeyWithSending, meta *rspb.SnapshotMeta) ([]string, uint64, error) {
+	prefix := snapFilePrefix(key.SnapKey, key.IsSending)
+	files := []string{prefix + metaFileSuffix}
+	if !util.FileExists(filepath.Join(m.dir, files[0])) {
+		return nil, 0, errors.Errorf("meta file %s is missing", files[0])
+	}
+	var size uint64
+	for _, cfFile := range meta.GetCfFiles() {
+		if cfFile.GetSize_() == 0 {
+			continue
+		}
+		name := cfFileName(prefix, cfFile.GetCf())
+		if err := checkFileSize(filepath.Join(m.dir, name), cfFile.GetSize_()); err != nil {
+			return nil, 0, err
+		}
+		files = append(files, name)
+		size += cfFile.GetSize_()
+	}
+	return files, size, nil
+}
+
+// adoptSnapshots adds the snapshots of a directory without a manifest, written before the manifest was introduced.
+// A snapshot is adopted if its meta file is complete and its files exist with the sizes in the meta.
+func (m *Manifest) adoptSnapshots(sizeTrack *int64, deleter SnapshotDeleter) error {
+	m.Lock()
+	defer m.Unlock()
+	fis, err := ioutil.ReadDir(m.dir)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	for _, fi := range fis {
+		name := fi.Name()
+		if fi.IsDir() || !strings.HasSuffix(name, metaFileSuffix) {
+			continue
+		}
+		key, err := parseSnapFileName(strings.TrimSuffix(name, metaFileSuffix))
+		if err != nil {
+			log.Warnf("skip snapshot meta file %s: %v", name, err)
+			continue
+		}
+		s, err := NewSnap(m.dir, key.SnapKey, sizeTrack, key.IsSending, false, deleter)
+		if err != nil {
+			log.Warnf("skip snapshot %s: %v", key.SnapKey, err)
+			continue
+		}
+		if s.Exists() {
+			m.snaps[key] = s.MetaFile.Meta
+		}
+	}
+	return m.save()
+}
+
+// parseSnapFileName parses the key of a snapshot from the name of its files without the suffix.
+func parseSnapFileName(name string) (SnapKeyWithSending, error) {
+	var key SnapKeyWithSending
+	numberStrs := strings.Split(name, "_")
+	if len(numberStrs) != 4 {
+		return key, errors.Errorf("failed to parse file %s", name)
+	}
+	key.IsSending = numberStrs[0] == snapGenPrefix
+	var err error
+	key.SnapKey.RegionID, err = strconv.ParseUint(numberStrs[1], 10, 64)
+	if err != nil {
+		return key, errors.WithStack(err)
+	}
+	key.SnapKey.Term, err = strconv.ParseUint(numberStrs[2], 10, 64)
+	if err != nil {
+		return key, errors.WithStack(err)
+	}
+	key.SnapKey.Index, err = strconv.ParseUint(numberStrs[3], 10, 64)
+	if err != nil {
+		return key, errors.WithStack(err)
+	}
+	return key, nil
+}
+
+// snapFileKey parses the key of the snapshot a file belongs to from its name, e.g. gen_1_2_3_default.sst.
+func snapFileKey(name string) (SnapKeyWithSending, error) {
+	parts := strings.SplitN(name, "_", 5)
+	if len(parts) < 4 {
+		return SnapKeyWithSending{}, errors.Errorf("failed to parse file %s", name)
+	}
+	parts[3] = strings.SplitN(parts[3], ".", 2)[0]
+	return parseSnapFileName(strings.Join(parts[:4], "_"))
+}
+
+func snapFilePrefix(key SnapKey, isSending bool) string {
+	if isSending {
+		return fmt.Sprintf("%s_%s", snapGenPrefix, key)
+	}
+	return fmt.Sprintf("%s_%s", snapRevPrefix, key)
+}
+
+func cfFileName(prefix, cf string) string {
+	return fmt.Sprintf("%s_%s%s", prefix, cf, sstFileSuffix)
+}
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer d.Close()
+	return errors.WithStack(d.Sync())
+}
@@ -0,0 +1,158 @@
+package snap
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	rspb "github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
+	"github.com/stretchr/testify/require"
+)
+
+func buildTestSnap(t *testing.T, mgr *SnapManager, dbDir string, key SnapKey) *Snap {
+	db := openDB(t, dbDir)
+	defer db.Close()
+	fillDBData(t, db)
+	s, err := mgr.GetSnapshotForBuilding(key)
+	require.Nil(t, err)
+	snapData := &rspb.RaftSnapshotData{Region: genTestRegion(key.RegionID, 1, 1)}
+	require.Nil(t, s.Build(db.NewTransaction(false), snapData.Region, snapData, new(SnapStatistics), mgr))
+	return s.(*Snap)
+}
+
+func listIdleSnap(t *testing.T, mgr *SnapManager) []SnapKeyWithSending {
+	keys, err := mgr.ListIdleSnap()
+	require.Nil(t, err)
+	return keys
+}
+
+func TestSnapManifest(t *testing.T) {
+	dbDir, err := ioutil.TempDir("", "snapshot-db")
+	require.Nil(t, err)
+	defer os.RemoveAll(dbDir)
+	dir, err := ioutil.TempDir("", "snapshot")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	mgr := NewSnapManager(dir)
+	require.Nil(t, mgr.Init())
+	key1, key2 := SnapKey{1, 1, 2}, SnapKey{2, 1, 2}
+	s1 := buildTestSnap(t, mgr, dbDir, key1)
+	s2 := buildTestSnap(t, mgr, dbDir, key2)
+	size := mgr.GetTotalSnapSize()
+	require.Equal(t, s1.TotalSize()+s2.TotalSize(), size)
+	require.Equal(t, []SnapKeyWithSending{{key1, true}, {key2, true}}, listIdleSnap(t, mgr))
+
+	// the files of a snapshot being written and a snapshot whose meta file is written without the manifest
+	require.Nil(t, ioutil.WriteFile(filepath.Join(dir, "rev_3_1_2_default.sst.tmp"), []byte("x"), 0600))
+	require.Nil(t, ioutil.WriteFile(filepath.Join(dir, "rev_4_1_2_default.sst"), []byte("x"), 0600))
+	require.Nil(t, ioutil.WriteFile(filepath.Join(dir, "rev_4_1_2.meta"), []byte{}, 0600))
+	// a file of a listed snapshot is lost
+	for _, cfFile := range s2.CFFiles {
+		if cfFile.Size > 0 {
+			require.Nil(t, os.Remove(cfFile.Path))
+			break
+		}
+	}
+
+	mgr = NewSnapManager(dir)
+	require.Nil(t, mgr.Init())
+	require.Equal(t, []SnapKeyWithSending{{key1, true}}, listIdleSnap(t, mgr))
+	require.Equal(t, s1.TotalSize(), mgr.GetTotalSnapSize())
+	fis, err := ioutil.ReadDir(dir)
+	require.Nil(t, err)
+	names := make(map[string]bool)
+	for _, fi := range fis {
+		names[fi.Name()] = true
+	}
+	require.True(t, names[manifestFileName])
+	require.True(t, names["gen_1_1_2.meta"])
+	require.False(t, names["gen_2_1_2.meta"])
+	require.False(t, names["rev_3_1_2_default.sst.tmp"])
+	require.False(t, names["rev_4_1_2.meta"])
+
+	// a deleted snapshot is removed from the manifest
+	s, err := mgr.GetSnapshotForSending(key1)
+	require.Nil(t, err)
+	require.True(t, mgr.DeleteSnapshot(key1, s, false))
+	require.Empty(t, listIdleSnap(t, mgr))
+	require.Equal(t, int64(0), atomic.LoadInt64(mgr.snapSize))
+	mgr = NewSnapManager(dir)
+	require.Nil(t, mgr.Init())
+	require.Empty(t, listIdleSnap(t, mgr))
+}
+
+func TestSnapManifestAdopt(t *testing.T) {
+	dbDir, err := ioutil.TempDir("", "snapshot-db")
+	require.Nil(t, err)
+	defer os.RemoveAll(dbDir)
+	dir, err := ioutil.TempDir("", "snapshot")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	mgr := NewSnapManager(dir)
+	require.Nil(t, mgr.Init())
+	key := SnapKey{1, 1, 2}
+	s := buildTestSnap(t, mgr, dbDir, key)
+	// the directory of a store which doesn't keep a manifest
+	require.Nil(t, os.Remove(filepath.Join(dir, manifestFileName)))
+	require.Nil(t, ioutil.WriteFile(filepath.Join(dir, "rev_4_1_2_default.sst"), []byte("x"), 0600))
+
+	mgr = NewSnapManager(dir)
+	require.Nil(t, mgr.Init())
+	require.Equal(t, []SnapKeyWithSending{{key, true}}, listIdleSnap(t, mgr))
+	require.Equal(t, s.TotalSize(), mgr.GetTotalSnapSize())
+	_, err = os.Stat(filepath.Join(dir, "rev_4_1_2_default.sst"))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestSnapManifestOrphanFiles(t *testing.T) {
+	dbDir, err := ioutil.TempDir("", "snapshot-db")
+	require.Nil(t, err)
+	defer os.RemoveAll(dbDir)
+	dir, err := ioutil.TempDir("", "snapshot")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	mgr := NewSnapManager(dir)
+	require.Nil(t, mgr.Init())
+	key := SnapKey{1, 1, 2}
+	s := buildTestSnap(t, mgr, dbDir, key)
+	oldTime := time.Now().Add(-time.Hour)
+	require.Nil(t, os.Chtimes(s.MetaFile.Path, oldTime, oldTime))
+	writeFile := func(name string, age time.Duration) {
+		path := filepath.Join(dir, name)
+		require.Nil(t, ioutil.WriteFile(path, []byte("x"), 0600))
+		modTime := time.Now().Add(-age)
+		require.Nil(t, os.Chtimes(path, modTime, modTime))
+	}
+	// the files left by a failed transfer, the files of a snapshot being received, and a file just written
+	writeFile("rev_3_1_2_default.sst.tmp", time.Hour)
+	writeFile("rev_3_1_2.meta.tmp", time.Hour)
+	writeFile("rev_4_1_2_default.sst.tmp", time.Hour)
+	writeFile("rev_5_1_2_default.sst.tmp", 0)
+	mgr.Register(SnapKey{4, 1, 2}, SnapEntryReceiving)
+
+	modTime, err := mgr.ModTime(SnapKeyWithSending{key, true})
+	require.Nil(t, err)
+	require.Equal(t, oldTime.Unix(), modTime.Unix())
+	n, err := mgr.DeleteOrphanFiles(time.Minute)
+	require.Nil(t, err)
+	require.Equal(t, 2, n)
+	fis, err := ioutil.ReadDir(dir)
+	require.Nil(t, err)
+	names := make(map[string]bool)
+	for _, fi := range fis {
+		names[fi.Name()] = true
+	}
+	require.True(t, names[manifestFileName])
+	require.True(t, names["gen_1_1_2.meta"])
+	require.False(t, names["rev_3_1_2_default.sst.tmp"])
+	require.False(t, names["rev_3_1_2.meta.tmp"])
+	require.True(t, names["rev_4_1_2_default.sst.tmp"])
+	require.True(t, names["rev_5_1_2_default.sst.tmp"])
+	require.Equal(t, []SnapKeyWithSending{{key, true}}, listIdleSnap(t, mgr))
+}
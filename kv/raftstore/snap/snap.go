@@ -16,6 +16,7 @@ import (
 
 	"github.com/pingcap-incubator/tinykv/kv/util"
 	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/kv/util/failpoint"
 	"github.com/pingcap-incubator/tinykv/log"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
@@ -124,6 +125,8 @@ type Snapshot interface {
 	TotalSize() uint64
 	Save() error
 	Apply(option ApplyOptions) error
+	Verify() error
+	Drop()
 }
 
 // `SnapshotDeleter` is a trait for deleting snapshot.
@@ -134,6 +137,11 @@ type SnapshotDeleter interface {
 	DeleteSnapshot(key SnapKey, snapshot Snapshot, checkEntry bool) bool
 }
 
+// manifestKeeper is a SnapshotDeleter which keeps the manifest of the snapshot directory.
+type manifestKeeper interface {
+	snapManifest() *Manifest
+}
+
 func retryDeleteSnapshot(deleter SnapshotDeleter, key SnapKey, snap Snapshot) bool {
 	for i := 0; i < deleteRetryMaxTime; i++ {
 		if deleter.DeleteSnapshot(key, snap, true) {
@@ -226,6 +234,7 @@ var _ Snapshot = new(Snap)
 
 type Snap struct {
 	key         SnapKey
+	isSending   bool
 	displayPath string
 	CFFiles     []*CFFile
 	cfIndex     int
@@ -233,6 +242,8 @@ type Snap struct {
 	MetaFile     *MetaFile
 	SizeTrack    *int64
 	holdTmpFiles bool
+	// the manifest of the directory, the snapshot is added once saved and removed when deleted
+	manifest *Manifest
 }
 
 func NewSnap(dir string, key SnapKey, sizeTrack *int64, isSending, toBuild bool,
@@ -243,18 +254,11 @@ func NewSnap(dir string, key SnapKey, sizeTrack *int64, isSending, toBuild bool,
 			return nil, errors.WithStack(err)
 		}
 	}
-	var snapPrefix string
-	if isSending {
-		snapPrefix = snapGenPrefix
-	} else {
-		snapPrefix = snapRevPrefix
-	}
-	prefix := fmt.Sprintf("%s_%s", snapPrefix, key)
+	prefix := snapFilePrefix(key, isSending)
 	displayPath := getDisplayPath(dir, prefix)
 	cfFiles := make([]*CFFile, 0, len(engine_util.CFs))
 	for _, cf := range engine_util.CFs {
-		fileName := fmt.Sprintf("%s_%s%s", prefix, cf, sstFileSuffix)
-		path := filepath.Join(dir, fileName)
+		path := filepath.Join(dir, cfFileName(prefix, cf))
 		tmpPath := path + tmpFileSuffix
 		cfFile := &CFFile{
 			CF:      cf,
@@ -272,11 +276,15 @@ func NewSnap(dir string, key SnapKey, sizeTrack *int64, isSending, toBuild bool,
 	}
 	s := &Snap{
 		key:         key,
+		isSending:   isSending,
 		displayPath: displayPath,
 		CFFiles:     cfFiles,
 		MetaFile:    metaFile,
 		SizeTrack:   sizeTrack,
 	}
+	if keeper, ok := deleter.(manifestKeeper); ok {
+		s.manifest = keeper.snapManifest()
+	}
 
 	// load snapshot meta if meta file exists.
 	if util.FileExists(metaFile.Path) {
@@ -561,6 +569,10 @@ func (s *Snap) Build(dbSnap *badger.Txn, region *metapb.Region, snapData *rspb.R
 	if err != nil {
 		return err
 	}
+	err = s.manifest.add(s.manifestKey(), s.MetaFile.Meta)
+	if err != nil {
+		return err
+	}
 	totalSize := s.TotalSize()
 	stat.Size = totalSize
 	// set snapshot meta data
@@ -584,6 +596,10 @@ func (s *Snap) Exists() bool {
 
 func (s *Snap) Delete() {
 	log.Debugf("deleting %s", s.Path())
+	// removed from the manifest first, so the files left by a crash are deleted on restart
+	if err := s.manifest.remove(s.manifestKey()); err != nil {
+		panic(err)
+	}
 	for _, cfFile := range s.CFFiles {
 		if s.holdTmpFiles {
 			_, err := util.DeleteFileIfExists(cfFile.TmpPath)
@@ -611,6 +627,10 @@ func (s *Snap) Delete() {
 	}
 }
 
+func (s *Snap) manifestKey() SnapKeyWithSending {
+	return SnapKeyWithSending{SnapKey: s.key, IsSending: s.isSending}
+}
+
 func (s *Snap) Meta() (os.FileInfo, error) {
 	fi, err := os.Stat(s.MetaFile.Path)
 	if err != nil {
@@ -658,15 +678,31 @@ func (s *Snap) Save() error {
 	if err != nil {
 		return errors.WithStack(err)
 	}
-	err = s.MetaFile.File.Sync()
-	if err != nil {
-		return errors.WithStack(err)
+	if !failpoint.Skipped("snapshot/fsync") {
+		err = s.MetaFile.File.Sync()
+		if err != nil {
+			return errors.WithStack(err)
+		}
 	}
 	err = os.Rename(s.MetaFile.TmpPath, s.MetaFile.Path)
 	if err != nil {
 		return errors.WithStack(err)
 	}
 	s.holdTmpFiles = false
+	return s.manifest.add(s.manifestKey(), s.MetaFile.Meta)
+}
+
+// Verify checks the size and the checksum of each cf file against the snapshot meta, so a snapshot corrupted on the
+// disk after it's received isn't applied.
+func (s *Snap) Verify() error {
+	for _, cfFile := range s.CFFiles {
+		if cfFile.Size == 0 {
+			continue
+		}
+		if err := checkFileSizeAndChecksum(cfFile.Path, cfFile.Size, cfFile.Checksum); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -1,13 +1,10 @@
 package snap
 
 import (
-	"io/ioutil"
 	"math"
 	"os"
 	"path/filepath"
 	"sort"
-	"strconv"
-	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -43,6 +40,17 @@ func (e SnapEntry) String() string {
 type SnapStats struct {
 	ReceivingCount int
 	SendingCount   int
+
+	// The snapshots in each stage and their total size, a snapshot in several stages is counted in each of them.
+	GeneratingCount int
+	ApplyingCount   int
+	SendingBytes    uint64
+	ReceivingBytes  uint64
+	ApplyingBytes   uint64
+
+	// Total bytes of the snapshot chunks sent and received since the manager is built.
+	SentBytes     uint64
+	ReceivedBytes uint64
 }
 
 type SnapManager struct {
@@ -50,13 +58,23 @@ type SnapManager struct {
 	snapSize     *int64
 	registryLock sync.RWMutex
 	registry     map[SnapKey][]SnapEntry
+	// sizes of the registered snapshots, set once they are known
+	sizes map[SnapKey]uint64
+	// the valid snapshots of the directory, loaded by Init
+	manifest     *Manifest
 	MaxTotalSize uint64
+	// see SnapStats
+	sentBytes     uint64
+	receivedBytes uint64
 }
 
 func NewSnapManager(path string) *SnapManager {
 	return new(SnapManagerBuilder).Build(path)
 }
 
+// Init loads the manifest of the snapshot directory and reconciles the directory with it: the snapshots whose files
+// are broken are dropped from the manifest, and the files which don't belong to a snapshot of the manifest, left by
+// a crash while a snapshot is written or deleted, are deleted.
 func (sm *SnapManager) Init() error {
 	fi, err := os.Stat(sm.base)
 	if os.IsNotExist(err) {
@@ -64,68 +82,34 @@ func (sm *SnapManager) Init() error {
 		if err != nil {
 			return errors.WithStack(err)
 		}
-		return nil
 	} else if err != nil {
 		return errors.WithStack(err)
-	}
-	if !fi.IsDir() {
+	} else if !fi.IsDir() {
 		return errors.Errorf("%s should be a directory", sm.base)
 	}
-	fis, err := ioutil.ReadDir(sm.base)
+	manifest, exists, err := loadManifest(sm.base)
 	if err != nil {
-		return errors.WithStack(err)
+		return err
 	}
-	for _, fi := range fis {
-		if !fi.IsDir() {
-			name := fi.Name()
-			if strings.HasSuffix(name, tmpFileSuffix) {
-				err = os.Remove(filepath.Join(sm.base, name))
-				if err != nil {
-					return errors.WithStack(err)
-				}
-			} else if strings.HasSuffix(name, sstFileSuffix) {
-				atomic.AddInt64(sm.snapSize, fi.Size())
-			}
+	sm.manifest = manifest
+	if !exists {
+		if err := manifest.adoptSnapshots(new(int64), sm); err != nil {
+			return err
 		}
 	}
+	size, err := manifest.reconcile()
+	if err != nil {
+		return err
+	}
+	atomic.StoreInt64(sm.snapSize, int64(size))
 	return nil
 }
 
+// ListIdleSnap returns the snapshots of the manifest which are not registered.
 func (sm *SnapManager) ListIdleSnap() ([]SnapKeyWithSending, error) {
-	fis, err := ioutil.ReadDir(sm.base)
-	if err != nil {
-		return nil, errors.WithStack(err)
-	}
-	results := make([]SnapKeyWithSending, 0, len(fis))
-	for _, fi := range fis {
-		if fi.IsDir() {
-			continue
-		}
-		name := fi.Name()
-		if !strings.HasSuffix(name, metaFileSuffix) {
-			continue
-		}
-		name = name[:len(name)-len(metaFileSuffix)]
-		var key SnapKeyWithSending
-		if strings.HasPrefix(name, snapGenPrefix) {
-			key.IsSending = true
-		}
-		numberStrs := strings.Split(name, "_")
-		if len(numberStrs) != 4 {
-			return nil, errors.Errorf("failed to parse file %s", name)
-		}
-		key.SnapKey.RegionID, err = strconv.ParseUint(numberStrs[1], 10, 64)
-		if err != nil {
-			return nil, errors.WithStack(err)
-		}
-		key.SnapKey.Term, err = strconv.ParseUint(numberStrs[2], 10, 64)
-		if err != nil {
-			return nil, errors.WithStack(err)
-		}
-		key.SnapKey.Index, err = strconv.ParseUint(numberStrs[3], 10, 64)
-		if err != nil {
-			return nil, errors.WithStack(err)
-		}
+	keys := sm.manifest.list()
+	results := make([]SnapKeyWithSending, 0, len(keys))
+	for _, key := range keys {
 		sm.registryLock.RLock()
 		_, ok := sm.registry[key.SnapKey]
 		sm.registryLock.RUnlock()
@@ -135,23 +119,39 @@ func (sm *SnapManager) ListIdleSnap() ([]SnapKeyWithSending, error) {
 		}
 		results = append(results, key)
 	}
-	sort.Slice(results, func(i, j int) bool {
-		keyI := &results[i].SnapKey
-		keyJ := &results[j].SnapKey
-		if keyI.RegionID == keyJ.RegionID {
-			if keyI.Term == keyJ.Term {
-				if keyI.Index == keyJ.Index {
-					return !results[i].IsSending
-				}
-				return keyI.Index < keyJ.Index
-			}
-			return keyI.Term < keyJ.Term
-		}
-		return keyI.RegionID < keyJ.RegionID
-	})
 	return results, nil
 }
 
+// DeleteOrphanFiles deletes the files of the directory which belong to neither a snapshot of the manifest nor a
+// registered one, e.g. the files left by a transfer which failed before dropping them, once they aren't modified for
+// the grace period. It returns the number of files deleted.
+func (sm *SnapManager) DeleteOrphanFiles(grace time.Duration) (int, error) {
+	names, err := sm.manifest.orphanFiles(sm.HasRegistered, time.Now().Add(-grace))
+	if err != nil {
+		return 0, err
+	}
+	for i, name := range names {
+		log.Infof("delete orphan snapshot file %s", name)
+		if err := os.Remove(filepath.Join(sm.base, name)); err != nil && !os.IsNotExist(err) {
+			return i, errors.WithStack(err)
+		}
+	}
+	return len(names), nil
+}
+
+// ModTime returns the time the snapshot was written, the modification time of its meta file.
+func (sm *SnapManager) ModTime(key SnapKeyWithSending) (time.Time, error) {
+	fi, err := os.Stat(filepath.Join(sm.base, snapFilePrefix(key.SnapKey, key.IsSending)+metaFileSuffix))
+	if err != nil {
+		return time.Time{}, errors.WithStack(err)
+	}
+	return fi.ModTime(), nil
+}
+
+func (sm *SnapManager) snapManifest() *Manifest {
+	return sm.manifest
+}
+
 func (sm *SnapManager) HasRegistered(key SnapKey) bool {
 	sm.registryLock.RLock()
 	_, ok := sm.registry[key]
@@ -272,6 +272,7 @@ func (sm *SnapManager) Deregister(key SnapKey, entry SnapEntry) {
 				sm.registry[key] = entries
 			} else {
 				delete(sm.registry, key)
+				delete(sm.sizes, key)
 			}
 			return
 		}
@@ -279,28 +280,60 @@ func (sm *SnapManager) Deregister(key SnapKey, entry SnapEntry) {
 	log.Warnf("stale deregister key:%s, entry:%s", key, entry)
 }
 
+// SetSnapSize records the size of a registered snapshot, for the in-flight snapshot stats. It's forgotten when the
+// snapshot is deregistered from all its entries.
+func (sm *SnapManager) SetSnapSize(key SnapKey, size uint64) {
+	sm.registryLock.Lock()
+	defer sm.registryLock.Unlock()
+	if _, ok := sm.registry[key]; ok {
+		sm.sizes[key] = size
+	}
+}
+
+// AddSentBytes counts the bytes of a snapshot chunk sent.
+func (sm *SnapManager) AddSentBytes(n uint64) {
+	atomic.AddUint64(&sm.sentBytes, n)
+}
+
+// AddReceivedBytes counts the bytes of a snapshot chunk received.
+func (sm *SnapManager) AddReceivedBytes(n uint64) {
+	atomic.AddUint64(&sm.receivedBytes, n)
+}
+
 func (sm *SnapManager) Stats() SnapStats {
 	sm.registryLock.RLock()
 	defer sm.registryLock.RUnlock()
-	var sendingCount, receivingCount int
-	for _, entries := range sm.registry {
+	var stats SnapStats
+	stats.SentBytes = atomic.LoadUint64(&sm.sentBytes)
+	stats.ReceivedBytes = atomic.LoadUint64(&sm.receivedBytes)
+	for key, entries := range sm.registry {
 		var isSending, isReceiving bool
+		size := sm.sizes[key]
 		for _, entry := range entries {
 			switch entry {
-			case SnapEntryGenerating, SnapEntrySending:
+			case SnapEntryGenerating:
+				isSending = true
+				stats.GeneratingCount++
+			case SnapEntrySending:
 				isSending = true
-			case SnapEntryReceiving, SnapEntryApplying:
+				stats.SendingBytes += size
+			case SnapEntryReceiving:
+				isReceiving = true
+				stats.ReceivingBytes += size
+			case SnapEntryApplying:
 				isReceiving = true
+				stats.ApplyingCount++
+				stats.ApplyingBytes += size
 			}
 		}
 		if isSending {
-			sendingCount++
+			stats.SendingCount++
 		}
 		if isReceiving {
-			receivingCount++
+			stats.ReceivingCount++
 		}
 	}
-	return SnapStats{SendingCount: sendingCount, ReceivingCount: receivingCount}
+	return stats
 }
 
 func (sm *SnapManager) DeleteSnapshot(key SnapKey, snapshot Snapshot, checkEntry bool) bool {
@@ -340,6 +373,7 @@ func (smb *SnapManagerBuilder) Build(path string) *SnapManager {
 		base:         path,
 		snapSize:     new(int64),
 		registry:     map[SnapKey][]SnapEntry{},
+		sizes:        map[SnapKey]uint64{},
 		MaxTotalSize: maxTotalSize,
 	}
 }
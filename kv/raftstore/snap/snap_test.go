@@ -47,12 +47,12 @@ func openDB(t *testing.T, dir string) *badger.DB {
 }
 
 func fillDBData(t *testing.T, db *badger.DB) {
-	// write some data for multiple cfs.
+	// write some data for every cf, a snapshot carries all of them.
 	wb := new(engine_util.WriteBatch)
 	value := make([]byte, 32)
-	wb.SetCF(engine_util.CfDefault, snapTestKey, value)
-	wb.SetCF(engine_util.CfWrite, snapTestKey, value)
-	wb.SetCF(engine_util.CfLock, snapTestKey, value)
+	for _, cf := range engine_util.CFs {
+		wb.SetCF(cf, snapTestKey, value)
+	}
 	err := wb.WriteToDB(db)
 	require.Nil(t, err)
 }
@@ -135,6 +135,37 @@ func TestSnapDisplayPath(t *testing.T) {
 	assert.NotEqual(t, displayPath, "")
 }
 
+func TestSnapManagerStats(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	mgr := NewSnapManager(dir)
+	key1, key2 := SnapKey{1, 1, 2}, SnapKey{2, 1, 2}
+	mgr.Register(key1, SnapEntryGenerating)
+	mgr.Register(key1, SnapEntrySending)
+	mgr.SetSnapSize(key1, 100)
+	mgr.Register(key2, SnapEntryApplying)
+	mgr.SetSnapSize(key2, 10)
+	// the size of an unregistered snapshot is ignored
+	mgr.SetSnapSize(SnapKey{3, 1, 2}, 1000)
+
+	stats := mgr.Stats()
+	assert.Equal(t, SnapStats{
+		SendingCount:    1,
+		ReceivingCount:  1,
+		GeneratingCount: 1,
+		ApplyingCount:   1,
+		SendingBytes:    100,
+		ApplyingBytes:   10,
+	}, stats)
+
+	mgr.Deregister(key1, SnapEntryGenerating)
+	mgr.Deregister(key1, SnapEntrySending)
+	mgr.Deregister(key2, SnapEntryApplying)
+	assert.Equal(t, SnapStats{}, mgr.Stats())
+	assert.Empty(t, mgr.sizes)
+}
+
 func TestSnapFile(t *testing.T) {
 	doTestSnapFile(t, true)
 	doTestSnapFile(t, false)
@@ -176,9 +207,8 @@ func doTestSnapFile(t *testing.T, dbHasData bool) {
 	assert.Equal(t, int64(totalSize), size)
 	assert.Equal(t, int64(stat.Size), size)
 	if dbHasData {
-		assert.Equal(t, 3, getKVCount(t, db))
-		// stat.KVCount is 5 because there are two extra default cf value.
-		assert.Equal(t, 3, stat.KVCount)
+		assert.Equal(t, len(engine_util.CFs), getKVCount(t, db))
+		assert.Equal(t, len(engine_util.CFs), stat.KVCount)
 	}
 
 	// Ensure this snapshot could be read for sending.
@@ -239,3 +269,56 @@ func doTestSnapFile(t *testing.T, dbHasData bool) {
 		assertEqDB(t, db, dstDB)
 	}
 }
+
+func TestSnapVerify(t *testing.T) {
+	region := genTestRegion(1, 1, 1)
+	dir, err := ioutil.TempDir("", "snapshot")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	db := openDB(t, dir)
+	fillDBData(t, db)
+
+	snapDir, err := ioutil.TempDir("", "snapshot")
+	require.Nil(t, err)
+	defer os.RemoveAll(snapDir)
+	key := SnapKey{RegionID: 1, Term: 1, Index: 1}
+	sizeTrack := new(int64)
+	deleter := &dummyDeleter{}
+	s1, err := NewSnapForBuilding(snapDir, key, sizeTrack, deleter)
+	require.Nil(t, err)
+	snapData := &rspb.RaftSnapshotData{Region: region}
+	require.Nil(t, s1.Build(db.NewTransaction(false), region, snapData, new(SnapStatistics), deleter))
+	s2, err := NewSnapForSending(snapDir, key, sizeTrack, deleter)
+	require.Nil(t, err)
+
+	dstDir, err := ioutil.TempDir("", "snapshot")
+	require.Nil(t, err)
+	defer os.RemoveAll(dstDir)
+	s3, err := NewSnapForReceiving(dstDir, key, snapData.Meta, sizeTrack, deleter)
+	require.Nil(t, err)
+	_, err = io.Copy(s3, s2)
+	require.Nil(t, err)
+	require.Nil(t, s3.Save())
+
+	s4, err := NewSnapForApplying(dstDir, key, sizeTrack, deleter)
+	require.Nil(t, err)
+	require.Nil(t, s4.Verify())
+
+	// corrupt a byte of the first non-empty cf file
+	for _, cfFile := range s4.CFFiles {
+		if cfFile.Size == 0 {
+			continue
+		}
+		f, err := os.OpenFile(cfFile.Path, os.O_RDWR, 0600)
+		require.Nil(t, err)
+		b := make([]byte, 1)
+		_, err = f.ReadAt(b, 0)
+		require.Nil(t, err)
+		b[0] ^= 0xff
+		_, err = f.WriteAt(b, 0)
+		require.Nil(t, err)
+		require.Nil(t, f.Close())
+		break
+	}
+	assert.NotNil(t, s4.Verify())
+}
@@ -0,0 +1,89 @@
+package raftstore
+
+import (
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/kv/config"
+	"github.com/pingcap-incubator/tinykv/log"
+)
+
+// snapBackoff backs off the snapshots a leader sends to the followers which need another one before catching up with
+// the last, e.g. a follower which keeps failing to apply them, so the leader doesn't regenerate large snapshots in a
+// tight loop. Each snapshot sent to a follower doubles the time until the next one may be generated for it, the
+// backoff of a follower is reset once it catches up. See config.SnapResendBackoff.
+//
+// A nil snapBackoff never backs off.
+type snapBackoff struct {
+	base       time.Duration
+	max        time.Duration
+	alarmCount int
+	followers  map[uint64]*followerSnapBackoff
+}
+
+type followerSnapBackoff struct {
+	// the snapshots sent to the follower since it last caught up
+	sent int
+	// no snapshot is generated for the follower before it
+	next time.Time
+}
+
+func newSnapBackoff(cfg *config.Config) *snapBackoff {
+	if cfg.SnapResendBackoff == 0 {
+		return nil
+	}
+	return &snapBackoff{
+		base:       cfg.SnapResendBackoff,
+		max:        cfg.SnapResendMaxBackoff,
+		alarmCount: cfg.SnapResendAlarmCount,
+		followers:  make(map[uint64]*followerSnapBackoff),
+	}
+}
+
+// onSent records a snapshot sent to the follower at now.
+func (b *snapBackoff) onSent(tag string, to uint64, now time.Time) {
+	if b == nil {
+		return
+	}
+	f := b.followers[to]
+	if f == nil {
+		f = new(followerSnapBackoff)
+		b.followers[to] = f
+	}
+	f.sent++
+	backoff := b.max
+	if shift := uint(f.sent - 1); shift < 32 && b.base<<shift < b.max {
+		backoff = b.base << shift
+	}
+	f.next = now.Add(backoff)
+	if b.alarmCount > 0 && f.sent >= b.alarmCount {
+		log.Errorf("%v ALARM: peer %d is sent %d snapshots in a row without catching up, the next one is backed off for %v",
+			tag, to, f.sent, backoff)
+	}
+}
+
+// onCaughtUp resets the backoff of the follower, which no longer needs a snapshot.
+func (b *snapBackoff) onCaughtUp(id uint64) {
+	if b == nil {
+		return
+	}
+	delete(b.followers, id)
+}
+
+// until returns the time before which no snapshot should be generated for the lagging followers, it's zero if any of
+// them may be sent one by now.
+func (b *snapBackoff) until(lagging []uint64, now time.Time) time.Time {
+	if b == nil || len(lagging) == 0 {
+		return time.Time{}
+	}
+	var until time.Time
+	for _, id := range lagging {
+		f := b.followers[id]
+		if f == nil || !now.Before(f.next) {
+			return time.Time{}
+		}
+		if until.IsZero() || f.next.Before(until) {
+			until = f.next
+		}
+	}
+	return until
+}
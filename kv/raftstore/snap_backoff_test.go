@@ -0,0 +1,38 @@
+package raftstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/kv/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapBackoff(t *testing.T) {
+	require.Nil(t, newSnapBackoff(config.NewTestConfig()))
+
+	cfg := config.NewDefaultConfig()
+	cfg.SnapResendBackoff = time.Second
+	cfg.SnapResendMaxBackoff = 3 * time.Second
+	b := newSnapBackoff(cfg)
+	now := time.Now()
+	require.True(t, b.until([]uint64{2}, now).IsZero())
+
+	// the backoff doubles with each snapshot up to the max
+	b.onSent("test", 2, now)
+	require.Equal(t, now.Add(time.Second), b.until([]uint64{2}, now))
+	b.onSent("test", 2, now)
+	require.Equal(t, now.Add(2*time.Second), b.until([]uint64{2}, now))
+	b.onSent("test", 2, now)
+	require.Equal(t, now.Add(3*time.Second), b.until([]uint64{2}, now))
+	require.True(t, b.until([]uint64{2}, now.Add(3*time.Second)).IsZero())
+
+	// a lagging follower which isn't backed off may be sent a snapshot at once
+	require.True(t, b.until([]uint64{2, 3}, now).IsZero())
+	b.onSent("test", 3, now)
+	require.Equal(t, now.Add(time.Second), b.until([]uint64{2, 3}, now))
+
+	b.onCaughtUp(2)
+	b.onSent("test", 2, now)
+	require.Equal(t, now.Add(time.Second), b.until([]uint64{2}, now))
+}
@@ -2,13 +2,14 @@ package raftstore
 
 import (
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/Connor1996/badger"
 	"github.com/pingcap-incubator/tinykv/kv/config"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/message"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/meta"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/runner"
-	"github.com/pingcap-incubator/tinykv/kv/raftstore/snap"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
 	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
 	"github.com/pingcap-incubator/tinykv/log"
@@ -23,12 +24,18 @@ type StoreTick int
 const (
 	StoreTickSchedulerStoreHeartbeat StoreTick = 1
 	StoreTickSnapGC                  StoreTick = 2
+	StoreTickCheckpointGC            StoreTick = 3
 )
 
 type storeState struct {
 	id       uint64
 	receiver <-chan message.Msg
 	ticker   *ticker
+
+	// the snapshot bytes sent and received as of the previous store heartbeat, to report the throughput since
+	lastHeartbeat     time.Time
+	lastSnapSentBytes uint64
+	lastSnapRecvBytes uint64
 }
 
 func newStoreState(cfg *config.Config) (chan<- message.Msg, *storeState) {
@@ -72,6 +79,8 @@ func (d *storeWorker) onTick(tick StoreTick) {
 		d.onSchedulerStoreHeartbeatTick()
 	case StoreTickSnapGC:
 		d.onSnapMgrGC()
+	case StoreTickCheckpointGC:
+		d.onCheckpointGC()
 	}
 }
 
@@ -85,6 +94,14 @@ func (d *storeWorker) handleMsg(msg message.Msg) {
 		d.onTick(msg.Data.(StoreTick))
 	case message.MsgTypeStoreStart:
 		d.start(msg.Data.(*metapb.Store))
+	case message.MsgTypeStoreCreatePeer:
+		create := msg.Data.(*message.MsgCreatePeer)
+		if err := d.onCreatePeer(create.Region, create.Peer); err != nil {
+			log.Warnf("create peer %s of region %d failed storeID %d, %v", create.Peer, create.Region.GetId(), d.id, err)
+			create.Callback.Done(ErrResp(err))
+			return
+		}
+		create.Callback.Done(newCmdResp())
 	}
 }
 
@@ -92,6 +109,7 @@ func (d *storeWorker) start(store *metapb.Store) {
 	d.id = store.Id
 	d.ticker.scheduleStore(StoreTickSchedulerStoreHeartbeat)
 	d.ticker.scheduleStore(StoreTickSnapGC)
+	d.ticker.scheduleStore(StoreTickCheckpointGC)
 }
 
 /// Checks if the message is targeting a stale peer.
@@ -151,7 +169,16 @@ func (d *storeWorker) checkMsg(msg *rspb.RaftMessage) (bool, error) {
 
 func (d *storeWorker) onRaftMessage(msg *rspb.RaftMessage) error {
 	regionID := msg.RegionId
-	if err := d.ctx.router.send(regionID, message.Msg{Type: message.MsgTypeRaftMessage, Data: msg}); err == nil {
+	if err := d.ctx.router.sendRaftMessage(regionID, msg); err == nil {
+		return nil
+	}
+	if msg.SnapshotDelegation != nil {
+		// The delegated peer doesn't exist on this store, the leader will
+		// fall back to sending the snapshot by itself.
+		return nil
+	}
+	if msg.CheckpointCatchUp != nil {
+		// The peer waiting for the checkpoint is gone.
 		return nil
 	}
 	log.Debugf("handle raft message. from_peer:%d, to_peer:%d, store:%d, region:%d, msg:%+v",
@@ -184,7 +211,7 @@ func (d *storeWorker) onRaftMessage(msg *rspb.RaftMessage) error {
 	if !created {
 		return nil
 	}
-	_ = d.ctx.router.send(regionID, message.Msg{Type: message.MsgTypeRaftMessage, Data: msg})
+	_ = d.ctx.router.sendRaftMessage(regionID, msg)
 	return nil
 }
 
@@ -205,6 +232,10 @@ func (d *storeWorker) maybeCreatePeer(regionID uint64, msg *rspb.RaftMessage) (b
 		log.Debugf("target peer %s doesn't exist", msg.ToPeer)
 		return false, nil
 	}
+	if d.ctx.cfg.ExplicitPeerCreation {
+		log.Debugf("target peer %s doesn't exist and isn't created by messages", msg.ToPeer)
+		return false, nil
+	}
 
 	for _, region := range meta.getOverlapRegions(&metapb.Region{
 		StartKey: msg.StartKey,
@@ -222,6 +253,7 @@ func (d *storeWorker) maybeCreatePeer(regionID uint64, msg *rspb.RaftMessage) (b
 	if err != nil {
 		return false, err
 	}
+	peer.checkpoint = loadRegionCheckpoint(d.ctx.engine.Kv, regionID)
 	// following snapshot may overlap, should insert into regionRanges after
 	// snapshot is applied.
 	meta.regions[regionID] = peer.Region()
@@ -230,6 +262,65 @@ func (d *storeWorker) maybeCreatePeer(regionID uint64, msg *rspb.RaftMessage) (b
 	return true, nil
 }
 
+// onCreatePeer creates an uninitialized peer of the region on the store, as
+// asked by the scheduler for a new replica. The peer waits for a snapshot from
+// the leader to be initialized, and it's destroyed if none is applied within
+// PeerBootstrapTimeout. Creating a peer which exists already succeeds.
+func (d *storeWorker) onCreatePeer(region *metapb.Region, metaPeer *metapb.Peer) error {
+	regionID := region.GetId()
+	if metaPeer.GetStoreId() != d.ctx.store.Id {
+		return &util.ErrStoreNotMatch{RequestStoreId: metaPeer.GetStoreId(), ActualStoreId: d.ctx.store.Id}
+	}
+	if util.FindPeer(region, d.ctx.store.Id).GetId() != metaPeer.GetId() {
+		return errors.Errorf("peer %s is not in region %s", metaPeer, region)
+	}
+	storeMeta := d.ctx.storeMeta
+	storeMeta.Lock()
+	defer storeMeta.Unlock()
+	if _, ok := storeMeta.regions[regionID]; ok {
+		if local := d.ctx.router.get(regionID); local != nil && local.peer.PeerId() == metaPeer.GetId() {
+			return nil
+		}
+		return errors.Errorf("region %d has another peer on store %d", regionID, d.ctx.store.Id)
+	}
+
+	localState, err := meta.GetRegionLocalState(d.ctx.engine.Kv, regionID)
+	if err != nil && err != badger.ErrKeyNotFound {
+		return err
+	}
+	if err == nil {
+		if localState.State != rspb.PeerState_Tombstone {
+			return errors.Errorf("region %d not exists but not tombstone: %s", regionID, localState)
+		}
+		if !util.IsEpochStale(localState.Region.RegionEpoch, region.RegionEpoch) {
+			return errors.Errorf("region %d is destroyed at epoch %s, not older than %s",
+				regionID, localState.Region.RegionEpoch, region.RegionEpoch)
+		}
+	}
+	for _, overlap := range storeMeta.getOverlapRegions(region) {
+		return errors.Errorf("region %s overlaps with region %s", region, overlap)
+	}
+
+	peer, err := replicatePeer(
+		d.ctx.store.Id, d.ctx.cfg, d.ctx.regionTaskSender, d.ctx.engine, regionID, metaPeer)
+	if err != nil {
+		return err
+	}
+	peer.awaitingSnapshot = true
+	if timeout := d.ctx.cfg.PeerBootstrapTimeout; timeout > 0 {
+		peer.bootstrapDeadline = time.Now().Add(timeout)
+	}
+	atomic.AddInt32(&d.ctx.bootstrappingPeers, 1)
+	peer.checkpoint = loadRegionCheckpoint(d.ctx.engine.Kv, regionID)
+	// following snapshot may overlap, should insert into regionRanges after
+	// snapshot is applied.
+	storeMeta.regions[regionID] = peer.Region()
+	d.ctx.router.register(peer)
+	_ = d.ctx.router.send(regionID, message.Msg{Type: message.MsgTypeStart})
+	log.Infof("%s is created and waits for a snapshot", peer.Tag)
+	return nil
+}
+
 func (d *storeWorker) storeHeartbeatScheduler() {
 	stats := new(schedulerpb.StoreStats)
 	stats.StoreId = d.ctx.store.Id
@@ -237,10 +328,29 @@ func (d *storeWorker) storeHeartbeatScheduler() {
 	meta.RLock()
 	stats.RegionCount = uint32(len(meta.regions))
 	meta.RUnlock()
+	stats.SlowLeaderTransfers = atomic.SwapUint32(&d.ctx.slowLeaderTransfers, 0)
+	stats.BootstrappingPeerCount = uint32(atomic.LoadInt32(&d.ctx.bootstrappingPeers))
+	snapStats := d.ctx.snapMgr.Stats()
+	stats.SendingSnapCount = uint32(snapStats.SendingCount)
+	stats.ReceivingSnapCount = uint32(snapStats.ReceivingCount)
+	stats.GeneratingSnapCount = uint32(snapStats.GeneratingCount)
+	stats.ApplyingSnapCount = uint32(snapStats.ApplyingCount)
+	stats.SendingSnapBytes = snapStats.SendingBytes
+	stats.ReceivingSnapBytes = snapStats.ReceivingBytes
+	stats.ApplyingSnapBytes = snapStats.ApplyingBytes
+	now := time.Now()
+	if elapsed := now.Sub(d.lastHeartbeat).Seconds(); !d.lastHeartbeat.IsZero() && elapsed > 0 {
+		stats.SnapSendThroughput = uint64(float64(snapStats.SentBytes-d.lastSnapSentBytes) / elapsed)
+		stats.SnapRecvThroughput = uint64(float64(snapStats.ReceivedBytes-d.lastSnapRecvBytes) / elapsed)
+	}
+	d.lastHeartbeat, d.lastSnapSentBytes, d.lastSnapRecvBytes = now, snapStats.SentBytes, snapStats.ReceivedBytes
 	d.ctx.schedulerTaskSender <- &runner.SchedulerStoreHeartbeatTask{
-		Stats:  stats,
-		Engine: d.ctx.engine.Kv,
-		Path:   d.ctx.engine.KvPath,
+		Stats:    stats,
+		Engine:   d.ctx.engine.Kv,
+		Path:     d.ctx.engine.KvPath,
+		DataDirs: d.ctx.cfg.DataDirs(),
+
+		CompactionPendingL0Tables: d.ctx.cfg.CompactionPendingL0Tables,
 	}
 }
 
@@ -249,68 +359,7 @@ func (d *storeWorker) onSchedulerStoreHeartbeatTick() {
 	d.ticker.scheduleStore(StoreTickSchedulerStoreHeartbeat)
 }
 
-func (d *storeWorker) handleSnapMgrGC() error {
-	mgr := d.ctx.snapMgr
-	snapKeys, err := mgr.ListIdleSnap()
-	if err != nil {
-		return err
-	}
-	if len(snapKeys) == 0 {
-		return nil
-	}
-	var lastRegionID uint64
-	var keys []snap.SnapKeyWithSending
-	for _, pair := range snapKeys {
-		key := pair.SnapKey
-		if lastRegionID == key.RegionID {
-			keys = append(keys, pair)
-			continue
-		}
-		if len(keys) > 0 {
-			err = d.scheduleGCSnap(lastRegionID, keys)
-			if err != nil {
-				return err
-			}
-			keys = nil
-		}
-		lastRegionID = key.RegionID
-		keys = append(keys, pair)
-	}
-	if len(keys) > 0 {
-		return d.scheduleGCSnap(lastRegionID, keys)
-	}
-	return nil
-}
-
-func (d *storeWorker) scheduleGCSnap(regionID uint64, keys []snap.SnapKeyWithSending) error {
-	gcSnap := message.Msg{Type: message.MsgTypeGcSnap, Data: &message.MsgGCSnap{Snaps: keys}}
-	if d.ctx.router.send(regionID, gcSnap) != nil {
-		// The snapshot exists because MsgAppend has been rejected. So the
-		// peer must have been exist. But now it's disconnected, so the peer
-		// has to be destroyed instead of being created.
-		log.Infof("region %d is disconnected, remove snaps %v", regionID, keys)
-		for _, pair := range keys {
-			key := pair.SnapKey
-			isSending := pair.IsSending
-			var snapshot snap.Snapshot
-			var err error
-			if isSending {
-				snapshot, err = d.ctx.snapMgr.GetSnapshotForSending(key)
-			} else {
-				snapshot, err = d.ctx.snapMgr.GetSnapshotForApplying(key)
-			}
-			if err != nil {
-				return err
-			}
-			d.ctx.snapMgr.DeleteSnapshot(key, snapshot, false)
-		}
-	}
-	return nil
-}
-
 func (d *storeWorker) onSnapMgrGC() {
-	if err := d.handleSnapMgrGC(); err != nil {
-		log.Errorf("handle snap GC failed store_id %d, err %s", d.storeState.id, err)
-	}
+	d.ctx.snapGCTaskSender <- &runner.SnapGCTask{}
 	d.ticker.scheduleStore(StoreTickSnapGC)
 }
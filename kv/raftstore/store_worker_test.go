@@ -0,0 +1,66 @@
+package raftstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/kv/config"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/message"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
+	"github.com/pingcap-incubator/tinykv/kv/util/worker"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStoreWorker(t *testing.T) *storeWorker {
+	engines := util.NewTestEngines()
+	t.Cleanup(func() { engines.Destroy() })
+	ctx := &GlobalContext{
+		cfg:              config.NewTestConfig(),
+		engine:           engines,
+		store:            &metapb.Store{Id: 1},
+		storeMeta:        newStoreMeta(nil),
+		router:           newRouter(make(chan message.Msg, 16), nil),
+		regionTaskSender: make(chan worker.Task, 16),
+	}
+	_, state := newStoreState(ctx.cfg)
+	return newStoreWorker(ctx, state)
+}
+
+func TestCreatePeer(t *testing.T) {
+	sw := newTestStoreWorker(t)
+	region := &metapb.Region{
+		Id:          2,
+		EndKey:      []byte("m"),
+		RegionEpoch: &metapb.RegionEpoch{ConfVer: 2, Version: 1},
+		Peers:       []*metapb.Peer{{Id: 3, StoreId: 2}, {Id: 4, StoreId: 1}},
+	}
+	_, ok := sw.onCreatePeer(region, &metapb.Peer{Id: 5, StoreId: 2}).(*util.ErrStoreNotMatch)
+	assert.True(t, ok)
+	assert.NotNil(t, sw.onCreatePeer(region, &metapb.Peer{Id: 5, StoreId: 1}))
+
+	require.Nil(t, sw.onCreatePeer(region, &metapb.Peer{Id: 4, StoreId: 1}))
+	assert.Equal(t, int32(1), sw.ctx.bootstrappingPeers)
+	// creating the same peer again succeeds, a different one fails
+	require.Nil(t, sw.onCreatePeer(region, &metapb.Peer{Id: 4, StoreId: 1}))
+	assert.Equal(t, int32(1), sw.ctx.bootstrappingPeers)
+	region.Peers[1].Id = 6
+	assert.NotNil(t, sw.onCreatePeer(region, &metapb.Peer{Id: 6, StoreId: 1}))
+
+	// the peer is destroyed once it waited for a snapshot too long
+	d := newPeerMsgHandler(sw.ctx.router.get(2).peer, sw.ctx)
+	assert.True(t, d.awaitingSnapshot)
+	d.checkBootstrap()
+	assert.False(t, d.stopped)
+	d.bootstrapDeadline = time.Now().Add(-time.Second)
+	d.checkBootstrap()
+	assert.True(t, d.stopped)
+	assert.Equal(t, int32(0), sw.ctx.bootstrappingPeers)
+	assert.Nil(t, sw.ctx.router.get(2))
+	assert.Empty(t, sw.ctx.storeMeta.regions)
+
+	// the tombstone of an uninitialized peer has no epoch, so the peer can be created again
+	region.Peers[1].Id = 4
+	require.Nil(t, sw.onCreatePeer(region, &metapb.Peer{Id: 4, StoreId: 1}))
+}
@@ -22,16 +22,22 @@ func newTicker(regionID uint64, cfg *config.Config) *ticker {
 	baseInterval := cfg.RaftBaseTickInterval
 	t := &ticker{
 		regionID:  regionID,
-		schedules: make([]tickSchedule, 6),
+		schedules: make([]tickSchedule, 7),
 	}
 	t.schedules[int(PeerTickRaft)].interval = 1
 	t.schedules[int(PeerTickRaftLogGC)].interval = int64(cfg.RaftLogGCTickInterval / baseInterval)
 	t.schedules[int(PeerTickSplitRegionCheck)].interval = int64(cfg.SplitRegionCheckTickInterval / baseInterval)
 	t.schedules[int(PeerTickSchedulerHeartbeat)].interval = int64(cfg.SchedulerHeartbeatTickInterval / baseInterval)
+	t.schedules[int(PeerTickRollbackCleanup)].interval = int64(cfg.RollbackCleanupTickInterval / baseInterval)
+	t.schedules[int(PeerTickCheckMerge)].interval = int64(cfg.MergeCheckTickInterval / baseInterval)
+	if cfg.RawSoftDeleteWindow > 0 {
+		t.schedules[int(PeerTickRawTombstoneGC)].interval = int64(cfg.RawTombstoneGCTickInterval / baseInterval)
+	}
 	return t
 }
 
 const SnapMgrGcTickInterval = 1 * time.Minute
+const CheckpointGcTickInterval = 1 * time.Minute
 
 func newStoreTicker(cfg *config.Config) *ticker {
 	baseInterval := cfg.RaftBaseTickInterval
@@ -40,6 +46,7 @@ func newStoreTicker(cfg *config.Config) *ticker {
 	}
 	t.schedules[int(StoreTickSchedulerStoreHeartbeat)].interval = int64(cfg.SchedulerStoreHeartbeatTickInterval / baseInterval)
 	t.schedules[int(StoreTickSnapGC)].interval = int64(SnapMgrGcTickInterval / baseInterval)
+	t.schedules[int(StoreTickCheckpointGC)].interval = int64(CheckpointGcTickInterval / baseInterval)
 	return t
 }
 
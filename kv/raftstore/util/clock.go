@@ -0,0 +1,48 @@
+package util
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// ClockSkew tracks the offset of the scheduler clock to the local clock. It is measured on every store heartbeat,
+// the same way as NTP does, assuming the scheduler handled the heartbeat halfway through its round trip.
+type ClockSkew struct {
+	maxSkew  time.Duration
+	offset   int64
+	measured uint32
+}
+
+func NewClockSkew(maxSkew time.Duration) *ClockSkew {
+	return &ClockSkew{maxSkew: maxSkew}
+}
+
+// Observe records the offset measured by a heartbeat sent at sent and answered at received, schedulerTime is the
+// unix time in nanoseconds the scheduler handled it at. It returns the measured offset.
+func (c *ClockSkew) Observe(sent, received time.Time, schedulerTime int64) time.Duration {
+	midpoint := sent.UnixNano() + received.Sub(sent).Nanoseconds()/2
+	offset := schedulerTime - midpoint
+	atomic.StoreInt64(&c.offset, offset)
+	atomic.StoreUint32(&c.measured, 1)
+	return time.Duration(offset)
+}
+
+// Offset returns the last measured offset, the scheduler clock is ahead of the local clock if it is positive.
+func (c *ClockSkew) Offset() time.Duration {
+	return time.Duration(atomic.LoadInt64(&c.offset))
+}
+
+// Exceeded returns true if the last measured offset is larger than the max skew.
+func (c *ClockSkew) Exceeded() bool {
+	offset := c.Offset()
+	if offset < 0 {
+		offset = -offset
+	}
+	return offset > c.maxSkew
+}
+
+// LeaseReadSafe returns true if the local clock can be trusted for lease based reads, i.e. the offset was measured
+// and is within the max skew. A lease read must fall back to a raft read otherwise.
+func (c *ClockSkew) LeaseReadSafe() bool {
+	return atomic.LoadUint32(&c.measured) == 1 && !c.Exceeded()
+}
@@ -0,0 +1,28 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClockSkew(t *testing.T) {
+	c := NewClockSkew(100 * time.Millisecond)
+	assert.False(t, c.LeaseReadSafe())
+
+	sent := time.Unix(100, 0)
+	received := sent.Add(20 * time.Millisecond)
+	// handled halfway through the round trip by a scheduler 50ms ahead
+	offset := c.Observe(sent, received, sent.Add(60*time.Millisecond).UnixNano())
+	assert.Equal(t, 50*time.Millisecond, offset)
+	assert.Equal(t, 50*time.Millisecond, c.Offset())
+	assert.False(t, c.Exceeded())
+	assert.True(t, c.LeaseReadSafe())
+
+	// a scheduler 200ms behind
+	c.Observe(sent, received, sent.Add(-190*time.Millisecond).UnixNano())
+	assert.Equal(t, -200*time.Millisecond, c.Offset())
+	assert.True(t, c.Exceeded())
+	assert.False(t, c.LeaseReadSafe())
+}
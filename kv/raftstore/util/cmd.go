@@ -0,0 +1,159 @@
+package util
+
+import (
+	"fmt"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
+)
+
+// Cmd is a well-formed RaftCmdRequest, which is exactly one of a *WriteCmd, a *ReadCmd or an *AdminCmd. The wire
+// format allows any list of requests along with an admin request, but only a command of a single kind is applied
+// atomically, so the commands are built and parsed as a Cmd in the propose and apply paths and an ill-formed
+// RaftCmdRequest is rejected before it's proposed.
+type Cmd interface {
+	// RaftCmdRequest converts the command to the request proposed.
+	RaftCmdRequest() *raft_cmdpb.RaftCmdRequest
+}
+
+// WriteCmd is a batch of puts, deletes and custom requests, they are applied in one write batch.
+type WriteCmd struct {
+	Header   *raft_cmdpb.RaftRequestHeader
+	Requests []*raft_cmdpb.Request
+}
+
+// ReadCmd is a set of gets and snaps, they read the same applied state of the region.
+type ReadCmd struct {
+	Header   *raft_cmdpb.RaftRequestHeader
+	Requests []*raft_cmdpb.Request
+}
+
+// AdminCmd is a single admin request.
+type AdminCmd struct {
+	Header  *raft_cmdpb.RaftRequestHeader
+	Request *raft_cmdpb.AdminRequest
+}
+
+func (c *WriteCmd) RaftCmdRequest() *raft_cmdpb.RaftCmdRequest {
+	return &raft_cmdpb.RaftCmdRequest{Header: c.Header, Requests: c.Requests}
+}
+
+// Size returns the size of the keys and values the command writes, the region size changes by about as much. A
+// delete counts its key, the data deleted is reclaimed once it's compacted.
+func (c *WriteCmd) Size() uint64 {
+	var size uint64
+	for _, req := range c.Requests {
+		switch req.CmdType {
+		case raft_cmdpb.CmdType_Put:
+			size += uint64(len(req.Put.Key) + len(req.Put.Value))
+		case raft_cmdpb.CmdType_Delete:
+			size += uint64(len(req.Delete.Key))
+		}
+	}
+	return size
+}
+
+func (c *ReadCmd) RaftCmdRequest() *raft_cmdpb.RaftCmdRequest {
+	return &raft_cmdpb.RaftCmdRequest{Header: c.Header, Requests: c.Requests}
+}
+
+func (c *AdminCmd) RaftCmdRequest() *raft_cmdpb.RaftCmdRequest {
+	return &raft_cmdpb.RaftCmdRequest{Header: c.Header, AdminRequest: c.Request}
+}
+
+// ErrInvalidCmd is returned for a RaftCmdRequest which isn't a well-formed Cmd.
+type ErrInvalidCmd struct {
+	Reason string
+}
+
+func (e *ErrInvalidCmd) Error() string {
+	return fmt.Sprintf("invalid raft command: %s", e.Reason)
+}
+
+func invalidCmd(format string, args ...interface{}) error {
+	return &ErrInvalidCmd{Reason: fmt.Sprintf(format, args...)}
+}
+
+// ParseCmd checks the request is a well-formed command and returns it as a Cmd: either a single admin request, or a
+// non-empty list of requests which are all writes or all reads, each with the body of its type.
+func ParseCmd(req *raft_cmdpb.RaftCmdRequest) (Cmd, error) {
+	if req.AdminRequest != nil {
+		if len(req.Requests) != 0 {
+			return nil, invalidCmd("admin request %s with %d requests", req.AdminRequest.CmdType, len(req.Requests))
+		}
+		if err := checkAdminRequest(req.AdminRequest); err != nil {
+			return nil, err
+		}
+		return &AdminCmd{Header: req.Header, Request: req.AdminRequest}, nil
+	}
+	if len(req.Requests) == 0 {
+		return nil, invalidCmd("empty command")
+	}
+	var reads, writes int
+	for _, r := range req.Requests {
+		write, err := checkRequest(r)
+		if err != nil {
+			return nil, err
+		}
+		if write {
+			writes++
+		} else {
+			reads++
+		}
+	}
+	if reads != 0 && writes != 0 {
+		return nil, invalidCmd("%d reads mixed with %d writes", reads, writes)
+	}
+	if writes != 0 {
+		return &WriteCmd{Header: req.Header, Requests: req.Requests}, nil
+	}
+	return &ReadCmd{Header: req.Header, Requests: req.Requests}, nil
+}
+
+// checkRequest checks the request has the body of its type, and returns whether it's a write.
+func checkRequest(r *raft_cmdpb.Request) (bool, error) {
+	var hasBody, write bool
+	switch r.CmdType {
+	case raft_cmdpb.CmdType_Get:
+		hasBody = r.Get != nil
+	case raft_cmdpb.CmdType_Snap:
+		hasBody = r.Snap != nil
+	case raft_cmdpb.CmdType_Put:
+		hasBody, write = r.Put != nil, true
+	case raft_cmdpb.CmdType_Delete:
+		hasBody, write = r.Delete != nil, true
+	case raft_cmdpb.CmdType_Custom:
+		hasBody, write = r.Custom != nil, true
+	default:
+		return false, invalidCmd("unknown request type %s", r.CmdType)
+	}
+	if !hasBody {
+		return false, invalidCmd("%s request without body", r.CmdType)
+	}
+	return write, nil
+}
+
+func checkAdminRequest(r *raft_cmdpb.AdminRequest) error {
+	var hasBody bool
+	switch r.CmdType {
+	case raft_cmdpb.AdminCmdType_ChangePeer:
+		hasBody = r.ChangePeer != nil
+	case raft_cmdpb.AdminCmdType_CompactLog:
+		hasBody = r.CompactLog != nil
+	case raft_cmdpb.AdminCmdType_TransferLeader:
+		hasBody = r.TransferLeader != nil
+	case raft_cmdpb.AdminCmdType_Split:
+		hasBody = r.Split != nil
+	case raft_cmdpb.AdminCmdType_PrepareMerge:
+		hasBody = r.PrepareMerge != nil && r.PrepareMerge.Target != nil
+	case raft_cmdpb.AdminCmdType_CommitMerge:
+		hasBody = r.CommitMerge != nil && r.CommitMerge.Source != nil
+	case raft_cmdpb.AdminCmdType_RollbackMerge:
+		hasBody = r.RollbackMerge != nil
+	default:
+		return invalidCmd("unknown admin request type %s", r.CmdType)
+	}
+	if !hasBody {
+		return invalidCmd("%s admin request without body", r.CmdType)
+	}
+	return nil
+}
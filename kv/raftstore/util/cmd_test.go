@@ -0,0 +1,48 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCmd(t *testing.T) {
+	header := &raft_cmdpb.RaftRequestHeader{RegionId: 1}
+	put := &raft_cmdpb.Request{CmdType: raft_cmdpb.CmdType_Put, Put: &raft_cmdpb.PutRequest{Key: []byte("k")}}
+	del := &raft_cmdpb.Request{CmdType: raft_cmdpb.CmdType_Delete, Delete: &raft_cmdpb.DeleteRequest{Key: []byte("k")}}
+	snap := &raft_cmdpb.Request{CmdType: raft_cmdpb.CmdType_Snap, Snap: &raft_cmdpb.SnapRequest{}}
+	compact := &raft_cmdpb.AdminRequest{CmdType: raft_cmdpb.AdminCmdType_CompactLog, CompactLog: &raft_cmdpb.CompactLogRequest{}}
+
+	for _, cmd := range []Cmd{
+		&WriteCmd{Header: header, Requests: []*raft_cmdpb.Request{put, del}},
+		&ReadCmd{Header: header, Requests: []*raft_cmdpb.Request{snap}},
+		&AdminCmd{Header: header, Request: compact},
+	} {
+		parsed, err := ParseCmd(cmd.RaftCmdRequest())
+		assert.Nil(t, err)
+		assert.Equal(t, cmd, parsed)
+	}
+
+	for _, req := range []*raft_cmdpb.RaftCmdRequest{
+		{Header: header},
+		{Header: header, Requests: []*raft_cmdpb.Request{put, snap}},
+		{Header: header, Requests: []*raft_cmdpb.Request{put}, AdminRequest: compact},
+		{Header: header, Requests: []*raft_cmdpb.Request{{CmdType: raft_cmdpb.CmdType_Put}}},
+		{Header: header, Requests: []*raft_cmdpb.Request{{CmdType: raft_cmdpb.CmdType_Invalid}}},
+		{Header: header, AdminRequest: &raft_cmdpb.AdminRequest{CmdType: raft_cmdpb.AdminCmdType_Split}},
+	} {
+		_, err := ParseCmd(req)
+		_, ok := err.(*ErrInvalidCmd)
+		assert.True(t, ok, "%v", req)
+	}
+}
+
+func TestWriteCmdSize(t *testing.T) {
+	cmd := &WriteCmd{Requests: []*raft_cmdpb.Request{
+		{CmdType: raft_cmdpb.CmdType_Put, Put: &raft_cmdpb.PutRequest{Key: []byte("k1"), Value: []byte("value")}},
+		{CmdType: raft_cmdpb.CmdType_Delete, Delete: &raft_cmdpb.DeleteRequest{Key: []byte("k2")}},
+		{CmdType: raft_cmdpb.CmdType_Custom, Custom: &raft_cmdpb.CustomRequest{}},
+	}}
+	assert.Equal(t, uint64(9), cmd.Size())
+}
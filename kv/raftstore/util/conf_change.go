@@ -0,0 +1,95 @@
+package util
+
+import (
+	"fmt"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
+)
+
+// NewConfChange builds the ConfChange proposed for a ChangePeer command, with the peer and the region epoch in its
+// context, so the appliers update the region from the entry alone.
+func NewConfChange(cmd *AdminCmd) (*eraftpb.ConfChange, error) {
+	changePeer := cmd.Request.GetChangePeer()
+	if changePeer == nil || changePeer.Peer == nil {
+		return nil, invalidCmd("%s admin request is not a conf change", cmd.Request.CmdType)
+	}
+	ctx := &raft_cmdpb.ConfChangeContext{
+		Peer:        changePeer.Peer,
+		RegionEpoch: cmd.Header.GetRegionEpoch(),
+	}
+	data, err := ctx.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return &eraftpb.ConfChange{
+		ChangeType: changePeer.ChangeType,
+		NodeId:     changePeer.Peer.Id,
+		Context:    data,
+	}, nil
+}
+
+// ParseConfChangeContext returns the context of a ConfChange built by NewConfChange.
+func ParseConfChangeContext(cc *eraftpb.ConfChange) (*raft_cmdpb.ConfChangeContext, error) {
+	ctx := new(raft_cmdpb.ConfChangeContext)
+	if err := ctx.Unmarshal(cc.Context); err != nil {
+		return nil, err
+	}
+	if ctx.Peer == nil || ctx.Peer.Id != cc.NodeId {
+		return nil, invalidCmd("conf change of node %d with peer %s in context", cc.NodeId, ctx.Peer)
+	}
+	return ctx, nil
+}
+
+// ApplyConfChange returns the region after the conf change, with its conf version increased. The region isn't
+// modified. An ErrEpochNotMatch is returned if another conf change was applied since the change was proposed.
+func ApplyConfChange(region *metapb.Region, cc *eraftpb.ConfChange, ctx *raft_cmdpb.ConfChangeContext) (*metapb.Region, error) {
+	if ctx.GetRegionEpoch().GetConfVer() != region.GetRegionEpoch().GetConfVer() {
+		return nil, &ErrEpochNotMatch{
+			Message: fmt.Sprintf("conf change proposed at conf version %d, current conf version %d",
+				ctx.GetRegionEpoch().GetConfVer(), region.GetRegionEpoch().GetConfVer()),
+			Regions: []*metapb.Region{region},
+		}
+	}
+	newRegion := proto.Clone(region).(*metapb.Region)
+	switch cc.ChangeType {
+	case eraftpb.ConfChangeType_AddNode:
+		exist := FindPeer(newRegion, ctx.Peer.StoreId)
+		if exist != nil && (exist.Id != ctx.Peer.Id || !exist.IsLearner) {
+			return nil, fmt.Errorf("can't add peer %s, store %d already has peer %d", ctx.Peer, ctx.Peer.StoreId, exist.Id)
+		}
+		if exist != nil {
+			// promote the learner
+			exist.IsLearner = false
+		} else {
+			peer := proto.Clone(ctx.Peer).(*metapb.Peer)
+			peer.IsLearner = false
+			newRegion.Peers = append(newRegion.Peers, peer)
+		}
+	case eraftpb.ConfChangeType_AddLearnerNode:
+		if exist := FindPeer(newRegion, ctx.Peer.StoreId); exist != nil {
+			return nil, fmt.Errorf("can't add learner %s, store %d already has peer %d", ctx.Peer, ctx.Peer.StoreId, exist.Id)
+		}
+		peer := proto.Clone(ctx.Peer).(*metapb.Peer)
+		peer.IsLearner = true
+		newRegion.Peers = append(newRegion.Peers, peer)
+	case eraftpb.ConfChangeType_AddWitnessNode:
+		if exist := FindPeer(newRegion, ctx.Peer.StoreId); exist != nil {
+			return nil, fmt.Errorf("can't add witness %s, store %d already has peer %d", ctx.Peer, ctx.Peer.StoreId, exist.Id)
+		}
+		peer := proto.Clone(ctx.Peer).(*metapb.Peer)
+		peer.IsLearner, peer.IsWitness = false, true
+		newRegion.Peers = append(newRegion.Peers, peer)
+	case eraftpb.ConfChangeType_RemoveNode:
+		if exist := FindPeer(newRegion, ctx.Peer.StoreId); exist == nil || exist.Id != ctx.Peer.Id {
+			return nil, fmt.Errorf("can't remove peer %s, it's not in region %d", ctx.Peer, region.Id)
+		}
+		RemovePeer(newRegion, ctx.Peer.StoreId)
+	default:
+		return nil, fmt.Errorf("unknown conf change type %s", cc.ChangeType)
+	}
+	newRegion.RegionEpoch.ConfVer++
+	return newRegion, nil
+}
@@ -0,0 +1,116 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfChange(t *testing.T) {
+	region := &metapb.Region{
+		Id:          1,
+		Peers:       []*metapb.Peer{{Id: 1, StoreId: 1}, {Id: 2, StoreId: 2}},
+		RegionEpoch: &metapb.RegionEpoch{ConfVer: 2, Version: 3},
+	}
+	changePeer := func(tp eraftpb.ConfChangeType, peer *metapb.Peer, epoch *metapb.RegionEpoch) *eraftpb.ConfChange {
+		cc, err := NewConfChange(&AdminCmd{
+			Header: &raft_cmdpb.RaftRequestHeader{RegionId: 1, RegionEpoch: epoch},
+			Request: &raft_cmdpb.AdminRequest{
+				CmdType:    raft_cmdpb.AdminCmdType_ChangePeer,
+				ChangePeer: &raft_cmdpb.ChangePeerRequest{ChangeType: tp, Peer: peer},
+			},
+		})
+		assert.Nil(t, err)
+		return cc
+	}
+	apply := func(region *metapb.Region, cc *eraftpb.ConfChange) (*metapb.Region, error) {
+		ctx, err := ParseConfChangeContext(cc)
+		assert.Nil(t, err)
+		return ApplyConfChange(region, cc, ctx)
+	}
+
+	cc := changePeer(eraftpb.ConfChangeType_AddNode, &metapb.Peer{Id: 3, StoreId: 3}, region.RegionEpoch)
+	assert.Equal(t, uint64(3), cc.NodeId)
+	added, err := apply(region, cc)
+	assert.Nil(t, err)
+	assert.Equal(t, []*metapb.Peer{{Id: 1, StoreId: 1}, {Id: 2, StoreId: 2}, {Id: 3, StoreId: 3}}, added.Peers)
+	assert.Equal(t, &metapb.RegionEpoch{ConfVer: 3, Version: 3}, added.RegionEpoch)
+	// the region isn't modified
+	assert.Len(t, region.Peers, 2)
+	assert.Equal(t, uint64(2), region.RegionEpoch.ConfVer)
+
+	// proposed before the peer was added
+	_, err = apply(added, changePeer(eraftpb.ConfChangeType_RemoveNode, &metapb.Peer{Id: 2, StoreId: 2}, region.RegionEpoch))
+	_, ok := err.(*ErrEpochNotMatch)
+	assert.True(t, ok)
+	removed, err := apply(added, changePeer(eraftpb.ConfChangeType_RemoveNode, &metapb.Peer{Id: 2, StoreId: 2}, added.RegionEpoch))
+	assert.Nil(t, err)
+	assert.Equal(t, []*metapb.Peer{{Id: 1, StoreId: 1}, {Id: 3, StoreId: 3}}, removed.Peers)
+
+	// another peer on the same store
+	_, err = apply(region, changePeer(eraftpb.ConfChangeType_AddNode, &metapb.Peer{Id: 4, StoreId: 2}, region.RegionEpoch))
+	assert.NotNil(t, err)
+	_, err = apply(region, changePeer(eraftpb.ConfChangeType_RemoveNode, &metapb.Peer{Id: 4, StoreId: 2}, region.RegionEpoch))
+	assert.NotNil(t, err)
+
+	// the node id doesn't match the peer
+	cc.NodeId = 4
+	_, err = ParseConfChangeContext(cc)
+	assert.NotNil(t, err)
+	_, err = NewConfChange(&AdminCmd{Header: &raft_cmdpb.RaftRequestHeader{}, Request: &raft_cmdpb.AdminRequest{CmdType: raft_cmdpb.AdminCmdType_CompactLog}})
+	assert.NotNil(t, err)
+}
+
+func TestLearnerConfChange(t *testing.T) {
+	region := &metapb.Region{
+		Id:          1,
+		Peers:       []*metapb.Peer{{Id: 1, StoreId: 1}},
+		RegionEpoch: &metapb.RegionEpoch{ConfVer: 1, Version: 1},
+	}
+	apply := func(region *metapb.Region, tp eraftpb.ConfChangeType, peer *metapb.Peer) (*metapb.Region, error) {
+		cc := &eraftpb.ConfChange{ChangeType: tp, NodeId: peer.Id}
+		return ApplyConfChange(region, cc, &raft_cmdpb.ConfChangeContext{Peer: peer, RegionEpoch: region.RegionEpoch})
+	}
+
+	learner, err := apply(region, eraftpb.ConfChangeType_AddLearnerNode, &metapb.Peer{Id: 2, StoreId: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, &metapb.Peer{Id: 2, StoreId: 2, IsLearner: true}, learner.Peers[1])
+	assert.Equal(t, eraftpb.ConfState{Nodes: []uint64{1}, Learners: []uint64{2}}, ConfStateFromRegion(learner))
+	_, err = apply(learner, eraftpb.ConfChangeType_AddLearnerNode, &metapb.Peer{Id: 2, StoreId: 2})
+	assert.NotNil(t, err)
+	// only the same peer is promoted
+	_, err = apply(learner, eraftpb.ConfChangeType_AddNode, &metapb.Peer{Id: 3, StoreId: 2})
+	assert.NotNil(t, err)
+	promoted, err := apply(learner, eraftpb.ConfChangeType_AddNode, &metapb.Peer{Id: 2, StoreId: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, []*metapb.Peer{{Id: 1, StoreId: 1}, {Id: 2, StoreId: 2}}, promoted.Peers)
+	assert.Equal(t, uint64(3), promoted.RegionEpoch.ConfVer)
+	assert.True(t, learner.Peers[1].IsLearner)
+	_, err = apply(promoted, eraftpb.ConfChangeType_AddNode, &metapb.Peer{Id: 2, StoreId: 2})
+	assert.NotNil(t, err)
+}
+
+func TestWitnessConfChange(t *testing.T) {
+	region := &metapb.Region{
+		Id:          1,
+		Peers:       []*metapb.Peer{{Id: 1, StoreId: 1}, {Id: 2, StoreId: 2}},
+		RegionEpoch: &metapb.RegionEpoch{ConfVer: 1, Version: 1},
+	}
+	apply := func(region *metapb.Region, tp eraftpb.ConfChangeType, peer *metapb.Peer) (*metapb.Region, error) {
+		cc := &eraftpb.ConfChange{ChangeType: tp, NodeId: peer.Id}
+		return ApplyConfChange(region, cc, &raft_cmdpb.ConfChangeContext{Peer: peer, RegionEpoch: region.RegionEpoch})
+	}
+
+	witness, err := apply(region, eraftpb.ConfChangeType_AddWitnessNode, &metapb.Peer{Id: 3, StoreId: 3})
+	assert.Nil(t, err)
+	assert.Equal(t, &metapb.Peer{Id: 3, StoreId: 3, IsWitness: true}, witness.Peers[2])
+	assert.Equal(t, eraftpb.ConfState{Nodes: []uint64{1, 2, 3}, Witnesses: []uint64{3}}, ConfStateFromRegion(witness))
+	_, err = apply(witness, eraftpb.ConfChangeType_AddWitnessNode, &metapb.Peer{Id: 4, StoreId: 3})
+	assert.NotNil(t, err)
+	removed, err := apply(witness, eraftpb.ConfChangeType_RemoveNode, &metapb.Peer{Id: 3, StoreId: 3})
+	assert.Nil(t, err)
+	assert.Equal(t, region.Peers, removed.Peers)
+}
@@ -58,6 +58,34 @@ func (e *ErrStoreNotMatch) Error() string {
 	return fmt.Sprintf("store not match, request store id is %v, but actual store id is %v", e.RequestStoreId, e.ActualStoreId)
 }
 
+type ErrRaftEntryTooLarge struct {
+	RegionId  uint64
+	EntrySize uint64
+}
+
+func (e *ErrRaftEntryTooLarge) Error() string {
+	return fmt.Sprintf("raft entry of region %v is too large, entry size %v", e.RegionId, e.EntrySize)
+}
+
+type ErrRaftLogQuotaExceeded struct {
+	RegionId uint64
+	LogSize  uint64
+	Quota    uint64
+}
+
+func (e *ErrRaftLogQuotaExceeded) Error() string {
+	return fmt.Sprintf("raft log of region %v exceeds its quota, log size %v, quota %v", e.RegionId, e.LogSize, e.Quota)
+}
+
+type ErrServerIsBusy struct {
+	RegionId uint64
+	Reason   string
+}
+
+func (e *ErrServerIsBusy) Error() string {
+	return fmt.Sprintf("server is busy for region %v: %v", e.RegionId, e.Reason)
+}
+
 func RaftstoreErrToPbError(e error) *errorpb.Error {
 	ret := new(errorpb.Error)
 	switch err := errors.Cause(e).(type) {
@@ -74,6 +102,12 @@ func RaftstoreErrToPbError(e error) *errorpb.Error {
 		ret.StaleCommand = &errorpb.StaleCommand{}
 	case *ErrStoreNotMatch:
 		ret.StoreNotMatch = &errorpb.StoreNotMatch{RequestStoreId: err.RequestStoreId, ActualStoreId: err.ActualStoreId}
+	case *ErrRaftEntryTooLarge:
+		ret.RaftEntryTooLarge = &errorpb.RaftEntryTooLarge{RegionId: err.RegionId, EntrySize: err.EntrySize}
+	case *ErrRaftLogQuotaExceeded:
+		ret.RaftLogQuotaExceeded = &errorpb.RaftLogQuotaExceeded{RegionId: err.RegionId, LogSize: err.LogSize, Quota: err.Quota}
+	case *ErrServerIsBusy:
+		ret.ServerIsBusy = &errorpb.ServerIsBusy{RegionId: err.RegionId, Reason: err.Reason}
 	default:
 		ret.Message = e.Error()
 	}
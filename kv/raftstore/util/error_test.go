@@ -44,4 +44,16 @@ func TestRaftstoreErrToPbError(t *testing.T) {
 	require.NotNil(t, pbErr.StoreNotMatch)
 	assert.Equal(t, requestStoreId, pbErr.StoreNotMatch.RequestStoreId)
 	assert.Equal(t, actualStoreId, pbErr.StoreNotMatch.ActualStoreId)
+
+	quotaExceeded := &ErrRaftLogQuotaExceeded{RegionId: regionId, LogSize: 200, Quota: 100}
+	pbErr = RaftstoreErrToPbError(quotaExceeded)
+	require.NotNil(t, pbErr.RaftLogQuotaExceeded)
+	assert.Equal(t, uint64(200), pbErr.RaftLogQuotaExceeded.LogSize)
+	assert.Equal(t, uint64(100), pbErr.RaftLogQuotaExceeded.Quota)
+
+	busy := &ErrServerIsBusy{RegionId: regionId, Reason: "too many uncommitted entries"}
+	pbErr = RaftstoreErrToPbError(busy)
+	require.NotNil(t, pbErr.ServerIsBusy)
+	assert.Equal(t, regionId, pbErr.ServerIsBusy.RegionId)
+	assert.Equal(t, busy.Reason, pbErr.ServerIsBusy.Reason)
 }
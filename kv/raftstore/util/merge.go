@@ -0,0 +1,92 @@
+package util
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
+	rspb "github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
+)
+
+// CheckMergeTarget checks the source region can be merged into the target: they must be adjacent, and have their
+// peers on the same stores in the same roles, so every peer of the target has a source peer on its store to merge.
+func CheckMergeTarget(source, target *metapb.Region) error {
+	if source.Id == target.Id {
+		return fmt.Errorf("can't merge region %d into itself", source.Id)
+	}
+	if !isAdjacent(source, target) {
+		return fmt.Errorf("region %d [%q, %q) isn't adjacent to region %d [%q, %q)", source.Id, source.StartKey,
+			source.EndKey, target.Id, target.StartKey, target.EndKey)
+	}
+	if len(source.Peers) != len(target.Peers) {
+		return fmt.Errorf("region %d has %d peers, target region %d has %d", source.Id, len(source.Peers), target.Id,
+			len(target.Peers))
+	}
+	for _, p := range source.Peers {
+		t := FindPeer(target, p.StoreId)
+		if t == nil || t.IsLearner != p.IsLearner || t.IsWitness != p.IsWitness {
+			return fmt.Errorf("peer %s of region %d has no peer in the same role on its store in region %d", p,
+				source.Id, target.Id)
+		}
+	}
+	return nil
+}
+
+func isAdjacent(source, target *metapb.Region) bool {
+	return (len(source.EndKey) != 0 && bytes.Equal(source.EndKey, target.StartKey)) ||
+		(len(target.EndKey) != 0 && bytes.Equal(target.EndKey, source.StartKey))
+}
+
+// ApplyPrepareMerge returns the region after the PrepareMerge at the index and its merge state. Both versions of
+// the region epoch are increased, so no command proposed before it is applied after it. The region isn't modified.
+func ApplyPrepareMerge(region *metapb.Region, req *raft_cmdpb.PrepareMergeRequest, index uint64) (*metapb.Region, *rspb.MergeState) {
+	newRegion := proto.Clone(region).(*metapb.Region)
+	newRegion.RegionEpoch.Version++
+	newRegion.RegionEpoch.ConfVer++
+	return newRegion, &rspb.MergeState{MinIndex: req.MinIndex, Target: req.Target, Commit: index}
+}
+
+// ApplyCommitMerge returns the target region after the source is merged into it, covering the ranges of both. Its
+// version is increased past the versions of both, so a request for either range with a stale epoch is rejected. The
+// regions aren't modified.
+func ApplyCommitMerge(target, source *metapb.Region) (*metapb.Region, error) {
+	if !isAdjacent(source, target) {
+		return nil, fmt.Errorf("can't merge region %d into region %d, they aren't adjacent", source.Id, target.Id)
+	}
+	newRegion := proto.Clone(target).(*metapb.Region)
+	if bytes.Equal(source.EndKey, target.StartKey) {
+		newRegion.StartKey = source.StartKey
+	} else {
+		newRegion.EndKey = source.EndKey
+	}
+	version := target.RegionEpoch.Version
+	if source.RegionEpoch.Version > version {
+		version = source.RegionEpoch.Version
+	}
+	newRegion.RegionEpoch.Version = version + 1
+	return newRegion, nil
+}
+
+// ApplyRollbackMerge returns the region after the PrepareMerge in the merge state is rolled back, with its version
+// increased. The region isn't modified.
+func ApplyRollbackMerge(region *metapb.Region, state *rspb.MergeState, req *raft_cmdpb.RollbackMergeRequest) (*metapb.Region, error) {
+	if state == nil || state.Commit != req.Commit {
+		return nil, fmt.Errorf("can't roll back the merge at %d of region %d, its merge state is %v", req.Commit,
+			region.Id, state)
+	}
+	newRegion := proto.Clone(region).(*metapb.Region)
+	newRegion.RegionEpoch.Version++
+	return newRegion, nil
+}
+
+// IsMergedInto returns true if the target region, as known on the store, has merged the source region, i.e. its
+// version is past the target of the merge state and its range covers the source.
+func IsMergedInto(source *metapb.Region, state *rspb.MergeState, target *metapb.Region) bool {
+	if target.GetRegionEpoch().GetVersion() <= state.Target.GetRegionEpoch().GetVersion() {
+		return false
+	}
+	return bytes.Compare(target.StartKey, source.StartKey) <= 0 &&
+		(len(target.EndKey) == 0 || (len(source.EndKey) != 0 && bytes.Compare(source.EndKey, target.EndKey) <= 0))
+}
@@ -0,0 +1,65 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge(t *testing.T) {
+	source := &metapb.Region{
+		Id: 1, StartKey: []byte("a"), EndKey: []byte("c"),
+		Peers:       []*metapb.Peer{{Id: 2, StoreId: 1}, {Id: 3, StoreId: 2}},
+		RegionEpoch: &metapb.RegionEpoch{ConfVer: 2, Version: 5},
+	}
+	target := &metapb.Region{
+		Id: 4, StartKey: []byte("c"), EndKey: []byte("e"),
+		Peers:       []*metapb.Peer{{Id: 5, StoreId: 2}, {Id: 6, StoreId: 1}},
+		RegionEpoch: &metapb.RegionEpoch{ConfVer: 3, Version: 2},
+	}
+	assert.Nil(t, CheckMergeTarget(source, target))
+	assert.Nil(t, CheckMergeTarget(target, source))
+	assert.NotNil(t, CheckMergeTarget(source, source))
+	far := &metapb.Region{Id: 7, StartKey: []byte("d"), Peers: target.Peers, RegionEpoch: target.RegionEpoch}
+	assert.NotNil(t, CheckMergeTarget(source, far))
+	learner := &metapb.Region{Id: 4, StartKey: []byte("c"), RegionEpoch: target.RegionEpoch,
+		Peers: []*metapb.Peer{{Id: 5, StoreId: 2}, {Id: 6, StoreId: 1, IsLearner: true}}}
+	assert.NotNil(t, CheckMergeTarget(source, learner))
+	moved := &metapb.Region{Id: 4, StartKey: []byte("c"), RegionEpoch: target.RegionEpoch,
+		Peers: []*metapb.Peer{{Id: 5, StoreId: 2}, {Id: 6, StoreId: 3}}}
+	assert.NotNil(t, CheckMergeTarget(source, moved))
+
+	prepared, state := ApplyPrepareMerge(source, &raft_cmdpb.PrepareMergeRequest{MinIndex: 8, Target: target}, 10)
+	assert.Equal(t, &metapb.RegionEpoch{ConfVer: 3, Version: 6}, prepared.RegionEpoch)
+	assert.Equal(t, uint64(5), source.RegionEpoch.Version)
+	assert.Equal(t, uint64(8), state.MinIndex)
+	assert.Equal(t, uint64(10), state.Commit)
+
+	rolledBack, err := ApplyRollbackMerge(prepared, state, &raft_cmdpb.RollbackMergeRequest{Commit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, &metapb.RegionEpoch{ConfVer: 3, Version: 7}, rolledBack.RegionEpoch)
+	_, err = ApplyRollbackMerge(prepared, state, &raft_cmdpb.RollbackMergeRequest{Commit: 9})
+	assert.NotNil(t, err)
+	_, err = ApplyRollbackMerge(prepared, nil, &raft_cmdpb.RollbackMergeRequest{Commit: 10})
+	assert.NotNil(t, err)
+
+	assert.False(t, IsMergedInto(prepared, state, target))
+	merged, err := ApplyCommitMerge(target, prepared)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("a"), merged.StartKey)
+	assert.Equal(t, []byte("e"), merged.EndKey)
+	assert.Equal(t, &metapb.RegionEpoch{ConfVer: 3, Version: 7}, merged.RegionEpoch)
+	assert.Equal(t, []byte("c"), target.StartKey)
+	assert.True(t, IsMergedInto(prepared, state, merged))
+
+	// the target before the source
+	merged, err = ApplyCommitMerge(&metapb.Region{Id: 8, EndKey: []byte("a"), RegionEpoch: &metapb.RegionEpoch{Version: 9}}, source)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte(nil), merged.StartKey)
+	assert.Equal(t, []byte("c"), merged.EndKey)
+	assert.Equal(t, uint64(10), merged.RegionEpoch.Version)
+	_, err = ApplyCommitMerge(far, source)
+	assert.NotNil(t, err)
+}
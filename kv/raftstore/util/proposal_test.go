@@ -0,0 +1,37 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProposalChecksum(t *testing.T) {
+	req := &raft_cmdpb.RaftCmdRequest{
+		Header: &raft_cmdpb.RaftRequestHeader{RegionId: 1},
+		Requests: []*raft_cmdpb.Request{{
+			CmdType: raft_cmdpb.CmdType_Put,
+			Put:     &raft_cmdpb.PutRequest{Cf: "default", Key: []byte("k"), Value: []byte("v")},
+		}},
+	}
+	for _, checksum := range []bool{false, true} {
+		data, err := EncodeRaftCmd(req, checksum)
+		assert.Nil(t, err)
+		decoded, err := DecodeRaftCmd(data)
+		assert.Nil(t, err)
+		assert.Equal(t, req, decoded)
+	}
+
+	data, err := EncodeRaftCmd(req, true)
+	assert.Nil(t, err)
+	data[len(data)-1] ^= 1
+	_, err = DecodeRaftCmd(data)
+	_, ok := err.(*ErrProposalChecksum)
+	assert.True(t, ok)
+
+	// an empty request has no checksum
+	decoded, err := DecodeRaftCmd(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, &raft_cmdpb.RaftCmdRequest{}, decoded)
+}
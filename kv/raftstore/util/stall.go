@@ -0,0 +1,46 @@
+package util
+
+import "time"
+
+// StallDetector tells whether the writes of a peer have been slow for a while. Each persisted or applied ready is
+// observed with its latency, the peer is stalled once every write since some time at least the duration ago took
+// longer than the threshold. A nil StallDetector is never stalled.
+type StallDetector struct {
+	threshold time.Duration
+	duration  time.Duration
+	// start of the first of the consecutive slow writes, zero if the last write isn't slow
+	slowSince time.Time
+}
+
+// NewStallDetector returns nil if threshold is 0, which disables the detection.
+func NewStallDetector(threshold, duration time.Duration) *StallDetector {
+	if threshold == 0 {
+		return nil
+	}
+	return &StallDetector{threshold: threshold, duration: duration}
+}
+
+// Observe records a write which finished at now and took latency.
+func (d *StallDetector) Observe(latency time.Duration, now time.Time) {
+	if d == nil {
+		return
+	}
+	if latency < d.threshold {
+		d.slowSince = time.Time{}
+		return
+	}
+	if d.slowSince.IsZero() {
+		d.slowSince = now.Add(-latency)
+	}
+}
+
+func (d *StallDetector) Stalled(now time.Time) bool {
+	return d != nil && !d.slowSince.IsZero() && now.Sub(d.slowSince) >= d.duration
+}
+
+// Reset forgets the slow writes, e.g. after the leadership is transferred away.
+func (d *StallDetector) Reset() {
+	if d != nil {
+		d.slowSince = time.Time{}
+	}
+}
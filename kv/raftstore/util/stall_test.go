@@ -0,0 +1,33 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStallDetector(t *testing.T) {
+	d := NewStallDetector(time.Second, 10*time.Second)
+	now := time.Now()
+	d.Observe(2*time.Second, now)
+	assert.False(t, d.Stalled(now))
+	d.Observe(3*time.Second, now.Add(5*time.Second))
+	assert.False(t, d.Stalled(now.Add(5*time.Second)))
+	d.Observe(2*time.Second, now.Add(8*time.Second))
+	assert.True(t, d.Stalled(now.Add(8*time.Second)))
+
+	// a fast write ends the stall
+	d.Observe(time.Millisecond, now.Add(9*time.Second))
+	assert.False(t, d.Stalled(now.Add(20*time.Second)))
+
+	d.Observe(20*time.Second, now.Add(30*time.Second))
+	assert.True(t, d.Stalled(now.Add(30*time.Second)))
+	d.Reset()
+	assert.False(t, d.Stalled(now.Add(30*time.Second)))
+
+	var disabled *StallDetector
+	disabled.Observe(time.Hour, now)
+	assert.False(t, disabled.Stalled(now))
+	assert.Nil(t, NewStallDetector(0, time.Second))
+}
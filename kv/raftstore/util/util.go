@@ -85,7 +85,9 @@ func CheckRegionEpoch(req *raft_cmdpb.RaftCmdRequest, region *metapb.Region, inc
 		case raft_cmdpb.AdminCmdType_CompactLog, raft_cmdpb.AdminCmdType_InvalidAdmin:
 		case raft_cmdpb.AdminCmdType_ChangePeer:
 			checkConfVer = true
-		case raft_cmdpb.AdminCmdType_Split, raft_cmdpb.AdminCmdType_TransferLeader:
+		case raft_cmdpb.AdminCmdType_Split, raft_cmdpb.AdminCmdType_TransferLeader,
+			raft_cmdpb.AdminCmdType_PrepareMerge, raft_cmdpb.AdminCmdType_CommitMerge,
+			raft_cmdpb.AdminCmdType_RollbackMerge:
 			checkVer = true
 			checkConfVer = true
 		}
@@ -152,7 +154,14 @@ func RemovePeer(region *metapb.Region, storeID uint64) *metapb.Peer {
 
 func ConfStateFromRegion(region *metapb.Region) (confState eraftpb.ConfState) {
 	for _, p := range region.Peers {
-		confState.Nodes = append(confState.Nodes, p.GetId())
+		if p.GetIsLearner() {
+			confState.Learners = append(confState.Learners, p.GetId())
+		} else {
+			confState.Nodes = append(confState.Nodes, p.GetId())
+			if p.GetIsWitness() {
+				confState.Witnesses = append(confState.Witnesses, p.GetId())
+			}
+		}
 	}
 	return
 }
@@ -0,0 +1,153 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/log"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+)
+
+// adminOpTTL is how long the state of a finished admin operation is kept, a request retried with its token
+// afterwards starts the operation again.
+const adminOpTTL = 10 * time.Minute
+
+// adminStorage is a storage which runs the admin operations, see kvrpcpb.AdminOpType.
+type adminStorage interface {
+	CompactRegionLog(regionID uint64) error
+	TriggerSplitCheck(regionID uint64) error
+	FlushEngines() error
+	HashRegion(regionID uint64) error
+	RecreatePeer(regionID, storeID uint64) error
+}
+
+// adminOp is an admin operation running in the background.
+type adminOp struct {
+	sync.Mutex
+	tp       kvrpcpb.AdminOpType
+	regionID uint64
+	state    kvrpcpb.AdminOpState
+	err      string
+	finished time.Time
+}
+
+func (op *adminOp) status() (kvrpcpb.AdminOpState, string) {
+	op.Lock()
+	defer op.Unlock()
+	return op.state, op.err
+}
+
+func (op *adminOp) finish(err error) {
+	op.Lock()
+	defer op.Unlock()
+	op.state = kvrpcpb.AdminOpState_AdminOpFinished
+	if err != nil {
+		op.state = kvrpcpb.AdminOpState_AdminOpFailed
+		op.err = err.Error()
+	}
+	op.finished = time.Now()
+}
+
+// adminOps keeps the admin operations by their tokens, so a retried request doesn't run an operation twice. The
+// state of an operation is kept for a while after it finishes.
+type adminOps struct {
+	sync.Mutex
+	ops map[string]*adminOp
+}
+
+func newAdminOps() *adminOps {
+	return &adminOps{ops: make(map[string]*adminOp)}
+}
+
+func (ops *adminOps) get(token string) *adminOp {
+	ops.Lock()
+	defer ops.Unlock()
+	return ops.ops[token]
+}
+
+// add adds a running operation with the token, it returns the known operation with the token and false if there is
+// one.
+func (ops *adminOps) add(token string, tp kvrpcpb.AdminOpType, regionID uint64) (*adminOp, bool) {
+	ops.Lock()
+	defer ops.Unlock()
+	now := time.Now()
+	for key, op := range ops.ops {
+		op.Lock()
+		if op.state != kvrpcpb.AdminOpState_AdminOpRunning && now.Sub(op.finished) > adminOpTTL {
+			delete(ops.ops, key)
+		}
+		op.Unlock()
+	}
+	if op, ok := ops.ops[token]; ok {
+		return op, false
+	}
+	op := &adminOp{tp: tp, regionID: regionID, state: kvrpcpb.AdminOpState_AdminOpRunning}
+	ops.ops[token] = op
+	return op, true
+}
+
+// AdminOp starts an admin operation in the background, the caller polls its state with AdminOpStatus. A request
+// with the token of a known operation returns its state, so the request can be retried safely.
+func (server *Server) AdminOp(_ context.Context, req *kvrpcpb.AdminOpRequest) (*kvrpcpb.AdminOpResponse, error) {
+	resp := new(kvrpcpb.AdminOpResponse)
+	if req.Token == "" {
+		resp.Error = "the token of the operation is empty"
+		return resp, nil
+	}
+	s := server.innerStorage()
+	admin, ok := s.(adminStorage)
+	if !ok {
+		resp.Error = "the storage doesn't support admin operations"
+		return resp, nil
+	}
+	op, ok := server.adminOps.add(req.Token, req.Type, req.RegionId)
+	if !ok {
+		if op.tp != req.Type || op.regionID != req.RegionId {
+			resp.Error = fmt.Sprintf("the token %s is used by the %v operation of region %d", req.Token, op.tp, op.regionID)
+			return resp, nil
+		}
+		resp.State, resp.Error = op.status()
+		return resp, nil
+	}
+	go func() {
+		err := runAdminOp(admin, req)
+		if err != nil {
+			log.Warnf("admin operation %s %v of region %d failed: %v", req.Token, req.Type, req.RegionId, err)
+		}
+		op.finish(err)
+	}()
+	resp.State = kvrpcpb.AdminOpState_AdminOpRunning
+	return resp, nil
+}
+
+func runAdminOp(admin adminStorage, req *kvrpcpb.AdminOpRequest) error {
+	regionID := req.RegionId
+	switch req.Type {
+	case kvrpcpb.AdminOpType_CompactRegionLog:
+		return admin.CompactRegionLog(regionID)
+	case kvrpcpb.AdminOpType_FlushEngine:
+		return admin.FlushEngines()
+	case kvrpcpb.AdminOpType_TriggerSplitCheck:
+		return admin.TriggerSplitCheck(regionID)
+	case kvrpcpb.AdminOpType_TriggerConsistencyCheck:
+		// the replicas are compared by the hashes in their apply watermarks, see ApplyWatermark
+		return admin.HashRegion(regionID)
+	case kvrpcpb.AdminOpType_RecreatePeer:
+		return admin.RecreatePeer(regionID, req.StoreId)
+	}
+	return fmt.Errorf("unknown admin operation %v", req.Type)
+}
+
+// AdminOpStatus returns the state of the admin operation with the token.
+func (server *Server) AdminOpStatus(_ context.Context, req *kvrpcpb.AdminOpStatusRequest) (*kvrpcpb.AdminOpStatusResponse, error) {
+	resp := new(kvrpcpb.AdminOpStatusResponse)
+	op := server.adminOps.get(req.Token)
+	if op == nil {
+		resp.State = kvrpcpb.AdminOpState_AdminOpNone
+		return resp, nil
+	}
+	resp.State, resp.Error = op.status()
+	return resp, nil
+}
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/kv/storage"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockAdminStorage struct {
+	storage.Storage
+	compacted chan uint64
+	flushes   int
+	recreated []uint64
+}
+
+func (s *mockAdminStorage) CompactRegionLog(regionID uint64) error {
+	s.compacted <- regionID
+	return nil
+}
+
+func (s *mockAdminStorage) TriggerSplitCheck(regionID uint64) error {
+	return errors.New("not leader")
+}
+
+func (s *mockAdminStorage) FlushEngines() error {
+	s.flushes++
+	return nil
+}
+
+func (s *mockAdminStorage) HashRegion(regionID uint64) error {
+	return errors.New("region not found")
+}
+
+func (s *mockAdminStorage) RecreatePeer(regionID, storeID uint64) error {
+	s.recreated = []uint64{regionID, storeID}
+	return nil
+}
+
+func waitAdminOp(t *testing.T, server *Server, token string) *kvrpcpb.AdminOpStatusResponse {
+	for i := 0; i < 100; i++ {
+		resp, err := server.AdminOpStatus(context.Background(), &kvrpcpb.AdminOpStatusRequest{Token: token})
+		assert.Nil(t, err)
+		if resp.State != kvrpcpb.AdminOpState_AdminOpRunning {
+			return resp
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("admin operation %s is still running", token)
+	return nil
+}
+
+func TestAdminOp(t *testing.T) {
+	s := &mockAdminStorage{compacted: make(chan uint64)}
+	server := NewServer(s)
+	ctx := context.Background()
+
+	resp, _ := server.AdminOp(ctx, &kvrpcpb.AdminOpRequest{Type: kvrpcpb.AdminOpType_FlushEngine})
+	assert.NotEmpty(t, resp.Error)
+	status, _ := server.AdminOpStatus(ctx, &kvrpcpb.AdminOpStatusRequest{Token: "a"})
+	assert.Equal(t, kvrpcpb.AdminOpState_AdminOpNone, status.State)
+
+	req := &kvrpcpb.AdminOpRequest{Token: "a", Type: kvrpcpb.AdminOpType_CompactRegionLog, RegionId: 2}
+	resp, _ = server.AdminOp(ctx, req)
+	assert.Equal(t, kvrpcpb.AdminOpState_AdminOpRunning, resp.State)
+	// a retried request doesn't start the operation again
+	resp, _ = server.AdminOp(ctx, req)
+	assert.Equal(t, kvrpcpb.AdminOpState_AdminOpRunning, resp.State)
+	assert.Empty(t, resp.Error)
+	// the token of another operation
+	resp, _ = server.AdminOp(ctx, &kvrpcpb.AdminOpRequest{Token: "a", Type: kvrpcpb.AdminOpType_CompactRegionLog, RegionId: 3})
+	assert.NotEmpty(t, resp.Error)
+	assert.Equal(t, uint64(2), <-s.compacted)
+	assert.Equal(t, kvrpcpb.AdminOpState_AdminOpFinished, waitAdminOp(t, server, "a").State)
+	resp, _ = server.AdminOp(ctx, req)
+	assert.Equal(t, kvrpcpb.AdminOpState_AdminOpFinished, resp.State)
+	select {
+	case <-s.compacted:
+		t.Fatal("the operation ran twice")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	server.AdminOp(ctx, &kvrpcpb.AdminOpRequest{Token: "b", Type: kvrpcpb.AdminOpType_FlushEngine})
+	assert.Equal(t, kvrpcpb.AdminOpState_AdminOpFinished, waitAdminOp(t, server, "b").State)
+	assert.Equal(t, 1, s.flushes)
+
+	server.AdminOp(ctx, &kvrpcpb.AdminOpRequest{Token: "c", Type: kvrpcpb.AdminOpType_TriggerSplitCheck, RegionId: 2})
+	status = waitAdminOp(t, server, "c")
+	assert.Equal(t, kvrpcpb.AdminOpState_AdminOpFailed, status.State)
+	assert.Equal(t, "not leader", status.Error)
+
+	server.AdminOp(ctx, &kvrpcpb.AdminOpRequest{Token: "d", Type: kvrpcpb.AdminOpType_TriggerConsistencyCheck, RegionId: 2})
+	status = waitAdminOp(t, server, "d")
+	assert.Equal(t, kvrpcpb.AdminOpState_AdminOpFailed, status.State)
+	assert.Equal(t, "region not found", status.Error)
+
+	server.AdminOp(ctx, &kvrpcpb.AdminOpRequest{Token: "e", Type: kvrpcpb.AdminOpType_RecreatePeer, RegionId: 2, StoreId: 3})
+	assert.Equal(t, kvrpcpb.AdminOpState_AdminOpFinished, waitAdminOp(t, server, "e").State)
+	assert.Equal(t, []uint64{2, 3}, s.recreated)
+}
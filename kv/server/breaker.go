@@ -0,0 +1,197 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	coppb "github.com/pingcap-incubator/tinykv/proto/pkg/coprocessor"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/errorpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"google.golang.org/grpc"
+)
+
+// The requests to a region which is flapping, e.g. electing leaders or splitting over and over, fail with timeouts,
+// stale epochs or not leader errors, and each of them holds a goroutine while it does. Once the errors of a region
+// exceed its error budget, the region breaker fast-fails its new requests for a short cooldown with a
+// RegionUnavailable error, which makes the clients refresh the route of the region and back off.
+
+// regionBreakerState is the error budget of a region in the current window.
+type regionBreakerState struct {
+	windowStart time.Time
+	requests    int
+	errors      int
+	// the region is fast-failed before openUntil
+	openUntil time.Time
+}
+
+type regionBreakers struct {
+	minErrors  int
+	errorRatio float64
+	window     time.Duration
+	cooldown   time.Duration
+
+	mu        sync.Mutex
+	regions   map[uint64]*regionBreakerState
+	lastSweep time.Time
+}
+
+func newRegionBreakers(minErrors int, errorRatio float64, window, cooldown time.Duration) *regionBreakers {
+	return &regionBreakers{
+		minErrors:  minErrors,
+		errorRatio: errorRatio,
+		window:     window,
+		cooldown:   cooldown,
+		regions:    make(map[uint64]*regionBreakerState),
+	}
+}
+
+// allow tells whether a request to the region may be served at now, and if not, the time to retry after.
+func (b *regionBreakers) allow(regionID uint64, now time.Time) (bool, time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	state, ok := b.regions[regionID]
+	if !ok || !now.Before(state.openUntil) {
+		return true, 0
+	}
+	return false, state.openUntil.Sub(now)
+}
+
+// record counts a request served to the region, and trips the breaker of the region if its error budget is spent.
+func (b *regionBreakers) record(regionID uint64, failed bool, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sweep(now)
+	state, ok := b.regions[regionID]
+	if !ok {
+		if !failed {
+			return
+		}
+		state = &regionBreakerState{windowStart: now}
+		b.regions[regionID] = state
+	}
+	if now.Sub(state.windowStart) >= b.window {
+		state.windowStart = now
+		state.requests, state.errors = 0, 0
+	}
+	state.requests++
+	if !failed {
+		return
+	}
+	state.errors++
+	if state.errors >= b.minErrors && float64(state.errors) >= b.errorRatio*float64(state.requests) {
+		state.openUntil = now.Add(b.cooldown)
+		state.windowStart = state.openUntil
+		state.requests, state.errors = 0, 0
+	}
+}
+
+// sweep removes the regions without errors in the last window once a window, so the removed regions don't leak.
+func (b *regionBreakers) sweep(now time.Time) {
+	if now.Sub(b.lastSweep) < b.window {
+		return
+	}
+	b.lastSweep = now
+	for id, state := range b.regions {
+		if now.Sub(state.windowStart) >= b.window && !now.Before(state.openUntil) {
+			delete(b.regions, id)
+		}
+	}
+}
+
+// EnableRegionBreaker fast-fails the requests to a region for cooldown once at least minErrors, and at least
+// errorRatio, of its requests within window failed with timeouts, stale epochs or not leader errors. Only the
+// requests through BreakerInterceptor are counted.
+func (server *Server) EnableRegionBreaker(minErrors int, errorRatio float64, window, cooldown time.Duration) {
+	server.breakers = newRegionBreakers(minErrors, errorRatio, window, cooldown)
+}
+
+// BreakerInterceptor counts the errors of the unary requests to each region, and fast-fails the requests to a
+// region whose breaker is tripped. The requests it can't answer with a region error are only counted.
+func (server *Server) BreakerInterceptor(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if server.breakers == nil {
+		return handler(ctx, req)
+	}
+	r, ok := req.(interface{ GetContext() *kvrpcpb.Context })
+	if !ok || r.GetContext().GetRegionId() == 0 {
+		return handler(ctx, req)
+	}
+	regionID := r.GetContext().GetRegionId()
+	if allowed, retryAfter := server.breakers.allow(regionID, time.Now()); !allowed {
+		if resp := regionUnavailableResponse(req, regionID, retryAfter); resp != nil {
+			return resp, nil
+		}
+	}
+	resp, err := handler(ctx, req)
+	server.breakers.record(regionID, isRegionFailure(ctx, resp, err), time.Now())
+	return resp, err
+}
+
+// isRegionFailure tells whether a request failed because its region is unavailable.
+func isRegionFailure(ctx context.Context, resp interface{}, err error) bool {
+	if err != nil {
+		return ctx.Err() == context.DeadlineExceeded
+	}
+	r, ok := resp.(interface{ GetRegionError() *errorpb.Error })
+	if !ok {
+		return false
+	}
+	regionErr := r.GetRegionError()
+	return regionErr.GetNotLeader() != nil || regionErr.GetEpochNotMatch() != nil || regionErr.GetStaleCommand() != nil
+}
+
+// regionUnavailableResponse returns the response to req failed with a RegionUnavailable error, or nil if the
+// response of req has no region error.
+func regionUnavailableResponse(req interface{}, regionID uint64, retryAfter time.Duration) interface{} {
+	regionErr := &errorpb.Error{
+		Message: fmt.Sprintf("region %d is unavailable, retry after %v", regionID, retryAfter),
+		RegionUnavailable: &errorpb.RegionUnavailable{
+			RegionId:     regionID,
+			RetryAfterMs: uint64(retryAfter / time.Millisecond),
+		},
+	}
+	switch req.(type) {
+	case *kvrpcpb.RawGetRequest:
+		return &kvrpcpb.RawGetResponse{RegionError: regionErr}
+	case *kvrpcpb.RawPutRequest:
+		return &kvrpcpb.RawPutResponse{RegionError: regionErr}
+	case *kvrpcpb.RawDeleteRequest:
+		return &kvrpcpb.RawDeleteResponse{RegionError: regionErr}
+	case *kvrpcpb.RawScanRequest:
+		return &kvrpcpb.RawScanResponse{RegionError: regionErr}
+	case *kvrpcpb.GetRequest:
+		return &kvrpcpb.GetResponse{RegionError: regionErr}
+	case *kvrpcpb.ScanRequest:
+		return &kvrpcpb.ScanResponse{RegionError: regionErr}
+	case *kvrpcpb.StageRequest:
+		return &kvrpcpb.StageResponse{RegionError: regionErr}
+	case *kvrpcpb.PrewriteRequest:
+		return &kvrpcpb.PrewriteResponse{RegionError: regionErr}
+	case *kvrpcpb.CommitRequest:
+		return &kvrpcpb.CommitResponse{RegionError: regionErr}
+	case *kvrpcpb.CheckTxnStatusRequest:
+		return &kvrpcpb.CheckTxnStatusResponse{RegionError: regionErr}
+	case *kvrpcpb.BatchRollbackRequest:
+		return &kvrpcpb.BatchRollbackResponse{RegionError: regionErr}
+	case *kvrpcpb.ResolveLockRequest:
+		return &kvrpcpb.ResolveLockResponse{RegionError: regionErr}
+	case *kvrpcpb.ScanLockRequest:
+		return &kvrpcpb.ScanLockResponse{RegionError: regionErr}
+	case *coppb.Request:
+		return &coppb.Response{RegionError: regionErr}
+	default:
+		return nil
+	}
+}
+
+// UnaryInterceptor is the interceptor of the unary requests, SlaInterceptor around BreakerInterceptor around
+// KeyModeInterceptor, so the fast-failed requests are timed too, and the rejected ones aren't counted as region
+// failures.
+func (server *Server) UnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return server.SlaInterceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return server.BreakerInterceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return server.KeyModeInterceptor(ctx, req, info, handler)
+		})
+	})
+}
@@ -0,0 +1,71 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/errorpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func TestRegionBreakers(t *testing.T) {
+	b := newRegionBreakers(3, 0.5, time.Second, 100*time.Millisecond)
+	now := time.Now()
+	b.record(1, false, now)
+	assert.Empty(t, b.regions)
+
+	// 2 errors of 3 requests
+	b.record(1, true, now)
+	b.record(1, false, now)
+	b.record(1, true, now)
+	allowed, _ := b.allow(1, now)
+	assert.True(t, allowed)
+	// 3 errors of 6 requests in a new window don't trip the breaker with the old ones
+	for i := 0; i < 3; i++ {
+		b.record(1, false, now.Add(time.Second))
+		b.record(1, true, now.Add(time.Second))
+	}
+	allowed, retryAfter := b.allow(1, now.Add(time.Second))
+	assert.False(t, allowed)
+	assert.Equal(t, 100*time.Millisecond, retryAfter)
+	allowed, _ = b.allow(2, now.Add(time.Second))
+	assert.True(t, allowed)
+	allowed, _ = b.allow(1, now.Add(1100*time.Millisecond))
+	assert.True(t, allowed)
+
+	// the regions without errors are swept
+	b.record(2, false, now.Add(3*time.Second))
+	assert.Empty(t, b.regions)
+}
+
+func TestBreakerInterceptor(t *testing.T) {
+	server := new(Server)
+	server.EnableRegionBreaker(2, 0.5, time.Minute, time.Minute)
+	notLeader := func(context.Context, interface{}) (interface{}, error) {
+		return &kvrpcpb.GetResponse{RegionError: &errorpb.Error{NotLeader: &errorpb.NotLeader{RegionId: 1}}}, nil
+	}
+	req := &kvrpcpb.GetRequest{Context: &kvrpcpb.Context{RegionId: 1}}
+	for i := 0; i < 2; i++ {
+		resp, err := server.BreakerInterceptor(context.Background(), req, new(grpc.UnaryServerInfo), notLeader)
+		assert.Nil(t, err)
+		assert.NotNil(t, resp.(*kvrpcpb.GetResponse).RegionError.NotLeader)
+	}
+	resp, err := server.BreakerInterceptor(context.Background(), req, new(grpc.UnaryServerInfo), notLeader)
+	assert.Nil(t, err)
+	unavailable := resp.(*kvrpcpb.GetResponse).RegionError.RegionUnavailable
+	assert.Equal(t, uint64(1), unavailable.RegionId)
+	assert.True(t, unavailable.RetryAfterMs > 0)
+
+	// a request without a region error response is served
+	called := false
+	_, err = server.BreakerInterceptor(context.Background(), &kvrpcpb.RangeLockRequest{Context: &kvrpcpb.Context{RegionId: 1}},
+		new(grpc.UnaryServerInfo), func(context.Context, interface{}) (interface{}, error) {
+			called = true
+			return new(kvrpcpb.RangeLockResponse), nil
+		})
+	assert.Nil(t, err)
+	assert.True(t, called)
+}
@@ -0,0 +1,166 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The raw API writes the keys as they are, while the transactional API encodes them with timestamps in the default
+// and write CFs and keeps its locks in the lock CF, so a raw write to a key of a transaction corrupts the MVCC data.
+// The key mode guard splits the keys into raw and transactional keyspaces by prefix, and rejects the requests which
+// access a keyspace of the other API. The keys out of both keyspaces may be accessed by both APIs, but the raw API
+// may never write the lock and write CFs. A scan is checked by its start keys.
+
+type keyMode int
+
+const (
+	keyModeAny keyMode = iota
+	keyModeRaw
+	keyModeTxn
+)
+
+func (m keyMode) String() string {
+	switch m {
+	case keyModeRaw:
+		return "raw"
+	case keyModeTxn:
+		return "transactional"
+	}
+	return "any"
+}
+
+type keyModes struct {
+	rawPrefixes [][]byte
+	txnPrefixes [][]byte
+}
+
+// mode returns the keyspace of the key.
+func (m *keyModes) mode(key []byte) keyMode {
+	for _, prefix := range m.rawPrefixes {
+		if bytes.HasPrefix(key, prefix) {
+			return keyModeRaw
+		}
+	}
+	for _, prefix := range m.txnPrefixes {
+		if bytes.HasPrefix(key, prefix) {
+			return keyModeTxn
+		}
+	}
+	return keyModeAny
+}
+
+// checkKeys returns the error of the first key in the keyspace of the other API than mode, or "" if there is none.
+func (m *keyModes) checkKeys(mode keyMode, keys ...[]byte) string {
+	for _, key := range keys {
+		if keyMode := m.mode(key); keyMode != keyModeAny && keyMode != mode {
+			return fmt.Sprintf("key %q is in a %v keyspace, it can't be accessed by the %v API", key, keyMode, mode)
+		}
+	}
+	return ""
+}
+
+// checkRawWrite is checkKeys of a raw write to the CF.
+func (m *keyModes) checkRawWrite(cf string, key []byte) string {
+	if cf == engine_util.CfLock || cf == engine_util.CfWrite || cf == engine_util.CfRawTombstone {
+		return fmt.Sprintf("the %s CF can't be written by the raw API", cf)
+	}
+	return m.checkKeys(keyModeRaw, key)
+}
+
+// rangeStartKeys returns the start key of a scan and the start keys of its ranges.
+func rangeStartKeys(startKey []byte, ranges []*kvrpcpb.KeyRange) [][]byte {
+	keys := [][]byte{startKey}
+	for _, r := range ranges {
+		keys = append(keys, r.StartKey)
+	}
+	return keys
+}
+
+func mutationKeys(mutations []*kvrpcpb.Mutation) [][]byte {
+	keys := make([][]byte, 0, len(mutations))
+	for _, m := range mutations {
+		keys = append(keys, m.Key)
+	}
+	return keys
+}
+
+// check returns the response to req failed with the error of the key it accesses in the wrong keyspace, nil if it
+// may be served. A request whose response carries no error is failed with a FailedPrecondition error instead.
+func (m *keyModes) check(req interface{}) (interface{}, error) {
+	switch req := req.(type) {
+	case *kvrpcpb.RawGetRequest:
+		if msg := m.checkKeys(keyModeRaw, req.Key); msg != "" {
+			return &kvrpcpb.RawGetResponse{Error: msg}, nil
+		}
+	case *kvrpcpb.RawPutRequest:
+		if msg := m.checkRawWrite(req.Cf, req.Key); msg != "" {
+			return &kvrpcpb.RawPutResponse{Error: msg}, nil
+		}
+	case *kvrpcpb.RawDeleteRequest:
+		if msg := m.checkRawWrite(req.Cf, req.Key); msg != "" {
+			return &kvrpcpb.RawDeleteResponse{Error: msg}, nil
+		}
+	case *kvrpcpb.RawUndeleteRequest:
+		if msg := m.checkRawWrite(req.Cf, req.Key); msg != "" {
+			return &kvrpcpb.RawUndeleteResponse{Error: msg}, nil
+		}
+	case *kvrpcpb.RawScanRequest:
+		if msg := m.checkKeys(keyModeRaw, rangeStartKeys(req.StartKey, req.Ranges)...); msg != "" {
+			return &kvrpcpb.RawScanResponse{Error: msg}, nil
+		}
+	case *kvrpcpb.GetRequest:
+		if msg := m.checkKeys(keyModeTxn, req.Key); msg != "" {
+			return &kvrpcpb.GetResponse{Error: &kvrpcpb.KeyError{Abort: msg}}, nil
+		}
+	case *kvrpcpb.ScanRequest:
+		if msg := m.checkKeys(keyModeTxn, rangeStartKeys(req.StartKey, req.Ranges)...); msg != "" {
+			return &kvrpcpb.ScanResponse{Pairs: []*kvrpcpb.KvPair{{Key: req.StartKey, Error: &kvrpcpb.KeyError{Abort: msg}}}}, nil
+		}
+	case *kvrpcpb.StageRequest:
+		if msg := m.checkKeys(keyModeTxn, mutationKeys(req.Mutations)...); msg != "" {
+			return &kvrpcpb.StageResponse{Error: &kvrpcpb.KeyError{Abort: msg}}, nil
+		}
+	case *kvrpcpb.PrewriteRequest:
+		if msg := m.checkKeys(keyModeTxn, mutationKeys(req.Mutations)...); msg != "" {
+			return &kvrpcpb.PrewriteResponse{Errors: []*kvrpcpb.KeyError{{Abort: msg}}}, nil
+		}
+	case *kvrpcpb.CommitRequest:
+		if msg := m.checkKeys(keyModeTxn, req.Keys...); msg != "" {
+			return &kvrpcpb.CommitResponse{Error: &kvrpcpb.KeyError{Abort: msg}}, nil
+		}
+	case *kvrpcpb.BatchRollbackRequest:
+		if msg := m.checkKeys(keyModeTxn, req.Keys...); msg != "" {
+			return &kvrpcpb.BatchRollbackResponse{Error: &kvrpcpb.KeyError{Abort: msg}}, nil
+		}
+	case *kvrpcpb.CheckTxnStatusRequest:
+		if msg := m.checkKeys(keyModeTxn, req.PrimaryKey); msg != "" {
+			return nil, status.Error(codes.FailedPrecondition, msg)
+		}
+	}
+	return nil, nil
+}
+
+// EnableKeyModeGuard reserves the keys with any of rawPrefixes for the raw API and the keys with any of txnPrefixes
+// for the transactional API. Only the requests through KeyModeInterceptor are checked.
+func (server *Server) EnableKeyModeGuard(rawPrefixes, txnPrefixes [][]byte) {
+	server.keyModes = &keyModes{rawPrefixes: rawPrefixes, txnPrefixes: txnPrefixes}
+}
+
+// KeyModeInterceptor rejects the unary requests which access a keyspace reserved for the other API, see
+// EnableKeyModeGuard.
+func (server *Server) KeyModeInterceptor(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if server.keyModes == nil {
+		return handler(ctx, req)
+	}
+	if resp, err := server.keyModes.check(req); resp != nil || err != nil {
+		return resp, err
+	}
+	return handler(ctx, req)
+}
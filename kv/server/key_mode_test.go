@@ -0,0 +1,64 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestKeyModeInterceptor(t *testing.T) {
+	server := new(Server)
+	server.EnableKeyModeGuard([][]byte{[]byte("r")}, [][]byte{[]byte("t")})
+	served := 0
+	handler := func(context.Context, interface{}) (interface{}, error) {
+		served++
+		return nil, nil
+	}
+	intercept := func(req interface{}) (interface{}, error) {
+		return server.KeyModeInterceptor(context.Background(), req, new(grpc.UnaryServerInfo), handler)
+	}
+
+	// the keys of its own keyspace or out of both keyspaces are served
+	for _, req := range []interface{}{
+		&kvrpcpb.RawPutRequest{Key: []byte("r1"), Cf: engine_util.CfDefault},
+		&kvrpcpb.RawGetRequest{Key: []byte("x1")},
+		&kvrpcpb.RawScanRequest{StartKey: []byte("r"), Ranges: []*kvrpcpb.KeyRange{{StartKey: []byte("r2")}}},
+		&kvrpcpb.GetRequest{Key: []byte("t1")},
+		&kvrpcpb.PrewriteRequest{Mutations: []*kvrpcpb.Mutation{{Key: []byte("t1")}, {Key: []byte("x1")}}},
+		&kvrpcpb.CheckTxnStatusRequest{PrimaryKey: []byte("t1")},
+	} {
+		resp, err := intercept(req)
+		assert.Nil(t, resp)
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, 6, served)
+
+	resp, err := intercept(&kvrpcpb.RawPutRequest{Key: []byte("t1"), Cf: engine_util.CfDefault})
+	assert.Nil(t, err)
+	assert.Contains(t, resp.(*kvrpcpb.RawPutResponse).Error, "transactional keyspace")
+	// the MVCC CFs can't be written by the raw API even out of the transactional keyspace
+	resp, err = intercept(&kvrpcpb.RawDeleteRequest{Key: []byte("x1"), Cf: engine_util.CfWrite})
+	assert.Nil(t, err)
+	assert.Contains(t, resp.(*kvrpcpb.RawDeleteResponse).Error, "write CF")
+	resp, err = intercept(&kvrpcpb.RawUndeleteRequest{Key: []byte("t1"), Cf: engine_util.CfDefault})
+	assert.Nil(t, err)
+	assert.Contains(t, resp.(*kvrpcpb.RawUndeleteResponse).Error, "transactional keyspace")
+	resp, err = intercept(&kvrpcpb.RawScanRequest{StartKey: []byte("r"), Ranges: []*kvrpcpb.KeyRange{{StartKey: []byte("t")}}})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, resp.(*kvrpcpb.RawScanResponse).Error)
+	resp, err = intercept(&kvrpcpb.PrewriteRequest{Mutations: []*kvrpcpb.Mutation{{Key: []byte("t1")}, {Key: []byte("r1")}}})
+	assert.Nil(t, err)
+	assert.Contains(t, resp.(*kvrpcpb.PrewriteResponse).Errors[0].Abort, "raw keyspace")
+	resp, err = intercept(&kvrpcpb.ScanRequest{StartKey: []byte("r1")})
+	assert.Nil(t, err)
+	assert.NotNil(t, resp.(*kvrpcpb.ScanResponse).Pairs[0].Error)
+	_, err = intercept(&kvrpcpb.CheckTxnStatusRequest{PrimaryKey: []byte("r1")})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Equal(t, 6, served)
+}
@@ -0,0 +1,53 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+)
+
+// scanRanges returns the ranges read by a scan request with the given start key and ranges. A request without
+// ranges reads from startKey to the end of the key space. Ranges must be sorted and must not overlap, so that
+// pairs read from them in order are sorted too.
+func scanRanges(startKey []byte, ranges []*kvrpcpb.KeyRange) ([]*kvrpcpb.KeyRange, error) {
+	if len(ranges) == 0 {
+		return []*kvrpcpb.KeyRange{{StartKey: startKey}}, nil
+	}
+	for i, r := range ranges {
+		if len(r.EndKey) != 0 && bytes.Compare(r.StartKey, r.EndKey) >= 0 {
+			return nil, fmt.Errorf("invalid scan range %d: start key %v is not before end key %v", i, r.StartKey, r.EndKey)
+		}
+		if i == 0 {
+			continue
+		}
+		prev := ranges[i-1]
+		if len(prev.EndKey) == 0 || bytes.Compare(prev.EndKey, r.StartKey) > 0 {
+			return nil, fmt.Errorf("invalid scan range %d: overlaps or precedes range %d", i, i-1)
+		}
+	}
+	return ranges, nil
+}
+
+// beforeRangeEnd returns true if key is before the exclusive end key of a range.
+func beforeRangeEnd(key []byte, endKey []byte) bool {
+	return len(endKey) == 0 || bytes.Compare(key, endKey) < 0
+}
+
+// scanMultiRange reads at most limit pairs in total from ranges, in order. scan is called for each range with the
+// number of pairs which may still be read and must not return more than that.
+func scanMultiRange(ranges []*kvrpcpb.KeyRange, limit uint32, scan func(r *kvrpcpb.KeyRange, limit uint32) ([]*kvrpcpb.KvPair, error)) ([]*kvrpcpb.KvPair, error) {
+	var pairs []*kvrpcpb.KvPair
+	for _, r := range ranges {
+		remaining := limit - uint32(len(pairs))
+		if remaining == 0 {
+			break
+		}
+		rangePairs, err := scan(r, remaining)
+		if err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, rangePairs...)
+	}
+	return pairs, nil
+}
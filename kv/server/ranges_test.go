@@ -0,0 +1,56 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScanRanges(t *testing.T) {
+	ranges, err := scanRanges([]byte{1}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []*kvrpcpb.KeyRange{{StartKey: []byte{1}}}, ranges)
+
+	valid := []*kvrpcpb.KeyRange{{StartKey: []byte{1}, EndKey: []byte{3}}, {StartKey: []byte{3}, EndKey: []byte{4}}, {StartKey: []byte{8}}}
+	ranges, err = scanRanges([]byte{1}, valid)
+	assert.Nil(t, err)
+	assert.Equal(t, valid, ranges)
+
+	for _, invalid := range [][]*kvrpcpb.KeyRange{
+		{{StartKey: []byte{3}, EndKey: []byte{3}}},
+		{{StartKey: []byte{1}, EndKey: []byte{5}}, {StartKey: []byte{4}, EndKey: []byte{6}}},
+		{{StartKey: []byte{5}, EndKey: []byte{6}}, {StartKey: []byte{1}, EndKey: []byte{2}}},
+		{{StartKey: []byte{1}}, {StartKey: []byte{4}}},
+	} {
+		_, err = scanRanges(nil, invalid)
+		assert.NotNil(t, err)
+	}
+}
+
+func TestScanMultiRange(t *testing.T) {
+	ranges := []*kvrpcpb.KeyRange{{StartKey: []byte{1}, EndKey: []byte{3}}, {StartKey: []byte{5}, EndKey: []byte{8}}, {StartKey: []byte{9}}}
+	keys := [][]byte{{1}, {2}, {4}, {5}, {6}, {7}, {9}}
+	scan := func(r *kvrpcpb.KeyRange, limit uint32) ([]*kvrpcpb.KvPair, error) {
+		var pairs []*kvrpcpb.KvPair
+		for _, key := range keys {
+			if uint32(len(pairs)) == limit {
+				break
+			}
+			if string(key) >= string(r.StartKey) && beforeRangeEnd(key, r.EndKey) {
+				pairs = append(pairs, &kvrpcpb.KvPair{Key: key})
+			}
+		}
+		return pairs, nil
+	}
+
+	pairs, err := scanMultiRange(ranges, 10, scan)
+	assert.Nil(t, err)
+	assert.Equal(t, 6, len(pairs))
+	assert.Equal(t, []byte{9}, pairs[5].Key)
+
+	pairs, err = scanMultiRange(ranges, 4, scan)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(pairs))
+	assert.Equal(t, []byte{6}, pairs[3].Key)
+}
@@ -0,0 +1,126 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/kv/storage"
+	"github.com/pingcap-incubator/tinykv/kv/storage/raft_storage"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/tinykvpb"
+	"github.com/pingcap/errors"
+)
+
+const (
+	// regionDumpPageSize is the default max number of entries of a RegionDump response.
+	regionDumpPageSize = 1024
+	// regionDumpWaitTimeout bounds the wait for the min applied index of a RegionDump.
+	regionDumpWaitTimeout = 3 * time.Second
+	// regionDumpRetryInterval is the interval of reading the applied index again while waiting for it.
+	regionDumpRetryInterval = 10 * time.Millisecond
+)
+
+// RegionDump streams the data of the CFs of a region, read from a single snapshot whose applied index is at least
+// the requested one.
+func (server *Server) RegionDump(req *kvrpcpb.RegionDumpRequest, stream tinykvpb.TinyKv_RegionDumpServer) error {
+	cfs := req.Cfs
+	if len(cfs) == 0 {
+		cfs = engine_util.CFs[:]
+	}
+	for _, cf := range cfs {
+		if !isEngineCF(cf) {
+			return fmt.Errorf("unknown CF %q", cf)
+		}
+	}
+	pageSize := int(req.PageSize)
+	if pageSize == 0 {
+		pageSize = regionDumpPageSize
+	}
+
+	reader, applied, err := server.readerAtIndex(stream.Context(), req.Context, req.MinAppliedIndex)
+	if err != nil {
+		if regionErr, ok := err.(*raft_storage.RegionError); ok {
+			return stream.Send(&kvrpcpb.RegionDumpResponse{RegionError: regionErr.RequestErr})
+		}
+		return err
+	}
+	defer reader.Close()
+
+	resp := &kvrpcpb.RegionDumpResponse{AppliedIndex: applied, Region: readerRegion(reader)}
+	sent := false
+	for _, cf := range cfs {
+		iter := reader.IterCF(cf)
+		for iter.Seek(nil); iter.Valid(); iter.Next() {
+			item := iter.Item()
+			value, err := item.ValueCopy(nil)
+			if err != nil {
+				iter.Close()
+				return err
+			}
+			resp.Entries = append(resp.Entries, &kvrpcpb.RegionDumpEntry{Cf: cf, Key: item.KeyCopy(nil), Value: value})
+			if len(resp.Entries) < pageSize {
+				continue
+			}
+			if err := stream.Send(resp); err != nil {
+				iter.Close()
+				return err
+			}
+			sent = true
+			resp = &kvrpcpb.RegionDumpResponse{AppliedIndex: applied}
+		}
+		iter.Close()
+	}
+	if len(resp.Entries) > 0 || !sent {
+		return stream.Send(resp)
+	}
+	return nil
+}
+
+// readerAtIndex returns a reader of the region whose applied index is at least minApplied, and its applied index.
+// It reads again until the index is applied, at most for regionDumpWaitTimeout.
+func (server *Server) readerAtIndex(ctx context.Context, reqCtx *kvrpcpb.Context, minApplied uint64) (storage.StorageReader, uint64, error) {
+	deadline := time.Now().Add(regionDumpWaitTimeout)
+	for {
+		reader, err := server.storage.Reader(reqCtx)
+		if err != nil {
+			return nil, 0, err
+		}
+		var inner storage.StorageReader = reader
+		if wrapper, ok := reader.(interface{ Inner() storage.StorageReader }); ok {
+			inner = wrapper.Inner()
+		}
+		indexReader, ok := inner.(applyIndexReader)
+		if !ok {
+			reader.Close()
+			return nil, 0, errors.New("storage does not support RegionDump")
+		}
+		applied, err := indexReader.ApplyIndex()
+		if err != nil {
+			reader.Close()
+			return nil, 0, err
+		}
+		if applied >= minApplied {
+			return reader, applied, nil
+		}
+		reader.Close()
+		if time.Now().After(deadline) {
+			return nil, 0, errors.Errorf("applied index %d of region %d is behind %d", applied, reqCtx.GetRegionId(), minApplied)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, 0, ctx.Err()
+		case <-time.After(regionDumpRetryInterval):
+		}
+	}
+}
+
+func isEngineCF(cf string) bool {
+	for _, c := range engine_util.CFs {
+		if c == cf {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/kv/storage"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/tinykvpb"
+	"github.com/stretchr/testify/assert"
+)
+
+// appliedStorage is a storage whose applied index advances on each read.
+type appliedStorage struct {
+	*storage.MemStorage
+	applied uint64
+}
+
+type appliedReader struct {
+	storage.StorageReader
+	applied uint64
+}
+
+func (s *appliedStorage) Reader(ctx *kvrpcpb.Context) (storage.StorageReader, error) {
+	s.applied++
+	reader, err := s.MemStorage.Reader(ctx)
+	return &appliedReader{StorageReader: reader, applied: s.applied}, err
+}
+
+func (r *appliedReader) ApplyIndex() (uint64, error) {
+	return r.applied, nil
+}
+
+type dumpStream struct {
+	tinykvpb.TinyKv_RegionDumpServer
+	resps []*kvrpcpb.RegionDumpResponse
+}
+
+func (s *dumpStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *dumpStream) Send(resp *kvrpcpb.RegionDumpResponse) error {
+	s.resps = append(s.resps, resp)
+	return nil
+}
+
+func TestRegionDump(t *testing.T) {
+	mem := storage.NewMemStorage()
+	mem.Set(engine_util.CfDefault, []byte{1}, []byte{10})
+	mem.Set(engine_util.CfDefault, []byte{2}, []byte{20})
+	mem.Set(engine_util.CfWrite, []byte{3}, []byte{30})
+	server := NewServer(&appliedStorage{MemStorage: mem})
+
+	stream := new(dumpStream)
+	req := &kvrpcpb.RegionDumpRequest{
+		Context:         new(kvrpcpb.Context),
+		MinAppliedIndex: 3,
+		Cfs:             []string{engine_util.CfDefault, engine_util.CfWrite},
+		PageSize:        2,
+	}
+	assert.Nil(t, server.RegionDump(req, stream))
+	assert.Equal(t, 2, len(stream.resps))
+	for _, resp := range stream.resps {
+		assert.Equal(t, uint64(3), resp.AppliedIndex)
+	}
+	assert.Equal(t, []*kvrpcpb.RegionDumpEntry{
+		{Cf: engine_util.CfDefault, Key: []byte{1}, Value: []byte{10}},
+		{Cf: engine_util.CfDefault, Key: []byte{2}, Value: []byte{20}},
+	}, stream.resps[0].Entries)
+	assert.Equal(t, []*kvrpcpb.RegionDumpEntry{
+		{Cf: engine_util.CfWrite, Key: []byte{3}, Value: []byte{30}},
+	}, stream.resps[1].Entries)
+
+	// a CF without data
+	stream = new(dumpStream)
+	req = &kvrpcpb.RegionDumpRequest{Context: new(kvrpcpb.Context), Cfs: []string{engine_util.CfLock}}
+	assert.Nil(t, server.RegionDump(req, stream))
+	assert.Equal(t, 1, len(stream.resps))
+	assert.Empty(t, stream.resps[0].Entries)
+
+	req.Cfs = []string{"unknown"}
+	assert.NotNil(t, server.RegionDump(req, new(dumpStream)))
+}
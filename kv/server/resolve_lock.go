@@ -0,0 +1,176 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/log"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/errorpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+)
+
+const (
+	// resolveLockBatchSize is the number of keys a background task commits or rolls back in a request.
+	resolveLockBatchSize = 256
+	// resolveLockTaskTTL is how long the status of a finished task is kept.
+	resolveLockTaskTTL = 10 * time.Minute
+)
+
+type resolveLockTaskKey struct {
+	regionID     uint64
+	startVersion uint64
+}
+
+// resolveLockTask resolves the locks of a transaction in a region in the background, in batches of keys.
+type resolveLockTask struct {
+	sync.Mutex
+	state    kvrpcpb.ResolveLockState
+	total    uint64
+	resolved uint64
+	err      *kvrpcpb.KeyError
+	finished time.Time
+}
+
+func (t *resolveLockTask) status() *kvrpcpb.ResolveLockStatusResponse {
+	t.Lock()
+	defer t.Unlock()
+	return &kvrpcpb.ResolveLockStatusResponse{
+		State:        t.state,
+		TotalKeys:    t.total,
+		ResolvedKeys: t.resolved,
+		Error:        t.err,
+	}
+}
+
+func (t *resolveLockTask) finish(err *kvrpcpb.KeyError) {
+	t.Lock()
+	defer t.Unlock()
+	t.state = kvrpcpb.ResolveLockState_Finished
+	if err != nil {
+		t.state = kvrpcpb.ResolveLockState_Failed
+		t.err = err
+	}
+	t.finished = time.Now()
+}
+
+// resolveLockTasks keeps the background tasks resolving the locks of large transactions, so a client resolving
+// the locks of a huge transaction doesn't time out. The status of a task is kept for a while after it finishes.
+type resolveLockTasks struct {
+	sync.Mutex
+	tasks map[resolveLockTaskKey]*resolveLockTask
+}
+
+func newResolveLockTasks() *resolveLockTasks {
+	return &resolveLockTasks{tasks: make(map[resolveLockTaskKey]*resolveLockTask)}
+}
+
+func (ts *resolveLockTasks) get(regionID, startVersion uint64) *resolveLockTask {
+	ts.Lock()
+	defer ts.Unlock()
+	return ts.tasks[resolveLockTaskKey{regionID, startVersion}]
+}
+
+// add adds a running task of the transaction in the region, it returns false if one is already running.
+func (ts *resolveLockTasks) add(regionID, startVersion uint64, total int) (*resolveLockTask, bool) {
+	ts.Lock()
+	defer ts.Unlock()
+	now := time.Now()
+	for key, task := range ts.tasks {
+		task.Lock()
+		if task.state != kvrpcpb.ResolveLockState_Running && now.Sub(task.finished) > resolveLockTaskTTL {
+			delete(ts.tasks, key)
+		}
+		task.Unlock()
+	}
+	key := resolveLockTaskKey{regionID, startVersion}
+	if task, ok := ts.tasks[key]; ok && task.status().State == kvrpcpb.ResolveLockState_Running {
+		return task, false
+	}
+	task := &resolveLockTask{state: kvrpcpb.ResolveLockState_Running, total: uint64(total)}
+	ts.tasks[key] = task
+	return task, true
+}
+
+// resolveLocksAsync starts a background task resolving the locks of keys, which are locked by the transaction of
+// req, and returns the response of req. The keys are resolved in key order, through KvCommit if req commits the
+// transaction or KvBatchRollback otherwise, so the task takes the latches and checks the locks like the client
+// requests do. A region error or key error stops the task, the client gets it from KvResolveLockStatus and
+// resolves the remaining locks again. (Used in 4C)
+func (server *Server) resolveLocksAsync(req *kvrpcpb.ResolveLockRequest, keys [][]byte) *kvrpcpb.ResolveLockResponse {
+	resp := &kvrpcpb.ResolveLockResponse{Async: true}
+	task, ok := server.resolveLocks.add(req.GetContext().GetRegionId(), req.StartVersion, len(keys))
+	if !ok {
+		return resp
+	}
+	sorted := make([][]byte, len(keys))
+	copy(sorted, keys)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i], sorted[j]) < 0
+	})
+	go func() {
+		err := server.resolveLockBatches(req, sorted, task)
+		if err != nil {
+			log.Warnf("resolve locks of txn %d in region %d failed: %v", req.StartVersion, req.GetContext().GetRegionId(), err)
+		}
+		task.finish(err)
+	}()
+	return resp
+}
+
+func (server *Server) resolveLockBatches(req *kvrpcpb.ResolveLockRequest, keys [][]byte, task *resolveLockTask) *kvrpcpb.KeyError {
+	for len(keys) > 0 {
+		n := resolveLockBatchSize
+		if n > len(keys) {
+			n = len(keys)
+		}
+		var err error
+		var regionErr *errorpb.Error
+		var keyErr *kvrpcpb.KeyError
+		if req.CommitVersion == 0 {
+			var resp *kvrpcpb.BatchRollbackResponse
+			resp, err = server.KvBatchRollback(context.Background(), &kvrpcpb.BatchRollbackRequest{
+				Context:      req.Context,
+				StartVersion: req.StartVersion,
+				Keys:         keys[:n],
+			})
+			if resp != nil {
+				regionErr, keyErr = resp.RegionError, resp.Error
+			}
+		} else {
+			var resp *kvrpcpb.CommitResponse
+			resp, err = server.KvCommit(context.Background(), &kvrpcpb.CommitRequest{
+				Context:       req.Context,
+				StartVersion:  req.StartVersion,
+				Keys:          keys[:n],
+				CommitVersion: req.CommitVersion,
+			})
+			if resp != nil {
+				regionErr, keyErr = resp.RegionError, resp.Error
+			}
+		}
+		switch {
+		case err != nil:
+			return &kvrpcpb.KeyError{Abort: err.Error()}
+		case regionErr != nil:
+			return &kvrpcpb.KeyError{Retryable: regionErr.String()}
+		case keyErr != nil:
+			return keyErr
+		}
+		keys = keys[n:]
+		task.Lock()
+		task.resolved += uint64(n)
+		task.Unlock()
+	}
+	return nil
+}
+
+func (server *Server) KvResolveLockStatus(_ context.Context, req *kvrpcpb.ResolveLockStatusRequest) (*kvrpcpb.ResolveLockStatusResponse, error) {
+	task := server.resolveLocks.get(req.GetContext().GetRegionId(), req.StartVersion)
+	if task == nil {
+		return &kvrpcpb.ResolveLockStatusResponse{State: kvrpcpb.ResolveLockState_NoTask}, nil
+	}
+	return task.status(), nil
+}
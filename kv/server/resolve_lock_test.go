@@ -0,0 +1,36 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveLockTasks(t *testing.T) {
+	tasks := newResolveLockTasks()
+	assert.Nil(t, tasks.get(1, 10))
+
+	task, ok := tasks.add(1, 10, 300)
+	assert.True(t, ok)
+	_, ok = tasks.add(1, 10, 300)
+	assert.False(t, ok)
+	_, ok = tasks.add(2, 10, 300)
+	assert.True(t, ok)
+
+	task.resolved = 256
+	status := tasks.get(1, 10).status()
+	assert.Equal(t, kvrpcpb.ResolveLockState_Running, status.State)
+	assert.Equal(t, uint64(300), status.TotalKeys)
+	assert.Equal(t, uint64(256), status.ResolvedKeys)
+
+	task.finish(&kvrpcpb.KeyError{Abort: "stopped"})
+	status = tasks.get(1, 10).status()
+	assert.Equal(t, kvrpcpb.ResolveLockState_Failed, status.State)
+	assert.Equal(t, "stopped", status.Error.Abort)
+
+	// a failed task can be started again
+	_, ok = tasks.add(1, 10, 44)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(44), tasks.get(1, 10).status().TotalKeys)
+}
@@ -0,0 +1,121 @@
+package server
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/pingcap-incubator/tinykv/kv/storage"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/errorpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+)
+
+const (
+	scanTokenFormat    byte = 1
+	scanTokenHeaderLen      = 1 + 3*8
+)
+
+// scanToken is the position a paged scan goes on from, it's sent to clients as an opaque continuation token. The
+// region and epoch are of the region the last page is read from.
+type scanToken struct {
+	regionID uint64
+	epoch    metapb.RegionEpoch
+	lastKey  []byte
+}
+
+func encodeScanToken(region *metapb.Region, lastKey []byte) []byte {
+	token := make([]byte, scanTokenHeaderLen, scanTokenHeaderLen+len(lastKey))
+	token[0] = scanTokenFormat
+	binary.BigEndian.PutUint64(token[1:], region.GetId())
+	binary.BigEndian.PutUint64(token[9:], region.GetRegionEpoch().GetConfVer())
+	binary.BigEndian.PutUint64(token[17:], region.GetRegionEpoch().GetVersion())
+	return append(token, lastKey...)
+}
+
+func decodeScanToken(token []byte) (*scanToken, error) {
+	if len(token) < scanTokenHeaderLen || token[0] != scanTokenFormat {
+		return nil, fmt.Errorf("invalid continuation token %v", token)
+	}
+	return &scanToken{
+		regionID: binary.BigEndian.Uint64(token[1:]),
+		epoch: metapb.RegionEpoch{
+			ConfVer: binary.BigEndian.Uint64(token[9:]),
+			Version: binary.BigEndian.Uint64(token[17:]),
+		},
+		lastKey: token[scanTokenHeaderLen:],
+	}, nil
+}
+
+// resumeKey is the first key after the last key read.
+func (t *scanToken) resumeKey() []byte {
+	key := make([]byte, len(t.lastKey)+1)
+	copy(key, t.lastKey)
+	return key
+}
+
+// check returns a region error if the scan can't go on in the region, which happens if the region is split or
+// merged between the pages. The error has the resume key, so the client can send the token to the region holding it.
+func (t *scanToken) check(region *metapb.Region) *errorpb.Error {
+	if region == nil {
+		return nil
+	}
+	if t.regionID == region.Id && t.epoch.Version == region.GetRegionEpoch().GetVersion() {
+		return nil
+	}
+	key := t.resumeKey()
+	if bytes.Compare(key, region.StartKey) >= 0 && beforeRangeEnd(key, region.EndKey) {
+		return nil
+	}
+	return &errorpb.Error{
+		Message: fmt.Sprintf("scan resumes at key %v which is not in region %d", key, region.Id),
+		KeyNotInRegion: &errorpb.KeyNotInRegion{
+			Key:      key,
+			RegionId: region.Id,
+			StartKey: region.StartKey,
+			EndKey:   region.EndKey,
+		},
+	}
+}
+
+// resumeRanges returns the part of ranges after the last key of the token.
+func resumeRanges(ranges []*kvrpcpb.KeyRange, token *scanToken) []*kvrpcpb.KeyRange {
+	key := token.resumeKey()
+	var resumed []*kvrpcpb.KeyRange
+	for _, r := range ranges {
+		if !beforeRangeEnd(key, r.EndKey) {
+			continue
+		}
+		if bytes.Compare(r.StartKey, key) < 0 {
+			r = &kvrpcpb.KeyRange{StartKey: key, EndKey: r.EndKey}
+		}
+		resumed = append(resumed, r)
+	}
+	return resumed
+}
+
+// pageScan sets the continuation token of a scan response whose pairs reached the limit, or the next region start
+// key if the scanned ranges go on after the end of the region. region is nil if the storage isn't region based.
+func pageScan(resp *kvrpcpb.ScanResponse, region *metapb.Region, ranges []*kvrpcpb.KeyRange, limit uint32) {
+	if limit > 0 && uint32(len(resp.Pairs)) >= limit {
+		resp.ContinuationToken = encodeScanToken(region, resp.Pairs[len(resp.Pairs)-1].Key)
+		return
+	}
+	if region == nil || len(region.EndKey) == 0 || len(ranges) == 0 {
+		return
+	}
+	if last := ranges[len(ranges)-1]; len(last.EndKey) == 0 || bytes.Compare(last.EndKey, region.EndKey) > 0 {
+		resp.NextRegionStartKey = region.EndKey
+	}
+}
+
+// readerRegion returns the region the reader reads, nil if the storage isn't region based.
+func readerRegion(reader storage.StorageReader) *metapb.Region {
+	if wrapper, ok := reader.(interface{ Inner() storage.StorageReader }); ok {
+		reader = wrapper.Inner()
+	}
+	if r, ok := reader.(regionReader); ok {
+		return r.Region()
+	}
+	return nil
+}
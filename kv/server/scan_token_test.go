@@ -0,0 +1,61 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScanToken(t *testing.T) {
+	region := &metapb.Region{Id: 2, StartKey: []byte{2}, EndKey: []byte{8}, RegionEpoch: &metapb.RegionEpoch{ConfVer: 1, Version: 3}}
+	token, err := decodeScanToken(encodeScanToken(region, []byte{4}))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), token.regionID)
+	assert.Equal(t, uint64(3), token.epoch.Version)
+	assert.Equal(t, []byte{4, 0}, token.resumeKey())
+	assert.Nil(t, token.check(region))
+
+	_, err = decodeScanToken([]byte{1, 2, 3})
+	assert.NotNil(t, err)
+
+	// the region is split after the first page
+	left := &metapb.Region{Id: 2, StartKey: []byte{2}, EndKey: []byte{4}, RegionEpoch: &metapb.RegionEpoch{ConfVer: 1, Version: 4}}
+	right := &metapb.Region{Id: 5, StartKey: []byte{4}, EndKey: []byte{8}, RegionEpoch: &metapb.RegionEpoch{ConfVer: 1, Version: 4}}
+	regionErr := token.check(left)
+	assert.NotNil(t, regionErr)
+	assert.Equal(t, []byte{4, 0}, regionErr.KeyNotInRegion.Key)
+	assert.Nil(t, token.check(right))
+}
+
+func TestResumeRanges(t *testing.T) {
+	token := &scanToken{lastKey: []byte{4}}
+	ranges := []*kvrpcpb.KeyRange{{StartKey: []byte{1}, EndKey: []byte{3}}, {StartKey: []byte{3}, EndKey: []byte{6}}, {StartKey: []byte{8}}}
+	assert.Equal(t, []*kvrpcpb.KeyRange{{StartKey: []byte{4, 0}, EndKey: []byte{6}}, {StartKey: []byte{8}}}, resumeRanges(ranges, token))
+	assert.Equal(t, []*kvrpcpb.KeyRange{{StartKey: []byte{4, 0}}}, resumeRanges([]*kvrpcpb.KeyRange{{StartKey: []byte{1}}}, token))
+}
+
+func TestPageScan(t *testing.T) {
+	region := &metapb.Region{Id: 2, StartKey: []byte{2}, EndKey: []byte{8}, RegionEpoch: &metapb.RegionEpoch{ConfVer: 1, Version: 3}}
+	ranges := []*kvrpcpb.KeyRange{{StartKey: []byte{2}}}
+	pairs := []*kvrpcpb.KvPair{{Key: []byte{3}}, {Key: []byte{5}}}
+
+	resp := &kvrpcpb.ScanResponse{Pairs: pairs}
+	pageScan(resp, region, ranges, 2)
+	token, err := decodeScanToken(resp.ContinuationToken)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{5}, token.lastKey)
+	assert.Nil(t, resp.NextRegionStartKey)
+
+	resp = &kvrpcpb.ScanResponse{Pairs: pairs}
+	pageScan(resp, region, ranges, 10)
+	assert.Nil(t, resp.ContinuationToken)
+	assert.Equal(t, []byte{8}, resp.NextRegionStartKey)
+
+	// the ranges end in the region
+	resp = &kvrpcpb.ScanResponse{Pairs: pairs}
+	pageScan(resp, region, []*kvrpcpb.KeyRange{{StartKey: []byte{2}, EndKey: []byte{7}}}, 10)
+	assert.Nil(t, resp.ContinuationToken)
+	assert.Nil(t, resp.NextRegionStartKey)
+}
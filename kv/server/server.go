@@ -2,14 +2,26 @@ package server
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/pingcap-incubator/tinykv/kv/coprocessor"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/keycheck"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/keyfilter"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/lockindex"
 	"github.com/pingcap-incubator/tinykv/kv/storage"
 	"github.com/pingcap-incubator/tinykv/kv/storage/raft_storage"
 	"github.com/pingcap-incubator/tinykv/kv/transaction/latches"
+	"github.com/pingcap-incubator/tinykv/kv/transaction/mvcc"
+	"github.com/pingcap-incubator/tinykv/kv/util/failpoint"
 	coppb "github.com/pingcap-incubator/tinykv/proto/pkg/coprocessor"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/tinykvpb"
+	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/kv"
 )
 
@@ -19,20 +31,86 @@ var _ tinykvpb.TinyKvServer = new(Server)
 type Server struct {
 	storage storage.Storage
 
-	// (Used in 4A/4B)
+	// (Used in 1/4A/4B) the raw writes hold the latches of their keys too, so they are shared with the raw
+	// tombstone GC of a raft storage with the soft delete enabled
 	Latches *latches.Latches
+	// Isolation decides whether a read uses snapshot isolation or read committed (used in 4B/4C)
+	Isolation *mvcc.IsolationPolicy
+	// KeyFilters tells the keys which are surely not in a region, nil if disabled (used in 4B)
+	KeyFilters *keyfilter.KeyFilters
+	// LockIndex answers the lock reads of the regions from memory, nil if disabled (used in 4B/4C)
+	LockIndex *lockindex.LockIndex
+	// RangeLocks is held for reading by prewrites while they check range locks and write their locks, and for
+	// writing while a range lock is placed (used in 4B)
+	RangeLocks sync.RWMutex
+	// AsyncResolveLockThreshold is the number of locks above which a ResolveLock request resolves them in the
+	// background, 0 if disabled (used in 4C)
+	AsyncResolveLockThreshold int
+	// KeyChecker checks the written keys against the TinySQL key layout, nil if disabled
+	KeyChecker *keycheck.KeyChecker
+	// ReadyStats is the time the raft workers spent in each stage of their loops, nil without a raft storage
+	ReadyStats *raftstore.ReadyStats
+	// EnableFailPoints allows the FailPoint RPC to enable failpoints
+	EnableFailPoints bool
+	// RawSoftDeleteWindow is how long a raw delete keeps the deleted value for RawUndelete, 0 if disabled (used in 1)
+	RawSoftDeleteWindow time.Duration
+
+	resolveLocks *resolveLockTasks
+	adminOps     *adminOps
+	// batches the writes of the transactional commands to a region, nil if disabled
+	writes *writeScheduler
+	// the error budgets of the regions, nil if the region breaker is disabled
+	breakers *regionBreakers
+	// the keyspaces of the raw and the transactional APIs, nil if the key mode guard is disabled
+	keyModes *keyModes
 
 	// coprocessor API handler, out of course scope
 	copHandler *coprocessor.CopHandler
+	// cache of DAG responses, nil if disabled
+	copCache *coprocessor.Cache
 }
 
 func NewServer(storage storage.Storage) *Server {
 	return &Server{
-		storage: storage,
-		Latches: latches.NewLatches(),
+		storage:      storage,
+		Latches:      latches.NewLatches(),
+		resolveLocks: newResolveLockTasks(),
+		adminOps:     newAdminOps(),
 	}
 }
 
+// EnableCopCache caches up to capacity bytes of coprocessor DAG responses. Only a storage whose readers report
+// the applied index of their region supports the cache.
+func (server *Server) EnableCopCache(capacity uint64) {
+	server.copCache = coprocessor.NewCache(capacity)
+}
+
+// applyIndexReader is a storage reader which knows the applied index of the region it reads.
+type applyIndexReader interface {
+	ApplyIndex() (uint64, error)
+}
+
+// regionReader is a storage reader which reads a single region.
+type regionReader interface {
+	Region() *metapb.Region
+}
+
+// readIndexer is a storage which knows the committed and applied indexes of its regions.
+type readIndexer interface {
+	ReadIndex(ctx *kvrpcpb.Context, local bool) (uint64, uint64, error)
+}
+
+// peerCreator is a storage which creates the peers of new replicas on demand.
+type peerCreator interface {
+	CreatePeer(region *metapb.Region, peer *metapb.Peer) error
+}
+
+// regionWatcher is a storage which notifies the leader and epoch changes of its regions.
+type regionWatcher interface {
+	WatchRegions(regionIDs []uint64) *raft_storage.RegionWatch
+	Unwatch(w *raft_storage.RegionWatch)
+}
+
 // The below functions are Server's gRPC API (implements TinyKvServer).
 
 // Raw API.
@@ -42,16 +120,55 @@ func (server *Server) RawGet(_ context.Context, req *kvrpcpb.RawGetRequest) (*kv
 }
 
 func (server *Server) RawPut(_ context.Context, req *kvrpcpb.RawPutRequest) (*kvrpcpb.RawPutResponse, error) {
+	// NOTE: if server.RawSoftDeleteWindow > 0, hold the latch of the key while writing it, so a RawUndelete checking
+	// the key isn't put again doesn't restore over the value.
 	// Your Code Here (1).
 	return nil, nil
 }
 
 func (server *Server) RawDelete(_ context.Context, req *kvrpcpb.RawDeleteRequest) (*kvrpcpb.RawDeleteResponse, error) {
+	// NOTE: if server.RawSoftDeleteWindow > 0, write the modifies of storage.RawSoftDelete expiring after the window
+	// instead, holding the latch of the key as RawUndelete does.
 	// Your Code Here (1).
 	return nil, nil
 }
 
+// RawUndelete restores a key deleted by a raw delete in the soft delete mode from its tombstone.
+func (server *Server) RawUndelete(_ context.Context, req *kvrpcpb.RawUndeleteRequest) (*kvrpcpb.RawUndeleteResponse, error) {
+	resp := new(kvrpcpb.RawUndeleteResponse)
+	keys := [][]byte{req.Key}
+	server.Latches.GroupWaitForLatches(keys)
+	defer server.Latches.ReleaseLatches(keys)
+	reader, err := server.storage.Reader(req.Context)
+	if err != nil {
+		if regionErr, ok := err.(*raft_storage.RegionError); ok {
+			resp.RegionError = regionErr.RequestErr
+			return resp, nil
+		}
+		return nil, err
+	}
+	defer reader.Close()
+	modifies, err := storage.RawUndelete(reader, req.Cf, req.Key, time.Now())
+	if err != nil {
+		resp.Error = err.Error()
+		return resp, nil
+	}
+	if modifies == nil {
+		resp.NotFound = true
+		return resp, nil
+	}
+	if err := server.storage.Write(req.Context, modifies); err != nil {
+		if regionErr, ok := err.(*raft_storage.RegionError); ok {
+			resp.RegionError = regionErr.RequestErr
+			return resp, nil
+		}
+		return nil, err
+	}
+	return resp, nil
+}
+
 func (server *Server) RawScan(_ context.Context, req *kvrpcpb.RawScanRequest) (*kvrpcpb.RawScanResponse, error) {
+	// NOTE: a request may carry several ranges in req.Ranges, see scanRanges and scanMultiRange.
 	// Your Code Here (1).
 	return nil, nil
 }
@@ -59,32 +176,97 @@ func (server *Server) RawScan(_ context.Context, req *kvrpcpb.RawScanRequest) (*
 // Raft commands (tinykv <-> tinykv)
 // Only used for RaftStorage, so trivially forward it.
 func (server *Server) Raft(stream tinykvpb.TinyKv_RaftServer) error {
-	return server.storage.(*raft_storage.RaftStorage).Raft(stream)
+	rs, ok := server.raftStorage()
+	if !ok {
+		return errors.New("storage does not support Raft")
+	}
+	return rs.Raft(stream)
 }
 
 // Snapshot stream (tinykv <-> tinykv)
 // Only used for RaftStorage, so trivially forward it.
 func (server *Server) Snapshot(stream tinykvpb.TinyKv_SnapshotServer) error {
-	return server.storage.(*raft_storage.RaftStorage).Snapshot(stream)
+	rs, ok := server.raftStorage()
+	if !ok {
+		return errors.New("storage does not support Snapshot")
+	}
+	return rs.Snapshot(stream)
+}
+
+// CreatePeer creates the peer of a new replica of a region on the store, which waits for a snapshot from the leader
+// to be initialized.
+func (server *Server) CreatePeer(_ context.Context, req *kvrpcpb.CreatePeerRequest) (*kvrpcpb.CreatePeerResponse, error) {
+	resp := new(kvrpcpb.CreatePeerResponse)
+	s := server.innerStorage()
+	creator, ok := s.(peerCreator)
+	if !ok {
+		return nil, errors.New("storage does not support CreatePeer")
+	}
+	if err := creator.CreatePeer(req.Region, req.Peer); err != nil {
+		if regionErr, ok := err.(*raft_storage.RegionError); ok {
+			resp.RegionError = regionErr.RequestErr
+			return resp, nil
+		}
+		resp.Error = err.Error()
+	}
+	return resp, nil
+}
+
+// innerStorage returns the underlying storage, unwrapping a wrapper like AuditStorage.
+func (server *Server) innerStorage() storage.Storage {
+	s := server.storage
+	if wrapper, ok := s.(interface{ Inner() storage.Storage }); ok {
+		s = wrapper.Inner()
+	}
+	return s
+}
+
+// raftStorage returns the underlying RaftStorage, unwrapping a wrapper like AuditStorage, false if the storage
+// doesn't run raft.
+func (server *Server) raftStorage() (*raft_storage.RaftStorage, bool) {
+	rs, ok := server.innerStorage().(*raft_storage.RaftStorage)
+	return rs, ok
 }
 
 // Transactional API.
 func (server *Server) KvGet(_ context.Context, req *kvrpcpb.GetRequest) (*kvrpcpb.GetResponse, error) {
+	// NOTE: a read committed read, see server.Isolation.Level, is not blocked by locks, use Lock.IsLockedForLevel.
+	// The key is not found without reading the write CF if server.KeyFilters.MayContain returns false, the locks
+	// must still be checked. Wrap the reader with mvcc.NewStatsReader and set resp.ExecDetails from it.
+	// The lock of the key may be read with server.LockIndex.GetLock, reading the lock CF if it returns false.
+	// If req.ReadStaged is set, a mutation staged by the transaction, see MvccTxn.GetStaged, is returned first.
 	// Your Code Here (4B).
 	return nil, nil
 }
 
-func (server *Server) KvPrewrite(_ context.Context, req *kvrpcpb.PrewriteRequest) (*kvrpcpb.PrewriteResponse, error) {
+func (server *Server) KvPrewrite(ctx context.Context, req *kvrpcpb.PrewriteRequest) (*kvrpcpb.PrewriteResponse, error) {
+	// NOTE: if req.TryAmend is set, a write committed after req.StartVersion is not a conflict error. Record it
+	// with Lock.Amend and return it in resp.Amended instead; conflicts with other locks are still errors.
+	// A key in a range locked by another transaction is locked too, hold server.RangeLocks.RLock and check
+	// every key with MvccTxn.CheckRangeLock. The locks of the keys may be read with server.LockIndex.GetLock.
+	// Replace the mutations with the Staged op by the staged ones with MvccTxn.TakeStaged first, a missing staged
+	// mutation aborts the transaction.
+	// Take the latches with server.Latches.GroupWaitForLatches, so the concurrent commands take them together, and
+	// count the time waiting for them and server.RangeLocks with recordWait(ctx, start).
+	// Write txn.Writes() with server.write, so the writes are batched with other commands to the region.
 	// Your Code Here (4B).
 	return nil, nil
 }
 
-func (server *Server) KvCommit(_ context.Context, req *kvrpcpb.CommitRequest) (*kvrpcpb.CommitResponse, error) {
+func (server *Server) KvCommit(ctx context.Context, req *kvrpcpb.CommitRequest) (*kvrpcpb.CommitResponse, error) {
+	// NOTE: a lock which amended a write conflict must be checked with Lock.CheckAmendedCommit before committing.
+	// Take the latches with server.Latches.GroupWaitForLatches and count the time waiting for them with
+	// recordWait(ctx, start).
 	// Your Code Here (4B).
 	return nil, nil
 }
 
 func (server *Server) KvScan(_ context.Context, req *kvrpcpb.ScanRequest) (*kvrpcpb.ScanResponse, error) {
+	// NOTE: a request may carry several ranges in req.Ranges, see scanRanges and scanMultiRange.
+	// With a req.ContinuationToken, decode it with decodeScanToken, check it against the region of the reader and
+	// scan resumeRanges of the ranges instead. Set the token or the next region start key with pageScan.
+	// A read committed scan, see server.Isolation.Level, is not blocked by locks, use Lock.IsLockedForLevel.
+	// Wrap the reader with mvcc.NewStatsReader and set resp.ExecDetails from it.
 	// Your Code Here (4C).
 	return nil, nil
 }
@@ -95,15 +277,342 @@ func (server *Server) KvCheckTxnStatus(_ context.Context, req *kvrpcpb.CheckTxnS
 }
 
 func (server *Server) KvBatchRollback(_ context.Context, req *kvrpcpb.BatchRollbackRequest) (*kvrpcpb.BatchRollbackResponse, error) {
+	// NOTE: delete the mutations of the keys staged by the transaction with MvccTxn.DeleteStaged.
 	// Your Code Here (4C).
 	return nil, nil
 }
 
 func (server *Server) KvResolveLock(_ context.Context, req *kvrpcpb.ResolveLockRequest) (*kvrpcpb.ResolveLockResponse, error) {
+	// NOTE: if server.AsyncResolveLockThreshold is set and the transaction has more locks in the region, return
+	// server.resolveLocksAsync(req, keys) instead of resolving them in the request.
 	// Your Code Here (4C).
 	return nil, nil
 }
 
+// KvStage stages mutations of a transaction, which it prewrites later with mutations of the Staged op.
+func (server *Server) KvStage(ctx context.Context, req *kvrpcpb.StageRequest) (*kvrpcpb.StageResponse, error) {
+	resp := new(kvrpcpb.StageResponse)
+	keys := make([][]byte, 0, len(req.Mutations))
+	for _, m := range req.Mutations {
+		keys = append(keys, m.Key)
+	}
+	// a prewrite taking the staged mutations of the keys holds their latches too
+	waitStart := time.Now()
+	server.Latches.GroupWaitForLatches(keys)
+	defer server.Latches.ReleaseLatches(keys)
+	recordWait(ctx, waitStart)
+	reader, err := server.storage.Reader(req.Context)
+	if err != nil {
+		if regionErr, ok := err.(*raft_storage.RegionError); ok {
+			resp.RegionError = regionErr.RequestErr
+			return resp, nil
+		}
+		return nil, err
+	}
+	defer reader.Close()
+	txn := mvcc.NewMvccTxn(reader, req.StartVersion)
+	for _, m := range req.Mutations {
+		if m.Op == kvrpcpb.Op_Staged || m.Op == kvrpcpb.Op_Rollback {
+			resp.Error = &kvrpcpb.KeyError{Abort: fmt.Sprintf("can't stage a mutation of op %s", m.Op)}
+			return resp, nil
+		}
+		txn.PutStaged(m)
+	}
+	if err := server.write(req.Context, txn.Writes()); err != nil {
+		if regionErr, ok := err.(*raft_storage.RegionError); ok {
+			resp.RegionError = regionErr.RequestErr
+			return resp, nil
+		}
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (server *Server) KvRangeLock(ctx context.Context, req *kvrpcpb.RangeLockRequest) (*kvrpcpb.RangeLockResponse, error) {
+	resp := new(kvrpcpb.RangeLockResponse)
+	waitStart := time.Now()
+	server.RangeLocks.Lock()
+	defer server.RangeLocks.Unlock()
+	recordWait(ctx, waitStart)
+	reader, err := server.storage.Reader(req.Context)
+	if err != nil {
+		if regionErr, ok := err.(*raft_storage.RegionError); ok {
+			resp.RegionError = regionErr.RequestErr
+			return resp, nil
+		}
+		return nil, err
+	}
+	defer reader.Close()
+	txn := mvcc.NewMvccTxn(reader, req.StartVersion)
+	keyErr, err := txn.LockRange(req.GetRange().GetStartKey(), req.GetRange().GetEndKey(), req.LockTtl)
+	if err != nil {
+		return nil, err
+	}
+	if keyErr != nil {
+		resp.Error = keyErr
+		return resp, nil
+	}
+	if err := server.write(req.Context, txn.Writes()); err != nil {
+		if regionErr, ok := err.(*raft_storage.RegionError); ok {
+			resp.RegionError = regionErr.RequestErr
+			return resp, nil
+		}
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (server *Server) KvRangeUnlock(ctx context.Context, req *kvrpcpb.RangeUnlockRequest) (*kvrpcpb.RangeUnlockResponse, error) {
+	resp := new(kvrpcpb.RangeUnlockResponse)
+	waitStart := time.Now()
+	server.RangeLocks.Lock()
+	defer server.RangeLocks.Unlock()
+	recordWait(ctx, waitStart)
+	reader, err := server.storage.Reader(req.Context)
+	if err != nil {
+		if regionErr, ok := err.(*raft_storage.RegionError); ok {
+			resp.RegionError = regionErr.RequestErr
+			return resp, nil
+		}
+		return nil, err
+	}
+	defer reader.Close()
+	txn := mvcc.NewMvccTxn(reader, req.StartVersion)
+	if err := txn.UnlockRange(req.StartKey); err != nil {
+		return nil, err
+	}
+	if len(txn.Writes()) == 0 {
+		return resp, nil
+	}
+	if err := server.write(req.Context, txn.Writes()); err != nil {
+		if regionErr, ok := err.(*raft_storage.RegionError); ok {
+			resp.RegionError = regionErr.RequestErr
+			return resp, nil
+		}
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (server *Server) KvScanLock(_ context.Context, req *kvrpcpb.ScanLockRequest) (*kvrpcpb.ScanLockResponse, error) {
+	resp := new(kvrpcpb.ScanLockResponse)
+	reader, err := server.storage.Reader(req.Context)
+	if err != nil {
+		if regionErr, ok := err.(*raft_storage.RegionError); ok {
+			resp.RegionError = regionErr.RequestErr
+			return resp, nil
+		}
+		return nil, err
+	}
+	defer reader.Close()
+	var scanPoints mvcc.PointLockScanner
+	if rr, ok := reader.(regionReader); ok && server.LockIndex != nil {
+		regionID := rr.Region().Id
+		scanPoints = func(maxTs uint64, startKey, endKey []byte, limit uint32) ([]*kvrpcpb.LockInfo, error) {
+			if infos, ok := server.LockIndex.ScanLocks(regionID, maxTs, startKey, endKey, limit); ok {
+				return infos, nil
+			}
+			return mvcc.ScanPointLocks(reader, maxTs, startKey, endKey, limit)
+		}
+	}
+	locks, err := mvcc.ScanLocksWith(reader, scanPoints, req.MaxVersion, req.StartKey, req.EndKey, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	resp.Locks = locks
+	return resp, nil
+}
+
+// ReadIndex returns the committed index of the region and the applied index of the serving peer, for a client
+// which waits on a follower until the writes committed before a read are applied.
+func (server *Server) ReadIndex(_ context.Context, req *kvrpcpb.ReadIndexRequest) (*kvrpcpb.ReadIndexResponse, error) {
+	resp := new(kvrpcpb.ReadIndexResponse)
+	s := server.innerStorage()
+	indexer, ok := s.(readIndexer)
+	if !ok {
+		return nil, errors.New("storage does not support ReadIndex")
+	}
+	committed, applied, err := indexer.ReadIndex(req.Context, req.Local)
+	if err != nil {
+		if regionErr, ok := err.(*raft_storage.RegionError); ok {
+			resp.RegionError = regionErr.RequestErr
+			return resp, nil
+		}
+		return nil, err
+	}
+	resp.CommittedIndex = committed
+	resp.AppliedIndex = applied
+	return resp, nil
+}
+
+// regionWatchBatchSize is the max number of region events sent in a response.
+const regionWatchBatchSize = 128
+
+// WatchRegions streams the leader and epoch changes of the watched regions, until the client cancels it or falls
+// behind the changes, then the client watches again to get the current state.
+func (server *Server) WatchRegions(req *kvrpcpb.WatchRegionsRequest, stream tinykvpb.TinyKv_WatchRegionsServer) error {
+	s := server.innerStorage()
+	watcher, ok := s.(regionWatcher)
+	if !ok {
+		return errors.New("storage does not support WatchRegions")
+	}
+	w := watcher.WatchRegions(req.RegionIds)
+	defer watcher.Unwatch(w)
+	for {
+		var resp kvrpcpb.WatchRegionsResponse
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case ev, ok := <-w.Events():
+			if !ok {
+				return errors.New("the watch falls behind the region changes")
+			}
+			resp.Events = append(resp.Events, ev)
+		}
+		// Send the events pending together.
+	batch:
+		for len(resp.Events) < regionWatchBatchSize {
+			select {
+			case ev, ok := <-w.Events():
+				if !ok {
+					break batch
+				}
+				resp.Events = append(resp.Events, ev)
+			default:
+				break batch
+			}
+		}
+		if err := stream.Send(&resp); err != nil {
+			return err
+		}
+	}
+}
+
+// Debug commands.
+func (server *Server) KvAuditScan(_ context.Context, req *kvrpcpb.AuditScanRequest) (*kvrpcpb.AuditScanResponse, error) {
+	resp := new(kvrpcpb.AuditScanResponse)
+	reader, err := server.storage.Reader(req.Context)
+	if err != nil {
+		if regionErr, ok := err.(*raft_storage.RegionError); ok {
+			resp.RegionError = regionErr.RequestErr
+			return resp, nil
+		}
+		return nil, err
+	}
+	defer reader.Close()
+	records, err := mvcc.ScanAuditRecords(reader, req.Key, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	resp.Records = records
+	return resp, nil
+}
+
+// FailPoint sets a failpoint of the store, see package failpoint.
+func (server *Server) FailPoint(_ context.Context, req *kvrpcpb.FailPointRequest) (*kvrpcpb.FailPointResponse, error) {
+	resp := new(kvrpcpb.FailPointResponse)
+	if !server.EnableFailPoints {
+		resp.Error = "failpoints are disabled by the config of the store"
+		return resp, nil
+	}
+	if req.Name != "" {
+		if req.Actions == "" {
+			failpoint.Disable(req.Name)
+		} else if err := failpoint.Enable(req.Name, req.Actions); err != nil {
+			resp.Error = err.Error()
+		}
+	}
+	for _, fp := range failpoint.List() {
+		resp.FailPoints = append(resp.FailPoints, &kvrpcpb.FailPoint{Name: fp[0], Actions: fp[1]})
+	}
+	return resp, nil
+}
+
+// KvKeyViolations returns the keys written to the store which break the TinySQL key layout.
+func (server *Server) KvKeyViolations(_ context.Context, _ *kvrpcpb.KeyViolationsRequest) (*kvrpcpb.KeyViolationsResponse, error) {
+	resp := new(kvrpcpb.KeyViolationsResponse)
+	if server.KeyChecker == nil {
+		resp.Error = "the key layout check is disabled by the config of the store"
+		return resp, nil
+	}
+	for reason, count := range server.KeyChecker.Counts() {
+		resp.Counts = append(resp.Counts, &kvrpcpb.KeyViolationCount{Reason: reason, Count: count})
+	}
+	sort.Slice(resp.Counts, func(i, j int) bool {
+		return resp.Counts[i].Reason < resp.Counts[j].Reason
+	})
+	for _, v := range server.KeyChecker.Recent() {
+		resp.Violations = append(resp.Violations, &kvrpcpb.KeyViolation{
+			RegionId: v.RegionID,
+			Cf:       v.CF,
+			Key:      v.Key,
+			Reason:   v.Reason,
+		})
+	}
+	return resp, nil
+}
+
+// RaftReadyStats returns the time the raft workers of the store spent in each stage of their loops.
+func (server *Server) RaftReadyStats(_ context.Context, _ *kvrpcpb.RaftReadyStatsRequest) (*kvrpcpb.RaftReadyStatsResponse, error) {
+	resp := new(kvrpcpb.RaftReadyStatsResponse)
+	if server.ReadyStats == nil {
+		resp.Error = "the store doesn't run raft"
+		return resp, nil
+	}
+	for _, w := range server.ReadyStats.Workers() {
+		worker := &kvrpcpb.RaftWorkerStats{
+			WorkerId:         uint64(w.WorkerID),
+			Rounds:           w.Rounds,
+			EntryCacheHits:   w.EntryCacheHits,
+			EntryCacheMisses: w.EntryCacheMisses,
+		}
+		for _, s := range w.Stages {
+			worker.Stages = append(worker.Stages, &kvrpcpb.RaftStageStats{
+				Stage:   s.Stage,
+				Count:   s.Count,
+				TotalNs: uint64(s.Total),
+				MaxNs:   uint64(s.Max),
+			})
+		}
+		resp.Workers = append(resp.Workers, worker)
+	}
+	return resp, nil
+}
+
+// RaftStatus returns the raft status of the peer of a region on the store.
+func (server *Server) RaftStatus(_ context.Context, req *kvrpcpb.RaftStatusRequest) (*kvrpcpb.RaftStatusResponse, error) {
+	resp := new(kvrpcpb.RaftStatusResponse)
+	rs, ok := server.raftStorage()
+	if !ok {
+		resp.Error = "the storage doesn't support RaftStatus"
+		return resp, nil
+	}
+	status, err := rs.RaftStatus(req.RegionId)
+	if err != nil {
+		resp.Error = err.Error()
+		return resp, nil
+	}
+	resp.Status = status.String()
+	return resp, nil
+}
+
+// ApplyWatermark returns the apply watermarks of the replicas of a region, see kvrpcpb.ApplyWatermarkRequest.
+func (server *Server) ApplyWatermark(_ context.Context, req *kvrpcpb.ApplyWatermarkRequest) (*kvrpcpb.ApplyWatermarkResponse, error) {
+	resp := new(kvrpcpb.ApplyWatermarkResponse)
+	rs, ok := server.raftStorage()
+	if !ok {
+		resp.Error = "the storage doesn't support ApplyWatermark"
+		return resp, nil
+	}
+	watermarks, err := rs.ApplyWatermarks(req.RegionId, req.Local)
+	if err != nil {
+		resp.Error = err.Error()
+		return resp, nil
+	}
+	resp.Watermarks = watermarks
+	return resp, nil
+}
+
 // SQL push down commands.
 func (server *Server) Coprocessor(_ context.Context, req *coppb.Request) (*coppb.Response, error) {
 	resp := new(coppb.Response)
@@ -117,9 +626,27 @@ func (server *Server) Coprocessor(_ context.Context, req *coppb.Request) (*coppb
 	}
 	switch req.Tp {
 	case kv.ReqTypeDAG:
-		return server.copHandler.HandleCopDAGRequest(reader, req), nil
+		return server.handleCopDAGRequest(reader, req), nil
 	case kv.ReqTypeAnalyze:
 		return server.copHandler.HandleCopAnalyzeRequest(reader, req), nil
 	}
 	return nil, nil
 }
+
+func (server *Server) handleCopDAGRequest(reader storage.StorageReader, req *coppb.Request) *coppb.Response {
+	indexReader, ok := reader.(applyIndexReader)
+	if server.copCache == nil || !ok {
+		return server.copHandler.HandleCopDAGRequest(reader, req)
+	}
+	applyIndex, err := indexReader.ApplyIndex()
+	if err != nil {
+		return server.copHandler.HandleCopDAGRequest(reader, req)
+	}
+	key := coprocessor.CacheKey(req)
+	if resp := server.copCache.Get(key, applyIndex); resp != nil {
+		return resp
+	}
+	resp := server.copHandler.HandleCopDAGRequest(reader, req)
+	server.copCache.Put(key, applyIndex, resp)
+	return resp
+}
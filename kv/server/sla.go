@@ -0,0 +1,133 @@
+package server
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/stats"
+)
+
+// Every request is tagged with an SLA class derived from the priority of its context, and the time it spends on the
+// server is split into the queueing before its handler starts, the wait for latches and locks in the handler, and
+// the processing. The times are returned in the exec details of the responses which have them.
+
+// SlaClass returns the SLA class of a request with the context.
+func SlaClass(ctx *kvrpcpb.Context) kvrpcpb.SlaClass {
+	switch ctx.GetPriority() {
+	case kvrpcpb.CommandPri_Low:
+		return kvrpcpb.SlaClass_Background
+	case kvrpcpb.CommandPri_High:
+		return kvrpcpb.SlaClass_Interactive
+	default:
+		return kvrpcpb.SlaClass_Standard
+	}
+}
+
+type arrivalKey struct{}
+
+type requestTimerKey struct{}
+
+// requestTimer measures where the time of a request is spent.
+type requestTimer struct {
+	class   kvrpcpb.SlaClass
+	arrival time.Time
+	start   time.Time
+	// nanoseconds spent waiting in the handler
+	wait int64
+}
+
+// recordWait adds the time since start to the wait of the request of ctx, if it's timed.
+func recordWait(ctx context.Context, start time.Time) {
+	if t, ok := ctx.Value(requestTimerKey{}).(*requestTimer); ok {
+		atomic.AddInt64(&t.wait, int64(time.Since(start)))
+	}
+}
+
+func (t *requestTimer) timeDetail(end time.Time) *kvrpcpb.TimeDetail {
+	wait := time.Duration(atomic.LoadInt64(&t.wait))
+	process := end.Sub(t.start) - wait
+	if process < 0 {
+		process = 0
+	}
+	return &kvrpcpb.TimeDetail{
+		SlaClass:          t.class,
+		QueueDurationNs:   uint64(t.start.Sub(t.arrival)),
+		WaitDurationNs:    uint64(wait),
+		ProcessDurationNs: uint64(process),
+	}
+}
+
+// slaStatsHandler records the arrival time of the requests, before they are decoded and dispatched to a handler.
+type slaStatsHandler struct{}
+
+func (slaStatsHandler) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return context.WithValue(ctx, arrivalKey{}, time.Now())
+}
+
+func (slaStatsHandler) HandleRPC(context.Context, stats.RPCStats) {}
+
+func (slaStatsHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (slaStatsHandler) HandleConn(context.Context, stats.ConnStats) {}
+
+// SlaStatsHandler returns the gRPC stats handler recording the arrival time of the requests for SlaInterceptor.
+func (server *Server) SlaStatsHandler() stats.Handler {
+	return slaStatsHandler{}
+}
+
+// SlaInterceptor times the unary requests by their SLA class and sets the time detail of their responses. The
+// arrival time is recorded by SlaStatsHandler, without it the queueing time is not counted.
+func (server *Server) SlaInterceptor(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	t := &requestTimer{start: time.Now()}
+	t.arrival = t.start
+	if arrival, ok := ctx.Value(arrivalKey{}).(time.Time); ok {
+		t.arrival = arrival
+	}
+	if r, ok := req.(interface{ GetContext() *kvrpcpb.Context }); ok {
+		t.class = SlaClass(r.GetContext())
+	}
+	resp, err := handler(context.WithValue(ctx, requestTimerKey{}, t), req)
+	if err == nil {
+		setTimeDetail(resp, t.timeDetail(time.Now()))
+	}
+	return resp, err
+}
+
+// setTimeDetail sets the time detail of the exec details of a response, if it has them.
+func setTimeDetail(resp interface{}, detail *kvrpcpb.TimeDetail) {
+	var details **kvrpcpb.ExecDetails
+	switch resp := resp.(type) {
+	case *kvrpcpb.RawGetResponse:
+		if resp != nil {
+			details = &resp.ExecDetails
+		}
+	case *kvrpcpb.GetResponse:
+		if resp != nil {
+			details = &resp.ExecDetails
+		}
+	case *kvrpcpb.ScanResponse:
+		if resp != nil {
+			details = &resp.ExecDetails
+		}
+	case *kvrpcpb.PrewriteResponse:
+		if resp != nil {
+			details = &resp.ExecDetails
+		}
+	case *kvrpcpb.CommitResponse:
+		if resp != nil {
+			details = &resp.ExecDetails
+		}
+	}
+	if details == nil {
+		return
+	}
+	if *details == nil {
+		*details = new(kvrpcpb.ExecDetails)
+	}
+	(*details).TimeDetail = detail
+}
@@ -0,0 +1,47 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func TestSlaInterceptor(t *testing.T) {
+	server := new(Server)
+	ctx := server.SlaStatsHandler().TagRPC(context.Background(), nil)
+	time.Sleep(5 * time.Millisecond)
+
+	req := &kvrpcpb.GetRequest{Context: &kvrpcpb.Context{Priority: kvrpcpb.CommandPri_High}}
+	scanDetail := &kvrpcpb.ScanDetail{KeysExamined: 3}
+	resp, err := server.SlaInterceptor(ctx, req, new(grpc.UnaryServerInfo), func(ctx context.Context, _ interface{}) (interface{}, error) {
+		start := time.Now()
+		time.Sleep(5 * time.Millisecond)
+		recordWait(ctx, start)
+		return &kvrpcpb.GetResponse{ExecDetails: &kvrpcpb.ExecDetails{ScanDetail: scanDetail}}, nil
+	})
+	assert.Nil(t, err)
+	details := resp.(*kvrpcpb.GetResponse).ExecDetails
+	assert.Equal(t, scanDetail, details.ScanDetail)
+	assert.Equal(t, kvrpcpb.SlaClass_Interactive, details.TimeDetail.SlaClass)
+	assert.True(t, details.TimeDetail.QueueDurationNs >= uint64(5*time.Millisecond))
+	assert.True(t, details.TimeDetail.WaitDurationNs >= uint64(5*time.Millisecond))
+
+	// a response without exec details and a nil one of a handler not implemented
+	handler := func(context.Context, interface{}) (interface{}, error) {
+		return new(kvrpcpb.RawPutResponse), nil
+	}
+	_, err = server.SlaInterceptor(context.Background(), new(kvrpcpb.RawPutRequest), new(grpc.UnaryServerInfo), handler)
+	assert.Nil(t, err)
+	handler = func(context.Context, interface{}) (interface{}, error) {
+		return (*kvrpcpb.CommitResponse)(nil), nil
+	}
+	_, err = server.SlaInterceptor(context.Background(), new(kvrpcpb.CommitRequest), new(grpc.UnaryServerInfo), handler)
+	assert.Nil(t, err)
+
+	assert.Equal(t, kvrpcpb.SlaClass_Background, SlaClass(&kvrpcpb.Context{Priority: kvrpcpb.CommandPri_Low}))
+	assert.Equal(t, kvrpcpb.SlaClass_Standard, SlaClass(nil))
+}
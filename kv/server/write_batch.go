@@ -0,0 +1,93 @@
+package server
+
+import (
+	"sync"
+
+	"github.com/pingcap-incubator/tinykv/kv/storage"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+)
+
+// writeScheduler batches the writes of the transactional commands to a region: while a batch of a region is being
+// written, the commands writing to it wait, then up to maxCommands of them are written together as the next batch,
+// with one raft proposal. The commands are still answered once their own writes are done.
+type writeScheduler struct {
+	storage     storage.Storage
+	maxCommands int
+
+	mu      sync.Mutex
+	regions map[uint64]*regionWrites
+}
+
+// regionWrites are the writes to a region waiting for the batch being written.
+type regionWrites struct {
+	pending []*pendingWrite
+}
+
+type pendingWrite struct {
+	ctx      *kvrpcpb.Context
+	modifies []storage.Modify
+	done     chan error
+}
+
+func newWriteScheduler(s storage.Storage, maxCommands int) *writeScheduler {
+	return &writeScheduler{storage: s, maxCommands: maxCommands, regions: make(map[uint64]*regionWrites)}
+}
+
+// write writes the modifies of a command with the next batch of its region, and waits for it. A command whose
+// context has no region is batched with the other such commands, the batch splits them by region.
+func (s *writeScheduler) write(ctx *kvrpcpb.Context, modifies []storage.Modify) error {
+	w := &pendingWrite{ctx: ctx, modifies: modifies, done: make(chan error, 1)}
+	regionID := ctx.GetRegionId()
+	s.mu.Lock()
+	writes := s.regions[regionID]
+	if writes == nil {
+		writes = new(regionWrites)
+		s.regions[regionID] = writes
+		go s.run(regionID, writes)
+	}
+	writes.pending = append(writes.pending, w)
+	s.mu.Unlock()
+	return <-w.done
+}
+
+// run writes the batches of the region until no command waits, then forgets the region.
+func (s *writeScheduler) run(regionID uint64, writes *regionWrites) {
+	for {
+		s.mu.Lock()
+		if len(writes.pending) == 0 {
+			delete(s.regions, regionID)
+			s.mu.Unlock()
+			return
+		}
+		n := len(writes.pending)
+		if n > s.maxCommands {
+			n = s.maxCommands
+		}
+		pending := writes.pending[:n:n]
+		writes.pending = writes.pending[n:]
+		s.mu.Unlock()
+
+		batch := s.storage.BeginBatch()
+		for _, w := range pending {
+			batch.Write(w.ctx, w.modifies)
+		}
+		for i, err := range batch.Commit() {
+			pending[i].done <- err
+		}
+	}
+}
+
+// EnableWriteBatching batches the writes of up to maxCommands transactional commands to a region into one write, see
+// writeScheduler.
+func (server *Server) EnableWriteBatching(maxCommands int) {
+	server.writes = newWriteScheduler(server.storage, maxCommands)
+}
+
+// write writes the modifies of a transactional command, batched with the writes of other commands to the region if
+// write batching is enabled.
+func (server *Server) write(ctx *kvrpcpb.Context, modifies []storage.Modify) error {
+	if server.writes == nil {
+		return server.storage.Write(ctx, modifies)
+	}
+	return server.writes.write(ctx, modifies)
+}
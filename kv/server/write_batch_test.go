@@ -0,0 +1,106 @@
+package server
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/kv/storage"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/stretchr/testify/assert"
+)
+
+// blockingStorage blocks each write until it's released, and records the number of modifies of each.
+type blockingStorage struct {
+	*storage.MemStorage
+	started chan struct{}
+	release chan struct{}
+	mu      sync.Mutex
+	writes  []int
+}
+
+func (s *blockingStorage) Write(ctx *kvrpcpb.Context, batch []storage.Modify) error {
+	s.started <- struct{}{}
+	<-s.release
+	s.mu.Lock()
+	s.writes = append(s.writes, len(batch))
+	s.mu.Unlock()
+	if ctx.RegionId == 3 {
+		return errors.New("region 3 is unavailable")
+	}
+	return s.MemStorage.Write(ctx, batch)
+}
+
+func (s *blockingStorage) BeginBatch() storage.Batch {
+	return storage.NewBatch(s)
+}
+
+func put(key string) []storage.Modify {
+	return []storage.Modify{{Data: storage.Put{Cf: engine_util.CfDefault, Key: []byte(key), Value: []byte(key)}}}
+}
+
+func TestWriteBatching(t *testing.T) {
+	s := &blockingStorage{MemStorage: storage.NewMemStorage(), started: make(chan struct{}, 10), release: make(chan struct{})}
+	server := NewServer(s)
+	server.EnableWriteBatching(2)
+
+	var wg sync.WaitGroup
+	write := func(regionID uint64, key string) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Nil(t, server.write(&kvrpcpb.Context{RegionId: regionID}, put(key)))
+		}()
+	}
+	// the commands waiting for the first write of the region are written in batches of 2
+	write(1, "a")
+	<-s.started
+	for _, key := range []string{"b", "c", "d"} {
+		write(1, key)
+	}
+	for {
+		server.writes.mu.Lock()
+		n := len(server.writes.regions[1].pending)
+		server.writes.mu.Unlock()
+		if n == 3 {
+			break
+		}
+	}
+	s.release <- struct{}{}
+	<-s.started
+	s.release <- struct{}{}
+	<-s.started
+	s.release <- struct{}{}
+	wg.Wait()
+	assert.Equal(t, []int{1, 2, 1}, s.writes)
+	for _, key := range []string{"a", "b", "c", "d"} {
+		assert.Equal(t, []byte(key), s.Get(engine_util.CfDefault, []byte(key)))
+	}
+	// the region is forgotten once no command waits
+	for {
+		server.writes.mu.Lock()
+		n := len(server.writes.regions)
+		server.writes.mu.Unlock()
+		if n == 0 {
+			break
+		}
+	}
+
+	// a batch writes each region on its own, and returns the errors of the commands
+	batch := s.BeginBatch()
+	batch.Write(&kvrpcpb.Context{RegionId: 2}, put("e"))
+	batch.Write(&kvrpcpb.Context{RegionId: 3}, put("f"))
+	batch.Write(&kvrpcpb.Context{RegionId: 2}, put("g"))
+	go func() {
+		for i := 0; i < 2; i++ {
+			<-s.started
+			s.release <- struct{}{}
+		}
+	}()
+	errs := batch.Commit()
+	assert.Nil(t, errs[0])
+	assert.NotNil(t, errs[1])
+	assert.Nil(t, errs[2])
+	assert.Equal(t, []int{1, 2, 1, 2, 1}, s.writes)
+}
@@ -0,0 +1,45 @@
+package audit_storage
+
+import (
+	"github.com/pingcap-incubator/tinykv/kv/config"
+	"github.com/pingcap-incubator/tinykv/kv/storage"
+	"github.com/pingcap-incubator/tinykv/kv/transaction/mvcc"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+)
+
+// AuditStorage is a wrapper of `Storage` which appends an audit record to the audit CF for every transactional
+// write on the audited keys. The records are written in the same batch as the writes, so they are replicated and
+// applied together.
+type AuditStorage struct {
+	storage.Storage
+	prefixes [][]byte
+}
+
+func NewAuditStorage(inner storage.Storage, conf *config.Config) *AuditStorage {
+	prefixes := make([][]byte, 0, len(conf.AuditPrefixes))
+	for _, prefix := range conf.AuditPrefixes {
+		prefixes = append(prefixes, []byte(prefix))
+	}
+	return &AuditStorage{Storage: inner, prefixes: prefixes}
+}
+
+// Inner returns the wrapped storage.
+func (s *AuditStorage) Inner() storage.Storage {
+	return s.Storage
+}
+
+func (s *AuditStorage) Write(ctx *kvrpcpb.Context, batch []storage.Modify) error {
+	records := mvcc.AuditRecords(ctx, batch, s.prefixes)
+	if len(records) == 0 {
+		return s.Storage.Write(ctx, batch)
+	}
+	withRecords := make([]storage.Modify, 0, len(batch)+len(records))
+	withRecords = append(withRecords, batch...)
+	withRecords = append(withRecords, records...)
+	return s.Storage.Write(ctx, withRecords)
+}
+
+// BeginBatch starts a batch whose writes are audited.
+func (s *AuditStorage) BeginBatch() storage.Batch {
+	return storage.NewBatch(s)
+}
@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+)
+
+// Batch accumulates the writes of several commands, Commit writes them with one Write per region, so the writes of
+// the commands to a region are one raft proposal.
+type Batch interface {
+	// Write adds the modifies of a command to the batch.
+	Write(ctx *kvrpcpb.Context, batch []Modify)
+	// Commit writes the modifies added, it returns the error of each command in the order they were added. The
+	// commands to the same region share the error of its write.
+	Commit() []error
+}
+
+// contextFiller is a storage which fills the missing region of a context from the key written, see
+// RaftStorage.FillContext.
+type contextFiller interface {
+	FillContext(ctx *kvrpcpb.Context, key []byte) error
+}
+
+// batchKey identifies the region and the peer a command writes to, the commands with the same key are written
+// together.
+type batchKey struct {
+	regionID uint64
+	confVer  uint64
+	version  uint64
+	peerID   uint64
+	term     uint64
+}
+
+type batchGroup struct {
+	ctx      *kvrpcpb.Context
+	modifies []Modify
+	err      error
+}
+
+// writeBatch is a Batch on top of the Write of a storage.
+type writeBatch struct {
+	storage Storage
+	filler  contextFiller
+	groups  map[batchKey]*batchGroup
+	// the groups in the order they were added, and the group of each command
+	order    []*batchGroup
+	commands []*batchGroup
+}
+
+// NewBatch returns a Batch which writes to the storage. A command whose context has no region is written on its own,
+// unless the storage fills the region from the key.
+func NewBatch(s Storage) Batch {
+	b := &writeBatch{storage: s, groups: make(map[batchKey]*batchGroup)}
+	// a wrapper like AuditStorage fills the context with the storage it wraps
+	inner := s
+	if wrapper, ok := s.(interface{ Inner() Storage }); ok {
+		inner = wrapper.Inner()
+	}
+	b.filler, _ = inner.(contextFiller)
+	return b
+}
+
+func (b *writeBatch) Write(ctx *kvrpcpb.Context, batch []Modify) {
+	var group *batchGroup
+	if b.filler != nil && len(batch) > 0 {
+		if err := b.filler.FillContext(ctx, batch[0].Key()); err != nil {
+			b.commands = append(b.commands, &batchGroup{err: err})
+			return
+		}
+	}
+	if ctx.GetRegionId() != 0 {
+		key := batchKey{
+			regionID: ctx.RegionId,
+			confVer:  ctx.GetRegionEpoch().GetConfVer(),
+			version:  ctx.GetRegionEpoch().GetVersion(),
+			peerID:   ctx.GetPeer().GetId(),
+			term:     ctx.Term,
+		}
+		group = b.groups[key]
+		if group == nil {
+			group = &batchGroup{ctx: ctx}
+			b.groups[key] = group
+			b.order = append(b.order, group)
+		}
+	} else {
+		group = &batchGroup{ctx: ctx}
+		b.order = append(b.order, group)
+	}
+	group.modifies = append(group.modifies, batch...)
+	b.commands = append(b.commands, group)
+}
+
+func (b *writeBatch) Commit() []error {
+	for _, group := range b.order {
+		if len(group.modifies) > 0 {
+			group.err = b.storage.Write(group.ctx, group.modifies)
+		}
+	}
+	errs := make([]error, len(b.commands))
+	for i, group := range b.commands {
+		errs[i] = group.err
+	}
+	return errs
+}
@@ -13,16 +13,22 @@ import (
 // MemStorage is an in-memory storage engine used for testing. Data is not written to disk, nor sent to other
 // nodes. It is intended for testing only.
 type MemStorage struct {
-	CfDefault *llrb.LLRB
-	CfLock    *llrb.LLRB
-	CfWrite   *llrb.LLRB
+	CfDefault      *llrb.LLRB
+	CfLock         *llrb.LLRB
+	CfWrite        *llrb.LLRB
+	CfRangeLock    *llrb.LLRB
+	CfStaging      *llrb.LLRB
+	CfRawTombstone *llrb.LLRB
 }
 
 func NewMemStorage() *MemStorage {
 	return &MemStorage{
-		CfDefault: llrb.New(),
-		CfLock:    llrb.New(),
-		CfWrite:   llrb.New(),
+		CfDefault:      llrb.New(),
+		CfLock:         llrb.New(),
+		CfWrite:        llrb.New(),
+		CfRangeLock:    llrb.New(),
+		CfStaging:      llrb.New(),
+		CfRawTombstone: llrb.New(),
 	}
 }
 
@@ -50,6 +56,12 @@ func (s *MemStorage) Write(ctx *kvrpcpb.Context, batch []Modify) error {
 				s.CfLock.ReplaceOrInsert(item)
 			case engine_util.CfWrite:
 				s.CfWrite.ReplaceOrInsert(item)
+			case engine_util.CfRangeLock:
+				s.CfRangeLock.ReplaceOrInsert(item)
+			case engine_util.CfStaging:
+				s.CfStaging.ReplaceOrInsert(item)
+			case engine_util.CfRawTombstone:
+				s.CfRawTombstone.ReplaceOrInsert(item)
 			}
 		case Delete:
 			item := memItem{key: data.Key}
@@ -60,6 +72,12 @@ func (s *MemStorage) Write(ctx *kvrpcpb.Context, batch []Modify) error {
 				s.CfLock.Delete(item)
 			case engine_util.CfWrite:
 				s.CfWrite.Delete(item)
+			case engine_util.CfRangeLock:
+				s.CfRangeLock.Delete(item)
+			case engine_util.CfStaging:
+				s.CfStaging.Delete(item)
+			case engine_util.CfRawTombstone:
+				s.CfRawTombstone.Delete(item)
 			}
 		}
 	}
@@ -67,6 +85,10 @@ func (s *MemStorage) Write(ctx *kvrpcpb.Context, batch []Modify) error {
 	return nil
 }
 
+func (s *MemStorage) BeginBatch() Batch {
+	return NewBatch(s)
+}
+
 func (s *MemStorage) Get(cf string, key []byte) []byte {
 	item := memItem{key: key}
 	var result llrb.Item
@@ -77,6 +99,12 @@ func (s *MemStorage) Get(cf string, key []byte) []byte {
 		result = s.CfLock.Get(item)
 	case engine_util.CfWrite:
 		result = s.CfWrite.Get(item)
+	case engine_util.CfRangeLock:
+		result = s.CfRangeLock.Get(item)
+	case engine_util.CfStaging:
+		result = s.CfStaging.Get(item)
+	case engine_util.CfRawTombstone:
+		result = s.CfRawTombstone.Get(item)
 	}
 
 	if result == nil {
@@ -95,6 +123,12 @@ func (s *MemStorage) Set(cf string, key []byte, value []byte) {
 		s.CfLock.ReplaceOrInsert(item)
 	case engine_util.CfWrite:
 		s.CfWrite.ReplaceOrInsert(item)
+	case engine_util.CfRangeLock:
+		s.CfRangeLock.ReplaceOrInsert(item)
+	case engine_util.CfStaging:
+		s.CfStaging.ReplaceOrInsert(item)
+	case engine_util.CfRawTombstone:
+		s.CfRawTombstone.ReplaceOrInsert(item)
 	}
 }
 
@@ -108,6 +142,12 @@ func (s *MemStorage) HasChanged(cf string, key []byte) bool {
 		result = s.CfLock.Get(item)
 	case engine_util.CfWrite:
 		result = s.CfWrite.Get(item)
+	case engine_util.CfRangeLock:
+		result = s.CfRangeLock.Get(item)
+	case engine_util.CfStaging:
+		result = s.CfStaging.Get(item)
+	case engine_util.CfRawTombstone:
+		result = s.CfRawTombstone.Get(item)
 	}
 	if result == nil {
 		return true
@@ -124,6 +164,12 @@ func (s *MemStorage) Len(cf string) int {
 		return s.CfLock.Len()
 	case engine_util.CfWrite:
 		return s.CfWrite.Len()
+	case engine_util.CfRangeLock:
+		return s.CfRangeLock.Len()
+	case engine_util.CfStaging:
+		return s.CfStaging.Len()
+	case engine_util.CfRawTombstone:
+		return s.CfRawTombstone.Len()
 	}
 
 	return -1
@@ -145,6 +191,12 @@ func (mr *memReader) GetCF(cf string, key []byte) ([]byte, error) {
 		result = mr.inner.CfLock.Get(item)
 	case engine_util.CfWrite:
 		result = mr.inner.CfWrite.Get(item)
+	case engine_util.CfRangeLock:
+		result = mr.inner.CfRangeLock.Get(item)
+	case engine_util.CfStaging:
+		result = mr.inner.CfStaging.Get(item)
+	case engine_util.CfRawTombstone:
+		result = mr.inner.CfRawTombstone.Get(item)
 	default:
 		return nil, fmt.Errorf("mem-server: bad CF %s", cf)
 	}
@@ -165,6 +217,12 @@ func (mr *memReader) IterCF(cf string) engine_util.DBIterator {
 		data = mr.inner.CfLock
 	case engine_util.CfWrite:
 		data = mr.inner.CfWrite
+	case engine_util.CfRangeLock:
+		data = mr.inner.CfRangeLock
+	case engine_util.CfStaging:
+		data = mr.inner.CfStaging
+	case engine_util.CfRawTombstone:
+		data = mr.inner.CfRawTombstone
 	default:
 		return nil
 	}
@@ -0,0 +1,230 @@
+package raft_storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Connor1996/badger"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/meta"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	rspb "github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/tinykvpb"
+	"github.com/pingcap/errors"
+	"google.golang.org/grpc"
+)
+
+// applyWatermarkTimeout bounds asking the store of another replica for its watermark.
+const applyWatermarkTimeout = 3 * time.Second
+
+// regionHash is the hash of the data of a replica at an applied index, taken by its last consistency check.
+type regionHash struct {
+	index uint64
+	hash  []byte
+}
+
+// regionHashes are the hashes of the replicas on the store by region, they are kept in memory only.
+type regionHashes struct {
+	sync.Mutex
+	hashes map[uint64]regionHash
+}
+
+func (h *regionHashes) get(regionID uint64) regionHash {
+	h.Lock()
+	defer h.Unlock()
+	return h.hashes[regionID]
+}
+
+func (h *regionHashes) put(regionID uint64, hash regionHash) {
+	h.Lock()
+	defer h.Unlock()
+	if h.hashes == nil {
+		h.hashes = make(map[uint64]regionHash)
+	}
+	h.hashes[regionID] = hash
+}
+
+// HashRegion hashes the data of the replica of the region on this store at its applied index, the hash is reported
+// by ApplyWatermark. The data and the applied index are read from a single snapshot of the kv engine.
+func (rs *RaftStorage) HashRegion(regionID uint64) error {
+	index, hash, err := hashRegion(rs.engines.Kv, regionID, rs.config.MemoryLockCF)
+	if err != nil {
+		return err
+	}
+	rs.regionHashes.put(regionID, regionHash{index: index, hash: hash})
+	return nil
+}
+
+// hashRegion returns the applied index of the region and the hash of its data then. The lock CF is skipped if it's
+// kept in memory, its data in the engine is a checkpoint which differs between the replicas.
+func hashRegion(db *badger.DB, regionID uint64, skipLockCF bool) (uint64, []byte, error) {
+	txn := db.NewTransaction(false)
+	defer txn.Discard()
+	region, err := regionFromTxn(txn, regionID)
+	if err != nil {
+		return 0, nil, err
+	}
+	applyState := new(rspb.RaftApplyState)
+	if err := engine_util.GetMetaFromTxn(txn, meta.ApplyStateKey(regionID), applyState); err != nil {
+		return 0, nil, err
+	}
+	digest := sha256.New()
+	var buf [binary.MaxVarintLen64]byte
+	write := func(b []byte) {
+		digest.Write(buf[:binary.PutUvarint(buf[:], uint64(len(b)))])
+		digest.Write(b)
+	}
+	for _, cf := range engine_util.CFs {
+		if cf == engine_util.CfLock && skipLockCF {
+			continue
+		}
+		write([]byte(cf))
+		iter := NewRegionIterator(engine_util.NewCFIterator(cf, txn), region)
+		for iter.Seek(region.StartKey); iter.Valid(); iter.Next() {
+			item := iter.Item()
+			value, err := item.Value()
+			if err != nil {
+				iter.Close()
+				return 0, nil, err
+			}
+			write(item.Key())
+			write(value)
+		}
+		iter.Close()
+	}
+	return applyState.AppliedIndex, digest.Sum(nil), nil
+}
+
+// regionFromTxn returns the region of the replica on the store, or ErrRegionNotFound if there is none.
+func regionFromTxn(txn *badger.Txn, regionID uint64) (*metapb.Region, error) {
+	regionState := new(rspb.RegionLocalState)
+	err := engine_util.GetMetaFromTxn(txn, meta.RegionStateKey(regionID), regionState)
+	if err != nil && err != badger.ErrKeyNotFound {
+		return nil, err
+	}
+	if err == badger.ErrKeyNotFound || regionState.State == rspb.PeerState_Tombstone {
+		return nil, &util.ErrRegionNotFound{RegionId: regionID}
+	}
+	return regionState.Region, nil
+}
+
+// readWatermark returns the region and the watermark of its replica on the store, without the hash. The applied
+// index and the region are read from a single snapshot of the kv engine.
+func readWatermark(engines *engine_util.Engines, storeID, regionID uint64) (*metapb.Region, *kvrpcpb.ReplicaWatermark, error) {
+	txn := engines.Kv.NewTransaction(false)
+	defer txn.Discard()
+	region, err := regionFromTxn(txn, regionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	peer := util.FindPeer(region, storeID)
+	if peer == nil {
+		return nil, nil, &util.ErrRegionNotFound{RegionId: regionID}
+	}
+	applyState := new(rspb.RaftApplyState)
+	if err := engine_util.GetMetaFromTxn(txn, meta.ApplyStateKey(regionID), applyState); err != nil {
+		return nil, nil, err
+	}
+	w := &kvrpcpb.ReplicaWatermark{StoreId: storeID, PeerId: peer.Id, AppliedIndex: applyState.AppliedIndex}
+	if applyState.AppliedIndex == applyState.TruncatedState.GetIndex() {
+		w.AppliedTerm = applyState.TruncatedState.GetTerm()
+	} else {
+		// the entry is kept until the truncated index passes it, which needs the applied index to move on first
+		entry, err := meta.GetRaftEntry(engines.Raft, regionID, applyState.AppliedIndex)
+		if err != nil {
+			return nil, nil, errors.Annotatef(err, "read the applied entry %d of region %d", applyState.AppliedIndex, regionID)
+		}
+		w.AppliedTerm = entry.Term
+	}
+	return region, w, nil
+}
+
+// ApplyWatermarks returns the apply watermarks of the replicas of the region in the order of its peers. The peers
+// are read from the replica on this store, and unless local is set the stores of the other peers are asked for their
+// watermarks, a replica whose store doesn't answer has the error in its watermark.
+func (rs *RaftStorage) ApplyWatermarks(regionID uint64, local bool) ([]*kvrpcpb.ReplicaWatermark, error) {
+	region, w, err := readWatermark(rs.engines, rs.node.GetStoreID(), regionID)
+	if err != nil {
+		return nil, err
+	}
+	if hash := rs.regionHashes.get(regionID); hash.hash != nil {
+		w.HashIndex, w.Hash = hash.index, hash.hash
+	}
+	if local {
+		return []*kvrpcpb.ReplicaWatermark{w}, nil
+	}
+	watermarks := make([]*kvrpcpb.ReplicaWatermark, len(region.Peers))
+	var wg sync.WaitGroup
+	for i, peer := range region.Peers {
+		if peer.Id == w.PeerId {
+			watermarks[i] = w
+			continue
+		}
+		wg.Add(1)
+		go func(i int, peer *metapb.Peer) {
+			defer wg.Done()
+			watermarks[i] = rs.remoteWatermark(regionID, peer)
+		}(i, peer)
+	}
+	wg.Wait()
+	return watermarks, nil
+}
+
+// remoteWatermark asks the store of the peer for the watermark of its replica of the region.
+func (rs *RaftStorage) remoteWatermark(regionID uint64, peer *metapb.Peer) *kvrpcpb.ReplicaWatermark {
+	w := &kvrpcpb.ReplicaWatermark{StoreId: peer.StoreId, PeerId: peer.Id}
+	ctx, cancel := context.WithTimeout(context.Background(), applyWatermarkTimeout)
+	defer cancel()
+	addr, err := rs.resolveAddr(ctx, peer.StoreId)
+	if err != nil {
+		w.Error = err.Error()
+		return w
+	}
+	cc, err := grpc.DialContext(ctx, addr, grpc.WithInsecure())
+	if err != nil {
+		w.Error = err.Error()
+		return w
+	}
+	defer cc.Close()
+	resp, err := tinykvpb.NewTinyKvClient(cc).ApplyWatermark(ctx, &kvrpcpb.ApplyWatermarkRequest{RegionId: regionID, Local: true})
+	switch {
+	case err != nil:
+		w.Error = err.Error()
+	case resp.Error != "":
+		w.Error = resp.Error
+	case len(resp.Watermarks) != 1:
+		w.Error = fmt.Sprintf("store %d returned %d watermarks", peer.StoreId, len(resp.Watermarks))
+	case resp.Watermarks[0].PeerId != peer.Id:
+		w.Error = fmt.Sprintf("store %d has peer %d of the region", peer.StoreId, resp.Watermarks[0].PeerId)
+	default:
+		w = resp.Watermarks[0]
+	}
+	return w
+}
+
+// resolveAddr returns the address of the store by the resolver.
+func (rs *RaftStorage) resolveAddr(ctx context.Context, storeID uint64) (string, error) {
+	type result struct {
+		addr string
+		err  error
+	}
+	ch := make(chan result, 1)
+	rs.resolveWorker.Sender() <- &resolveAddrTask{
+		storeID: storeID,
+		callback: func(addr string, err error) {
+			ch <- result{addr: addr, err: err}
+		},
+	}
+	select {
+	case r := <-ch:
+		return r.addr, r.err
+	case <-ctx.Done():
+		return "", errors.Errorf("resolve the address of store %d: %v", storeID, ctx.Err())
+	}
+}
@@ -0,0 +1,60 @@
+package raft_storage
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/meta"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	rspb "github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyWatermark(t *testing.T) {
+	engines := util.NewTestEngines()
+	defer engines.Destroy()
+	region := &metapb.Region{Id: 1, StartKey: []byte("b"), EndKey: []byte("d"),
+		Peers: []*metapb.Peer{{Id: 2, StoreId: 1}, {Id: 3, StoreId: 2}}}
+	assert.Nil(t, engine_util.PutMeta(engines.Kv, meta.RegionStateKey(1), &rspb.RegionLocalState{Region: region}))
+	applyState := &rspb.RaftApplyState{AppliedIndex: 5, TruncatedState: &rspb.RaftTruncatedState{Index: 5, Term: 2}}
+	assert.Nil(t, engine_util.PutMeta(engines.Kv, meta.ApplyStateKey(1), applyState))
+	for _, key := range []string{"a", "b", "c", "d"} {
+		assert.Nil(t, engine_util.PutCF(engines.Kv, engine_util.CfDefault, []byte(key), []byte("v"+key)))
+	}
+
+	_, w, err := readWatermark(engines, 1, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), w.PeerId)
+	assert.Equal(t, uint64(5), w.AppliedIndex)
+	assert.Equal(t, uint64(2), w.AppliedTerm)
+	_, _, err = readWatermark(engines, 3, 1)
+	assert.NotNil(t, err)
+	_, _, err = hashRegion(engines.Kv, 2, false)
+	assert.NotNil(t, err)
+
+	// the term of an applied entry after the truncated one is read from the raft log
+	applyState.AppliedIndex = 6
+	assert.Nil(t, engine_util.PutMeta(engines.Kv, meta.ApplyStateKey(1), applyState))
+	assert.Nil(t, engine_util.PutMeta(engines.Raft, meta.RaftLogKey(1, 6), &eraftpb.Entry{Index: 6, Term: 3}))
+	_, w, err = readWatermark(engines, 1, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), w.AppliedTerm)
+
+	index, hash, err := hashRegion(engines.Kv, 1, false)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(6), index)
+	// the data out of the region doesn't change the hash
+	assert.Nil(t, engine_util.PutCF(engines.Kv, engine_util.CfWrite, []byte("e"), []byte("v")))
+	_, same, err := hashRegion(engines.Kv, 1, false)
+	assert.Nil(t, err)
+	assert.Equal(t, hash, same)
+	assert.Nil(t, engine_util.PutCF(engines.Kv, engine_util.CfLock, []byte("b"), []byte("l")))
+	_, changed, err := hashRegion(engines.Kv, 1, false)
+	assert.Nil(t, err)
+	assert.NotEqual(t, hash, changed)
+	_, skipped, err := hashRegion(engines.Kv, 1, true)
+	assert.Nil(t, err)
+	assert.NotEqual(t, changed, skipped)
+}
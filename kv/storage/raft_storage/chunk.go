@@ -0,0 +1,148 @@
+package raft_storage
+
+import (
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/log"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
+	"github.com/pingcap/errors"
+)
+
+// The reserved size for the header of a raft command and the encoding of each request.
+const (
+	headerOverhead  = 1024
+	requestOverhead = 16
+)
+
+func requestsSize(reqs []*raft_cmdpb.Request) uint64 {
+	size := uint64(headerOverhead)
+	for _, req := range reqs {
+		size += uint64(req.Size()) + requestOverhead
+	}
+	return size
+}
+
+// splitRequests splits reqs into chunks whose size doesn't exceed maxSize. A request larger than maxSize makes a
+// chunk by itself, which will be rejected by raftstore.
+func splitRequests(reqs []*raft_cmdpb.Request, maxSize uint64) [][]*raft_cmdpb.Request {
+	var chunks [][]*raft_cmdpb.Request
+	var chunk []*raft_cmdpb.Request
+	size := uint64(headerOverhead)
+	for _, req := range reqs {
+		reqSize := uint64(req.Size()) + requestOverhead
+		if len(chunk) > 0 && size+reqSize > maxSize {
+			chunks = append(chunks, chunk)
+			chunk = nil
+			size = headerOverhead
+		}
+		chunk = append(chunk, req)
+		size += reqSize
+	}
+	if len(chunk) > 0 {
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
+// writeInChunks proposes a write batch which is too large for a single raft entry as several sequential proposals.
+// The values overwritten by the batch are read before the first proposal, so if a proposal fails the proposed
+// chunks are undone by writing the original values back. Every chunk carries the region epoch of ctx, so a split
+// or conf change in between fails the remaining chunks instead of applying them to a different region. The undo is
+// best effort, if it fails too the batch is left partially written and the returned error says so. The caller holds
+// the write latches of the keys, so no other write to them is proposed in between and overwritten by the undo.
+func (rs *RaftStorage) writeInChunks(ctx *kvrpcpb.Context, reqs []*raft_cmdpb.Request) error {
+	undo, err := rs.undoRequests(ctx, reqs)
+	if err != nil {
+		return err
+	}
+	chunks := splitRequests(reqs, rs.config.RaftEntryMaxSize)
+	proposed := 0
+	for _, chunk := range chunks {
+		// A failed proposal may still be applied, so it's undone too.
+		proposed += len(chunk)
+		if err := rs.propose(ctx, chunk); err != nil {
+			if undoErr := rs.undoChunks(ctx, undo[:proposed]); undoErr != nil {
+				log.Errorf("region %d failed to undo a partially written batch: %v", ctx.RegionId, undoErr)
+				return errors.Errorf("region %d: the batch is partially written, up to %d of its %d requests may be "+
+					"applied: %v, and undoing them failed: %v", ctx.RegionId, proposed, len(reqs), err, undoErr)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// undoChunks proposes the undo requests with the current region epoch of the replica on this store, as the failed
+// proposal may have been rejected by a split or conf change which the epoch of the batch doesn't know.
+func (rs *RaftStorage) undoChunks(ctx *kvrpcpb.Context, undo []*raft_cmdpb.Request) error {
+	region, err := rs.localRegion(ctx.RegionId)
+	if err != nil {
+		return err
+	}
+	undoCtx := &kvrpcpb.Context{
+		RegionId:    ctx.RegionId,
+		RegionEpoch: region.RegionEpoch,
+		Peer:        ctx.Peer,
+		Term:        ctx.Term,
+	}
+	for _, chunk := range splitRequests(undo, rs.config.RaftEntryMaxSize) {
+		if err := rs.propose(undoCtx, chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// undoRequests returns the requests which restore the current values of the keys written by reqs, the i-th one
+// undoes the i-th request.
+func (rs *RaftStorage) undoRequests(ctx *kvrpcpb.Context, reqs []*raft_cmdpb.Request) ([]*raft_cmdpb.Request, error) {
+	reader, err := rs.Reader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	undo := make([]*raft_cmdpb.Request, 0, len(reqs))
+	for _, req := range reqs {
+		cf, key := requestKey(req)
+		value, err := reader.GetCF(cf, key)
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			undo = append(undo, &raft_cmdpb.Request{
+				CmdType: raft_cmdpb.CmdType_Delete,
+				Delete:  &raft_cmdpb.DeleteRequest{Cf: cf, Key: key},
+			})
+		} else {
+			undo = append(undo, &raft_cmdpb.Request{
+				CmdType: raft_cmdpb.CmdType_Put,
+				Put:     &raft_cmdpb.PutRequest{Cf: cf, Key: key, Value: value},
+			})
+		}
+	}
+	return undo, nil
+}
+
+func requestKey(req *raft_cmdpb.Request) (string, []byte) {
+	switch req.CmdType {
+	case raft_cmdpb.CmdType_Put:
+		return req.Put.Cf, req.Put.Key
+	case raft_cmdpb.CmdType_Delete:
+		return req.Delete.Cf, req.Delete.Key
+	}
+	return "", nil
+}
+
+// latchKeys returns the write latches of the keys written by reqs, each once.
+func latchKeys(reqs []*raft_cmdpb.Request) [][]byte {
+	keys := make([][]byte, 0, len(reqs))
+	seen := make(map[string]bool, len(reqs))
+	for _, req := range reqs {
+		key := engine_util.KeyWithCF(requestKey(req))
+		if !seen[string(key)] {
+			seen[string(key)] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
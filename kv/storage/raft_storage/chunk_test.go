@@ -0,0 +1,32 @@
+package raft_storage
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatchKeys(t *testing.T) {
+	put := func(cf, key string) *raft_cmdpb.Request {
+		return &raft_cmdpb.Request{CmdType: raft_cmdpb.CmdType_Put,
+			Put: &raft_cmdpb.PutRequest{Cf: cf, Key: []byte(key), Value: []byte("v")}}
+	}
+	del := func(cf, key string) *raft_cmdpb.Request {
+		return &raft_cmdpb.Request{CmdType: raft_cmdpb.CmdType_Delete,
+			Delete: &raft_cmdpb.DeleteRequest{Cf: cf, Key: []byte(key)}}
+	}
+	reqs := []*raft_cmdpb.Request{
+		put(engine_util.CfDefault, "a"),
+		put(engine_util.CfWrite, "a"),
+		del(engine_util.CfDefault, "a"),
+		del(engine_util.CfLock, "b"),
+	}
+	// a key written twice is latched once, the same key of another CF has its own latch
+	assert.Equal(t, [][]byte{
+		engine_util.KeyWithCF(engine_util.CfDefault, []byte("a")),
+		engine_util.KeyWithCF(engine_util.CfWrite, []byte("a")),
+		engine_util.KeyWithCF(engine_util.CfLock, []byte("b")),
+	}, latchKeys(reqs))
+}
@@ -0,0 +1,225 @@
+package raft_storage
+
+import (
+	"bytes"
+
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/log"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+)
+
+// MultiRegionReader reads a key range across the regions of the range led by this store, for internal consumers
+// like the GC worker or a backup which scan a whole range on the store without routing the requests themselves.
+// The data of each region is read from a snapshot taken through raft when the region is first read, so the read
+// is checked against the leadership of the peer as a Reader is, but the regions are not read at the same point of
+// time. The parts of the range the store doesn't lead are skipped, see Skipped. A MultiRegionReader isn't safe for
+// concurrent use.
+type MultiRegionReader struct {
+	startKey []byte
+	endKey   []byte
+	// the regions of the range to read in key order, with the peers of this store
+	regions []*metapb.Region
+	peers   []*metapb.Peer
+	readers map[uint64]*RegionReader
+	skipped []*kvrpcpb.KeyRange
+	open    func(region *metapb.Region, peer *metapb.Peer) (*RegionReader, error)
+}
+
+// MultiRegionReader returns a reader of [startKey, endKey), an empty endKey is unbounded. The regions are the ones
+// the store knows it leads when the reader is created, or whose leader is unknown.
+func (rs *RaftStorage) MultiRegionReader(startKey, endKey []byte) *MultiRegionReader {
+	var storeID uint64
+	if rs.node != nil {
+		storeID = rs.node.GetStoreID()
+	}
+	r := newMultiRegionReader(startKey, endKey, func(region *metapb.Region, peer *metapb.Peer) (*RegionReader, error) {
+		reader, err := rs.Reader(&kvrpcpb.Context{RegionId: region.Id, RegionEpoch: region.RegionEpoch, Peer: peer})
+		if err != nil {
+			return nil, err
+		}
+		return reader.(*RegionReader), nil
+	})
+	for _, cached := range rs.regionCache.scan(startKey, endKey) {
+		peer := util.FindPeer(cached.region, storeID)
+		if peer == nil || (cached.leader != nil && cached.leader.Id != peer.Id) {
+			continue
+		}
+		r.addRegion(cached.region, peer)
+	}
+	r.skipGaps()
+	return r
+}
+
+func newMultiRegionReader(startKey, endKey []byte, open func(region *metapb.Region, peer *metapb.Peer) (*RegionReader, error)) *MultiRegionReader {
+	return &MultiRegionReader{
+		startKey: startKey,
+		endKey:   endKey,
+		readers:  make(map[uint64]*RegionReader),
+		open:     open,
+	}
+}
+
+// addRegion adds a region to read, the regions must be added in key order.
+func (r *MultiRegionReader) addRegion(region *metapb.Region, peer *metapb.Peer) {
+	r.regions = append(r.regions, region)
+	r.peers = append(r.peers, peer)
+}
+
+// skipGaps records the parts of the range out of the regions to read as skipped.
+func (r *MultiRegionReader) skipGaps() {
+	key := r.startKey
+	for _, region := range r.regions {
+		if bytes.Compare(key, region.StartKey) < 0 {
+			r.skip(key, region.StartKey)
+		}
+		if len(region.EndKey) == 0 {
+			return
+		}
+		key = region.EndKey
+	}
+	if !engine_util.ExceedEndKey(key, r.endKey) {
+		r.skip(key, r.endKey)
+	}
+}
+
+// skip records the part of [start, end) in the range of the reader as skipped.
+func (r *MultiRegionReader) skip(start, end []byte) {
+	if bytes.Compare(start, r.startKey) < 0 {
+		start = r.startKey
+	}
+	if len(end) == 0 || (len(r.endKey) > 0 && bytes.Compare(end, r.endKey) > 0) {
+		end = r.endKey
+	}
+	r.skipped = append(r.skipped, &kvrpcpb.KeyRange{StartKey: start, EndKey: end})
+}
+
+// Skipped returns the parts of the range which are not read, because the store doesn't lead the regions there, or
+// their read check failed. A consumer which has to read the whole range must read them on the other stores. The
+// ranges are complete once the iterators are exhausted.
+func (r *MultiRegionReader) Skipped() []*kvrpcpb.KeyRange {
+	return r.skipped
+}
+
+// reader returns the reader of the i-th region, nil if it can't be read.
+func (r *MultiRegionReader) reader(i int) *RegionReader {
+	region := r.regions[i]
+	if reader, ok := r.readers[region.Id]; ok {
+		return reader
+	}
+	reader, err := r.open(region, r.peers[i])
+	if err != nil {
+		log.Infof("multi region reader skips region %d: %v", region.Id, err)
+		r.skip(region.StartKey, region.EndKey)
+	}
+	r.readers[region.Id] = reader
+	return reader
+}
+
+// regionIndex returns the index of the first region whose end is after the key.
+func (r *MultiRegionReader) regionIndex(key []byte) int {
+	for i, region := range r.regions {
+		if !engine_util.ExceedEndKey(key, region.EndKey) {
+			return i
+		}
+	}
+	return len(r.regions)
+}
+
+// GetCF returns the value of the key, the key must be in the range. It returns nil if the key doesn't exist, or is
+// in a skipped part of the range.
+func (r *MultiRegionReader) GetCF(cf string, key []byte) ([]byte, error) {
+	i := r.regionIndex(key)
+	if i == len(r.regions) || bytes.Compare(key, r.regions[i].StartKey) < 0 {
+		return nil, nil
+	}
+	reader := r.reader(i)
+	if reader == nil {
+		return nil, nil
+	}
+	return reader.GetCF(cf, key)
+}
+
+// IterCF returns an iterator of the CF over the regions of the range, in key order.
+func (r *MultiRegionReader) IterCF(cf string) engine_util.DBIterator {
+	return &multiRegionIterator{reader: r, cf: cf, idx: len(r.regions)}
+}
+
+// Close releases the snapshots of the regions, the iterators must be closed first.
+func (r *MultiRegionReader) Close() {
+	for _, reader := range r.readers {
+		if reader != nil {
+			reader.Close()
+		}
+	}
+}
+
+// multiRegionIterator iterates the regions of a MultiRegionReader one by one, the regions that can't be read are
+// skipped.
+type multiRegionIterator struct {
+	reader *MultiRegionReader
+	cf     string
+	// the region iterated, len(reader.regions) if the iteration is done
+	idx  int
+	iter engine_util.DBIterator
+}
+
+func (it *multiRegionIterator) Item() engine_util.DBItem {
+	return it.iter.Item()
+}
+
+func (it *multiRegionIterator) Valid() bool {
+	return it.iter != nil && it.iter.Valid() && !engine_util.ExceedEndKey(it.iter.Item().Key(), it.reader.endKey)
+}
+
+func (it *multiRegionIterator) Next() {
+	it.iter.Next()
+	if !it.iter.Valid() {
+		it.seekRegion(it.idx+1, nil)
+	}
+}
+
+func (it *multiRegionIterator) Seek(key []byte) {
+	if bytes.Compare(key, it.reader.startKey) < 0 {
+		key = it.reader.startKey
+	}
+	it.seekRegion(it.reader.regionIndex(key), key)
+}
+
+// seekRegion seeks to the key in the idx-th region, or to the start of the region if the key is before it. It goes
+// on in the next region until it finds a valid key.
+func (it *multiRegionIterator) seekRegion(idx int, key []byte) {
+	it.closeIter()
+	for it.idx = idx; it.idx < len(it.reader.regions); it.idx++ {
+		region := it.reader.regions[it.idx]
+		if len(it.reader.endKey) > 0 && bytes.Compare(region.StartKey, it.reader.endKey) >= 0 {
+			break
+		}
+		reader := it.reader.reader(it.idx)
+		if reader == nil {
+			continue
+		}
+		if bytes.Compare(key, region.StartKey) < 0 {
+			key = region.StartKey
+		}
+		it.iter = reader.IterCF(it.cf)
+		it.iter.Seek(key)
+		if it.iter.Valid() {
+			return
+		}
+		it.closeIter()
+	}
+	it.idx = len(it.reader.regions)
+}
+
+func (it *multiRegionIterator) closeIter() {
+	if it.iter != nil {
+		it.iter.Close()
+		it.iter = nil
+	}
+}
+
+func (it *multiRegionIterator) Close() {
+	it.closeIter()
+}
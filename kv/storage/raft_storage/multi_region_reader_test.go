@@ -0,0 +1,64 @@
+package raft_storage
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/pingcap/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiRegionReader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "multi-region-reader")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	db := engine_util.CreateDB(dir, false)
+	defer db.Close()
+	for _, key := range []string{"a", "b", "d", "g", "h", "i", "z"} {
+		assert.Nil(t, engine_util.PutCF(db, engine_util.CfDefault, []byte(key), []byte("v"+key)))
+	}
+
+	// the store leads [, c), [d, f) whose read fails, and [h, )
+	r := newMultiRegionReader([]byte("b"), []byte("x"), func(region *metapb.Region, peer *metapb.Peer) (*RegionReader, error) {
+		if region.Id == 2 {
+			return nil, errors.New("not leader")
+		}
+		return NewRegionReader(db.NewTransaction(false), *region), nil
+	})
+	r.addRegion(newTestRegion(1, "", "c", 1), nil)
+	r.addRegion(newTestRegion(2, "d", "f", 1), nil)
+	r.addRegion(newTestRegion(3, "h", "", 1), nil)
+	r.skipGaps()
+	defer r.Close()
+
+	iter := r.IterCF(engine_util.CfDefault)
+	var keys []string
+	for iter.Seek(nil); iter.Valid(); iter.Next() {
+		keys = append(keys, string(iter.Item().Key()))
+	}
+	assert.Equal(t, []string{"b", "h", "i"}, keys)
+
+	keys = nil
+	for iter.Seek([]byte("c")); iter.Valid(); iter.Next() {
+		keys = append(keys, string(iter.Item().Key()))
+	}
+	assert.Equal(t, []string{"h", "i"}, keys)
+	iter.Close()
+
+	value, err := r.GetCF(engine_util.CfDefault, []byte("i"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("vi"), value)
+	value, err = r.GetCF(engine_util.CfDefault, []byte("g"))
+	assert.Nil(t, err)
+	assert.Nil(t, value)
+
+	assert.Equal(t, []*kvrpcpb.KeyRange{
+		{StartKey: []byte("c"), EndKey: []byte("d")},
+		{StartKey: []byte("f"), EndKey: []byte("h")},
+		{StartKey: []byte("d"), EndKey: []byte("f")},
+	}, r.Skipped())
+}
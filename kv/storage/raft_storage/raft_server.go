@@ -2,23 +2,33 @@ package raft_storage
 
 import (
 	"context"
-	"os"
-	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/Connor1996/badger"
 	"github.com/pingcap-incubator/tinykv/kv/config"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/cdc"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/keycheck"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/keyfilter"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/lockindex"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/message"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/meta"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/scheduler_client"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/snap"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
 	"github.com/pingcap-incubator/tinykv/kv/storage"
+	"github.com/pingcap-incubator/tinykv/kv/transaction/latches"
 	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
 	"github.com/pingcap-incubator/tinykv/kv/util/worker"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/errorpb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
+	rspb "github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/tinykvpb"
+	"github.com/pingcap-incubator/tinykv/raft"
 	"github.com/pingcap/errors"
 )
 
@@ -34,6 +44,21 @@ type RaftStorage struct {
 	raftSystem    *raftstore.Raftstore
 	resolveWorker *worker.Worker
 	snapWorker    *worker.Worker
+	// allocates the ids of the peers added by RecreatePeer
+	schedulerClient scheduler_client.Client
+
+	regionObservers []raftstore.RegionChangeObserver
+	applyDelegates  map[string]raftstore.ApplyDelegate
+	applyObservers  []applyObserver
+	// routing table of the regions on the store, used to fill incomplete request contexts
+	regionCache *regionCache
+	// the leader and epoch change subscriptions of the clients
+	regionWatches *regionWatches
+	// the hashes of the replicas taken by their last consistency checks, see HashRegion
+	regionHashes regionHashes
+	// latches of the keys written by Write, by CF, so a batch written in chunks is undone before the keys are written
+	// again
+	writeLatches *latches.Latches
 
 	wg sync.WaitGroup
 }
@@ -48,6 +73,7 @@ func (re *RegionError) Error() string {
 
 func (rs *RaftStorage) checkResponse(resp *raft_cmdpb.RaftCmdResponse, reqCount int) error {
 	if resp.Header.Error != nil {
+		rs.regionCache.onRegionError(resp.Header.Error)
 		return &RegionError{RequestErr: resp.Header.Error}
 	}
 	if len(resp.Responses) != reqCount {
@@ -59,23 +85,74 @@ func (rs *RaftStorage) checkResponse(resp *raft_cmdpb.RaftCmdResponse, reqCount
 
 // NewRaftStorage creates a new storage engine backed by a raftstore.
 func NewRaftStorage(conf *config.Config) *RaftStorage {
-	dbPath := conf.DBPath
-	kvPath := filepath.Join(dbPath, "kv")
-	raftPath := filepath.Join(dbPath, "raft")
-	snapPath := filepath.Join(dbPath, "snap")
-
-	os.MkdirAll(kvPath, os.ModePerm)
-	os.MkdirAll(raftPath, os.ModePerm)
-	os.Mkdir(snapPath, os.ModePerm)
+	if err := conf.PrepareDataDirs(); err != nil {
+		panic(err)
+	}
+	kvPath := conf.KvDir()
+	raftPath := conf.RaftDir()
 
-	raftDB := engine_util.CreateDB(raftPath, true)
+	raftDB := engine_util.CreateDBWithSync(raftPath, true, conf.RaftSyncLog == config.SyncLogAlways)
 	kvDB := engine_util.CreateDB(kvPath, false)
 	engines := engine_util.NewEngines(kvDB, raftDB, kvPath, raftPath)
 
-	return &RaftStorage{engines: engines, config: conf}
+	cache := newRegionCache()
+	watches := newRegionWatches()
+	return &RaftStorage{
+		engines:         engines,
+		config:          conf,
+		regionObservers: []raftstore.RegionChangeObserver{cache, watches},
+		regionCache:     cache,
+		regionWatches:   watches,
+		writeLatches:    latches.NewLatches(),
+	}
+}
+
+// LocateKey returns the region on the store containing the key and its leader, which is nil if it's unknown. The
+// region is nil if no region on the store is known to contain the key.
+func (rs *RaftStorage) LocateKey(key []byte) (*metapb.Region, *metapb.Peer) {
+	return rs.regionCache.locate(key)
+}
+
+// FillContext fills the missing region id, epoch and peer of ctx from the regions on the store, so a client only
+// has to give the key of the request, or the region id. It returns a RegionError if the region is not on the store.
+func (rs *RaftStorage) FillContext(ctx *kvrpcpb.Context, key []byte) error {
+	if ctx.RegionId != 0 && ctx.RegionEpoch != nil && ctx.Peer != nil {
+		return nil
+	}
+	var region *metapb.Region
+	if ctx.RegionId == 0 {
+		if region, _ = rs.regionCache.locate(key); region == nil {
+			return &RegionError{RequestErr: &errorpb.Error{
+				Message:        "no region on the store contains the key",
+				KeyNotInRegion: &errorpb.KeyNotInRegion{Key: key},
+			}}
+		}
+		ctx.RegionId = region.Id
+	} else {
+		region, _ = rs.regionCache.get(ctx.RegionId)
+	}
+	var peer *metapb.Peer
+	if region != nil && rs.node != nil {
+		peer = util.FindPeer(region, rs.node.GetStoreID())
+	}
+	if peer == nil {
+		return &RegionError{RequestErr: util.RaftstoreErrToPbError(&util.ErrRegionNotFound{RegionId: ctx.RegionId})}
+	}
+	if ctx.RegionEpoch == nil {
+		ctx.RegionEpoch = region.RegionEpoch
+	}
+	if ctx.Peer == nil {
+		ctx.Peer = peer
+	}
+	return nil
 }
 
 func (rs *RaftStorage) Write(ctx *kvrpcpb.Context, batch []storage.Modify) error {
+	if len(batch) > 0 {
+		if err := rs.FillContext(ctx, batch[0].Key()); err != nil {
+			return err
+		}
+	}
 	var reqs []*raft_cmdpb.Request
 	for _, m := range batch {
 		switch m.Data.(type) {
@@ -99,18 +176,35 @@ func (rs *RaftStorage) Write(ctx *kvrpcpb.Context, batch []storage.Modify) error
 		}
 	}
 
+	if requestsSize(reqs) > rs.config.RaftEntryMaxSize {
+		// A single proposal is applied atomically, only a batch written in chunks has to keep other writes to its
+		// keys from being proposed in between and overwritten by its undo.
+		keys := latchKeys(reqs)
+		rs.writeLatches.GroupWaitForLatches(keys)
+		defer rs.writeLatches.ReleaseLatches(keys)
+		return rs.writeInChunks(ctx, reqs)
+	}
+	return rs.propose(ctx, reqs)
+}
+
+// BeginBatch starts a batch which proposes the writes of the commands to a region together.
+func (rs *RaftStorage) BeginBatch() storage.Batch {
+	return storage.NewBatch(rs)
+}
+
+func (rs *RaftStorage) propose(ctx *kvrpcpb.Context, reqs []*raft_cmdpb.Request) error {
 	header := &raft_cmdpb.RaftRequestHeader{
 		RegionId:    ctx.RegionId,
 		Peer:        ctx.Peer,
 		RegionEpoch: ctx.RegionEpoch,
 		Term:        ctx.Term,
 	}
-	request := &raft_cmdpb.RaftCmdRequest{
+	request := &util.WriteCmd{
 		Header:   header,
 		Requests: reqs,
 	}
 	cb := message.NewCallback()
-	if err := rs.raftRouter.SendRaftCommand(request, cb); err != nil {
+	if err := rs.raftRouter.SendRaftCommand(request.RaftCmdRequest(), cb); err != nil {
 		return err
 	}
 
@@ -118,13 +212,16 @@ func (rs *RaftStorage) Write(ctx *kvrpcpb.Context, batch []storage.Modify) error
 }
 
 func (rs *RaftStorage) Reader(ctx *kvrpcpb.Context) (storage.StorageReader, error) {
+	if err := rs.FillContext(ctx, nil); err != nil {
+		return nil, err
+	}
 	header := &raft_cmdpb.RaftRequestHeader{
 		RegionId:    ctx.RegionId,
 		Peer:        ctx.Peer,
 		RegionEpoch: ctx.RegionEpoch,
 		Term:        ctx.Term,
 	}
-	request := &raft_cmdpb.RaftCmdRequest{
+	request := &util.ReadCmd{
 		Header: header,
 		Requests: []*raft_cmdpb.Request{{
 			CmdType: raft_cmdpb.CmdType_Snap,
@@ -132,7 +229,7 @@ func (rs *RaftStorage) Reader(ctx *kvrpcpb.Context) (storage.StorageReader, erro
 		}},
 	}
 	cb := message.NewCallback()
-	if err := rs.raftRouter.SendRaftCommand(request, cb); err != nil {
+	if err := rs.raftRouter.SendRaftCommand(request.RaftCmdRequest(), cb); err != nil {
 		return nil, err
 	}
 
@@ -149,7 +246,226 @@ func (rs *RaftStorage) Reader(ctx *kvrpcpb.Context) (storage.StorageReader, erro
 	if len(resp.Responses) != 1 {
 		panic("wrong response count for snap cmd")
 	}
-	return NewRegionReader(cb.Txn, *resp.Responses[0].GetSnap().Region), nil
+	reader := NewRegionReader(cb.Txn, *resp.Responses[0].GetSnap().Region)
+	reader.locks = cb.Locks
+	return reader, nil
+}
+
+// RegisterRegionObserver subscribes the observer to the region changes of this store. The observers registered
+// before Start also receive the creation of the regions loaded on start.
+func (rs *RaftStorage) RegisterRegionObserver(observer raftstore.RegionChangeObserver) {
+	if rs.raftSystem != nil {
+		rs.raftSystem.RegionObservers().Register(observer)
+		return
+	}
+	rs.regionObservers = append(rs.regionObservers, observer)
+}
+
+// RegisterApplyDelegate registers the applier of the custom commands with the name, it must be called before Start.
+func (rs *RaftStorage) RegisterApplyDelegate(name string, delegate raftstore.ApplyDelegate) {
+	if rs.applyDelegates == nil {
+		rs.applyDelegates = make(map[string]raftstore.ApplyDelegate)
+	}
+	rs.applyDelegates[name] = delegate
+}
+
+type applyObserver struct {
+	observer raftstore.ApplyObserver
+	types    []raft_cmdpb.CmdType
+}
+
+// RegisterApplyObserver adds the observer of the applied requests of the types, of all the types if none is given.
+// It must be called before Start.
+func (rs *RaftStorage) RegisterApplyObserver(observer raftstore.ApplyObserver, types ...raft_cmdpb.CmdType) {
+	rs.applyObservers = append(rs.applyObservers, applyObserver{observer: observer, types: types})
+}
+
+// ProposeCustom replicates a custom command through the raft group of the region in ctx, returning the data
+// returned by its apply delegate.
+func (rs *RaftStorage) ProposeCustom(ctx *kvrpcpb.Context, name string, data []byte) ([]byte, error) {
+	if err := rs.FillContext(ctx, nil); err != nil {
+		return nil, err
+	}
+	request := &util.WriteCmd{
+		Header: &raft_cmdpb.RaftRequestHeader{
+			RegionId:    ctx.RegionId,
+			Peer:        ctx.Peer,
+			RegionEpoch: ctx.RegionEpoch,
+			Term:        ctx.Term,
+		},
+		Requests: []*raft_cmdpb.Request{{
+			CmdType: raft_cmdpb.CmdType_Custom,
+			Custom:  &raft_cmdpb.CustomRequest{Name: name, Data: data},
+		}},
+	}
+	cb := message.NewCallback()
+	if err := rs.raftRouter.SendRaftCommand(request.RaftCmdRequest(), cb); err != nil {
+		return nil, err
+	}
+	resp := cb.WaitResp()
+	if err := rs.checkResponse(resp, 1); err != nil {
+		return nil, err
+	}
+	return resp.Responses[0].GetCustom().GetData(), nil
+}
+
+// KeyFilters returns the filters of the keys in the regions of the store, nil if they are disabled.
+func (rs *RaftStorage) KeyFilters() *keyfilter.KeyFilters {
+	if rs.raftSystem == nil {
+		return nil
+	}
+	return rs.raftSystem.KeyFilters()
+}
+
+// LockIndex returns the index of the locks in the regions of the store, nil if it's disabled.
+func (rs *RaftStorage) LockIndex() *lockindex.LockIndex {
+	if rs.raftSystem == nil {
+		return nil
+	}
+	return rs.raftSystem.LockIndex()
+}
+
+// RawLatches returns the latches of the raw keys shared with the raw tombstone GC, nil if the raw soft delete is
+// disabled.
+func (rs *RaftStorage) RawLatches() *latches.Latches {
+	if rs.raftSystem == nil {
+		return nil
+	}
+	return rs.raftSystem.RawLatches()
+}
+
+// KeyChecker returns the checker of the keys written to the store, nil if it's disabled.
+func (rs *RaftStorage) KeyChecker() *keycheck.KeyChecker {
+	if rs.raftSystem == nil {
+		return nil
+	}
+	return rs.raftSystem.KeyChecker()
+}
+
+// ChangeCapturer returns the capturer of the changes of the regions with CDC enabled, nil if it's disabled.
+func (rs *RaftStorage) ChangeCapturer() *cdc.Capturer {
+	if rs.raftSystem == nil {
+		return nil
+	}
+	return rs.raftSystem.ChangeCapturer()
+}
+
+// ReadyStats returns the time the raft workers spent in each stage of their loops, nil if the store is not started.
+func (rs *RaftStorage) ReadyStats() *raftstore.ReadyStats {
+	if rs.raftSystem == nil {
+		return nil
+	}
+	return rs.raftSystem.ReadyStats()
+}
+
+// CreatePeer creates the peer of a new replica of the region on this store
+// and waits until it's started. The peer is initialized by a snapshot from the
+// leader, it's destroyed if none arrives within the bootstrap timeout.
+func (rs *RaftStorage) CreatePeer(region *metapb.Region, peer *metapb.Peer) error {
+	cb := message.NewCallback()
+	rs.raftRouter.CreatePeer(region, peer, cb)
+	resp := cb.WaitResp()
+	if err := resp.Header.Error; err != nil {
+		if err.StoreNotMatch != nil {
+			return &RegionError{RequestErr: err}
+		}
+		return errors.New(err.Message)
+	}
+	return nil
+}
+
+// CompactRegionLog compacts the raft log of the region applied so far regardless of the raft log gc limits, and waits
+// until the compaction is applied. The peer of the region on this store must be the leader.
+func (rs *RaftStorage) CompactRegionLog(regionID uint64) error {
+	cb := message.NewCallback()
+	if err := rs.raftRouter.CompactLog(regionID, cb); err != nil {
+		return err
+	}
+	resp := cb.WaitResp()
+	if resp.Header.Error != nil {
+		return &RegionError{RequestErr: resp.Header.Error}
+	}
+	return nil
+}
+
+// TriggerSplitCheck schedules a check whether the region needs to be split, regardless of the size changed since the
+// last check. The peer of the region on this store must be the leader.
+func (rs *RaftStorage) TriggerSplitCheck(regionID uint64) error {
+	cb := message.NewCallback()
+	if err := rs.raftRouter.SplitCheck(regionID, cb); err != nil {
+		return err
+	}
+	resp := cb.WaitResp()
+	if resp.Header.Error != nil {
+		return &RegionError{RequestErr: resp.Header.Error}
+	}
+	return nil
+}
+
+// FlushEngines syncs the files of the kv engine and the raft engine to the disk.
+func (rs *RaftStorage) FlushEngines() error {
+	if err := engine_util.SyncDB(rs.engines.KvPath, time.Time{}); err != nil {
+		return err
+	}
+	return engine_util.SyncDB(rs.engines.RaftPath, time.Time{})
+}
+
+// raftStatusTimeout is how long RaftStatus waits for the peer, which may be busy in a stuck raft worker.
+const raftStatusTimeout = 3 * time.Second
+
+// RaftStatus returns the raft status of the peer of the region on this store.
+func (rs *RaftStorage) RaftStatus(regionID uint64) (raft.Status, error) {
+	ch := make(chan raft.Status, 1)
+	if err := rs.raftRouter.RaftStatus(regionID, ch); err != nil {
+		return raft.Status{}, &util.ErrRegionNotFound{RegionId: regionID}
+	}
+	select {
+	case status := <-ch:
+		return status, nil
+	case <-time.After(raftStatusTimeout):
+		return raft.Status{}, errors.Errorf("region %d didn't return its raft status within %v", regionID, raftStatusTimeout)
+	}
+}
+
+// ReadIndex returns the committed index of the region and the applied index of the peer in ctx. Unless local is
+// set, the indexes are read after a read through raft, so the peer must be the leader and the committed index
+// covers every write committed before the call. Otherwise they are read from the local raft state of the peer.
+func (rs *RaftStorage) ReadIndex(ctx *kvrpcpb.Context, local bool) (uint64, uint64, error) {
+	var applied uint64
+	if !local {
+		reader, err := rs.Reader(ctx)
+		if err != nil {
+			return 0, 0, err
+		}
+		defer reader.Close()
+		if applied, err = reader.(*RegionReader).ApplyIndex(); err != nil {
+			return 0, 0, err
+		}
+	} else {
+		regionState, err := meta.GetRegionLocalState(rs.engines.Kv, ctx.RegionId)
+		if err != nil && err != badger.ErrKeyNotFound {
+			return 0, 0, err
+		}
+		if err == badger.ErrKeyNotFound || regionState.State == rspb.PeerState_Tombstone ||
+			util.FindPeer(regionState.Region, ctx.GetPeer().GetStoreId()).GetId() != ctx.GetPeer().GetId() {
+			return 0, 0, &RegionError{RequestErr: util.RaftstoreErrToPbError(&util.ErrRegionNotFound{RegionId: ctx.RegionId})}
+		}
+		applyState, err := meta.GetApplyState(rs.engines.Kv, ctx.RegionId)
+		if err != nil {
+			return 0, 0, err
+		}
+		applied = applyState.AppliedIndex
+	}
+	raftState, err := meta.GetRaftLocalState(rs.engines.Raft, ctx.RegionId)
+	if err != nil {
+		return 0, 0, err
+	}
+	committed := raftState.HardState.GetCommit()
+	if committed < applied {
+		// never report a committed index behind the applied one
+		committed = applied
+	}
+	return committed, applied, nil
 }
 
 func (rs *RaftStorage) Raft(stream tinykvpb.TinyKv_RaftServer) error {
@@ -158,6 +474,11 @@ func (rs *RaftStorage) Raft(stream tinykvpb.TinyKv_RaftServer) error {
 		if err != nil {
 			return err
 		}
+		// not reading the stream while the peer is behind makes gRPC flow control slow the sender down, rather than
+		// piling up the messages in memory
+		if err := rs.raftRouter.WaitRaftMessages(stream.Context(), msg.RegionId, rs.config.RaftRecvMaxPendingSize); err != nil {
+			return err
+		}
 		rs.raftRouter.SendRaftMessage(msg)
 	}
 }
@@ -178,18 +499,28 @@ func (rs *RaftStorage) Snapshot(stream tinykvpb.TinyKv_SnapshotServer) error {
 
 func (rs *RaftStorage) Start() error {
 	cfg := rs.config
-	schedulerClient, err := scheduler_client.NewClient(strings.Split(cfg.SchedulerAddr, ","), "")
+	schedulerClient, err := scheduler_client.NewClient(strings.Split(cfg.SchedulerAddr, ","), "", cfg.SchedulerToken)
 	if err != nil {
 		return err
 	}
+	rs.schedulerClient = schedulerClient
 	rs.raftRouter, rs.raftSystem = raftstore.CreateRaftstore(cfg)
+	for _, observer := range rs.regionObservers {
+		rs.raftSystem.RegionObservers().Register(observer)
+	}
+	for name, delegate := range rs.applyDelegates {
+		rs.raftSystem.ApplyDelegates().Register(name, delegate)
+	}
+	for _, o := range rs.applyObservers {
+		rs.raftSystem.ApplyObservers().Register(o.observer, o.types...)
+	}
 
 	rs.resolveWorker = worker.NewWorker("resolver", &rs.wg)
 	resolveSender := rs.resolveWorker.Sender()
 	resolveRunner := newResolverRunner(schedulerClient)
 	rs.resolveWorker.Start(resolveRunner)
 
-	rs.snapManager = snap.NewSnapManager(filepath.Join(cfg.DBPath, "snap"))
+	rs.snapManager = snap.NewSnapManager(cfg.SnapDir())
 	rs.snapWorker = worker.NewWorker("snap-worker", &rs.wg)
 	snapSender := rs.snapWorker.Sender()
 	snapRunner := newSnapRunner(rs.snapManager, rs.config, rs.raftRouter)
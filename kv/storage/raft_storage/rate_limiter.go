@@ -0,0 +1,54 @@
+package raft_storage
+
+import (
+	"sync"
+	"time"
+)
+
+// rateLimiter limits a throughput to bytesPerSec, the throughput left unused accumulates up to a second of it. A nil
+// rateLimiter is unlimited.
+type rateLimiter struct {
+	bytesPerSec uint64
+
+	mu sync.Mutex
+	// the time until which the bytes taken so far are paid for
+	next time.Time
+}
+
+func newRateLimiter(bytesPerSec uint64) *rateLimiter {
+	if bytesPerSec == 0 {
+		return nil
+	}
+	return &rateLimiter{bytesPerSec: bytesPerSec}
+}
+
+// reserve takes n bytes, it returns how long to wait before they may be sent.
+func (l *rateLimiter) reserve(n int, now time.Time) time.Duration {
+	if l == nil {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if earliest := now.Add(-time.Second); l.next.Before(earliest) {
+		l.next = earliest
+	}
+	l.next = l.next.Add(time.Duration(uint64(n) * uint64(time.Second) / l.bytesPerSec))
+	if l.next.After(now) {
+		return l.next.Sub(now)
+	}
+	return 0
+}
+
+// waitRateLimiters takes n bytes from each of the limiters, and waits until all of them allow sending them.
+func waitRateLimiters(n int, limiters ...*rateLimiter) {
+	now := time.Now()
+	var wait time.Duration
+	for _, l := range limiters {
+		if d := l.reserve(n, now); d > wait {
+			wait = d
+		}
+	}
+	if wait > 0 {
+		time.Sleep(wait)
+	}
+}
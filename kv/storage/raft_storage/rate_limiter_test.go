@@ -0,0 +1,26 @@
+package raft_storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter(t *testing.T) {
+	unlimited := newRateLimiter(0)
+	assert.Nil(t, unlimited)
+	assert.Equal(t, time.Duration(0), unlimited.reserve(1<<30, time.Now()))
+
+	l := newRateLimiter(1000)
+	now := time.Now()
+	// a second of unused throughput is sent at once
+	assert.Equal(t, time.Duration(0), l.reserve(1000, now))
+	assert.Equal(t, 500*time.Millisecond, l.reserve(500, now))
+	assert.Equal(t, time.Second, l.reserve(500, now))
+	assert.Equal(t, 500*time.Millisecond, l.reserve(0, now.Add(500*time.Millisecond)))
+	// the throughput unused for long accumulates up to a second
+	now = now.Add(time.Hour)
+	assert.Equal(t, time.Duration(0), l.reserve(1000, now))
+	assert.Equal(t, time.Second, l.reserve(1000, now))
+}
@@ -0,0 +1,100 @@
+package raft_storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/message"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
+)
+
+// RecreatePeer replaces the replica of the region on the store by an empty one, to repair a corrupted replica without
+// touching the others. The peer on the store is removed by a conf change, so it's destroyed and leaves a tombstone,
+// then a peer with a new id is added on the store in the same role, which the leader fills by a snapshot. The peer
+// isn't wiped and brought back with its id, it would forget its vote and the entries the leader counted it for.
+// The leader of the region must be on this store, and it can't recreate itself.
+func (rs *RaftStorage) RecreatePeer(regionID, storeID uint64) error {
+	region, err := rs.localRegion(regionID)
+	if err != nil {
+		return err
+	}
+	newID, err := rs.schedulerClient.AllocID(context.TODO())
+	if err != nil {
+		return err
+	}
+	changes, err := recreateChanges(region, rs.node.GetStoreID(), storeID, newID)
+	if err != nil {
+		return err
+	}
+	for _, change := range changes {
+		if region, err = rs.changePeer(region, change); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recreateChanges returns the conf changes which replace the peer of the region on the store by a peer with the new
+// id in the same role, proposed by the leader on the leader store in order.
+func recreateChanges(region *metapb.Region, leaderStoreID, storeID, newID uint64) ([]*raft_cmdpb.ChangePeerRequest, error) {
+	if storeID == leaderStoreID {
+		return nil, fmt.Errorf("can't recreate the peer of region %d on the leader store %d, transfer the leader first",
+			region.Id, storeID)
+	}
+	if util.FindPeer(region, leaderStoreID) == nil {
+		return nil, &util.ErrRegionNotFound{RegionId: region.Id}
+	}
+	old := util.FindPeer(region, storeID)
+	if old == nil {
+		return nil, fmt.Errorf("region %d has no peer on store %d", region.Id, storeID)
+	}
+	addType := eraftpb.ConfChangeType_AddNode
+	switch {
+	case old.IsLearner:
+		addType = eraftpb.ConfChangeType_AddLearnerNode
+	case old.IsWitness:
+		addType = eraftpb.ConfChangeType_AddWitnessNode
+	}
+	return []*raft_cmdpb.ChangePeerRequest{
+		{ChangeType: eraftpb.ConfChangeType_RemoveNode, Peer: old},
+		{ChangeType: addType, Peer: &metapb.Peer{Id: newID, StoreId: storeID, IsLearner: old.IsLearner, IsWitness: old.IsWitness}},
+	}, nil
+}
+
+// localRegion returns the region of the replica on this store.
+func (rs *RaftStorage) localRegion(regionID uint64) (*metapb.Region, error) {
+	txn := rs.engines.Kv.NewTransaction(false)
+	defer txn.Discard()
+	return regionFromTxn(txn, regionID)
+}
+
+// changePeer proposes the conf change of the region by its peer on this store, which must be the leader, and returns
+// the region once the change is applied.
+func (rs *RaftStorage) changePeer(region *metapb.Region, change *raft_cmdpb.ChangePeerRequest) (*metapb.Region, error) {
+	cmd := &util.AdminCmd{
+		Header: &raft_cmdpb.RaftRequestHeader{
+			RegionId:    region.Id,
+			Peer:        util.FindPeer(region, rs.node.GetStoreID()),
+			RegionEpoch: region.RegionEpoch,
+		},
+		Request: &raft_cmdpb.AdminRequest{CmdType: raft_cmdpb.AdminCmdType_ChangePeer, ChangePeer: change},
+	}
+	cb := message.NewCallback()
+	if err := rs.raftRouter.SendRaftCommand(cmd.RaftCmdRequest(), cb); err != nil {
+		return nil, err
+	}
+	resp := cb.WaitResp()
+	if resp == nil {
+		return nil, &util.ErrStaleCommand{}
+	}
+	if resp.Header.Error != nil {
+		return nil, &RegionError{RequestErr: resp.Header.Error}
+	}
+	if newRegion := resp.GetAdminResponse().GetChangePeer().GetRegion(); newRegion != nil {
+		return newRegion, nil
+	}
+	return rs.localRegion(region.Id)
+}
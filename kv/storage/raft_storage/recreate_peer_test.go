@@ -0,0 +1,51 @@
+package raft_storage
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecreateChanges(t *testing.T) {
+	region := &metapb.Region{Id: 1, RegionEpoch: &metapb.RegionEpoch{ConfVer: 3, Version: 1},
+		Peers: []*metapb.Peer{{Id: 2, StoreId: 1}, {Id: 3, StoreId: 2}, {Id: 4, StoreId: 3, IsLearner: true}}}
+
+	changes, err := recreateChanges(region, 1, 2, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []*raft_cmdpb.ChangePeerRequest{
+		{ChangeType: eraftpb.ConfChangeType_RemoveNode, Peer: &metapb.Peer{Id: 3, StoreId: 2}},
+		{ChangeType: eraftpb.ConfChangeType_AddNode, Peer: &metapb.Peer{Id: 10, StoreId: 2}},
+	}, changes)
+	// the changes apply in order, and the recreated peer has a new id
+	for _, change := range changes {
+		cmd := &util.AdminCmd{
+			Header:  &raft_cmdpb.RaftRequestHeader{RegionEpoch: region.RegionEpoch},
+			Request: &raft_cmdpb.AdminRequest{CmdType: raft_cmdpb.AdminCmdType_ChangePeer, ChangePeer: change},
+		}
+		cc, err := util.NewConfChange(cmd)
+		assert.Nil(t, err)
+		ctx, err := util.ParseConfChangeContext(cc)
+		assert.Nil(t, err)
+		region, err = util.ApplyConfChange(region, cc, ctx)
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, uint64(10), util.FindPeer(region, 2).Id)
+	assert.Equal(t, uint64(5), region.RegionEpoch.ConfVer)
+
+	// a learner is recreated as a learner
+	changes, err = recreateChanges(region, 1, 3, 11)
+	assert.Nil(t, err)
+	assert.Equal(t, eraftpb.ConfChangeType_AddLearnerNode, changes[1].ChangeType)
+	assert.True(t, changes[1].Peer.IsLearner)
+
+	_, err = recreateChanges(region, 1, 1, 12)
+	assert.NotNil(t, err)
+	_, err = recreateChanges(region, 1, 4, 12)
+	assert.NotNil(t, err)
+	_, err = recreateChanges(region, 5, 2, 12)
+	assert.NotNil(t, err)
+}
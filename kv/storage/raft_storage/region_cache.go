@@ -0,0 +1,200 @@
+package raft_storage
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/errorpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/pingcap-incubator/tinykv/scheduler/pkg/btree"
+)
+
+// cachedRegion is an entry of the region cache, leader is nil if it's unknown.
+type cachedRegion struct {
+	region *metapb.Region
+	leader *metapb.Peer
+}
+
+func (c *cachedRegion) Less(other btree.Item) bool {
+	return bytes.Compare(c.region.GetStartKey(), other.(*cachedRegion).region.GetStartKey()) < 0
+}
+
+// regionCache is the routing table of the regions on the store, it maps a key to the region, its epoch and leader.
+// It's updated by the region change events and the region errors of the requests, a cached region is only replaced
+// by a region with a newer epoch, so a late event never brings back a stale route.
+type regionCache struct {
+	sync.RWMutex
+	ranges  *btree.BTree
+	regions map[uint64]*cachedRegion
+}
+
+func newRegionCache() *regionCache {
+	return &regionCache{
+		ranges:  btree.New(2),
+		regions: make(map[uint64]*cachedRegion),
+	}
+}
+
+func (c *regionCache) OnRegionChanged(event *raftstore.RegionChangeEvent) {
+	switch event.Type {
+	case raftstore.RegionChangeCreate, raftstore.RegionChangeSplit, raftstore.RegionChangeMerge, raftstore.RegionChangeEpoch:
+		c.update(event.Region, 0)
+	case raftstore.RegionChangeLeader:
+		c.update(event.Region, event.LeaderId)
+	case raftstore.RegionChangeDestroy:
+		c.remove(event.Region)
+	}
+}
+
+// onRegionError updates the cache with the current regions of an epoch not match error and the leader of a not
+// leader error.
+func (c *regionCache) onRegionError(err *errorpb.Error) {
+	for _, region := range err.GetEpochNotMatch().GetCurrentRegions() {
+		c.update(region, 0)
+	}
+	if notLeader := err.GetNotLeader(); notLeader != nil && notLeader.Leader != nil {
+		c.Lock()
+		defer c.Unlock()
+		if cached := c.regions[notLeader.RegionId]; cached != nil {
+			cached.leader = notLeader.Leader
+		}
+	}
+}
+
+// update caches the region unless a newer epoch of it or of an overlapping region is cached. The leader is kept
+// if the region still has the peer, unless leaderID is set.
+func (c *regionCache) update(region *metapb.Region, leaderID uint64) {
+	if region == nil || region.RegionEpoch == nil {
+		return
+	}
+	region = proto.Clone(region).(*metapb.Region)
+	c.Lock()
+	defer c.Unlock()
+	entry := &cachedRegion{region: region}
+	if old := c.regions[region.Id]; old != nil {
+		if util.IsEpochStale(region.RegionEpoch, old.region.RegionEpoch) {
+			return
+		}
+		if old.leader != nil {
+			entry.leader = findPeerByID(region, old.leader.Id)
+		}
+	}
+	overlaps := c.overlaps(region)
+	for _, over := range overlaps {
+		if over.region.Id != region.Id && over.region.RegionEpoch.Version > region.RegionEpoch.Version {
+			return
+		}
+	}
+	for _, over := range overlaps {
+		c.delete(over)
+	}
+	if old := c.regions[region.Id]; old != nil {
+		c.delete(old)
+	}
+	if leaderID != 0 {
+		entry.leader = findPeerByID(region, leaderID)
+	}
+	c.ranges.ReplaceOrInsert(entry)
+	c.regions[region.Id] = entry
+}
+
+// remove drops the region unless a newer epoch of it is cached.
+func (c *regionCache) remove(region *metapb.Region) {
+	c.Lock()
+	defer c.Unlock()
+	old := c.regions[region.GetId()]
+	if old == nil || (region.RegionEpoch != nil && util.IsEpochStale(region.RegionEpoch, old.region.RegionEpoch)) {
+		return
+	}
+	c.delete(old)
+}
+
+func (c *regionCache) delete(entry *cachedRegion) {
+	c.ranges.Delete(entry)
+	delete(c.regions, entry.region.Id)
+}
+
+// overlaps returns the cached regions overlapping with the region.
+func (c *regionCache) overlaps(region *metapb.Region) []*cachedRegion {
+	var overlaps []*cachedRegion
+	item := &cachedRegion{region: region}
+	var first *cachedRegion
+	c.ranges.DescendLessOrEqual(item, func(i btree.Item) bool {
+		first = i.(*cachedRegion)
+		return false
+	})
+	if first == nil || engine_util.ExceedEndKey(region.GetStartKey(), first.region.GetEndKey()) {
+		first = item
+	}
+	c.ranges.AscendGreaterOrEqual(first, func(i btree.Item) bool {
+		over := i.(*cachedRegion)
+		if engine_util.ExceedEndKey(over.region.GetStartKey(), region.GetEndKey()) {
+			return false
+		}
+		overlaps = append(overlaps, over)
+		return true
+	})
+	return overlaps
+}
+
+// locate returns the region containing the key and its leader, nil if the region isn't cached.
+func (c *regionCache) locate(key []byte) (*metapb.Region, *metapb.Peer) {
+	c.RLock()
+	defer c.RUnlock()
+	var found *cachedRegion
+	c.ranges.DescendLessOrEqual(&cachedRegion{region: &metapb.Region{StartKey: key}}, func(i btree.Item) bool {
+		found = i.(*cachedRegion)
+		return false
+	})
+	if found == nil || engine_util.ExceedEndKey(key, found.region.GetEndKey()) {
+		return nil, nil
+	}
+	return found.region, found.leader
+}
+
+// scan returns the cached regions overlapping with [startKey, endKey) in key order, an empty endKey is unbounded.
+func (c *regionCache) scan(startKey, endKey []byte) []cachedRegion {
+	c.RLock()
+	defer c.RUnlock()
+	overlaps := c.overlaps(&metapb.Region{StartKey: startKey, EndKey: endKey})
+	regions := make([]cachedRegion, 0, len(overlaps))
+	for _, over := range overlaps {
+		regions = append(regions, *over)
+	}
+	return regions
+}
+
+// get returns the cached region and its leader, nil if the region isn't cached.
+func (c *regionCache) get(regionID uint64) (*metapb.Region, *metapb.Peer) {
+	c.RLock()
+	defer c.RUnlock()
+	if cached := c.regions[regionID]; cached != nil {
+		return cached.region, cached.leader
+	}
+	return nil, nil
+}
+
+// all returns the cached regions in key order.
+func (c *regionCache) all() []*cachedRegion {
+	c.RLock()
+	defer c.RUnlock()
+	regions := make([]*cachedRegion, 0, c.ranges.Len())
+	c.ranges.Ascend(func(i btree.Item) bool {
+		regions = append(regions, i.(*cachedRegion))
+		return true
+	})
+	return regions
+}
+
+func findPeerByID(region *metapb.Region, peerID uint64) *metapb.Peer {
+	for _, peer := range region.GetPeers() {
+		if peer.Id == peerID {
+			return peer
+		}
+	}
+	return nil
+}
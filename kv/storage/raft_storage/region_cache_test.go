@@ -0,0 +1,65 @@
+package raft_storage
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/kv/raftstore"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/errorpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRegion(id uint64, start, end string, version uint64) *metapb.Region {
+	return &metapb.Region{
+		Id:          id,
+		StartKey:    []byte(start),
+		EndKey:      []byte(end),
+		RegionEpoch: &metapb.RegionEpoch{ConfVer: 1, Version: version},
+		Peers:       []*metapb.Peer{{Id: id * 10, StoreId: 1}, {Id: id*10 + 1, StoreId: 2}},
+	}
+}
+
+func TestRegionCache(t *testing.T) {
+	c := newRegionCache()
+	c.OnRegionChanged(&raftstore.RegionChangeEvent{Type: raftstore.RegionChangeCreate, Region: newTestRegion(1, "", "", 1)})
+	region, leader := c.locate([]byte("k"))
+	assert.Equal(t, uint64(1), region.Id)
+	assert.Nil(t, leader)
+
+	c.OnRegionChanged(&raftstore.RegionChangeEvent{Type: raftstore.RegionChangeLeader, Region: newTestRegion(1, "", "", 1), LeaderId: 11})
+	_, leader = c.locate([]byte("k"))
+	assert.Equal(t, uint64(11), leader.Id)
+
+	// split into [, m) and [m, )
+	c.OnRegionChanged(&raftstore.RegionChangeEvent{Type: raftstore.RegionChangeSplit, Region: newTestRegion(1, "", "m", 2)})
+	c.OnRegionChanged(&raftstore.RegionChangeEvent{Type: raftstore.RegionChangeCreate, Region: newTestRegion(2, "m", "", 2)})
+	region, leader = c.locate([]byte("a"))
+	assert.Equal(t, uint64(1), region.Id)
+	assert.Equal(t, uint64(11), leader.Id)
+	region, _ = c.locate([]byte("k"))
+	assert.Equal(t, uint64(1), region.Id)
+	region, _ = c.locate([]byte("x"))
+	assert.Equal(t, uint64(2), region.Id)
+
+	// a late event of the region before the split is ignored
+	c.OnRegionChanged(&raftstore.RegionChangeEvent{Type: raftstore.RegionChangeCreate, Region: newTestRegion(1, "", "", 1)})
+	region, _ = c.locate([]byte("x"))
+	assert.Equal(t, uint64(2), region.Id)
+	c.OnRegionChanged(&raftstore.RegionChangeEvent{Type: raftstore.RegionChangeDestroy, Region: newTestRegion(1, "", "", 1)})
+	region, _ = c.get(1)
+	assert.NotNil(t, region)
+
+	// an epoch not match error brings the newer regions
+	c.onRegionError(&errorpb.Error{EpochNotMatch: &errorpb.EpochNotMatch{CurrentRegions: []*metapb.Region{
+		newTestRegion(1, "", "c", 3), newTestRegion(3, "c", "m", 3),
+	}}})
+	region, _ = c.locate([]byte("k"))
+	assert.Equal(t, uint64(3), region.Id)
+	c.onRegionError(&errorpb.Error{NotLeader: &errorpb.NotLeader{RegionId: 3, Leader: &metapb.Peer{Id: 31, StoreId: 2}}})
+	_, leader = c.locate([]byte("k"))
+	assert.Equal(t, uint64(31), leader.Id)
+
+	c.OnRegionChanged(&raftstore.RegionChangeEvent{Type: raftstore.RegionChangeDestroy, Region: newTestRegion(2, "m", "", 2)})
+	region, _ = c.locate([]byte("x"))
+	assert.Nil(t, region)
+}
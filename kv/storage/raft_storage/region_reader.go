@@ -2,14 +2,19 @@ package raft_storage
 
 import (
 	"github.com/Connor1996/badger"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/locktable"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/meta"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
 	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	rspb "github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
 )
 
 type RegionReader struct {
 	txn    *badger.Txn
 	region *metapb.Region
+	// the lock CF of the region when it's kept in memory, nil if it's in txn
+	locks *locktable.Snapshot
 }
 
 func NewRegionReader(txn *badger.Txn, region metapb.Region) *RegionReader {
@@ -23,6 +28,9 @@ func (r *RegionReader) GetCF(cf string, key []byte) ([]byte, error) {
 	if err := util.CheckKeyInRegion(key, r.region); err != nil {
 		return nil, err
 	}
+	if cf == engine_util.CfLock && r.locks != nil {
+		return r.locks.Get(key), nil
+	}
 	val, err := engine_util.GetCFFromTxn(r.txn, cf, key)
 	if err == badger.ErrKeyNotFound {
 		return nil, nil
@@ -31,9 +39,26 @@ func (r *RegionReader) GetCF(cf string, key []byte) ([]byte, error) {
 }
 
 func (r *RegionReader) IterCF(cf string) engine_util.DBIterator {
+	if cf == engine_util.CfLock && r.locks != nil {
+		return r.locks.NewIterator()
+	}
 	return NewRegionIterator(engine_util.NewCFIterator(cf, r.txn), r.region)
 }
 
+// Region returns the region the reader reads.
+func (r *RegionReader) Region() *metapb.Region {
+	return r.region
+}
+
+// ApplyIndex returns the applied index of the region at the time the reader was created.
+func (r *RegionReader) ApplyIndex() (uint64, error) {
+	applyState := new(rspb.RaftApplyState)
+	if err := engine_util.GetMetaFromTxn(r.txn, meta.ApplyStateKey(r.region.Id), applyState); err != nil {
+		return 0, err
+	}
+	return applyState.AppliedIndex, nil
+}
+
 func (r *RegionReader) Close() {
 	r.txn.Discard()
 }
@@ -0,0 +1,140 @@
+package raft_storage
+
+import (
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+)
+
+// regionWatchBufferSize is the number of events buffered for a watch, a watch which falls further behind is closed.
+const regionWatchBufferSize = 1024
+
+// RegionWatch receives the leader and epoch changes of a set of regions on the store. Its events channel is closed
+// when the watch falls behind the changes, then the client has to watch again to get the current state.
+type RegionWatch struct {
+	// the watched regions, nil watches all
+	regionIDs map[uint64]struct{}
+	events    chan *kvrpcpb.RegionEvent
+	closed    bool
+}
+
+// Events returns the events of the watch.
+func (w *RegionWatch) Events() <-chan *kvrpcpb.RegionEvent {
+	return w.events
+}
+
+func (w *RegionWatch) watches(regionID uint64) bool {
+	if w.regionIDs == nil {
+		return true
+	}
+	_, ok := w.regionIDs[regionID]
+	return ok
+}
+
+// regionWatches sends the region changes of the store to the watches.
+type regionWatches struct {
+	sync.Mutex
+	watches map[*RegionWatch]struct{}
+}
+
+func newRegionWatches() *regionWatches {
+	return &regionWatches{watches: make(map[*RegionWatch]struct{})}
+}
+
+func (ws *regionWatches) OnRegionChanged(event *raftstore.RegionChangeEvent) {
+	ev := &kvrpcpb.RegionEvent{Type: kvrpcpb.RegionEventType_EpochChange}
+	switch event.Type {
+	case raftstore.RegionChangeLeader:
+		ev.Type = kvrpcpb.RegionEventType_LeaderChange
+		ev.Leader = findPeerByID(event.Region, event.LeaderId)
+	case raftstore.RegionChangeDestroy:
+		ev.Type = kvrpcpb.RegionEventType_Removed
+	}
+	ev.Region = proto.Clone(event.Region).(*metapb.Region)
+	if ev.Leader != nil {
+		ev.Leader = proto.Clone(ev.Leader).(*metapb.Peer)
+	}
+	ws.send(ev)
+}
+
+func (ws *regionWatches) send(ev *kvrpcpb.RegionEvent) {
+	ws.Lock()
+	defer ws.Unlock()
+	for w := range ws.watches {
+		if w.watches(ev.Region.GetId()) {
+			ws.sendLocked(w, ev)
+		}
+	}
+}
+
+// sendTo sends the event to the watch only.
+func (ws *regionWatches) sendTo(w *RegionWatch, ev *kvrpcpb.RegionEvent) {
+	ws.Lock()
+	defer ws.Unlock()
+	if !w.closed {
+		ws.sendLocked(w, ev)
+	}
+}
+
+func (ws *regionWatches) sendLocked(w *RegionWatch, ev *kvrpcpb.RegionEvent) {
+	select {
+	case w.events <- ev:
+	default:
+		// The observer must not block the raftstore, drop the watch instead.
+		ws.closeLocked(w)
+	}
+}
+
+func (ws *regionWatches) add(w *RegionWatch) {
+	ws.Lock()
+	defer ws.Unlock()
+	ws.watches[w] = struct{}{}
+}
+
+func (ws *regionWatches) remove(w *RegionWatch) {
+	ws.Lock()
+	defer ws.Unlock()
+	ws.closeLocked(w)
+}
+
+func (ws *regionWatches) closeLocked(w *RegionWatch) {
+	if w.closed {
+		return
+	}
+	w.closed = true
+	close(w.events)
+	delete(ws.watches, w)
+}
+
+// WatchRegions starts watching the leader and epoch changes of the regions, all the regions on the store if
+// regionIDs is empty. The current state of each watched region in the routing table of the store is sent first.
+// The watch must be stopped by Unwatch.
+func (rs *RaftStorage) WatchRegions(regionIDs []uint64) *RegionWatch {
+	w := &RegionWatch{events: make(chan *kvrpcpb.RegionEvent, regionWatchBufferSize)}
+	if len(regionIDs) > 0 {
+		w.regionIDs = make(map[uint64]struct{}, len(regionIDs))
+		for _, id := range regionIDs {
+			w.regionIDs[id] = struct{}{}
+		}
+	}
+	rs.regionWatches.add(w)
+	for _, cached := range rs.regionCache.all() {
+		if !w.watches(cached.region.Id) {
+			continue
+		}
+		ev := &kvrpcpb.RegionEvent{Type: kvrpcpb.RegionEventType_EpochChange, Region: cached.region, Leader: cached.leader}
+		if cached.leader != nil {
+			ev.Type = kvrpcpb.RegionEventType_LeaderChange
+		}
+		rs.regionWatches.sendTo(w, ev)
+	}
+	return w
+}
+
+// Unwatch stops the watch and closes its events channel.
+func (rs *RaftStorage) Unwatch(w *RegionWatch) {
+	rs.regionWatches.remove(w)
+}
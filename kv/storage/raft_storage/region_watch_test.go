@@ -0,0 +1,56 @@
+package raft_storage
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/kv/raftstore"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegionWatch(t *testing.T) {
+	rs := &RaftStorage{regionCache: newRegionCache(), regionWatches: newRegionWatches()}
+	notify := func(event *raftstore.RegionChangeEvent) {
+		rs.regionCache.OnRegionChanged(event)
+		rs.regionWatches.OnRegionChanged(event)
+	}
+	notify(&raftstore.RegionChangeEvent{Type: raftstore.RegionChangeCreate, Region: newTestRegion(1, "", "b", 1)})
+	notify(&raftstore.RegionChangeEvent{Type: raftstore.RegionChangeCreate, Region: newTestRegion(2, "b", "", 1)})
+	notify(&raftstore.RegionChangeEvent{Type: raftstore.RegionChangeLeader, Region: newTestRegion(2, "b", "", 1), LeaderId: 21})
+
+	all := rs.WatchRegions(nil)
+	one := rs.WatchRegions([]uint64{2})
+	// the current state
+	ev := <-all.Events()
+	assert.Equal(t, kvrpcpb.RegionEventType_EpochChange, ev.Type)
+	assert.Equal(t, uint64(1), ev.Region.Id)
+	ev = <-all.Events()
+	assert.Equal(t, kvrpcpb.RegionEventType_LeaderChange, ev.Type)
+	assert.Equal(t, uint64(21), ev.Leader.Id)
+	ev = <-one.Events()
+	assert.Equal(t, uint64(2), ev.Region.Id)
+
+	notify(&raftstore.RegionChangeEvent{Type: raftstore.RegionChangeLeader, Region: newTestRegion(1, "", "b", 1), LeaderId: 10})
+	notify(&raftstore.RegionChangeEvent{Type: raftstore.RegionChangeSplit, Region: newTestRegion(2, "c", "", 2)})
+	ev = <-all.Events()
+	assert.Equal(t, kvrpcpb.RegionEventType_LeaderChange, ev.Type)
+	assert.Equal(t, uint64(10), ev.Leader.Id)
+	ev = <-all.Events()
+	assert.Equal(t, kvrpcpb.RegionEventType_EpochChange, ev.Type)
+	assert.Equal(t, uint64(2), ev.Region.RegionEpoch.Version)
+	ev = <-one.Events()
+	assert.Equal(t, []byte("c"), ev.Region.StartKey)
+	assert.Len(t, one.Events(), 0)
+
+	rs.Unwatch(one)
+	_, ok := <-one.Events()
+	assert.False(t, ok)
+
+	// a watch falling behind is closed
+	for i := 0; i <= regionWatchBufferSize; i++ {
+		notify(&raftstore.RegionChangeEvent{Type: raftstore.RegionChangeDestroy, Region: newTestRegion(3, "", "", 1)})
+	}
+	for range all.Events() {
+	}
+	rs.Unwatch(all)
+}
@@ -3,12 +3,15 @@ package raft_storage
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"hash/crc32"
 	"io"
 	"time"
 
 	"github.com/pingcap-incubator/tinykv/kv/config"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/message"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/snap"
+	"github.com/pingcap-incubator/tinykv/kv/util/failpoint"
 	"github.com/pingcap-incubator/tinykv/kv/util/worker"
 	"github.com/pingcap-incubator/tinykv/log"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
@@ -33,6 +36,8 @@ type snapRunner struct {
 	config      *config.Config
 	snapManager *snap.SnapManager
 	router      message.RaftRouter
+	// limits the snapshots sent by the store in total
+	sendLimiter *rateLimiter
 }
 
 func newSnapRunner(snapManager *snap.SnapManager, config *config.Config, router message.RaftRouter) *snapRunner {
@@ -40,6 +45,7 @@ func newSnapRunner(snapManager *snap.SnapManager, config *config.Config, router
 		config:      config,
 		snapManager: snapManager,
 		router:      router,
+		sendLimiter: newRateLimiter(config.SnapSendRateLimit),
 	}
 }
 
@@ -59,6 +65,9 @@ func (r *snapRunner) send(t *sendSnapTask) {
 const snapChunkLen = 1024 * 1024
 
 func (r *snapRunner) sendSnap(addr string, msg *raft_serverpb.RaftMessage) error {
+	if err := failpoint.Inject("snapshot/send"); err != nil {
+		return err
+	}
 	start := time.Now()
 	msgSnap := msg.GetMessage().GetSnapshot()
 	snapKey, err := snap.SnapKeyFromSnap(msgSnap)
@@ -76,6 +85,7 @@ func (r *snapRunner) sendSnap(addr string, msg *raft_serverpb.RaftMessage) error
 	if !snap.Exists() {
 		return errors.Errorf("missing snap file: %v", snap.Path())
 	}
+	r.snapManager.SetSnapSize(snapKey, snap.TotalSize())
 
 	cc, err := grpc.Dial(addr, grpc.WithInsecure(),
 		grpc.WithInitialWindowSize(2*1024*1024),
@@ -96,6 +106,10 @@ func (r *snapRunner) sendSnap(addr string, msg *raft_serverpb.RaftMessage) error
 		return err
 	}
 
+	// each chunk carries its CRC32, and the last one the SHA256 of the snapshot, so a corrupted snapshot is
+	// rejected by the receiver instead of being applied
+	digest := sha256.New()
+	streamLimiter := newRateLimiter(r.config.SnapSendStreamRateLimit)
 	buf := make([]byte, snapChunkLen)
 	for remain := snap.TotalSize(); remain > 0; remain -= uint64(len(buf)) {
 		if remain < uint64(len(buf)) {
@@ -105,10 +119,17 @@ func (r *snapRunner) sendSnap(addr string, msg *raft_serverpb.RaftMessage) error
 		if err != nil {
 			return errors.Errorf("failed to read snapshot chunk: %v", err)
 		}
-		err = stream.Send(&raft_serverpb.SnapshotChunk{Data: buf})
+		digest.Write(buf)
+		waitRateLimiters(len(buf), r.sendLimiter, streamLimiter)
+		err = stream.Send(&raft_serverpb.SnapshotChunk{Data: buf, Crc32: crc32.ChecksumIEEE(buf)})
 		if err != nil {
 			return err
 		}
+		r.snapManager.AddSentBytes(uint64(len(buf)))
+	}
+	err = stream.Send(&raft_serverpb.SnapshotChunk{Sha256: digest.Sum(nil)})
+	if err != nil {
+		return err
 	}
 	_, err = stream.CloseAndRecv()
 	if err != nil {
@@ -127,7 +148,10 @@ func (r *snapRunner) recv(t *recvSnapTask) {
 	t.callback(err)
 }
 
-func (r *snapRunner) recvSnap(stream tinykvpb.TinyKv_SnapshotServer) (*raft_serverpb.RaftMessage, error) {
+func (r *snapRunner) recvSnap(stream tinykvpb.TinyKv_SnapshotServer) (_ *raft_serverpb.RaftMessage, err error) {
+	if err := failpoint.Inject("snapshot/recv"); err != nil {
+		return nil, err
+	}
 	head, err := stream.Recv()
 	if err != nil {
 		return nil, err
@@ -153,7 +177,17 @@ func (r *snapRunner) recvSnap(stream tinykvpb.TinyKv_SnapshotServer) (*raft_serv
 	}
 	r.snapManager.Register(snapKey, snap.SnapEntryReceiving)
 	defer r.snapManager.Deregister(snapKey, snap.SnapEntryReceiving)
+	r.snapManager.SetSnapSize(snapKey, snapshot.TotalSize())
+	// the partially written files of a failed or corrupted snapshot are dropped, the sender reports the failure to
+	// the leader, which sends the snapshot again
+	defer func() {
+		if err != nil {
+			snapshot.Drop()
+		}
+	}()
 
+	digest := sha256.New()
+	var checksum []byte
 	for {
 		chunk, err := stream.Recv()
 		if err != nil {
@@ -162,14 +196,29 @@ func (r *snapRunner) recvSnap(stream tinykvpb.TinyKv_SnapshotServer) (*raft_serv
 			}
 			return nil, err
 		}
+		if checksum != nil {
+			return nil, errors.Errorf("%v receive chunk after the checksum", snapKey)
+		}
+		if len(chunk.GetSha256()) != 0 {
+			checksum = chunk.GetSha256()
+			continue
+		}
 		data := chunk.GetData()
 		if len(data) == 0 {
 			return nil, errors.Errorf("%v receive chunk with empty data", snapKey)
 		}
+		if crc := crc32.ChecksumIEEE(data); crc != chunk.GetCrc32() {
+			return nil, errors.Errorf("%v receive corrupted chunk, checksum %d, expected %d", snapKey, crc, chunk.GetCrc32())
+		}
+		digest.Write(data)
 		_, err = bytes.NewReader(data).WriteTo(snapshot)
 		if err != nil {
 			return nil, errors.Errorf("%v failed to write snapshot file %v: %v", snapKey, snapshot.Path(), err)
 		}
+		r.snapManager.AddReceivedBytes(uint64(len(data)))
+	}
+	if !bytes.Equal(digest.Sum(nil), checksum) {
+		return nil, errors.Errorf("%v receive corrupted snapshot, sha256 %x, expected %x", snapKey, digest.Sum(nil), checksum)
 	}
 
 	err = snapshot.Save()
@@ -6,6 +6,7 @@ import (
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/message"
 	"github.com/pingcap-incubator/tinykv/kv/util/worker"
 	"github.com/pingcap-incubator/tinykv/log"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
 )
 
@@ -81,6 +82,9 @@ func (t *ServerTransport) SendSnapshotSock(addr string, msg *raft_serverpb.RaftM
 		toPeerID := msg.GetToPeer().GetId()
 		toStoreID := msg.GetToPeer().GetStoreId()
 		log.Debugf("send snapshot. toPeerID: %v, toStoreID: %v, regionID: %v, status: %v", toPeerID, toStoreID, regionID, err)
+		if err != nil {
+			t.reportSnapshotFailure(msg)
+		}
 	}
 
 	t.snapScheduler <- &sendSnapTask{
@@ -90,6 +94,28 @@ func (t *ServerTransport) SendSnapshotSock(addr string, msg *raft_serverpb.RaftM
 	}
 }
 
+// reportSnapshotFailure tells the leader which sent the snapshot that it can't be sent, so the leader retries
+// with a fresh snapshot.
+func (t *ServerTransport) reportSnapshotFailure(msg *raft_serverpb.RaftMessage) {
+	status := &raft_serverpb.RaftMessage{
+		RegionId:    msg.RegionId,
+		FromPeer:    msg.ToPeer,
+		ToPeer:      msg.FromPeer,
+		RegionEpoch: msg.RegionEpoch,
+		Message: &eraftpb.Message{
+			MsgType: eraftpb.MessageType_MsgSnapStatus,
+			From:    msg.ToPeer.GetId(),
+			To:      msg.FromPeer.GetId(),
+			Term:    msg.Message.GetTerm(),
+			Index:   msg.Message.GetSnapshot().GetMetadata().GetIndex(),
+			Reject:  true,
+		},
+	}
+	if err := t.raftRouter.SendRaftMessage(status); err != nil {
+		log.Errorf("report snapshot failure err. err: %v", err)
+	}
+}
+
 func (t *ServerTransport) Flush() {
 	t.raftClient.Flush()
 }
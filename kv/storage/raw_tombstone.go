@@ -0,0 +1,181 @@
+package storage
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/log"
+)
+
+// In the soft delete mode of the raw API, a raw delete moves the value of the key to a tombstone in the raw
+// tombstone CF, which RawUndelete restores it from until the tombstone expires. The tombstone is kept under the key
+// itself, so it stays in the region of the key, and it holds an entry for each CF the key was deleted from. The
+// tombstones whose entries all expired are removed in the background. A raw write of a key holds the latch of the
+// key from reading it to writing it, as does the removal of its tombstone, so none of them overwrites another.
+
+// rawTombstoneEntry is the value of a key deleted from a CF, kept until the expire time in unix ms.
+type rawTombstoneEntry struct {
+	cf     string
+	expire uint64
+	value  []byte
+}
+
+func encodeRawTombstone(entries []rawTombstoneEntry) []byte {
+	var buf []byte
+	var n [binary.MaxVarintLen64]byte
+	for _, e := range entries {
+		buf = append(buf, n[:binary.PutUvarint(n[:], uint64(len(e.cf)))]...)
+		buf = append(buf, e.cf...)
+		buf = append(buf, n[:8]...)
+		binary.BigEndian.PutUint64(buf[len(buf)-8:], e.expire)
+		buf = append(buf, n[:binary.PutUvarint(n[:], uint64(len(e.value)))]...)
+		buf = append(buf, e.value...)
+	}
+	return buf
+}
+
+func decodeRawTombstone(buf []byte) ([]rawTombstoneEntry, error) {
+	var entries []rawTombstoneEntry
+	readBytes := func() ([]byte, bool) {
+		l, n := binary.Uvarint(buf)
+		if n <= 0 || uint64(len(buf)-n) < l {
+			return nil, false
+		}
+		b := buf[n : n+int(l)]
+		buf = buf[n+int(l):]
+		return b, true
+	}
+	for len(buf) > 0 {
+		cf, ok := readBytes()
+		if !ok || len(buf) < 8 {
+			return nil, fmt.Errorf("storage: error parsing raw tombstone, truncated entry")
+		}
+		expire := binary.BigEndian.Uint64(buf)
+		buf = buf[8:]
+		value, ok := readBytes()
+		if !ok {
+			return nil, fmt.Errorf("storage: error parsing raw tombstone, truncated entry")
+		}
+		entries = append(entries, rawTombstoneEntry{cf: string(cf), expire: expire, value: value})
+	}
+	return entries, nil
+}
+
+func readRawTombstone(reader StorageReader, key []byte) ([]rawTombstoneEntry, error) {
+	value, err := reader.GetCF(engine_util.CfRawTombstone, key)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	return decodeRawTombstone(value)
+}
+
+func unixMs(t time.Time) uint64 {
+	return uint64(t.UnixNano() / int64(time.Millisecond))
+}
+
+// RawSoftDelete returns the modifies which delete the key from the CF and keep its value in a tombstone until
+// expire. A tombstone of the key deleted from the CF before is replaced. There is nothing to modify if the key
+// doesn't exist.
+func RawSoftDelete(reader StorageReader, cf string, key []byte, expire time.Time) ([]Modify, error) {
+	value, err := reader.GetCF(cf, key)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	entries, err := readRawTombstone(reader, key)
+	if err != nil {
+		return nil, err
+	}
+	kept := entries[:0]
+	for _, e := range entries {
+		if e.cf != cf {
+			kept = append(kept, e)
+		}
+	}
+	kept = append(kept, rawTombstoneEntry{cf: cf, expire: unixMs(expire), value: value})
+	return []Modify{
+		{Data: Delete{Key: key, Cf: cf}},
+		{Data: Put{Key: key, Value: encodeRawTombstone(kept), Cf: engine_util.CfRawTombstone}},
+	}, nil
+}
+
+// RawUndelete returns the modifies which restore the value of the key deleted from the CF from its tombstone, and
+// remove the tombstone. It returns nil modifies if there is no tombstone to restore from, i.e. the key wasn't soft
+// deleted from the CF or its tombstone expired before now. It's an error if the key was put again since.
+func RawUndelete(reader StorageReader, cf string, key []byte, now time.Time) ([]Modify, error) {
+	entries, err := readRawTombstone(reader, key)
+	if err != nil {
+		return nil, err
+	}
+	var restored *rawTombstoneEntry
+	kept := make([]rawTombstoneEntry, 0, len(entries))
+	for i, e := range entries {
+		if e.cf == cf {
+			restored = &entries[i]
+		} else {
+			kept = append(kept, e)
+		}
+	}
+	if restored == nil || restored.expire <= unixMs(now) {
+		return nil, nil
+	}
+	value, err := reader.GetCF(cf, key)
+	if err != nil {
+		return nil, err
+	}
+	if value != nil {
+		return nil, fmt.Errorf("key %q of cf %s was put again after it was deleted", key, cf)
+	}
+	modifies := []Modify{{Data: Put{Key: key, Value: restored.value, Cf: cf}}}
+	if len(kept) == 0 {
+		modifies = append(modifies, Modify{Data: Delete{Key: key, Cf: engine_util.CfRawTombstone}})
+	} else {
+		modifies = append(modifies, Modify{Data: Put{Key: key, Value: encodeRawTombstone(kept), Cf: engine_util.CfRawTombstone}})
+	}
+	return modifies, nil
+}
+
+// StaleRawTombstones returns the keys in [startKey, endKey) of the raw tombstone CF iter iterates, whose tombstone
+// entries all expired before safeTime in unix ms. At most limit keys are returned, along with the key to continue
+// from, which is nil if the range is done.
+func StaleRawTombstones(iter engine_util.DBIterator, startKey, endKey []byte, safeTime uint64, limit int) ([][]byte, []byte, error) {
+	var keys [][]byte
+	for iter.Seek(startKey); iter.Valid(); iter.Next() {
+		item := iter.Item()
+		if engine_util.ExceedEndKey(item.Key(), endKey) {
+			break
+		}
+		if len(keys) >= limit {
+			return keys, item.KeyCopy(nil), nil
+		}
+		value, err := item.Value()
+		if err != nil {
+			return nil, nil, err
+		}
+		stale, err := IsStaleRawTombstone(value, safeTime)
+		if err != nil {
+			// a tombstone which can't be parsed is kept for inspection rather than failing the rest of the range
+			log.Warnf("skip raw tombstone of key %q: %v", item.Key(), err)
+			continue
+		}
+		if stale {
+			keys = append(keys, item.KeyCopy(nil))
+		}
+	}
+	return keys, nil, nil
+}
+
+// IsStaleRawTombstone returns true if the entries of the raw tombstone all expired before safeTime in unix ms.
+func IsStaleRawTombstone(value []byte, safeTime uint64) (bool, error) {
+	entries, err := decodeRawTombstone(value)
+	if err != nil {
+		return false, err
+	}
+	for _, e := range entries {
+		if e.expire >= safeTime {
+			return false, nil
+		}
+	}
+	return true, nil
+}
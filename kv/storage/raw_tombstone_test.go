@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRawTombstone(t *testing.T) {
+	mem := NewMemStorage()
+	mem.Set(engine_util.CfDefault, []byte("a"), []byte("1"))
+	mem.Set(engine_util.CfWrite, []byte("a"), []byte("2"))
+	mem.Set(engine_util.CfDefault, []byte("b"), []byte("3"))
+	now := time.Unix(100, 0)
+	softDelete := func(cf, key string, expire time.Time) {
+		reader, _ := mem.Reader(nil)
+		modifies, err := RawSoftDelete(reader, cf, []byte(key), expire)
+		assert.Nil(t, err)
+		assert.Nil(t, mem.Write(nil, modifies))
+	}
+	softDelete(engine_util.CfDefault, "a", now.Add(time.Minute))
+	softDelete(engine_util.CfWrite, "a", now.Add(time.Second))
+	softDelete(engine_util.CfDefault, "b", now.Add(time.Second))
+	softDelete(engine_util.CfDefault, "c", now.Add(time.Second))
+	assert.Nil(t, mem.Get(engine_util.CfDefault, []byte("a")))
+	assert.Nil(t, mem.Get(engine_util.CfRawTombstone, []byte("c")))
+	assert.Equal(t, 2, mem.Len(engine_util.CfRawTombstone))
+
+	// only the tombstones whose entries all expired are stale
+	reader, _ := mem.Reader(nil)
+	iter := reader.IterCF(engine_util.CfRawTombstone)
+	keys, next, err := StaleRawTombstones(iter, nil, nil, unixMs(now.Add(2*time.Second)), 10)
+	iter.Close()
+	assert.Nil(t, err)
+	assert.Nil(t, next)
+	assert.Equal(t, [][]byte{[]byte("b")}, keys)
+	iter = reader.IterCF(engine_util.CfRawTombstone)
+	keys, next, err = StaleRawTombstones(iter, nil, nil, unixMs(now.Add(2*time.Minute)), 1)
+	iter.Close()
+	assert.Nil(t, err)
+	assert.Equal(t, [][]byte{[]byte("a")}, keys)
+	assert.Equal(t, []byte("b"), next)
+	reader.Close()
+
+	undelete := func(cf, key string, now time.Time) ([]Modify, error) {
+		reader, _ := mem.Reader(nil)
+		defer reader.Close()
+		modifies, err := RawUndelete(reader, cf, []byte(key), now)
+		if err == nil {
+			assert.Nil(t, mem.Write(nil, modifies))
+		}
+		return modifies, err
+	}
+	// expired
+	modifies, err := undelete(engine_util.CfWrite, "a", now.Add(time.Second))
+	assert.Nil(t, err)
+	assert.Nil(t, modifies)
+	modifies, err = undelete(engine_util.CfDefault, "a", now)
+	assert.Nil(t, err)
+	assert.NotNil(t, modifies)
+	assert.Equal(t, []byte("1"), mem.Get(engine_util.CfDefault, []byte("a")))
+	// the entry of the write CF is kept
+	assert.NotNil(t, mem.Get(engine_util.CfRawTombstone, []byte("a")))
+	modifies, err = undelete(engine_util.CfWrite, "a", now)
+	assert.Nil(t, err)
+	assert.NotNil(t, modifies)
+	assert.Equal(t, []byte("2"), mem.Get(engine_util.CfWrite, []byte("a")))
+	assert.Nil(t, mem.Get(engine_util.CfRawTombstone, []byte("a")))
+
+	// put again after the delete
+	mem.Set(engine_util.CfDefault, []byte("b"), []byte("4"))
+	_, err = undelete(engine_util.CfDefault, "b", now)
+	assert.NotNil(t, err)
+}
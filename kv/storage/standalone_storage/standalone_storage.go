@@ -36,3 +36,7 @@ func (s *StandAloneStorage) Write(ctx *kvrpcpb.Context, batch []storage.Modify)
 	// Your Code Here (1).
 	return nil
 }
+
+func (s *StandAloneStorage) BeginBatch() storage.Batch {
+	return storage.NewBatch(s)
+}
@@ -11,6 +11,8 @@ type Storage interface {
 	Start() error
 	Stop() error
 	Write(ctx *kvrpcpb.Context, batch []Modify) error
+	// BeginBatch starts a batch of the writes of several commands, see Batch.
+	BeginBatch() Batch
 	Reader(ctx *kvrpcpb.Context) (StorageReader, error)
 }
 
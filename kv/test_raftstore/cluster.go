@@ -38,6 +38,8 @@ type Cluster struct {
 	dirs            []string
 	simulator       Simulator
 	cfg             *config.Config
+	// the region imported into the first region by Start, see PreloadRegion
+	preloaded []byte
 }
 
 func NewCluster(count int, schedulerClient *MockSchedulerClient, simulator Simulator, cfg *config.Config) *Cluster {
@@ -80,7 +82,7 @@ func (c *Cluster) Start() {
 			panic(err)
 		}
 
-		raftDB := engine_util.CreateDB(raftPath, true)
+		raftDB := engine_util.CreateDBWithSync(raftPath, true, c.cfg.RaftSyncLog == config.SyncLogAlways)
 		kvDB := engine_util.CreateDB(kvPath, false)
 		engine := engine_util.NewEngines(kvDB, raftDB, kvPath, raftPath)
 		c.engines[storeID] = engine
@@ -108,6 +110,11 @@ func (c *Cluster) Start() {
 
 	for _, engine := range c.engines {
 		raftstore.PrepareBootstrapCluster(engine, firstRegion)
+		if c.preloaded != nil {
+			if err := raftstore.ImportRegion(engine, firstRegion, c.preloaded); err != nil {
+				panic(err)
+			}
+		}
 	}
 
 	store := &metapb.Store{
@@ -139,6 +146,21 @@ func (c *Cluster) Start() {
 	}
 }
 
+// PreloadRegion makes Start import the region exported by ExportRegion into the first region, so the cluster starts
+// with the data of the region instead of replaying the writes. It must be called before Start.
+func (c *Cluster) PreloadRegion(blob []byte) {
+	c.preloaded = blob
+}
+
+// ExportRegion exports the state and the data of the region on the store, see raftstore.ExportRegion.
+func (c *Cluster) ExportRegion(storeID, regionID uint64) []byte {
+	blob, err := raftstore.ExportRegion(c.engines[storeID], regionID)
+	if err != nil {
+		panic(err)
+	}
+	return blob
+}
+
 func (c *Cluster) Shutdown() {
 	for _, storeID := range c.simulator.GetStoreIds() {
 		c.simulator.StopStore(storeID)
@@ -430,6 +452,21 @@ func (c *Cluster) MustRemovePeer(regionID uint64, peer *metapb.Peer) {
 	c.MustNonePeer(regionID, peer)
 }
 
+func (c *Cluster) MustMergeRegion(sourceID, targetID uint64) {
+	c.schedulerClient.MergeRegion(sourceID, targetID)
+	for i := 0; i < 500; i++ {
+		region, _, err := c.schedulerClient.GetRegionByID(context.TODO(), sourceID)
+		if err != nil {
+			panic(err)
+		}
+		if region == nil || region.GetId() != sourceID {
+			return
+		}
+		SleepMS(10)
+	}
+	panic(fmt.Sprintf("region %d isn't merged into region %d", sourceID, targetID))
+}
+
 func (c *Cluster) MustHavePeer(regionID uint64, peer *metapb.Peer) {
 	for i := 0; i < 500; i++ {
 		region, _, err := c.schedulerClient.GetRegionByID(context.TODO(), regionID)
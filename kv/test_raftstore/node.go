@@ -149,7 +149,7 @@ func (c *NodeSimulator) RunStore(cfg *config.Config, engine *engine_util.Engines
 	defer c.Unlock()
 
 	raftRouter, raftSystem := raftstore.CreateRaftstore(cfg)
-	snapManager := snap.NewSnapManager(cfg.DBPath + "/snap")
+	snapManager := snap.NewSnapManager(cfg.SnapDir())
 	node := raftstore.NewNode(raftSystem, cfg, c.schedulerClient)
 
 	err := node.Start(ctx, engine, c.trans, snapManager)
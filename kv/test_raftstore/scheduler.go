@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"sync"
+	"time"
 
 	"github.com/google/btree"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
@@ -39,6 +40,7 @@ const (
 	OperatorTypeAddPeer        = 1
 	OperatorTypeRemovePeer     = 2
 	OperatorTypeTransferLeader = 3
+	OperatorTypeMerge          = 4
 )
 
 type Operator struct {
@@ -59,6 +61,10 @@ type OpTransferLeader struct {
 	peer *metapb.Peer
 }
 
+type OpMerge struct {
+	targetID uint64
+}
+
 type Store struct {
 	store                    metapb.Store
 	heartbeatResponseHandler func(*schedulerpb.RegionHeartbeatResponse)
@@ -239,12 +245,14 @@ func (m *MockSchedulerClient) AskSplit(ctx context.Context, region *metapb.Regio
 	return resp, nil
 }
 
-func (m *MockSchedulerClient) StoreHeartbeat(ctx context.Context, stats *schedulerpb.StoreStats) error {
+func (m *MockSchedulerClient) StoreHeartbeat(ctx context.Context, stats *schedulerpb.StoreStats) (*schedulerpb.StoreHeartbeatResponse, error) {
 	if err := m.checkBootstrap(); err != nil {
-		return err
+		return nil, err
 	}
-	// nothing need to do
-	return nil
+	return &schedulerpb.StoreHeartbeatResponse{
+		Header:        &schedulerpb.ResponseHeader{ClusterId: m.clusterID},
+		SchedulerTime: time.Now().UnixNano(),
+	}, nil
 }
 
 func (m *MockSchedulerClient) RegionHeartbeat(req *schedulerpb.RegionHeartbeatRequest) error {
@@ -413,6 +421,9 @@ func (m *MockSchedulerClient) tryFinished(op *Operator, region *metapb.Region, l
 	case OperatorTypeTransferLeader:
 		transfer := op.Data.(*OpTransferLeader)
 		return leader.GetId() == transfer.peer.GetId()
+	case OperatorTypeMerge:
+		// The merged region doesn't report any more.
+		return false
 	}
 	panic("unreachable")
 }
@@ -438,6 +449,13 @@ func (m *MockSchedulerClient) makeRegionHeartbeatResponse(op *Operator, resp *sc
 		resp.TransferLeader = &schedulerpb.TransferLeader{
 			Peer: transfer.peer,
 		}
+	case OperatorTypeMerge:
+		merge := op.Data.(*OpMerge)
+		if target, _, _ := m.getRegionByIDLocked(merge.targetID); target != nil && target.GetId() == merge.targetID {
+			resp.Merge = &schedulerpb.Merge{
+				Target: target,
+			}
+		}
 	}
 }
 
@@ -514,6 +532,15 @@ func (m *MockSchedulerClient) TransferLeader(regionID uint64, peer *metapb.Peer)
 	})
 }
 
+func (m *MockSchedulerClient) MergeRegion(sourceID, targetID uint64) {
+	m.scheduleOperator(sourceID, &Operator{
+		Type: OperatorTypeMerge,
+		Data: &OpMerge{
+			targetID: targetID,
+		},
+	})
+}
+
 func (m *MockSchedulerClient) getRandomRegion() *metapb.Region {
 	m.RLock()
 	defer m.RUnlock()
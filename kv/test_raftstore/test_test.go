@@ -685,6 +685,45 @@ func TestOneSplit3B(t *testing.T) {
 	MustGetEqual(cluster.engines[5], []byte("k100"), []byte("v100"))
 }
 
+func TestOneMerge3B(t *testing.T) {
+	cfg := config.NewTestConfig()
+	cfg.RegionMaxSize = 800
+	cfg.RegionSplitSize = 500
+	cluster := NewTestCluster(3, cfg)
+	cluster.Start()
+	defer cluster.Shutdown()
+
+	// write some data to trigger split
+	for i := 100; i < 200; i++ {
+		cluster.MustPut([]byte(fmt.Sprintf("k%d", i)), []byte(fmt.Sprintf("v%d", i)))
+	}
+	time.Sleep(200 * time.Millisecond)
+
+	left := cluster.GetRegion([]byte("k100"))
+	right := cluster.GetRegion(left.GetEndKey())
+	assert.NotEqual(t, left.GetId(), right.GetId())
+
+	cluster.MustMergeRegion(left.GetId(), right.GetId())
+
+	// the merged region serves the range of the source on every store
+	merged := cluster.GetRegion([]byte("k100"))
+	assert.Equal(t, right.GetId(), merged.GetId())
+	for i := 100; i < 200; i++ {
+		cluster.MustGet([]byte(fmt.Sprintf("k%d", i)), []byte(fmt.Sprintf("v%d", i)))
+	}
+	for id := range cluster.engines {
+		MustGetEqual(cluster.engines[id], []byte("k100"), []byte("v100"))
+	}
+
+	// the source peers are destroyed
+	for _, peer := range left.GetPeers() {
+		req := NewRequest(left.GetId(), left.GetRegionEpoch(), []*raft_cmdpb.Request{NewGetCfCmd(engine_util.CfDefault, []byte("k100"))})
+		req.Header.Peer = peer
+		resp, _ := cluster.CallCommand(&req, time.Second)
+		assert.True(t, resp == nil || resp.GetHeader().GetError() != nil)
+	}
+}
+
 func TestSplitRecover3B(t *testing.T) {
 	// Test: restarts, snapshots, conf change, one client (3B) ...
 	GenericTest(t, "3B", 1, false, true, false, -1, false, true)
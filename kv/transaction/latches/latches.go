@@ -17,7 +17,8 @@ import (
 //
 // Latching is implemented using a single map which maps keys to a Go WaitGroup. Access to this map is guarded by a mutex
 // to ensure that latching is atomic and consistent. Since the mutex is a global lock, it would cause intolerable contention
-// in a real system.
+// in a real system. GroupWaitForLatches eases the contention by letting one thread acquire the latches of all queued
+// commands in one critical section.
 
 type Latches struct {
 	// Before modifying any property of a key, the thread must have the latch for that key. `Latches` maps each latched
@@ -27,6 +28,18 @@ type Latches struct {
 	latchGuard sync.Mutex
 	// An optional validation function, only used for testing.
 	Validation func(txn *mvcc.MvccTxn, keys [][]byte)
+
+	// Commands queued in GroupWaitForLatches, waiting for a thread to acquire their latches.
+	pending []*latchWaiter
+	// Mutex to guard pending.
+	pendingGuard sync.Mutex
+}
+
+// latchWaiter is a command queued in GroupWaitForLatches. The result of acquiring its latches is sent on result, see
+// AcquireLatches.
+type latchWaiter struct {
+	keys   [][]byte
+	result chan *sync.WaitGroup
 }
 
 // NewLatches creates a new Latches object for managing a databases latches. There should only be one such object, shared
@@ -43,6 +56,25 @@ func (l *Latches) AcquireLatches(keysToLatch [][]byte) *sync.WaitGroup {
 	l.latchGuard.Lock()
 	defer l.latchGuard.Unlock()
 
+	return l.acquireLatches(keysToLatch)
+}
+
+// AcquireLatchesBatch is like AcquireLatches for several commands, but takes latchGuard only once. The latches of the
+// commands are acquired in order, a command whose keys are disjoint from the keys latched so far, including those of
+// the earlier commands in the batch, gets a nil WaitGroup and may run concurrently with the others.
+func (l *Latches) AcquireLatchesBatch(keySets [][][]byte) []*sync.WaitGroup {
+	l.latchGuard.Lock()
+	defer l.latchGuard.Unlock()
+
+	wgs := make([]*sync.WaitGroup, len(keySets))
+	for i, keys := range keySets {
+		wgs[i] = l.acquireLatches(keys)
+	}
+	return wgs
+}
+
+// acquireLatches implements AcquireLatches, latchGuard must be held.
+func (l *Latches) acquireLatches(keysToLatch [][]byte) *sync.WaitGroup {
 	// Check none of the keys we want to write are locked.
 	for _, key := range keysToLatch {
 		if latchWg, ok := l.latchMap[string(key)]; ok {
@@ -91,6 +123,45 @@ func (l *Latches) WaitForLatches(keysToLatch [][]byte) {
 	}
 }
 
+// GroupWaitForLatches is like WaitForLatches, but the commands waiting concurrently are queued and their latches are
+// acquired together by one of the threads using AcquireLatchesBatch, so each command does not take latchGuard on its
+// own.
+func (l *Latches) GroupWaitForLatches(keysToLatch [][]byte) {
+	waiter := &latchWaiter{keys: keysToLatch, result: make(chan *sync.WaitGroup, 1)}
+	for {
+		l.pendingGuard.Lock()
+		l.pending = append(l.pending, waiter)
+		// The first thread to queue a command acquires the latches of the commands queued until it takes the queue.
+		leader := len(l.pending) == 1
+		l.pendingGuard.Unlock()
+		if leader {
+			l.acquirePending()
+		}
+
+		wg := <-waiter.result
+		if wg == nil {
+			return
+		}
+		wg.Wait()
+	}
+}
+
+// acquirePending acquires the latches of the queued commands and sends the results to their threads.
+func (l *Latches) acquirePending() {
+	l.pendingGuard.Lock()
+	waiters := l.pending
+	l.pending = nil
+	l.pendingGuard.Unlock()
+
+	keySets := make([][][]byte, len(waiters))
+	for i, waiter := range waiters {
+		keySets[i] = waiter.keys
+	}
+	for i, wg := range l.AcquireLatchesBatch(keySets) {
+		waiters[i].result <- wg
+	}
+}
+
 // Validate calls the function in Validation, if it exists.
 func (l *Latches) Validate(txn *mvcc.MvccTxn, latched [][]byte) {
 	if l.Validation != nil {
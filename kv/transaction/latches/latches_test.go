@@ -28,3 +28,50 @@ func TestAcquireLatches(t *testing.T) {
 	wg = l.AcquireLatches([][]byte{{3, 0, 42}})
 	assert.NotNil(t, wg)
 }
+
+func TestAcquireLatchesBatch(t *testing.T) {
+	l := Latches{
+		latchMap: make(map[string]*sync.WaitGroup),
+	}
+	held := l.AcquireLatches([][]byte{{1}})
+	assert.Nil(t, held)
+
+	wgs := l.AcquireLatchesBatch([][][]byte{{{2}, {3}}, {{1}}, {{3}, {4}}, {{5}}})
+	assert.Equal(t, 4, len(wgs))
+	assert.Nil(t, wgs[0])
+	// Conflicts with a latch held before the batch.
+	assert.NotNil(t, wgs[1])
+	// Conflicts with an earlier command in the batch.
+	assert.Equal(t, l.latchMap[string([]byte{2})], wgs[2])
+	assert.Nil(t, wgs[3])
+}
+
+func TestGroupWaitForLatches(t *testing.T) {
+	l := NewLatches()
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	holders := make(map[string]int)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		keys := [][]byte{{byte(i % 5)}, {byte(i%5 + 1)}}
+		go func() {
+			defer wg.Done()
+			l.GroupWaitForLatches(keys)
+			mu.Lock()
+			for _, key := range keys {
+				holders[string(key)]++
+				assert.Equal(t, 1, holders[string(key)])
+			}
+			mu.Unlock()
+
+			mu.Lock()
+			for _, key := range keys {
+				holders[string(key)]--
+			}
+			mu.Unlock()
+			l.ReleaseLatches(keys)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 0, len(l.latchMap))
+}
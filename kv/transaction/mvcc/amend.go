@@ -0,0 +1,35 @@
+package mvcc
+
+import (
+	"fmt"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+)
+
+// Amend records that a prewrite with try_amend set found a write committed at conflictTs, newer than the
+// transaction's start timestamp. Rather than failing the prewrite, the lock remembers the newest such conflict and
+// the transaction may only commit at a later timestamp, after the client has validated it against that write.
+// It returns the conflict to report in the prewrite response.
+func (lock *Lock) Amend(key []byte, conflictTs uint64) *kvrpcpb.WriteConflict {
+	if conflictTs > lock.MinCommitTs {
+		lock.MinCommitTs = conflictTs
+	}
+	return &kvrpcpb.WriteConflict{
+		StartTs:    lock.Ts,
+		ConflictTs: conflictTs,
+		Key:        key,
+		Primary:    lock.Primary,
+	}
+}
+
+// CheckAmendedCommit checks that a lock may be committed at commitTs. A lock which amended a write conflict can
+// only be committed after the conflicting write, otherwise the commit would be ordered before a write the
+// transaction did not see. It returns nil if the commit may proceed.
+func (lock *Lock) CheckAmendedCommit(commitTs uint64) *kvrpcpb.KeyError {
+	if lock.MinCommitTs == 0 || commitTs > lock.MinCommitTs {
+		return nil
+	}
+	return &kvrpcpb.KeyError{
+		Abort: fmt.Sprintf("commit version %d is not greater than amended conflict version %d", commitTs, lock.MinCommitTs),
+	}
+}
@@ -0,0 +1,42 @@
+package mvcc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAmendedLockEncoding(t *testing.T) {
+	lock := &Lock{Primary: []byte{1, 2, 3}, Ts: 100, Ttl: 10, Kind: WriteKindPut}
+	parsed, err := ParseLock(lock.ToBytes())
+	assert.Nil(t, err)
+	assert.Equal(t, lock, parsed)
+
+	conflict := lock.Amend([]byte{4}, 120)
+	assert.Equal(t, uint64(100), conflict.StartTs)
+	assert.Equal(t, uint64(120), conflict.ConflictTs)
+	// an older conflict does not lower the bound
+	lock.Amend([]byte{5}, 110)
+	assert.Equal(t, uint64(120), lock.MinCommitTs)
+
+	parsed, err = ParseLock(lock.ToBytes())
+	assert.Nil(t, err)
+	assert.Equal(t, lock, parsed)
+
+	// an empty primary still round trips
+	lock = &Lock{Ts: 100, Kind: WriteKindDelete, MinCommitTs: 101}
+	parsed, err = ParseLock(lock.ToBytes())
+	assert.Nil(t, err)
+	assert.Equal(t, lock.MinCommitTs, parsed.MinCommitTs)
+	assert.Equal(t, WriteKindDelete, parsed.Kind)
+}
+
+func TestCheckAmendedCommit(t *testing.T) {
+	lock := &Lock{Primary: []byte{1}, Ts: 100, Kind: WriteKindPut}
+	assert.Nil(t, lock.CheckAmendedCommit(101))
+
+	lock.Amend([]byte{1}, 120)
+	assert.NotNil(t, lock.CheckAmendedCommit(110))
+	assert.NotNil(t, lock.CheckAmendedCommit(120))
+	assert.Nil(t, lock.CheckAmendedCommit(121))
+}
@@ -0,0 +1,114 @@
+package mvcc
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/kv/storage"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+)
+
+// The audit CF keeps a record for every prewrite, commit and rollback on the audited keys. A record is keyed by
+// the user key and the timestamp of the write, so the records of a key are sorted from the newest to the oldest.
+
+// AuditKey encodes the key of an audit record. A rollback is written at the start timestamp of the transaction,
+// so a suffix is appended to sort it before the prewrite of the same transaction.
+func AuditKey(key []byte, ts uint64, committed bool) []byte {
+	if committed {
+		return append(EncodeKey(key, ts), 0)
+	}
+	return append(EncodeKey(key, ts), 1)
+}
+
+// AuditRecords builds the audit records of the transactional writes in batch whose keys have any of prefixes.
+// Prewrites are found by the puts to the lock CF, commits and rollbacks by the puts to the write CF.
+func AuditRecords(ctx *kvrpcpb.Context, batch []storage.Modify, prefixes [][]byte) []storage.Modify {
+	var records []storage.Modify
+	now := time.Now().UnixNano()
+	for _, m := range batch {
+		put, ok := m.Data.(storage.Put)
+		if !ok {
+			continue
+		}
+		record := &kvrpcpb.AuditRecord{
+			Time:    now,
+			Client:  ctx.GetClient(),
+			StoreId: ctx.GetPeer().GetStoreId(),
+		}
+		switch put.Cf {
+		case engine_util.CfLock:
+			lock, err := ParseLock(put.Value)
+			if err != nil {
+				continue
+			}
+			record.Key = put.Key
+			record.Op = lock.Kind.ToProto()
+			record.StartTs = lock.Ts
+		case engine_util.CfWrite:
+			write, err := ParseWrite(put.Value)
+			if err != nil || write == nil {
+				continue
+			}
+			record.Key = DecodeUserKey(put.Key)
+			record.Op = write.Kind.ToProto()
+			record.StartTs = write.StartTS
+			record.CommitTs = decodeTimestamp(put.Key)
+		default:
+			continue
+		}
+		if !hasAnyPrefix(record.Key, prefixes) {
+			continue
+		}
+		value, err := record.Marshal()
+		if err != nil {
+			continue
+		}
+		ts := record.CommitTs
+		if ts == 0 {
+			ts = record.StartTs
+		}
+		records = append(records, storage.Modify{Data: storage.Put{
+			Key:   AuditKey(record.Key, ts, record.CommitTs != 0),
+			Value: value,
+			Cf:    engine_util.CfAudit,
+		}})
+	}
+	return records
+}
+
+// ScanAuditRecords reads at most limit audit records of key, from the newest to the oldest. A zero limit means no
+// limit.
+func ScanAuditRecords(reader storage.StorageReader, key []byte, limit uint32) ([]*kvrpcpb.AuditRecord, error) {
+	iter := reader.IterCF(engine_util.CfAudit)
+	defer iter.Close()
+	var records []*kvrpcpb.AuditRecord
+	for iter.Seek(EncodeKey(key, TsMax)); iter.Valid(); iter.Next() {
+		item := iter.Item()
+		if !bytes.Equal(DecodeUserKey(item.Key()), key) {
+			break
+		}
+		value, err := item.Value()
+		if err != nil {
+			return nil, err
+		}
+		record := new(kvrpcpb.AuditRecord)
+		if err := record.Unmarshal(value); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+		if limit != 0 && uint32(len(records)) >= limit {
+			break
+		}
+	}
+	return records, nil
+}
+
+func hasAnyPrefix(key []byte, prefixes [][]byte) bool {
+	for _, prefix := range prefixes {
+		if bytes.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package mvcc
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/kv/storage"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditRecords(t *testing.T) {
+	ctx := &kvrpcpb.Context{Client: "c1"}
+	lock := &Lock{Primary: []byte{1}, Ts: 100, Ttl: 10, Kind: WriteKindPut}
+	write := &Write{StartTS: 100, Kind: WriteKindPut}
+	batch := []storage.Modify{
+		{Data: storage.Put{Cf: engine_util.CfDefault, Key: EncodeKey([]byte{1}, 100), Value: []byte{42}}},
+		{Data: storage.Put{Cf: engine_util.CfLock, Key: []byte{1}, Value: lock.ToBytes()}},
+		{Data: storage.Put{Cf: engine_util.CfWrite, Key: EncodeKey([]byte{1}, 110), Value: write.ToBytes()}},
+		{Data: storage.Put{Cf: engine_util.CfLock, Key: []byte{2}, Value: lock.ToBytes()}},
+	}
+
+	records := AuditRecords(ctx, batch, [][]byte{{1}})
+	assert.Equal(t, 2, len(records))
+	prewrite := new(kvrpcpb.AuditRecord)
+	assert.Nil(t, prewrite.Unmarshal(records[0].Value()))
+	assert.Equal(t, AuditKey([]byte{1}, 100, false), records[0].Key())
+	assert.Equal(t, engine_util.CfAudit, records[0].Cf())
+	assert.Equal(t, kvrpcpb.Op_Put, prewrite.Op)
+	assert.Equal(t, uint64(100), prewrite.StartTs)
+	assert.Equal(t, uint64(0), prewrite.CommitTs)
+	assert.Equal(t, "c1", prewrite.Client)
+	commit := new(kvrpcpb.AuditRecord)
+	assert.Nil(t, commit.Unmarshal(records[1].Value()))
+	assert.Equal(t, AuditKey([]byte{1}, 110, true), records[1].Key())
+	assert.Equal(t, uint64(100), commit.StartTs)
+	assert.Equal(t, uint64(110), commit.CommitTs)
+
+	assert.Equal(t, 0, len(AuditRecords(ctx, batch, nil)))
+	assert.Equal(t, 3, len(AuditRecords(ctx, batch, [][]byte{{}})))
+}
+
+func TestAuditKeyOrder(t *testing.T) {
+	// A rollback sorts before the prewrite of the same transaction.
+	assert.True(t, bytes.Compare(AuditKey([]byte{1}, 100, true), AuditKey([]byte{1}, 100, false)) < 0)
+	assert.True(t, bytes.Compare(AuditKey([]byte{1}, 110, false), AuditKey([]byte{1}, 100, true)) < 0)
+	assert.Equal(t, []byte{1}, DecodeUserKey(AuditKey([]byte{1}, 100, false)))
+}
@@ -0,0 +1,41 @@
+package mvcc
+
+import (
+	"bytes"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+)
+
+// IsolationPolicy decides the isolation level of a read. A read uses read committed if its request asks for it,
+// or if the key read is in one of the read committed keyspaces, otherwise it uses snapshot isolation.
+type IsolationPolicy struct {
+	rcPrefixes [][]byte
+}
+
+// NewIsolationPolicy creates a policy with the keys with any of rcPrefixes read with read committed.
+func NewIsolationPolicy(rcPrefixes [][]byte) *IsolationPolicy {
+	return &IsolationPolicy{rcPrefixes: rcPrefixes}
+}
+
+// Level returns the isolation level of a read of key, for a scan key is the start key. A nil policy always
+// returns the level of the request.
+func (p *IsolationPolicy) Level(ctx *kvrpcpb.Context, key []byte) kvrpcpb.IsolationLevel {
+	if ctx.GetIsolationLevel() == kvrpcpb.IsolationLevel_RC || p == nil {
+		return ctx.GetIsolationLevel()
+	}
+	for _, prefix := range p.rcPrefixes {
+		if bytes.HasPrefix(key, prefix) {
+			return kvrpcpb.IsolationLevel_RC
+		}
+	}
+	return kvrpcpb.IsolationLevel_SI
+}
+
+// IsLockedForLevel is IsLockedFor for a read with the given isolation level. A read committed read is never
+// blocked by a lock, it reads the latest value committed before txnStartTs.
+func (lock *Lock) IsLockedForLevel(key []byte, txnStartTs uint64, level kvrpcpb.IsolationLevel, resp interface{}) bool {
+	if level == kvrpcpb.IsolationLevel_RC {
+		return false
+	}
+	return lock.IsLockedFor(key, txnStartTs, resp)
+}
@@ -0,0 +1,31 @@
+package mvcc
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsolationPolicy(t *testing.T) {
+	si := &kvrpcpb.Context{}
+	rc := &kvrpcpb.Context{IsolationLevel: kvrpcpb.IsolationLevel_RC}
+
+	var policy *IsolationPolicy
+	assert.Equal(t, kvrpcpb.IsolationLevel_SI, policy.Level(si, []byte{1}))
+	assert.Equal(t, kvrpcpb.IsolationLevel_RC, policy.Level(rc, []byte{1}))
+
+	policy = NewIsolationPolicy([][]byte{{1, 2}})
+	assert.Equal(t, kvrpcpb.IsolationLevel_RC, policy.Level(si, []byte{1, 2, 3}))
+	assert.Equal(t, kvrpcpb.IsolationLevel_SI, policy.Level(si, []byte{1, 3}))
+	assert.Equal(t, kvrpcpb.IsolationLevel_RC, policy.Level(rc, []byte{1, 3}))
+}
+
+func TestIsLockedForLevel(t *testing.T) {
+	lock := &Lock{Primary: []byte{1}, Ts: 100, Kind: WriteKindPut}
+	resp := new(kvrpcpb.GetResponse)
+	assert.False(t, lock.IsLockedForLevel([]byte{1}, 110, kvrpcpb.IsolationLevel_RC, resp))
+	assert.Nil(t, resp.Error)
+	assert.True(t, lock.IsLockedForLevel([]byte{1}, 110, kvrpcpb.IsolationLevel_SI, resp))
+	assert.NotNil(t, resp.Error.Locked)
+}
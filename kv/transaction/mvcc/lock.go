@@ -17,8 +17,14 @@ type Lock struct {
 	Ts      uint64
 	Ttl     uint64
 	Kind    WriteKind
+	// MinCommitTs is non-zero for a lock whose prewrite amended a write conflict, it is the commit
+	// timestamp of the newest conflicting write. See Amend.
+	MinCommitTs uint64
 }
 
+// lockAmendedFlag is set in the encoded kind byte of a lock which carries a MinCommitTs.
+const lockAmendedFlag byte = 0x80
+
 type KlPair struct {
 	Key  []byte
 	Lock *Lock
@@ -34,11 +40,20 @@ func (lock *Lock) Info(key []byte) *kvrpcpb.LockInfo {
 	return &info
 }
 
+// ToBytes encodes a lock as primary, [min commit ts,] kind, ts and ttl. The min commit ts is only present if the
+// amended flag is set in the kind byte, so locks without one keep the original encoding.
 func (lock *Lock) ToBytes() []byte {
-	buf := append(lock.Primary, byte(lock.Kind))
+	buf := append([]byte{}, lock.Primary...)
+	kind := byte(lock.Kind)
+	if lock.MinCommitTs != 0 {
+		buf = append(buf, make([]byte, 8)...)
+		binary.BigEndian.PutUint64(buf[len(lock.Primary):], lock.MinCommitTs)
+		kind |= lockAmendedFlag
+	}
+	buf = append(buf, kind)
 	buf = append(buf, make([]byte, 16)...)
-	binary.BigEndian.PutUint64(buf[len(lock.Primary)+1:], lock.Ts)
-	binary.BigEndian.PutUint64(buf[len(lock.Primary)+9:], lock.Ttl)
+	binary.BigEndian.PutUint64(buf[len(buf)-16:], lock.Ts)
+	binary.BigEndian.PutUint64(buf[len(buf)-8:], lock.Ttl)
 	return buf
 }
 
@@ -49,12 +64,21 @@ func ParseLock(input []byte) (*Lock, error) {
 	}
 
 	primaryLen := len(input) - 17
-	primary := input[:primaryLen]
-	kind := WriteKind(input[primaryLen])
+	kind := input[primaryLen]
 	ts := binary.BigEndian.Uint64(input[primaryLen+1:])
 	ttl := binary.BigEndian.Uint64(input[primaryLen+9:])
+	var minCommitTs uint64
+	if kind&lockAmendedFlag != 0 {
+		if primaryLen < 8 {
+			return nil, fmt.Errorf("mvcc: error parsing amended lock, not enough input, found %d bytes", len(input))
+		}
+		primaryLen -= 8
+		minCommitTs = binary.BigEndian.Uint64(input[primaryLen:])
+		kind &^= lockAmendedFlag
+	}
+	primary := input[:primaryLen]
 
-	return &Lock{Primary: primary, Ts: ts, Ttl: ttl, Kind: kind}, nil
+	return &Lock{Primary: primary, Ts: ts, Ttl: ttl, Kind: WriteKind(kind), MinCommitTs: minCommitTs}, nil
 }
 
 // IsLockedFor checks if lock locks key at txnStartTs.
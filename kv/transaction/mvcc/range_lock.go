@@ -0,0 +1,253 @@
+package mvcc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/pingcap-incubator/tinykv/kv/storage"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+)
+
+// A range lock locks the keys of [StartKey, EndKey) for a DDL-style operation, e.g. an index backfill. Range locks
+// are kept in the range lock CF keyed by their start key, they are few so a region's range locks are read in full.
+// The range locks of a region never overlap unless one of them has expired.
+type RangeLock struct {
+	StartKey []byte
+	// EndKey is empty if the range is unbounded.
+	EndKey []byte
+	Ts     uint64
+	Ttl    uint64
+}
+
+// Contains checks if key is in the locked range.
+func (lock *RangeLock) Contains(key []byte) bool {
+	return bytes.Compare(key, lock.StartKey) >= 0 && (len(lock.EndKey) == 0 || bytes.Compare(key, lock.EndKey) < 0)
+}
+
+// Overlaps checks if the locked range overlaps [startKey, endKey), an empty endKey means an unbounded range.
+func (lock *RangeLock) Overlaps(startKey, endKey []byte) bool {
+	return (len(endKey) == 0 || bytes.Compare(lock.StartKey, endKey) < 0) &&
+		(len(lock.EndKey) == 0 || bytes.Compare(startKey, lock.EndKey) < 0)
+}
+
+// ExpiredAt checks if the ttl of the lock has run out at ts.
+func (lock *RangeLock) ExpiredAt(ts uint64) bool {
+	return PhysicalTime(lock.Ts)+lock.Ttl <= PhysicalTime(ts)
+}
+
+// Info creates a LockInfo object from a RangeLock object for key.
+func (lock *RangeLock) Info(key []byte) *kvrpcpb.LockInfo {
+	return &kvrpcpb.LockInfo{
+		PrimaryLock: lock.StartKey,
+		LockVersion: lock.Ts,
+		Key:         key,
+		LockTtl:     lock.Ttl,
+		Range:       &kvrpcpb.KeyRange{StartKey: lock.StartKey, EndKey: lock.EndKey},
+	}
+}
+
+// ToBytes encodes a range lock as end key, ts and ttl, the start key is the key of the lock.
+func (lock *RangeLock) ToBytes() []byte {
+	buf := append([]byte{}, lock.EndKey...)
+	buf = append(buf, make([]byte, 16)...)
+	binary.BigEndian.PutUint64(buf[len(buf)-16:], lock.Ts)
+	binary.BigEndian.PutUint64(buf[len(buf)-8:], lock.Ttl)
+	return buf
+}
+
+// ParseRangeLock attempts to parse the key and value of a range lock into a RangeLock object.
+func ParseRangeLock(key, value []byte) (*RangeLock, error) {
+	if len(value) < 16 {
+		return nil, fmt.Errorf("mvcc: error parsing range lock, not enough input, found %d bytes", len(value))
+	}
+	endLen := len(value) - 16
+	return &RangeLock{
+		StartKey: key,
+		EndKey:   value[:endLen],
+		Ts:       binary.BigEndian.Uint64(value[endLen:]),
+		Ttl:      binary.BigEndian.Uint64(value[endLen+8:]),
+	}, nil
+}
+
+// ScanRangeLocks returns the range locks which overlap [startKey, endKey), ordered by their start keys.
+func ScanRangeLocks(reader storage.StorageReader, startKey, endKey []byte) ([]*RangeLock, error) {
+	iter := reader.IterCF(engine_util.CfRangeLock)
+	defer iter.Close()
+	var locks []*RangeLock
+	for ; iter.Valid(); iter.Next() {
+		item := iter.Item()
+		if len(endKey) != 0 && bytes.Compare(item.Key(), endKey) >= 0 {
+			break
+		}
+		value, err := item.Value()
+		if err != nil {
+			return nil, err
+		}
+		lock, err := ParseRangeLock(item.KeyCopy(nil), value)
+		if err != nil {
+			return nil, err
+		}
+		if lock.Overlaps(startKey, endKey) {
+			locks = append(locks, lock)
+		}
+	}
+	return locks, nil
+}
+
+// CheckRangeLock returns a locked error if key is in a range locked by another transaction, which has not
+// expired at the start timestamp of txn. A prewrite checks every key it writes.
+func (txn *MvccTxn) CheckRangeLock(key []byte) (*kvrpcpb.KeyError, error) {
+	locks, err := ScanRangeLocks(txn.Reader, key, append(append([]byte{}, key...), 0))
+	if err != nil {
+		return nil, err
+	}
+	for _, lock := range locks {
+		if lock.Ts != txn.StartTS && !lock.ExpiredAt(txn.StartTS) {
+			return &kvrpcpb.KeyError{Locked: lock.Info(key)}, nil
+		}
+	}
+	return nil, nil
+}
+
+// LockRange adds a range lock over [startKey, endKey) to txn, refreshing the ttl if txn already locked the range.
+// A locked error is returned if the range overlaps a lock of another transaction, i.e. a range lock which has not
+// expired or the lock of a key. Expired range locks in the range are removed.
+func (txn *MvccTxn) LockRange(startKey, endKey []byte, ttl uint64) (*kvrpcpb.KeyError, error) {
+	locks, err := ScanRangeLocks(txn.Reader, startKey, endKey)
+	if err != nil {
+		return nil, err
+	}
+	for _, lock := range locks {
+		switch {
+		case lock.Ts == txn.StartTS && bytes.Equal(lock.StartKey, startKey):
+		case lock.ExpiredAt(txn.StartTS):
+			txn.DeleteRangeLock(lock.StartKey)
+		default:
+			return &kvrpcpb.KeyError{Locked: lock.Info(startKey)}, nil
+		}
+	}
+
+	iter := txn.Reader.IterCF(engine_util.CfLock)
+	defer iter.Close()
+	for iter.Seek(startKey); iter.Valid(); iter.Next() {
+		item := iter.Item()
+		if len(endKey) != 0 && bytes.Compare(item.Key(), endKey) >= 0 {
+			break
+		}
+		value, err := item.Value()
+		if err != nil {
+			return nil, err
+		}
+		lock, err := ParseLock(value)
+		if err != nil {
+			return nil, err
+		}
+		if lock.Ts != txn.StartTS {
+			return &kvrpcpb.KeyError{Locked: lock.Info(item.KeyCopy(nil))}, nil
+		}
+	}
+
+	txn.PutRangeLock(&RangeLock{StartKey: startKey, EndKey: endKey, Ts: txn.StartTS, Ttl: ttl})
+	return nil, nil
+}
+
+// UnlockRange removes the range lock starting at startKey if it was placed by txn.
+func (txn *MvccTxn) UnlockRange(startKey []byte) error {
+	value, err := txn.Reader.GetCF(engine_util.CfRangeLock, startKey)
+	if err != nil || value == nil {
+		return err
+	}
+	lock, err := ParseRangeLock(startKey, value)
+	if err != nil {
+		return err
+	}
+	if lock.Ts == txn.StartTS {
+		txn.DeleteRangeLock(startKey)
+	}
+	return nil
+}
+
+// PutRangeLock adds a range lock to this transaction.
+func (txn *MvccTxn) PutRangeLock(lock *RangeLock) {
+	txn.writes = append(txn.writes, storage.Modify{Data: storage.Put{
+		Key:   lock.StartKey,
+		Value: lock.ToBytes(),
+		Cf:    engine_util.CfRangeLock,
+	}})
+}
+
+// DeleteRangeLock adds a delete of the range lock starting at startKey to this transaction.
+func (txn *MvccTxn) DeleteRangeLock(startKey []byte) {
+	txn.writes = append(txn.writes, storage.Modify{Data: storage.Delete{
+		Key: startKey,
+		Cf:  engine_util.CfRangeLock,
+	}})
+}
+
+// PointLockScanner returns at most limit locks of the keys in [startKey, endKey) placed at or before maxTs, in key
+// order. A zero limit means no limit.
+type PointLockScanner func(maxTs uint64, startKey, endKey []byte, limit uint32) ([]*kvrpcpb.LockInfo, error)
+
+// ScanLocks returns at most limit locks placed at or before maxTs, the range locks overlapping
+// [startKey, endKey) followed by the locks of the keys in it. A zero limit means no limit.
+func ScanLocks(reader storage.StorageReader, maxTs uint64, startKey, endKey []byte, limit uint32) ([]*kvrpcpb.LockInfo, error) {
+	return ScanLocksWith(reader, nil, maxTs, startKey, endKey, limit)
+}
+
+// ScanLocksWith is ScanLocks reading the locks of the keys with scanPoints, or from the lock CF if it's nil.
+func ScanLocksWith(reader storage.StorageReader, scanPoints PointLockScanner, maxTs uint64, startKey, endKey []byte, limit uint32) ([]*kvrpcpb.LockInfo, error) {
+	var infos []*kvrpcpb.LockInfo
+	rangeLocks, err := ScanRangeLocks(reader, startKey, endKey)
+	if err != nil {
+		return nil, err
+	}
+	for _, lock := range rangeLocks {
+		if limit != 0 && uint32(len(infos)) >= limit {
+			return infos, nil
+		}
+		if lock.Ts <= maxTs {
+			infos = append(infos, lock.Info(lock.StartKey))
+		}
+	}
+
+	if scanPoints == nil {
+		scanPoints = func(maxTs uint64, startKey, endKey []byte, limit uint32) ([]*kvrpcpb.LockInfo, error) {
+			return ScanPointLocks(reader, maxTs, startKey, endKey, limit)
+		}
+	}
+	if limit != 0 {
+		limit -= uint32(len(infos))
+	}
+	points, err := scanPoints(maxTs, startKey, endKey, limit)
+	if err != nil {
+		return nil, err
+	}
+	return append(infos, points...), nil
+}
+
+// ScanPointLocks scans the lock CF for the locks of the keys in [startKey, endKey).
+func ScanPointLocks(reader storage.StorageReader, maxTs uint64, startKey, endKey []byte, limit uint32) ([]*kvrpcpb.LockInfo, error) {
+	var infos []*kvrpcpb.LockInfo
+	iter := reader.IterCF(engine_util.CfLock)
+	defer iter.Close()
+	for iter.Seek(startKey); iter.Valid() && (limit == 0 || uint32(len(infos)) < limit); iter.Next() {
+		item := iter.Item()
+		if len(endKey) != 0 && bytes.Compare(item.Key(), endKey) >= 0 {
+			break
+		}
+		value, err := item.Value()
+		if err != nil {
+			return nil, err
+		}
+		lock, err := ParseLock(value)
+		if err != nil {
+			return nil, err
+		}
+		if lock.Ts <= maxTs {
+			infos = append(infos, lock.Info(item.KeyCopy(nil)))
+		}
+	}
+	return infos, nil
+}
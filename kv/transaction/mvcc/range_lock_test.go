@@ -0,0 +1,117 @@
+package mvcc
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/kv/storage"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/scheduler/pkg/tsoutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func tsAt(physical uint64) uint64 {
+	return physical << tsoutil.PhysicalShiftBits
+}
+
+func TestRangeLockEncoding(t *testing.T) {
+	lock := &RangeLock{StartKey: []byte{1}, EndKey: []byte{5}, Ts: 100, Ttl: 10}
+	parsed, err := ParseRangeLock(lock.StartKey, lock.ToBytes())
+	assert.Nil(t, err)
+	assert.Equal(t, lock, parsed)
+
+	assert.True(t, lock.Contains([]byte{1}))
+	assert.True(t, lock.Contains([]byte{4, 9}))
+	assert.False(t, lock.Contains([]byte{5}))
+	assert.True(t, lock.Overlaps([]byte{4}, nil))
+	assert.False(t, lock.Overlaps([]byte{5}, []byte{6}))
+	assert.False(t, lock.Overlaps(nil, []byte{1}))
+}
+
+func TestCheckRangeLock(t *testing.T) {
+	lock := &RangeLock{StartKey: []byte{1}, EndKey: []byte{5}, Ts: tsAt(100), Ttl: 10}
+	put := func(m *storage.MemStorage) {
+		m.Set(engine_util.CfRangeLock, lock.StartKey, lock.ToBytes())
+	}
+
+	keyErr, err := testTxn(tsAt(105), put).CheckRangeLock([]byte{3})
+	assert.Nil(t, err)
+	assert.NotNil(t, keyErr)
+	assert.Equal(t, lock.Ts, keyErr.Locked.LockVersion)
+	assert.Equal(t, []byte{5}, keyErr.Locked.Range.EndKey)
+
+	// outside of the range
+	keyErr, err = testTxn(tsAt(105), put).CheckRangeLock([]byte{5})
+	assert.Nil(t, err)
+	assert.Nil(t, keyErr)
+	// the transaction which placed the lock
+	keyErr, err = testTxn(lock.Ts, put).CheckRangeLock([]byte{3})
+	assert.Nil(t, err)
+	assert.Nil(t, keyErr)
+	// expired
+	keyErr, err = testTxn(tsAt(110), put).CheckRangeLock([]byte{3})
+	assert.Nil(t, err)
+	assert.Nil(t, keyErr)
+}
+
+func TestLockRange(t *testing.T) {
+	old := &RangeLock{StartKey: []byte{2}, EndKey: []byte{4}, Ts: tsAt(100), Ttl: 10}
+	put := func(m *storage.MemStorage) {
+		m.Set(engine_util.CfRangeLock, old.StartKey, old.ToBytes())
+	}
+
+	txn := testTxn(tsAt(105), put)
+	keyErr, err := txn.LockRange([]byte{1}, []byte{3}, 10)
+	assert.Nil(t, err)
+	assert.NotNil(t, keyErr)
+	assert.Equal(t, 0, len(txn.Writes()))
+
+	// the expired lock is replaced
+	txn = testTxn(tsAt(110), put)
+	keyErr, err = txn.LockRange([]byte{1}, []byte{3}, 10)
+	assert.Nil(t, err)
+	assert.Nil(t, keyErr)
+	assert.Equal(t, 2, len(txn.Writes()))
+	assert.Equal(t, storage.Delete{Cf: engine_util.CfRangeLock, Key: old.StartKey}, txn.Writes()[0].Data)
+
+	// the lock of a key in the range
+	lock := &Lock{Primary: []byte{2}, Ts: tsAt(90), Kind: WriteKindPut}
+	txn = testTxn(tsAt(110), func(m *storage.MemStorage) {
+		m.Set(engine_util.CfLock, []byte{2, 1}, lock.ToBytes())
+	})
+	keyErr, err = txn.LockRange([]byte{1}, []byte{3}, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{2, 1}, keyErr.Locked.Key)
+	assert.Nil(t, keyErr.Locked.Range)
+
+	txn = testTxn(tsAt(110), put)
+	assert.Nil(t, txn.UnlockRange(old.StartKey))
+	assert.Equal(t, 0, len(txn.Writes()))
+	txn = testTxn(old.Ts, put)
+	assert.Nil(t, txn.UnlockRange(old.StartKey))
+	assertDeleteInTxn(t, txn, old.StartKey, engine_util.CfRangeLock)
+}
+
+func TestScanLocks(t *testing.T) {
+	rangeLock := &RangeLock{StartKey: []byte{1}, EndKey: []byte{3}, Ts: 100, Ttl: 10}
+	lock := &Lock{Primary: []byte{4}, Ts: 90, Kind: WriteKindPut}
+	newer := &Lock{Primary: []byte{5}, Ts: 200, Kind: WriteKindPut}
+	txn := testTxn(0, func(m *storage.MemStorage) {
+		m.Set(engine_util.CfRangeLock, rangeLock.StartKey, rangeLock.ToBytes())
+		m.Set(engine_util.CfLock, []byte{4}, lock.ToBytes())
+		m.Set(engine_util.CfLock, []byte{5}, newer.ToBytes())
+	})
+
+	infos, err := ScanLocks(txn.Reader, 150, []byte{2}, nil, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(infos))
+	assert.Equal(t, []byte{1}, infos[0].Key)
+	assert.NotNil(t, infos[0].Range)
+	assert.Equal(t, []byte{4}, infos[1].Key)
+
+	infos, err = ScanLocks(txn.Reader, 150, []byte{3}, nil, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(infos))
+	infos, err = ScanLocks(txn.Reader, 300, nil, nil, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(infos))
+}
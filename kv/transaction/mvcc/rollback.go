@@ -0,0 +1,40 @@
+package mvcc
+
+import (
+	"github.com/pingcap-incubator/tinykv/kv/util/codec"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+)
+
+// StaleRollbacks returns the keys of the rollback records in [startKey, endKey) of the write CF iter iterates, whose
+// transactions started before safeTs. A rollback record only stops a late prewrite of its transaction, which can't
+// come once the locks of the transaction would have expired, while the records pile up on a key with many aborted
+// transactions and lengthen the seeks for its latest write. At most limit keys are returned, along with the key to
+// continue from, which is nil if the range is done.
+func StaleRollbacks(iter engine_util.DBIterator, startKey, endKey []byte, safeTs uint64, limit int) ([][]byte, []byte, error) {
+	var keys [][]byte
+	for iter.Seek(startKey); iter.Valid(); iter.Next() {
+		item := iter.Item()
+		key := item.Key()
+		if engine_util.ExceedEndKey(key, endKey) {
+			break
+		}
+		if len(keys) >= limit {
+			return keys, item.KeyCopy(nil), nil
+		}
+		if left, _, err := codec.DecodeBytes(key); err != nil || len(left) != 8 || decodeTimestamp(key) >= safeTs {
+			continue
+		}
+		value, err := item.Value()
+		if err != nil {
+			return nil, nil, err
+		}
+		write, err := ParseWrite(value)
+		if err != nil {
+			return nil, nil, err
+		}
+		if write != nil && write.Kind == WriteKindRollback && write.StartTS < safeTs {
+			keys = append(keys, item.KeyCopy(nil))
+		}
+	}
+	return keys, nil, nil
+}
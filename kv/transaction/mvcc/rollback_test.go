@@ -0,0 +1,41 @@
+package mvcc
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/kv/storage"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaleRollbacks(t *testing.T) {
+	mem := storage.NewMemStorage()
+	rollback := func(key []byte, ts uint64) {
+		mem.Set(engine_util.CfWrite, EncodeKey(key, ts), (&Write{StartTS: ts, Kind: WriteKindRollback}).ToBytes())
+	}
+	rollback([]byte{1}, ComposeTs(10))
+	rollback([]byte{1}, ComposeTs(20))
+	rollback([]byte{1}, ComposeTs(30))
+	mem.Set(engine_util.CfWrite, EncodeKey([]byte{1}, ComposeTs(16)), (&Write{StartTS: ComposeTs(15), Kind: WriteKindPut}).ToBytes())
+	rollback([]byte{2}, ComposeTs(10))
+	rollback([]byte{3}, ComposeTs(10))
+
+	reader, err := mem.Reader(nil)
+	assert.Nil(t, err)
+	iter := reader.IterCF(engine_util.CfWrite)
+	defer iter.Close()
+
+	keys, next, err := StaleRollbacks(iter, nil, EncodeKey([]byte{3}, ComposeTs(100)), ComposeTs(25), 10)
+	assert.Nil(t, err)
+	assert.Nil(t, next)
+	assert.Equal(t, [][]byte{
+		EncodeKey([]byte{1}, ComposeTs(20)),
+		EncodeKey([]byte{1}, ComposeTs(10)),
+		EncodeKey([]byte{2}, ComposeTs(10)),
+	}, keys)
+
+	keys, next, err = StaleRollbacks(iter, nil, nil, ComposeTs(25), 1)
+	assert.Nil(t, err)
+	assert.Equal(t, [][]byte{EncodeKey([]byte{1}, ComposeTs(20))}, keys)
+	assert.Equal(t, EncodeKey([]byte{1}, ComposeTs(16)), next)
+}
@@ -0,0 +1,96 @@
+package mvcc
+
+import (
+	"fmt"
+
+	"github.com/pingcap-incubator/tinykv/kv/storage"
+	"github.com/pingcap-incubator/tinykv/kv/util/codec"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+)
+
+// A large transaction may flush its buffered mutations to the stores before it commits, so the client doesn't keep
+// them in memory. The mutations are staged in the staging CF under the key and the start timestamp of the
+// transaction, they are invisible to other transactions, and the transaction reads them back with the staged flag of
+// a get. A prewrite takes the staged mutation of a key for a mutation with the Staged op, and deletes it. The staged
+// mutations of a transaction which never prewrites them are removed in the background once they are stale.
+
+// PutStaged adds the staging of the mutation to this transaction. The staged value is the op followed by the value
+// of the mutation, so it's never empty.
+func (txn *MvccTxn) PutStaged(m *kvrpcpb.Mutation) {
+	value := make([]byte, 1, 1+len(m.Value))
+	value[0] = byte(m.Op)
+	txn.writes = append(txn.writes, storage.Modify{Data: storage.Put{
+		Key:   EncodeKey(m.Key, txn.StartTS),
+		Value: append(value, m.Value...),
+		Cf:    engine_util.CfStaging,
+	}})
+}
+
+// GetStaged returns the mutation of the key staged by this transaction, or nil if there is none.
+func (txn *MvccTxn) GetStaged(key []byte) (*kvrpcpb.Mutation, error) {
+	value, err := txn.Reader.GetCF(engine_util.CfStaging, EncodeKey(key, txn.StartTS))
+	if err != nil || value == nil {
+		return nil, err
+	}
+	if len(value) == 0 {
+		return nil, fmt.Errorf("mvcc: error parsing staged mutation of key %q, empty value", key)
+	}
+	return &kvrpcpb.Mutation{Op: kvrpcpb.Op(value[0]), Key: key, Value: value[1:]}, nil
+}
+
+// DeleteStaged adds the delete of the mutation of the key staged by this transaction to this transaction.
+func (txn *MvccTxn) DeleteStaged(key []byte) {
+	txn.writes = append(txn.writes, storage.Modify{Data: storage.Delete{
+		Key: EncodeKey(key, txn.StartTS),
+		Cf:  engine_util.CfStaging,
+	}})
+}
+
+// TakeStaged replaces the mutations with the Staged op by the mutations staged by this transaction, and adds the
+// deletes of the staged mutations to this transaction. The keys whose staged mutation is missing, e.g. it was removed
+// as stale, are returned, the transaction can't be committed then.
+func (txn *MvccTxn) TakeStaged(mutations []*kvrpcpb.Mutation) ([]*kvrpcpb.Mutation, [][]byte, error) {
+	var missing [][]byte
+	result := make([]*kvrpcpb.Mutation, 0, len(mutations))
+	for _, m := range mutations {
+		if m.Op != kvrpcpb.Op_Staged {
+			result = append(result, m)
+			continue
+		}
+		staged, err := txn.GetStaged(m.Key)
+		if err != nil {
+			return nil, nil, err
+		}
+		if staged == nil {
+			missing = append(missing, m.Key)
+			continue
+		}
+		txn.DeleteStaged(m.Key)
+		result = append(result, staged)
+	}
+	return result, missing, nil
+}
+
+// StaleStaged returns the keys in [startKey, endKey) of the staging CF iter iterates, of the mutations staged by the
+// transactions started before safeTs. At most limit keys are returned, along with the key to continue from, which is
+// nil if the range is done.
+func StaleStaged(iter engine_util.DBIterator, startKey, endKey []byte, safeTs uint64, limit int) ([][]byte, []byte, error) {
+	var keys [][]byte
+	for iter.Seek(startKey); iter.Valid(); iter.Next() {
+		key := iter.Item().Key()
+		if engine_util.ExceedEndKey(key, endKey) {
+			break
+		}
+		if len(keys) >= limit {
+			return keys, iter.Item().KeyCopy(nil), nil
+		}
+		if left, _, err := codec.DecodeBytes(key); err != nil || len(left) != 8 {
+			continue
+		}
+		if decodeTimestamp(key) < safeTs {
+			keys = append(keys, iter.Item().KeyCopy(nil))
+		}
+	}
+	return keys, nil, nil
+}
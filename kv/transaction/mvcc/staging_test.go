@@ -0,0 +1,62 @@
+package mvcc
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/kv/storage"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaging(t *testing.T) {
+	mem := storage.NewMemStorage()
+	staging := testTxn(10, nil)
+	staging.PutStaged(&kvrpcpb.Mutation{Op: kvrpcpb.Op_Put, Key: []byte{1}, Value: []byte{}})
+	staging.PutStaged(&kvrpcpb.Mutation{Op: kvrpcpb.Op_Del, Key: []byte{2}})
+	assert.Nil(t, mem.Write(nil, staging.Writes()))
+	// staged by another transaction
+	other := testTxn(20, nil)
+	other.PutStaged(&kvrpcpb.Mutation{Op: kvrpcpb.Op_Put, Key: []byte{3}, Value: []byte{42}})
+	assert.Nil(t, mem.Write(nil, other.Writes()))
+
+	reader, _ := mem.Reader(nil)
+	txn := NewMvccTxn(reader, 10)
+	staged, err := txn.GetStaged([]byte{1})
+	assert.Nil(t, err)
+	assert.Equal(t, &kvrpcpb.Mutation{Op: kvrpcpb.Op_Put, Key: []byte{1}, Value: []byte{}}, staged)
+	staged, err = txn.GetStaged([]byte{3})
+	assert.Nil(t, err)
+	assert.Nil(t, staged)
+
+	mutations, missing, err := txn.TakeStaged([]*kvrpcpb.Mutation{
+		{Op: kvrpcpb.Op_Staged, Key: []byte{1}},
+		{Op: kvrpcpb.Op_Staged, Key: []byte{2}},
+		{Op: kvrpcpb.Op_Staged, Key: []byte{3}},
+		{Op: kvrpcpb.Op_Put, Key: []byte{4}, Value: []byte{7}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, [][]byte{{3}}, missing)
+	assert.Equal(t, []*kvrpcpb.Mutation{
+		{Op: kvrpcpb.Op_Put, Key: []byte{1}, Value: []byte{}},
+		{Op: kvrpcpb.Op_Del, Key: []byte{2}, Value: []byte{}},
+		{Op: kvrpcpb.Op_Put, Key: []byte{4}, Value: []byte{7}},
+	}, mutations)
+	assert.Equal(t, []storage.Modify{
+		{Data: storage.Delete{Key: EncodeKey([]byte{1}, 10), Cf: engine_util.CfStaging}},
+		{Data: storage.Delete{Key: EncodeKey([]byte{2}, 10), Cf: engine_util.CfStaging}},
+	}, txn.Writes())
+
+	iter := reader.IterCF(engine_util.CfStaging)
+	keys, next, err := StaleStaged(iter, nil, nil, 15, 10)
+	iter.Close()
+	assert.Nil(t, err)
+	assert.Nil(t, next)
+	assert.Equal(t, [][]byte{EncodeKey([]byte{1}, 10), EncodeKey([]byte{2}, 10)}, keys)
+	iter = reader.IterCF(engine_util.CfStaging)
+	keys, next, err = StaleStaged(iter, nil, nil, 25, 1)
+	iter.Close()
+	assert.Nil(t, err)
+	assert.Equal(t, [][]byte{EncodeKey([]byte{1}, 10)}, keys)
+	assert.Equal(t, EncodeKey([]byte{2}, 10), next)
+}
@@ -0,0 +1,123 @@
+package mvcc
+
+import (
+	"bytes"
+
+	"github.com/pingcap-incubator/tinykv/kv/storage"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+)
+
+// StatsReader is a storage reader which records how much of the storage is read through it, for the exec details
+// of a request which sets Context.RecordScanStat. It must only be used by a single request.
+type StatsReader struct {
+	storage.StorageReader
+	detail kvrpcpb.ScanDetail
+}
+
+// NewStatsReader wraps reader if the request asks for its execution statistics, otherwise it returns reader.
+func NewStatsReader(ctx *kvrpcpb.Context, reader storage.StorageReader) storage.StorageReader {
+	if !ctx.GetRecordScanStat() {
+		return reader
+	}
+	return &StatsReader{StorageReader: reader}
+}
+
+func (r *StatsReader) GetCF(cf string, key []byte) ([]byte, error) {
+	value, err := r.StorageReader.GetCF(cf, key)
+	if err == nil {
+		r.detail.KeysExamined++
+		r.detail.BytesRead += uint64(len(key) + len(value))
+	}
+	return value, err
+}
+
+func (r *StatsReader) IterCF(cf string) engine_util.DBIterator {
+	return &statsIterator{DBIterator: r.StorageReader.IterCF(cf), detail: &r.detail, write: cf == engine_util.CfWrite}
+}
+
+// Inner returns the wrapped reader.
+func (r *StatsReader) Inner() storage.StorageReader {
+	return r.StorageReader
+}
+
+// ExecDetails returns the statistics recorded so far.
+func (r *StatsReader) ExecDetails() *kvrpcpb.ExecDetails {
+	detail := r.detail
+	return &kvrpcpb.ExecDetails{ScanDetail: &detail}
+}
+
+// ExecDetails returns the execution statistics of a reader made by NewStatsReader, nil if it records none.
+func ExecDetails(reader storage.StorageReader) *kvrpcpb.ExecDetails {
+	if r, ok := reader.(*StatsReader); ok {
+		return r.ExecDetails()
+	}
+	return nil
+}
+
+type statsIterator struct {
+	engine_util.DBIterator
+	detail *kvrpcpb.ScanDetail
+	// whether the iterator is over the write CF, whose versions and rollbacks are counted
+	write bool
+	// whether the item at the position has been counted
+	visited bool
+	// the user key of the last item visited in the write CF
+	lastUserKey []byte
+}
+
+func (it *statsIterator) Seek(key []byte) {
+	it.detail.Seeks++
+	it.visited = false
+	it.DBIterator.Seek(key)
+}
+
+func (it *statsIterator) Next() {
+	it.visited = false
+	it.DBIterator.Next()
+}
+
+func (it *statsIterator) Item() engine_util.DBItem {
+	item := it.DBIterator.Item()
+	if !it.visited {
+		it.visited = true
+		it.visit(item)
+	}
+	return &statsItem{DBItem: item, detail: it.detail}
+}
+
+func (it *statsIterator) visit(item engine_util.DBItem) {
+	it.detail.KeysExamined++
+	it.detail.BytesRead += uint64(len(item.Key()))
+	if !it.write {
+		return
+	}
+	userKey := DecodeUserKey(item.Key())
+	if it.lastUserKey != nil && bytes.Equal(userKey, it.lastUserKey) {
+		it.detail.VersionsSkipped++
+	}
+	it.lastUserKey = append(it.lastUserKey[:0], userKey...)
+	if value, err := item.Value(); err == nil {
+		if write, err := ParseWrite(value); err == nil && write != nil && write.Kind == WriteKindRollback {
+			it.detail.RollbacksSkipped++
+		}
+	}
+}
+
+// statsItem counts the bytes of the values read.
+type statsItem struct {
+	engine_util.DBItem
+	detail *kvrpcpb.ScanDetail
+}
+
+func (i *statsItem) Value() ([]byte, error) {
+	value, err := i.DBItem.Value()
+	i.detail.BytesRead += uint64(len(value))
+	return value, err
+}
+
+func (i *statsItem) ValueCopy(dst []byte) ([]byte, error) {
+	value, err := i.DBItem.ValueCopy(dst)
+	i.detail.BytesRead += uint64(len(value))
+	return value, err
+}
@@ -0,0 +1,42 @@
+package mvcc
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/kv/storage"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatsReader(t *testing.T) {
+	mem := storage.NewMemStorage()
+	mem.Set(engine_util.CfWrite, EncodeKey([]byte{1}, 30), (&Write{StartTS: 30, Kind: WriteKindRollback}).ToBytes())
+	mem.Set(engine_util.CfWrite, EncodeKey([]byte{1}, 20), (&Write{StartTS: 10, Kind: WriteKindPut}).ToBytes())
+	mem.Set(engine_util.CfWrite, EncodeKey([]byte{2}, 20), (&Write{StartTS: 10, Kind: WriteKindPut}).ToBytes())
+	mem.Set(engine_util.CfDefault, EncodeKey([]byte{1}, 10), []byte{42})
+
+	reader, err := mem.Reader(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, reader, NewStatsReader(&kvrpcpb.Context{}, reader))
+	assert.Nil(t, ExecDetails(reader))
+
+	stats := NewStatsReader(&kvrpcpb.Context{RecordScanStat: true}, reader)
+	iter := stats.IterCF(engine_util.CfWrite)
+	for iter.Seek(EncodeKey([]byte{1}, 40)); iter.Valid(); iter.Next() {
+		iter.Item()
+		iter.Item()
+	}
+	iter.Close()
+	value, err := stats.GetCF(engine_util.CfDefault, EncodeKey([]byte{1}, 10))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{42}, value)
+
+	detail := ExecDetails(stats).ScanDetail
+	assert.Equal(t, uint64(4), detail.KeysExamined)
+	assert.Equal(t, uint64(1), detail.VersionsSkipped)
+	assert.Equal(t, uint64(1), detail.RollbacksSkipped)
+	assert.Equal(t, uint64(1), detail.Seeks)
+	// the encoded keys are 17 bytes, the values of the write CF are not read
+	assert.Equal(t, uint64(4*17+1), detail.BytesRead)
+}
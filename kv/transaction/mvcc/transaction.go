@@ -123,3 +123,8 @@ func decodeTimestamp(key []byte) uint64 {
 func PhysicalTime(ts uint64) uint64 {
 	return ts >> tsoutil.PhysicalShiftBits
 }
+
+// ComposeTs returns the first timestamp of the physical time, in milliseconds.
+func ComposeTs(physical uint64) uint64 {
+	return physical << tsoutil.PhysicalShiftBits
+}
@@ -0,0 +1,72 @@
+package bloom
+
+import (
+	"hash/fnv"
+)
+
+// Filter is a bloom filter over byte strings. It has no false negatives, the rate of false positives depends on the
+// bits per key it's created with, about 1% for 10 bits per key as long as no more keys than expected are added.
+// A Filter is not safe for concurrent use.
+type Filter struct {
+	bits []uint64
+	// number of hash functions
+	k uint32
+}
+
+// New creates a filter for about n keys.
+func New(n int, bitsPerKey int) *Filter {
+	if n < 64 {
+		n = 64
+	}
+	if bitsPerKey < 1 {
+		bitsPerKey = 1
+	}
+	// k = ln(2) * bits per key minimizes the false positive rate.
+	k := uint32(float64(bitsPerKey) * 0.69)
+	if k < 1 {
+		k = 1
+	} else if k > 30 {
+		k = 30
+	}
+	return &Filter{
+		bits: make([]uint64, (n*bitsPerKey+63)/64),
+		k:    k,
+	}
+}
+
+// Add adds key to the filter.
+func (f *Filter) Add(key []byte) {
+	h1, h2 := hash(key)
+	m := uint32(len(f.bits) * 64)
+	for i := uint32(0); i < f.k; i++ {
+		pos := (h1 + i*h2) % m
+		f.bits[pos/64] |= 1 << (pos % 64)
+	}
+}
+
+// MayContain returns false if key is surely not added to the filter.
+func (f *Filter) MayContain(key []byte) bool {
+	h1, h2 := hash(key)
+	m := uint32(len(f.bits) * 64)
+	for i := uint32(0); i < f.k; i++ {
+		pos := (h1 + i*h2) % m
+		if f.bits[pos/64]&(1<<(pos%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// hash returns the two hashes of key used for double hashing.
+func hash(key []byte) (uint32, uint32) {
+	h := fnv.New64a()
+	h.Write(key)
+	sum := h.Sum64()
+	// FNV mixes the last bytes of similar keys poorly, finalize it like murmur3.
+	sum ^= sum >> 33
+	sum *= 0xff51afd7ed558ccd
+	sum ^= sum >> 33
+	sum *= 0xc4ceb9fe1a85ec53
+	sum ^= sum >> 33
+	return uint32(sum), uint32(sum>>32) | 1
+}
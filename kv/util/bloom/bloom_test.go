@@ -0,0 +1,42 @@
+package bloom
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func key(i int) []byte {
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, uint64(i))
+	return k
+}
+
+func TestFilter(t *testing.T) {
+	const n = 10000
+	f := New(n, 10)
+	for i := 0; i < n; i++ {
+		f.Add(key(i))
+	}
+	for i := 0; i < n; i++ {
+		assert.True(t, f.MayContain(key(i)))
+	}
+
+	falsePositives := 0
+	for i := n; i < 2*n; i++ {
+		if f.MayContain(key(i)) {
+			falsePositives++
+		}
+	}
+	assert.True(t, falsePositives < n/50, "%d false positives", falsePositives)
+}
+
+func TestFilterSmall(t *testing.T) {
+	f := New(0, 0)
+	assert.False(t, f.MayContain([]byte("a")))
+	f.Add([]byte("a"))
+	f.Add(nil)
+	assert.True(t, f.MayContain([]byte("a")))
+	assert.True(t, f.MayContain(nil))
+}
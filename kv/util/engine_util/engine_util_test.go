@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/Connor1996/badger"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
 	"github.com/stretchr/testify/require"
 )
 
@@ -91,3 +92,42 @@ func TestEngineUtil(t *testing.T) {
 	require.False(t, lockIter.Valid())
 	lockIter.Close()
 }
+
+func TestWriteBatchSetMeta(t *testing.T) {
+	wb := new(WriteBatch)
+	entries := []*eraftpb.Entry{{Index: 1, Data: []byte("a")}, {Index: 2, Data: bytes.Repeat([]byte("b"), 100)}}
+	for i, e := range entries {
+		require.Nil(t, wb.SetMeta([]byte{byte(i)}, e))
+	}
+	for i, e := range entries {
+		got := new(eraftpb.Entry)
+		require.Nil(t, got.Unmarshal(wb.entries[i].Value))
+		require.Equal(t, e.Index, got.Index)
+		require.Equal(t, e.Data, got.Data)
+	}
+}
+
+func benchmarkSetMeta(b *testing.B, reset bool) {
+	key := []byte("key")
+	entry := &eraftpb.Entry{Term: 5, Index: 100, Data: bytes.Repeat([]byte("v"), 256)}
+	wb := new(WriteBatch)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if reset {
+			wb.Reset()
+		} else {
+			wb = new(WriteBatch)
+		}
+		for j := 0; j < 64; j++ {
+			wb.SetMeta(key, entry)
+		}
+	}
+}
+
+func BenchmarkSetMeta(b *testing.B) {
+	benchmarkSetMeta(b, false)
+}
+
+func BenchmarkSetMetaReset(b *testing.B) {
+	benchmarkSetMeta(b, true)
+}
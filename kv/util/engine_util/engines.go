@@ -2,6 +2,8 @@ package engine_util
 
 import (
 	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/Connor1996/badger"
 	"github.com/pingcap-incubator/tinykv/log"
@@ -62,7 +64,14 @@ func (en *Engines) Destroy() error {
 
 // CreateDB creates a new Badger DB on disk at subPath.
 func CreateDB(path string, raft bool) *badger.DB {
+	return CreateDBWithSync(path, raft, true)
+}
+
+// CreateDBWithSync creates a new Badger DB on disk at subPath, whose writes
+// are synced one by one if syncWrites, otherwise by SyncDB.
+func CreateDBWithSync(path string, raft bool, syncWrites bool) *badger.DB {
 	opts := badger.DefaultOptions
+	opts.SyncWrites = syncWrites
 	if raft {
 		// Do not need to write blob for raft engine because it will be deleted soon.
 		opts.ValueThreshold = 0
@@ -78,3 +87,41 @@ func CreateDB(path string, raft bool) *badger.DB {
 	}
 	return db
 }
+
+// SyncDB syncs the value log files of the Badger DB in dir modified since the
+// time, so the writes to them before the call are durable. The value log is
+// the write ahead log of Badger, the LSM tree is recovered from it.
+func SyncDB(dir string, since time.Time) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.vlog"))
+	if err != nil {
+		return err
+	}
+	for _, name := range files {
+		info, err := os.Stat(name)
+		if os.IsNotExist(err) {
+			// removed by the value log GC
+			continue
+		} else if err != nil {
+			return err
+		}
+		if info.ModTime().Before(since) {
+			continue
+		}
+		if err := syncFile(name); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func syncFile(name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	err = f.Sync()
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
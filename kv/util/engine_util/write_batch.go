@@ -1,13 +1,18 @@
 package engine_util
 
 import (
+	"bytes"
+
 	"github.com/Connor1996/badger"
 	"github.com/golang/protobuf/proto"
 	"github.com/pingcap/errors"
 )
 
 type WriteBatch struct {
-	entries       []*badger.Entry
+	entries []*badger.Entry
+	// slab holds the entries of the batch and buf the meta values marshaled into it, both reused after Reset
+	slab          []badger.Entry
+	buf           []byte
 	size          int
 	safePoint     int
 	safePointSize int
@@ -15,52 +20,119 @@ type WriteBatch struct {
 }
 
 const (
-	CfDefault string = "default"
-	CfWrite   string = "write"
-	CfLock    string = "lock"
+	CfDefault   string = "default"
+	CfWrite     string = "write"
+	CfLock      string = "lock"
+	CfAudit     string = "audit"
+	CfRangeLock string = "range_lock"
+	CfStaging   string = "staging"
+	// the raw deletes kept for a while in the soft delete mode of the raw API
+	CfRawTombstone string = "raw_tombstone"
 )
 
-var CFs [3]string = [3]string{CfDefault, CfWrite, CfLock}
+var CFs [7]string = [7]string{CfDefault, CfWrite, CfLock, CfAudit, CfRangeLock, CfStaging, CfRawTombstone}
 
 func (wb *WriteBatch) Len() int {
 	return len(wb.entries)
 }
 
+// addEntry adds an entry taken from the slab. The entries taken before stay in the old slab when it's full, so the
+// pointers to them remain valid.
+func (wb *WriteBatch) addEntry(key, val []byte) {
+	if len(wb.slab) == cap(wb.slab) {
+		c := 2 * cap(wb.slab)
+		if c < 16 {
+			c = 16
+		}
+		wb.slab = make([]badger.Entry, 0, c)
+	}
+	wb.slab = append(wb.slab, badger.Entry{Key: key, Value: val})
+	wb.entries = append(wb.entries, &wb.slab[len(wb.slab)-1])
+}
+
 func (wb *WriteBatch) SetCF(cf string, key, val []byte) {
-	wb.entries = append(wb.entries, &badger.Entry{
-		Key:   KeyWithCF(cf, key),
-		Value: val,
-	})
+	wb.addEntry(KeyWithCF(cf, key), val)
 	wb.size += len(key) + len(val)
 }
 
 func (wb *WriteBatch) DeleteMeta(key []byte) {
-	wb.entries = append(wb.entries, &badger.Entry{
-		Key: key,
-	})
+	wb.addEntry(key, nil)
 	wb.size += len(key)
 }
 
 func (wb *WriteBatch) DeleteCF(cf string, key []byte) {
-	wb.entries = append(wb.entries, &badger.Entry{
-		Key: KeyWithCF(cf, key),
-	})
+	wb.addEntry(KeyWithCF(cf, key), nil)
 	wb.size += len(key)
 }
 
+// sizedMarshaler is implemented by the generated protobuf messages, which can be marshaled into a given buffer.
+type sizedMarshaler interface {
+	Size() int
+	MarshalTo([]byte) (int, error)
+}
+
+// SetMeta marshals msg into the arena of the batch, the value is valid until the batch is reset.
 func (wb *WriteBatch) SetMeta(key []byte, msg proto.Message) error {
-	val, err := proto.Marshal(msg)
+	val, err := wb.marshal(msg)
 	if err != nil {
 		return errors.WithStack(err)
 	}
-	wb.entries = append(wb.entries, &badger.Entry{
-		Key:   key,
-		Value: val,
-	})
+	wb.addEntry(key, val)
 	wb.size += len(key) + len(val)
 	return nil
 }
 
+func (wb *WriteBatch) marshal(msg proto.Message) ([]byte, error) {
+	m, ok := msg.(sizedMarshaler)
+	if !ok {
+		return proto.Marshal(msg)
+	}
+	size := m.Size()
+	if cap(wb.buf)-len(wb.buf) < size {
+		// the values in the old arena are still referenced by the entries, so it's replaced rather than grown
+		c := 2 * cap(wb.buf)
+		if c < size {
+			c = size
+		}
+		wb.buf = make([]byte, 0, c)
+	}
+	start := len(wb.buf)
+	n, err := m.MarshalTo(wb.buf[start : start+size])
+	if err != nil {
+		return nil, err
+	}
+	wb.buf = wb.buf[:start+n]
+	return wb.buf[start : start+n : start+n], nil
+}
+
+// TakeCF removes the entries of the column family from the batch, returning their keys and values. The value of a
+// delete is nil.
+func (wb *WriteBatch) TakeCF(cf string) (keys, values [][]byte) {
+	prefix := KeyWithCF(cf, nil)
+	safePoint := wb.safePoint
+	entries := wb.entries[:0]
+	for i, entry := range wb.entries {
+		if !bytes.HasPrefix(entry.Key, prefix) {
+			entries = append(entries, entry)
+			continue
+		}
+		keys = append(keys, entry.Key[len(prefix):])
+		if len(entry.Value) == 0 {
+			values = append(values, nil)
+		} else {
+			values = append(values, entry.Value)
+		}
+		size := len(entry.Key) - len(prefix) + len(entry.Value)
+		wb.size -= size
+		if i < safePoint {
+			wb.safePoint--
+			wb.safePointSize -= size
+		}
+	}
+	wb.entries = entries
+	return keys, values
+}
+
 func (wb *WriteBatch) SetSafePoint() {
 	wb.safePoint = len(wb.entries)
 	wb.safePointSize = wb.size
@@ -101,8 +173,18 @@ func (wb *WriteBatch) MustWriteToDB(db *badger.DB) {
 	}
 }
 
+// Reset clears the batch, keeping its buffers for the next writes. The entries and the values set by SetMeta must
+// not be used after the batch is reset.
 func (wb *WriteBatch) Reset() {
+	for i := range wb.entries {
+		wb.entries[i] = nil
+	}
 	wb.entries = wb.entries[:0]
+	for i := range wb.slab {
+		wb.slab[i] = badger.Entry{}
+	}
+	wb.slab = wb.slab[:0]
+	wb.buf = wb.buf[:0]
 	wb.size = 0
 	wb.safePoint = 0
 	wb.safePointSize = 0
@@ -0,0 +1,191 @@
+// Package failpoint injects failures at named points of the store for integration tests. A failpoint is evaluated
+// by the code at its point, and does nothing until it's enabled, e.g. by the FailPoint RPC of a store whose config
+// enables failpoints:
+//
+//	if err := failpoint.Inject("snapshot/apply"); err != nil {
+//		return err
+//	}
+//
+// A failpoint is enabled with actions of the form [count*]action, where the action is one of
+//
+//	sleep(ms)  sleep for the milliseconds before going on
+//	error      return an error, the code at the point fails as it would for a real error
+//	panic      panic
+//	skip       skip the step guarded by the point, e.g. an fsync, see Skipped
+//
+// and count limits the times the failpoint triggers, after which it's turned off.
+package failpoint
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pingcap/errors"
+)
+
+type actionType int
+
+const (
+	actionSleep actionType = iota
+	actionError
+	actionPanic
+	actionSkip
+)
+
+type failpoint struct {
+	actions string
+	tp      actionType
+	sleep   time.Duration
+	// the remaining times to trigger, negative if unlimited
+	remaining int
+}
+
+var (
+	mu         sync.Mutex
+	failpoints = make(map[string]*failpoint)
+	// the number of enabled failpoints, so the points are free while none is enabled
+	enabled int32
+)
+
+// Error is the error returned by a failpoint with the error action.
+type Error struct {
+	Name string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("injected failure by failpoint %s", e.Name)
+}
+
+func parse(actions string) (*failpoint, error) {
+	fp := &failpoint{actions: actions, remaining: -1}
+	action := actions
+	if i := strings.IndexByte(actions, '*'); i >= 0 {
+		count, err := strconv.Atoi(actions[:i])
+		if err != nil || count <= 0 {
+			return nil, errors.Errorf("invalid count in failpoint actions %q", actions)
+		}
+		fp.remaining = count
+		action = actions[i+1:]
+	}
+	switch {
+	case action == "error":
+		fp.tp = actionError
+	case action == "panic":
+		fp.tp = actionPanic
+	case action == "skip":
+		fp.tp = actionSkip
+	case strings.HasPrefix(action, "sleep(") && strings.HasSuffix(action, ")"):
+		ms, err := strconv.Atoi(action[len("sleep(") : len(action)-1])
+		if err != nil || ms < 0 {
+			return nil, errors.Errorf("invalid sleep in failpoint actions %q", actions)
+		}
+		fp.tp = actionSleep
+		fp.sleep = time.Duration(ms) * time.Millisecond
+	default:
+		return nil, errors.Errorf("unknown failpoint actions %q", actions)
+	}
+	return fp, nil
+}
+
+// Enable enables the failpoint with the actions, replacing its previous actions.
+func Enable(name, actions string) error {
+	fp, err := parse(actions)
+	if err != nil {
+		return err
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := failpoints[name]; !ok {
+		atomic.AddInt32(&enabled, 1)
+	}
+	failpoints[name] = fp
+	return nil
+}
+
+// Disable turns the failpoint off, it returns false if it isn't enabled.
+func Disable(name string) bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return disableLocked(name)
+}
+
+func disableLocked(name string) bool {
+	if _, ok := failpoints[name]; !ok {
+		return false
+	}
+	delete(failpoints, name)
+	atomic.AddInt32(&enabled, -1)
+	return true
+}
+
+// List returns the enabled failpoints and their actions, sorted by name.
+func List() [][2]string {
+	mu.Lock()
+	defer mu.Unlock()
+	list := make([][2]string, 0, len(failpoints))
+	for name, fp := range failpoints {
+		list = append(list, [2]string{name, fp.actions})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i][0] < list[j][0] })
+	return list
+}
+
+// eval returns the failpoint if it triggers, counting the trigger.
+func eval(name string) *failpoint {
+	if atomic.LoadInt32(&enabled) == 0 {
+		return nil
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	fp, ok := failpoints[name]
+	if !ok {
+		return nil
+	}
+	if fp.remaining > 0 {
+		fp.remaining--
+		if fp.remaining == 0 {
+			disableLocked(name)
+		}
+	}
+	return fp
+}
+
+// Inject evaluates the failpoint at its point. It sleeps for a sleep action, panics for a panic action and returns
+// an *Error for an error action. It returns nil if the failpoint isn't enabled or its action is skip.
+func Inject(name string) error {
+	fp := eval(name)
+	if fp == nil {
+		return nil
+	}
+	switch fp.tp {
+	case actionSleep:
+		time.Sleep(fp.sleep)
+	case actionError:
+		return &Error{Name: name}
+	case actionPanic:
+		panic(fmt.Sprintf("failpoint %s panic", name))
+	}
+	return nil
+}
+
+// Skipped evaluates the failpoint guarding a step which may be skipped, it returns true if the step should be
+// skipped. Actions other than skip are handled as by Inject, an error also skips the step.
+func Skipped(name string) bool {
+	fp := eval(name)
+	if fp == nil {
+		return false
+	}
+	switch fp.tp {
+	case actionSleep:
+		time.Sleep(fp.sleep)
+		return false
+	case actionPanic:
+		panic(fmt.Sprintf("failpoint %s panic", name))
+	}
+	return true
+}
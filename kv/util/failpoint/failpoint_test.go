@@ -0,0 +1,50 @@
+package failpoint
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFailPoint(t *testing.T) {
+	assert.Nil(t, Inject("test/a"))
+	assert.False(t, Skipped("test/a"))
+
+	assert.Nil(t, Enable("test/a", "error"))
+	err := Inject("test/a")
+	assert.Equal(t, &Error{Name: "test/a"}, err)
+	assert.True(t, Skipped("test/a"))
+	assert.Nil(t, Inject("test/b"))
+
+	assert.Nil(t, Enable("test/b", "sleep(20)"))
+	start := time.Now()
+	assert.Nil(t, Inject("test/b"))
+	assert.True(t, time.Since(start) >= 20*time.Millisecond)
+	assert.Equal(t, [][2]string{{"test/a", "error"}, {"test/b", "sleep(20)"}}, List())
+
+	assert.True(t, Disable("test/a"))
+	assert.False(t, Disable("test/a"))
+	assert.Nil(t, Inject("test/a"))
+
+	assert.Nil(t, Enable("test/b", "panic"))
+	assert.Panics(t, func() { Inject("test/b") })
+	assert.True(t, Disable("test/b"))
+	assert.Empty(t, List())
+}
+
+func TestFailPointCount(t *testing.T) {
+	assert.Nil(t, Enable("test/count", "2*skip"))
+	assert.True(t, Skipped("test/count"))
+	assert.Nil(t, Inject("test/count"))
+	// turned off after the count
+	assert.False(t, Skipped("test/count"))
+	assert.Empty(t, List())
+}
+
+func TestFailPointParse(t *testing.T) {
+	for _, actions := range []string{"", "crash", "0*error", "x*error", "sleep(x)", "sleep(-1)", "sleep(10"} {
+		assert.NotNil(t, Enable("test/parse", actions), actions)
+	}
+	assert.Empty(t, List())
+}
@@ -42,7 +42,7 @@ func (x EntryType) String() string {
 	return proto.EnumName(EntryType_name, int32(x))
 }
 func (EntryType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_eraftpb_2f2e0bcef614736b, []int{0}
+	return fileDescriptor_eraftpb_381ef6a5f920281a, []int{0}
 }
 
 // Some MessageType defined here are local messages which not come from the network, but should
@@ -77,6 +77,24 @@ const (
 	// 'MessageType_MsgTimeoutNow' send from the leader to the leadership transfer target, to let
 	// the transfer target timeout immediately and start a new election.
 	MessageType_MsgTimeoutNow MessageType = 12
+	// 'MessageType_MsgSnapStatus' reports the result of a snapshot to the leader. It's sent by the
+	// follower after the snapshot is applied, or reported locally when the snapshot can't be sent.
+	// 'index' is the index of the snapshot and 'reject' is set if the snapshot failed, then the
+	// leader retries with a fresh snapshot.
+	MessageType_MsgSnapStatus MessageType = 13
+	// 'MessageType_MsgReadIndex' asks the leader for the committed index a linearizable read must wait to be
+	// applied, see RawNode.ReadIndex. A follower forwards it to the leader. The request context is the data of
+	// the single entry.
+	MessageType_MsgReadIndex MessageType = 14
+	// 'MessageType_MsgReadIndexResp' returns the read index of a 'MessageType_MsgReadIndex' forwarded by a
+	// follower in 'index', with the entry of the request.
+	MessageType_MsgReadIndexResp MessageType = 15
+	// 'MessageType_MsgRequestPreVote' asks whether the sender could win an election of 'term', without changing
+	// the term of the receiver, see Config.PreVote.
+	MessageType_MsgRequestPreVote MessageType = 16
+	// 'MessageType_MsgRequestPreVoteResponse' is the response to 'MessageType_MsgRequestPreVote', the term of a
+	// granted pre-vote is the term of the request.
+	MessageType_MsgRequestPreVoteResponse MessageType = 17
 )
 
 var MessageType_name = map[int32]string{
@@ -92,50 +110,82 @@ var MessageType_name = map[int32]string{
 	9:  "MsgHeartbeatResponse",
 	11: "MsgTransferLeader",
 	12: "MsgTimeoutNow",
+	13: "MsgSnapStatus",
+	14: "MsgReadIndex",
+	15: "MsgReadIndexResp",
+	16: "MsgRequestPreVote",
+	17: "MsgRequestPreVoteResponse",
 }
 var MessageType_value = map[string]int32{
-	"MsgHup":                 0,
-	"MsgBeat":                1,
-	"MsgPropose":             2,
-	"MsgAppend":              3,
-	"MsgAppendResponse":      4,
-	"MsgRequestVote":         5,
-	"MsgRequestVoteResponse": 6,
-	"MsgSnapshot":            7,
-	"MsgHeartbeat":           8,
-	"MsgHeartbeatResponse":   9,
-	"MsgTransferLeader":      11,
-	"MsgTimeoutNow":          12,
+	"MsgHup":                    0,
+	"MsgBeat":                   1,
+	"MsgPropose":                2,
+	"MsgAppend":                 3,
+	"MsgAppendResponse":         4,
+	"MsgRequestVote":            5,
+	"MsgRequestVoteResponse":    6,
+	"MsgSnapshot":               7,
+	"MsgHeartbeat":              8,
+	"MsgHeartbeatResponse":      9,
+	"MsgTransferLeader":         11,
+	"MsgTimeoutNow":             12,
+	"MsgSnapStatus":             13,
+	"MsgReadIndex":              14,
+	"MsgReadIndexResp":          15,
+	"MsgRequestPreVote":         16,
+	"MsgRequestPreVoteResponse": 17,
 }
 
 func (x MessageType) String() string {
 	return proto.EnumName(MessageType_name, int32(x))
 }
 func (MessageType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_eraftpb_2f2e0bcef614736b, []int{1}
+	return fileDescriptor_eraftpb_381ef6a5f920281a, []int{1}
 }
 
 type ConfChangeType int32
 
 const (
+	// Add a voter, or promote a learner to a voter.
 	ConfChangeType_AddNode    ConfChangeType = 0
 	ConfChangeType_RemoveNode ConfChangeType = 1
+	// Enter the joint configuration of the current voters and 'configuration', in which the commits and the
+	// elections need a quorum of both, so several nodes can be added and removed at once.
+	ConfChangeType_BeginMembershipChange ConfChangeType = 2
+	// Leave the joint configuration for the 'configuration' of the begun change, proposed by the leader once
+	// the begin is applied.
+	ConfChangeType_FinalizeMembershipChange ConfChangeType = 3
+	// Add a learner, which is replicated to but neither votes nor counts towards the commit quorum, so a new
+	// replica catches up before it's promoted by an AddNode without weakening the group meanwhile.
+	ConfChangeType_AddLearnerNode ConfChangeType = 4
+	// Add a witness, a voter which is replicated the log to vote and count towards the commit quorum, but stores
+	// no data of the region and never becomes the leader, so two data centers reach an odd number of voters with
+	// a witness in a third one.
+	ConfChangeType_AddWitnessNode ConfChangeType = 5
 )
 
 var ConfChangeType_name = map[int32]string{
 	0: "AddNode",
 	1: "RemoveNode",
+	2: "BeginMembershipChange",
+	3: "FinalizeMembershipChange",
+	4: "AddLearnerNode",
+	5: "AddWitnessNode",
 }
 var ConfChangeType_value = map[string]int32{
-	"AddNode":    0,
-	"RemoveNode": 1,
+	"AddNode":                  0,
+	"RemoveNode":               1,
+	"BeginMembershipChange":    2,
+	"FinalizeMembershipChange": 3,
+	"AddLearnerNode":           4,
+	"AddWitnessNode":           5,
 }
 
 func (x ConfChangeType) String() string {
 	return proto.EnumName(ConfChangeType_name, int32(x))
 }
 func (ConfChangeType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_eraftpb_2f2e0bcef614736b, []int{2}
+	return fileDescriptor_eraftpb_381ef6a5f920281a, []int{2}
 }
 
 // The entry is a type of change that needs to be applied. It contains two data fields.
@@ -162,7 +212,7 @@ func (m *Entry) Reset()         { *m = Entry{} }
 func (m *Entry) String() string { return proto.CompactTextString(m) }
 func (*Entry) ProtoMessage()    {}
 func (*Entry) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eraftpb_2f2e0bcef614736b, []int{0}
+	return fileDescriptor_eraftpb_381ef6a5f920281a, []int{0}
 }
 func (m *Entry) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -234,7 +284,7 @@ func (m *SnapshotMetadata) Reset()         { *m = SnapshotMetadata{} }
 func (m *SnapshotMetadata) String() string { return proto.CompactTextString(m) }
 func (*SnapshotMetadata) ProtoMessage()    {}
 func (*SnapshotMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eraftpb_2f2e0bcef614736b, []int{1}
+	return fileDescriptor_eraftpb_381ef6a5f920281a, []int{1}
 }
 func (m *SnapshotMetadata) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -296,7 +346,7 @@ func (m *Snapshot) Reset()         { *m = Snapshot{} }
 func (m *Snapshot) String() string { return proto.CompactTextString(m) }
 func (*Snapshot) ProtoMessage()    {}
 func (*Snapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eraftpb_2f2e0bcef614736b, []int{2}
+	return fileDescriptor_eraftpb_381ef6a5f920281a, []int{2}
 }
 func (m *Snapshot) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -340,26 +390,29 @@ func (m *Snapshot) GetMetadata() *SnapshotMetadata {
 }
 
 type Message struct {
-	MsgType              MessageType `protobuf:"varint,1,opt,name=msg_type,json=msgType,proto3,enum=eraftpb.MessageType" json:"msg_type,omitempty"`
-	To                   uint64      `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
-	From                 uint64      `protobuf:"varint,3,opt,name=from,proto3" json:"from,omitempty"`
-	Term                 uint64      `protobuf:"varint,4,opt,name=term,proto3" json:"term,omitempty"`
-	LogTerm              uint64      `protobuf:"varint,5,opt,name=log_term,json=logTerm,proto3" json:"log_term,omitempty"`
-	Index                uint64      `protobuf:"varint,6,opt,name=index,proto3" json:"index,omitempty"`
-	Entries              []*Entry    `protobuf:"bytes,7,rep,name=entries" json:"entries,omitempty"`
-	Commit               uint64      `protobuf:"varint,8,opt,name=commit,proto3" json:"commit,omitempty"`
-	Snapshot             *Snapshot   `protobuf:"bytes,9,opt,name=snapshot" json:"snapshot,omitempty"`
-	Reject               bool        `protobuf:"varint,10,opt,name=reject,proto3" json:"reject,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+	MsgType  MessageType `protobuf:"varint,1,opt,name=msg_type,json=msgType,proto3,enum=eraftpb.MessageType" json:"msg_type,omitempty"`
+	To       uint64      `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
+	From     uint64      `protobuf:"varint,3,opt,name=from,proto3" json:"from,omitempty"`
+	Term     uint64      `protobuf:"varint,4,opt,name=term,proto3" json:"term,omitempty"`
+	LogTerm  uint64      `protobuf:"varint,5,opt,name=log_term,json=logTerm,proto3" json:"log_term,omitempty"`
+	Index    uint64      `protobuf:"varint,6,opt,name=index,proto3" json:"index,omitempty"`
+	Entries  []*Entry    `protobuf:"bytes,7,rep,name=entries" json:"entries,omitempty"`
+	Commit   uint64      `protobuf:"varint,8,opt,name=commit,proto3" json:"commit,omitempty"`
+	Snapshot *Snapshot   `protobuf:"bytes,9,opt,name=snapshot" json:"snapshot,omitempty"`
+	Reject   bool        `protobuf:"varint,10,opt,name=reject,proto3" json:"reject,omitempty"`
+	// The context of the latest pending read index request of the leader, carried by heartbeats and their
+	// responses to confirm the leadership for the reads.
+	Context              []byte   `protobuf:"bytes,11,opt,name=context,proto3" json:"context,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Message) Reset()         { *m = Message{} }
 func (m *Message) String() string { return proto.CompactTextString(m) }
 func (*Message) ProtoMessage()    {}
 func (*Message) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eraftpb_2f2e0bcef614736b, []int{3}
+	return fileDescriptor_eraftpb_381ef6a5f920281a, []int{3}
 }
 func (m *Message) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -458,6 +511,13 @@ func (m *Message) GetReject() bool {
 	return false
 }
 
+func (m *Message) GetContext() []byte {
+	if m != nil {
+		return m.Context
+	}
+	return nil
+}
+
 // HardState contains the state of a node need to be peristed, including the current term, commit index
 // and the vote record
 type HardState struct {
@@ -473,7 +533,7 @@ func (m *HardState) Reset()         { *m = HardState{} }
 func (m *HardState) String() string { return proto.CompactTextString(m) }
 func (*HardState) ProtoMessage()    {}
 func (*HardState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eraftpb_2f2e0bcef614736b, []int{4}
+	return fileDescriptor_eraftpb_381ef6a5f920281a, []int{4}
 }
 func (m *HardState) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -525,8 +585,12 @@ func (m *HardState) GetCommit() uint64 {
 
 // ConfState contains the current membership information of the raft group
 type ConfState struct {
-	// all node id
-	Nodes                []uint64 `protobuf:"varint,1,rep,packed,name=nodes" json:"nodes,omitempty"`
+	// all voter node id
+	Nodes []uint64 `protobuf:"varint,1,rep,packed,name=nodes" json:"nodes,omitempty"`
+	// the learner node id, which are replicated to but don't vote
+	Learners []uint64 `protobuf:"varint,2,rep,packed,name=learners" json:"learners,omitempty"`
+	// the witness node id, which are voters in nodes too
+	Witnesses            []uint64 `protobuf:"varint,3,rep,packed,name=witnesses" json:"witnesses,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -536,7 +600,7 @@ func (m *ConfState) Reset()         { *m = ConfState{} }
 func (m *ConfState) String() string { return proto.CompactTextString(m) }
 func (*ConfState) ProtoMessage()    {}
 func (*ConfState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eraftpb_2f2e0bcef614736b, []int{5}
+	return fileDescriptor_eraftpb_381ef6a5f920281a, []int{5}
 }
 func (m *ConfState) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -572,22 +636,38 @@ func (m *ConfState) GetNodes() []uint64 {
 	return nil
 }
 
+func (m *ConfState) GetLearners() []uint64 {
+	if m != nil {
+		return m.Learners
+	}
+	return nil
+}
+
+func (m *ConfState) GetWitnesses() []uint64 {
+	if m != nil {
+		return m.Witnesses
+	}
+	return nil
+}
+
 // ConfChange is the data that attach on entry with EntryConfChange type
 type ConfChange struct {
 	ChangeType ConfChangeType `protobuf:"varint,1,opt,name=change_type,json=changeType,proto3,enum=eraftpb.ConfChangeType" json:"change_type,omitempty"`
 	// node will be add/remove
-	NodeId               uint64   `protobuf:"varint,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
-	Context              []byte   `protobuf:"bytes,3,opt,name=context,proto3" json:"context,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	NodeId  uint64 `protobuf:"varint,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Context []byte `protobuf:"bytes,3,opt,name=context,proto3" json:"context,omitempty"`
+	// The voters after a BeginMembershipChange.
+	Configuration        *ConfState `protobuf:"bytes,4,opt,name=configuration" json:"configuration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
 func (m *ConfChange) Reset()         { *m = ConfChange{} }
 func (m *ConfChange) String() string { return proto.CompactTextString(m) }
 func (*ConfChange) ProtoMessage()    {}
 func (*ConfChange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eraftpb_2f2e0bcef614736b, []int{6}
+	return fileDescriptor_eraftpb_381ef6a5f920281a, []int{6}
 }
 func (m *ConfChange) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -637,6 +717,13 @@ func (m *ConfChange) GetContext() []byte {
 	return nil
 }
 
+func (m *ConfChange) GetConfiguration() *ConfState {
+	if m != nil {
+		return m.Configuration
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Entry)(nil), "eraftpb.Entry")
 	proto.RegisterType((*SnapshotMetadata)(nil), "eraftpb.SnapshotMetadata")
@@ -851,6 +938,12 @@ func (m *Message) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i++
 	}
+	if len(m.Context) > 0 {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintEraftpb(dAtA, i, uint64(len(m.Context)))
+		i += copy(dAtA[i:], m.Context)
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -925,6 +1018,40 @@ func (m *ConfState) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintEraftpb(dAtA, i, uint64(j4))
 		i += copy(dAtA[i:], dAtA5[:j4])
 	}
+	if len(m.Learners) > 0 {
+		dAtA7 := make([]byte, len(m.Learners)*10)
+		var j6 int
+		for _, num := range m.Learners {
+			for num >= 1<<7 {
+				dAtA7[j6] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j6++
+			}
+			dAtA7[j6] = uint8(num)
+			j6++
+		}
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintEraftpb(dAtA, i, uint64(j6))
+		i += copy(dAtA[i:], dAtA7[:j6])
+	}
+	if len(m.Witnesses) > 0 {
+		dAtA9 := make([]byte, len(m.Witnesses)*10)
+		var j8 int
+		for _, num := range m.Witnesses {
+			for num >= 1<<7 {
+				dAtA9[j8] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j8++
+			}
+			dAtA9[j8] = uint8(num)
+			j8++
+		}
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintEraftpb(dAtA, i, uint64(j8))
+		i += copy(dAtA[i:], dAtA9[:j8])
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -962,6 +1089,16 @@ func (m *ConfChange) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintEraftpb(dAtA, i, uint64(len(m.Context)))
 		i += copy(dAtA[i:], m.Context)
 	}
+	if m.Configuration != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintEraftpb(dAtA, i, uint64(m.Configuration.Size()))
+		n10, err := m.Configuration.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n10
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -1072,6 +1209,10 @@ func (m *Message) Size() (n int) {
 	if m.Reject {
 		n += 2
 	}
+	l = len(m.Context)
+	if l > 0 {
+		n += 1 + l + sovEraftpb(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1106,6 +1247,20 @@ func (m *ConfState) Size() (n int) {
 		}
 		n += 1 + sovEraftpb(uint64(l)) + l
 	}
+	if len(m.Learners) > 0 {
+		l = 0
+		for _, e := range m.Learners {
+			l += sovEraftpb(uint64(e))
+		}
+		n += 1 + sovEraftpb(uint64(l)) + l
+	}
+	if len(m.Witnesses) > 0 {
+		l = 0
+		for _, e := range m.Witnesses {
+			l += sovEraftpb(uint64(e))
+		}
+		n += 1 + sovEraftpb(uint64(l)) + l
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1125,6 +1280,10 @@ func (m *ConfChange) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovEraftpb(uint64(l))
 	}
+	if m.Configuration != nil {
+		l = m.Configuration.Size()
+		n += 1 + l + sovEraftpb(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1766,6 +1925,37 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.Reject = bool(v != 0)
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Context", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEraftpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthEraftpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Context = append(m.Context[:0], dAtA[iNdEx:postIndex]...)
+			if m.Context == nil {
+				m.Context = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEraftpb(dAtA[iNdEx:])
@@ -1987,6 +2177,130 @@ func (m *ConfState) Unmarshal(dAtA []byte) error {
 			} else {
 				return fmt.Errorf("proto: wrong wireType = %d for field Nodes", wireType)
 			}
+		case 2:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowEraftpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Learners = append(m.Learners, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowEraftpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthEraftpb
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowEraftpb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Learners = append(m.Learners, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Learners", wireType)
+			}
+		case 3:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowEraftpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Witnesses = append(m.Witnesses, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowEraftpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthEraftpb
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowEraftpb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Witnesses = append(m.Witnesses, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Witnesses", wireType)
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEraftpb(dAtA[iNdEx:])
@@ -2107,6 +2421,39 @@ func (m *ConfChange) Unmarshal(dAtA []byte) error {
 				m.Context = []byte{}
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Configuration", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEraftpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEraftpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Configuration == nil {
+				m.Configuration = &ConfState{}
+			}
+			if err := m.Configuration.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEraftpb(dAtA[iNdEx:])
@@ -2234,50 +2581,59 @@ var (
 	ErrIntOverflowEraftpb   = fmt.Errorf("proto: integer overflow")
 )
 
-func init() { proto.RegisterFile("eraftpb.proto", fileDescriptor_eraftpb_2f2e0bcef614736b) }
-
-var fileDescriptor_eraftpb_2f2e0bcef614736b = []byte{
-	// 666 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x94, 0x5f, 0x4e, 0xdb, 0x4e,
-	0x10, 0xc7, 0xb3, 0xf9, 0x67, 0x7b, 0x1c, 0xc2, 0xb2, 0x3f, 0x7e, 0x60, 0xfa, 0x10, 0xa5, 0x7e,
-	0x8a, 0x90, 0xa0, 0x82, 0xaa, 0x52, 0x5f, 0x01, 0x55, 0xa2, 0x6a, 0x8d, 0x2a, 0x43, 0xfb, 0x1a,
-	0x2d, 0xf6, 0xc4, 0xa4, 0xc2, 0x5e, 0xd7, 0xbb, 0x50, 0x72, 0x93, 0x1e, 0xa2, 0x07, 0xe9, 0x63,
-	0x8f, 0x50, 0xd1, 0x23, 0xf4, 0x02, 0xd5, 0x6e, 0x6c, 0xc7, 0xa1, 0x6f, 0xf3, 0x1d, 0xcf, 0xee,
-	0x7c, 0xe6, 0x3b, 0x9b, 0xc0, 0x06, 0x16, 0x7c, 0xa6, 0xf2, 0xeb, 0xc3, 0xbc, 0x10, 0x4a, 0x30,
-	0xab, 0x94, 0xfe, 0x03, 0xf4, 0xde, 0x64, 0xaa, 0x58, 0xb0, 0x23, 0x00, 0xd4, 0xc1, 0x54, 0x2d,
-	0x72, 0xf4, 0xc8, 0x98, 0x4c, 0x86, 0xc7, 0xec, 0xb0, 0x3a, 0x65, 0x6a, 0xae, 0x16, 0x39, 0x86,
-	0x0e, 0x56, 0x21, 0x63, 0xd0, 0x55, 0x58, 0xa4, 0x5e, 0x7b, 0x4c, 0x26, 0xdd, 0xd0, 0xc4, 0x6c,
-	0x1b, 0x7a, 0xf3, 0x2c, 0xc6, 0x07, 0xaf, 0x63, 0x92, 0x4b, 0xa1, 0x2b, 0x63, 0xae, 0xb8, 0xd7,
-	0x1d, 0x93, 0xc9, 0x20, 0x34, 0xb1, 0x2f, 0x80, 0x5e, 0x66, 0x3c, 0x97, 0x37, 0x42, 0x05, 0xa8,
-	0xb8, 0xce, 0x69, 0x88, 0x48, 0x64, 0xb3, 0xa9, 0x54, 0x5c, 0x2d, 0x21, 0xdc, 0x06, 0xc4, 0x99,
-	0xc8, 0x66, 0x97, 0xfa, 0x4b, 0xe8, 0x44, 0x55, 0xb8, 0x6a, 0xd8, 0x7e, 0xd2, 0xd0, 0xa0, 0x75,
-	0x56, 0x68, 0xfe, 0x47, 0xb0, 0xab, 0x86, 0x35, 0x10, 0x59, 0x01, 0xb1, 0x57, 0x60, 0xa7, 0x25,
-	0x88, 0xb9, 0xcc, 0x3d, 0xde, 0xab, 0x5b, 0x3f, 0x25, 0x0d, 0xeb, 0x52, 0xff, 0x7b, 0x1b, 0xac,
-	0x00, 0xa5, 0xe4, 0x09, 0xb2, 0x17, 0x60, 0xa7, 0x32, 0x69, 0x5a, 0xb8, 0x5d, 0x5f, 0x51, 0xd6,
-	0x18, 0x13, 0xad, 0x54, 0x26, 0xc6, 0xc2, 0x21, 0xb4, 0x95, 0x28, 0xd1, 0xdb, 0x4a, 0x68, 0xae,
-	0x59, 0x21, 0x6a, 0x6e, 0x1d, 0xd7, 0xb3, 0x74, 0x1b, 0x36, 0xef, 0x81, 0x7d, 0x2b, 0x92, 0xa9,
-	0xc9, 0xf7, 0x4c, 0xde, 0xba, 0x15, 0xc9, 0xd5, 0xda, 0x06, 0xfa, 0x4d, 0x43, 0x26, 0x60, 0xe9,
-	0xc5, 0xcd, 0x51, 0x7a, 0xd6, 0xb8, 0x33, 0x71, 0x8f, 0x87, 0xeb, 0xbb, 0x0d, 0xab, 0xcf, 0x6c,
-	0x07, 0xfa, 0x91, 0x48, 0xd3, 0xb9, 0xf2, 0x6c, 0x73, 0x41, 0xa9, 0xd8, 0x01, 0xd8, 0xb2, 0x74,
-	0xc1, 0x73, 0x8c, 0x3d, 0x5b, 0xff, 0xd8, 0x13, 0xd6, 0x25, 0xfa, 0x9a, 0x02, 0x3f, 0x63, 0xa4,
-	0x3c, 0x18, 0x93, 0x89, 0x1d, 0x96, 0xca, 0x7f, 0x07, 0xce, 0x39, 0x2f, 0xe2, 0xe5, 0xf2, 0xaa,
-	0xd1, 0x48, 0x63, 0x34, 0x06, 0xdd, 0x7b, 0xa1, 0xb0, 0x7a, 0x55, 0x3a, 0x6e, 0x30, 0x75, 0x9a,
-	0x4c, 0xfe, 0x73, 0x70, 0xce, 0x9a, 0x2f, 0x21, 0x13, 0x31, 0x4a, 0x8f, 0x8c, 0x3b, 0x7a, 0x70,
-	0x23, 0xfc, 0x05, 0x80, 0x2e, 0x39, 0xbb, 0xe1, 0x59, 0x82, 0xec, 0x35, 0xb8, 0x91, 0x89, 0x9a,
-	0x3b, 0xda, 0x5d, 0x7b, 0x61, 0xcb, 0x4a, 0xb3, 0x26, 0x88, 0xea, 0x98, 0xed, 0x82, 0xa5, 0x2f,
-	0x9c, 0xce, 0xe3, 0x92, 0xac, 0xaf, 0xe5, 0xdb, 0x98, 0x79, 0x60, 0x45, 0x22, 0x53, 0xf8, 0xb0,
-	0x84, 0x1b, 0x84, 0x95, 0xdc, 0x3f, 0x02, 0xa7, 0xfe, 0xdd, 0xb0, 0x4d, 0x70, 0x8d, 0xb8, 0x10,
-	0x45, 0xca, 0x6f, 0x69, 0x8b, 0xfd, 0x07, 0x9b, 0x26, 0xb1, 0xea, 0x49, 0xc9, 0xfe, 0x1f, 0x02,
-	0x6e, 0xe3, 0xa1, 0x30, 0x80, 0x7e, 0x20, 0x93, 0xf3, 0xbb, 0x9c, 0xb6, 0x98, 0x0b, 0x56, 0x20,
-	0x93, 0x53, 0xe4, 0x8a, 0x12, 0x36, 0x04, 0x08, 0x64, 0xf2, 0xa1, 0x10, 0xb9, 0x90, 0x48, 0xdb,
-	0x6c, 0x03, 0x9c, 0x40, 0x26, 0x27, 0x79, 0x8e, 0x59, 0x4c, 0x3b, 0xec, 0x7f, 0xd8, 0xaa, 0x65,
-	0x88, 0x32, 0x17, 0x99, 0x44, 0xda, 0x65, 0x0c, 0x86, 0x81, 0x4c, 0x42, 0xfc, 0x72, 0x87, 0x52,
-	0x7d, 0x12, 0x0a, 0x69, 0x8f, 0x3d, 0x83, 0x9d, 0xf5, 0x5c, 0x5d, 0xdf, 0xd7, 0xd0, 0x81, 0x4c,
-	0xaa, 0xed, 0x52, 0x8b, 0x51, 0x18, 0x68, 0x1e, 0xe4, 0x85, 0xba, 0xd6, 0x20, 0x36, 0xf3, 0x60,
-	0xbb, 0x99, 0xa9, 0x0f, 0x3b, 0x25, 0xc3, 0x55, 0xc1, 0x33, 0x39, 0xc3, 0xe2, 0x3d, 0xf2, 0x18,
-	0x0b, 0xea, 0xb2, 0x2d, 0xd8, 0xd0, 0xe9, 0x79, 0x8a, 0xe2, 0x4e, 0x5d, 0x88, 0xaf, 0x74, 0xb0,
-	0x7f, 0x00, 0xc3, 0x75, 0xe7, 0xf5, 0xac, 0x27, 0x71, 0x7c, 0x21, 0x62, 0xa4, 0x2d, 0x3d, 0x6b,
-	0x88, 0xa9, 0xb8, 0x47, 0xa3, 0xc9, 0x29, 0xfd, 0xf1, 0x38, 0x22, 0x3f, 0x1f, 0x47, 0xe4, 0xd7,
-	0xe3, 0x88, 0x7c, 0xfb, 0x3d, 0x6a, 0x5d, 0xf7, 0xcd, 0xbf, 0xda, 0xcb, 0xbf, 0x01, 0x00, 0x00,
-	0xff, 0xff, 0x2c, 0x70, 0xc6, 0xf6, 0xe6, 0x04, 0x00, 0x00,
+func init() { proto.RegisterFile("eraftpb.proto", fileDescriptor_eraftpb_381ef6a5f920281a) }
+
+var fileDescriptor_eraftpb_381ef6a5f920281a = []byte{
+	// 813 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x55, 0xc1, 0x6e, 0xdb, 0x46,
+	0x10, 0x35, 0x45, 0x49, 0x24, 0x87, 0x96, 0xbc, 0x9e, 0x3a, 0x09, 0x1d, 0xa4, 0x86, 0xa0, 0x93,
+	0x60, 0xa0, 0x29, 0xe2, 0xa2, 0x40, 0xae, 0xb6, 0xd1, 0x22, 0x41, 0x43, 0x23, 0x60, 0xdc, 0xf6,
+	0xd0, 0x83, 0xb1, 0x16, 0x47, 0x34, 0x0b, 0x91, 0xcb, 0xee, 0xae, 0x12, 0xbb, 0x1f, 0xd1, 0x73,
+	0xff, 0xa3, 0xff, 0x50, 0xf4, 0xd8, 0x4f, 0x28, 0xdc, 0x7e, 0x48, 0xb1, 0x2b, 0x92, 0xa2, 0x62,
+	0xe4, 0x36, 0xef, 0xed, 0x70, 0xe6, 0xed, 0x9b, 0x59, 0x10, 0x46, 0x24, 0xf9, 0x42, 0x57, 0xd7,
+	0xcf, 0x2b, 0x29, 0xb4, 0x40, 0xaf, 0x86, 0xd3, 0x5b, 0x18, 0x7c, 0x53, 0x6a, 0x79, 0x87, 0x2f,
+	0x00, 0xc8, 0x04, 0x57, 0xfa, 0xae, 0xa2, 0xc8, 0x99, 0x38, 0xb3, 0xf1, 0x09, 0x3e, 0x6f, 0xbe,
+	0xb2, 0x39, 0x97, 0x77, 0x15, 0x25, 0x01, 0x35, 0x21, 0x22, 0xf4, 0x35, 0xc9, 0x22, 0xea, 0x4d,
+	0x9c, 0x59, 0x3f, 0xb1, 0x31, 0x1e, 0xc0, 0x20, 0x2f, 0x53, 0xba, 0x8d, 0x5c, 0x4b, 0xae, 0x81,
+	0xc9, 0x4c, 0xb9, 0xe6, 0x51, 0x7f, 0xe2, 0xcc, 0x76, 0x13, 0x1b, 0x4f, 0x05, 0xb0, 0x77, 0x25,
+	0xaf, 0xd4, 0x8d, 0xd0, 0x31, 0x69, 0x6e, 0x38, 0x23, 0x62, 0x2e, 0xca, 0xc5, 0x95, 0xd2, 0x5c,
+	0xaf, 0x45, 0x84, 0x1d, 0x11, 0xe7, 0xa2, 0x5c, 0xbc, 0x33, 0x27, 0x49, 0x30, 0x6f, 0xc2, 0x4d,
+	0xc3, 0xde, 0x47, 0x0d, 0xad, 0x34, 0x77, 0x23, 0x6d, 0xfa, 0x3d, 0xf8, 0x4d, 0xc3, 0x56, 0x90,
+	0xb3, 0x11, 0x84, 0x5f, 0x83, 0x5f, 0xd4, 0x42, 0x6c, 0xb1, 0xf0, 0xe4, 0xb0, 0x6d, 0xfd, 0xb1,
+	0xd2, 0xa4, 0x4d, 0x9d, 0xfe, 0xd9, 0x03, 0x2f, 0x26, 0xa5, 0x78, 0x46, 0xf8, 0x25, 0xf8, 0x85,
+	0xca, 0xba, 0x16, 0x1e, 0xb4, 0x25, 0xea, 0x1c, 0x6b, 0xa2, 0x57, 0xa8, 0xcc, 0x04, 0x38, 0x86,
+	0x9e, 0x16, 0xb5, 0xf4, 0x9e, 0x16, 0x46, 0xd7, 0x42, 0x8a, 0x56, 0xb7, 0x89, 0xdb, 0xbb, 0xf4,
+	0x3b, 0x36, 0x1f, 0x82, 0xbf, 0x14, 0xd9, 0x95, 0xe5, 0x07, 0x96, 0xf7, 0x96, 0x22, 0xbb, 0xdc,
+	0x9a, 0xc0, 0xb0, 0x6b, 0xc8, 0x0c, 0x3c, 0x33, 0xb8, 0x9c, 0x54, 0xe4, 0x4d, 0xdc, 0x59, 0x78,
+	0x32, 0xde, 0x9e, 0x6d, 0xd2, 0x1c, 0xe3, 0x63, 0x18, 0xce, 0x45, 0x51, 0xe4, 0x3a, 0xf2, 0x6d,
+	0x81, 0x1a, 0xe1, 0x17, 0xe0, 0xab, 0xda, 0x85, 0x28, 0xb0, 0xf6, 0xec, 0x3f, 0xb0, 0x27, 0x69,
+	0x53, 0x4c, 0x19, 0x49, 0x3f, 0xd3, 0x5c, 0x47, 0x30, 0x71, 0x66, 0x7e, 0x52, 0x23, 0x8c, 0xc0,
+	0x9b, 0x8b, 0x52, 0xd3, 0xad, 0x8e, 0x42, 0x6b, 0x7e, 0x03, 0xa7, 0xdf, 0x41, 0xf0, 0x8a, 0xcb,
+	0x74, 0x3d, 0xd6, 0xe6, 0xd2, 0x4e, 0xe7, 0xd2, 0x08, 0xfd, 0xf7, 0x42, 0x53, 0xb3, 0x6f, 0x26,
+	0xee, 0xa8, 0x75, 0xbb, 0x6a, 0xa7, 0x3f, 0x41, 0x70, 0xde, 0xdd, 0x91, 0x52, 0xa4, 0xa4, 0x22,
+	0x67, 0xe2, 0x1a, 0x4b, 0x2c, 0xc0, 0xa7, 0xe0, 0x2f, 0x89, 0xcb, 0x92, 0xa4, 0x8a, 0x7a, 0xf6,
+	0xa0, 0xc5, 0xf8, 0x0c, 0x82, 0x0f, 0xb9, 0x2e, 0x49, 0x29, 0x52, 0x91, 0x6b, 0x0f, 0x37, 0xc4,
+	0xf4, 0x0f, 0x07, 0xc0, 0x54, 0x3f, 0xbf, 0xe1, 0x65, 0x46, 0xf8, 0x12, 0xc2, 0xb9, 0x8d, 0xba,
+	0x83, 0x7f, 0xb2, 0xb5, 0xb6, 0xeb, 0x4c, 0x3b, 0x7b, 0x98, 0xb7, 0x31, 0x3e, 0x01, 0xcf, 0x68,
+	0xb9, 0xca, 0xd3, 0xfa, 0x52, 0x43, 0x03, 0x5f, 0xa7, 0x5d, 0x97, 0xdc, 0x2d, 0x97, 0xf0, 0x25,
+	0x8c, 0xcc, 0xf2, 0xe7, 0xd9, 0x4a, 0x72, 0x9d, 0x8b, 0x32, 0xea, 0x7f, 0xf2, 0x95, 0x6c, 0x27,
+	0x1e, 0xbf, 0x80, 0xa0, 0x7d, 0xc6, 0xb8, 0x07, 0xa1, 0x05, 0x17, 0x42, 0x16, 0x7c, 0xc9, 0x76,
+	0xf0, 0x33, 0xd8, 0xb3, 0xc4, 0x46, 0x2d, 0x73, 0x8e, 0xff, 0xeb, 0x41, 0xd8, 0xd9, 0x5b, 0x04,
+	0x18, 0xc6, 0x2a, 0x7b, 0xb5, 0xaa, 0xd8, 0x0e, 0x86, 0xe0, 0xc5, 0x2a, 0x3b, 0x23, 0xae, 0x99,
+	0x83, 0x63, 0x80, 0x58, 0x65, 0x6f, 0xa5, 0xa8, 0x84, 0x22, 0xd6, 0xc3, 0x11, 0x04, 0xb1, 0xca,
+	0x4e, 0xab, 0x8a, 0xca, 0x94, 0xb9, 0xf8, 0x08, 0xf6, 0x5b, 0x98, 0x90, 0xaa, 0x44, 0xa9, 0x88,
+	0xf5, 0x11, 0x61, 0x1c, 0xab, 0x2c, 0xa1, 0x5f, 0x56, 0xa4, 0xf4, 0x0f, 0x42, 0x13, 0x1b, 0xe0,
+	0x53, 0x78, 0xbc, 0xcd, 0xb5, 0xf9, 0x43, 0x23, 0x3a, 0x56, 0x59, 0xb3, 0x6c, 0xcc, 0x43, 0x06,
+	0xbb, 0x46, 0x0f, 0x71, 0xa9, 0xaf, 0x8d, 0x10, 0x1f, 0x23, 0x38, 0xe8, 0x32, 0xed, 0xc7, 0x41,
+	0xad, 0xe1, 0x52, 0xf2, 0x52, 0x2d, 0x48, 0xbe, 0x21, 0x9e, 0x92, 0x64, 0x21, 0xee, 0xc3, 0xc8,
+	0xd0, 0x79, 0x41, 0x62, 0xa5, 0x2f, 0xc4, 0x07, 0xb6, 0x5b, 0x53, 0xa6, 0x8d, 0xf1, 0x71, 0xa5,
+	0xd8, 0xa8, 0x6e, 0x94, 0x10, 0x4f, 0x5f, 0x9b, 0xe7, 0xc4, 0xc6, 0x78, 0x00, 0xac, 0xcb, 0x98,
+	0x46, 0x6c, 0xaf, 0x6e, 0x52, 0xab, 0x7f, 0x2b, 0xc9, 0x5e, 0x8a, 0xe1, 0xe7, 0x70, 0xf8, 0x80,
+	0x6e, 0xa5, 0xed, 0x1f, 0xff, 0xe6, 0xc0, 0x78, 0x7b, 0x4b, 0x8c, 0xbb, 0xa7, 0x69, 0x7a, 0x21,
+	0x52, 0x62, 0x3b, 0xc6, 0xdd, 0x84, 0x0a, 0xf1, 0x9e, 0x2c, 0x76, 0xf0, 0x10, 0x1e, 0x9d, 0x51,
+	0x96, 0x97, 0x31, 0x15, 0xd7, 0x24, 0xd5, 0x4d, 0x5e, 0xd5, 0x13, 0xeb, 0xe1, 0x33, 0x88, 0xbe,
+	0xcd, 0x4b, 0xbe, 0xcc, 0x7f, 0xa5, 0x07, 0xa7, 0xae, 0x31, 0xfc, 0x34, 0x4d, 0xdf, 0xac, 0xb7,
+	0xdc, 0x16, 0xeb, 0xd7, 0xdc, 0x8f, 0xeb, 0xe5, 0xb6, 0xdc, 0xe0, 0x8c, 0xfd, 0x75, 0x7f, 0xe4,
+	0xfc, 0x7d, 0x7f, 0xe4, 0xfc, 0x73, 0x7f, 0xe4, 0xfc, 0xfe, 0xef, 0xd1, 0xce, 0xf5, 0xd0, 0xfe,
+	0x37, 0xbe, 0xfa, 0x7f, 0x00, 0x41, 0x4e, 0x1a, 0xa7, 0x48, 0x06, 0x00, 0x00,
 }
@@ -47,7 +47,7 @@ func (x StoreState) String() string {
 	return proto.EnumName(StoreState_name, int32(x))
 }
 func (StoreState) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_metapb_33de520265e54ab4, []int{0}
+	return fileDescriptor_metapb_c28b75a67806a1d9, []int{0}
 }
 
 type Cluster struct {
@@ -64,7 +64,7 @@ func (m *Cluster) Reset()         { *m = Cluster{} }
 func (m *Cluster) String() string { return proto.CompactTextString(m) }
 func (*Cluster) ProtoMessage()    {}
 func (*Cluster) Descriptor() ([]byte, []int) {
-	return fileDescriptor_metapb_33de520265e54ab4, []int{0}
+	return fileDescriptor_metapb_c28b75a67806a1d9, []int{0}
 }
 func (m *Cluster) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -110,18 +110,21 @@ func (m *Cluster) GetMaxPeerCount() uint32 {
 type Store struct {
 	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	// Address to handle client requests (kv, cop, etc.)
-	Address              string     `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
-	State                StoreState `protobuf:"varint,3,opt,name=state,proto3,enum=metapb.StoreState" json:"state,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	Address string     `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	State   StoreState `protobuf:"varint,3,opt,name=state,proto3,enum=metapb.StoreState" json:"state,omitempty"`
+	// Issued to the store from the cluster's ID allocator when it's bootstrapped, the scheduler refuses a store
+	// registering with a token other than the one it first registered with.
+	FencingToken         uint64   `protobuf:"varint,4,opt,name=fencing_token,json=fencingToken,proto3" json:"fencing_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Store) Reset()         { *m = Store{} }
 func (m *Store) String() string { return proto.CompactTextString(m) }
 func (*Store) ProtoMessage()    {}
 func (*Store) Descriptor() ([]byte, []int) {
-	return fileDescriptor_metapb_33de520265e54ab4, []int{1}
+	return fileDescriptor_metapb_c28b75a67806a1d9, []int{1}
 }
 func (m *Store) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -171,6 +174,13 @@ func (m *Store) GetState() StoreState {
 	return StoreState_Up
 }
 
+func (m *Store) GetFencingToken() uint64 {
+	if m != nil {
+		return m.FencingToken
+	}
+	return 0
+}
+
 type RegionEpoch struct {
 	// Conf change version, auto increment when add or remove peer
 	ConfVer uint64 `protobuf:"varint,1,opt,name=conf_ver,json=confVer,proto3" json:"conf_ver,omitempty"`
@@ -185,7 +195,7 @@ func (m *RegionEpoch) Reset()         { *m = RegionEpoch{} }
 func (m *RegionEpoch) String() string { return proto.CompactTextString(m) }
 func (*RegionEpoch) ProtoMessage()    {}
 func (*RegionEpoch) Descriptor() ([]byte, []int) {
-	return fileDescriptor_metapb_33de520265e54ab4, []int{2}
+	return fileDescriptor_metapb_c28b75a67806a1d9, []int{2}
 }
 func (m *RegionEpoch) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -244,7 +254,7 @@ func (m *Region) Reset()         { *m = Region{} }
 func (m *Region) String() string { return proto.CompactTextString(m) }
 func (*Region) ProtoMessage()    {}
 func (*Region) Descriptor() ([]byte, []int) {
-	return fileDescriptor_metapb_33de520265e54ab4, []int{3}
+	return fileDescriptor_metapb_c28b75a67806a1d9, []int{3}
 }
 func (m *Region) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -309,8 +319,12 @@ func (m *Region) GetPeers() []*Peer {
 }
 
 type Peer struct {
-	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	StoreId              uint64   `protobuf:"varint,2,opt,name=store_id,json=storeId,proto3" json:"store_id,omitempty"`
+	Id      uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	StoreId uint64 `protobuf:"varint,2,opt,name=store_id,json=storeId,proto3" json:"store_id,omitempty"`
+	// A learner is replicated to but doesn't vote, see ConfChangeType.AddLearnerNode.
+	IsLearner bool `protobuf:"varint,3,opt,name=is_learner,json=isLearner,proto3" json:"is_learner,omitempty"`
+	// A witness votes but stores no data and never becomes the leader, see ConfChangeType.AddWitnessNode.
+	IsWitness            bool     `protobuf:"varint,4,opt,name=is_witness,json=isWitness,proto3" json:"is_witness,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -320,7 +334,7 @@ func (m *Peer) Reset()         { *m = Peer{} }
 func (m *Peer) String() string { return proto.CompactTextString(m) }
 func (*Peer) ProtoMessage()    {}
 func (*Peer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_metapb_33de520265e54ab4, []int{4}
+	return fileDescriptor_metapb_c28b75a67806a1d9, []int{4}
 }
 func (m *Peer) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -363,6 +377,20 @@ func (m *Peer) GetStoreId() uint64 {
 	return 0
 }
 
+func (m *Peer) GetIsLearner() bool {
+	if m != nil {
+		return m.IsLearner
+	}
+	return false
+}
+
+func (m *Peer) GetIsWitness() bool {
+	if m != nil {
+		return m.IsWitness
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*Cluster)(nil), "metapb.Cluster")
 	proto.RegisterType((*Store)(nil), "metapb.Store")
@@ -433,6 +461,11 @@ func (m *Store) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintMetapb(dAtA, i, uint64(m.State))
 	}
+	if m.FencingToken != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.FencingToken))
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -555,6 +588,26 @@ func (m *Peer) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintMetapb(dAtA, i, uint64(m.StoreId))
 	}
+	if m.IsLearner {
+		dAtA[i] = 0x18
+		i++
+		if m.IsLearner {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.IsWitness {
+		dAtA[i] = 0x20
+		i++
+		if m.IsWitness {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -598,6 +651,9 @@ func (m *Store) Size() (n int) {
 	if m.State != 0 {
 		n += 1 + sovMetapb(uint64(m.State))
 	}
+	if m.FencingToken != 0 {
+		n += 1 + sovMetapb(uint64(m.FencingToken))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -658,6 +714,12 @@ func (m *Peer) Size() (n int) {
 	if m.StoreId != 0 {
 		n += 1 + sovMetapb(uint64(m.StoreId))
 	}
+	if m.IsLearner {
+		n += 2
+	}
+	if m.IsWitness {
+		n += 2
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -862,6 +924,25 @@ func (m *Store) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FencingToken", wireType)
+			}
+			m.FencingToken = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FencingToken |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMetapb(dAtA[iNdEx:])
@@ -1236,6 +1317,46 @@ func (m *Peer) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsLearner", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IsLearner = bool(v != 0)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsWitness", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IsWitness = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMetapb(dAtA[iNdEx:])
@@ -1363,33 +1484,36 @@ var (
 	ErrIntOverflowMetapb   = fmt.Errorf("proto: integer overflow")
 )
 
-func init() { proto.RegisterFile("metapb.proto", fileDescriptor_metapb_33de520265e54ab4) }
-
-var fileDescriptor_metapb_33de520265e54ab4 = []byte{
-	// 390 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x52, 0xdd, 0x8a, 0xd3, 0x40,
-	0x18, 0xdd, 0x49, 0xf3, 0xd3, 0x7e, 0xc9, 0x96, 0x30, 0x0a, 0x66, 0x15, 0x42, 0x08, 0x5e, 0x04,
-	0x2f, 0x56, 0xad, 0xe0, 0xad, 0xb0, 0x8b, 0x17, 0xe2, 0x85, 0x32, 0xab, 0xde, 0x78, 0x11, 0xd2,
-	0xce, 0xd7, 0x1a, 0xdc, 0xcc, 0x84, 0x99, 0xd9, 0xa5, 0x7d, 0x13, 0x9f, 0xc1, 0x27, 0xf1, 0xd2,
-	0x47, 0x90, 0xfa, 0x22, 0x32, 0x93, 0x06, 0x0b, 0xbd, 0xcb, 0xf9, 0x4e, 0xce, 0x77, 0xce, 0x77,
-	0x18, 0x48, 0x3a, 0x34, 0x4d, 0xbf, 0xbc, 0xec, 0x95, 0x34, 0x92, 0x86, 0x03, 0x7a, 0xfc, 0x70,
-	0x23, 0x37, 0xd2, 0x8d, 0x9e, 0xdb, 0xaf, 0x81, 0x2d, 0xdf, 0x40, 0x74, 0x7d, 0x7b, 0xa7, 0x0d,
-	0x2a, 0x3a, 0x07, 0xaf, 0xe5, 0x19, 0x29, 0x48, 0xe5, 0x33, 0xaf, 0xe5, 0xf4, 0x29, 0xcc, 0xbb,
-	0x66, 0x5b, 0xf7, 0x88, 0xaa, 0x5e, 0xc9, 0x3b, 0x61, 0x32, 0xaf, 0x20, 0xd5, 0x39, 0x4b, 0xba,
-	0x66, 0xfb, 0x11, 0x51, 0x5d, 0xdb, 0x59, 0xf9, 0x15, 0x82, 0x1b, 0x23, 0x15, 0x9e, 0xc8, 0x33,
-	0x88, 0x1a, 0xce, 0x15, 0x6a, 0xed, 0x74, 0x33, 0x36, 0x42, 0x5a, 0x41, 0xa0, 0x4d, 0x63, 0x30,
-	0x9b, 0x14, 0xa4, 0x9a, 0x2f, 0xe8, 0xe5, 0x21, 0xaf, 0xdb, 0x73, 0x63, 0x19, 0x36, 0xfc, 0x50,
-	0x5e, 0x41, 0xcc, 0x70, 0xd3, 0x4a, 0xf1, 0xb6, 0x97, 0xab, 0x6f, 0xf4, 0x02, 0xa6, 0x2b, 0x29,
-	0xd6, 0xf5, 0x3d, 0xaa, 0x83, 0x51, 0x64, 0xf1, 0x17, 0x54, 0xd6, 0xed, 0x1e, 0x95, 0x6e, 0xa5,
-	0x70, 0x6e, 0x3e, 0x1b, 0x61, 0xf9, 0x93, 0x40, 0x38, 0x2c, 0x39, 0x89, 0xf8, 0x04, 0x66, 0xda,
-	0x34, 0xca, 0xd4, 0xdf, 0x71, 0xe7, 0x64, 0x09, 0x9b, 0xba, 0xc1, 0x7b, 0xdc, 0xd1, 0x47, 0x10,
-	0xa1, 0xe0, 0x8e, 0x9a, 0x38, 0x2a, 0x44, 0xc1, 0x2d, 0xf1, 0x1a, 0x12, 0xe5, 0xf6, 0xd5, 0x68,
-	0x53, 0x65, 0x7e, 0x41, 0xaa, 0x78, 0xf1, 0x60, 0xbc, 0xe2, 0x28, 0x30, 0x8b, 0xd5, 0x51, 0xfa,
-	0x12, 0x02, 0xdb, 0xa5, 0xce, 0x82, 0x62, 0x52, 0xc5, 0x8b, 0x64, 0x14, 0xd8, 0x2e, 0xd9, 0x40,
-	0x95, 0x2f, 0xc1, 0xb7, 0xf0, 0x24, 0xe9, 0x05, 0x4c, 0xb5, 0x6d, 0xa7, 0x6e, 0xf9, 0x78, 0x9f,
-	0xc3, 0xef, 0xf8, 0xb3, 0x17, 0x00, 0xff, 0x8b, 0xa3, 0x21, 0x78, 0x9f, 0xfb, 0xf4, 0x8c, 0xc6,
-	0x10, 0x7d, 0x58, 0xaf, 0x6f, 0x5b, 0x81, 0x29, 0xa1, 0xe7, 0x30, 0xfb, 0x24, 0xbb, 0xa5, 0x36,
-	0x52, 0x60, 0xea, 0x5d, 0xa5, 0xbf, 0xf6, 0x39, 0xf9, 0xbd, 0xcf, 0xc9, 0x9f, 0x7d, 0x4e, 0x7e,
-	0xfc, 0xcd, 0xcf, 0x96, 0xa1, 0x7b, 0x0c, 0xaf, 0xfe, 0x05, 0x00, 0x00, 0xff, 0xff, 0x77, 0xf7,
-	0x52, 0x80, 0x3a, 0x02, 0x00, 0x00,
+func init() { proto.RegisterFile("metapb.proto", fileDescriptor_metapb_c28b75a67806a1d9) }
+
+var fileDescriptor_metapb_c28b75a67806a1d9 = []byte{
+	// 436 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x92, 0xc1, 0x8e, 0xd3, 0x30,
+	0x10, 0x86, 0xd7, 0x69, 0x9a, 0xb4, 0x93, 0xb4, 0xaa, 0x0c, 0x12, 0x59, 0x10, 0x55, 0x15, 0x38,
+	0x44, 0x1c, 0x16, 0x54, 0x24, 0xae, 0x48, 0xbb, 0xe2, 0x80, 0x40, 0x02, 0x79, 0x17, 0x38, 0x5a,
+	0x69, 0x3d, 0x2d, 0xd6, 0xb6, 0x76, 0x64, 0x7b, 0x97, 0xdd, 0x1b, 0x8f, 0xc1, 0x33, 0xf0, 0x24,
+	0x1c, 0x79, 0x04, 0x54, 0x5e, 0x04, 0xd9, 0x69, 0xb4, 0x48, 0xbd, 0xe5, 0xff, 0xff, 0xcc, 0xe8,
+	0x9b, 0x19, 0x43, 0xbe, 0x45, 0x57, 0x37, 0x8b, 0x93, 0xc6, 0x68, 0xa7, 0x69, 0xd2, 0xaa, 0x87,
+	0xf7, 0xd7, 0x7a, 0xad, 0x83, 0xf5, 0xdc, 0x7f, 0xb5, 0x69, 0xf9, 0x1a, 0xd2, 0xb3, 0xcd, 0x95,
+	0x75, 0x68, 0xe8, 0x18, 0x22, 0x29, 0x0a, 0x32, 0x23, 0x55, 0xcc, 0x22, 0x29, 0xe8, 0x53, 0x18,
+	0x6f, 0xeb, 0x1b, 0xde, 0x20, 0x1a, 0xbe, 0xd4, 0x57, 0xca, 0x15, 0xd1, 0x8c, 0x54, 0x23, 0x96,
+	0x6f, 0xeb, 0x9b, 0x8f, 0x88, 0xe6, 0xcc, 0x7b, 0xe5, 0x77, 0x02, 0xfd, 0x73, 0xa7, 0x0d, 0x1e,
+	0xd4, 0x17, 0x90, 0xd6, 0x42, 0x18, 0xb4, 0x36, 0x14, 0x0e, 0x59, 0x27, 0x69, 0x05, 0x7d, 0xeb,
+	0x6a, 0x87, 0x45, 0x6f, 0x46, 0xaa, 0xf1, 0x9c, 0x9e, 0xec, 0x81, 0x43, 0x9f, 0x73, 0x9f, 0xb0,
+	0xf6, 0x07, 0xfa, 0x04, 0x46, 0x2b, 0x54, 0x4b, 0xa9, 0xd6, 0xdc, 0xe9, 0x4b, 0x54, 0x45, 0x1c,
+	0xda, 0xe7, 0x7b, 0xf3, 0xc2, 0x7b, 0xe5, 0x29, 0x64, 0x0c, 0xd7, 0x52, 0xab, 0x37, 0x8d, 0x5e,
+	0x7e, 0xa5, 0xc7, 0x30, 0x58, 0x6a, 0xb5, 0xe2, 0xd7, 0x68, 0xf6, 0x34, 0xa9, 0xd7, 0x9f, 0xd1,
+	0x78, 0xa4, 0x6b, 0x34, 0x56, 0x6a, 0x15, 0x90, 0x62, 0xd6, 0xc9, 0xf2, 0x27, 0x81, 0xa4, 0x6d,
+	0x72, 0x30, 0xc7, 0x23, 0x18, 0x5a, 0x57, 0x1b, 0xc7, 0x2f, 0xf1, 0x36, 0x94, 0xe5, 0x6c, 0x10,
+	0x8c, 0x77, 0x78, 0x4b, 0x1f, 0x40, 0x8a, 0x4a, 0x84, 0xa8, 0x17, 0xa2, 0x04, 0x95, 0xf0, 0xc1,
+	0x2b, 0xc8, 0x4d, 0xe8, 0xc7, 0xd1, 0x53, 0x05, 0xf0, 0x6c, 0x7e, 0xaf, 0x1b, 0xf5, 0x3f, 0x60,
+	0x96, 0x99, 0x3b, 0x41, 0x4b, 0xe8, 0xfb, 0x8d, 0xdb, 0xa2, 0x3f, 0xeb, 0x55, 0xd9, 0x3c, 0xef,
+	0x0a, 0xfc, 0xc6, 0x59, 0x1b, 0x95, 0x1a, 0x62, 0x2f, 0x0f, 0x48, 0x8f, 0x61, 0x60, 0xfd, 0x0a,
+	0xb9, 0x14, 0xdd, 0x7c, 0x41, 0xbf, 0x15, 0xf4, 0x31, 0x80, 0xb4, 0x7c, 0x83, 0xb5, 0x51, 0x68,
+	0x02, 0xea, 0x80, 0x0d, 0xa5, 0x7d, 0xdf, 0x1a, 0xfb, 0xf8, 0x9b, 0x74, 0xca, 0x9f, 0x2b, 0xee,
+	0xe2, 0x2f, 0xad, 0xf1, 0xec, 0x05, 0xc0, 0xdd, 0x6d, 0x68, 0x02, 0xd1, 0xa7, 0x66, 0x72, 0x44,
+	0x33, 0x48, 0x3f, 0xac, 0x56, 0x1b, 0xa9, 0x70, 0x42, 0xe8, 0x08, 0x86, 0x17, 0x7a, 0xbb, 0xb0,
+	0x4e, 0x2b, 0x9c, 0x44, 0xa7, 0x93, 0x5f, 0xbb, 0x29, 0xf9, 0xbd, 0x9b, 0x92, 0x3f, 0xbb, 0x29,
+	0xf9, 0xf1, 0x77, 0x7a, 0xb4, 0x48, 0xc2, 0x83, 0x7b, 0xf9, 0x6f, 0x00, 0x68, 0x19, 0x2a, 0x99,
+	0x9e, 0x02, 0x00, 0x00,
 }
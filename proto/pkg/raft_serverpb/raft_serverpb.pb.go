@@ -11,6 +11,7 @@ import (
 	proto "github.com/golang/protobuf/proto"
 
 	eraftpb "github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+
 	metapb "github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
 )
 
@@ -32,22 +33,26 @@ type PeerState int32
 const (
 	PeerState_Normal    PeerState = 0
 	PeerState_Tombstone PeerState = 2
+	// The region applied a PrepareMerge, see MergeState.
+	PeerState_Merging PeerState = 3
 )
 
 var PeerState_name = map[int32]string{
 	0: "Normal",
 	2: "Tombstone",
+	3: "Merging",
 }
 var PeerState_value = map[string]int32{
 	"Normal":    0,
 	"Tombstone": 2,
+	"Merging":   3,
 }
 
 func (x PeerState) String() string {
 	return proto.EnumName(PeerState_name, int32(x))
 }
 func (PeerState) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_raft_serverpb_9d4bf28a94e26664, []int{0}
+	return fileDescriptor_raft_serverpb_bd195e7effb233ce, []int{0}
 }
 
 // The message sent between Raft peer, it wraps the raft meessage with some meta information.
@@ -60,18 +65,29 @@ type RaftMessage struct {
 	// true means to_peer is a tombstone peer and it should remove itself.
 	IsTombstone bool `protobuf:"varint,6,opt,name=is_tombstone,json=isTombstone,proto3" json:"is_tombstone,omitempty"`
 	// Region key range [start_key, end_key). (Used in 3B)
-	StartKey             []byte   `protobuf:"bytes,7,opt,name=start_key,json=startKey,proto3" json:"start_key,omitempty"`
-	EndKey               []byte   `protobuf:"bytes,8,opt,name=end_key,json=endKey,proto3" json:"end_key,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	StartKey []byte `protobuf:"bytes,7,opt,name=start_key,json=startKey,proto3" json:"start_key,omitempty"`
+	EndKey   []byte `protobuf:"bytes,8,opt,name=end_key,json=endKey,proto3" json:"end_key,omitempty"`
+	// Set when the leader asks to_peer to send a snapshot on its behalf,
+	// message is empty in this case.
+	SnapshotDelegation *SnapshotDelegation `protobuf:"bytes,9,opt,name=snapshot_delegation,json=snapshotDelegation" json:"snapshot_delegation,omitempty"`
+	// Set on the snapshot status a follower reports to the leader after
+	// applying a snapshot, the leader includes it in the next region
+	// heartbeat.
+	SnapshotApplied *SnapshotApplied `protobuf:"bytes,10,opt,name=snapshot_applied,json=snapshotApplied" json:"snapshot_applied,omitempty"`
+	// Set when a peer re-added to a store which kept the data of the removed
+	// peer asks the leader to verify it, and on the reply, message is empty
+	// in this case.
+	CheckpointCatchUp    *CheckpointCatchUp `protobuf:"bytes,11,opt,name=checkpoint_catch_up,json=checkpointCatchUp" json:"checkpoint_catch_up,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *RaftMessage) Reset()         { *m = RaftMessage{} }
 func (m *RaftMessage) String() string { return proto.CompactTextString(m) }
 func (*RaftMessage) ProtoMessage()    {}
 func (*RaftMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_serverpb_9d4bf28a94e26664, []int{0}
+	return fileDescriptor_raft_serverpb_bd195e7effb233ce, []int{0}
 }
 func (m *RaftMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -156,6 +172,310 @@ func (m *RaftMessage) GetEndKey() []byte {
 	return nil
 }
 
+func (m *RaftMessage) GetSnapshotDelegation() *SnapshotDelegation {
+	if m != nil {
+		return m.SnapshotDelegation
+	}
+	return nil
+}
+
+func (m *RaftMessage) GetSnapshotApplied() *SnapshotApplied {
+	if m != nil {
+		return m.SnapshotApplied
+	}
+	return nil
+}
+
+func (m *RaftMessage) GetCheckpointCatchUp() *CheckpointCatchUp {
+	if m != nil {
+		return m.CheckpointCatchUp
+	}
+	return nil
+}
+
+// A re-added peer asks the leader whether the data kept from a removed peer
+// of the region is consistent with the leader's log, so it catches up by
+// replaying the log after the checkpoint instead of applying a snapshot.
+type CheckpointCatchUp struct {
+	Index uint64 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Term  uint64 `protobuf:"varint,2,opt,name=term,proto3" json:"term,omitempty"`
+	// The region of the checkpoint in the request, the leader's region in
+	// the response.
+	Region   *metapb.Region `protobuf:"bytes,3,opt,name=region" json:"region,omitempty"`
+	Response bool           `protobuf:"varint,4,opt,name=response,proto3" json:"response,omitempty"`
+	// Set in the response if the log after the checkpoint can be replayed.
+	Accepted             bool     `protobuf:"varint,5,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointCatchUp) Reset()         { *m = CheckpointCatchUp{} }
+func (m *CheckpointCatchUp) String() string { return proto.CompactTextString(m) }
+func (*CheckpointCatchUp) ProtoMessage()    {}
+func (*CheckpointCatchUp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_raft_serverpb_bd195e7effb233ce, []int{1}
+}
+func (m *CheckpointCatchUp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CheckpointCatchUp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CheckpointCatchUp.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *CheckpointCatchUp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointCatchUp.Merge(dst, src)
+}
+func (m *CheckpointCatchUp) XXX_Size() int {
+	return m.Size()
+}
+func (m *CheckpointCatchUp) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointCatchUp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointCatchUp proto.InternalMessageInfo
+
+func (m *CheckpointCatchUp) GetIndex() uint64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *CheckpointCatchUp) GetTerm() uint64 {
+	if m != nil {
+		return m.Term
+	}
+	return 0
+}
+
+func (m *CheckpointCatchUp) GetRegion() *metapb.Region {
+	if m != nil {
+		return m.Region
+	}
+	return nil
+}
+
+func (m *CheckpointCatchUp) GetResponse() bool {
+	if m != nil {
+		return m.Response
+	}
+	return false
+}
+
+func (m *CheckpointCatchUp) GetAccepted() bool {
+	if m != nil {
+		return m.Accepted
+	}
+	return false
+}
+
+// A snapshot a peer has applied.
+type SnapshotApplied struct {
+	Index uint64 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Term  uint64 `protobuf:"varint,2,opt,name=term,proto3" json:"term,omitempty"`
+	// The time from the snapshot being received to being applied.
+	ApplyDurationMs      uint64   `protobuf:"varint,3,opt,name=apply_duration_ms,json=applyDurationMs,proto3" json:"apply_duration_ms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotApplied) Reset()         { *m = SnapshotApplied{} }
+func (m *SnapshotApplied) String() string { return proto.CompactTextString(m) }
+func (*SnapshotApplied) ProtoMessage()    {}
+func (*SnapshotApplied) Descriptor() ([]byte, []int) {
+	return fileDescriptor_raft_serverpb_bd195e7effb233ce, []int{2}
+}
+func (m *SnapshotApplied) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SnapshotApplied) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SnapshotApplied.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *SnapshotApplied) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotApplied.Merge(dst, src)
+}
+func (m *SnapshotApplied) XXX_Size() int {
+	return m.Size()
+}
+func (m *SnapshotApplied) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotApplied.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotApplied proto.InternalMessageInfo
+
+func (m *SnapshotApplied) GetIndex() uint64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *SnapshotApplied) GetTerm() uint64 {
+	if m != nil {
+		return m.Term
+	}
+	return 0
+}
+
+func (m *SnapshotApplied) GetApplyDurationMs() uint64 {
+	if m != nil {
+		return m.ApplyDurationMs
+	}
+	return 0
+}
+
+// The leader delegates generating and sending the snapshot for a lagging peer
+// to a follower, so that the leader doesn't bear the snapshot IO.
+type SnapshotDelegation struct {
+	// The peer which needs the snapshot.
+	Target *metapb.Peer `protobuf:"bytes,1,opt,name=target" json:"target,omitempty"`
+	// The term of the leader, the snapshot is sent in the name of the leader.
+	Term                 uint64   `protobuf:"varint,2,opt,name=term,proto3" json:"term,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotDelegation) Reset()         { *m = SnapshotDelegation{} }
+func (m *SnapshotDelegation) String() string { return proto.CompactTextString(m) }
+func (*SnapshotDelegation) ProtoMessage()    {}
+func (*SnapshotDelegation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_raft_serverpb_bd195e7effb233ce, []int{3}
+}
+func (m *SnapshotDelegation) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SnapshotDelegation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SnapshotDelegation.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *SnapshotDelegation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotDelegation.Merge(dst, src)
+}
+func (m *SnapshotDelegation) XXX_Size() int {
+	return m.Size()
+}
+func (m *SnapshotDelegation) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotDelegation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotDelegation proto.InternalMessageInfo
+
+func (m *SnapshotDelegation) GetTarget() *metapb.Peer {
+	if m != nil {
+		return m.Target
+	}
+	return nil
+}
+
+func (m *SnapshotDelegation) GetTerm() uint64 {
+	if m != nil {
+		return m.Term
+	}
+	return 0
+}
+
+// The applied state of a removed peer whose data is kept on the store for a
+// while, see CheckpointCatchUp.
+type RegionCheckpoint struct {
+	Region       *metapb.Region `protobuf:"bytes,1,opt,name=region" json:"region,omitempty"`
+	AppliedIndex uint64         `protobuf:"varint,2,opt,name=applied_index,json=appliedIndex,proto3" json:"applied_index,omitempty"`
+	AppliedTerm  uint64         `protobuf:"varint,3,opt,name=applied_term,json=appliedTerm,proto3" json:"applied_term,omitempty"`
+	// The unix time in nanoseconds after which the data is deleted.
+	ExpireAt             int64    `protobuf:"varint,4,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RegionCheckpoint) Reset()         { *m = RegionCheckpoint{} }
+func (m *RegionCheckpoint) String() string { return proto.CompactTextString(m) }
+func (*RegionCheckpoint) ProtoMessage()    {}
+func (*RegionCheckpoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_raft_serverpb_bd195e7effb233ce, []int{4}
+}
+func (m *RegionCheckpoint) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RegionCheckpoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RegionCheckpoint.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *RegionCheckpoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegionCheckpoint.Merge(dst, src)
+}
+func (m *RegionCheckpoint) XXX_Size() int {
+	return m.Size()
+}
+func (m *RegionCheckpoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegionCheckpoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RegionCheckpoint proto.InternalMessageInfo
+
+func (m *RegionCheckpoint) GetRegion() *metapb.Region {
+	if m != nil {
+		return m.Region
+	}
+	return nil
+}
+
+func (m *RegionCheckpoint) GetAppliedIndex() uint64 {
+	if m != nil {
+		return m.AppliedIndex
+	}
+	return 0
+}
+
+func (m *RegionCheckpoint) GetAppliedTerm() uint64 {
+	if m != nil {
+		return m.AppliedTerm
+	}
+	return 0
+}
+
+func (m *RegionCheckpoint) GetExpireAt() int64 {
+	if m != nil {
+		return m.ExpireAt
+	}
+	return 0
+}
+
 // Used to store the persistent state for Raft, including the hard state for raft and the last index of the raft log.
 type RaftLocalState struct {
 	HardState            *eraftpb.HardState `protobuf:"bytes,1,opt,name=hard_state,json=hardState" json:"hard_state,omitempty"`
@@ -170,7 +490,7 @@ func (m *RaftLocalState) Reset()         { *m = RaftLocalState{} }
 func (m *RaftLocalState) String() string { return proto.CompactTextString(m) }
 func (*RaftLocalState) ProtoMessage()    {}
 func (*RaftLocalState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_serverpb_9d4bf28a94e26664, []int{1}
+	return fileDescriptor_raft_serverpb_bd195e7effb233ce, []int{5}
 }
 func (m *RaftLocalState) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -236,7 +556,7 @@ func (m *RaftApplyState) Reset()         { *m = RaftApplyState{} }
 func (m *RaftApplyState) String() string { return proto.CompactTextString(m) }
 func (*RaftApplyState) ProtoMessage()    {}
 func (*RaftApplyState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_serverpb_9d4bf28a94e26664, []int{2}
+	return fileDescriptor_raft_serverpb_bd195e7effb233ce, []int{6}
 }
 func (m *RaftApplyState) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -292,7 +612,7 @@ func (m *RaftTruncatedState) Reset()         { *m = RaftTruncatedState{} }
 func (m *RaftTruncatedState) String() string { return proto.CompactTextString(m) }
 func (*RaftTruncatedState) ProtoMessage()    {}
 func (*RaftTruncatedState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_serverpb_9d4bf28a94e26664, []int{3}
+	return fileDescriptor_raft_serverpb_bd195e7effb233ce, []int{7}
 }
 func (m *RaftTruncatedState) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -337,18 +657,20 @@ func (m *RaftTruncatedState) GetTerm() uint64 {
 
 // Used to store Region information and the corresponding Peer state on this Store.
 type RegionLocalState struct {
-	State                PeerState      `protobuf:"varint,1,opt,name=state,proto3,enum=raft_serverpb.PeerState" json:"state,omitempty"`
-	Region               *metapb.Region `protobuf:"bytes,2,opt,name=region" json:"region,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+	State  PeerState      `protobuf:"varint,1,opt,name=state,proto3,enum=raft_serverpb.PeerState" json:"state,omitempty"`
+	Region *metapb.Region `protobuf:"bytes,2,opt,name=region" json:"region,omitempty"`
+	// The PrepareMerge applied, if the state is Merging.
+	MergeState           *MergeState `protobuf:"bytes,3,opt,name=merge_state,json=mergeState" json:"merge_state,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *RegionLocalState) Reset()         { *m = RegionLocalState{} }
 func (m *RegionLocalState) String() string { return proto.CompactTextString(m) }
 func (*RegionLocalState) ProtoMessage()    {}
 func (*RegionLocalState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_serverpb_9d4bf28a94e26664, []int{4}
+	return fileDescriptor_raft_serverpb_bd195e7effb233ce, []int{8}
 }
 func (m *RegionLocalState) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -391,28 +713,35 @@ func (m *RegionLocalState) GetRegion() *metapb.Region {
 	return nil
 }
 
-// The persistent identification for Store.
-// It used to recover the store id after restart.
-type StoreIdent struct {
-	ClusterId            uint64   `protobuf:"varint,1,opt,name=cluster_id,json=clusterId,proto3" json:"cluster_id,omitempty"`
-	StoreId              uint64   `protobuf:"varint,2,opt,name=store_id,json=storeId,proto3" json:"store_id,omitempty"`
+func (m *RegionLocalState) GetMergeState() *MergeState {
+	if m != nil {
+		return m.MergeState
+	}
+	return nil
+}
+
+type MergeState struct {
+	MinIndex uint64         `protobuf:"varint,1,opt,name=min_index,json=minIndex,proto3" json:"min_index,omitempty"`
+	Target   *metapb.Region `protobuf:"bytes,2,opt,name=target" json:"target,omitempty"`
+	// The index of the PrepareMerge.
+	Commit               uint64   `protobuf:"varint,3,opt,name=commit,proto3" json:"commit,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *StoreIdent) Reset()         { *m = StoreIdent{} }
-func (m *StoreIdent) String() string { return proto.CompactTextString(m) }
-func (*StoreIdent) ProtoMessage()    {}
-func (*StoreIdent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_serverpb_9d4bf28a94e26664, []int{5}
+func (m *MergeState) Reset()         { *m = MergeState{} }
+func (m *MergeState) String() string { return proto.CompactTextString(m) }
+func (*MergeState) ProtoMessage()    {}
+func (*MergeState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_raft_serverpb_bd195e7effb233ce, []int{9}
 }
-func (m *StoreIdent) XXX_Unmarshal(b []byte) error {
+func (m *MergeState) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *StoreIdent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *MergeState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_StoreIdent.Marshal(b, m, deterministic)
+		return xxx_messageInfo_MergeState.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -422,54 +751,60 @@ func (m *StoreIdent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (dst *StoreIdent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_StoreIdent.Merge(dst, src)
+func (dst *MergeState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MergeState.Merge(dst, src)
 }
-func (m *StoreIdent) XXX_Size() int {
+func (m *MergeState) XXX_Size() int {
 	return m.Size()
 }
-func (m *StoreIdent) XXX_DiscardUnknown() {
-	xxx_messageInfo_StoreIdent.DiscardUnknown(m)
+func (m *MergeState) XXX_DiscardUnknown() {
+	xxx_messageInfo_MergeState.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_StoreIdent proto.InternalMessageInfo
+var xxx_messageInfo_MergeState proto.InternalMessageInfo
 
-func (m *StoreIdent) GetClusterId() uint64 {
+func (m *MergeState) GetMinIndex() uint64 {
 	if m != nil {
-		return m.ClusterId
+		return m.MinIndex
 	}
 	return 0
 }
 
-func (m *StoreIdent) GetStoreId() uint64 {
+func (m *MergeState) GetTarget() *metapb.Region {
 	if m != nil {
-		return m.StoreId
+		return m.Target
+	}
+	return nil
+}
+
+func (m *MergeState) GetCommit() uint64 {
+	if m != nil {
+		return m.Commit
 	}
 	return 0
 }
 
-// Snapshot sending and reciveing related messages.
-// Not included in the course scope.
-type KeyValue struct {
-	Key                  []byte   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Value                []byte   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+// The applied index the lock CF of a Region is persisted at, when the lock CF
+// is kept in memory. The lock CF is recovered from it by replaying the raft log.
+type LockCheckpoint struct {
+	Index                uint64   `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *KeyValue) Reset()         { *m = KeyValue{} }
-func (m *KeyValue) String() string { return proto.CompactTextString(m) }
-func (*KeyValue) ProtoMessage()    {}
-func (*KeyValue) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_serverpb_9d4bf28a94e26664, []int{6}
+func (m *LockCheckpoint) Reset()         { *m = LockCheckpoint{} }
+func (m *LockCheckpoint) String() string { return proto.CompactTextString(m) }
+func (*LockCheckpoint) ProtoMessage()    {}
+func (*LockCheckpoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_raft_serverpb_bd195e7effb233ce, []int{10}
 }
-func (m *KeyValue) XXX_Unmarshal(b []byte) error {
+func (m *LockCheckpoint) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *KeyValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *LockCheckpoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_KeyValue.Marshal(b, m, deterministic)
+		return xxx_messageInfo_LockCheckpoint.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -479,27 +814,143 @@ func (m *KeyValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (dst *KeyValue) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_KeyValue.Merge(dst, src)
+func (dst *LockCheckpoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LockCheckpoint.Merge(dst, src)
 }
-func (m *KeyValue) XXX_Size() int {
+func (m *LockCheckpoint) XXX_Size() int {
 	return m.Size()
 }
-func (m *KeyValue) XXX_DiscardUnknown() {
-	xxx_messageInfo_KeyValue.DiscardUnknown(m)
+func (m *LockCheckpoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_LockCheckpoint.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_KeyValue proto.InternalMessageInfo
+var xxx_messageInfo_LockCheckpoint proto.InternalMessageInfo
 
-func (m *KeyValue) GetKey() []byte {
+func (m *LockCheckpoint) GetIndex() uint64 {
 	if m != nil {
-		return m.Key
+		return m.Index
 	}
-	return nil
+	return 0
 }
 
-func (m *KeyValue) GetValue() []byte {
-	if m != nil {
+// The persistent identification for Store.
+// It used to recover the store id after restart.
+type StoreIdent struct {
+	ClusterId uint64 `protobuf:"varint,1,opt,name=cluster_id,json=clusterId,proto3" json:"cluster_id,omitempty"`
+	StoreId   uint64 `protobuf:"varint,2,opt,name=store_id,json=storeId,proto3" json:"store_id,omitempty"`
+	// The token the store registers to the scheduler with, 0 if the store hasn't got one yet.
+	FencingToken         uint64   `protobuf:"varint,3,opt,name=fencing_token,json=fencingToken,proto3" json:"fencing_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StoreIdent) Reset()         { *m = StoreIdent{} }
+func (m *StoreIdent) String() string { return proto.CompactTextString(m) }
+func (*StoreIdent) ProtoMessage()    {}
+func (*StoreIdent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_raft_serverpb_bd195e7effb233ce, []int{11}
+}
+func (m *StoreIdent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StoreIdent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StoreIdent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *StoreIdent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StoreIdent.Merge(dst, src)
+}
+func (m *StoreIdent) XXX_Size() int {
+	return m.Size()
+}
+func (m *StoreIdent) XXX_DiscardUnknown() {
+	xxx_messageInfo_StoreIdent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StoreIdent proto.InternalMessageInfo
+
+func (m *StoreIdent) GetClusterId() uint64 {
+	if m != nil {
+		return m.ClusterId
+	}
+	return 0
+}
+
+func (m *StoreIdent) GetStoreId() uint64 {
+	if m != nil {
+		return m.StoreId
+	}
+	return 0
+}
+
+func (m *StoreIdent) GetFencingToken() uint64 {
+	if m != nil {
+		return m.FencingToken
+	}
+	return 0
+}
+
+// Snapshot sending and reciveing related messages.
+// Not included in the course scope.
+type KeyValue struct {
+	Key                  []byte   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value                []byte   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KeyValue) Reset()         { *m = KeyValue{} }
+func (m *KeyValue) String() string { return proto.CompactTextString(m) }
+func (*KeyValue) ProtoMessage()    {}
+func (*KeyValue) Descriptor() ([]byte, []int) {
+	return fileDescriptor_raft_serverpb_bd195e7effb233ce, []int{12}
+}
+func (m *KeyValue) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KeyValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KeyValue.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *KeyValue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeyValue.Merge(dst, src)
+}
+func (m *KeyValue) XXX_Size() int {
+	return m.Size()
+}
+func (m *KeyValue) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeyValue.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeyValue proto.InternalMessageInfo
+
+func (m *KeyValue) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *KeyValue) GetValue() []byte {
+	if m != nil {
 		return m.Value
 	}
 	return nil
@@ -519,7 +970,7 @@ func (m *RaftSnapshotData) Reset()         { *m = RaftSnapshotData{} }
 func (m *RaftSnapshotData) String() string { return proto.CompactTextString(m) }
 func (*RaftSnapshotData) ProtoMessage()    {}
 func (*RaftSnapshotData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_serverpb_9d4bf28a94e26664, []int{7}
+	return fileDescriptor_raft_serverpb_bd195e7effb233ce, []int{13}
 }
 func (m *RaftSnapshotData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -589,7 +1040,7 @@ func (m *SnapshotCFFile) Reset()         { *m = SnapshotCFFile{} }
 func (m *SnapshotCFFile) String() string { return proto.CompactTextString(m) }
 func (*SnapshotCFFile) ProtoMessage()    {}
 func (*SnapshotCFFile) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_serverpb_9d4bf28a94e26664, []int{8}
+	return fileDescriptor_raft_serverpb_bd195e7effb233ce, []int{14}
 }
 func (m *SnapshotCFFile) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -650,7 +1101,7 @@ func (m *SnapshotMeta) Reset()         { *m = SnapshotMeta{} }
 func (m *SnapshotMeta) String() string { return proto.CompactTextString(m) }
 func (*SnapshotMeta) ProtoMessage()    {}
 func (*SnapshotMeta) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_serverpb_9d4bf28a94e26664, []int{9}
+	return fileDescriptor_raft_serverpb_bd195e7effb233ce, []int{15}
 }
 func (m *SnapshotMeta) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -686,26 +1137,27 @@ func (m *SnapshotMeta) GetCfFiles() []*SnapshotCFFile {
 	return nil
 }
 
-type SnapshotChunk struct {
-	Message              *RaftMessage `protobuf:"bytes,1,opt,name=message" json:"message,omitempty"`
-	Data                 []byte       `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+// The valid snapshots in the snapshot directory of a store, the files of a
+// snapshot not listed are partially written or partially deleted.
+type SnapshotManifest struct {
+	Snapshots            []*SnapshotManifestEntry `protobuf:"bytes,1,rep,name=snapshots" json:"snapshots,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
 }
 
-func (m *SnapshotChunk) Reset()         { *m = SnapshotChunk{} }
-func (m *SnapshotChunk) String() string { return proto.CompactTextString(m) }
-func (*SnapshotChunk) ProtoMessage()    {}
-func (*SnapshotChunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_serverpb_9d4bf28a94e26664, []int{10}
+func (m *SnapshotManifest) Reset()         { *m = SnapshotManifest{} }
+func (m *SnapshotManifest) String() string { return proto.CompactTextString(m) }
+func (*SnapshotManifest) ProtoMessage()    {}
+func (*SnapshotManifest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_raft_serverpb_bd195e7effb233ce, []int{16}
 }
-func (m *SnapshotChunk) XXX_Unmarshal(b []byte) error {
+func (m *SnapshotManifest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *SnapshotChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *SnapshotManifest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_SnapshotChunk.Marshal(b, m, deterministic)
+		return xxx_messageInfo_SnapshotManifest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -715,50 +1167,130 @@ func (m *SnapshotChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error
 		return b[:n], nil
 	}
 }
-func (dst *SnapshotChunk) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SnapshotChunk.Merge(dst, src)
+func (dst *SnapshotManifest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotManifest.Merge(dst, src)
 }
-func (m *SnapshotChunk) XXX_Size() int {
+func (m *SnapshotManifest) XXX_Size() int {
 	return m.Size()
 }
-func (m *SnapshotChunk) XXX_DiscardUnknown() {
-	xxx_messageInfo_SnapshotChunk.DiscardUnknown(m)
+func (m *SnapshotManifest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotManifest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SnapshotChunk proto.InternalMessageInfo
+var xxx_messageInfo_SnapshotManifest proto.InternalMessageInfo
 
-func (m *SnapshotChunk) GetMessage() *RaftMessage {
+func (m *SnapshotManifest) GetSnapshots() []*SnapshotManifestEntry {
 	if m != nil {
-		return m.Message
+		return m.Snapshots
 	}
 	return nil
 }
 
-func (m *SnapshotChunk) GetData() []byte {
+type SnapshotManifestEntry struct {
+	RegionId uint64 `protobuf:"varint,1,opt,name=region_id,json=regionId,proto3" json:"region_id,omitempty"`
+	Term     uint64 `protobuf:"varint,2,opt,name=term,proto3" json:"term,omitempty"`
+	Index    uint64 `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
+	// Whether the snapshot is generated by the store, rather than received.
+	IsSending            bool          `protobuf:"varint,4,opt,name=is_sending,json=isSending,proto3" json:"is_sending,omitempty"`
+	Meta                 *SnapshotMeta `protobuf:"bytes,5,opt,name=meta" json:"meta,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *SnapshotManifestEntry) Reset()         { *m = SnapshotManifestEntry{} }
+func (m *SnapshotManifestEntry) String() string { return proto.CompactTextString(m) }
+func (*SnapshotManifestEntry) ProtoMessage()    {}
+func (*SnapshotManifestEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_raft_serverpb_bd195e7effb233ce, []int{17}
+}
+func (m *SnapshotManifestEntry) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SnapshotManifestEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SnapshotManifestEntry.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *SnapshotManifestEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotManifestEntry.Merge(dst, src)
+}
+func (m *SnapshotManifestEntry) XXX_Size() int {
+	return m.Size()
+}
+func (m *SnapshotManifestEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotManifestEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotManifestEntry proto.InternalMessageInfo
+
+func (m *SnapshotManifestEntry) GetRegionId() uint64 {
 	if m != nil {
-		return m.Data
+		return m.RegionId
+	}
+	return 0
+}
+
+func (m *SnapshotManifestEntry) GetTerm() uint64 {
+	if m != nil {
+		return m.Term
+	}
+	return 0
+}
+
+func (m *SnapshotManifestEntry) GetIndex() uint64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *SnapshotManifestEntry) GetIsSending() bool {
+	if m != nil {
+		return m.IsSending
+	}
+	return false
+}
+
+func (m *SnapshotManifestEntry) GetMeta() *SnapshotMeta {
+	if m != nil {
+		return m.Meta
 	}
 	return nil
 }
 
-type Done struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+// The full state of a Region exported from a store, used by the tests to clone
+// a prepopulated Region into another store instead of replaying the writes.
+type RegionExport struct {
+	RegionState *RegionLocalState `protobuf:"bytes,1,opt,name=region_state,json=regionState" json:"region_state,omitempty"`
+	ApplyState  *RaftApplyState   `protobuf:"bytes,2,opt,name=apply_state,json=applyState" json:"apply_state,omitempty"`
+	// The term of the entry at the applied index.
+	AppliedTerm          uint64            `protobuf:"varint,3,opt,name=applied_term,json=appliedTerm,proto3" json:"applied_term,omitempty"`
+	Cfs                  []*RegionExportCF `protobuf:"bytes,4,rep,name=cfs" json:"cfs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
-func (m *Done) Reset()         { *m = Done{} }
-func (m *Done) String() string { return proto.CompactTextString(m) }
-func (*Done) ProtoMessage()    {}
-func (*Done) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_serverpb_9d4bf28a94e26664, []int{11}
+func (m *RegionExport) Reset()         { *m = RegionExport{} }
+func (m *RegionExport) String() string { return proto.CompactTextString(m) }
+func (*RegionExport) ProtoMessage()    {}
+func (*RegionExport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_raft_serverpb_bd195e7effb233ce, []int{18}
 }
-func (m *Done) XXX_Unmarshal(b []byte) error {
+func (m *RegionExport) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Done) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *RegionExport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Done.Marshal(b, m, deterministic)
+		return xxx_messageInfo_RegionExport.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -768,62 +1300,268 @@ func (m *Done) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (dst *Done) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Done.Merge(dst, src)
+func (dst *RegionExport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegionExport.Merge(dst, src)
 }
-func (m *Done) XXX_Size() int {
+func (m *RegionExport) XXX_Size() int {
 	return m.Size()
 }
-func (m *Done) XXX_DiscardUnknown() {
-	xxx_messageInfo_Done.DiscardUnknown(m)
+func (m *RegionExport) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegionExport.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Done proto.InternalMessageInfo
+var xxx_messageInfo_RegionExport proto.InternalMessageInfo
 
-func init() {
-	proto.RegisterType((*RaftMessage)(nil), "raft_serverpb.RaftMessage")
-	proto.RegisterType((*RaftLocalState)(nil), "raft_serverpb.RaftLocalState")
-	proto.RegisterType((*RaftApplyState)(nil), "raft_serverpb.RaftApplyState")
-	proto.RegisterType((*RaftTruncatedState)(nil), "raft_serverpb.RaftTruncatedState")
-	proto.RegisterType((*RegionLocalState)(nil), "raft_serverpb.RegionLocalState")
-	proto.RegisterType((*StoreIdent)(nil), "raft_serverpb.StoreIdent")
-	proto.RegisterType((*KeyValue)(nil), "raft_serverpb.KeyValue")
-	proto.RegisterType((*RaftSnapshotData)(nil), "raft_serverpb.RaftSnapshotData")
-	proto.RegisterType((*SnapshotCFFile)(nil), "raft_serverpb.SnapshotCFFile")
-	proto.RegisterType((*SnapshotMeta)(nil), "raft_serverpb.SnapshotMeta")
-	proto.RegisterType((*SnapshotChunk)(nil), "raft_serverpb.SnapshotChunk")
-	proto.RegisterType((*Done)(nil), "raft_serverpb.Done")
-	proto.RegisterEnum("raft_serverpb.PeerState", PeerState_name, PeerState_value)
+func (m *RegionExport) GetRegionState() *RegionLocalState {
+	if m != nil {
+		return m.RegionState
+	}
+	return nil
 }
-func (m *RaftMessage) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+
+func (m *RegionExport) GetApplyState() *RaftApplyState {
+	if m != nil {
+		return m.ApplyState
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *RaftMessage) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.RegionId != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.RegionId))
+func (m *RegionExport) GetAppliedTerm() uint64 {
+	if m != nil {
+		return m.AppliedTerm
 	}
-	if m.FromPeer != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.FromPeer.Size()))
-		n1, err := m.FromPeer.MarshalTo(dAtA[i:])
+	return 0
+}
+
+func (m *RegionExport) GetCfs() []*RegionExportCF {
+	if m != nil {
+		return m.Cfs
+	}
+	return nil
+}
+
+type RegionExportCF struct {
+	Cf                   string      `protobuf:"bytes,1,opt,name=cf,proto3" json:"cf,omitempty"`
+	Data                 []*KeyValue `protobuf:"bytes,2,rep,name=data" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *RegionExportCF) Reset()         { *m = RegionExportCF{} }
+func (m *RegionExportCF) String() string { return proto.CompactTextString(m) }
+func (*RegionExportCF) ProtoMessage()    {}
+func (*RegionExportCF) Descriptor() ([]byte, []int) {
+	return fileDescriptor_raft_serverpb_bd195e7effb233ce, []int{19}
+}
+func (m *RegionExportCF) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RegionExportCF) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RegionExportCF.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i += n1
+		return b[:n], nil
+	}
+}
+func (dst *RegionExportCF) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegionExportCF.Merge(dst, src)
+}
+func (m *RegionExportCF) XXX_Size() int {
+	return m.Size()
+}
+func (m *RegionExportCF) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegionExportCF.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RegionExportCF proto.InternalMessageInfo
+
+func (m *RegionExportCF) GetCf() string {
+	if m != nil {
+		return m.Cf
+	}
+	return ""
+}
+
+func (m *RegionExportCF) GetData() []*KeyValue {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type SnapshotChunk struct {
+	Message *RaftMessage `protobuf:"bytes,1,opt,name=message" json:"message,omitempty"`
+	Data    []byte       `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// The CRC32 (IEEE) of the data.
+	Crc32 uint32 `protobuf:"varint,3,opt,name=crc32,proto3" json:"crc32,omitempty"`
+	// The SHA256 of the whole snapshot, set in the last chunk which carries
+	// no data.
+	Sha256               []byte   `protobuf:"bytes,4,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotChunk) Reset()         { *m = SnapshotChunk{} }
+func (m *SnapshotChunk) String() string { return proto.CompactTextString(m) }
+func (*SnapshotChunk) ProtoMessage()    {}
+func (*SnapshotChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_raft_serverpb_bd195e7effb233ce, []int{20}
+}
+func (m *SnapshotChunk) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SnapshotChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SnapshotChunk.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *SnapshotChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotChunk.Merge(dst, src)
+}
+func (m *SnapshotChunk) XXX_Size() int {
+	return m.Size()
+}
+func (m *SnapshotChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotChunk proto.InternalMessageInfo
+
+func (m *SnapshotChunk) GetMessage() *RaftMessage {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+func (m *SnapshotChunk) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *SnapshotChunk) GetCrc32() uint32 {
+	if m != nil {
+		return m.Crc32
+	}
+	return 0
+}
+
+func (m *SnapshotChunk) GetSha256() []byte {
+	if m != nil {
+		return m.Sha256
+	}
+	return nil
+}
+
+type Done struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Done) Reset()         { *m = Done{} }
+func (m *Done) String() string { return proto.CompactTextString(m) }
+func (*Done) ProtoMessage()    {}
+func (*Done) Descriptor() ([]byte, []int) {
+	return fileDescriptor_raft_serverpb_bd195e7effb233ce, []int{21}
+}
+func (m *Done) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Done) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Done.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *Done) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Done.Merge(dst, src)
+}
+func (m *Done) XXX_Size() int {
+	return m.Size()
+}
+func (m *Done) XXX_DiscardUnknown() {
+	xxx_messageInfo_Done.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Done proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*RaftMessage)(nil), "raft_serverpb.RaftMessage")
+	proto.RegisterType((*CheckpointCatchUp)(nil), "raft_serverpb.CheckpointCatchUp")
+	proto.RegisterType((*SnapshotApplied)(nil), "raft_serverpb.SnapshotApplied")
+	proto.RegisterType((*SnapshotDelegation)(nil), "raft_serverpb.SnapshotDelegation")
+	proto.RegisterType((*RegionCheckpoint)(nil), "raft_serverpb.RegionCheckpoint")
+	proto.RegisterType((*RaftLocalState)(nil), "raft_serverpb.RaftLocalState")
+	proto.RegisterType((*RaftApplyState)(nil), "raft_serverpb.RaftApplyState")
+	proto.RegisterType((*RaftTruncatedState)(nil), "raft_serverpb.RaftTruncatedState")
+	proto.RegisterType((*RegionLocalState)(nil), "raft_serverpb.RegionLocalState")
+	proto.RegisterType((*MergeState)(nil), "raft_serverpb.MergeState")
+	proto.RegisterType((*LockCheckpoint)(nil), "raft_serverpb.LockCheckpoint")
+	proto.RegisterType((*StoreIdent)(nil), "raft_serverpb.StoreIdent")
+	proto.RegisterType((*KeyValue)(nil), "raft_serverpb.KeyValue")
+	proto.RegisterType((*RaftSnapshotData)(nil), "raft_serverpb.RaftSnapshotData")
+	proto.RegisterType((*SnapshotCFFile)(nil), "raft_serverpb.SnapshotCFFile")
+	proto.RegisterType((*SnapshotMeta)(nil), "raft_serverpb.SnapshotMeta")
+	proto.RegisterType((*SnapshotManifest)(nil), "raft_serverpb.SnapshotManifest")
+	proto.RegisterType((*SnapshotManifestEntry)(nil), "raft_serverpb.SnapshotManifestEntry")
+	proto.RegisterType((*RegionExport)(nil), "raft_serverpb.RegionExport")
+	proto.RegisterType((*RegionExportCF)(nil), "raft_serverpb.RegionExportCF")
+	proto.RegisterType((*SnapshotChunk)(nil), "raft_serverpb.SnapshotChunk")
+	proto.RegisterType((*Done)(nil), "raft_serverpb.Done")
+	proto.RegisterEnum("raft_serverpb.PeerState", PeerState_name, PeerState_value)
+}
+func (m *RaftMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RaftMessage) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.RegionId != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.RegionId))
+	}
+	if m.FromPeer != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.FromPeer.Size()))
+		n1, err := m.FromPeer.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n1
 	}
 	if m.ToPeer != nil {
 		dAtA[i] = 0x1a
@@ -877,13 +1615,43 @@ func (m *RaftMessage) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintRaftServerpb(dAtA, i, uint64(len(m.EndKey)))
 		i += copy(dAtA[i:], m.EndKey)
 	}
+	if m.SnapshotDelegation != nil {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.SnapshotDelegation.Size()))
+		n5, err := m.SnapshotDelegation.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n5
+	}
+	if m.SnapshotApplied != nil {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.SnapshotApplied.Size()))
+		n6, err := m.SnapshotApplied.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n6
+	}
+	if m.CheckpointCatchUp != nil {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.CheckpointCatchUp.Size()))
+		n7, err := m.CheckpointCatchUp.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n7
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *RaftLocalState) Marshal() (dAtA []byte, err error) {
+func (m *CheckpointCatchUp) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -893,30 +1661,50 @@ func (m *RaftLocalState) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *RaftLocalState) MarshalTo(dAtA []byte) (int, error) {
+func (m *CheckpointCatchUp) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.HardState != nil {
-		dAtA[i] = 0xa
+	if m.Index != 0 {
+		dAtA[i] = 0x8
 		i++
-		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.HardState.Size()))
-		n5, err := m.HardState.MarshalTo(dAtA[i:])
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.Index))
+	}
+	if m.Term != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.Term))
+	}
+	if m.Region != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.Region.Size()))
+		n8, err := m.Region.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n5
+		i += n8
 	}
-	if m.LastIndex != 0 {
-		dAtA[i] = 0x10
+	if m.Response {
+		dAtA[i] = 0x20
+		i++
+		if m.Response {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
 		i++
-		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.LastIndex))
 	}
-	if m.LastTerm != 0 {
-		dAtA[i] = 0x18
+	if m.Accepted {
+		dAtA[i] = 0x28
+		i++
+		if m.Accepted {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
 		i++
-		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.LastTerm))
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -924,7 +1712,7 @@ func (m *RaftLocalState) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *RaftApplyState) Marshal() (dAtA []byte, err error) {
+func (m *SnapshotApplied) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -934,25 +1722,25 @@ func (m *RaftApplyState) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *RaftApplyState) MarshalTo(dAtA []byte) (int, error) {
+func (m *SnapshotApplied) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.AppliedIndex != 0 {
+	if m.Index != 0 {
 		dAtA[i] = 0x8
 		i++
-		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.AppliedIndex))
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.Index))
 	}
-	if m.TruncatedState != nil {
-		dAtA[i] = 0x12
+	if m.Term != 0 {
+		dAtA[i] = 0x10
 		i++
-		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.TruncatedState.Size()))
-		n6, err := m.TruncatedState.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n6
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.Term))
+	}
+	if m.ApplyDurationMs != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.ApplyDurationMs))
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -960,7 +1748,7 @@ func (m *RaftApplyState) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *RaftTruncatedState) Marshal() (dAtA []byte, err error) {
+func (m *SnapshotDelegation) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -970,15 +1758,20 @@ func (m *RaftTruncatedState) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *RaftTruncatedState) MarshalTo(dAtA []byte) (int, error) {
+func (m *SnapshotDelegation) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.Index != 0 {
-		dAtA[i] = 0x8
+	if m.Target != nil {
+		dAtA[i] = 0xa
 		i++
-		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.Index))
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.Target.Size()))
+		n9, err := m.Target.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n9
 	}
 	if m.Term != 0 {
 		dAtA[i] = 0x10
@@ -991,7 +1784,7 @@ func (m *RaftTruncatedState) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *RegionLocalState) Marshal() (dAtA []byte, err error) {
+func (m *RegionCheckpoint) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1001,25 +1794,256 @@ func (m *RegionLocalState) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *RegionLocalState) MarshalTo(dAtA []byte) (int, error) {
+func (m *RegionCheckpoint) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.State != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.State))
-	}
 	if m.Region != nil {
-		dAtA[i] = 0x12
+		dAtA[i] = 0xa
 		i++
 		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.Region.Size()))
-		n7, err := m.Region.MarshalTo(dAtA[i:])
+		n10, err := m.Region.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n7
+		i += n10
+	}
+	if m.AppliedIndex != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.AppliedIndex))
+	}
+	if m.AppliedTerm != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.AppliedTerm))
+	}
+	if m.ExpireAt != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.ExpireAt))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *RaftLocalState) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RaftLocalState) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.HardState != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.HardState.Size()))
+		n11, err := m.HardState.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n11
+	}
+	if m.LastIndex != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.LastIndex))
+	}
+	if m.LastTerm != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.LastTerm))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *RaftApplyState) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RaftApplyState) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.AppliedIndex != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.AppliedIndex))
+	}
+	if m.TruncatedState != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.TruncatedState.Size()))
+		n12, err := m.TruncatedState.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n12
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *RaftTruncatedState) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RaftTruncatedState) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Index != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.Index))
+	}
+	if m.Term != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.Term))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *RegionLocalState) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RegionLocalState) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.State != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.State))
+	}
+	if m.Region != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.Region.Size()))
+		n13, err := m.Region.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n13
+	}
+	if m.MergeState != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.MergeState.Size()))
+		n14, err := m.MergeState.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n14
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *MergeState) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MergeState) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.MinIndex != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.MinIndex))
+	}
+	if m.Target != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.Target.Size()))
+		n15, err := m.Target.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n15
+	}
+	if m.Commit != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.Commit))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *LockCheckpoint) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LockCheckpoint) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Index != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.Index))
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -1052,6 +2076,11 @@ func (m *StoreIdent) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.StoreId))
 	}
+	if m.FencingToken != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.FencingToken))
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -1110,11 +2139,11 @@ func (m *RaftSnapshotData) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.Region.Size()))
-		n8, err := m.Region.MarshalTo(dAtA[i:])
+		n16, err := m.Region.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n8
+		i += n16
 	}
 	if m.FileSize != 0 {
 		dAtA[i] = 0x10
@@ -1137,11 +2166,11 @@ func (m *RaftSnapshotData) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x2a
 		i++
 		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.Meta.Size()))
-		n9, err := m.Meta.MarshalTo(dAtA[i:])
+		n17, err := m.Meta.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n9
+		i += n17
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -1219,7 +2248,7 @@ func (m *SnapshotMeta) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *SnapshotChunk) Marshal() (dAtA []byte, err error) {
+func (m *SnapshotManifest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1229,26 +2258,22 @@ func (m *SnapshotChunk) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *SnapshotChunk) MarshalTo(dAtA []byte) (int, error) {
+func (m *SnapshotManifest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.Message != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.Message.Size()))
-		n10, err := m.Message.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	if len(m.Snapshots) > 0 {
+		for _, msg := range m.Snapshots {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintRaftServerpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
 		}
-		i += n10
-	}
-	if len(m.Data) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintRaftServerpb(dAtA, i, uint64(len(m.Data)))
-		i += copy(dAtA[i:], m.Data)
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -1256,7 +2281,7 @@ func (m *SnapshotChunk) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *Done) Marshal() (dAtA []byte, err error) {
+func (m *SnapshotManifestEntry) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1266,21 +2291,222 @@ func (m *Done) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Done) MarshalTo(dAtA []byte) (int, error) {
+func (m *SnapshotManifestEntry) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if m.RegionId != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.RegionId))
 	}
-	return i, nil
-}
-
-func encodeVarintRaftServerpb(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
+	if m.Term != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.Term))
+	}
+	if m.Index != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.Index))
+	}
+	if m.IsSending {
+		dAtA[i] = 0x20
+		i++
+		if m.IsSending {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Meta != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.Meta.Size()))
+		n18, err := m.Meta.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n18
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *RegionExport) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RegionExport) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.RegionState != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.RegionState.Size()))
+		n19, err := m.RegionState.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n19
+	}
+	if m.ApplyState != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.ApplyState.Size()))
+		n20, err := m.ApplyState.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n20
+	}
+	if m.AppliedTerm != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.AppliedTerm))
+	}
+	if len(m.Cfs) > 0 {
+		for _, msg := range m.Cfs {
+			dAtA[i] = 0x22
+			i++
+			i = encodeVarintRaftServerpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *RegionExportCF) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RegionExportCF) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Cf) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(len(m.Cf)))
+		i += copy(dAtA[i:], m.Cf)
+	}
+	if len(m.Data) > 0 {
+		for _, msg := range m.Data {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintRaftServerpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *SnapshotChunk) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SnapshotChunk) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Message != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.Message.Size()))
+		n21, err := m.Message.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n21
+	}
+	if len(m.Data) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(len(m.Data)))
+		i += copy(dAtA[i:], m.Data)
+	}
+	if m.Crc32 != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.Crc32))
+	}
+	if len(m.Sha256) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(len(m.Sha256)))
+		i += copy(dAtA[i:], m.Sha256)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Done) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Done) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func encodeVarintRaftServerpb(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
 		offset++
 	}
 	dAtA[offset] = uint8(v)
@@ -1319,24 +2545,42 @@ func (m *RaftMessage) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovRaftServerpb(uint64(l))
 	}
+	if m.SnapshotDelegation != nil {
+		l = m.SnapshotDelegation.Size()
+		n += 1 + l + sovRaftServerpb(uint64(l))
+	}
+	if m.SnapshotApplied != nil {
+		l = m.SnapshotApplied.Size()
+		n += 1 + l + sovRaftServerpb(uint64(l))
+	}
+	if m.CheckpointCatchUp != nil {
+		l = m.CheckpointCatchUp.Size()
+		n += 1 + l + sovRaftServerpb(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *RaftLocalState) Size() (n int) {
+func (m *CheckpointCatchUp) Size() (n int) {
 	var l int
 	_ = l
-	if m.HardState != nil {
-		l = m.HardState.Size()
+	if m.Index != 0 {
+		n += 1 + sovRaftServerpb(uint64(m.Index))
+	}
+	if m.Term != 0 {
+		n += 1 + sovRaftServerpb(uint64(m.Term))
+	}
+	if m.Region != nil {
+		l = m.Region.Size()
 		n += 1 + l + sovRaftServerpb(uint64(l))
 	}
-	if m.LastIndex != 0 {
-		n += 1 + sovRaftServerpb(uint64(m.LastIndex))
+	if m.Response {
+		n += 2
 	}
-	if m.LastTerm != 0 {
-		n += 1 + sovRaftServerpb(uint64(m.LastTerm))
+	if m.Accepted {
+		n += 2
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -1344,15 +2588,17 @@ func (m *RaftLocalState) Size() (n int) {
 	return n
 }
 
-func (m *RaftApplyState) Size() (n int) {
+func (m *SnapshotApplied) Size() (n int) {
 	var l int
 	_ = l
-	if m.AppliedIndex != 0 {
-		n += 1 + sovRaftServerpb(uint64(m.AppliedIndex))
+	if m.Index != 0 {
+		n += 1 + sovRaftServerpb(uint64(m.Index))
 	}
-	if m.TruncatedState != nil {
-		l = m.TruncatedState.Size()
-		n += 1 + l + sovRaftServerpb(uint64(l))
+	if m.Term != 0 {
+		n += 1 + sovRaftServerpb(uint64(m.Term))
+	}
+	if m.ApplyDurationMs != 0 {
+		n += 1 + sovRaftServerpb(uint64(m.ApplyDurationMs))
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -1360,11 +2606,12 @@ func (m *RaftApplyState) Size() (n int) {
 	return n
 }
 
-func (m *RaftTruncatedState) Size() (n int) {
+func (m *SnapshotDelegation) Size() (n int) {
 	var l int
 	_ = l
-	if m.Index != 0 {
-		n += 1 + sovRaftServerpb(uint64(m.Index))
+	if m.Target != nil {
+		l = m.Target.Size()
+		n += 1 + l + sovRaftServerpb(uint64(l))
 	}
 	if m.Term != 0 {
 		n += 1 + sovRaftServerpb(uint64(m.Term))
@@ -1375,30 +2622,40 @@ func (m *RaftTruncatedState) Size() (n int) {
 	return n
 }
 
-func (m *RegionLocalState) Size() (n int) {
+func (m *RegionCheckpoint) Size() (n int) {
 	var l int
 	_ = l
-	if m.State != 0 {
-		n += 1 + sovRaftServerpb(uint64(m.State))
-	}
 	if m.Region != nil {
 		l = m.Region.Size()
 		n += 1 + l + sovRaftServerpb(uint64(l))
 	}
+	if m.AppliedIndex != 0 {
+		n += 1 + sovRaftServerpb(uint64(m.AppliedIndex))
+	}
+	if m.AppliedTerm != 0 {
+		n += 1 + sovRaftServerpb(uint64(m.AppliedTerm))
+	}
+	if m.ExpireAt != 0 {
+		n += 1 + sovRaftServerpb(uint64(m.ExpireAt))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *StoreIdent) Size() (n int) {
+func (m *RaftLocalState) Size() (n int) {
 	var l int
 	_ = l
-	if m.ClusterId != 0 {
-		n += 1 + sovRaftServerpb(uint64(m.ClusterId))
+	if m.HardState != nil {
+		l = m.HardState.Size()
+		n += 1 + l + sovRaftServerpb(uint64(l))
 	}
-	if m.StoreId != 0 {
-		n += 1 + sovRaftServerpb(uint64(m.StoreId))
+	if m.LastIndex != 0 {
+		n += 1 + sovRaftServerpb(uint64(m.LastIndex))
+	}
+	if m.LastTerm != 0 {
+		n += 1 + sovRaftServerpb(uint64(m.LastTerm))
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -1406,15 +2663,14 @@ func (m *StoreIdent) Size() (n int) {
 	return n
 }
 
-func (m *KeyValue) Size() (n int) {
+func (m *RaftApplyState) Size() (n int) {
 	var l int
 	_ = l
-	l = len(m.Key)
-	if l > 0 {
-		n += 1 + l + sovRaftServerpb(uint64(l))
+	if m.AppliedIndex != 0 {
+		n += 1 + sovRaftServerpb(uint64(m.AppliedIndex))
 	}
-	l = len(m.Value)
-	if l > 0 {
+	if m.TruncatedState != nil {
+		l = m.TruncatedState.Size()
 		n += 1 + l + sovRaftServerpb(uint64(l))
 	}
 	if m.XXX_unrecognized != nil {
@@ -1423,14 +2679,115 @@ func (m *KeyValue) Size() (n int) {
 	return n
 }
 
-func (m *RaftSnapshotData) Size() (n int) {
+func (m *RaftTruncatedState) Size() (n int) {
 	var l int
 	_ = l
-	if m.Region != nil {
-		l = m.Region.Size()
-		n += 1 + l + sovRaftServerpb(uint64(l))
+	if m.Index != 0 {
+		n += 1 + sovRaftServerpb(uint64(m.Index))
 	}
-	if m.FileSize != 0 {
+	if m.Term != 0 {
+		n += 1 + sovRaftServerpb(uint64(m.Term))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RegionLocalState) Size() (n int) {
+	var l int
+	_ = l
+	if m.State != 0 {
+		n += 1 + sovRaftServerpb(uint64(m.State))
+	}
+	if m.Region != nil {
+		l = m.Region.Size()
+		n += 1 + l + sovRaftServerpb(uint64(l))
+	}
+	if m.MergeState != nil {
+		l = m.MergeState.Size()
+		n += 1 + l + sovRaftServerpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *MergeState) Size() (n int) {
+	var l int
+	_ = l
+	if m.MinIndex != 0 {
+		n += 1 + sovRaftServerpb(uint64(m.MinIndex))
+	}
+	if m.Target != nil {
+		l = m.Target.Size()
+		n += 1 + l + sovRaftServerpb(uint64(l))
+	}
+	if m.Commit != 0 {
+		n += 1 + sovRaftServerpb(uint64(m.Commit))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *LockCheckpoint) Size() (n int) {
+	var l int
+	_ = l
+	if m.Index != 0 {
+		n += 1 + sovRaftServerpb(uint64(m.Index))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *StoreIdent) Size() (n int) {
+	var l int
+	_ = l
+	if m.ClusterId != 0 {
+		n += 1 + sovRaftServerpb(uint64(m.ClusterId))
+	}
+	if m.StoreId != 0 {
+		n += 1 + sovRaftServerpb(uint64(m.StoreId))
+	}
+	if m.FencingToken != 0 {
+		n += 1 + sovRaftServerpb(uint64(m.FencingToken))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *KeyValue) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovRaftServerpb(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovRaftServerpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RaftSnapshotData) Size() (n int) {
+	var l int
+	_ = l
+	if m.Region != nil {
+		l = m.Region.Size()
+		n += 1 + l + sovRaftServerpb(uint64(l))
+	}
+	if m.FileSize != 0 {
 		n += 1 + sovRaftServerpb(uint64(m.FileSize))
 	}
 	if len(m.Data) > 0 {
@@ -1483,6 +2840,91 @@ func (m *SnapshotMeta) Size() (n int) {
 	return n
 }
 
+func (m *SnapshotManifest) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Snapshots) > 0 {
+		for _, e := range m.Snapshots {
+			l = e.Size()
+			n += 1 + l + sovRaftServerpb(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SnapshotManifestEntry) Size() (n int) {
+	var l int
+	_ = l
+	if m.RegionId != 0 {
+		n += 1 + sovRaftServerpb(uint64(m.RegionId))
+	}
+	if m.Term != 0 {
+		n += 1 + sovRaftServerpb(uint64(m.Term))
+	}
+	if m.Index != 0 {
+		n += 1 + sovRaftServerpb(uint64(m.Index))
+	}
+	if m.IsSending {
+		n += 2
+	}
+	if m.Meta != nil {
+		l = m.Meta.Size()
+		n += 1 + l + sovRaftServerpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RegionExport) Size() (n int) {
+	var l int
+	_ = l
+	if m.RegionState != nil {
+		l = m.RegionState.Size()
+		n += 1 + l + sovRaftServerpb(uint64(l))
+	}
+	if m.ApplyState != nil {
+		l = m.ApplyState.Size()
+		n += 1 + l + sovRaftServerpb(uint64(l))
+	}
+	if m.AppliedTerm != 0 {
+		n += 1 + sovRaftServerpb(uint64(m.AppliedTerm))
+	}
+	if len(m.Cfs) > 0 {
+		for _, e := range m.Cfs {
+			l = e.Size()
+			n += 1 + l + sovRaftServerpb(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RegionExportCF) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Cf)
+	if l > 0 {
+		n += 1 + l + sovRaftServerpb(uint64(l))
+	}
+	if len(m.Data) > 0 {
+		for _, e := range m.Data {
+			l = e.Size()
+			n += 1 + l + sovRaftServerpb(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func (m *SnapshotChunk) Size() (n int) {
 	var l int
 	_ = l
@@ -1494,6 +2936,13 @@ func (m *SnapshotChunk) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovRaftServerpb(uint64(l))
 	}
+	if m.Crc32 != 0 {
+		n += 1 + sovRaftServerpb(uint64(m.Crc32))
+	}
+	l = len(m.Sha256)
+	if l > 0 {
+		n += 1 + l + sovRaftServerpb(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1506,23 +2955,1234 @@ func (m *Done) Size() (n int) {
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
-	return n
-}
+	return n
+}
+
+func sovRaftServerpb(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozRaftServerpb(x uint64) (n int) {
+	return sovRaftServerpb(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *RaftMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaftServerpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RaftMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RaftMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RegionId", wireType)
+			}
+			m.RegionId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RegionId |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FromPeer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftServerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.FromPeer == nil {
+				m.FromPeer = &metapb.Peer{}
+			}
+			if err := m.FromPeer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ToPeer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftServerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ToPeer == nil {
+				m.ToPeer = &metapb.Peer{}
+			}
+			if err := m.ToPeer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftServerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Message == nil {
+				m.Message = &eraftpb.Message{}
+			}
+			if err := m.Message.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RegionEpoch", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftServerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RegionEpoch == nil {
+				m.RegionEpoch = &metapb.RegionEpoch{}
+			}
+			if err := m.RegionEpoch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsTombstone", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IsTombstone = bool(v != 0)
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRaftServerpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StartKey = append(m.StartKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.StartKey == nil {
+				m.StartKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRaftServerpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EndKey = append(m.EndKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.EndKey == nil {
+				m.EndKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotDelegation", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftServerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SnapshotDelegation == nil {
+				m.SnapshotDelegation = &SnapshotDelegation{}
+			}
+			if err := m.SnapshotDelegation.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotApplied", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftServerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SnapshotApplied == nil {
+				m.SnapshotApplied = &SnapshotApplied{}
+			}
+			if err := m.SnapshotApplied.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckpointCatchUp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftServerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.CheckpointCatchUp == nil {
+				m.CheckpointCatchUp = &CheckpointCatchUp{}
+			}
+			if err := m.CheckpointCatchUp.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaftServerpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRaftServerpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CheckpointCatchUp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaftServerpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CheckpointCatchUp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CheckpointCatchUp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+			}
+			m.Term = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Term |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Region", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftServerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Region == nil {
+				m.Region = &metapb.Region{}
+			}
+			if err := m.Region.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Response", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Response = bool(v != 0)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Accepted", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Accepted = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaftServerpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRaftServerpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SnapshotApplied) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaftServerpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SnapshotApplied: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SnapshotApplied: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+			}
+			m.Term = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Term |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ApplyDurationMs", wireType)
+			}
+			m.ApplyDurationMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ApplyDurationMs |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaftServerpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRaftServerpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SnapshotDelegation) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaftServerpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SnapshotDelegation: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SnapshotDelegation: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Target", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftServerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Target == nil {
+				m.Target = &metapb.Peer{}
+			}
+			if err := m.Target.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+			}
+			m.Term = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Term |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaftServerpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRaftServerpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RegionCheckpoint) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaftServerpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RegionCheckpoint: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RegionCheckpoint: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Region", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftServerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Region == nil {
+				m.Region = &metapb.Region{}
+			}
+			if err := m.Region.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppliedIndex", wireType)
+			}
+			m.AppliedIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AppliedIndex |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppliedTerm", wireType)
+			}
+			m.AppliedTerm = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AppliedTerm |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpireAt", wireType)
+			}
+			m.ExpireAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpireAt |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaftServerpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRaftServerpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RaftLocalState) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaftServerpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RaftLocalState: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RaftLocalState: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HardState", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftServerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.HardState == nil {
+				m.HardState = &eraftpb.HardState{}
+			}
+			if err := m.HardState.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastIndex", wireType)
+			}
+			m.LastIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastIndex |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastTerm", wireType)
+			}
+			m.LastTerm = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastTerm |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaftServerpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRaftServerpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RaftApplyState) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaftServerpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RaftApplyState: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RaftApplyState: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppliedIndex", wireType)
+			}
+			m.AppliedIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AppliedIndex |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TruncatedState", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftServerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TruncatedState == nil {
+				m.TruncatedState = &RaftTruncatedState{}
+			}
+			if err := m.TruncatedState.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaftServerpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRaftServerpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RaftTruncatedState) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaftServerpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RaftTruncatedState: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RaftTruncatedState: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+			}
+			m.Term = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Term |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaftServerpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRaftServerpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
 
-func sovRaftServerpb(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
-		}
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
-}
-func sozRaftServerpb(x uint64) (n int) {
-	return sovRaftServerpb(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return nil
 }
-func (m *RaftMessage) Unmarshal(dAtA []byte) error {
+func (m *RegionLocalState) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1545,17 +4205,17 @@ func (m *RaftMessage) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RaftMessage: wiretype end group for non-group")
+			return fmt.Errorf("proto: RegionLocalState: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RaftMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RegionLocalState: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RegionId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
 			}
-			m.RegionId = 0
+			m.State = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftServerpb
@@ -1565,14 +4225,14 @@ func (m *RaftMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.RegionId |= (uint64(b) & 0x7F) << shift
+				m.State |= (PeerState(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FromPeer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Region", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1596,16 +4256,16 @@ func (m *RaftMessage) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.FromPeer == nil {
-				m.FromPeer = &metapb.Peer{}
+			if m.Region == nil {
+				m.Region = &metapb.Region{}
 			}
-			if err := m.FromPeer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Region.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ToPeer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MergeState", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1629,18 +4289,69 @@ func (m *RaftMessage) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ToPeer == nil {
-				m.ToPeer = &metapb.Peer{}
+			if m.MergeState == nil {
+				m.MergeState = &MergeState{}
 			}
-			if err := m.ToPeer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.MergeState.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaftServerpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			var msglen int
+			if skippy < 0 {
+				return ErrInvalidLengthRaftServerpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MergeState) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaftServerpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MergeState: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MergeState: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinIndex", wireType)
+			}
+			m.MinIndex = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftServerpb
@@ -1650,28 +4361,14 @@ func (m *RaftMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.MinIndex |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthRaftServerpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Message == nil {
-				m.Message = &eraftpb.Message{}
-			}
-			if err := m.Message.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 5:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RegionEpoch", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Target", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1695,18 +4392,18 @@ func (m *RaftMessage) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.RegionEpoch == nil {
-				m.RegionEpoch = &metapb.RegionEpoch{}
+			if m.Target == nil {
+				m.Target = &metapb.Region{}
 			}
-			if err := m.RegionEpoch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Target.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 6:
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IsTombstone", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
 			}
-			var v int
+			m.Commit = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftServerpb
@@ -1716,48 +4413,67 @@ func (m *RaftMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				m.Commit |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.IsTombstone = bool(v != 0)
-		case 7:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StartKey", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRaftServerpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaftServerpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if byteLen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthRaftServerpb
 			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.StartKey = append(m.StartKey[:0], dAtA[iNdEx:postIndex]...)
-			if m.StartKey == nil {
-				m.StartKey = []byte{}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LockCheckpoint) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaftServerpb
 			}
-			iNdEx = postIndex
-		case 8:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EndKey", wireType)
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			var byteLen int
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LockCheckpoint: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LockCheckpoint: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftServerpb
@@ -1767,23 +4483,11 @@ func (m *RaftMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				m.Index |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthRaftServerpb
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.EndKey = append(m.EndKey[:0], dAtA[iNdEx:postIndex]...)
-			if m.EndKey == nil {
-				m.EndKey = []byte{}
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRaftServerpb(dAtA[iNdEx:])
@@ -1806,7 +4510,7 @@ func (m *RaftMessage) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RaftLocalState) Unmarshal(dAtA []byte) error {
+func (m *StoreIdent) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1829,17 +4533,17 @@ func (m *RaftLocalState) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RaftLocalState: wiretype end group for non-group")
+			return fmt.Errorf("proto: StoreIdent: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RaftLocalState: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: StoreIdent: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field HardState", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClusterId", wireType)
 			}
-			var msglen int
+			m.ClusterId = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftServerpb
@@ -1849,30 +4553,16 @@ func (m *RaftLocalState) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.ClusterId |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthRaftServerpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.HardState == nil {
-				m.HardState = &eraftpb.HardState{}
-			}
-			if err := m.HardState.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LastIndex", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field StoreId", wireType)
 			}
-			m.LastIndex = 0
+			m.StoreId = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftServerpb
@@ -1882,16 +4572,16 @@ func (m *RaftLocalState) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.LastIndex |= (uint64(b) & 0x7F) << shift
+				m.StoreId |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LastTerm", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field FencingToken", wireType)
 			}
-			m.LastTerm = 0
+			m.FencingToken = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftServerpb
@@ -1901,7 +4591,7 @@ func (m *RaftLocalState) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.LastTerm |= (uint64(b) & 0x7F) << shift
+				m.FencingToken |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -1928,7 +4618,7 @@ func (m *RaftLocalState) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RaftApplyState) Unmarshal(dAtA []byte) error {
+func (m *KeyValue) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1951,17 +4641,17 @@ func (m *RaftApplyState) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RaftApplyState: wiretype end group for non-group")
+			return fmt.Errorf("proto: KeyValue: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RaftApplyState: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: KeyValue: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AppliedIndex", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
 			}
-			m.AppliedIndex = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftServerpb
@@ -1971,16 +4661,28 @@ func (m *RaftApplyState) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.AppliedIndex |= (uint64(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRaftServerpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TruncatedState", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftServerpb
@@ -1990,23 +4692,21 @@ func (m *RaftApplyState) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRaftServerpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.TruncatedState == nil {
-				m.TruncatedState = &RaftTruncatedState{}
-			}
-			if err := m.TruncatedState.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -2031,7 +4731,7 @@ func (m *RaftApplyState) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RaftTruncatedState) Unmarshal(dAtA []byte) error {
+func (m *RaftSnapshotData) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2054,17 +4754,17 @@ func (m *RaftTruncatedState) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RaftTruncatedState: wiretype end group for non-group")
+			return fmt.Errorf("proto: RaftSnapshotData: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RaftTruncatedState: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RaftSnapshotData: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Region", wireType)
 			}
-			m.Index = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftServerpb
@@ -2074,16 +4774,30 @@ func (m *RaftTruncatedState) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Index |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftServerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Region == nil {
+				m.Region = &metapb.Region{}
+			}
+			if err := m.Region.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field FileSize", wireType)
 			}
-			m.Term = 0
+			m.FileSize = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftServerpb
@@ -2093,67 +4807,16 @@ func (m *RaftTruncatedState) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Term |= (uint64(b) & 0x7F) << shift
+				m.FileSize |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipRaftServerpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthRaftServerpb
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *RegionLocalState) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowRaftServerpb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: RegionLocalState: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RegionLocalState: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
-			m.State = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftServerpb
@@ -2163,14 +4826,26 @@ func (m *RegionLocalState) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.State |= (PeerState(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
+			if msglen < 0 {
+				return ErrInvalidLengthRaftServerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data, &KeyValue{})
+			if err := m.Data[len(m.Data)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Region", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Meta", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2194,10 +4869,10 @@ func (m *RegionLocalState) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Region == nil {
-				m.Region = &metapb.Region{}
+			if m.Meta == nil {
+				m.Meta = &SnapshotMeta{}
 			}
-			if err := m.Region.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Meta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -2223,7 +4898,7 @@ func (m *RegionLocalState) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *StoreIdent) Unmarshal(dAtA []byte) error {
+func (m *SnapshotCFFile) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2246,17 +4921,17 @@ func (m *StoreIdent) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: StoreIdent: wiretype end group for non-group")
+			return fmt.Errorf("proto: SnapshotCFFile: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StoreIdent: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SnapshotCFFile: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ClusterId", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cf", wireType)
 			}
-			m.ClusterId = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftServerpb
@@ -2266,16 +4941,26 @@ func (m *StoreIdent) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ClusterId |= (uint64(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRaftServerpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Cf = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StoreId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Size_", wireType)
 			}
-			m.StoreId = 0
+			m.Size_ = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftServerpb
@@ -2285,7 +4970,26 @@ func (m *StoreIdent) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.StoreId |= (uint64(b) & 0x7F) << shift
+				m.Size_ |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Checksum", wireType)
+			}
+			m.Checksum = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Checksum |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2312,7 +5016,7 @@ func (m *StoreIdent) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *KeyValue) Unmarshal(dAtA []byte) error {
+func (m *SnapshotMeta) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2335,17 +5039,17 @@ func (m *KeyValue) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: KeyValue: wiretype end group for non-group")
+			return fmt.Errorf("proto: SnapshotMeta: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: KeyValue: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SnapshotMeta: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CfFiles", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftServerpb
@@ -2355,28 +5059,79 @@ func (m *KeyValue) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
+				return ErrInvalidLengthRaftServerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CfFiles = append(m.CfFiles, &SnapshotCFFile{})
+			if err := m.CfFiles[len(m.CfFiles)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaftServerpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthRaftServerpb
 			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SnapshotManifest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaftServerpb
+			}
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
-			if m.Key == nil {
-				m.Key = []byte{}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 2:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SnapshotManifest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SnapshotManifest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Snapshots", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftServerpb
@@ -2386,21 +5141,21 @@ func (m *KeyValue) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthRaftServerpb
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
-			if m.Value == nil {
-				m.Value = []byte{}
+			m.Snapshots = append(m.Snapshots, &SnapshotManifestEntry{})
+			if err := m.Snapshots[len(m.Snapshots)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		default:
@@ -2425,7 +5180,7 @@ func (m *KeyValue) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RaftSnapshotData) Unmarshal(dAtA []byte) error {
+func (m *SnapshotManifestEntry) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2448,17 +5203,17 @@ func (m *RaftSnapshotData) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RaftSnapshotData: wiretype end group for non-group")
+			return fmt.Errorf("proto: SnapshotManifestEntry: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RaftSnapshotData: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SnapshotManifestEntry: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Region", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RegionId", wireType)
 			}
-			var msglen int
+			m.RegionId = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftServerpb
@@ -2468,30 +5223,16 @@ func (m *RaftSnapshotData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.RegionId |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthRaftServerpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Region == nil {
-				m.Region = &metapb.Region{}
-			}
-			if err := m.Region.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FileSize", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
 			}
-			m.FileSize = 0
+			m.Term = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftServerpb
@@ -2501,16 +5242,16 @@ func (m *RaftSnapshotData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.FileSize |= (uint64(b) & 0x7F) << shift
+				m.Term |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
-			var msglen int
+			m.Index = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftServerpb
@@ -2520,23 +5261,31 @@ func (m *RaftSnapshotData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.Index |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthRaftServerpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsSending", wireType)
 			}
-			m.Data = append(m.Data, &KeyValue{})
-			if err := m.Data[len(m.Data)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
+			m.IsSending = bool(v != 0)
 		case 5:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Meta", wireType)
@@ -2592,7 +5341,7 @@ func (m *RaftSnapshotData) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SnapshotCFFile) Unmarshal(dAtA []byte) error {
+func (m *RegionExport) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2615,17 +5364,17 @@ func (m *SnapshotCFFile) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SnapshotCFFile: wiretype end group for non-group")
+			return fmt.Errorf("proto: RegionExport: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SnapshotCFFile: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RegionExport: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Cf", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RegionState", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftServerpb
@@ -2635,26 +5384,30 @@ func (m *SnapshotCFFile) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthRaftServerpb
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Cf = string(dAtA[iNdEx:postIndex])
+			if m.RegionState == nil {
+				m.RegionState = &RegionLocalState{}
+			}
+			if err := m.RegionState.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Size_", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ApplyState", wireType)
 			}
-			m.Size_ = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftServerpb
@@ -2664,16 +5417,30 @@ func (m *SnapshotCFFile) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Size_ |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftServerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ApplyState == nil {
+				m.ApplyState = &RaftApplyState{}
+			}
+			if err := m.ApplyState.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Checksum", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AppliedTerm", wireType)
 			}
-			m.Checksum = 0
+			m.AppliedTerm = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRaftServerpb
@@ -2683,11 +5450,42 @@ func (m *SnapshotCFFile) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Checksum |= (uint32(b) & 0x7F) << shift
+				m.AppliedTerm |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cfs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftServerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Cfs = append(m.Cfs, &RegionExportCF{})
+			if err := m.Cfs[len(m.Cfs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRaftServerpb(dAtA[iNdEx:])
@@ -2710,7 +5508,7 @@ func (m *SnapshotCFFile) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SnapshotMeta) Unmarshal(dAtA []byte) error {
+func (m *RegionExportCF) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2733,15 +5531,44 @@ func (m *SnapshotMeta) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SnapshotMeta: wiretype end group for non-group")
+			return fmt.Errorf("proto: RegionExportCF: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SnapshotMeta: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RegionExportCF: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CfFiles", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Cf", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRaftServerpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Cf = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2765,8 +5592,8 @@ func (m *SnapshotMeta) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.CfFiles = append(m.CfFiles, &SnapshotCFFile{})
-			if err := m.CfFiles[len(m.CfFiles)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Data = append(m.Data, &KeyValue{})
+			if err := m.Data[len(m.Data)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -2885,6 +5712,56 @@ func (m *SnapshotChunk) Unmarshal(dAtA []byte) error {
 				m.Data = []byte{}
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Crc32", wireType)
+			}
+			m.Crc32 = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Crc32 |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sha256", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRaftServerpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sha256 = append(m.Sha256[:0], dAtA[iNdEx:postIndex]...)
+			if m.Sha256 == nil {
+				m.Sha256 = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRaftServerpb(dAtA[iNdEx:])
@@ -3063,55 +5940,86 @@ var (
 	ErrIntOverflowRaftServerpb   = fmt.Errorf("proto: integer overflow")
 )
 
-func init() { proto.RegisterFile("raft_serverpb.proto", fileDescriptor_raft_serverpb_9d4bf28a94e26664) }
-
-var fileDescriptor_raft_serverpb_9d4bf28a94e26664 = []byte{
-	// 751 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x54, 0xdd, 0x6e, 0xeb, 0x44,
-	0x10, 0x3e, 0x4e, 0xdc, 0xc4, 0x9e, 0x38, 0x21, 0xda, 0x83, 0x74, 0x4c, 0x8e, 0x4e, 0x94, 0x1a,
-	0x51, 0x85, 0x22, 0x05, 0x11, 0x10, 0xe2, 0x0a, 0x09, 0x28, 0x55, 0x43, 0x29, 0xaa, 0x36, 0x15,
-	0x12, 0x57, 0xd6, 0xd6, 0x1e, 0x37, 0xa6, 0x8e, 0x6d, 0xed, 0x6e, 0x2a, 0xd2, 0x1b, 0xc4, 0x5b,
-	0xf0, 0x22, 0x5c, 0xf0, 0x06, 0x5c, 0xf2, 0x08, 0xa8, 0xbc, 0x08, 0xda, 0x5d, 0x3b, 0x3f, 0x6d,
-	0x39, 0x57, 0xd9, 0x99, 0xef, 0xf3, 0xec, 0x37, 0xdf, 0x4c, 0x16, 0x5e, 0x72, 0x96, 0xc8, 0x50,
-	0x20, 0xbf, 0x43, 0x5e, 0x5e, 0x4f, 0x4a, 0x5e, 0xc8, 0x82, 0x74, 0xf7, 0x92, 0x83, 0x2e, 0xaa,
-	0xb8, 0x46, 0x07, 0xde, 0x12, 0x25, 0xab, 0xa3, 0xe0, 0xcf, 0x06, 0x74, 0x28, 0x4b, 0xe4, 0x05,
-	0x0a, 0xc1, 0x6e, 0x90, 0xbc, 0x06, 0x97, 0xe3, 0x4d, 0x5a, 0xe4, 0x61, 0x1a, 0xfb, 0xd6, 0xc8,
-	0x1a, 0xdb, 0xd4, 0x31, 0x89, 0x59, 0x4c, 0x3e, 0x04, 0x37, 0xe1, 0xc5, 0x32, 0x2c, 0x11, 0xb9,
-	0xdf, 0x18, 0x59, 0xe3, 0xce, 0xd4, 0x9b, 0x54, 0xe5, 0x2e, 0x11, 0x39, 0x75, 0x14, 0xac, 0x4e,
-	0xe4, 0x03, 0x68, 0xcb, 0xc2, 0x10, 0x9b, 0xcf, 0x10, 0x5b, 0xb2, 0xd0, 0xb4, 0x63, 0x68, 0x2f,
-	0xcd, 0xcd, 0xbe, 0xad, 0x69, 0xfd, 0x49, 0xad, 0xb6, 0x52, 0x44, 0x6b, 0x02, 0xf9, 0x1c, 0xbc,
-	0x4a, 0x1a, 0x96, 0x45, 0xb4, 0xf0, 0x0f, 0xf4, 0x07, 0x2f, 0xeb, 0xba, 0x54, 0x63, 0xdf, 0x2a,
-	0x88, 0x76, 0xf8, 0x36, 0x20, 0x87, 0xe0, 0xa5, 0x22, 0x94, 0xc5, 0xf2, 0x5a, 0xc8, 0x22, 0x47,
-	0xbf, 0x35, 0xb2, 0xc6, 0x0e, 0xed, 0xa4, 0xe2, 0xaa, 0x4e, 0xa9, 0xae, 0x85, 0x64, 0x5c, 0x86,
-	0xb7, 0xb8, 0xf6, 0xdb, 0x23, 0x6b, 0xec, 0x51, 0x47, 0x27, 0xce, 0x71, 0x4d, 0x5e, 0x41, 0x1b,
-	0xf3, 0x58, 0x43, 0x8e, 0x86, 0x5a, 0x98, 0xc7, 0xe7, 0xb8, 0x0e, 0x7e, 0x85, 0x9e, 0xb2, 0xee,
-	0xfb, 0x22, 0x62, 0xd9, 0x5c, 0x32, 0x89, 0xe4, 0x13, 0x80, 0x05, 0xe3, 0x71, 0x28, 0x54, 0xa4,
-	0xed, 0xeb, 0x4c, 0xc9, 0xa6, 0xa3, 0x33, 0xc6, 0x63, 0xcd, 0xa3, 0xee, 0xa2, 0x3e, 0x92, 0x37,
-	0x00, 0x19, 0x13, 0x32, 0x4c, 0xf3, 0x18, 0x7f, 0xd1, 0xa6, 0xda, 0xd4, 0x55, 0x99, 0x99, 0x4a,
-	0x28, 0x65, 0x1a, 0x96, 0xc8, 0x97, 0xda, 0x49, 0x9b, 0x3a, 0x2a, 0x71, 0x85, 0x7c, 0x19, 0xfc,
-	0x66, 0x19, 0x05, 0x5f, 0x95, 0x65, 0xb6, 0x36, 0xe5, 0xde, 0x87, 0x2e, 0x2b, 0xcb, 0x2c, 0xc5,
-	0xb8, 0xaa, 0x68, 0x66, 0xe8, 0x55, 0x49, 0x53, 0xf4, 0x3b, 0x78, 0x47, 0xf2, 0x55, 0x1e, 0x31,
-	0x89, 0xb5, 0x56, 0x33, 0xcd, 0xc3, 0xc9, 0xfe, 0x3e, 0xa9, 0xe2, 0x57, 0x35, 0xd3, 0x48, 0xef,
-	0xc9, 0xbd, 0x38, 0xf8, 0x12, 0xc8, 0x53, 0x16, 0x79, 0x17, 0x0e, 0x76, 0xaf, 0x37, 0x01, 0x21,
-	0x60, 0xeb, 0x3e, 0x4c, 0x97, 0xfa, 0x1c, 0xfc, 0x0c, 0x7d, 0x33, 0xb9, 0x1d, 0x1b, 0x27, 0x70,
-	0xb0, 0x75, 0xb0, 0x37, 0xf5, 0x1f, 0xa9, 0x52, 0x9b, 0x63, 0xc4, 0x18, 0x1a, 0x39, 0x82, 0x96,
-	0x19, 0x78, 0xd5, 0x46, 0x6f, 0x7f, 0x27, 0x68, 0x85, 0x06, 0xa7, 0x00, 0x73, 0x59, 0x70, 0x9c,
-	0xc5, 0x98, 0x4b, 0xe5, 0x7c, 0x94, 0xad, 0x84, 0x44, 0xbe, 0xdd, 0x75, 0xb7, 0xca, 0xcc, 0x62,
-	0xf2, 0x1e, 0x38, 0x42, 0x91, 0x15, 0x68, 0x04, 0xb7, 0x85, 0xf9, 0x38, 0x98, 0x82, 0x73, 0x8e,
-	0xeb, 0x1f, 0x59, 0xb6, 0x42, 0xd2, 0x87, 0xa6, 0xda, 0x0c, 0x4b, 0x6f, 0x86, 0x3a, 0xaa, 0xde,
-	0xef, 0x14, 0xa4, 0xbf, 0xf2, 0xa8, 0x09, 0x82, 0x3f, 0x2c, 0xe8, 0x2b, 0xa3, 0xe6, 0x39, 0x2b,
-	0xc5, 0xa2, 0x90, 0x27, 0x4c, 0xb2, 0x1d, 0xe1, 0xd6, 0xdb, 0x84, 0xab, 0x2d, 0x48, 0xd2, 0x0c,
-	0x43, 0x91, 0xde, 0x63, 0x25, 0xc6, 0x51, 0x89, 0x79, 0x7a, 0x8f, 0xe4, 0x23, 0xb0, 0x63, 0x26,
-	0x99, 0xdf, 0x1c, 0x35, 0xc7, 0x9d, 0xe9, 0xab, 0x47, 0x66, 0xd5, 0x42, 0xa9, 0x26, 0x91, 0x8f,
-	0xc1, 0x56, 0x57, 0x54, 0x7f, 0x9e, 0xd7, 0x8f, 0xc8, 0xb5, 0xb8, 0x0b, 0x94, 0x8c, 0x6a, 0x62,
-	0x70, 0x09, 0xbd, 0x3a, 0xfb, 0xcd, 0xe9, 0x69, 0x9a, 0x21, 0xe9, 0x41, 0x23, 0x4a, 0xb4, 0x60,
-	0x97, 0x36, 0xa2, 0x44, 0x4d, 0x75, 0x47, 0x97, 0x3e, 0x93, 0x01, 0x38, 0xd1, 0x02, 0xa3, 0x5b,
-	0xb1, 0x32, 0x5b, 0xdb, 0xa5, 0x9b, 0x38, 0x38, 0x03, 0x6f, 0xf7, 0x1e, 0xf2, 0x05, 0x38, 0x51,
-	0x12, 0xaa, 0x76, 0x84, 0x6f, 0xe9, 0x1e, 0xde, 0xfc, 0x8f, 0x2c, 0x23, 0x80, 0xb6, 0xa3, 0x44,
-	0xfd, 0x8a, 0xe0, 0x27, 0xe8, 0x6e, 0xa0, 0xc5, 0x2a, 0xbf, 0x25, 0x9f, 0x6d, 0x9f, 0x13, 0x63,
-	0xe8, 0xe0, 0x99, 0x85, 0x7e, 0xf2, 0xb0, 0x90, 0xca, 0x40, 0x33, 0x2f, 0x7d, 0x0e, 0x5a, 0x60,
-	0x9f, 0x14, 0x39, 0x1e, 0x1f, 0x81, 0xbb, 0x59, 0x37, 0x02, 0xd0, 0xfa, 0xa1, 0xe0, 0x4b, 0x96,
-	0xf5, 0x5f, 0x90, 0x2e, 0xb8, 0x9b, 0xf7, 0xa3, 0xdf, 0xf8, 0xba, 0xff, 0xd7, 0xc3, 0xd0, 0xfa,
-	0xfb, 0x61, 0x68, 0xfd, 0xf3, 0x30, 0xb4, 0x7e, 0xff, 0x77, 0xf8, 0xe2, 0xba, 0xa5, 0x1f, 0xd8,
-	0x4f, 0xff, 0x0b, 0x00, 0x00, 0xff, 0xff, 0x79, 0xcc, 0xfd, 0xcf, 0xa3, 0x05, 0x00, 0x00,
+func init() { proto.RegisterFile("raft_serverpb.proto", fileDescriptor_raft_serverpb_bd195e7effb233ce) }
+
+var fileDescriptor_raft_serverpb_bd195e7effb233ce = []byte{
+	// 1234 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0xcd, 0x6e, 0x14, 0xc7,
+	0x13, 0x67, 0x76, 0x97, 0xdd, 0x99, 0xda, 0xf5, 0x7a, 0x69, 0xff, 0xff, 0x61, 0xb0, 0x65, 0xc7,
+	0x4c, 0x08, 0x72, 0x88, 0x64, 0x14, 0x93, 0xa0, 0x28, 0x07, 0x24, 0x30, 0x20, 0x1c, 0x30, 0x42,
+	0x6d, 0x87, 0xeb, 0xa8, 0x99, 0xa9, 0xdd, 0x1d, 0x79, 0xe7, 0x43, 0xdd, 0xbd, 0x88, 0xe5, 0x12,
+	0xe5, 0x94, 0x57, 0xc8, 0x25, 0xca, 0x2d, 0x87, 0x1c, 0x72, 0xcb, 0x3b, 0xe4, 0x98, 0x47, 0x88,
+	0x88, 0x94, 0xe7, 0x88, 0xfa, 0x63, 0x66, 0x3f, 0x8d, 0xcc, 0x69, 0xba, 0xaa, 0x7e, 0x53, 0xfd,
+	0xab, 0x8f, 0xae, 0x6e, 0xd8, 0xe0, 0xac, 0x2f, 0x43, 0x81, 0xfc, 0x35, 0xf2, 0xe2, 0xd5, 0x7e,
+	0xc1, 0x73, 0x99, 0x93, 0xb5, 0x39, 0xe5, 0xe6, 0x1a, 0x2a, 0xb9, 0xb4, 0x6e, 0x76, 0x52, 0x94,
+	0xac, 0x94, 0x82, 0xdf, 0x1a, 0xd0, 0xa6, 0xac, 0x2f, 0x8f, 0x51, 0x08, 0x36, 0x40, 0xb2, 0x05,
+	0x1e, 0xc7, 0x41, 0x92, 0x67, 0x61, 0x12, 0xfb, 0xce, 0xae, 0xb3, 0xd7, 0xa0, 0xae, 0x51, 0x1c,
+	0xc5, 0xe4, 0x33, 0xf0, 0xfa, 0x3c, 0x4f, 0xc3, 0x02, 0x91, 0xfb, 0xb5, 0x5d, 0x67, 0xaf, 0x7d,
+	0xd0, 0xd9, 0xb7, 0xee, 0x5e, 0x20, 0x72, 0xea, 0x2a, 0xb3, 0x5a, 0x91, 0x4f, 0xa1, 0x25, 0x73,
+	0x03, 0xac, 0xaf, 0x00, 0x36, 0x65, 0xae, 0x61, 0xb7, 0xa0, 0x95, 0x9a, 0x9d, 0xfd, 0x86, 0x86,
+	0xf5, 0xf6, 0x4b, 0xb6, 0x96, 0x11, 0x2d, 0x01, 0xe4, 0x2e, 0x74, 0x2c, 0x35, 0x2c, 0xf2, 0x68,
+	0xe8, 0x5f, 0xd6, 0x3f, 0x6c, 0x94, 0x7e, 0xa9, 0xb6, 0x3d, 0x52, 0x26, 0xda, 0xe6, 0x53, 0x81,
+	0x5c, 0x87, 0x4e, 0x22, 0x42, 0x99, 0xa7, 0xaf, 0x84, 0xcc, 0x33, 0xf4, 0x9b, 0xbb, 0xce, 0x9e,
+	0x4b, 0xdb, 0x89, 0x38, 0x2d, 0x55, 0x2a, 0x6a, 0x21, 0x19, 0x97, 0xe1, 0x19, 0x4e, 0xfc, 0xd6,
+	0xae, 0xb3, 0xd7, 0xa1, 0xae, 0x56, 0x3c, 0xc5, 0x09, 0xb9, 0x0a, 0x2d, 0xcc, 0x62, 0x6d, 0x72,
+	0xb5, 0xa9, 0x89, 0x59, 0xac, 0x0c, 0x14, 0x36, 0x44, 0xc6, 0x0a, 0x31, 0xcc, 0x65, 0x18, 0xe3,
+	0x08, 0x07, 0x4c, 0x26, 0x79, 0xe6, 0x7b, 0x9a, 0xd7, 0xf5, 0xfd, 0xf9, 0xd2, 0x9c, 0x58, 0xe4,
+	0xc3, 0x0a, 0x48, 0x89, 0x58, 0xd2, 0x91, 0x23, 0xe8, 0x55, 0x3e, 0x59, 0x51, 0x8c, 0x12, 0x8c,
+	0x7d, 0xd0, 0x0e, 0x77, 0xce, 0x71, 0x78, 0xdf, 0xa0, 0xe8, 0xba, 0x98, 0x57, 0x90, 0x17, 0xb0,
+	0x11, 0x0d, 0x31, 0x3a, 0x2b, 0xf2, 0x24, 0x93, 0x61, 0xc4, 0x64, 0x34, 0x0c, 0xc7, 0x85, 0xdf,
+	0xd6, 0xde, 0x76, 0x17, 0xbc, 0x1d, 0x56, 0xc8, 0x43, 0x05, 0xfc, 0xae, 0xa0, 0x57, 0xa2, 0x45,
+	0x55, 0xf0, 0xb3, 0x03, 0x57, 0x96, 0x80, 0xe4, 0x7f, 0x70, 0x39, 0xc9, 0x62, 0x7c, 0x63, 0xdb,
+	0xc5, 0x08, 0x84, 0x40, 0x43, 0x22, 0x4f, 0x75, 0x9b, 0x34, 0xa8, 0x5e, 0x93, 0x9b, 0xd0, 0x34,
+	0x85, 0xb1, 0x3d, 0xd1, 0x9d, 0xaf, 0x1d, 0xb5, 0x56, 0xb2, 0x09, 0x2e, 0x47, 0x51, 0xe4, 0x99,
+	0x30, 0x6d, 0xe1, 0xd2, 0x4a, 0x56, 0x36, 0x16, 0x45, 0x58, 0x48, 0x8c, 0x75, 0x07, 0xb8, 0xb4,
+	0x92, 0x83, 0x01, 0xac, 0x2f, 0x64, 0xe5, 0x03, 0xc8, 0xdd, 0x82, 0x2b, 0x2a, 0xe1, 0x93, 0x30,
+	0x1e, 0x73, 0x5d, 0x8b, 0x30, 0x15, 0x9a, 0x67, 0x83, 0xae, 0x6b, 0xc3, 0x43, 0xab, 0x3f, 0x16,
+	0xc1, 0x73, 0x20, 0xcb, 0xf5, 0x24, 0x37, 0xa0, 0x29, 0x19, 0x1f, 0xa0, 0xf4, 0x9d, 0x95, 0x2d,
+	0xaf, 0x6d, 0xab, 0xf6, 0x0e, 0x7e, 0x71, 0xa0, 0x67, 0x72, 0x30, 0x4d, 0xef, 0x4c, 0xb6, 0x9c,
+	0xf7, 0x66, 0xeb, 0x13, 0x58, 0xb3, 0x9d, 0x12, 0x9a, 0x50, 0x8d, 0xe7, 0x8e, 0x55, 0x1e, 0xe9,
+	0x88, 0xaf, 0x43, 0x29, 0x87, 0x7a, 0x77, 0x13, 0x58, 0xdb, 0xea, 0x4e, 0x55, 0x02, 0xb6, 0xc0,
+	0xc3, 0x37, 0x45, 0xc2, 0x31, 0x64, 0x52, 0xa7, 0xbd, 0x4e, 0x5d, 0xa3, 0xb8, 0x2f, 0x83, 0xef,
+	0xa1, 0xab, 0xc6, 0xc4, 0xb3, 0x3c, 0x62, 0xa3, 0x13, 0xc9, 0x24, 0x92, 0x2f, 0x00, 0x86, 0x8c,
+	0xc7, 0xa1, 0x50, 0x92, 0xa5, 0x48, 0xaa, 0xd3, 0xfb, 0x84, 0xf1, 0x58, 0xe3, 0xa8, 0x37, 0x2c,
+	0x97, 0x64, 0x1b, 0x60, 0xc4, 0x84, 0x9c, 0xa3, 0xe9, 0x29, 0x8d, 0xe1, 0xb8, 0x05, 0x5a, 0x98,
+	0x25, 0xe8, 0x2a, 0x85, 0x62, 0x17, 0xfc, 0xe0, 0x18, 0x06, 0xaa, 0xb0, 0x13, 0xe3, 0x6e, 0x29,
+	0x70, 0x67, 0x45, 0xe0, 0xdf, 0xc2, 0xba, 0xe4, 0xe3, 0x2c, 0x62, 0x12, 0x4b, 0xae, 0xb5, 0x95,
+	0x07, 0x54, 0x39, 0x3f, 0x2d, 0x91, 0x86, 0x7a, 0x57, 0xce, 0xc9, 0xc1, 0x3d, 0x20, 0xcb, 0xa8,
+	0x8b, 0xb7, 0x58, 0xf0, 0x6b, 0x55, 0xe6, 0x99, 0x3c, 0xee, 0xc3, 0xe5, 0x69, 0x0a, 0xbb, 0x07,
+	0xfe, 0x02, 0x2d, 0xd5, 0x3b, 0x86, 0x8d, 0x81, 0xcd, 0xb4, 0x45, 0xed, 0xbd, 0x6d, 0xf1, 0x0d,
+	0xb4, 0x53, 0xe4, 0x03, 0xb4, 0x41, 0x9b, 0x13, 0x77, 0x6d, 0xc1, 0xfb, 0xb1, 0x42, 0x18, 0xf7,
+	0x90, 0x56, 0xeb, 0x20, 0x01, 0x98, 0x5a, 0x54, 0x5d, 0xd2, 0x24, 0x9b, 0xcb, 0xb1, 0x9b, 0x26,
+	0x99, 0xc9, 0xef, 0xcd, 0xaa, 0xe9, 0xcf, 0xa1, 0x63, 0xac, 0xe4, 0x23, 0x68, 0x46, 0x79, 0x9a,
+	0x26, 0xd2, 0x56, 0xd6, 0x4a, 0xc1, 0x4d, 0xe8, 0x3e, 0xcb, 0xa3, 0xb3, 0x99, 0xbe, 0x5f, 0x99,
+	0xcf, 0xe0, 0x0c, 0xe0, 0x44, 0xe6, 0x1c, 0x8f, 0x62, 0xcc, 0xa4, 0xea, 0xa4, 0x68, 0x34, 0x16,
+	0x12, 0xf9, 0xf4, 0x9e, 0xf2, 0xac, 0xe6, 0x28, 0x26, 0xd7, 0xc0, 0x15, 0x0a, 0xac, 0x8c, 0xa6,
+	0x00, 0x2d, 0x61, 0x7e, 0x56, 0x4d, 0xd3, 0xc7, 0x2c, 0x4a, 0xb2, 0x41, 0x28, 0xf3, 0x33, 0xcc,
+	0x2c, 0x9d, 0x8e, 0x55, 0x9e, 0x2a, 0x5d, 0x70, 0x00, 0xee, 0x53, 0x9c, 0xbc, 0x64, 0xa3, 0x31,
+	0x92, 0x1e, 0xd4, 0xd5, 0xe8, 0x77, 0xf4, 0xe8, 0x57, 0x4b, 0x45, 0xf0, 0xb5, 0x32, 0x69, 0xd7,
+	0x1d, 0x6a, 0x84, 0xe0, 0x0f, 0x55, 0x5c, 0xd6, 0x97, 0xd5, 0x60, 0x60, 0x92, 0x5d, 0xf8, 0x0c,
+	0x6f, 0x81, 0xd7, 0x4f, 0x46, 0x18, 0x8a, 0xe4, 0x2d, 0x5a, 0xc6, 0xae, 0x52, 0x9c, 0x24, 0x6f,
+	0x91, 0x7c, 0x0e, 0x8d, 0x98, 0x49, 0xe6, 0xd7, 0x77, 0xeb, 0x7b, 0xed, 0x83, 0xab, 0x0b, 0x25,
+	0x2c, 0x89, 0x52, 0x0d, 0x22, 0xb7, 0xa1, 0xa1, 0xb6, 0xb0, 0xb7, 0xe3, 0xd6, 0x39, 0x97, 0xc6,
+	0x31, 0x4a, 0x46, 0x35, 0x30, 0x78, 0x01, 0xdd, 0x52, 0x7b, 0xf8, 0xf8, 0x71, 0x32, 0x42, 0xd2,
+	0x85, 0x5a, 0xd4, 0xd7, 0x84, 0x3d, 0x5a, 0x8b, 0xfa, 0xaa, 0x95, 0x67, 0x78, 0xe9, 0xb5, 0x1a,
+	0xc3, 0xfa, 0x7e, 0x10, 0x63, 0x73, 0x54, 0xd7, 0x68, 0x25, 0x07, 0x4f, 0xa0, 0x33, 0xbb, 0x0f,
+	0xf9, 0x1a, 0xdc, 0xa8, 0x1f, 0xaa, 0x70, 0x84, 0xef, 0xe8, 0x18, 0xb6, 0xcf, 0xa1, 0x65, 0x08,
+	0xd0, 0x56, 0xd4, 0x57, 0x5f, 0x11, 0xbc, 0x84, 0x5e, 0xe5, 0x89, 0x65, 0x49, 0x1f, 0x85, 0x24,
+	0x0f, 0xc0, 0x2b, 0x6f, 0xba, 0xd2, 0xdd, 0x8d, 0xf3, 0xa2, 0xb4, 0xff, 0x3c, 0xca, 0x24, 0x9f,
+	0xd0, 0xe9, 0x6f, 0xc1, 0xef, 0x0e, 0xfc, 0x7f, 0x25, 0xe8, 0xfd, 0xef, 0x9f, 0x55, 0xd7, 0x46,
+	0xd5, 0xad, 0xf5, 0xd9, 0xd3, 0xbf, 0x0d, 0x90, 0x88, 0x50, 0x60, 0x16, 0x27, 0xd9, 0xc0, 0xde,
+	0x61, 0x5e, 0x22, 0x4e, 0x8c, 0xe2, 0xc3, 0x8b, 0xf4, 0xaf, 0x03, 0x1d, 0xfb, 0xc0, 0x79, 0x53,
+	0xe4, 0x5c, 0x65, 0xa1, 0x7c, 0x0c, 0xcd, 0xce, 0xdf, 0x8f, 0x17, 0x67, 0xda, 0xc2, 0xb0, 0x29,
+	0x1f, 0x46, 0x5a, 0x20, 0xf7, 0xa0, 0x6d, 0x6e, 0xbc, 0xd9, 0xb1, 0xb8, 0xbd, 0x62, 0x2c, 0x4e,
+	0x67, 0x2e, 0x05, 0x56, 0xad, 0x2f, 0x72, 0xa7, 0xdc, 0x86, 0x7a, 0xd4, 0x17, 0x7e, 0x63, 0x65,
+	0xd5, 0x67, 0x03, 0x3a, 0x7c, 0x4c, 0x15, 0x32, 0x38, 0x86, 0xee, 0xbc, 0x7a, 0xa9, 0x1b, 0xcb,
+	0xd3, 0x50, 0xbb, 0xc0, 0x69, 0x08, 0x7e, 0x74, 0x60, 0xad, 0x6a, 0xae, 0xe1, 0x38, 0x3b, 0x23,
+	0x5f, 0x4e, 0x5f, 0x9c, 0x26, 0x67, 0x9b, 0x2b, 0x02, 0x5e, 0x7a, 0x7b, 0x92, 0x6a, 0x53, 0x75,
+	0xe2, 0xf5, 0x5a, 0x55, 0x3e, 0xe2, 0xd1, 0x9d, 0x03, 0xdb, 0xff, 0x46, 0x50, 0x73, 0x4e, 0x0c,
+	0xd9, 0xc1, 0x57, 0x77, 0x75, 0xd5, 0x3b, 0xd4, 0x4a, 0x41, 0x13, 0x1a, 0x0f, 0xf3, 0x0c, 0x6f,
+	0xdd, 0x01, 0xaf, 0x1a, 0xe9, 0x04, 0xa0, 0xf9, 0x3c, 0xe7, 0x29, 0x1b, 0xf5, 0x2e, 0x91, 0x35,
+	0xf0, 0xaa, 0x07, 0x69, 0xaf, 0x46, 0xda, 0xd0, 0x52, 0x23, 0x38, 0xc9, 0x06, 0xbd, 0xfa, 0x83,
+	0xde, 0x9f, 0xef, 0x76, 0x9c, 0xbf, 0xde, 0xed, 0x38, 0x7f, 0xbf, 0xdb, 0x71, 0x7e, 0xfa, 0x67,
+	0xe7, 0xd2, 0xab, 0xa6, 0x7e, 0xbe, 0xdf, 0xf9, 0x6f, 0x00, 0x80, 0x5b, 0x5f, 0xbd, 0x01, 0x0c,
+	0x00, 0x00,
 }
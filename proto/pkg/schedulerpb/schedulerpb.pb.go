@@ -42,6 +42,7 @@ const (
 	ErrorType_ALREADY_BOOTSTRAPPED ErrorType = 4
 	ErrorType_INCOMPATIBLE_VERSION ErrorType = 5
 	ErrorType_REGION_NOT_FOUND     ErrorType = 6
+	ErrorType_STORE_FENCED         ErrorType = 7
 )
 
 var ErrorType_name = map[int32]string{
@@ -52,6 +53,7 @@ var ErrorType_name = map[int32]string{
 	4: "ALREADY_BOOTSTRAPPED",
 	5: "INCOMPATIBLE_VERSION",
 	6: "REGION_NOT_FOUND",
+	7: "STORE_FENCED",
 }
 var ErrorType_value = map[string]int32{
 	"OK":                   0,
@@ -61,13 +63,14 @@ var ErrorType_value = map[string]int32{
 	"ALREADY_BOOTSTRAPPED": 4,
 	"INCOMPATIBLE_VERSION": 5,
 	"REGION_NOT_FOUND":     6,
+	"STORE_FENCED":         7,
 }
 
 func (x ErrorType) String() string {
 	return proto.EnumName(ErrorType_name, int32(x))
 }
 func (ErrorType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{0}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{0}
 }
 
 type OperatorStatus int32
@@ -99,7 +102,7 @@ func (x OperatorStatus) String() string {
 	return proto.EnumName(OperatorStatus_name, int32(x))
 }
 func (OperatorStatus) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{1}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{1}
 }
 
 type RequestHeader struct {
@@ -114,7 +117,7 @@ func (m *RequestHeader) Reset()         { *m = RequestHeader{} }
 func (m *RequestHeader) String() string { return proto.CompactTextString(m) }
 func (*RequestHeader) ProtoMessage()    {}
 func (*RequestHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{0}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{0}
 }
 func (m *RequestHeader) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -163,7 +166,7 @@ func (m *ResponseHeader) Reset()         { *m = ResponseHeader{} }
 func (m *ResponseHeader) String() string { return proto.CompactTextString(m) }
 func (*ResponseHeader) ProtoMessage()    {}
 func (*ResponseHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{1}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{1}
 }
 func (m *ResponseHeader) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -218,7 +221,7 @@ func (m *Error) Reset()         { *m = Error{} }
 func (m *Error) String() string { return proto.CompactTextString(m) }
 func (*Error) ProtoMessage()    {}
 func (*Error) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{2}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{2}
 }
 func (m *Error) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -273,7 +276,7 @@ func (m *TsoRequest) Reset()         { *m = TsoRequest{} }
 func (m *TsoRequest) String() string { return proto.CompactTextString(m) }
 func (*TsoRequest) ProtoMessage()    {}
 func (*TsoRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{3}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{3}
 }
 func (m *TsoRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -328,7 +331,7 @@ func (m *Timestamp) Reset()         { *m = Timestamp{} }
 func (m *Timestamp) String() string { return proto.CompactTextString(m) }
 func (*Timestamp) ProtoMessage()    {}
 func (*Timestamp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{4}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{4}
 }
 func (m *Timestamp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -384,7 +387,7 @@ func (m *TsoResponse) Reset()         { *m = TsoResponse{} }
 func (m *TsoResponse) String() string { return proto.CompactTextString(m) }
 func (*TsoResponse) ProtoMessage()    {}
 func (*TsoResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{5}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{5}
 }
 func (m *TsoResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -446,7 +449,7 @@ func (m *BootstrapRequest) Reset()         { *m = BootstrapRequest{} }
 func (m *BootstrapRequest) String() string { return proto.CompactTextString(m) }
 func (*BootstrapRequest) ProtoMessage()    {}
 func (*BootstrapRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{6}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{6}
 }
 func (m *BootstrapRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -500,7 +503,7 @@ func (m *BootstrapResponse) Reset()         { *m = BootstrapResponse{} }
 func (m *BootstrapResponse) String() string { return proto.CompactTextString(m) }
 func (*BootstrapResponse) ProtoMessage()    {}
 func (*BootstrapResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{7}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{7}
 }
 func (m *BootstrapResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -547,7 +550,7 @@ func (m *IsBootstrappedRequest) Reset()         { *m = IsBootstrappedRequest{} }
 func (m *IsBootstrappedRequest) String() string { return proto.CompactTextString(m) }
 func (*IsBootstrappedRequest) ProtoMessage()    {}
 func (*IsBootstrappedRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{8}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{8}
 }
 func (m *IsBootstrappedRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -595,7 +598,7 @@ func (m *IsBootstrappedResponse) Reset()         { *m = IsBootstrappedResponse{}
 func (m *IsBootstrappedResponse) String() string { return proto.CompactTextString(m) }
 func (*IsBootstrappedResponse) ProtoMessage()    {}
 func (*IsBootstrappedResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{9}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{9}
 }
 func (m *IsBootstrappedResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -649,7 +652,7 @@ func (m *AllocIDRequest) Reset()         { *m = AllocIDRequest{} }
 func (m *AllocIDRequest) String() string { return proto.CompactTextString(m) }
 func (*AllocIDRequest) ProtoMessage()    {}
 func (*AllocIDRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{10}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{10}
 }
 func (m *AllocIDRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -697,7 +700,7 @@ func (m *AllocIDResponse) Reset()         { *m = AllocIDResponse{} }
 func (m *AllocIDResponse) String() string { return proto.CompactTextString(m) }
 func (*AllocIDResponse) ProtoMessage()    {}
 func (*AllocIDResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{11}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{11}
 }
 func (m *AllocIDResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -752,7 +755,7 @@ func (m *GetStoreRequest) Reset()         { *m = GetStoreRequest{} }
 func (m *GetStoreRequest) String() string { return proto.CompactTextString(m) }
 func (*GetStoreRequest) ProtoMessage()    {}
 func (*GetStoreRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{12}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{12}
 }
 func (m *GetStoreRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -808,7 +811,7 @@ func (m *GetStoreResponse) Reset()         { *m = GetStoreResponse{} }
 func (m *GetStoreResponse) String() string { return proto.CompactTextString(m) }
 func (*GetStoreResponse) ProtoMessage()    {}
 func (*GetStoreResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{13}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{13}
 }
 func (m *GetStoreResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -870,7 +873,7 @@ func (m *PutStoreRequest) Reset()         { *m = PutStoreRequest{} }
 func (m *PutStoreRequest) String() string { return proto.CompactTextString(m) }
 func (*PutStoreRequest) ProtoMessage()    {}
 func (*PutStoreRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{14}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{14}
 }
 func (m *PutStoreRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -924,7 +927,7 @@ func (m *PutStoreResponse) Reset()         { *m = PutStoreResponse{} }
 func (m *PutStoreResponse) String() string { return proto.CompactTextString(m) }
 func (*PutStoreResponse) ProtoMessage()    {}
 func (*PutStoreResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{15}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{15}
 }
 func (m *PutStoreResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -973,7 +976,7 @@ func (m *GetAllStoresRequest) Reset()         { *m = GetAllStoresRequest{} }
 func (m *GetAllStoresRequest) String() string { return proto.CompactTextString(m) }
 func (*GetAllStoresRequest) ProtoMessage()    {}
 func (*GetAllStoresRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{16}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{16}
 }
 func (m *GetAllStoresRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1028,7 +1031,7 @@ func (m *GetAllStoresResponse) Reset()         { *m = GetAllStoresResponse{} }
 func (m *GetAllStoresResponse) String() string { return proto.CompactTextString(m) }
 func (*GetAllStoresResponse) ProtoMessage()    {}
 func (*GetAllStoresResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{17}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{17}
 }
 func (m *GetAllStoresResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1083,7 +1086,7 @@ func (m *GetRegionRequest) Reset()         { *m = GetRegionRequest{} }
 func (m *GetRegionRequest) String() string { return proto.CompactTextString(m) }
 func (*GetRegionRequest) ProtoMessage()    {}
 func (*GetRegionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{18}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{18}
 }
 func (m *GetRegionRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1140,7 +1143,7 @@ func (m *GetRegionResponse) Reset()         { *m = GetRegionResponse{} }
 func (m *GetRegionResponse) String() string { return proto.CompactTextString(m) }
 func (*GetRegionResponse) ProtoMessage()    {}
 func (*GetRegionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{19}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{19}
 }
 func (m *GetRegionResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1209,7 +1212,7 @@ func (m *GetRegionByIDRequest) Reset()         { *m = GetRegionByIDRequest{} }
 func (m *GetRegionByIDRequest) String() string { return proto.CompactTextString(m) }
 func (*GetRegionByIDRequest) ProtoMessage()    {}
 func (*GetRegionByIDRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{20}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{20}
 }
 func (m *GetRegionByIDRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1266,7 +1269,7 @@ func (m *ScanRegionsRequest) Reset()         { *m = ScanRegionsRequest{} }
 func (m *ScanRegionsRequest) String() string { return proto.CompactTextString(m) }
 func (*ScanRegionsRequest) ProtoMessage()    {}
 func (*ScanRegionsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{21}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{21}
 }
 func (m *ScanRegionsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1336,7 +1339,7 @@ func (m *ScanRegionsResponse) Reset()         { *m = ScanRegionsResponse{} }
 func (m *ScanRegionsResponse) String() string { return proto.CompactTextString(m) }
 func (*ScanRegionsResponse) ProtoMessage()    {}
 func (*ScanRegionsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{22}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{22}
 }
 func (m *ScanRegionsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1397,7 +1400,7 @@ func (m *GetClusterConfigRequest) Reset()         { *m = GetClusterConfigRequest
 func (m *GetClusterConfigRequest) String() string { return proto.CompactTextString(m) }
 func (*GetClusterConfigRequest) ProtoMessage()    {}
 func (*GetClusterConfigRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{23}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{23}
 }
 func (m *GetClusterConfigRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1445,7 +1448,7 @@ func (m *GetClusterConfigResponse) Reset()         { *m = GetClusterConfigRespon
 func (m *GetClusterConfigResponse) String() string { return proto.CompactTextString(m) }
 func (*GetClusterConfigResponse) ProtoMessage()    {}
 func (*GetClusterConfigResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{24}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{24}
 }
 func (m *GetClusterConfigResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1500,7 +1503,7 @@ func (m *PutClusterConfigRequest) Reset()         { *m = PutClusterConfigRequest
 func (m *PutClusterConfigRequest) String() string { return proto.CompactTextString(m) }
 func (*PutClusterConfigRequest) ProtoMessage()    {}
 func (*PutClusterConfigRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{25}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{25}
 }
 func (m *PutClusterConfigRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1554,7 +1557,7 @@ func (m *PutClusterConfigResponse) Reset()         { *m = PutClusterConfigRespon
 func (m *PutClusterConfigResponse) String() string { return proto.CompactTextString(m) }
 func (*PutClusterConfigResponse) ProtoMessage()    {}
 func (*PutClusterConfigResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{26}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{26}
 }
 func (m *PutClusterConfigResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1607,7 +1610,7 @@ func (m *Member) Reset()         { *m = Member{} }
 func (m *Member) String() string { return proto.CompactTextString(m) }
 func (*Member) ProtoMessage()    {}
 func (*Member) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{27}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{27}
 }
 func (m *Member) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1682,7 +1685,7 @@ func (m *GetMembersRequest) Reset()         { *m = GetMembersRequest{} }
 func (m *GetMembersRequest) String() string { return proto.CompactTextString(m) }
 func (*GetMembersRequest) ProtoMessage()    {}
 func (*GetMembersRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{28}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{28}
 }
 func (m *GetMembersRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1732,7 +1735,7 @@ func (m *GetMembersResponse) Reset()         { *m = GetMembersResponse{} }
 func (m *GetMembersResponse) String() string { return proto.CompactTextString(m) }
 func (*GetMembersResponse) ProtoMessage()    {}
 func (*GetMembersResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{29}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{29}
 }
 func (m *GetMembersResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1798,17 +1801,22 @@ type RegionHeartbeatRequest struct {
 	// working followers.
 	PendingPeers []*metapb.Peer `protobuf:"bytes,5,rep,name=pending_peers,json=pendingPeers" json:"pending_peers,omitempty"`
 	// Approximate region size.
-	ApproximateSize      uint64   `protobuf:"varint,10,opt,name=approximate_size,json=approximateSize,proto3" json:"approximate_size,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	ApproximateSize uint64 `protobuf:"varint,10,opt,name=approximate_size,json=approximateSize,proto3" json:"approximate_size,omitempty"`
+	// Approximate number of keys.
+	ApproximateKeys uint64 `protobuf:"varint,11,opt,name=approximate_keys,json=approximateKeys,proto3" json:"approximate_keys,omitempty"`
+	// The snapshots applied by the peers since the previous heartbeat, the
+	// peers are freshly seeded with the data of the snapshots.
+	SnapshotsApplied     []*SnapshotApplied `protobuf:"bytes,12,rep,name=snapshots_applied,json=snapshotsApplied" json:"snapshots_applied,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *RegionHeartbeatRequest) Reset()         { *m = RegionHeartbeatRequest{} }
 func (m *RegionHeartbeatRequest) String() string { return proto.CompactTextString(m) }
 func (*RegionHeartbeatRequest) ProtoMessage()    {}
 func (*RegionHeartbeatRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{30}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{30}
 }
 func (m *RegionHeartbeatRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1872,6 +1880,92 @@ func (m *RegionHeartbeatRequest) GetApproximateSize() uint64 {
 	return 0
 }
 
+func (m *RegionHeartbeatRequest) GetApproximateKeys() uint64 {
+	if m != nil {
+		return m.ApproximateKeys
+	}
+	return 0
+}
+
+func (m *RegionHeartbeatRequest) GetSnapshotsApplied() []*SnapshotApplied {
+	if m != nil {
+		return m.SnapshotsApplied
+	}
+	return nil
+}
+
+type SnapshotApplied struct {
+	Peer  *metapb.Peer `protobuf:"bytes,1,opt,name=peer" json:"peer,omitempty"`
+	Index uint64       `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	Term  uint64       `protobuf:"varint,3,opt,name=term,proto3" json:"term,omitempty"`
+	// The time from the snapshot being received to being applied.
+	ApplyDurationMs      uint64   `protobuf:"varint,4,opt,name=apply_duration_ms,json=applyDurationMs,proto3" json:"apply_duration_ms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotApplied) Reset()         { *m = SnapshotApplied{} }
+func (m *SnapshotApplied) String() string { return proto.CompactTextString(m) }
+func (*SnapshotApplied) ProtoMessage()    {}
+func (*SnapshotApplied) Descriptor() ([]byte, []int) {
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{31}
+}
+func (m *SnapshotApplied) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SnapshotApplied) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SnapshotApplied.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *SnapshotApplied) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotApplied.Merge(dst, src)
+}
+func (m *SnapshotApplied) XXX_Size() int {
+	return m.Size()
+}
+func (m *SnapshotApplied) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotApplied.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotApplied proto.InternalMessageInfo
+
+func (m *SnapshotApplied) GetPeer() *metapb.Peer {
+	if m != nil {
+		return m.Peer
+	}
+	return nil
+}
+
+func (m *SnapshotApplied) GetIndex() uint64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *SnapshotApplied) GetTerm() uint64 {
+	if m != nil {
+		return m.Term
+	}
+	return 0
+}
+
+func (m *SnapshotApplied) GetApplyDurationMs() uint64 {
+	if m != nil {
+		return m.ApplyDurationMs
+	}
+	return 0
+}
+
 type ChangePeer struct {
 	Peer                 *metapb.Peer           `protobuf:"bytes,1,opt,name=peer" json:"peer,omitempty"`
 	ChangeType           eraftpb.ConfChangeType `protobuf:"varint,2,opt,name=change_type,json=changeType,proto3,enum=eraftpb.ConfChangeType" json:"change_type,omitempty"`
@@ -1884,7 +1978,7 @@ func (m *ChangePeer) Reset()         { *m = ChangePeer{} }
 func (m *ChangePeer) String() string { return proto.CompactTextString(m) }
 func (*ChangePeer) ProtoMessage()    {}
 func (*ChangePeer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{31}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{32}
 }
 func (m *ChangePeer) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1938,7 +2032,7 @@ func (m *TransferLeader) Reset()         { *m = TransferLeader{} }
 func (m *TransferLeader) String() string { return proto.CompactTextString(m) }
 func (*TransferLeader) ProtoMessage()    {}
 func (*TransferLeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{32}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{33}
 }
 func (m *TransferLeader) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1974,6 +2068,54 @@ func (m *TransferLeader) GetPeer() *metapb.Peer {
 	return nil
 }
 
+type Merge struct {
+	// The adjacent region the region is merged into.
+	Target               *metapb.Region `protobuf:"bytes,1,opt,name=target" json:"target,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *Merge) Reset()         { *m = Merge{} }
+func (m *Merge) String() string { return proto.CompactTextString(m) }
+func (*Merge) ProtoMessage()    {}
+func (*Merge) Descriptor() ([]byte, []int) {
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{34}
+}
+func (m *Merge) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Merge) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Merge.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *Merge) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Merge.Merge(dst, src)
+}
+func (m *Merge) XXX_Size() int {
+	return m.Size()
+}
+func (m *Merge) XXX_DiscardUnknown() {
+	xxx_messageInfo_Merge.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Merge proto.InternalMessageInfo
+
+func (m *Merge) GetTarget() *metapb.Region {
+	if m != nil {
+		return m.Target
+	}
+	return nil
+}
+
 type RegionHeartbeatResponse struct {
 	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
 	// Notice, Scheduleeer only allows handling reported epoch >= current scheduler's.
@@ -1997,17 +2139,20 @@ type RegionHeartbeatResponse struct {
 	RegionId    uint64              `protobuf:"varint,4,opt,name=region_id,json=regionId,proto3" json:"region_id,omitempty"`
 	RegionEpoch *metapb.RegionEpoch `protobuf:"bytes,5,opt,name=region_epoch,json=regionEpoch" json:"region_epoch,omitempty"`
 	// Leader of the region at the moment of the corresponding request was made.
-	TargetPeer           *metapb.Peer `protobuf:"bytes,6,opt,name=target_peer,json=targetPeer" json:"target_peer,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	TargetPeer *metapb.Peer `protobuf:"bytes,6,opt,name=target_peer,json=targetPeer" json:"target_peer,omitempty"`
+	// Scheduler can return merge to let the region merge itself into an
+	// adjacent region.
+	Merge                *Merge   `protobuf:"bytes,7,opt,name=merge" json:"merge,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *RegionHeartbeatResponse) Reset()         { *m = RegionHeartbeatResponse{} }
 func (m *RegionHeartbeatResponse) String() string { return proto.CompactTextString(m) }
 func (*RegionHeartbeatResponse) ProtoMessage()    {}
 func (*RegionHeartbeatResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{33}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{35}
 }
 func (m *RegionHeartbeatResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2078,6 +2223,13 @@ func (m *RegionHeartbeatResponse) GetTargetPeer() *metapb.Peer {
 	return nil
 }
 
+func (m *RegionHeartbeatResponse) GetMerge() *Merge {
+	if m != nil {
+		return m.Merge
+	}
+	return nil
+}
+
 type AskSplitRequest struct {
 	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
 	Region               *metapb.Region `protobuf:"bytes,2,opt,name=region" json:"region,omitempty"`
@@ -2090,7 +2242,7 @@ func (m *AskSplitRequest) Reset()         { *m = AskSplitRequest{} }
 func (m *AskSplitRequest) String() string { return proto.CompactTextString(m) }
 func (*AskSplitRequest) ProtoMessage()    {}
 func (*AskSplitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{34}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{36}
 }
 func (m *AskSplitRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2150,7 +2302,7 @@ func (m *AskSplitResponse) Reset()         { *m = AskSplitResponse{} }
 func (m *AskSplitResponse) String() string { return proto.CompactTextString(m) }
 func (*AskSplitResponse) ProtoMessage()    {}
 func (*AskSplitResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{35}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{37}
 }
 func (m *AskSplitResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2213,7 +2365,7 @@ func (m *ReportSplitRequest) Reset()         { *m = ReportSplitRequest{} }
 func (m *ReportSplitRequest) String() string { return proto.CompactTextString(m) }
 func (*ReportSplitRequest) ProtoMessage()    {}
 func (*ReportSplitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{36}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{38}
 }
 func (m *ReportSplitRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2274,7 +2426,7 @@ func (m *ReportSplitResponse) Reset()         { *m = ReportSplitResponse{} }
 func (m *ReportSplitResponse) String() string { return proto.CompactTextString(m) }
 func (*ReportSplitResponse) ProtoMessage()    {}
 func (*ReportSplitResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{37}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{39}
 }
 func (m *ReportSplitResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2322,7 +2474,7 @@ func (m *SplitID) Reset()         { *m = SplitID{} }
 func (m *SplitID) String() string { return proto.CompactTextString(m) }
 func (*SplitID) ProtoMessage()    {}
 func (*SplitID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{38}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{40}
 }
 func (m *SplitID) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2379,7 +2531,7 @@ func (m *TimeInterval) Reset()         { *m = TimeInterval{} }
 func (m *TimeInterval) String() string { return proto.CompactTextString(m) }
 func (*TimeInterval) ProtoMessage()    {}
 func (*TimeInterval) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{39}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{41}
 }
 func (m *TimeInterval) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2434,7 +2586,7 @@ func (m *RecordPair) Reset()         { *m = RecordPair{} }
 func (m *RecordPair) String() string { return proto.CompactTextString(m) }
 func (*RecordPair) ProtoMessage()    {}
 func (*RecordPair) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{40}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{42}
 }
 func (m *RecordPair) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2506,17 +2658,44 @@ type StoreStats struct {
 	// Threads' write disk I/O rates in the store
 	WriteIoRates []*RecordPair `protobuf:"bytes,18,rep,name=write_io_rates,json=writeIoRates" json:"write_io_rates,omitempty"`
 	// Operations' latencies in the store
-	OpLatencies          []*RecordPair `protobuf:"bytes,19,rep,name=op_latencies,json=opLatencies" json:"op_latencies,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+	OpLatencies []*RecordPair `protobuf:"bytes,19,rep,name=op_latencies,json=opLatencies" json:"op_latencies,omitempty"`
+	// Offset of the scheduler clock to the store clock in nanoseconds, as
+	// measured by the store on its previous heartbeat.
+	ClockOffset int64 `protobuf:"varint,20,opt,name=clock_offset,json=clockOffset,proto3" json:"clock_offset,omitempty"`
+	// If the store is busy with a heavy background compaction, it's avoided
+	// as a target of new peers and leaders meanwhile.
+	IsCompacting bool `protobuf:"varint,21,opt,name=is_compacting,json=isCompacting,proto3" json:"is_compacting,omitempty"`
+	// Number of leaders transferred away since the previous heartbeat because
+	// the writes on the store stayed slow, e.g. for a degraded disk. The store
+	// is avoided as a target of leaders meanwhile.
+	SlowLeaderTransfers uint32 `protobuf:"varint,22,opt,name=slow_leader_transfers,json=slowLeaderTransfers,proto3" json:"slow_leader_transfers,omitempty"`
+	// Usage of the disks of the data directories, e.g. the raft engine may be
+	// placed on a separate disk from the kv engine.
+	DiskStats []*DiskStats `protobuf:"bytes,23,rep,name=disk_stats,json=diskStats" json:"disk_stats,omitempty"`
+	// Current generating snapshot count.
+	GeneratingSnapCount uint32 `protobuf:"varint,24,opt,name=generating_snap_count,json=generatingSnapCount,proto3" json:"generating_snap_count,omitempty"`
+	// Total size of the snapshots being sent, received and applied, the
+	// scheduler keeps them within its snapshot budgets.
+	SendingSnapBytes   uint64 `protobuf:"varint,25,opt,name=sending_snap_bytes,json=sendingSnapBytes,proto3" json:"sending_snap_bytes,omitempty"`
+	ReceivingSnapBytes uint64 `protobuf:"varint,26,opt,name=receiving_snap_bytes,json=receivingSnapBytes,proto3" json:"receiving_snap_bytes,omitempty"`
+	ApplyingSnapBytes  uint64 `protobuf:"varint,27,opt,name=applying_snap_bytes,json=applyingSnapBytes,proto3" json:"applying_snap_bytes,omitempty"`
+	// Number of peers created explicitly and still waiting for their first
+	// snapshot from the leader.
+	BootstrappingPeerCount uint32 `protobuf:"varint,28,opt,name=bootstrapping_peer_count,json=bootstrappingPeerCount,proto3" json:"bootstrapping_peer_count,omitempty"`
+	// Bytes per second of the snapshots sent and received since the previous
+	// heartbeat.
+	SnapSendThroughput   uint64   `protobuf:"varint,29,opt,name=snap_send_throughput,json=snapSendThroughput,proto3" json:"snap_send_throughput,omitempty"`
+	SnapRecvThroughput   uint64   `protobuf:"varint,30,opt,name=snap_recv_throughput,json=snapRecvThroughput,proto3" json:"snap_recv_throughput,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *StoreStats) Reset()         { *m = StoreStats{} }
 func (m *StoreStats) String() string { return proto.CompactTextString(m) }
 func (*StoreStats) ProtoMessage()    {}
 func (*StoreStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{41}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{43}
 }
 func (m *StoreStats) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2650,6 +2829,155 @@ func (m *StoreStats) GetOpLatencies() []*RecordPair {
 	return nil
 }
 
+func (m *StoreStats) GetClockOffset() int64 {
+	if m != nil {
+		return m.ClockOffset
+	}
+	return 0
+}
+
+func (m *StoreStats) GetIsCompacting() bool {
+	if m != nil {
+		return m.IsCompacting
+	}
+	return false
+}
+
+func (m *StoreStats) GetSlowLeaderTransfers() uint32 {
+	if m != nil {
+		return m.SlowLeaderTransfers
+	}
+	return 0
+}
+
+func (m *StoreStats) GetDiskStats() []*DiskStats {
+	if m != nil {
+		return m.DiskStats
+	}
+	return nil
+}
+
+func (m *StoreStats) GetGeneratingSnapCount() uint32 {
+	if m != nil {
+		return m.GeneratingSnapCount
+	}
+	return 0
+}
+
+func (m *StoreStats) GetSendingSnapBytes() uint64 {
+	if m != nil {
+		return m.SendingSnapBytes
+	}
+	return 0
+}
+
+func (m *StoreStats) GetReceivingSnapBytes() uint64 {
+	if m != nil {
+		return m.ReceivingSnapBytes
+	}
+	return 0
+}
+
+func (m *StoreStats) GetApplyingSnapBytes() uint64 {
+	if m != nil {
+		return m.ApplyingSnapBytes
+	}
+	return 0
+}
+
+func (m *StoreStats) GetBootstrappingPeerCount() uint32 {
+	if m != nil {
+		return m.BootstrappingPeerCount
+	}
+	return 0
+}
+
+func (m *StoreStats) GetSnapSendThroughput() uint64 {
+	if m != nil {
+		return m.SnapSendThroughput
+	}
+	return 0
+}
+
+func (m *StoreStats) GetSnapRecvThroughput() uint64 {
+	if m != nil {
+		return m.SnapRecvThroughput
+	}
+	return 0
+}
+
+type DiskStats struct {
+	// What's stored in the directory, e.g. "kv", "raft" or "snap".
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Path                 string   `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Capacity             uint64   `protobuf:"varint,3,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	Available            uint64   `protobuf:"varint,4,opt,name=available,proto3" json:"available,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DiskStats) Reset()         { *m = DiskStats{} }
+func (m *DiskStats) String() string { return proto.CompactTextString(m) }
+func (*DiskStats) ProtoMessage()    {}
+func (*DiskStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{44}
+}
+func (m *DiskStats) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DiskStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DiskStats.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *DiskStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiskStats.Merge(dst, src)
+}
+func (m *DiskStats) XXX_Size() int {
+	return m.Size()
+}
+func (m *DiskStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiskStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DiskStats proto.InternalMessageInfo
+
+func (m *DiskStats) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *DiskStats) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *DiskStats) GetCapacity() uint64 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
+}
+
+func (m *DiskStats) GetAvailable() uint64 {
+	if m != nil {
+		return m.Available
+	}
+	return 0
+}
+
 type StoreHeartbeatRequest struct {
 	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
 	Stats                *StoreStats    `protobuf:"bytes,2,opt,name=stats" json:"stats,omitempty"`
@@ -2662,7 +2990,7 @@ func (m *StoreHeartbeatRequest) Reset()         { *m = StoreHeartbeatRequest{} }
 func (m *StoreHeartbeatRequest) String() string { return proto.CompactTextString(m) }
 func (*StoreHeartbeatRequest) ProtoMessage()    {}
 func (*StoreHeartbeatRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{42}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{45}
 }
 func (m *StoreHeartbeatRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2706,17 +3034,22 @@ func (m *StoreHeartbeatRequest) GetStats() *StoreStats {
 }
 
 type StoreHeartbeatResponse struct {
-	Header               *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	// When the scheduler handled the heartbeat (unix timestamp in nanoseconds).
+	SchedulerTime int64 `protobuf:"varint,2,opt,name=scheduler_time,json=schedulerTime,proto3" json:"scheduler_time,omitempty"`
+	// The peers on the store which the scheduler found stale, the store should
+	// destroy them if their region epoch is still stale.
+	StalePeers           []*StalePeer `protobuf:"bytes,3,rep,name=stale_peers,json=stalePeers" json:"stale_peers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
 func (m *StoreHeartbeatResponse) Reset()         { *m = StoreHeartbeatResponse{} }
 func (m *StoreHeartbeatResponse) String() string { return proto.CompactTextString(m) }
 func (*StoreHeartbeatResponse) ProtoMessage()    {}
 func (*StoreHeartbeatResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{43}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{46}
 }
 func (m *StoreHeartbeatResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2752,30 +3085,44 @@ func (m *StoreHeartbeatResponse) GetHeader() *ResponseHeader {
 	return nil
 }
 
-type ScatterRegionRequest struct {
-	Header   *RequestHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
-	RegionId uint64         `protobuf:"varint,2,opt,name=region_id,json=regionId,proto3" json:"region_id,omitempty"`
-	// Scheduler will use these region information if it can't find the region.
-	// For example, the region is just split and hasn't report to Scheduler yet.
-	Region               *metapb.Region `protobuf:"bytes,3,opt,name=region" json:"region,omitempty"`
-	Leader               *metapb.Peer   `protobuf:"bytes,4,opt,name=leader" json:"leader,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+func (m *StoreHeartbeatResponse) GetSchedulerTime() int64 {
+	if m != nil {
+		return m.SchedulerTime
+	}
+	return 0
 }
 
-func (m *ScatterRegionRequest) Reset()         { *m = ScatterRegionRequest{} }
-func (m *ScatterRegionRequest) String() string { return proto.CompactTextString(m) }
-func (*ScatterRegionRequest) ProtoMessage()    {}
-func (*ScatterRegionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{44}
+func (m *StoreHeartbeatResponse) GetStalePeers() []*StalePeer {
+	if m != nil {
+		return m.StalePeers
+	}
+	return nil
 }
-func (m *ScatterRegionRequest) XXX_Unmarshal(b []byte) error {
+
+// A peer of a region whose range is covered by a newer region.
+type StalePeer struct {
+	RegionId uint64       `protobuf:"varint,1,opt,name=region_id,json=regionId,proto3" json:"region_id,omitempty"`
+	Peer     *metapb.Peer `protobuf:"bytes,2,opt,name=peer" json:"peer,omitempty"`
+	// An epoch newer than the epoch of the stale region, the peer is stale if
+	// its epoch is older than it.
+	RegionEpoch          *metapb.RegionEpoch `protobuf:"bytes,3,opt,name=region_epoch,json=regionEpoch" json:"region_epoch,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *StalePeer) Reset()         { *m = StalePeer{} }
+func (m *StalePeer) String() string { return proto.CompactTextString(m) }
+func (*StalePeer) ProtoMessage()    {}
+func (*StalePeer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{47}
+}
+func (m *StalePeer) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ScatterRegionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *StalePeer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ScatterRegionRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_StalePeer.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -2785,40 +3132,106 @@ func (m *ScatterRegionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte
 		return b[:n], nil
 	}
 }
-func (dst *ScatterRegionRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ScatterRegionRequest.Merge(dst, src)
+func (dst *StalePeer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StalePeer.Merge(dst, src)
 }
-func (m *ScatterRegionRequest) XXX_Size() int {
+func (m *StalePeer) XXX_Size() int {
 	return m.Size()
 }
-func (m *ScatterRegionRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ScatterRegionRequest.DiscardUnknown(m)
+func (m *StalePeer) XXX_DiscardUnknown() {
+	xxx_messageInfo_StalePeer.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ScatterRegionRequest proto.InternalMessageInfo
-
-func (m *ScatterRegionRequest) GetHeader() *RequestHeader {
-	if m != nil {
-		return m.Header
-	}
-	return nil
-}
+var xxx_messageInfo_StalePeer proto.InternalMessageInfo
 
-func (m *ScatterRegionRequest) GetRegionId() uint64 {
+func (m *StalePeer) GetRegionId() uint64 {
 	if m != nil {
 		return m.RegionId
 	}
 	return 0
 }
 
-func (m *ScatterRegionRequest) GetRegion() *metapb.Region {
+func (m *StalePeer) GetPeer() *metapb.Peer {
 	if m != nil {
-		return m.Region
+		return m.Peer
 	}
 	return nil
 }
 
-func (m *ScatterRegionRequest) GetLeader() *metapb.Peer {
+func (m *StalePeer) GetRegionEpoch() *metapb.RegionEpoch {
+	if m != nil {
+		return m.RegionEpoch
+	}
+	return nil
+}
+
+type ScatterRegionRequest struct {
+	Header   *RequestHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	RegionId uint64         `protobuf:"varint,2,opt,name=region_id,json=regionId,proto3" json:"region_id,omitempty"`
+	// Scheduler will use these region information if it can't find the region.
+	// For example, the region is just split and hasn't report to Scheduler yet.
+	Region               *metapb.Region `protobuf:"bytes,3,opt,name=region" json:"region,omitempty"`
+	Leader               *metapb.Peer   `protobuf:"bytes,4,opt,name=leader" json:"leader,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ScatterRegionRequest) Reset()         { *m = ScatterRegionRequest{} }
+func (m *ScatterRegionRequest) String() string { return proto.CompactTextString(m) }
+func (*ScatterRegionRequest) ProtoMessage()    {}
+func (*ScatterRegionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{48}
+}
+func (m *ScatterRegionRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ScatterRegionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ScatterRegionRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *ScatterRegionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScatterRegionRequest.Merge(dst, src)
+}
+func (m *ScatterRegionRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ScatterRegionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScatterRegionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScatterRegionRequest proto.InternalMessageInfo
+
+func (m *ScatterRegionRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *ScatterRegionRequest) GetRegionId() uint64 {
+	if m != nil {
+		return m.RegionId
+	}
+	return 0
+}
+
+func (m *ScatterRegionRequest) GetRegion() *metapb.Region {
+	if m != nil {
+		return m.Region
+	}
+	return nil
+}
+
+func (m *ScatterRegionRequest) GetLeader() *metapb.Peer {
 	if m != nil {
 		return m.Leader
 	}
@@ -2836,7 +3249,7 @@ func (m *ScatterRegionResponse) Reset()         { *m = ScatterRegionResponse{} }
 func (m *ScatterRegionResponse) String() string { return proto.CompactTextString(m) }
 func (*ScatterRegionResponse) ProtoMessage()    {}
 func (*ScatterRegionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{45}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{49}
 }
 func (m *ScatterRegionResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2883,7 +3296,7 @@ func (m *GetGCSafePointRequest) Reset()         { *m = GetGCSafePointRequest{} }
 func (m *GetGCSafePointRequest) String() string { return proto.CompactTextString(m) }
 func (*GetGCSafePointRequest) ProtoMessage()    {}
 func (*GetGCSafePointRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{46}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{50}
 }
 func (m *GetGCSafePointRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2931,7 +3344,7 @@ func (m *GetGCSafePointResponse) Reset()         { *m = GetGCSafePointResponse{}
 func (m *GetGCSafePointResponse) String() string { return proto.CompactTextString(m) }
 func (*GetGCSafePointResponse) ProtoMessage()    {}
 func (*GetGCSafePointResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{47}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{51}
 }
 func (m *GetGCSafePointResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2986,7 +3399,7 @@ func (m *UpdateGCSafePointRequest) Reset()         { *m = UpdateGCSafePointReque
 func (m *UpdateGCSafePointRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateGCSafePointRequest) ProtoMessage()    {}
 func (*UpdateGCSafePointRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{48}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{52}
 }
 func (m *UpdateGCSafePointRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3041,7 +3454,7 @@ func (m *UpdateGCSafePointResponse) Reset()         { *m = UpdateGCSafePointResp
 func (m *UpdateGCSafePointResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateGCSafePointResponse) ProtoMessage()    {}
 func (*UpdateGCSafePointResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{49}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{53}
 }
 func (m *UpdateGCSafePointResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3096,7 +3509,7 @@ func (m *GetOperatorRequest) Reset()         { *m = GetOperatorRequest{} }
 func (m *GetOperatorRequest) String() string { return proto.CompactTextString(m) }
 func (*GetOperatorRequest) ProtoMessage()    {}
 func (*GetOperatorRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{50}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{54}
 }
 func (m *GetOperatorRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3154,7 +3567,7 @@ func (m *GetOperatorResponse) Reset()         { *m = GetOperatorResponse{} }
 func (m *GetOperatorResponse) String() string { return proto.CompactTextString(m) }
 func (*GetOperatorResponse) ProtoMessage()    {}
 func (*GetOperatorResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{51}
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{55}
 }
 func (m *GetOperatorResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3218,6 +3631,194 @@ func (m *GetOperatorResponse) GetKind() []byte {
 	return nil
 }
 
+type PauseSchedulingRequest struct {
+	Header *RequestHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	// The name of the scheduler to pause or resume, all the scheduling if empty.
+	Scheduler string `protobuf:"bytes,2,opt,name=scheduler,proto3" json:"scheduler,omitempty"`
+	// Resume the scheduling instead of pausing it.
+	Resume bool `protobuf:"varint,3,opt,name=resume,proto3" json:"resume,omitempty"`
+	// How long to pause, after which the scheduling is resumed automatically. The max scheduling pause of the
+	// configuration if 0 or longer than it.
+	PauseSecs            uint64   `protobuf:"varint,4,opt,name=pause_secs,json=pauseSecs,proto3" json:"pause_secs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PauseSchedulingRequest) Reset()         { *m = PauseSchedulingRequest{} }
+func (m *PauseSchedulingRequest) String() string { return proto.CompactTextString(m) }
+func (*PauseSchedulingRequest) ProtoMessage()    {}
+func (*PauseSchedulingRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{56}
+}
+func (m *PauseSchedulingRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PauseSchedulingRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PauseSchedulingRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *PauseSchedulingRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PauseSchedulingRequest.Merge(dst, src)
+}
+func (m *PauseSchedulingRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *PauseSchedulingRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PauseSchedulingRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PauseSchedulingRequest proto.InternalMessageInfo
+
+func (m *PauseSchedulingRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *PauseSchedulingRequest) GetScheduler() string {
+	if m != nil {
+		return m.Scheduler
+	}
+	return ""
+}
+
+func (m *PauseSchedulingRequest) GetResume() bool {
+	if m != nil {
+		return m.Resume
+	}
+	return false
+}
+
+func (m *PauseSchedulingRequest) GetPauseSecs() uint64 {
+	if m != nil {
+		return m.PauseSecs
+	}
+	return 0
+}
+
+type SchedulingPause struct {
+	// The paused scheduler, empty if all the scheduling is paused.
+	Scheduler string `protobuf:"bytes,1,opt,name=scheduler,proto3" json:"scheduler,omitempty"`
+	// The unix time in seconds the scheduling is resumed at.
+	ResumeAt             int64    `protobuf:"varint,2,opt,name=resume_at,json=resumeAt,proto3" json:"resume_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SchedulingPause) Reset()         { *m = SchedulingPause{} }
+func (m *SchedulingPause) String() string { return proto.CompactTextString(m) }
+func (*SchedulingPause) ProtoMessage()    {}
+func (*SchedulingPause) Descriptor() ([]byte, []int) {
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{57}
+}
+func (m *SchedulingPause) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SchedulingPause) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SchedulingPause.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *SchedulingPause) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchedulingPause.Merge(dst, src)
+}
+func (m *SchedulingPause) XXX_Size() int {
+	return m.Size()
+}
+func (m *SchedulingPause) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchedulingPause.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SchedulingPause proto.InternalMessageInfo
+
+func (m *SchedulingPause) GetScheduler() string {
+	if m != nil {
+		return m.Scheduler
+	}
+	return ""
+}
+
+func (m *SchedulingPause) GetResumeAt() int64 {
+	if m != nil {
+		return m.ResumeAt
+	}
+	return 0
+}
+
+type PauseSchedulingResponse struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	// The pauses in effect after the request.
+	Pauses               []*SchedulingPause `protobuf:"bytes,2,rep,name=pauses" json:"pauses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *PauseSchedulingResponse) Reset()         { *m = PauseSchedulingResponse{} }
+func (m *PauseSchedulingResponse) String() string { return proto.CompactTextString(m) }
+func (*PauseSchedulingResponse) ProtoMessage()    {}
+func (*PauseSchedulingResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_schedulerpb_7a7bf47d61c3cf3f, []int{58}
+}
+func (m *PauseSchedulingResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PauseSchedulingResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PauseSchedulingResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *PauseSchedulingResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PauseSchedulingResponse.Merge(dst, src)
+}
+func (m *PauseSchedulingResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *PauseSchedulingResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PauseSchedulingResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PauseSchedulingResponse proto.InternalMessageInfo
+
+func (m *PauseSchedulingResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *PauseSchedulingResponse) GetPauses() []*SchedulingPause {
+	if m != nil {
+		return m.Pauses
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*RequestHeader)(nil), "schedulerpb.RequestHeader")
 	proto.RegisterType((*ResponseHeader)(nil), "schedulerpb.ResponseHeader")
@@ -3250,8 +3851,10 @@ func init() {
 	proto.RegisterType((*GetMembersRequest)(nil), "schedulerpb.GetMembersRequest")
 	proto.RegisterType((*GetMembersResponse)(nil), "schedulerpb.GetMembersResponse")
 	proto.RegisterType((*RegionHeartbeatRequest)(nil), "schedulerpb.RegionHeartbeatRequest")
+	proto.RegisterType((*SnapshotApplied)(nil), "schedulerpb.SnapshotApplied")
 	proto.RegisterType((*ChangePeer)(nil), "schedulerpb.ChangePeer")
 	proto.RegisterType((*TransferLeader)(nil), "schedulerpb.TransferLeader")
+	proto.RegisterType((*Merge)(nil), "schedulerpb.Merge")
 	proto.RegisterType((*RegionHeartbeatResponse)(nil), "schedulerpb.RegionHeartbeatResponse")
 	proto.RegisterType((*AskSplitRequest)(nil), "schedulerpb.AskSplitRequest")
 	proto.RegisterType((*AskSplitResponse)(nil), "schedulerpb.AskSplitResponse")
@@ -3261,8 +3864,10 @@ func init() {
 	proto.RegisterType((*TimeInterval)(nil), "schedulerpb.TimeInterval")
 	proto.RegisterType((*RecordPair)(nil), "schedulerpb.RecordPair")
 	proto.RegisterType((*StoreStats)(nil), "schedulerpb.StoreStats")
+	proto.RegisterType((*DiskStats)(nil), "schedulerpb.DiskStats")
 	proto.RegisterType((*StoreHeartbeatRequest)(nil), "schedulerpb.StoreHeartbeatRequest")
 	proto.RegisterType((*StoreHeartbeatResponse)(nil), "schedulerpb.StoreHeartbeatResponse")
+	proto.RegisterType((*StalePeer)(nil), "schedulerpb.StalePeer")
 	proto.RegisterType((*ScatterRegionRequest)(nil), "schedulerpb.ScatterRegionRequest")
 	proto.RegisterType((*ScatterRegionResponse)(nil), "schedulerpb.ScatterRegionResponse")
 	proto.RegisterType((*GetGCSafePointRequest)(nil), "schedulerpb.GetGCSafePointRequest")
@@ -3271,6 +3876,9 @@ func init() {
 	proto.RegisterType((*UpdateGCSafePointResponse)(nil), "schedulerpb.UpdateGCSafePointResponse")
 	proto.RegisterType((*GetOperatorRequest)(nil), "schedulerpb.GetOperatorRequest")
 	proto.RegisterType((*GetOperatorResponse)(nil), "schedulerpb.GetOperatorResponse")
+	proto.RegisterType((*PauseSchedulingRequest)(nil), "schedulerpb.PauseSchedulingRequest")
+	proto.RegisterType((*SchedulingPause)(nil), "schedulerpb.SchedulingPause")
+	proto.RegisterType((*PauseSchedulingResponse)(nil), "schedulerpb.PauseSchedulingResponse")
 	proto.RegisterEnum("schedulerpb.ErrorType", ErrorType_name, ErrorType_value)
 	proto.RegisterEnum("schedulerpb.OperatorStatus", OperatorStatus_name, OperatorStatus_value)
 }
@@ -3309,6 +3917,9 @@ type SchedulerClient interface {
 	GetGCSafePoint(ctx context.Context, in *GetGCSafePointRequest, opts ...grpc.CallOption) (*GetGCSafePointResponse, error)
 	UpdateGCSafePoint(ctx context.Context, in *UpdateGCSafePointRequest, opts ...grpc.CallOption) (*UpdateGCSafePointResponse, error)
 	GetOperator(ctx context.Context, in *GetOperatorRequest, opts ...grpc.CallOption) (*GetOperatorResponse, error)
+	// PauseScheduling pauses or resumes generating operators, of a scheduler or of all the schedulers and checkers,
+	// e.g. during a maintenance window. Heartbeats and TSO are served as usual, and the running operators go on.
+	PauseScheduling(ctx context.Context, in *PauseSchedulingRequest, opts ...grpc.CallOption) (*PauseSchedulingResponse, error)
 }
 
 type schedulerClient struct {
@@ -3552,6 +4163,15 @@ func (c *schedulerClient) GetOperator(ctx context.Context, in *GetOperatorReques
 	return out, nil
 }
 
+func (c *schedulerClient) PauseScheduling(ctx context.Context, in *PauseSchedulingRequest, opts ...grpc.CallOption) (*PauseSchedulingResponse, error) {
+	out := new(PauseSchedulingResponse)
+	err := c.cc.Invoke(ctx, "/schedulerpb.Scheduler/PauseScheduling", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Scheduler service
 
 type SchedulerServer interface {
@@ -3578,6 +4198,9 @@ type SchedulerServer interface {
 	GetGCSafePoint(context.Context, *GetGCSafePointRequest) (*GetGCSafePointResponse, error)
 	UpdateGCSafePoint(context.Context, *UpdateGCSafePointRequest) (*UpdateGCSafePointResponse, error)
 	GetOperator(context.Context, *GetOperatorRequest) (*GetOperatorResponse, error)
+	// PauseScheduling pauses or resumes generating operators, of a scheduler or of all the schedulers and checkers,
+	// e.g. during a maintenance window. Heartbeats and TSO are served as usual, and the running operators go on.
+	PauseScheduling(context.Context, *PauseSchedulingRequest) (*PauseSchedulingResponse, error)
 }
 
 func RegisterSchedulerServer(s *grpc.Server, srv SchedulerServer) {
@@ -3978,6 +4601,24 @@ func _Scheduler_GetOperator_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Scheduler_PauseScheduling_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseSchedulingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServer).PauseScheduling(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schedulerpb.Scheduler/PauseScheduling",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServer).PauseScheduling(ctx, req.(*PauseSchedulingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Scheduler_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "schedulerpb.Scheduler",
 	HandlerType: (*SchedulerServer)(nil),
@@ -4058,6 +4699,10 @@ var _Scheduler_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetOperator",
 			Handler:    _Scheduler_GetOperator_Handler,
 		},
+		{
+			MethodName: "PauseScheduling",
+			Handler:    _Scheduler_PauseScheduling_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -5343,13 +5988,30 @@ func (m *RegionHeartbeatRequest) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.ApproximateSize))
 	}
+	if m.ApproximateKeys != 0 {
+		dAtA[i] = 0x58
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.ApproximateKeys))
+	}
+	if len(m.SnapshotsApplied) > 0 {
+		for _, msg := range m.SnapshotsApplied {
+			dAtA[i] = 0x62
+			i++
+			i = encodeVarintSchedulerpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *ChangePeer) Marshal() (dAtA []byte, err error) {
+func (m *SnapshotApplied) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -5359,7 +6021,7 @@ func (m *ChangePeer) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ChangePeer) MarshalTo(dAtA []byte) (int, error) {
+func (m *SnapshotApplied) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -5374,10 +6036,20 @@ func (m *ChangePeer) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n41
 	}
-	if m.ChangeType != 0 {
+	if m.Index != 0 {
 		dAtA[i] = 0x10
 		i++
-		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.ChangeType))
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Index))
+	}
+	if m.Term != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Term))
+	}
+	if m.ApplyDurationMs != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.ApplyDurationMs))
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -5385,7 +6057,7 @@ func (m *ChangePeer) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *TransferLeader) Marshal() (dAtA []byte, err error) {
+func (m *ChangePeer) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -5395,7 +6067,7 @@ func (m *TransferLeader) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *TransferLeader) MarshalTo(dAtA []byte) (int, error) {
+func (m *ChangePeer) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -5410,13 +6082,18 @@ func (m *TransferLeader) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n42
 	}
+	if m.ChangeType != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.ChangeType))
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *RegionHeartbeatResponse) Marshal() (dAtA []byte, err error) {
+func (m *TransferLeader) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -5426,41 +6103,103 @@ func (m *RegionHeartbeatResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *RegionHeartbeatResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *TransferLeader) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.Header != nil {
+	if m.Peer != nil {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
-		n43, err := m.Header.MarshalTo(dAtA[i:])
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Peer.Size()))
+		n43, err := m.Peer.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
 		i += n43
 	}
-	if m.ChangePeer != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.ChangePeer.Size()))
-		n44, err := m.ChangePeer.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n44
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.TransferLeader != nil {
-		dAtA[i] = 0x1a
+	return i, nil
+}
+
+func (m *Merge) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Merge) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Target != nil {
+		dAtA[i] = 0xa
 		i++
-		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.TransferLeader.Size()))
-		n45, err := m.TransferLeader.MarshalTo(dAtA[i:])
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Target.Size()))
+		n44, err := m.Target.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n44
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *RegionHeartbeatResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RegionHeartbeatResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
+		n45, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
 		i += n45
 	}
+	if m.ChangePeer != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.ChangePeer.Size()))
+		n46, err := m.ChangePeer.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n46
+	}
+	if m.TransferLeader != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.TransferLeader.Size()))
+		n47, err := m.TransferLeader.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n47
+	}
 	if m.RegionId != 0 {
 		dAtA[i] = 0x20
 		i++
@@ -5470,21 +6209,31 @@ func (m *RegionHeartbeatResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x2a
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.RegionEpoch.Size()))
-		n46, err := m.RegionEpoch.MarshalTo(dAtA[i:])
+		n48, err := m.RegionEpoch.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n46
+		i += n48
 	}
 	if m.TargetPeer != nil {
 		dAtA[i] = 0x32
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.TargetPeer.Size()))
-		n47, err := m.TargetPeer.MarshalTo(dAtA[i:])
+		n49, err := m.TargetPeer.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n47
+		i += n49
+	}
+	if m.Merge != nil {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Merge.Size()))
+		n50, err := m.Merge.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n50
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -5511,21 +6260,21 @@ func (m *AskSplitRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
-		n48, err := m.Header.MarshalTo(dAtA[i:])
+		n51, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n48
+		i += n51
 	}
 	if m.Region != nil {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Region.Size()))
-		n49, err := m.Region.MarshalTo(dAtA[i:])
+		n52, err := m.Region.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n49
+		i += n52
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -5552,11 +6301,11 @@ func (m *AskSplitResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
-		n50, err := m.Header.MarshalTo(dAtA[i:])
+		n53, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n50
+		i += n53
 	}
 	if m.NewRegionId != 0 {
 		dAtA[i] = 0x10
@@ -5564,21 +6313,21 @@ func (m *AskSplitResponse) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.NewRegionId))
 	}
 	if len(m.NewPeerIds) > 0 {
-		dAtA52 := make([]byte, len(m.NewPeerIds)*10)
-		var j51 int
+		dAtA55 := make([]byte, len(m.NewPeerIds)*10)
+		var j54 int
 		for _, num := range m.NewPeerIds {
 			for num >= 1<<7 {
-				dAtA52[j51] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA55[j54] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j51++
+				j54++
 			}
-			dAtA52[j51] = uint8(num)
-			j51++
+			dAtA55[j54] = uint8(num)
+			j54++
 		}
 		dAtA[i] = 0x1a
 		i++
-		i = encodeVarintSchedulerpb(dAtA, i, uint64(j51))
-		i += copy(dAtA[i:], dAtA52[:j51])
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(j54))
+		i += copy(dAtA[i:], dAtA55[:j54])
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -5605,31 +6354,31 @@ func (m *ReportSplitRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
-		n53, err := m.Header.MarshalTo(dAtA[i:])
+		n56, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n53
+		i += n56
 	}
 	if m.Left != nil {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Left.Size()))
-		n54, err := m.Left.MarshalTo(dAtA[i:])
+		n57, err := m.Left.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n54
+		i += n57
 	}
 	if m.Right != nil {
 		dAtA[i] = 0x1a
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Right.Size()))
-		n55, err := m.Right.MarshalTo(dAtA[i:])
+		n58, err := m.Right.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n55
+		i += n58
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -5656,11 +6405,11 @@ func (m *ReportSplitResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
-		n56, err := m.Header.MarshalTo(dAtA[i:])
+		n59, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n56
+		i += n59
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -5689,21 +6438,21 @@ func (m *SplitID) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.NewRegionId))
 	}
 	if len(m.NewPeerIds) > 0 {
-		dAtA58 := make([]byte, len(m.NewPeerIds)*10)
-		var j57 int
+		dAtA61 := make([]byte, len(m.NewPeerIds)*10)
+		var j60 int
 		for _, num := range m.NewPeerIds {
 			for num >= 1<<7 {
-				dAtA58[j57] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA61[j60] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j57++
+				j60++
 			}
-			dAtA58[j57] = uint8(num)
-			j57++
+			dAtA61[j60] = uint8(num)
+			j60++
 		}
 		dAtA[i] = 0x12
 		i++
-		i = encodeVarintSchedulerpb(dAtA, i, uint64(j57))
-		i += copy(dAtA[i:], dAtA58[:j57])
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(j60))
+		i += copy(dAtA[i:], dAtA61[:j60])
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -5848,11 +6597,11 @@ func (m *StoreStats) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x7a
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Interval.Size()))
-		n59, err := m.Interval.MarshalTo(dAtA[i:])
+		n62, err := m.Interval.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n59
+		i += n62
 	}
 	if len(m.CpuUsages) > 0 {
 		for _, msg := range m.CpuUsages {
@@ -5910,6 +6659,138 @@ func (m *StoreStats) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
+	if m.ClockOffset != 0 {
+		dAtA[i] = 0xa0
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.ClockOffset))
+	}
+	if m.IsCompacting {
+		dAtA[i] = 0xa8
+		i++
+		dAtA[i] = 0x1
+		i++
+		if m.IsCompacting {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.SlowLeaderTransfers != 0 {
+		dAtA[i] = 0xb0
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.SlowLeaderTransfers))
+	}
+	if len(m.DiskStats) > 0 {
+		for _, msg := range m.DiskStats {
+			dAtA[i] = 0xba
+			i++
+			dAtA[i] = 0x1
+			i++
+			i = encodeVarintSchedulerpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.GeneratingSnapCount != 0 {
+		dAtA[i] = 0xc0
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.GeneratingSnapCount))
+	}
+	if m.SendingSnapBytes != 0 {
+		dAtA[i] = 0xc8
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.SendingSnapBytes))
+	}
+	if m.ReceivingSnapBytes != 0 {
+		dAtA[i] = 0xd0
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.ReceivingSnapBytes))
+	}
+	if m.ApplyingSnapBytes != 0 {
+		dAtA[i] = 0xd8
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.ApplyingSnapBytes))
+	}
+	if m.BootstrappingPeerCount != 0 {
+		dAtA[i] = 0xe0
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.BootstrappingPeerCount))
+	}
+	if m.SnapSendThroughput != 0 {
+		dAtA[i] = 0xe8
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.SnapSendThroughput))
+	}
+	if m.SnapRecvThroughput != 0 {
+		dAtA[i] = 0xf0
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.SnapRecvThroughput))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *DiskStats) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DiskStats) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if len(m.Path) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(len(m.Path)))
+		i += copy(dAtA[i:], m.Path)
+	}
+	if m.Capacity != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Capacity))
+	}
+	if m.Available != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Available))
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -5935,21 +6816,21 @@ func (m *StoreHeartbeatRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
-		n60, err := m.Header.MarshalTo(dAtA[i:])
+		n63, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n60
+		i += n63
 	}
 	if m.Stats != nil {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Stats.Size()))
-		n61, err := m.Stats.MarshalTo(dAtA[i:])
+		n64, err := m.Stats.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n61
+		i += n64
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -5976,11 +6857,74 @@ func (m *StoreHeartbeatResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
-		n62, err := m.Header.MarshalTo(dAtA[i:])
+		n65, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n62
+		i += n65
+	}
+	if m.SchedulerTime != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.SchedulerTime))
+	}
+	if len(m.StalePeers) > 0 {
+		for _, msg := range m.StalePeers {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintSchedulerpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *StalePeer) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StalePeer) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.RegionId != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.RegionId))
+	}
+	if m.Peer != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Peer.Size()))
+		n66, err := m.Peer.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n66
+	}
+	if m.RegionEpoch != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.RegionEpoch.Size()))
+		n67, err := m.RegionEpoch.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n67
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -6007,11 +6951,11 @@ func (m *ScatterRegionRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
-		n63, err := m.Header.MarshalTo(dAtA[i:])
+		n68, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n63
+		i += n68
 	}
 	if m.RegionId != 0 {
 		dAtA[i] = 0x10
@@ -6022,21 +6966,21 @@ func (m *ScatterRegionRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x1a
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Region.Size()))
-		n64, err := m.Region.MarshalTo(dAtA[i:])
+		n69, err := m.Region.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n64
+		i += n69
 	}
 	if m.Leader != nil {
 		dAtA[i] = 0x22
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Leader.Size()))
-		n65, err := m.Leader.MarshalTo(dAtA[i:])
+		n70, err := m.Leader.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n65
+		i += n70
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -6063,11 +7007,11 @@ func (m *ScatterRegionResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
-		n66, err := m.Header.MarshalTo(dAtA[i:])
+		n71, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n66
+		i += n71
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -6094,11 +7038,11 @@ func (m *GetGCSafePointRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
-		n67, err := m.Header.MarshalTo(dAtA[i:])
+		n72, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n67
+		i += n72
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -6125,11 +7069,11 @@ func (m *GetGCSafePointResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
-		n68, err := m.Header.MarshalTo(dAtA[i:])
+		n73, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n68
+		i += n73
 	}
 	if m.SafePoint != 0 {
 		dAtA[i] = 0x10
@@ -6161,11 +7105,11 @@ func (m *UpdateGCSafePointRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
-		n69, err := m.Header.MarshalTo(dAtA[i:])
+		n74, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n69
+		i += n74
 	}
 	if m.SafePoint != 0 {
 		dAtA[i] = 0x10
@@ -6197,11 +7141,11 @@ func (m *UpdateGCSafePointResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
-		n70, err := m.Header.MarshalTo(dAtA[i:])
+		n75, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n70
+		i += n75
 	}
 	if m.NewSafePoint != 0 {
 		dAtA[i] = 0x10
@@ -6233,11 +7177,11 @@ func (m *GetOperatorRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
-		n71, err := m.Header.MarshalTo(dAtA[i:])
+		n76, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n71
+		i += n76
 	}
 	if m.RegionId != 0 {
 		dAtA[i] = 0x10
@@ -6269,11 +7213,11 @@ func (m *GetOperatorResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
-		n72, err := m.Header.MarshalTo(dAtA[i:])
+		n77, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n72
+		i += n77
 	}
 	if m.RegionId != 0 {
 		dAtA[i] = 0x10
@@ -6303,6 +7247,133 @@ func (m *GetOperatorResponse) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *PauseSchedulingRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PauseSchedulingRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
+		n78, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n78
+	}
+	if len(m.Scheduler) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(len(m.Scheduler)))
+		i += copy(dAtA[i:], m.Scheduler)
+	}
+	if m.Resume {
+		dAtA[i] = 0x18
+		i++
+		if m.Resume {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.PauseSecs != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.PauseSecs))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *SchedulingPause) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SchedulingPause) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Scheduler) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(len(m.Scheduler)))
+		i += copy(dAtA[i:], m.Scheduler)
+	}
+	if m.ResumeAt != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.ResumeAt))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *PauseSchedulingResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PauseSchedulingResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
+		n79, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n79
+	}
+	if len(m.Pauses) > 0 {
+		for _, msg := range m.Pauses {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintSchedulerpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
 func encodeVarintSchedulerpb(dAtA []byte, offset int, v uint64) int {
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
@@ -6861,6 +7932,37 @@ func (m *RegionHeartbeatRequest) Size() (n int) {
 	if m.ApproximateSize != 0 {
 		n += 1 + sovSchedulerpb(uint64(m.ApproximateSize))
 	}
+	if m.ApproximateKeys != 0 {
+		n += 1 + sovSchedulerpb(uint64(m.ApproximateKeys))
+	}
+	if len(m.SnapshotsApplied) > 0 {
+		for _, e := range m.SnapshotsApplied {
+			l = e.Size()
+			n += 1 + l + sovSchedulerpb(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SnapshotApplied) Size() (n int) {
+	var l int
+	_ = l
+	if m.Peer != nil {
+		l = m.Peer.Size()
+		n += 1 + l + sovSchedulerpb(uint64(l))
+	}
+	if m.Index != 0 {
+		n += 1 + sovSchedulerpb(uint64(m.Index))
+	}
+	if m.Term != 0 {
+		n += 1 + sovSchedulerpb(uint64(m.Term))
+	}
+	if m.ApplyDurationMs != 0 {
+		n += 1 + sovSchedulerpb(uint64(m.ApplyDurationMs))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -6896,6 +7998,19 @@ func (m *TransferLeader) Size() (n int) {
 	return n
 }
 
+func (m *Merge) Size() (n int) {
+	var l int
+	_ = l
+	if m.Target != nil {
+		l = m.Target.Size()
+		n += 1 + l + sovSchedulerpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func (m *RegionHeartbeatResponse) Size() (n int) {
 	var l int
 	_ = l
@@ -6922,6 +8037,10 @@ func (m *RegionHeartbeatResponse) Size() (n int) {
 		l = m.TargetPeer.Size()
 		n += 1 + l + sovSchedulerpb(uint64(l))
 	}
+	if m.Merge != nil {
+		l = m.Merge.Size()
+		n += 1 + l + sovSchedulerpb(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -7113,6 +8232,65 @@ func (m *StoreStats) Size() (n int) {
 			n += 2 + l + sovSchedulerpb(uint64(l))
 		}
 	}
+	if m.ClockOffset != 0 {
+		n += 2 + sovSchedulerpb(uint64(m.ClockOffset))
+	}
+	if m.IsCompacting {
+		n += 3
+	}
+	if m.SlowLeaderTransfers != 0 {
+		n += 2 + sovSchedulerpb(uint64(m.SlowLeaderTransfers))
+	}
+	if len(m.DiskStats) > 0 {
+		for _, e := range m.DiskStats {
+			l = e.Size()
+			n += 2 + l + sovSchedulerpb(uint64(l))
+		}
+	}
+	if m.GeneratingSnapCount != 0 {
+		n += 2 + sovSchedulerpb(uint64(m.GeneratingSnapCount))
+	}
+	if m.SendingSnapBytes != 0 {
+		n += 2 + sovSchedulerpb(uint64(m.SendingSnapBytes))
+	}
+	if m.ReceivingSnapBytes != 0 {
+		n += 2 + sovSchedulerpb(uint64(m.ReceivingSnapBytes))
+	}
+	if m.ApplyingSnapBytes != 0 {
+		n += 2 + sovSchedulerpb(uint64(m.ApplyingSnapBytes))
+	}
+	if m.BootstrappingPeerCount != 0 {
+		n += 2 + sovSchedulerpb(uint64(m.BootstrappingPeerCount))
+	}
+	if m.SnapSendThroughput != 0 {
+		n += 2 + sovSchedulerpb(uint64(m.SnapSendThroughput))
+	}
+	if m.SnapRecvThroughput != 0 {
+		n += 2 + sovSchedulerpb(uint64(m.SnapRecvThroughput))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DiskStats) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovSchedulerpb(uint64(l))
+	}
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovSchedulerpb(uint64(l))
+	}
+	if m.Capacity != 0 {
+		n += 1 + sovSchedulerpb(uint64(m.Capacity))
+	}
+	if m.Available != 0 {
+		n += 1 + sovSchedulerpb(uint64(m.Available))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -7143,6 +8321,35 @@ func (m *StoreHeartbeatResponse) Size() (n int) {
 		l = m.Header.Size()
 		n += 1 + l + sovSchedulerpb(uint64(l))
 	}
+	if m.SchedulerTime != 0 {
+		n += 1 + sovSchedulerpb(uint64(m.SchedulerTime))
+	}
+	if len(m.StalePeers) > 0 {
+		for _, e := range m.StalePeers {
+			l = e.Size()
+			n += 1 + l + sovSchedulerpb(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *StalePeer) Size() (n int) {
+	var l int
+	_ = l
+	if m.RegionId != 0 {
+		n += 1 + sovSchedulerpb(uint64(m.RegionId))
+	}
+	if m.Peer != nil {
+		l = m.Peer.Size()
+		n += 1 + l + sovSchedulerpb(uint64(l))
+	}
+	if m.RegionEpoch != nil {
+		l = m.RegionEpoch.Size()
+		n += 1 + l + sovSchedulerpb(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -7290,6 +8497,64 @@ func (m *GetOperatorResponse) Size() (n int) {
 	return n
 }
 
+func (m *PauseSchedulingRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovSchedulerpb(uint64(l))
+	}
+	l = len(m.Scheduler)
+	if l > 0 {
+		n += 1 + l + sovSchedulerpb(uint64(l))
+	}
+	if m.Resume {
+		n += 2
+	}
+	if m.PauseSecs != 0 {
+		n += 1 + sovSchedulerpb(uint64(m.PauseSecs))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SchedulingPause) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Scheduler)
+	if l > 0 {
+		n += 1 + l + sovSchedulerpb(uint64(l))
+	}
+	if m.ResumeAt != 0 {
+		n += 1 + sovSchedulerpb(uint64(m.ResumeAt))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PauseSchedulingResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovSchedulerpb(uint64(l))
+	}
+	if len(m.Pauses) > 0 {
+		for _, e := range m.Pauses {
+			l = e.Size()
+			n += 1 + l + sovSchedulerpb(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func sovSchedulerpb(x uint64) (n int) {
 	for {
 		n++
@@ -10883,29 +12148,79 @@ func (m *RegionHeartbeatRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthSchedulerpb
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ApproximateKeys", wireType)
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
+			m.ApproximateKeys = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ApproximateKeys |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotsApplied", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SnapshotsApplied = append(m.SnapshotsApplied, &SnapshotApplied{})
+			if err := m.SnapshotsApplied[len(m.SnapshotsApplied)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
 
 	if iNdEx > l {
 		return io.ErrUnexpectedEOF
 	}
 	return nil
 }
-func (m *ChangePeer) Unmarshal(dAtA []byte) error {
+func (m *SnapshotApplied) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10928,10 +12243,10 @@ func (m *ChangePeer) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ChangePeer: wiretype end group for non-group")
+			return fmt.Errorf("proto: SnapshotApplied: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ChangePeer: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SnapshotApplied: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -10969,9 +12284,9 @@ func (m *ChangePeer) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChangeType", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
-			m.ChangeType = 0
+			m.Index = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSchedulerpb
@@ -10981,7 +12296,45 @@ func (m *ChangePeer) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ChangeType |= (eraftpb.ConfChangeType(b) & 0x7F) << shift
+				m.Index |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+			}
+			m.Term = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Term |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ApplyDurationMs", wireType)
+			}
+			m.ApplyDurationMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ApplyDurationMs |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -11008,7 +12361,7 @@ func (m *ChangePeer) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TransferLeader) Unmarshal(dAtA []byte) error {
+func (m *ChangePeer) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11031,10 +12384,10 @@ func (m *TransferLeader) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TransferLeader: wiretype end group for non-group")
+			return fmt.Errorf("proto: ChangePeer: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TransferLeader: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ChangePeer: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -11070,6 +12423,25 @@ func (m *TransferLeader) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangeType", wireType)
+			}
+			m.ChangeType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ChangeType |= (eraftpb.ConfChangeType(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
@@ -11092,7 +12464,7 @@ func (m *TransferLeader) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RegionHeartbeatResponse) Unmarshal(dAtA []byte) error {
+func (m *TransferLeader) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11115,15 +12487,15 @@ func (m *RegionHeartbeatResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RegionHeartbeatResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: TransferLeader: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RegionHeartbeatResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TransferLeader: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11147,16 +12519,67 @@ func (m *RegionHeartbeatResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Header == nil {
-				m.Header = &ResponseHeader{}
+			if m.Peer == nil {
+				m.Peer = &metapb.Peer{}
 			}
-			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Peer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Merge) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSchedulerpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Merge: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Merge: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChangePeer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Target", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11180,16 +12603,67 @@ func (m *RegionHeartbeatResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ChangePeer == nil {
-				m.ChangePeer = &ChangePeer{}
+			if m.Target == nil {
+				m.Target = &metapb.Region{}
 			}
-			if err := m.ChangePeer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Target.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RegionHeartbeatResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSchedulerpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RegionHeartbeatResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RegionHeartbeatResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TransferLeader", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11213,18 +12687,84 @@ func (m *RegionHeartbeatResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.TransferLeader == nil {
-				m.TransferLeader = &TransferLeader{}
+			if m.Header == nil {
+				m.Header = &ResponseHeader{}
 			}
-			if err := m.TransferLeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RegionId", wireType)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangePeer", wireType)
 			}
-			m.RegionId = 0
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ChangePeer == nil {
+				m.ChangePeer = &ChangePeer{}
+			}
+			if err := m.ChangePeer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TransferLeader", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TransferLeader == nil {
+				m.TransferLeader = &TransferLeader{}
+			}
+			if err := m.TransferLeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RegionId", wireType)
+			}
+			m.RegionId = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSchedulerpb
@@ -11305,6 +12845,39 @@ func (m *RegionHeartbeatResponse) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Merge", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Merge == nil {
+				m.Merge = &Merge{}
+			}
+			if err := m.Merge.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
@@ -12418,7 +13991,938 @@ func (m *StoreStats) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 16:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CpuUsages", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CpuUsages", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CpuUsages = append(m.CpuUsages, &RecordPair{})
+			if err := m.CpuUsages[len(m.CpuUsages)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadIoRates", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ReadIoRates = append(m.ReadIoRates, &RecordPair{})
+			if err := m.ReadIoRates[len(m.ReadIoRates)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WriteIoRates", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.WriteIoRates = append(m.WriteIoRates, &RecordPair{})
+			if err := m.WriteIoRates[len(m.WriteIoRates)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OpLatencies", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OpLatencies = append(m.OpLatencies, &RecordPair{})
+			if err := m.OpLatencies[len(m.OpLatencies)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 20:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClockOffset", wireType)
+			}
+			m.ClockOffset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ClockOffset |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 21:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsCompacting", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IsCompacting = bool(v != 0)
+		case 22:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SlowLeaderTransfers", wireType)
+			}
+			m.SlowLeaderTransfers = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SlowLeaderTransfers |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 23:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DiskStats", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DiskStats = append(m.DiskStats, &DiskStats{})
+			if err := m.DiskStats[len(m.DiskStats)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 24:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GeneratingSnapCount", wireType)
+			}
+			m.GeneratingSnapCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GeneratingSnapCount |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 25:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SendingSnapBytes", wireType)
+			}
+			m.SendingSnapBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SendingSnapBytes |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 26:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReceivingSnapBytes", wireType)
+			}
+			m.ReceivingSnapBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReceivingSnapBytes |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 27:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ApplyingSnapBytes", wireType)
+			}
+			m.ApplyingSnapBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ApplyingSnapBytes |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 28:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BootstrappingPeerCount", wireType)
+			}
+			m.BootstrappingPeerCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BootstrappingPeerCount |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 29:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SnapSendThroughput", wireType)
+			}
+			m.SnapSendThroughput = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SnapSendThroughput |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 30:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SnapRecvThroughput", wireType)
+			}
+			m.SnapRecvThroughput = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SnapRecvThroughput |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DiskStats) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSchedulerpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DiskStats: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DiskStats: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Capacity", wireType)
+			}
+			m.Capacity = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Capacity |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Available", wireType)
+			}
+			m.Available = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Available |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StoreHeartbeatRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSchedulerpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StoreHeartbeatRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StoreHeartbeatRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &RequestHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Stats == nil {
+				m.Stats = &StoreStats{}
+			}
+			if err := m.Stats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StoreHeartbeatResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSchedulerpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StoreHeartbeatResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StoreHeartbeatResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &ResponseHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SchedulerTime", wireType)
+			}
+			m.SchedulerTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SchedulerTime |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StalePeers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StalePeers = append(m.StalePeers, &StalePeer{})
+			if err := m.StalePeers[len(m.StalePeers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StalePeer) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSchedulerpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StalePeer: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StalePeer: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RegionId", wireType)
+			}
+			m.RegionId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RegionId |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Peer == nil {
+				m.Peer = &metapb.Peer{}
+			}
+			if err := m.Peer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RegionEpoch", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RegionEpoch == nil {
+				m.RegionEpoch = &metapb.RegionEpoch{}
+			}
+			if err := m.RegionEpoch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ScatterRegionRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSchedulerpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ScatterRegionRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ScatterRegionRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -12442,16 +14946,18 @@ func (m *StoreStats) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.CpuUsages = append(m.CpuUsages, &RecordPair{})
-			if err := m.CpuUsages[len(m.CpuUsages)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Header == nil {
+				m.Header = &RequestHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 17:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ReadIoRates", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RegionId", wireType)
 			}
-			var msglen int
+			m.RegionId = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSchedulerpb
@@ -12461,26 +14967,14 @@ func (m *StoreStats) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.RegionId |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthSchedulerpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ReadIoRates = append(m.ReadIoRates, &RecordPair{})
-			if err := m.ReadIoRates[len(m.ReadIoRates)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 18:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field WriteIoRates", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Region", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -12504,14 +14998,16 @@ func (m *StoreStats) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.WriteIoRates = append(m.WriteIoRates, &RecordPair{})
-			if err := m.WriteIoRates[len(m.WriteIoRates)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Region == nil {
+				m.Region = &metapb.Region{}
+			}
+			if err := m.Region.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 19:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OpLatencies", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -12535,8 +15031,10 @@ func (m *StoreStats) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.OpLatencies = append(m.OpLatencies, &RecordPair{})
-			if err := m.OpLatencies[len(m.OpLatencies)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Leader == nil {
+				m.Leader = &metapb.Peer{}
+			}
+			if err := m.Leader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -12562,7 +15060,7 @@ func (m *StoreStats) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *StoreHeartbeatRequest) Unmarshal(dAtA []byte) error {
+func (m *ScatterRegionResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12585,10 +15083,10 @@ func (m *StoreHeartbeatRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: StoreHeartbeatRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ScatterRegionResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StoreHeartbeatRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ScatterRegionResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -12618,45 +15116,12 @@ func (m *StoreHeartbeatRequest) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Header == nil {
-				m.Header = &RequestHeader{}
+				m.Header = &ResponseHeader{}
 			}
 			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSchedulerpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthSchedulerpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Stats == nil {
-				m.Stats = &StoreStats{}
-			}
-			if err := m.Stats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
@@ -12679,7 +15144,7 @@ func (m *StoreHeartbeatRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *StoreHeartbeatResponse) Unmarshal(dAtA []byte) error {
+func (m *GetGCSafePointRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12702,10 +15167,10 @@ func (m *StoreHeartbeatResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: StoreHeartbeatResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetGCSafePointRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StoreHeartbeatResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetGCSafePointRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -12735,7 +15200,7 @@ func (m *StoreHeartbeatResponse) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Header == nil {
-				m.Header = &ResponseHeader{}
+				m.Header = &RequestHeader{}
 			}
 			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
@@ -12763,7 +15228,7 @@ func (m *StoreHeartbeatResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ScatterRegionRequest) Unmarshal(dAtA []byte) error {
+func (m *GetGCSafePointResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12786,10 +15251,10 @@ func (m *ScatterRegionRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ScatterRegionRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetGCSafePointResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ScatterRegionRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetGCSafePointResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -12819,7 +15284,7 @@ func (m *ScatterRegionRequest) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Header == nil {
-				m.Header = &RequestHeader{}
+				m.Header = &ResponseHeader{}
 			}
 			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
@@ -12827,61 +15292,9 @@ func (m *ScatterRegionRequest) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RegionId", wireType)
-			}
-			m.RegionId = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSchedulerpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.RegionId |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Region", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSchedulerpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthSchedulerpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Region == nil {
-				m.Region = &metapb.Region{}
-			}
-			if err := m.Region.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SafePoint", wireType)
 			}
-			var msglen int
+			m.SafePoint = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSchedulerpb
@@ -12891,25 +15304,11 @@ func (m *ScatterRegionRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.SafePoint |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthSchedulerpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Leader == nil {
-				m.Leader = &metapb.Peer{}
-			}
-			if err := m.Leader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
@@ -12932,7 +15331,7 @@ func (m *ScatterRegionRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ScatterRegionResponse) Unmarshal(dAtA []byte) error {
+func (m *UpdateGCSafePointRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12955,10 +15354,10 @@ func (m *ScatterRegionResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ScatterRegionResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: UpdateGCSafePointRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ScatterRegionResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UpdateGCSafePointRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -12988,12 +15387,31 @@ func (m *ScatterRegionResponse) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Header == nil {
-				m.Header = &ResponseHeader{}
+				m.Header = &RequestHeader{}
 			}
 			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SafePoint", wireType)
+			}
+			m.SafePoint = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SafePoint |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
@@ -13016,7 +15434,7 @@ func (m *ScatterRegionResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetGCSafePointRequest) Unmarshal(dAtA []byte) error {
+func (m *UpdateGCSafePointResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13039,10 +15457,10 @@ func (m *GetGCSafePointRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetGCSafePointRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: UpdateGCSafePointResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetGCSafePointRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UpdateGCSafePointResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -13072,12 +15490,31 @@ func (m *GetGCSafePointRequest) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Header == nil {
-				m.Header = &RequestHeader{}
+				m.Header = &ResponseHeader{}
 			}
 			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewSafePoint", wireType)
+			}
+			m.NewSafePoint = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NewSafePoint |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
@@ -13100,7 +15537,7 @@ func (m *GetGCSafePointRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetGCSafePointResponse) Unmarshal(dAtA []byte) error {
+func (m *GetOperatorRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13123,10 +15560,10 @@ func (m *GetGCSafePointResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetGCSafePointResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetOperatorRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetGCSafePointResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetOperatorRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -13156,7 +15593,7 @@ func (m *GetGCSafePointResponse) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Header == nil {
-				m.Header = &ResponseHeader{}
+				m.Header = &RequestHeader{}
 			}
 			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
@@ -13164,9 +15601,9 @@ func (m *GetGCSafePointResponse) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SafePoint", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RegionId", wireType)
 			}
-			m.SafePoint = 0
+			m.RegionId = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSchedulerpb
@@ -13176,7 +15613,7 @@ func (m *GetGCSafePointResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.SafePoint |= (uint64(b) & 0x7F) << shift
+				m.RegionId |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -13203,7 +15640,7 @@ func (m *GetGCSafePointResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *UpdateGCSafePointRequest) Unmarshal(dAtA []byte) error {
+func (m *GetOperatorResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13226,10 +15663,10 @@ func (m *UpdateGCSafePointRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: UpdateGCSafePointRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetOperatorResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UpdateGCSafePointRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetOperatorResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -13259,7 +15696,7 @@ func (m *UpdateGCSafePointRequest) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Header == nil {
-				m.Header = &RequestHeader{}
+				m.Header = &ResponseHeader{}
 			}
 			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
@@ -13267,9 +15704,9 @@ func (m *UpdateGCSafePointRequest) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SafePoint", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RegionId", wireType)
 			}
-			m.SafePoint = 0
+			m.RegionId = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSchedulerpb
@@ -13279,11 +15716,92 @@ func (m *UpdateGCSafePointRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.SafePoint |= (uint64(b) & 0x7F) << shift
+				m.RegionId |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Desc", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Desc = append(m.Desc[:0], dAtA[iNdEx:postIndex]...)
+			if m.Desc == nil {
+				m.Desc = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= (OperatorStatus(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Kind", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Kind = append(m.Kind[:0], dAtA[iNdEx:postIndex]...)
+			if m.Kind == nil {
+				m.Kind = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
@@ -13306,7 +15824,7 @@ func (m *UpdateGCSafePointRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *UpdateGCSafePointResponse) Unmarshal(dAtA []byte) error {
+func (m *PauseSchedulingRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13329,10 +15847,10 @@ func (m *UpdateGCSafePointResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: UpdateGCSafePointResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: PauseSchedulingRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UpdateGCSafePointResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PauseSchedulingRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -13362,17 +15880,46 @@ func (m *UpdateGCSafePointResponse) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Header == nil {
-				m.Header = &ResponseHeader{}
+				m.Header = &RequestHeader{}
 			}
 			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Scheduler", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Scheduler = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NewSafePoint", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Resume", wireType)
 			}
-			m.NewSafePoint = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSchedulerpb
@@ -13382,7 +15929,27 @@ func (m *UpdateGCSafePointResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.NewSafePoint |= (uint64(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Resume = bool(v != 0)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PauseSecs", wireType)
+			}
+			m.PauseSecs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PauseSecs |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -13409,7 +15976,7 @@ func (m *UpdateGCSafePointResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetOperatorRequest) Unmarshal(dAtA []byte) error {
+func (m *SchedulingPause) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13432,17 +15999,17 @@ func (m *GetOperatorRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetOperatorRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: SchedulingPause: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetOperatorRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SchedulingPause: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Scheduler", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSchedulerpb
@@ -13452,30 +16019,26 @@ func (m *GetOperatorRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthSchedulerpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Header == nil {
-				m.Header = &RequestHeader{}
-			}
-			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Scheduler = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RegionId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ResumeAt", wireType)
 			}
-			m.RegionId = 0
+			m.ResumeAt = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSchedulerpb
@@ -13485,7 +16048,7 @@ func (m *GetOperatorRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.RegionId |= (uint64(b) & 0x7F) << shift
+				m.ResumeAt |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -13512,7 +16075,7 @@ func (m *GetOperatorRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetOperatorResponse) Unmarshal(dAtA []byte) error {
+func (m *PauseSchedulingResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13535,10 +16098,10 @@ func (m *GetOperatorResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetOperatorResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: PauseSchedulingResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetOperatorResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PauseSchedulingResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -13575,79 +16138,10 @@ func (m *GetOperatorResponse) Unmarshal(dAtA []byte) error {
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RegionId", wireType)
-			}
-			m.RegionId = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSchedulerpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.RegionId |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Desc", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSchedulerpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthSchedulerpb
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Desc = append(m.Desc[:0], dAtA[iNdEx:postIndex]...)
-			if m.Desc == nil {
-				m.Desc = []byte{}
-			}
-			iNdEx = postIndex
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
-			}
-			m.Status = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSchedulerpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Status |= (OperatorStatus(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Kind", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Pauses", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSchedulerpb
@@ -13657,21 +16151,21 @@ func (m *GetOperatorResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthSchedulerpb
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Kind = append(m.Kind[:0], dAtA[iNdEx:postIndex]...)
-			if m.Kind == nil {
-				m.Kind = []byte{}
+			m.Pauses = append(m.Pauses, &SchedulingPause{})
+			if err := m.Pauses[len(m.Pauses)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		default:
@@ -13801,155 +16295,189 @@ var (
 	ErrIntOverflowSchedulerpb   = fmt.Errorf("proto: integer overflow")
 )
 
-func init() { proto.RegisterFile("schedulerpb.proto", fileDescriptor_schedulerpb_4e333137f5959f12) }
-
-var fileDescriptor_schedulerpb_4e333137f5959f12 = []byte{
-	// 2343 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x5a, 0xcf, 0x6f, 0x23, 0x49,
-	0xf5, 0x4f, 0x3b, 0x8e, 0x63, 0x3f, 0x3b, 0xb6, 0x53, 0xc9, 0x26, 0x5e, 0xef, 0x4c, 0x36, 0x53,
-	0x33, 0x3b, 0xdf, 0xd9, 0xf9, 0x32, 0x61, 0xc9, 0x0e, 0xab, 0x15, 0x08, 0xa4, 0xc4, 0xf1, 0x66,
-	0xcc, 0x24, 0xb6, 0xd5, 0x76, 0x06, 0x56, 0x20, 0x35, 0x1d, 0x77, 0xc5, 0x69, 0xa6, 0xdd, 0xdd,
-	0xdb, 0x55, 0xce, 0x8c, 0xe7, 0xca, 0x89, 0x03, 0x08, 0x21, 0x90, 0x90, 0xe0, 0xc0, 0x3f, 0xc1,
-	0x8d, 0x23, 0x07, 0x8e, 0x88, 0x2b, 0x17, 0x34, 0xfc, 0x13, 0x1c, 0x51, 0x55, 0x75, 0xb7, 0xbb,
-	0xdb, 0x76, 0x12, 0xd4, 0x03, 0x37, 0x57, 0xbd, 0x4f, 0xbd, 0xdf, 0x55, 0xf5, 0xea, 0xb5, 0x61,
-	0x9d, 0x0e, 0x2e, 0x89, 0x31, 0xb6, 0x88, 0xe7, 0x9e, 0xef, 0xb9, 0x9e, 0xc3, 0x1c, 0x54, 0x8c,
-	0x4c, 0xd5, 0x4b, 0x23, 0xc2, 0xf4, 0x80, 0x54, 0x5f, 0x23, 0x9e, 0x7e, 0xc1, 0xc2, 0xe1, 0xe6,
-	0xd0, 0x19, 0x3a, 0xe2, 0xe7, 0xd7, 0xf9, 0x2f, 0x39, 0x8b, 0xf7, 0x60, 0x4d, 0x25, 0x5f, 0x8d,
-	0x09, 0x65, 0xcf, 0x88, 0x6e, 0x10, 0x0f, 0xdd, 0x05, 0x18, 0x58, 0x63, 0xca, 0x88, 0xa7, 0x99,
-	0x46, 0x4d, 0xd9, 0x55, 0x1e, 0x65, 0xd5, 0x82, 0x3f, 0xd3, 0x32, 0xf0, 0x97, 0x50, 0x56, 0x09,
-	0x75, 0x1d, 0x9b, 0x92, 0x5b, 0x2d, 0x40, 0x8f, 0x60, 0x85, 0x78, 0x9e, 0xe3, 0xd5, 0x32, 0xbb,
-	0xca, 0xa3, 0xe2, 0x3e, 0xda, 0x8b, 0xda, 0xd0, 0xe4, 0x14, 0x55, 0x02, 0xf0, 0x29, 0xac, 0x88,
-	0x31, 0x7a, 0x0c, 0x59, 0x36, 0x71, 0x89, 0xe0, 0x55, 0xde, 0xdf, 0x9a, 0x5d, 0xd1, 0x9f, 0xb8,
-	0x44, 0x15, 0x18, 0x54, 0x83, 0xd5, 0x11, 0xa1, 0x54, 0x1f, 0x12, 0x21, 0xa0, 0xa0, 0x06, 0x43,
-	0xfc, 0x02, 0xa0, 0x4f, 0x1d, 0xdf, 0x38, 0xb4, 0x0f, 0xb9, 0x4b, 0xa1, 0xaf, 0xe0, 0x5a, 0xdc,
-	0xaf, 0xc7, 0xb8, 0xc6, 0x5c, 0xa0, 0xfa, 0x48, 0xb4, 0x09, 0x2b, 0x03, 0x67, 0x6c, 0x33, 0xc1,
-	0x79, 0x4d, 0x95, 0x03, 0x7c, 0x00, 0x85, 0xbe, 0x39, 0x22, 0x94, 0xe9, 0x23, 0x17, 0xd5, 0x21,
-	0xef, 0x5e, 0x4e, 0xa8, 0x39, 0xd0, 0x2d, 0xc1, 0x78, 0x59, 0x0d, 0xc7, 0x5c, 0x35, 0xcb, 0x19,
-	0x0a, 0x52, 0x46, 0x90, 0x82, 0x21, 0xfe, 0x85, 0x02, 0x45, 0xa1, 0x9b, 0x74, 0x24, 0xfa, 0x34,
-	0xa1, 0xdc, 0x07, 0x09, 0xe5, 0xa2, 0xfe, 0xbe, 0x5e, 0x3b, 0xf4, 0x14, 0x0a, 0x2c, 0xd0, 0xae,
-	0xb6, 0x2c, 0xb8, 0xc5, 0x1d, 0x18, 0xea, 0xae, 0x4e, 0x81, 0xf8, 0x25, 0x54, 0x0f, 0x1d, 0x87,
-	0x51, 0xe6, 0xe9, 0x6e, 0x1a, 0x8f, 0xdd, 0x87, 0x15, 0xca, 0x1c, 0x8f, 0xf8, 0xc1, 0x5e, 0xdb,
-	0xf3, 0x13, 0xb2, 0xc7, 0x27, 0x55, 0x49, 0xc3, 0xcf, 0x60, 0x3d, 0x22, 0x2c, 0x85, 0x0b, 0xf0,
-	0x73, 0x78, 0xaf, 0x45, 0x43, 0x5e, 0x2e, 0x31, 0x52, 0xe8, 0x8e, 0xbf, 0x82, 0xad, 0x24, 0xb3,
-	0x34, 0xe1, 0xc1, 0x50, 0x3a, 0x8f, 0x30, 0x13, 0x1e, 0xc9, 0xab, 0xb1, 0x39, 0x7c, 0x04, 0xe5,
-	0x03, 0xcb, 0x72, 0x06, 0xad, 0xa3, 0x34, 0x8a, 0xbf, 0x80, 0x4a, 0xc8, 0x25, 0x8d, 0xc6, 0x65,
-	0xc8, 0x98, 0x52, 0xcf, 0xac, 0x9a, 0x31, 0x0d, 0xfc, 0x63, 0xa8, 0x1c, 0x13, 0x26, 0x43, 0x97,
-	0x22, 0x27, 0xde, 0x87, 0xbc, 0x88, 0xbb, 0x16, 0x32, 0x5f, 0x15, 0xe3, 0x96, 0x81, 0x7f, 0xa7,
-	0x40, 0x75, 0x2a, 0x22, 0x8d, 0xee, 0xb7, 0x49, 0x3c, 0xf4, 0x84, 0x83, 0x74, 0x46, 0xfd, 0x7d,
-	0xb1, 0x1d, 0x63, 0x2c, 0x90, 0x3d, 0x4e, 0x56, 0x25, 0x0a, 0xff, 0x04, 0x2a, 0xdd, 0x71, 0x7a,
-	0xfb, 0x6f, 0xb5, 0x27, 0x8e, 0xa1, 0x3a, 0x95, 0x95, 0x66, 0x4b, 0xfc, 0x54, 0x81, 0x8d, 0x63,
-	0xc2, 0x0e, 0x2c, 0x4b, 0x30, 0xa3, 0x69, 0x34, 0xff, 0x1c, 0x6a, 0xe4, 0xf5, 0xc0, 0x1a, 0x1b,
-	0x44, 0x63, 0xce, 0xe8, 0x9c, 0x32, 0xc7, 0x26, 0x9a, 0xd0, 0x97, 0xfa, 0xe9, 0xbc, 0xe5, 0xd3,
-	0xfb, 0x01, 0x59, 0x0a, 0xc5, 0x1e, 0x6c, 0xc6, 0x95, 0x48, 0x13, 0xdb, 0x8f, 0x20, 0x17, 0x0a,
-	0x5d, 0x9e, 0xf5, 0xa0, 0x4f, 0xc4, 0x44, 0xe4, 0x92, 0x4a, 0x86, 0xa6, 0x63, 0xa7, 0xb1, 0xfa,
-	0x2e, 0x80, 0x27, 0x98, 0x68, 0x2f, 0xc9, 0x44, 0xd8, 0x59, 0x52, 0x0b, 0x72, 0xe6, 0x39, 0x99,
-	0xe0, 0x3f, 0x29, 0xb0, 0x1e, 0x91, 0x93, 0xc6, 0xb0, 0x87, 0x90, 0x93, 0x7c, 0xfd, 0xd4, 0x28,
-	0x07, 0x86, 0xf9, 0xcc, 0x7d, 0x2a, 0x7a, 0x00, 0x39, 0x4b, 0x32, 0x97, 0x89, 0x5b, 0x0a, 0x70,
-	0x5d, 0xc2, 0xb9, 0x49, 0x1a, 0x47, 0x51, 0x4b, 0xbf, 0x22, 0xb4, 0x96, 0x15, 0x6e, 0x4a, 0xa0,
-	0x24, 0x0d, 0x0f, 0x45, 0x64, 0xa4, 0x80, 0xc3, 0x49, 0xaa, 0x83, 0x07, 0x7d, 0x00, 0xbe, 0x5f,
-	0xa6, 0x5b, 0x3b, 0x2f, 0x27, 0x5a, 0x06, 0xfe, 0xb5, 0x02, 0xa8, 0x37, 0xd0, 0x6d, 0x29, 0x8a,
-	0xa6, 0x94, 0x43, 0x99, 0xee, 0xb1, 0x48, 0x40, 0xf2, 0x62, 0xe2, 0x39, 0x99, 0xf0, 0x6b, 0xd0,
-	0x32, 0x47, 0x26, 0x13, 0xbe, 0x59, 0x51, 0xe5, 0x00, 0x6d, 0xc3, 0x2a, 0xb1, 0x0d, 0xb1, 0x20,
-	0x2b, 0x16, 0xe4, 0x88, 0x6d, 0xf0, 0xf0, 0xfd, 0x5e, 0x81, 0x8d, 0x98, 0x5a, 0x69, 0x02, 0xf8,
-	0x08, 0x56, 0xa5, 0xbd, 0x41, 0x6a, 0x26, 0x23, 0x18, 0x90, 0xd1, 0x43, 0x58, 0x95, 0x61, 0xe2,
-	0x87, 0xcf, 0x6c, 0x74, 0x02, 0x22, 0x3e, 0x85, 0xed, 0x63, 0xc2, 0x1a, 0xb2, 0x7a, 0x6a, 0x38,
-	0xf6, 0x85, 0x39, 0x4c, 0x73, 0x35, 0xbc, 0x81, 0xda, 0x2c, 0xbb, 0x34, 0x16, 0x7f, 0x0c, 0xab,
-	0x7e, 0x69, 0xe7, 0xe7, 0x6c, 0x25, 0xb0, 0xc3, 0x17, 0xa2, 0x06, 0x74, 0xfc, 0x1a, 0xb6, 0xbb,
-	0xe3, 0x77, 0x66, 0xca, 0x7f, 0x22, 0xb9, 0x03, 0xb5, 0x59, 0xc9, 0x69, 0x0e, 0xd5, 0x3f, 0x28,
-	0x90, 0x3b, 0x25, 0xa3, 0x73, 0xe2, 0x21, 0x04, 0x59, 0x5b, 0x1f, 0xc9, 0xda, 0xb4, 0xa0, 0x8a,
-	0xdf, 0x3c, 0x3f, 0x47, 0x82, 0x1a, 0xd9, 0x07, 0x72, 0xa2, 0x65, 0x70, 0xa2, 0x4b, 0x88, 0xa7,
-	0x8d, 0x3d, 0x4b, 0xc6, 0xbe, 0xa0, 0xe6, 0xf9, 0xc4, 0x99, 0x67, 0x51, 0xf4, 0x21, 0x14, 0x07,
-	0x96, 0x49, 0x6c, 0x26, 0xc9, 0x59, 0x41, 0x06, 0x39, 0x25, 0x00, 0xff, 0x07, 0x15, 0x99, 0x1a,
-	0x9a, 0xeb, 0x99, 0x8e, 0x67, 0xb2, 0x49, 0x6d, 0x45, 0xe4, 0x79, 0x59, 0x4e, 0x77, 0xfd, 0x59,
-	0x7c, 0x2c, 0x4e, 0x25, 0xa9, 0x64, 0x9a, 0xcd, 0x86, 0xff, 0xae, 0x00, 0x8a, 0x72, 0x4a, 0x93,
-	0x2d, 0x4f, 0x78, 0x71, 0x2e, 0xf8, 0xf8, 0xfb, 0x63, 0x23, 0xb6, 0x4a, 0xca, 0x50, 0x03, 0x0c,
-	0xfa, 0xff, 0xc4, 0x39, 0x37, 0x17, 0x1d, 0x1c, 0x77, 0x4f, 0xa1, 0x48, 0xd8, 0xc0, 0xd0, 0xfc,
-	0x15, 0xd9, 0xc5, 0x2b, 0x80, 0xe3, 0x4e, 0xa4, 0x75, 0xff, 0x52, 0x60, 0x4b, 0xee, 0xcd, 0x67,
-	0x44, 0xf7, 0xd8, 0x39, 0xd1, 0x59, 0x9a, 0xa4, 0x7c, 0xb7, 0x27, 0xf8, 0x37, 0x60, 0xcd, 0x25,
-	0xb6, 0x61, 0xda, 0x43, 0x8d, 0x67, 0x08, 0xad, 0xad, 0xcc, 0x39, 0x2a, 0x4a, 0x3e, 0x84, 0x0f,
-	0x28, 0xfa, 0x18, 0xaa, 0xba, 0xeb, 0x7a, 0xce, 0x6b, 0x73, 0xa4, 0x33, 0xa2, 0x51, 0xf3, 0x0d,
-	0xa9, 0x81, 0xc8, 0xc0, 0x4a, 0x64, 0xbe, 0x67, 0xbe, 0x21, 0xf8, 0x12, 0xa0, 0x71, 0xa9, 0xdb,
-	0x43, 0xc2, 0x57, 0xa2, 0x5d, 0xc8, 0x72, 0x19, 0xbe, 0xad, 0x71, 0x11, 0x82, 0x82, 0x3e, 0x87,
-	0xe2, 0x40, 0xe0, 0x35, 0xf1, 0x18, 0xcb, 0x88, 0xc7, 0xd8, 0xf6, 0x5e, 0xf0, 0xa8, 0xe4, 0xfb,
-	0x4a, 0xf2, 0x13, 0xaf, 0x31, 0x18, 0x84, 0xbf, 0xf1, 0x3e, 0x94, 0xfb, 0x9e, 0x6e, 0xd3, 0x0b,
-	0xe2, 0x49, 0xb7, 0xdf, 0x2c, 0x0d, 0xff, 0x2d, 0x03, 0xdb, 0x33, 0x81, 0x49, 0x93, 0x7b, 0x53,
-	0xf5, 0x85, 0xe4, 0xcc, 0x9c, 0x92, 0x6f, 0xea, 0x8e, 0x40, 0x7d, 0xe1, 0x9a, 0x23, 0xa8, 0x30,
-	0x5f, 0x7d, 0x2d, 0x16, 0xb5, 0xb8, 0xdc, 0xb8, 0x89, 0x6a, 0x99, 0xc5, 0x4d, 0x8e, 0x5d, 0x8e,
-	0xd9, 0xf8, 0xe5, 0x88, 0x3e, 0x83, 0x92, 0x4f, 0x24, 0xae, 0x33, 0xb8, 0x14, 0x7b, 0x9a, 0x67,
-	0x6f, 0x2c, 0x7b, 0x9a, 0x9c, 0xa4, 0x16, 0xbd, 0xe9, 0x00, 0x3d, 0x81, 0x22, 0xd3, 0xbd, 0x21,
-	0x61, 0xd2, 0xa8, 0xdc, 0x1c, 0x77, 0x82, 0x04, 0xf0, 0xdf, 0x78, 0x04, 0x95, 0x03, 0xfa, 0xb2,
-	0xe7, 0x5a, 0xe6, 0xff, 0x22, 0xcb, 0xf1, 0xcf, 0x15, 0xa8, 0x4e, 0xe5, 0xa5, 0x7b, 0x3c, 0xad,
-	0xd9, 0xe4, 0x95, 0x96, 0xac, 0x2e, 0x8a, 0x36, 0x79, 0xa5, 0x06, 0x3e, 0xdc, 0x85, 0x12, 0xc7,
-	0x88, 0xc3, 0xd5, 0x34, 0xe4, 0xd9, 0x9a, 0x55, 0xc1, 0x26, 0xaf, 0xb8, 0xed, 0x2d, 0x83, 0xe2,
-	0x5f, 0x29, 0x80, 0x54, 0xe2, 0x3a, 0x1e, 0x4b, 0xed, 0x02, 0x0c, 0x59, 0x8b, 0x5c, 0xb0, 0x05,
-	0x0e, 0x10, 0x34, 0xf4, 0x00, 0x56, 0x3c, 0x73, 0x78, 0xc9, 0xfc, 0x6c, 0x49, 0x82, 0x24, 0x11,
-	0x7f, 0x0f, 0x36, 0x62, 0x3a, 0xa5, 0xb9, 0x97, 0x3a, 0xb0, 0x2a, 0xb8, 0xb4, 0x8e, 0x66, 0x3d,
-	0xa6, 0xdc, 0xec, 0xb1, 0xcc, 0x8c, 0xc7, 0x7e, 0x04, 0xa5, 0xbe, 0x39, 0x22, 0x2d, 0x9b, 0x11,
-	0xef, 0x4a, 0xb7, 0xf8, 0xf5, 0x23, 0x2b, 0xaf, 0x69, 0x4f, 0x41, 0xf2, 0x2d, 0x8b, 0xe9, 0x69,
-	0x1f, 0xe4, 0x3e, 0xac, 0xf1, 0x7a, 0x6b, 0x0a, 0x93, 0x01, 0x2b, 0x11, 0xdb, 0x08, 0x41, 0xf8,
-	0x29, 0x80, 0x4a, 0x06, 0x8e, 0x67, 0x74, 0x75, 0xd3, 0x43, 0x55, 0x58, 0xe6, 0xe5, 0x99, 0xbc,
-	0x48, 0xf9, 0x4f, 0x5e, 0xca, 0x5d, 0xe9, 0xd6, 0x98, 0xf8, 0x8b, 0xe5, 0x00, 0xff, 0x72, 0x05,
-	0x60, 0xfa, 0x38, 0x8b, 0x3d, 0x27, 0x95, 0xd8, 0x73, 0x12, 0xd5, 0x21, 0x3f, 0xd0, 0x5d, 0x7d,
-	0xc0, 0x6f, 0x49, 0xff, 0x1a, 0x0e, 0xc6, 0xe8, 0x0e, 0x14, 0xf4, 0x2b, 0xdd, 0xb4, 0xf4, 0x73,
-	0x8b, 0x88, 0x00, 0x65, 0xd5, 0xe9, 0x04, 0xba, 0x17, 0xee, 0x47, 0xd9, 0x52, 0xc9, 0x8a, 0x96,
-	0x8a, 0xbf, 0xf5, 0x1a, 0xa2, 0xb1, 0xf2, 0x35, 0x40, 0xd4, 0x3f, 0x9c, 0xa9, 0xad, 0xbb, 0x3e,
-	0x70, 0x45, 0x00, 0xab, 0x3e, 0xa5, 0x67, 0xeb, 0xae, 0x44, 0x7f, 0x02, 0x9b, 0x1e, 0x19, 0x10,
-	0xf3, 0x2a, 0x81, 0xcf, 0x09, 0x3c, 0x0a, 0x69, 0xd3, 0x15, 0x77, 0x01, 0xa6, 0xae, 0xae, 0xad,
-	0x0a, 0x5c, 0x21, 0xf4, 0x32, 0xda, 0x83, 0x0d, 0xdd, 0x75, 0xad, 0x49, 0x82, 0x5f, 0x5e, 0xe0,
-	0xd6, 0x03, 0xd2, 0x94, 0xdd, 0x36, 0xac, 0x9a, 0x54, 0x3b, 0x1f, 0xd3, 0x49, 0xad, 0x20, 0x9e,
-	0x6a, 0x39, 0x93, 0x1e, 0x8e, 0xe9, 0x84, 0x9f, 0x4b, 0x63, 0x4a, 0x8c, 0xe8, 0x55, 0x91, 0xe7,
-	0x13, 0xfc, 0x8e, 0x40, 0xdf, 0x84, 0xbc, 0xe9, 0xc7, 0xbe, 0x56, 0x11, 0x79, 0xf8, 0xfe, 0x4c,
-	0xf3, 0x28, 0x48, 0x0e, 0x35, 0x84, 0xa2, 0xcf, 0x00, 0x06, 0xee, 0x58, 0x1b, 0x53, 0x7d, 0x48,
-	0x68, 0xad, 0x2a, 0x6e, 0xad, 0xed, 0x44, 0x02, 0x07, 0x71, 0x57, 0x0b, 0x03, 0x77, 0x7c, 0x26,
-	0x90, 0xe8, 0xdb, 0xb0, 0xe6, 0x11, 0xdd, 0xd0, 0x4c, 0x47, 0xf3, 0x74, 0x46, 0x68, 0x6d, 0xfd,
-	0xfa, 0xa5, 0x45, 0x8e, 0x6e, 0x39, 0x2a, 0xc7, 0xa2, 0xef, 0x40, 0xf9, 0x95, 0x67, 0x32, 0x32,
-	0x5d, 0x8d, 0xae, 0x5f, 0x5d, 0x12, 0xf0, 0x60, 0xf9, 0xb7, 0xa0, 0xe4, 0xb8, 0x9a, 0xa5, 0x33,
-	0x62, 0x0f, 0x4c, 0x42, 0x6b, 0x1b, 0x37, 0x88, 0x76, 0xdc, 0x93, 0x00, 0x8b, 0xdf, 0xc0, 0x7b,
-	0x22, 0x23, 0xdf, 0x49, 0x0d, 0x11, 0x76, 0x25, 0x32, 0xb7, 0xea, 0x4a, 0x9c, 0xc2, 0x56, 0x52,
-	0x76, 0x9a, 0x23, 0xe4, 0x8f, 0x0a, 0x6c, 0xf6, 0x06, 0x3a, 0xe3, 0x05, 0x74, 0xea, 0xa7, 0xf3,
-	0x75, 0x0f, 0xc2, 0xc8, 0x2d, 0xb2, 0x7c, 0xcb, 0x5a, 0x29, 0xbb, 0xb8, 0x56, 0xc2, 0x27, 0xf0,
-	0x5e, 0x42, 0xed, 0x94, 0x8d, 0xc4, 0x63, 0xc2, 0x8e, 0x1b, 0x3d, 0xfd, 0x82, 0x74, 0x1d, 0xd3,
-	0x4e, 0x13, 0x50, 0x6c, 0xc1, 0x56, 0x92, 0x59, 0x9a, 0xbb, 0x90, 0x1f, 0x0c, 0xfa, 0x05, 0xd1,
-	0x5c, 0xce, 0xca, 0xf7, 0x6a, 0x81, 0x06, 0xbc, 0xf1, 0x08, 0x6a, 0x67, 0xae, 0xa1, 0x33, 0xf2,
-	0x6e, 0xb4, 0xbf, 0x49, 0xdc, 0x15, 0xbc, 0x3f, 0x47, 0x5c, 0x1a, 0xfb, 0x1e, 0x40, 0x99, 0xdf,
-	0x4a, 0x33, 0x42, 0xf9, 0x5d, 0x15, 0x8a, 0xc0, 0x44, 0xbc, 0x4a, 0x3a, 0x2e, 0xf1, 0x74, 0xe6,
-	0x78, 0xff, 0xb5, 0xae, 0xc5, 0x9f, 0x65, 0xfb, 0x6c, 0x2a, 0x27, 0x8d, 0x65, 0xd7, 0x6e, 0x07,
-	0x04, 0x59, 0x83, 0xd0, 0x81, 0xd8, 0x0c, 0x25, 0x55, 0xfc, 0xe6, 0x52, 0xf8, 0x26, 0x1f, 0x53,
-	0x91, 0xfa, 0xe5, 0x84, 0x94, 0x40, 0xa9, 0x9e, 0x80, 0xa8, 0x3e, 0x94, 0x33, 0x7a, 0x69, 0xda,
-	0x86, 0xb8, 0x8a, 0x4a, 0xaa, 0xf8, 0xfd, 0xf8, 0x37, 0x0a, 0x14, 0xc2, 0x2f, 0x25, 0x28, 0x07,
-	0x99, 0xce, 0xf3, 0xea, 0x12, 0x2a, 0xc2, 0xea, 0x59, 0xfb, 0x79, 0xbb, 0xf3, 0xfd, 0x76, 0x55,
-	0x41, 0x9b, 0x50, 0x6d, 0x77, 0xfa, 0xda, 0x61, 0xa7, 0xd3, 0xef, 0xf5, 0xd5, 0x83, 0x6e, 0xb7,
-	0x79, 0x54, 0xcd, 0xa0, 0x0d, 0xa8, 0xf4, 0xfa, 0x1d, 0xb5, 0xa9, 0xf5, 0x3b, 0xa7, 0x87, 0xbd,
-	0x7e, 0xa7, 0xdd, 0xac, 0x2e, 0xa3, 0x1a, 0x6c, 0x1e, 0x9c, 0xa8, 0xcd, 0x83, 0xa3, 0x2f, 0xe3,
-	0xf0, 0x2c, 0xa7, 0xb4, 0xda, 0x8d, 0xce, 0x69, 0xf7, 0xa0, 0xdf, 0x3a, 0x3c, 0x69, 0x6a, 0x2f,
-	0x9a, 0x6a, 0xaf, 0xd5, 0x69, 0x57, 0x57, 0x38, 0x7b, 0xb5, 0x79, 0xdc, 0xea, 0xb4, 0x35, 0x2e,
-	0xe5, 0x8b, 0xce, 0x59, 0xfb, 0xa8, 0x9a, 0x7b, 0xdc, 0x85, 0x72, 0xdc, 0x0a, 0xae, 0x53, 0xef,
-	0xac, 0xd1, 0x68, 0xf6, 0x7a, 0x52, 0xc1, 0x7e, 0xeb, 0xb4, 0xd9, 0x39, 0xeb, 0x57, 0x15, 0x04,
-	0x90, 0x6b, 0x1c, 0xb4, 0x1b, 0xcd, 0x93, 0x6a, 0x86, 0x13, 0xd4, 0x66, 0xf7, 0xe4, 0xa0, 0xc1,
-	0xd5, 0xe1, 0x83, 0xb3, 0x76, 0xbb, 0xd5, 0x3e, 0xae, 0x66, 0xf7, 0x7f, 0x56, 0x86, 0x42, 0x2f,
-	0x70, 0x12, 0xea, 0x00, 0x4c, 0xdf, 0xae, 0x68, 0x27, 0xe6, 0xbe, 0x99, 0xe7, 0x71, 0xfd, 0xc3,
-	0x85, 0x74, 0x19, 0x4e, 0xbc, 0x84, 0xbe, 0x0b, 0xcb, 0x7d, 0xea, 0xa0, 0xf8, 0xa1, 0x3c, 0xfd,
-	0xac, 0x54, 0xaf, 0xcd, 0x12, 0x82, 0xb5, 0x8f, 0x94, 0x4f, 0x14, 0x74, 0x02, 0x85, 0xf0, 0x93,
-	0x02, 0xba, 0x1b, 0x03, 0x27, 0x3f, 0xb8, 0xd4, 0x77, 0x16, 0x91, 0x43, 0x6d, 0x7e, 0x08, 0xe5,
-	0xf8, 0x27, 0x0a, 0x84, 0x63, 0x6b, 0xe6, 0x7e, 0x0c, 0xa9, 0xdf, 0xbf, 0x16, 0x13, 0x32, 0xff,
-	0x02, 0x56, 0xfd, 0xcf, 0x08, 0x28, 0x9e, 0x77, 0xf1, 0x4f, 0x14, 0xf5, 0x3b, 0xf3, 0x89, 0x21,
-	0x9f, 0x16, 0xe4, 0x83, 0x9e, 0x3e, 0xba, 0x93, 0xf4, 0x70, 0xb4, 0x9b, 0x5e, 0xbf, 0xbb, 0x80,
-	0x1a, 0x65, 0x15, 0x74, 0xc5, 0x13, 0xac, 0x12, 0x8d, 0xf9, 0x04, 0xab, 0x64, 0x2b, 0x1d, 0x2f,
-	0xa1, 0x33, 0x28, 0x45, 0x3b, 0xd2, 0x68, 0x37, 0x29, 0x3b, 0xd9, 0x31, 0xaf, 0xdf, 0xbb, 0x06,
-	0x11, 0x8d, 0x48, 0xfc, 0x36, 0x4e, 0x44, 0x64, 0x6e, 0x99, 0x90, 0x88, 0xc8, 0xfc, 0xeb, 0x1c,
-	0x2f, 0xa1, 0x73, 0xa8, 0x24, 0x9e, 0xc4, 0xe8, 0x7e, 0xe2, 0xdc, 0x99, 0xd7, 0xc9, 0xa8, 0x3f,
-	0xb8, 0x1e, 0x94, 0x4c, 0xd0, 0xb0, 0x1f, 0x8c, 0x66, 0x02, 0x12, 0x2b, 0x09, 0xea, 0x3b, 0x8b,
-	0xc8, 0xa1, 0xc6, 0x5d, 0x58, 0x3b, 0x26, 0xac, 0xeb, 0x91, 0xab, 0x77, 0xc5, 0xb1, 0x2f, 0x38,
-	0x4e, 0xfb, 0xd5, 0xe8, 0xde, 0xfc, 0x25, 0x91, 0x5e, 0xf6, 0x2d, 0xb8, 0xaa, 0x50, 0x8c, 0x34,
-	0x81, 0x51, 0xfc, 0x20, 0x98, 0xed, 0x5a, 0xd7, 0x77, 0x17, 0x03, 0xa2, 0xc9, 0x1a, 0x3c, 0x7e,
-	0x13, 0xc9, 0x9a, 0x78, 0x83, 0x27, 0x92, 0x35, 0xf9, 0x62, 0xc6, 0x4b, 0x48, 0x17, 0x9f, 0x32,
-	0x62, 0x0d, 0x4c, 0xf4, 0x20, 0x69, 0xd4, 0xbc, 0xce, 0x6a, 0xfd, 0xa3, 0x1b, 0x50, 0x51, 0x11,
-	0xc9, 0x1e, 0x69, 0x42, 0xc4, 0x82, 0xe6, 0x6d, 0x42, 0xc4, 0xa2, 0x46, 0x2b, 0x5e, 0x42, 0x3f,
-	0x80, 0xb5, 0x58, 0x89, 0x96, 0x08, 0xdd, 0xbc, 0xaa, 0xb3, 0x8e, 0xaf, 0x83, 0x44, 0x77, 0x5d,
-	0xbc, 0xc2, 0x4a, 0xec, 0xba, 0xb9, 0xb5, 0x5c, 0x62, 0xd7, 0xcd, 0x2f, 0xd1, 0xf0, 0x12, 0x32,
-	0x60, 0x7d, 0xa6, 0xc2, 0x41, 0x71, 0xa3, 0x17, 0x15, 0x5c, 0xf5, 0x87, 0x37, 0xc1, 0xa2, 0x19,
-	0x18, 0xa9, 0x33, 0xd0, 0xcc, 0x55, 0x94, 0xa8, 0x74, 0xea, 0xbb, 0x8b, 0x01, 0x01, 0xcf, 0xc3,
-	0xea, 0x5f, 0xde, 0xee, 0x28, 0x7f, 0x7d, 0xbb, 0xa3, 0xfc, 0xe3, 0xed, 0x8e, 0xf2, 0xdb, 0x7f,
-	0xee, 0x2c, 0x9d, 0xe7, 0xc4, 0x9f, 0x3c, 0x3e, 0xfd, 0x77, 0x00, 0x00, 0x00, 0xff, 0xff, 0x40,
-	0x5d, 0x19, 0x5a, 0x39, 0x22, 0x00, 0x00,
+func init() { proto.RegisterFile("schedulerpb.proto", fileDescriptor_schedulerpb_7a7bf47d61c3cf3f) }
+
+var fileDescriptor_schedulerpb_7a7bf47d61c3cf3f = []byte{
+	// 2881 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x5a, 0xbd, 0x73, 0x23, 0x49,
+	0x15, 0xdf, 0x91, 0x64, 0xd9, 0x7a, 0x92, 0x25, 0xb9, 0xed, 0xb5, 0x67, 0x75, 0x6b, 0x9f, 0x6f,
+	0x76, 0xef, 0xd8, 0x5b, 0xb8, 0xbd, 0xc3, 0xb7, 0x77, 0x5c, 0x41, 0x41, 0x95, 0x3f, 0x74, 0x3e,
+	0xb1, 0xb6, 0xa4, 0x1a, 0xc9, 0x07, 0x57, 0x50, 0x0c, 0xe3, 0x99, 0xb6, 0x3c, 0x78, 0x34, 0x33,
+	0x37, 0xdd, 0xf2, 0xae, 0x36, 0x04, 0x12, 0x02, 0x08, 0x28, 0x02, 0xaa, 0x20, 0x80, 0x80, 0x22,
+	0x22, 0x24, 0x23, 0x24, 0x20, 0x24, 0x27, 0xa1, 0x96, 0x88, 0x84, 0xbf, 0x80, 0x80, 0xea, 0xee,
+	0xf9, 0xd0, 0x8c, 0x3e, 0xd6, 0xd4, 0x2c, 0x64, 0x9a, 0xf7, 0x7e, 0xfd, 0xde, 0xeb, 0xd7, 0xaf,
+	0x5f, 0xbf, 0x7e, 0x2d, 0x58, 0x23, 0xc6, 0x25, 0x36, 0x47, 0x36, 0xf6, 0xbd, 0xf3, 0x47, 0x9e,
+	0xef, 0x52, 0x17, 0x95, 0x27, 0x48, 0x8d, 0xca, 0x10, 0x53, 0x3d, 0x64, 0x35, 0x56, 0xb1, 0xaf,
+	0x5f, 0xd0, 0xe8, 0x73, 0x63, 0xe0, 0x0e, 0x5c, 0xfe, 0xf3, 0x5d, 0xf6, 0x4b, 0x50, 0x95, 0x47,
+	0xb0, 0xaa, 0xe2, 0xcf, 0x47, 0x98, 0xd0, 0x4f, 0xb0, 0x6e, 0x62, 0x1f, 0x6d, 0x03, 0x18, 0xf6,
+	0x88, 0x50, 0xec, 0x6b, 0x96, 0x29, 0x4b, 0xbb, 0xd2, 0x83, 0x82, 0x5a, 0x0a, 0x28, 0x2d, 0x53,
+	0xf9, 0x0c, 0xaa, 0x2a, 0x26, 0x9e, 0xeb, 0x10, 0x7c, 0xa3, 0x01, 0xe8, 0x01, 0x2c, 0x61, 0xdf,
+	0x77, 0x7d, 0x39, 0xb7, 0x2b, 0x3d, 0x28, 0xef, 0xa1, 0x47, 0x93, 0x73, 0x68, 0x32, 0x8e, 0x2a,
+	0x00, 0xca, 0x29, 0x2c, 0xf1, 0x6f, 0xf4, 0x10, 0x0a, 0x74, 0xec, 0x61, 0x2e, 0xab, 0xba, 0xb7,
+	0x39, 0x3d, 0xa2, 0x3f, 0xf6, 0xb0, 0xca, 0x31, 0x48, 0x86, 0xe5, 0x21, 0x26, 0x44, 0x1f, 0x60,
+	0xae, 0xa0, 0xa4, 0x86, 0x9f, 0xca, 0xa7, 0x00, 0x7d, 0xe2, 0x06, 0x93, 0x43, 0x7b, 0x50, 0xbc,
+	0xe4, 0xf6, 0x72, 0xa9, 0xe5, 0xbd, 0x46, 0x42, 0x6a, 0xc2, 0x05, 0x6a, 0x80, 0x44, 0x1b, 0xb0,
+	0x64, 0xb8, 0x23, 0x87, 0x72, 0xc9, 0xab, 0xaa, 0xf8, 0x50, 0xf6, 0xa1, 0xd4, 0xb7, 0x86, 0x98,
+	0x50, 0x7d, 0xe8, 0xa1, 0x06, 0xac, 0x78, 0x97, 0x63, 0x62, 0x19, 0xba, 0xcd, 0x05, 0xe7, 0xd5,
+	0xe8, 0x9b, 0x99, 0x66, 0xbb, 0x03, 0xce, 0xca, 0x71, 0x56, 0xf8, 0xa9, 0xfc, 0x4c, 0x82, 0x32,
+	0xb7, 0x4d, 0x38, 0x12, 0xbd, 0x9f, 0x32, 0xee, 0xb5, 0x94, 0x71, 0x93, 0xfe, 0x5e, 0x6c, 0x1d,
+	0x7a, 0x0c, 0x25, 0x1a, 0x5a, 0x27, 0xe7, 0xb9, 0xb4, 0xa4, 0x03, 0x23, 0xdb, 0xd5, 0x18, 0xa8,
+	0x5c, 0x41, 0xfd, 0xc0, 0x75, 0x29, 0xa1, 0xbe, 0xee, 0x65, 0xf1, 0xd8, 0x3d, 0x58, 0x22, 0xd4,
+	0xf5, 0x71, 0xb0, 0xd8, 0xab, 0x8f, 0x82, 0x80, 0xec, 0x31, 0xa2, 0x2a, 0x78, 0xca, 0x27, 0xb0,
+	0x36, 0xa1, 0x2c, 0x83, 0x0b, 0x94, 0x27, 0x70, 0xbb, 0x45, 0x22, 0x59, 0x1e, 0x36, 0x33, 0xd8,
+	0xae, 0x7c, 0x0e, 0x9b, 0x69, 0x61, 0x59, 0x96, 0x47, 0x81, 0xca, 0xf9, 0x84, 0x30, 0xee, 0x91,
+	0x15, 0x35, 0x41, 0x53, 0x8e, 0xa0, 0xba, 0x6f, 0xdb, 0xae, 0xd1, 0x3a, 0xca, 0x62, 0xf8, 0xa7,
+	0x50, 0x8b, 0xa4, 0x64, 0xb1, 0xb8, 0x0a, 0x39, 0x4b, 0xd8, 0x59, 0x50, 0x73, 0x96, 0xa9, 0x7c,
+	0x1f, 0x6a, 0xc7, 0x98, 0x8a, 0xa5, 0xcb, 0x10, 0x13, 0x77, 0x60, 0x85, 0xaf, 0xbb, 0x16, 0x09,
+	0x5f, 0xe6, 0xdf, 0x2d, 0x53, 0xf9, 0x95, 0x04, 0xf5, 0x58, 0x45, 0x16, 0xdb, 0x6f, 0x12, 0x78,
+	0xe8, 0x1d, 0x06, 0xd2, 0x29, 0x09, 0xf6, 0xc5, 0x56, 0x42, 0x30, 0x47, 0xf6, 0x18, 0x5b, 0x15,
+	0x28, 0xe5, 0x07, 0x50, 0xeb, 0x8e, 0xb2, 0xcf, 0xff, 0x46, 0x7b, 0xe2, 0x18, 0xea, 0xb1, 0xae,
+	0x2c, 0x5b, 0xe2, 0x47, 0x12, 0xac, 0x1f, 0x63, 0xba, 0x6f, 0xdb, 0x5c, 0x18, 0xc9, 0x62, 0xf9,
+	0x47, 0x20, 0xe3, 0x67, 0x86, 0x3d, 0x32, 0xb1, 0x46, 0xdd, 0xe1, 0x39, 0xa1, 0xae, 0x83, 0x35,
+	0x6e, 0x2f, 0x09, 0xc2, 0x79, 0x33, 0xe0, 0xf7, 0x43, 0xb6, 0x50, 0xaa, 0xf8, 0xb0, 0x91, 0x34,
+	0x22, 0xcb, 0xda, 0xbe, 0x09, 0xc5, 0x48, 0x69, 0x7e, 0xda, 0x83, 0x01, 0x53, 0xc1, 0x3c, 0x96,
+	0x54, 0x3c, 0xb0, 0x5c, 0x27, 0xcb, 0xac, 0xb7, 0x01, 0x7c, 0x2e, 0x44, 0xbb, 0xc2, 0x63, 0x3e,
+	0xcf, 0x8a, 0x5a, 0x12, 0x94, 0x27, 0x78, 0xac, 0xfc, 0x49, 0x82, 0xb5, 0x09, 0x3d, 0x59, 0x26,
+	0xf6, 0x16, 0x14, 0x85, 0xdc, 0x20, 0x34, 0xaa, 0xe1, 0xc4, 0x02, 0xe1, 0x01, 0x17, 0xdd, 0x87,
+	0xa2, 0x2d, 0x84, 0x8b, 0xc0, 0xad, 0x84, 0xb8, 0x2e, 0x66, 0xd2, 0x04, 0x8f, 0xa1, 0x88, 0xad,
+	0x5f, 0x63, 0x22, 0x17, 0x76, 0xf3, 0xd3, 0x28, 0xc1, 0x53, 0x06, 0x7c, 0x65, 0x84, 0x82, 0x83,
+	0x71, 0xa6, 0xc4, 0x83, 0x5e, 0x83, 0xc0, 0x2f, 0xf1, 0xd6, 0x5e, 0x11, 0x84, 0x96, 0xa9, 0xfc,
+	0x42, 0x02, 0xd4, 0x33, 0x74, 0x47, 0xa8, 0x22, 0x19, 0xf5, 0x10, 0xaa, 0xfb, 0x74, 0x62, 0x41,
+	0x56, 0x38, 0xe1, 0x09, 0x1e, 0xb3, 0x63, 0xd0, 0xb6, 0x86, 0x16, 0xe5, 0xbe, 0x59, 0x52, 0xc5,
+	0x07, 0xda, 0x82, 0x65, 0xec, 0x98, 0x7c, 0x40, 0x81, 0x0f, 0x28, 0x62, 0xc7, 0x64, 0xcb, 0xf7,
+	0x6b, 0x09, 0xd6, 0x13, 0x66, 0x65, 0x59, 0xc0, 0x07, 0xb0, 0x2c, 0xe6, 0x1b, 0x86, 0x66, 0x7a,
+	0x05, 0x43, 0x36, 0x7a, 0x0b, 0x96, 0xc5, 0x32, 0xb1, 0xe4, 0x33, 0xbd, 0x3a, 0x21, 0x53, 0x39,
+	0x85, 0xad, 0x63, 0x4c, 0x0f, 0x45, 0xf5, 0x74, 0xe8, 0x3a, 0x17, 0xd6, 0x20, 0xcb, 0xd1, 0xf0,
+	0x1c, 0xe4, 0x69, 0x71, 0x59, 0x66, 0xfc, 0x36, 0x2c, 0x07, 0xa5, 0x5d, 0x10, 0xb3, 0xb5, 0x70,
+	0x1e, 0x81, 0x12, 0x35, 0xe4, 0x2b, 0xcf, 0x60, 0xab, 0x3b, 0x7a, 0x65, 0x53, 0xf9, 0x6f, 0x34,
+	0x77, 0x40, 0x9e, 0xd6, 0x9c, 0x25, 0xa9, 0xfe, 0x46, 0x82, 0xe2, 0x29, 0x1e, 0x9e, 0x63, 0x1f,
+	0x21, 0x28, 0x38, 0xfa, 0x50, 0xd4, 0xa6, 0x25, 0x95, 0xff, 0x66, 0xf1, 0x39, 0xe4, 0xdc, 0x89,
+	0x7d, 0x20, 0x08, 0x2d, 0x93, 0x31, 0x3d, 0x8c, 0x7d, 0x6d, 0xe4, 0xdb, 0x62, 0xed, 0x4b, 0xea,
+	0x0a, 0x23, 0x9c, 0xf9, 0x36, 0x41, 0xaf, 0x43, 0xd9, 0xb0, 0x2d, 0xec, 0x50, 0xc1, 0x2e, 0x70,
+	0x36, 0x08, 0x12, 0x07, 0x7c, 0x01, 0x6a, 0x22, 0x34, 0x34, 0xcf, 0xb7, 0x5c, 0xdf, 0xa2, 0x63,
+	0x79, 0x89, 0xc7, 0x79, 0x55, 0x90, 0xbb, 0x01, 0x55, 0x39, 0xe6, 0x59, 0x49, 0x18, 0x99, 0x65,
+	0xb3, 0x29, 0x7f, 0x93, 0x00, 0x4d, 0x4a, 0xca, 0x12, 0x2d, 0xef, 0xb0, 0xe2, 0x9c, 0xcb, 0x09,
+	0xf6, 0xc7, 0x7a, 0x62, 0x94, 0xd0, 0xa1, 0x86, 0x18, 0xf4, 0xc5, 0x54, 0x9e, 0x9b, 0x89, 0x0e,
+	0x20, 0xe8, 0x31, 0x94, 0x31, 0x35, 0x4c, 0x2d, 0x18, 0x51, 0x98, 0x3f, 0x02, 0x18, 0xee, 0x44,
+	0xcc, 0xee, 0x5f, 0x39, 0xd8, 0x14, 0x7b, 0xf3, 0x13, 0xac, 0xfb, 0xf4, 0x1c, 0xeb, 0x34, 0x4b,
+	0x50, 0xbe, 0xda, 0x0c, 0xfe, 0x65, 0x58, 0xf5, 0xb0, 0x63, 0x5a, 0xce, 0x40, 0xf3, 0x30, 0x73,
+	0xda, 0xd2, 0x8c, 0x54, 0x51, 0x09, 0x20, 0xec, 0x83, 0xa0, 0xb7, 0xa1, 0xae, 0x7b, 0x9e, 0xef,
+	0x3e, 0xb3, 0x86, 0x3a, 0xc5, 0x1a, 0xb1, 0x9e, 0x63, 0x19, 0x78, 0x04, 0xd6, 0x26, 0xe8, 0x3d,
+	0xeb, 0x39, 0x4e, 0x43, 0xaf, 0xf0, 0x98, 0xc8, 0xe5, 0x29, 0xe8, 0x13, 0x3c, 0x26, 0xa8, 0x05,
+	0x6b, 0xc4, 0xd1, 0x3d, 0x72, 0xe9, 0x52, 0xa2, 0xe9, 0x9e, 0x67, 0x5b, 0xd8, 0x94, 0x2b, 0xdc,
+	0x98, 0xbb, 0xc9, 0xa2, 0x29, 0x40, 0xed, 0x0b, 0x8c, 0x5a, 0x8f, 0x86, 0x05, 0x14, 0xe5, 0x27,
+	0x12, 0xd4, 0x52, 0x28, 0xb4, 0x0b, 0x05, 0x0f, 0x47, 0x7e, 0x4e, 0x4e, 0x8f, 0x73, 0x58, 0x52,
+	0xb7, 0x1c, 0x13, 0x3f, 0x0b, 0x76, 0x93, 0xf8, 0x60, 0x7b, 0x8f, 0x62, 0x7f, 0xc8, 0x7d, 0x58,
+	0x50, 0xf9, 0x6f, 0xf4, 0x10, 0xd6, 0x98, 0x81, 0x63, 0xcd, 0x1c, 0xf9, 0x3a, 0x65, 0x67, 0xd1,
+	0x90, 0xc8, 0x85, 0x68, 0x5a, 0xf6, 0xf8, 0x28, 0xa0, 0x9f, 0x12, 0xe5, 0x12, 0xe0, 0xf0, 0x52,
+	0x77, 0x06, 0x98, 0x69, 0xba, 0x81, 0x15, 0x1f, 0x41, 0xd9, 0xe0, 0x78, 0x8d, 0x5f, 0x47, 0x73,
+	0xfc, 0x3a, 0xba, 0xf5, 0x28, 0xbc, 0x56, 0xb3, 0xcc, 0x22, 0xe4, 0xf1, 0xfb, 0x28, 0x18, 0xd1,
+	0x6f, 0x65, 0x0f, 0xaa, 0x7d, 0x5f, 0x77, 0xc8, 0x05, 0xf6, 0x45, 0xe0, 0xbd, 0x5c, 0x9b, 0xf2,
+	0x2e, 0x2c, 0x9d, 0x62, 0x7f, 0x80, 0x59, 0x50, 0x51, 0xdd, 0x1f, 0x60, 0x2a, 0x4b, 0xb3, 0x83,
+	0x4a, 0x70, 0x95, 0x7f, 0xe7, 0x60, 0x6b, 0x2a, 0x96, 0xb3, 0x6c, 0xd7, 0x78, 0xbe, 0xdc, 0xd4,
+	0xdc, 0x8c, 0x2a, 0x39, 0xf6, 0x5f, 0x38, 0x5f, 0xf6, 0x1b, 0x1d, 0x41, 0x8d, 0x06, 0xf3, 0xd5,
+	0x12, 0x81, 0x9e, 0xd4, 0x9b, 0xf4, 0x89, 0x5a, 0xa5, 0x49, 0x1f, 0x25, 0xea, 0x89, 0x42, 0xb2,
+	0x9e, 0x40, 0x1f, 0x42, 0x25, 0x60, 0x62, 0xcf, 0x35, 0x2e, 0xe5, 0xa5, 0x60, 0xc3, 0x27, 0x7c,
+	0xd3, 0x64, 0x2c, 0xb5, 0xec, 0xc7, 0x1f, 0xe8, 0x1d, 0x28, 0x0b, 0x7f, 0x89, 0x49, 0x15, 0x67,
+	0xf8, 0x1f, 0x04, 0x80, 0xcf, 0xe4, 0x01, 0x2c, 0x0d, 0xd9, 0x2a, 0xc8, 0xcb, 0x33, 0xda, 0x15,
+	0x7c, 0x7d, 0x54, 0x01, 0x50, 0x86, 0x50, 0xdb, 0x27, 0x57, 0x3d, 0xcf, 0xb6, 0xfe, 0x1f, 0x29,
+	0x44, 0xf9, 0xa9, 0x04, 0xf5, 0x58, 0x5f, 0xb6, 0x9b, 0xe9, 0xaa, 0x83, 0x9f, 0x6a, 0xe9, 0xd2,
+	0xad, 0xec, 0xe0, 0xa7, 0x6a, 0xe8, 0xed, 0x5d, 0xa8, 0x30, 0x0c, 0x3f, 0xb9, 0x2c, 0x53, 0x1c,
+	0x5c, 0x05, 0x15, 0x1c, 0xfc, 0x94, 0x79, 0xa9, 0x65, 0x12, 0xe5, 0xe7, 0x12, 0x20, 0x15, 0x7b,
+	0xae, 0x4f, 0x33, 0xbb, 0x40, 0x81, 0x82, 0x8d, 0x2f, 0xe8, 0x1c, 0x07, 0x70, 0x1e, 0xba, 0x0f,
+	0x4b, 0xbe, 0x35, 0xb8, 0xa4, 0x72, 0x7e, 0x26, 0x48, 0x30, 0x95, 0x6f, 0xc2, 0x7a, 0xc2, 0xa6,
+	0x2c, 0x87, 0x7e, 0x07, 0x96, 0xb9, 0x94, 0xd6, 0xd1, 0xb4, 0xc7, 0xa4, 0x97, 0x7b, 0x2c, 0x37,
+	0xe5, 0xb1, 0xef, 0x42, 0x85, 0x35, 0x5f, 0x5a, 0x0e, 0xc5, 0xfe, 0xb5, 0x6e, 0xb3, 0xb3, 0x5d,
+	0x94, 0xb5, 0x71, 0xc3, 0x46, 0xc8, 0xad, 0x72, 0x72, 0xdc, 0x64, 0xba, 0x07, 0xab, 0xac, 0x98,
+	0x8d, 0x61, 0x62, 0xc1, 0x2a, 0xd8, 0x31, 0x23, 0x90, 0xf2, 0x18, 0x40, 0xc5, 0x86, 0xeb, 0x9b,
+	0x5d, 0xdd, 0xf2, 0x51, 0x1d, 0xf2, 0xac, 0xf6, 0x15, 0x55, 0x0a, 0xfb, 0xc9, 0x52, 0xea, 0xb5,
+	0x6e, 0x8f, 0x70, 0x98, 0x52, 0xf9, 0x87, 0xf2, 0xbb, 0x12, 0x40, 0x7c, 0xf3, 0x4d, 0xdc, 0xd5,
+	0xa5, 0xc4, 0x5d, 0x9d, 0x75, 0xba, 0x0c, 0xdd, 0xd3, 0x0d, 0x56, 0x82, 0x04, 0x35, 0x4e, 0xf8,
+	0x8d, 0xee, 0x42, 0x49, 0xbf, 0xd6, 0x2d, 0x5b, 0x3f, 0xb7, 0x71, 0x90, 0x9d, 0x63, 0x02, 0x7a,
+	0x23, 0xda, 0xb9, 0xa2, 0x5f, 0x55, 0xe0, 0xfd, 0xaa, 0x60, 0x93, 0x1e, 0x32, 0x12, 0xfa, 0x12,
+	0x20, 0x12, 0x9c, 0x7c, 0xec, 0x04, 0x09, 0x80, 0x4b, 0x1c, 0x58, 0x0f, 0x38, 0xec, 0x14, 0x11,
+	0xe8, 0xf7, 0x60, 0xc3, 0xc7, 0x06, 0xb6, 0xae, 0x53, 0xf8, 0x22, 0xc7, 0xa3, 0x88, 0x17, 0x8f,
+	0xd8, 0x06, 0x88, 0x5d, 0xcd, 0xb7, 0xf6, 0xaa, 0x5a, 0x8a, 0xbc, 0x8c, 0x1e, 0xc1, 0x3a, 0x3f,
+	0x2b, 0x52, 0xf2, 0x56, 0x38, 0x6e, 0x2d, 0x64, 0xc5, 0xe2, 0xb6, 0x60, 0xd9, 0x22, 0xda, 0xf9,
+	0x88, 0x8c, 0xe5, 0x12, 0xbf, 0x07, 0x17, 0x2d, 0x72, 0x30, 0x22, 0x63, 0x96, 0xc1, 0x46, 0x04,
+	0x9b, 0x93, 0xe7, 0xf0, 0x0a, 0x23, 0xf0, 0x03, 0xf8, 0x03, 0x58, 0xb1, 0x82, 0xb5, 0x97, 0x6b,
+	0x3c, 0x0e, 0xef, 0x4c, 0x75, 0xe6, 0xc2, 0xe0, 0x50, 0x23, 0x28, 0xfa, 0x10, 0xc0, 0xf0, 0x46,
+	0xda, 0x88, 0xe8, 0x03, 0x4c, 0xe4, 0xfa, 0x6e, 0x7e, 0x2a, 0x29, 0xc7, 0xeb, 0xae, 0x96, 0x0c,
+	0x6f, 0x74, 0xc6, 0x91, 0xe8, 0x6b, 0xb0, 0xea, 0x63, 0xdd, 0xd4, 0x2c, 0x57, 0xf3, 0x75, 0x8a,
+	0x89, 0xbc, 0xb6, 0x78, 0x68, 0x99, 0xa1, 0x5b, 0xae, 0xca, 0xb0, 0xe8, 0xeb, 0x50, 0x7d, 0xea,
+	0x5b, 0x14, 0xc7, 0xa3, 0xd1, 0xe2, 0xd1, 0x15, 0x0e, 0x0f, 0x87, 0x7f, 0x15, 0x2a, 0xae, 0xa7,
+	0xd9, 0x3a, 0xc5, 0x8e, 0x61, 0x61, 0x22, 0xaf, 0xbf, 0x44, 0xb5, 0xeb, 0x9d, 0x84, 0x58, 0x16,
+	0x2e, 0x86, 0xed, 0x1a, 0x57, 0x9a, 0x7b, 0x71, 0x41, 0x30, 0x95, 0x37, 0x78, 0xef, 0xb4, 0xcc,
+	0x69, 0x1d, 0x4e, 0x62, 0x1b, 0xc2, 0x22, 0x9a, 0xe1, 0x0e, 0x3d, 0xdd, 0xa0, 0x96, 0x33, 0x90,
+	0x6f, 0x8b, 0xe6, 0x9a, 0x45, 0x0e, 0x23, 0x1a, 0xda, 0x83, 0xdb, 0xc4, 0x76, 0x9f, 0x06, 0xe7,
+	0x91, 0x16, 0x9e, 0x35, 0x44, 0xde, 0xe4, 0xcb, 0xba, 0xce, 0x98, 0xe2, 0xe0, 0x09, 0x8f, 0x25,
+	0x82, 0x3e, 0x00, 0x30, 0x2d, 0x72, 0xa5, 0x89, 0x36, 0xd1, 0xd6, 0x6e, 0x7e, 0xaa, 0x7d, 0x7a,
+	0x64, 0x91, 0x2b, 0xd1, 0x25, 0x2a, 0x99, 0xe1, 0x4f, 0xa6, 0x6a, 0x80, 0x1d, 0xcc, 0x0a, 0x8d,
+	0x64, 0x04, 0xc9, 0x42, 0x55, 0xcc, 0x8c, 0x63, 0x28, 0x1d, 0xf2, 0xe7, 0x63, 0xe6, 0xe5, 0x3b,
+	0x3c, 0x66, 0x26, 0x43, 0xfe, 0x80, 0xd1, 0x67, 0x84, 0xbc, 0xc0, 0x37, 0x38, 0x3e, 0x19, 0xf2,
+	0x62, 0xc4, 0x54, 0x4c, 0x8b, 0x01, 0xaf, 0xf1, 0x01, 0x89, 0x98, 0x16, 0xf8, 0x8f, 0x40, 0x8e,
+	0x7b, 0x93, 0x61, 0x09, 0x1a, 0x4c, 0xe3, 0x2e, 0x9f, 0xc6, 0x66, 0x82, 0xcf, 0xb2, 0x5a, 0xb4,
+	0x1d, 0xb9, 0x02, 0xc2, 0x93, 0xd4, 0xa5, 0xef, 0x8e, 0x06, 0x97, 0xde, 0x88, 0xca, 0xdb, 0xc2,
+	0x36, 0xc6, 0xeb, 0xb1, 0x54, 0x15, 0x71, 0xa2, 0x11, 0x3e, 0x36, 0xae, 0x27, 0x47, 0xec, 0xc4,
+	0x23, 0x54, 0x6c, 0x5c, 0xc7, 0x23, 0x94, 0x21, 0x94, 0x22, 0xcf, 0xcf, 0xbc, 0x83, 0x21, 0x28,
+	0x78, 0x3a, 0xbd, 0x0c, 0x1e, 0x01, 0xf8, 0xef, 0x44, 0xca, 0xca, 0x2f, 0x4a, 0x59, 0x85, 0x54,
+	0xca, 0x52, 0x9e, 0xc3, 0x6d, 0x9e, 0x15, 0x5f, 0xc9, 0x25, 0x21, 0x6a, 0x3b, 0xe6, 0x6e, 0xd4,
+	0x76, 0xfc, 0x83, 0x04, 0x9b, 0x69, 0xe5, 0xd9, 0xda, 0x67, 0xd5, 0x08, 0x25, 0xf2, 0x9f, 0x78,
+	0x8d, 0x58, 0x8d, 0xa8, 0x3c, 0x07, 0x7e, 0x05, 0xca, 0x84, 0xea, 0x36, 0x0e, 0xae, 0x1e, 0xf9,
+	0x19, 0xb1, 0xdf, 0x63, 0x7c, 0x51, 0x31, 0x91, 0xf0, 0x27, 0x51, 0x7e, 0x28, 0x41, 0x29, 0xe2,
+	0x24, 0x6b, 0x38, 0x29, 0x55, 0xc3, 0x85, 0x45, 0x70, 0x6e, 0x6e, 0xc9, 0x9d, 0xae, 0xf2, 0xf2,
+	0x37, 0xab, 0xf2, 0x94, 0x3f, 0x4a, 0xb0, 0xd1, 0x33, 0x74, 0x4a, 0xb1, 0x9f, 0xbd, 0x03, 0xb8,
+	0xa8, 0xaf, 0x35, 0x51, 0xaf, 0xe5, 0x6f, 0x78, 0xe5, 0x2b, 0xcc, 0xbf, 0xf2, 0x29, 0x27, 0x70,
+	0x3b, 0x65, 0x76, 0xc6, 0xf7, 0x90, 0x63, 0x4c, 0x8f, 0x0f, 0x7b, 0xfa, 0x05, 0xee, 0xba, 0x96,
+	0x93, 0x25, 0x6c, 0x15, 0x1b, 0x36, 0xd3, 0xc2, 0xb2, 0x84, 0x21, 0x3b, 0x82, 0xf5, 0x0b, 0xac,
+	0x79, 0x4c, 0x54, 0xe0, 0xd5, 0x12, 0x09, 0x65, 0x2b, 0x43, 0x90, 0xcf, 0x3c, 0x53, 0xa7, 0xf8,
+	0xd5, 0x58, 0xff, 0x32, 0x75, 0xd7, 0x70, 0x67, 0x86, 0xba, 0x2c, 0xf3, 0xbb, 0x0f, 0x55, 0x56,
+	0xff, 0x4d, 0x29, 0x65, 0x55, 0x61, 0xa4, 0x42, 0xc1, 0xbc, 0xb9, 0xd2, 0xf1, 0xd8, 0x71, 0xe0,
+	0xfa, 0xff, 0xb3, 0xe6, 0xeb, 0x9f, 0xc5, 0x2b, 0x40, 0xac, 0x27, 0xcb, 0xcc, 0x16, 0x6e, 0x07,
+	0x04, 0x05, 0x13, 0x13, 0x83, 0x6f, 0x86, 0x8a, 0xca, 0x7f, 0x33, 0x2d, 0x2c, 0x95, 0x8d, 0xc4,
+	0x45, 0xbc, 0x9a, 0xd2, 0x12, 0x1a, 0xd5, 0xe3, 0x10, 0x35, 0x80, 0x32, 0x41, 0x57, 0x96, 0x63,
+	0xf2, 0xa2, 0xaf, 0xa2, 0xf2, 0xdf, 0xca, 0x6f, 0x25, 0xd8, 0xec, 0xea, 0x23, 0x82, 0x7b, 0x62,
+	0xbc, 0xe5, 0x64, 0x6a, 0x21, 0xde, 0x85, 0x52, 0x04, 0x0a, 0x0e, 0x8a, 0x98, 0x80, 0x36, 0xd9,
+	0xc6, 0x26, 0xa3, 0xa1, 0xa8, 0x60, 0x57, 0xd4, 0xe0, 0x8b, 0x45, 0x92, 0xc7, 0x6c, 0xd0, 0x08,
+	0x36, 0xc2, 0xd6, 0x42, 0x89, 0x53, 0x7a, 0xd8, 0x20, 0xca, 0x09, 0xd4, 0x62, 0xeb, 0xb8, 0xb1,
+	0x49, 0x3d, 0x52, 0x5a, 0x0f, 0x77, 0x27, 0x93, 0xac, 0xe9, 0x34, 0x48, 0xc5, 0x2b, 0x82, 0xb0,
+	0x4f, 0x95, 0x1f, 0x4b, 0xb0, 0x35, 0x35, 0xe3, 0x2c, 0x8b, 0xf7, 0x18, 0x8a, 0xdc, 0xd6, 0xb0,
+	0x03, 0x97, 0xea, 0xdf, 0x24, 0x2d, 0x57, 0x03, 0xec, 0xc3, 0xdf, 0x4b, 0x50, 0x8a, 0x5e, 0xda,
+	0x51, 0x11, 0x72, 0x9d, 0x27, 0xf5, 0x5b, 0xa8, 0x0c, 0xcb, 0x67, 0xed, 0x27, 0xed, 0xce, 0xb7,
+	0xda, 0x75, 0x09, 0x6d, 0x40, 0xbd, 0xdd, 0xe9, 0x6b, 0x07, 0x9d, 0x4e, 0xbf, 0xd7, 0x57, 0xf7,
+	0xbb, 0xdd, 0xe6, 0x51, 0x3d, 0x87, 0xd6, 0xa1, 0xd6, 0xeb, 0x77, 0xd4, 0xa6, 0xd6, 0xef, 0x9c,
+	0x1e, 0xf4, 0xfa, 0x9d, 0x76, 0xb3, 0x9e, 0x47, 0x32, 0x6c, 0xec, 0x9f, 0xa8, 0xcd, 0xfd, 0xa3,
+	0xcf, 0x92, 0xf0, 0x02, 0xe3, 0xb4, 0xda, 0x87, 0x9d, 0xd3, 0xee, 0x7e, 0xbf, 0x75, 0x70, 0xd2,
+	0xd4, 0x3e, 0x6d, 0xaa, 0xbd, 0x56, 0xa7, 0x5d, 0x5f, 0x62, 0xe2, 0xd5, 0xe6, 0x71, 0xab, 0xd3,
+	0xd6, 0x98, 0x96, 0x8f, 0x3b, 0x67, 0xed, 0xa3, 0x7a, 0x11, 0xd5, 0xa1, 0x22, 0xc4, 0x7f, 0xdc,
+	0x6c, 0x1f, 0x36, 0x8f, 0xea, 0xcb, 0x0f, 0xbb, 0x50, 0x4d, 0x06, 0x14, 0xb3, 0xb2, 0x77, 0x76,
+	0x78, 0xd8, 0xec, 0xf5, 0x84, 0xc9, 0xfd, 0xd6, 0x69, 0xb3, 0x73, 0xd6, 0xaf, 0x4b, 0x08, 0xa0,
+	0x78, 0xb8, 0xdf, 0x3e, 0x6c, 0x9e, 0xd4, 0x73, 0x8c, 0xa1, 0x36, 0xbb, 0x27, 0xfb, 0x87, 0xcc,
+	0x40, 0xf6, 0x71, 0xd6, 0x6e, 0xb7, 0xda, 0xc7, 0xf5, 0xc2, 0xde, 0x3f, 0xab, 0x50, 0xea, 0x45,
+	0xab, 0xd5, 0x01, 0x88, 0xbb, 0xa1, 0x68, 0x27, 0xe1, 0xbd, 0xa9, 0x86, 0x6b, 0xe3, 0xf5, 0xb9,
+	0x7c, 0xb1, 0x38, 0xca, 0x2d, 0xf4, 0x0d, 0xc8, 0xf7, 0x89, 0x8b, 0x92, 0x55, 0x40, 0xfc, 0x47,
+	0x85, 0x86, 0x3c, 0xcd, 0x08, 0xc7, 0x3e, 0x90, 0xde, 0x93, 0xd0, 0x09, 0x94, 0xa2, 0x47, 0x6a,
+	0xb4, 0x9d, 0x00, 0xa7, 0x9f, 0xf0, 0x1b, 0x3b, 0xf3, 0xd8, 0x91, 0x35, 0xdf, 0x81, 0x6a, 0xf2,
+	0xd1, 0x1b, 0x29, 0x89, 0x31, 0x33, 0x9f, 0xd7, 0x1b, 0xf7, 0x16, 0x62, 0x22, 0xe1, 0x1f, 0xc3,
+	0x72, 0xf0, 0x30, 0x8d, 0x92, 0xb1, 0x9a, 0x7c, 0xf4, 0x6e, 0xdc, 0x9d, 0xcd, 0x8c, 0xe4, 0xb4,
+	0x60, 0x25, 0x7c, 0x25, 0x46, 0x77, 0xd3, 0x1e, 0x9e, 0x7c, 0x9f, 0x6d, 0x6c, 0xcf, 0xe1, 0x4e,
+	0x8a, 0xea, 0x8e, 0x66, 0x8a, 0xea, 0x8e, 0x16, 0x89, 0x4a, 0x3f, 0xce, 0x2a, 0xb7, 0xd0, 0x19,
+	0x54, 0x26, 0xdf, 0x38, 0xd1, 0x6e, 0x5a, 0x77, 0xfa, 0x0d, 0xb6, 0xf1, 0xc6, 0x02, 0xc4, 0xe4,
+	0x8a, 0x24, 0xab, 0xbf, 0xd4, 0x8a, 0xcc, 0xac, 0x4b, 0x1b, 0xf7, 0x16, 0x62, 0x22, 0xe1, 0xe7,
+	0x50, 0x4b, 0x75, 0x0c, 0xd1, 0xbd, 0x54, 0x16, 0x99, 0xd5, 0x1b, 0x6f, 0xdc, 0x5f, 0x0c, 0x4a,
+	0x07, 0x68, 0xf4, 0xc2, 0x88, 0xa6, 0x16, 0x24, 0x51, 0x9d, 0x35, 0x76, 0xe6, 0xb1, 0x23, 0x8b,
+	0xbb, 0xb0, 0x7a, 0x8c, 0x69, 0xd7, 0xc7, 0xd7, 0xaf, 0x4a, 0x62, 0x1f, 0x56, 0x23, 0x32, 0x7b,
+	0x01, 0x45, 0x6f, 0xcc, 0x1e, 0x32, 0xf1, 0x3a, 0x7a, 0x03, 0xa9, 0x2a, 0x94, 0x27, 0x9e, 0x15,
+	0xd1, 0xeb, 0xa9, 0x34, 0x9b, 0x7e, 0x07, 0x6d, 0xec, 0xce, 0x07, 0x4c, 0x06, 0x6b, 0xd8, 0xf1,
+	0x4b, 0x05, 0x6b, 0xaa, 0xf1, 0xd8, 0xd8, 0x9e, 0xc3, 0x8d, 0x44, 0xe9, 0xfc, 0x71, 0x3c, 0xf1,
+	0x24, 0x86, 0xee, 0xa7, 0x27, 0x35, 0xeb, 0xad, 0xae, 0xf1, 0xe6, 0x4b, 0x50, 0x93, 0x2a, 0xba,
+	0xa3, 0x85, 0x2a, 0xba, 0xa3, 0x9b, 0xa8, 0x98, 0xf7, 0x74, 0xa7, 0xdc, 0x42, 0xdf, 0x86, 0xd5,
+	0x44, 0xb5, 0x9c, 0x5a, 0xba, 0x59, 0x17, 0x80, 0x86, 0xb2, 0x08, 0x32, 0xb9, 0xeb, 0x92, 0xc5,
+	0x6e, 0x6a, 0xd7, 0xcd, 0x2c, 0xab, 0x1b, 0xf7, 0x16, 0x62, 0x22, 0xe1, 0x26, 0xac, 0x4d, 0x15,
+	0x9b, 0x28, 0x39, 0xe9, 0x79, 0xb5, 0x6f, 0xe3, 0xad, 0x97, 0xc1, 0x26, 0x23, 0x70, 0xa2, 0xe4,
+	0x43, 0x53, 0x47, 0x51, 0xaa, 0xe8, 0x6c, 0xec, 0xce, 0x07, 0x44, 0x32, 0xbf, 0x07, 0xb5, 0x54,
+	0x35, 0x92, 0xca, 0x17, 0xb3, 0xab, 0xb3, 0xc6, 0xfd, 0xc5, 0xa0, 0x50, 0xfe, 0x41, 0xfd, 0x2f,
+	0x2f, 0x76, 0xa4, 0xbf, 0xbe, 0xd8, 0x91, 0xfe, 0xfe, 0x62, 0x47, 0xfa, 0xe5, 0x3f, 0x76, 0x6e,
+	0x9d, 0x17, 0xf9, 0xdf, 0x12, 0xdf, 0xff, 0xcf, 0x00, 0xbc, 0x19, 0x22, 0x19, 0xeb, 0x28, 0x00,
+	0x00,
 }
@@ -48,18 +48,38 @@ type TinyKvClient interface {
 	KvGet(ctx context.Context, in *kvrpcpb.GetRequest, opts ...grpc.CallOption) (*kvrpcpb.GetResponse, error)
 	KvScan(ctx context.Context, in *kvrpcpb.ScanRequest, opts ...grpc.CallOption) (*kvrpcpb.ScanResponse, error)
 	KvPrewrite(ctx context.Context, in *kvrpcpb.PrewriteRequest, opts ...grpc.CallOption) (*kvrpcpb.PrewriteResponse, error)
+	KvStage(ctx context.Context, in *kvrpcpb.StageRequest, opts ...grpc.CallOption) (*kvrpcpb.StageResponse, error)
 	KvCommit(ctx context.Context, in *kvrpcpb.CommitRequest, opts ...grpc.CallOption) (*kvrpcpb.CommitResponse, error)
 	KvCheckTxnStatus(ctx context.Context, in *kvrpcpb.CheckTxnStatusRequest, opts ...grpc.CallOption) (*kvrpcpb.CheckTxnStatusResponse, error)
 	KvBatchRollback(ctx context.Context, in *kvrpcpb.BatchRollbackRequest, opts ...grpc.CallOption) (*kvrpcpb.BatchRollbackResponse, error)
 	KvResolveLock(ctx context.Context, in *kvrpcpb.ResolveLockRequest, opts ...grpc.CallOption) (*kvrpcpb.ResolveLockResponse, error)
+	KvResolveLockStatus(ctx context.Context, in *kvrpcpb.ResolveLockStatusRequest, opts ...grpc.CallOption) (*kvrpcpb.ResolveLockStatusResponse, error)
+	KvRangeLock(ctx context.Context, in *kvrpcpb.RangeLockRequest, opts ...grpc.CallOption) (*kvrpcpb.RangeLockResponse, error)
+	KvRangeUnlock(ctx context.Context, in *kvrpcpb.RangeUnlockRequest, opts ...grpc.CallOption) (*kvrpcpb.RangeUnlockResponse, error)
+	KvScanLock(ctx context.Context, in *kvrpcpb.ScanLockRequest, opts ...grpc.CallOption) (*kvrpcpb.ScanLockResponse, error)
+	ReadIndex(ctx context.Context, in *kvrpcpb.ReadIndexRequest, opts ...grpc.CallOption) (*kvrpcpb.ReadIndexResponse, error)
+	WatchRegions(ctx context.Context, in *kvrpcpb.WatchRegionsRequest, opts ...grpc.CallOption) (TinyKv_WatchRegionsClient, error)
+	RegionDump(ctx context.Context, in *kvrpcpb.RegionDumpRequest, opts ...grpc.CallOption) (TinyKv_RegionDumpClient, error)
 	// RawKV commands.
 	RawGet(ctx context.Context, in *kvrpcpb.RawGetRequest, opts ...grpc.CallOption) (*kvrpcpb.RawGetResponse, error)
 	RawPut(ctx context.Context, in *kvrpcpb.RawPutRequest, opts ...grpc.CallOption) (*kvrpcpb.RawPutResponse, error)
 	RawDelete(ctx context.Context, in *kvrpcpb.RawDeleteRequest, opts ...grpc.CallOption) (*kvrpcpb.RawDeleteResponse, error)
+	RawUndelete(ctx context.Context, in *kvrpcpb.RawUndeleteRequest, opts ...grpc.CallOption) (*kvrpcpb.RawUndeleteResponse, error)
 	RawScan(ctx context.Context, in *kvrpcpb.RawScanRequest, opts ...grpc.CallOption) (*kvrpcpb.RawScanResponse, error)
 	// Raft commands (tinykv <-> tinykv).
 	Raft(ctx context.Context, opts ...grpc.CallOption) (TinyKv_RaftClient, error)
 	Snapshot(ctx context.Context, opts ...grpc.CallOption) (TinyKv_SnapshotClient, error)
+	CreatePeer(ctx context.Context, in *kvrpcpb.CreatePeerRequest, opts ...grpc.CallOption) (*kvrpcpb.CreatePeerResponse, error)
+	// Admin commands.
+	AdminOp(ctx context.Context, in *kvrpcpb.AdminOpRequest, opts ...grpc.CallOption) (*kvrpcpb.AdminOpResponse, error)
+	AdminOpStatus(ctx context.Context, in *kvrpcpb.AdminOpStatusRequest, opts ...grpc.CallOption) (*kvrpcpb.AdminOpStatusResponse, error)
+	// Debug commands.
+	KvAuditScan(ctx context.Context, in *kvrpcpb.AuditScanRequest, opts ...grpc.CallOption) (*kvrpcpb.AuditScanResponse, error)
+	FailPoint(ctx context.Context, in *kvrpcpb.FailPointRequest, opts ...grpc.CallOption) (*kvrpcpb.FailPointResponse, error)
+	KvKeyViolations(ctx context.Context, in *kvrpcpb.KeyViolationsRequest, opts ...grpc.CallOption) (*kvrpcpb.KeyViolationsResponse, error)
+	RaftReadyStats(ctx context.Context, in *kvrpcpb.RaftReadyStatsRequest, opts ...grpc.CallOption) (*kvrpcpb.RaftReadyStatsResponse, error)
+	RaftStatus(ctx context.Context, in *kvrpcpb.RaftStatusRequest, opts ...grpc.CallOption) (*kvrpcpb.RaftStatusResponse, error)
+	ApplyWatermark(ctx context.Context, in *kvrpcpb.ApplyWatermarkRequest, opts ...grpc.CallOption) (*kvrpcpb.ApplyWatermarkResponse, error)
 	// Coprocessor
 	Coprocessor(ctx context.Context, in *coprocessor.Request, opts ...grpc.CallOption) (*coprocessor.Response, error)
 }
@@ -99,6 +119,15 @@ func (c *tinyKvClient) KvPrewrite(ctx context.Context, in *kvrpcpb.PrewriteReque
 	return out, nil
 }
 
+func (c *tinyKvClient) KvStage(ctx context.Context, in *kvrpcpb.StageRequest, opts ...grpc.CallOption) (*kvrpcpb.StageResponse, error) {
+	out := new(kvrpcpb.StageResponse)
+	err := c.cc.Invoke(ctx, "/tinykvpb.TinyKv/KvStage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *tinyKvClient) KvCommit(ctx context.Context, in *kvrpcpb.CommitRequest, opts ...grpc.CallOption) (*kvrpcpb.CommitResponse, error) {
 	out := new(kvrpcpb.CommitResponse)
 	err := c.cc.Invoke(ctx, "/tinykvpb.TinyKv/KvCommit", in, out, opts...)
@@ -135,6 +164,115 @@ func (c *tinyKvClient) KvResolveLock(ctx context.Context, in *kvrpcpb.ResolveLoc
 	return out, nil
 }
 
+func (c *tinyKvClient) KvResolveLockStatus(ctx context.Context, in *kvrpcpb.ResolveLockStatusRequest, opts ...grpc.CallOption) (*kvrpcpb.ResolveLockStatusResponse, error) {
+	out := new(kvrpcpb.ResolveLockStatusResponse)
+	err := c.cc.Invoke(ctx, "/tinykvpb.TinyKv/KvResolveLockStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tinyKvClient) KvRangeLock(ctx context.Context, in *kvrpcpb.RangeLockRequest, opts ...grpc.CallOption) (*kvrpcpb.RangeLockResponse, error) {
+	out := new(kvrpcpb.RangeLockResponse)
+	err := c.cc.Invoke(ctx, "/tinykvpb.TinyKv/KvRangeLock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tinyKvClient) KvRangeUnlock(ctx context.Context, in *kvrpcpb.RangeUnlockRequest, opts ...grpc.CallOption) (*kvrpcpb.RangeUnlockResponse, error) {
+	out := new(kvrpcpb.RangeUnlockResponse)
+	err := c.cc.Invoke(ctx, "/tinykvpb.TinyKv/KvRangeUnlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tinyKvClient) KvScanLock(ctx context.Context, in *kvrpcpb.ScanLockRequest, opts ...grpc.CallOption) (*kvrpcpb.ScanLockResponse, error) {
+	out := new(kvrpcpb.ScanLockResponse)
+	err := c.cc.Invoke(ctx, "/tinykvpb.TinyKv/KvScanLock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tinyKvClient) ReadIndex(ctx context.Context, in *kvrpcpb.ReadIndexRequest, opts ...grpc.CallOption) (*kvrpcpb.ReadIndexResponse, error) {
+	out := new(kvrpcpb.ReadIndexResponse)
+	err := c.cc.Invoke(ctx, "/tinykvpb.TinyKv/ReadIndex", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tinyKvClient) WatchRegions(ctx context.Context, in *kvrpcpb.WatchRegionsRequest, opts ...grpc.CallOption) (TinyKv_WatchRegionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_TinyKv_serviceDesc.Streams[0], "/tinykvpb.TinyKv/WatchRegions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &tinyKvWatchRegionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TinyKv_WatchRegionsClient interface {
+	Recv() (*kvrpcpb.WatchRegionsResponse, error)
+	grpc.ClientStream
+}
+
+type tinyKvWatchRegionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *tinyKvWatchRegionsClient) Recv() (*kvrpcpb.WatchRegionsResponse, error) {
+	m := new(kvrpcpb.WatchRegionsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *tinyKvClient) RegionDump(ctx context.Context, in *kvrpcpb.RegionDumpRequest, opts ...grpc.CallOption) (TinyKv_RegionDumpClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_TinyKv_serviceDesc.Streams[1], "/tinykvpb.TinyKv/RegionDump", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &tinyKvRegionDumpClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TinyKv_RegionDumpClient interface {
+	Recv() (*kvrpcpb.RegionDumpResponse, error)
+	grpc.ClientStream
+}
+
+type tinyKvRegionDumpClient struct {
+	grpc.ClientStream
+}
+
+func (x *tinyKvRegionDumpClient) Recv() (*kvrpcpb.RegionDumpResponse, error) {
+	m := new(kvrpcpb.RegionDumpResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *tinyKvClient) RawGet(ctx context.Context, in *kvrpcpb.RawGetRequest, opts ...grpc.CallOption) (*kvrpcpb.RawGetResponse, error) {
 	out := new(kvrpcpb.RawGetResponse)
 	err := c.cc.Invoke(ctx, "/tinykvpb.TinyKv/RawGet", in, out, opts...)
@@ -162,6 +300,15 @@ func (c *tinyKvClient) RawDelete(ctx context.Context, in *kvrpcpb.RawDeleteReque
 	return out, nil
 }
 
+func (c *tinyKvClient) RawUndelete(ctx context.Context, in *kvrpcpb.RawUndeleteRequest, opts ...grpc.CallOption) (*kvrpcpb.RawUndeleteResponse, error) {
+	out := new(kvrpcpb.RawUndeleteResponse)
+	err := c.cc.Invoke(ctx, "/tinykvpb.TinyKv/RawUndelete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *tinyKvClient) RawScan(ctx context.Context, in *kvrpcpb.RawScanRequest, opts ...grpc.CallOption) (*kvrpcpb.RawScanResponse, error) {
 	out := new(kvrpcpb.RawScanResponse)
 	err := c.cc.Invoke(ctx, "/tinykvpb.TinyKv/RawScan", in, out, opts...)
@@ -172,7 +319,7 @@ func (c *tinyKvClient) RawScan(ctx context.Context, in *kvrpcpb.RawScanRequest,
 }
 
 func (c *tinyKvClient) Raft(ctx context.Context, opts ...grpc.CallOption) (TinyKv_RaftClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_TinyKv_serviceDesc.Streams[0], "/tinykvpb.TinyKv/Raft", opts...)
+	stream, err := c.cc.NewStream(ctx, &_TinyKv_serviceDesc.Streams[2], "/tinykvpb.TinyKv/Raft", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -206,7 +353,7 @@ func (x *tinyKvRaftClient) CloseAndRecv() (*raft_serverpb.Done, error) {
 }
 
 func (c *tinyKvClient) Snapshot(ctx context.Context, opts ...grpc.CallOption) (TinyKv_SnapshotClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_TinyKv_serviceDesc.Streams[1], "/tinykvpb.TinyKv/Snapshot", opts...)
+	stream, err := c.cc.NewStream(ctx, &_TinyKv_serviceDesc.Streams[3], "/tinykvpb.TinyKv/Snapshot", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -239,6 +386,87 @@ func (x *tinyKvSnapshotClient) CloseAndRecv() (*raft_serverpb.Done, error) {
 	return m, nil
 }
 
+func (c *tinyKvClient) CreatePeer(ctx context.Context, in *kvrpcpb.CreatePeerRequest, opts ...grpc.CallOption) (*kvrpcpb.CreatePeerResponse, error) {
+	out := new(kvrpcpb.CreatePeerResponse)
+	err := c.cc.Invoke(ctx, "/tinykvpb.TinyKv/CreatePeer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tinyKvClient) AdminOp(ctx context.Context, in *kvrpcpb.AdminOpRequest, opts ...grpc.CallOption) (*kvrpcpb.AdminOpResponse, error) {
+	out := new(kvrpcpb.AdminOpResponse)
+	err := c.cc.Invoke(ctx, "/tinykvpb.TinyKv/AdminOp", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tinyKvClient) AdminOpStatus(ctx context.Context, in *kvrpcpb.AdminOpStatusRequest, opts ...grpc.CallOption) (*kvrpcpb.AdminOpStatusResponse, error) {
+	out := new(kvrpcpb.AdminOpStatusResponse)
+	err := c.cc.Invoke(ctx, "/tinykvpb.TinyKv/AdminOpStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tinyKvClient) KvAuditScan(ctx context.Context, in *kvrpcpb.AuditScanRequest, opts ...grpc.CallOption) (*kvrpcpb.AuditScanResponse, error) {
+	out := new(kvrpcpb.AuditScanResponse)
+	err := c.cc.Invoke(ctx, "/tinykvpb.TinyKv/KvAuditScan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tinyKvClient) FailPoint(ctx context.Context, in *kvrpcpb.FailPointRequest, opts ...grpc.CallOption) (*kvrpcpb.FailPointResponse, error) {
+	out := new(kvrpcpb.FailPointResponse)
+	err := c.cc.Invoke(ctx, "/tinykvpb.TinyKv/FailPoint", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tinyKvClient) KvKeyViolations(ctx context.Context, in *kvrpcpb.KeyViolationsRequest, opts ...grpc.CallOption) (*kvrpcpb.KeyViolationsResponse, error) {
+	out := new(kvrpcpb.KeyViolationsResponse)
+	err := c.cc.Invoke(ctx, "/tinykvpb.TinyKv/KvKeyViolations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tinyKvClient) RaftReadyStats(ctx context.Context, in *kvrpcpb.RaftReadyStatsRequest, opts ...grpc.CallOption) (*kvrpcpb.RaftReadyStatsResponse, error) {
+	out := new(kvrpcpb.RaftReadyStatsResponse)
+	err := c.cc.Invoke(ctx, "/tinykvpb.TinyKv/RaftReadyStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tinyKvClient) RaftStatus(ctx context.Context, in *kvrpcpb.RaftStatusRequest, opts ...grpc.CallOption) (*kvrpcpb.RaftStatusResponse, error) {
+	out := new(kvrpcpb.RaftStatusResponse)
+	err := c.cc.Invoke(ctx, "/tinykvpb.TinyKv/RaftStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tinyKvClient) ApplyWatermark(ctx context.Context, in *kvrpcpb.ApplyWatermarkRequest, opts ...grpc.CallOption) (*kvrpcpb.ApplyWatermarkResponse, error) {
+	out := new(kvrpcpb.ApplyWatermarkResponse)
+	err := c.cc.Invoke(ctx, "/tinykvpb.TinyKv/ApplyWatermark", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *tinyKvClient) Coprocessor(ctx context.Context, in *coprocessor.Request, opts ...grpc.CallOption) (*coprocessor.Response, error) {
 	out := new(coprocessor.Response)
 	err := c.cc.Invoke(ctx, "/tinykvpb.TinyKv/Coprocessor", in, out, opts...)
@@ -255,18 +483,38 @@ type TinyKvServer interface {
 	KvGet(context.Context, *kvrpcpb.GetRequest) (*kvrpcpb.GetResponse, error)
 	KvScan(context.Context, *kvrpcpb.ScanRequest) (*kvrpcpb.ScanResponse, error)
 	KvPrewrite(context.Context, *kvrpcpb.PrewriteRequest) (*kvrpcpb.PrewriteResponse, error)
+	KvStage(context.Context, *kvrpcpb.StageRequest) (*kvrpcpb.StageResponse, error)
 	KvCommit(context.Context, *kvrpcpb.CommitRequest) (*kvrpcpb.CommitResponse, error)
 	KvCheckTxnStatus(context.Context, *kvrpcpb.CheckTxnStatusRequest) (*kvrpcpb.CheckTxnStatusResponse, error)
 	KvBatchRollback(context.Context, *kvrpcpb.BatchRollbackRequest) (*kvrpcpb.BatchRollbackResponse, error)
 	KvResolveLock(context.Context, *kvrpcpb.ResolveLockRequest) (*kvrpcpb.ResolveLockResponse, error)
+	KvResolveLockStatus(context.Context, *kvrpcpb.ResolveLockStatusRequest) (*kvrpcpb.ResolveLockStatusResponse, error)
+	KvRangeLock(context.Context, *kvrpcpb.RangeLockRequest) (*kvrpcpb.RangeLockResponse, error)
+	KvRangeUnlock(context.Context, *kvrpcpb.RangeUnlockRequest) (*kvrpcpb.RangeUnlockResponse, error)
+	KvScanLock(context.Context, *kvrpcpb.ScanLockRequest) (*kvrpcpb.ScanLockResponse, error)
+	ReadIndex(context.Context, *kvrpcpb.ReadIndexRequest) (*kvrpcpb.ReadIndexResponse, error)
+	WatchRegions(*kvrpcpb.WatchRegionsRequest, TinyKv_WatchRegionsServer) error
+	RegionDump(*kvrpcpb.RegionDumpRequest, TinyKv_RegionDumpServer) error
 	// RawKV commands.
 	RawGet(context.Context, *kvrpcpb.RawGetRequest) (*kvrpcpb.RawGetResponse, error)
 	RawPut(context.Context, *kvrpcpb.RawPutRequest) (*kvrpcpb.RawPutResponse, error)
 	RawDelete(context.Context, *kvrpcpb.RawDeleteRequest) (*kvrpcpb.RawDeleteResponse, error)
+	RawUndelete(context.Context, *kvrpcpb.RawUndeleteRequest) (*kvrpcpb.RawUndeleteResponse, error)
 	RawScan(context.Context, *kvrpcpb.RawScanRequest) (*kvrpcpb.RawScanResponse, error)
 	// Raft commands (tinykv <-> tinykv).
 	Raft(TinyKv_RaftServer) error
 	Snapshot(TinyKv_SnapshotServer) error
+	CreatePeer(context.Context, *kvrpcpb.CreatePeerRequest) (*kvrpcpb.CreatePeerResponse, error)
+	// Admin commands.
+	AdminOp(context.Context, *kvrpcpb.AdminOpRequest) (*kvrpcpb.AdminOpResponse, error)
+	AdminOpStatus(context.Context, *kvrpcpb.AdminOpStatusRequest) (*kvrpcpb.AdminOpStatusResponse, error)
+	// Debug commands.
+	KvAuditScan(context.Context, *kvrpcpb.AuditScanRequest) (*kvrpcpb.AuditScanResponse, error)
+	FailPoint(context.Context, *kvrpcpb.FailPointRequest) (*kvrpcpb.FailPointResponse, error)
+	KvKeyViolations(context.Context, *kvrpcpb.KeyViolationsRequest) (*kvrpcpb.KeyViolationsResponse, error)
+	RaftReadyStats(context.Context, *kvrpcpb.RaftReadyStatsRequest) (*kvrpcpb.RaftReadyStatsResponse, error)
+	RaftStatus(context.Context, *kvrpcpb.RaftStatusRequest) (*kvrpcpb.RaftStatusResponse, error)
+	ApplyWatermark(context.Context, *kvrpcpb.ApplyWatermarkRequest) (*kvrpcpb.ApplyWatermarkResponse, error)
 	// Coprocessor
 	Coprocessor(context.Context, *coprocessor.Request) (*coprocessor.Response, error)
 }
@@ -329,6 +577,24 @@ func _TinyKv_KvPrewrite_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TinyKv_KvStage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(kvrpcpb.StageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyKvServer).KvStage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tinykvpb.TinyKv/KvStage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyKvServer).KvStage(ctx, req.(*kvrpcpb.StageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TinyKv_KvCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(kvrpcpb.CommitRequest)
 	if err := dec(in); err != nil {
@@ -401,6 +667,138 @@ func _TinyKv_KvResolveLock_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TinyKv_KvResolveLockStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(kvrpcpb.ResolveLockStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyKvServer).KvResolveLockStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tinykvpb.TinyKv/KvResolveLockStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyKvServer).KvResolveLockStatus(ctx, req.(*kvrpcpb.ResolveLockStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TinyKv_KvRangeLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(kvrpcpb.RangeLockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyKvServer).KvRangeLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tinykvpb.TinyKv/KvRangeLock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyKvServer).KvRangeLock(ctx, req.(*kvrpcpb.RangeLockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TinyKv_KvRangeUnlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(kvrpcpb.RangeUnlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyKvServer).KvRangeUnlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tinykvpb.TinyKv/KvRangeUnlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyKvServer).KvRangeUnlock(ctx, req.(*kvrpcpb.RangeUnlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TinyKv_KvScanLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(kvrpcpb.ScanLockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyKvServer).KvScanLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tinykvpb.TinyKv/KvScanLock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyKvServer).KvScanLock(ctx, req.(*kvrpcpb.ScanLockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TinyKv_ReadIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(kvrpcpb.ReadIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyKvServer).ReadIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tinykvpb.TinyKv/ReadIndex",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyKvServer).ReadIndex(ctx, req.(*kvrpcpb.ReadIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TinyKv_WatchRegions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(kvrpcpb.WatchRegionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TinyKvServer).WatchRegions(m, &tinyKvWatchRegionsServer{stream})
+}
+
+type TinyKv_WatchRegionsServer interface {
+	Send(*kvrpcpb.WatchRegionsResponse) error
+	grpc.ServerStream
+}
+
+type tinyKvWatchRegionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *tinyKvWatchRegionsServer) Send(m *kvrpcpb.WatchRegionsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TinyKv_RegionDump_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(kvrpcpb.RegionDumpRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TinyKvServer).RegionDump(m, &tinyKvRegionDumpServer{stream})
+}
+
+type TinyKv_RegionDumpServer interface {
+	Send(*kvrpcpb.RegionDumpResponse) error
+	grpc.ServerStream
+}
+
+type tinyKvRegionDumpServer struct {
+	grpc.ServerStream
+}
+
+func (x *tinyKvRegionDumpServer) Send(m *kvrpcpb.RegionDumpResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _TinyKv_RawGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(kvrpcpb.RawGetRequest)
 	if err := dec(in); err != nil {
@@ -455,6 +853,24 @@ func _TinyKv_RawDelete_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TinyKv_RawUndelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(kvrpcpb.RawUndeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyKvServer).RawUndelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tinykvpb.TinyKv/RawUndelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyKvServer).RawUndelete(ctx, req.(*kvrpcpb.RawUndeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TinyKv_RawScan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(kvrpcpb.RawScanRequest)
 	if err := dec(in); err != nil {
@@ -525,6 +941,168 @@ func (x *tinyKvSnapshotServer) Recv() (*raft_serverpb.SnapshotChunk, error) {
 	return m, nil
 }
 
+func _TinyKv_CreatePeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(kvrpcpb.CreatePeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyKvServer).CreatePeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tinykvpb.TinyKv/CreatePeer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyKvServer).CreatePeer(ctx, req.(*kvrpcpb.CreatePeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TinyKv_AdminOp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(kvrpcpb.AdminOpRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyKvServer).AdminOp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tinykvpb.TinyKv/AdminOp",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyKvServer).AdminOp(ctx, req.(*kvrpcpb.AdminOpRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TinyKv_AdminOpStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(kvrpcpb.AdminOpStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyKvServer).AdminOpStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tinykvpb.TinyKv/AdminOpStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyKvServer).AdminOpStatus(ctx, req.(*kvrpcpb.AdminOpStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TinyKv_KvAuditScan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(kvrpcpb.AuditScanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyKvServer).KvAuditScan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tinykvpb.TinyKv/KvAuditScan",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyKvServer).KvAuditScan(ctx, req.(*kvrpcpb.AuditScanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TinyKv_FailPoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(kvrpcpb.FailPointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyKvServer).FailPoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tinykvpb.TinyKv/FailPoint",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyKvServer).FailPoint(ctx, req.(*kvrpcpb.FailPointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TinyKv_KvKeyViolations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(kvrpcpb.KeyViolationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyKvServer).KvKeyViolations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tinykvpb.TinyKv/KvKeyViolations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyKvServer).KvKeyViolations(ctx, req.(*kvrpcpb.KeyViolationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TinyKv_RaftReadyStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(kvrpcpb.RaftReadyStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyKvServer).RaftReadyStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tinykvpb.TinyKv/RaftReadyStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyKvServer).RaftReadyStats(ctx, req.(*kvrpcpb.RaftReadyStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TinyKv_RaftStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(kvrpcpb.RaftStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyKvServer).RaftStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tinykvpb.TinyKv/RaftStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyKvServer).RaftStatus(ctx, req.(*kvrpcpb.RaftStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TinyKv_ApplyWatermark_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(kvrpcpb.ApplyWatermarkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyKvServer).ApplyWatermark(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tinykvpb.TinyKv/ApplyWatermark",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyKvServer).ApplyWatermark(ctx, req.(*kvrpcpb.ApplyWatermarkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TinyKv_Coprocessor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(coprocessor.Request)
 	if err := dec(in); err != nil {
@@ -559,6 +1137,10 @@ var _TinyKv_serviceDesc = grpc.ServiceDesc{
 			MethodName: "KvPrewrite",
 			Handler:    _TinyKv_KvPrewrite_Handler,
 		},
+		{
+			MethodName: "KvStage",
+			Handler:    _TinyKv_KvStage_Handler,
+		},
 		{
 			MethodName: "KvCommit",
 			Handler:    _TinyKv_KvCommit_Handler,
@@ -575,6 +1157,26 @@ var _TinyKv_serviceDesc = grpc.ServiceDesc{
 			MethodName: "KvResolveLock",
 			Handler:    _TinyKv_KvResolveLock_Handler,
 		},
+		{
+			MethodName: "KvResolveLockStatus",
+			Handler:    _TinyKv_KvResolveLockStatus_Handler,
+		},
+		{
+			MethodName: "KvRangeLock",
+			Handler:    _TinyKv_KvRangeLock_Handler,
+		},
+		{
+			MethodName: "KvRangeUnlock",
+			Handler:    _TinyKv_KvRangeUnlock_Handler,
+		},
+		{
+			MethodName: "KvScanLock",
+			Handler:    _TinyKv_KvScanLock_Handler,
+		},
+		{
+			MethodName: "ReadIndex",
+			Handler:    _TinyKv_ReadIndex_Handler,
+		},
 		{
 			MethodName: "RawGet",
 			Handler:    _TinyKv_RawGet_Handler,
@@ -587,16 +1189,66 @@ var _TinyKv_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RawDelete",
 			Handler:    _TinyKv_RawDelete_Handler,
 		},
+		{
+			MethodName: "RawUndelete",
+			Handler:    _TinyKv_RawUndelete_Handler,
+		},
 		{
 			MethodName: "RawScan",
 			Handler:    _TinyKv_RawScan_Handler,
 		},
+		{
+			MethodName: "CreatePeer",
+			Handler:    _TinyKv_CreatePeer_Handler,
+		},
+		{
+			MethodName: "AdminOp",
+			Handler:    _TinyKv_AdminOp_Handler,
+		},
+		{
+			MethodName: "AdminOpStatus",
+			Handler:    _TinyKv_AdminOpStatus_Handler,
+		},
+		{
+			MethodName: "KvAuditScan",
+			Handler:    _TinyKv_KvAuditScan_Handler,
+		},
+		{
+			MethodName: "FailPoint",
+			Handler:    _TinyKv_FailPoint_Handler,
+		},
+		{
+			MethodName: "KvKeyViolations",
+			Handler:    _TinyKv_KvKeyViolations_Handler,
+		},
+		{
+			MethodName: "RaftReadyStats",
+			Handler:    _TinyKv_RaftReadyStats_Handler,
+		},
+		{
+			MethodName: "RaftStatus",
+			Handler:    _TinyKv_RaftStatus_Handler,
+		},
+		{
+			MethodName: "ApplyWatermark",
+			Handler:    _TinyKv_ApplyWatermark_Handler,
+		},
 		{
 			MethodName: "Coprocessor",
 			Handler:    _TinyKv_Coprocessor_Handler,
 		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchRegions",
+			Handler:       _TinyKv_WatchRegions_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "RegionDump",
+			Handler:       _TinyKv_RegionDump_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "Raft",
 			Handler:       _TinyKv_Raft_Handler,
@@ -611,37 +1263,57 @@ var _TinyKv_serviceDesc = grpc.ServiceDesc{
 	Metadata: "tinykvpb.proto",
 }
 
-func init() { proto.RegisterFile("tinykvpb.proto", fileDescriptor_tinykvpb_71a6ae942ac295c5) }
-
-var fileDescriptor_tinykvpb_71a6ae942ac295c5 = []byte{
-	// 452 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x93, 0xdb, 0x6e, 0xd3, 0x30,
-	0x18, 0xc7, 0x5b, 0x09, 0xba, 0x62, 0x34, 0x18, 0x6e, 0x81, 0x2d, 0x8c, 0x20, 0xed, 0x8a, 0xab,
-	0x22, 0x01, 0x12, 0x17, 0x1c, 0x24, 0x96, 0x4a, 0xbb, 0xf0, 0x90, 0x2a, 0x77, 0x5c, 0x23, 0xd7,
-	0xfa, 0xd6, 0x56, 0xe9, 0xec, 0x60, 0x3b, 0x2e, 0x7d, 0x13, 0x9e, 0x83, 0xa7, 0xe0, 0x92, 0x47,
-	0x40, 0xe5, 0x45, 0x50, 0x92, 0xd9, 0x39, 0x34, 0xbd, 0xb3, 0x7f, 0xff, 0x83, 0x5b, 0xc7, 0x1f,
-	0x7a, 0x60, 0x96, 0x62, 0x13, 0xdb, 0x64, 0x36, 0x4a, 0x94, 0x34, 0x12, 0xf7, 0xdd, 0x3e, 0x38,
-	0x8c, 0xad, 0x4a, 0xb8, 0x13, 0x82, 0x81, 0x62, 0xd7, 0xe6, 0x9b, 0x06, 0x65, 0x41, 0x79, 0xf8,
-	0x88, 0xcb, 0x44, 0x49, 0x0e, 0x5a, 0x4b, 0x75, 0x8b, 0x86, 0x73, 0x39, 0x97, 0xf9, 0xf2, 0x55,
-	0xb6, 0x2a, 0xe8, 0xeb, 0x5f, 0x07, 0xa8, 0x77, 0xb5, 0x14, 0x1b, 0x62, 0xf1, 0x5b, 0x74, 0x97,
-	0xd8, 0x0b, 0x30, 0x78, 0x30, 0x72, 0x27, 0x5c, 0x80, 0xa1, 0xf0, 0x3d, 0x05, 0x6d, 0x82, 0x61,
-	0x1d, 0xea, 0x44, 0x0a, 0x0d, 0x67, 0x1d, 0xfc, 0x0e, 0xf5, 0x88, 0x9d, 0x72, 0x26, 0x70, 0xe9,
-	0xc8, 0xb6, 0x2e, 0xf7, 0xb8, 0x41, 0x7d, 0x30, 0x42, 0x88, 0xd8, 0x89, 0x82, 0xb5, 0x5a, 0x1a,
-	0xc0, 0xc7, 0xde, 0xe6, 0x90, 0x2b, 0x38, 0x69, 0x51, 0x7c, 0xc9, 0x47, 0xd4, 0x27, 0x36, 0x92,
-	0x37, 0x37, 0x4b, 0x83, 0x9f, 0x78, 0x63, 0x01, 0x5c, 0xc1, 0xd3, 0x1d, 0xee, 0xe3, 0x5f, 0xd1,
-	0x11, 0xb1, 0xd1, 0x02, 0x78, 0x7c, 0xf5, 0x43, 0x4c, 0x0d, 0x33, 0xa9, 0xc6, 0x61, 0x69, 0xaf,
-	0x09, 0xae, 0xee, 0xc5, 0x5e, 0xdd, 0xd7, 0x52, 0xf4, 0x90, 0xd8, 0x73, 0x66, 0xf8, 0x82, 0xca,
-	0xd5, 0x6a, 0xc6, 0x78, 0x8c, 0x9f, 0xfb, 0x54, 0x8d, 0xbb, 0xd2, 0x70, 0x9f, 0xec, 0x3b, 0x2f,
-	0xd1, 0x21, 0xb1, 0x14, 0xb4, 0x5c, 0x59, 0xb8, 0x94, 0x3c, 0xc6, 0xcf, 0x7c, 0xa4, 0x42, 0x5d,
-	0xdf, 0x69, 0xbb, 0xe8, 0xdb, 0xde, 0xa3, 0x1e, 0x65, 0xeb, 0xec, 0x63, 0x97, 0xb7, 0x56, 0x80,
-	0xdd, 0x5b, 0x73, 0xbc, 0x11, 0x9e, 0xa4, 0x8d, 0xf0, 0x24, 0x6d, 0x0f, 0xe7, 0xdc, 0x87, 0xc7,
-	0xe8, 0x1e, 0x65, 0xeb, 0x31, 0xac, 0xc0, 0x00, 0x3e, 0xa9, 0xfa, 0x0a, 0xe6, 0x2a, 0x82, 0x36,
-	0xc9, 0xb7, 0x7c, 0x42, 0x07, 0x94, 0xad, 0xf3, 0x67, 0x57, 0x3b, 0xab, 0xfa, 0xf2, 0x8e, 0x77,
-	0x85, 0xca, 0x5f, 0xb8, 0x43, 0xd9, 0xb5, 0xc1, 0xc1, 0xa8, 0x3e, 0x3d, 0x19, 0xfc, 0x02, 0x5a,
-	0xb3, 0x39, 0x04, 0x83, 0x86, 0x36, 0x96, 0x02, 0xce, 0x3a, 0x2f, 0xbb, 0xf8, 0x33, 0xea, 0x4f,
-	0x05, 0x4b, 0xf4, 0x42, 0x1a, 0x7c, 0xda, 0x30, 0x39, 0x21, 0x5a, 0xa4, 0x22, 0xde, 0x5f, 0xf1,
-	0x01, 0xdd, 0x8f, 0xca, 0x09, 0xc5, 0xc3, 0x51, 0x75, 0x5e, 0xcb, 0xd1, 0xa9, 0x53, 0xf7, 0xeb,
-	0xcf, 0x8f, 0x7e, 0x6f, 0xc3, 0xee, 0x9f, 0x6d, 0xd8, 0xfd, 0xbb, 0x0d, 0xbb, 0x3f, 0xff, 0x85,
-	0x9d, 0x59, 0x2f, 0x9f, 0xe6, 0x37, 0xff, 0x03, 0x00, 0x00, 0xff, 0xff, 0xc2, 0x6c, 0xc6, 0xd2,
-	0x36, 0x04, 0x00, 0x00,
+func init() { proto.RegisterFile("tinykvpb.proto", fileDescriptor_tinykvpb_b84055dd721c3c8a) }
+
+var fileDescriptor_tinykvpb_b84055dd721c3c8a = []byte{
+	// 773 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x96, 0xdf, 0x6e, 0xd3, 0x3c,
+	0x18, 0xc6, 0x57, 0xe9, 0xfb, 0xba, 0xf1, 0x8e, 0x8d, 0xe1, 0x0e, 0xd8, 0xba, 0xad, 0x88, 0x1d,
+	0x71, 0x54, 0x10, 0x20, 0x21, 0xf1, 0x4f, 0xea, 0x5a, 0xad, 0x1a, 0x19, 0x5a, 0x95, 0x6e, 0xec,
+	0x0c, 0xe4, 0xa5, 0xef, 0xda, 0xa8, 0xa9, 0x1d, 0x12, 0x27, 0x5d, 0xaf, 0x80, 0x5b, 0xe0, 0x92,
+	0x38, 0xe4, 0x12, 0xd0, 0xb8, 0x11, 0x94, 0x36, 0x76, 0xec, 0x24, 0xe5, 0xac, 0x79, 0x7e, 0x7e,
+	0x9e, 0xf8, 0xb5, 0xdf, 0xb8, 0x86, 0x4d, 0xe1, 0xb2, 0xd9, 0x38, 0xf6, 0xaf, 0x9a, 0x7e, 0xc0,
+	0x05, 0x27, 0x6b, 0xf2, 0xb9, 0xbe, 0x31, 0x8e, 0x03, 0xdf, 0x91, 0xa0, 0x5e, 0x0b, 0xe8, 0xb5,
+	0xf8, 0x1a, 0x62, 0x10, 0x63, 0xa0, 0xc4, 0xfb, 0x0e, 0xf7, 0x03, 0xee, 0x60, 0x18, 0xf2, 0x20,
+	0x95, 0xb6, 0x87, 0x7c, 0xc8, 0xe7, 0x3f, 0x9f, 0x25, 0xbf, 0x16, 0xea, 0x8b, 0xef, 0x04, 0xaa,
+	0xe7, 0x2e, 0x9b, 0x59, 0x31, 0x79, 0x05, 0xff, 0x5b, 0x71, 0x17, 0x05, 0xa9, 0x35, 0xe5, 0x1b,
+	0xba, 0x28, 0x6c, 0xfc, 0x16, 0x61, 0x28, 0xea, 0xdb, 0xa6, 0x18, 0xfa, 0x9c, 0x85, 0x78, 0xb8,
+	0x42, 0x5e, 0x43, 0xd5, 0x8a, 0xfb, 0x0e, 0x65, 0x24, 0x1b, 0x91, 0x3c, 0x4a, 0xdf, 0x83, 0x9c,
+	0xaa, 0x8c, 0x6d, 0x00, 0x2b, 0xee, 0x05, 0x38, 0x0d, 0x5c, 0x81, 0x64, 0x47, 0x0d, 0x93, 0x92,
+	0x0c, 0xd8, 0x2d, 0x21, 0x2a, 0xe4, 0x0d, 0xac, 0x5a, 0x71, 0x5f, 0xd0, 0x21, 0x12, 0xed, 0x45,
+	0xc9, 0xb3, 0xb4, 0x3f, 0xcc, 0xcb, 0xca, 0xfb, 0x1e, 0xd6, 0xac, 0xb8, 0xcd, 0x27, 0x13, 0x57,
+	0x90, 0x6c, 0xd4, 0x42, 0x90, 0xee, 0x47, 0x05, 0x5d, 0xd9, 0x2f, 0x60, 0xcb, 0x8a, 0xdb, 0x23,
+	0x74, 0xc6, 0xe7, 0x37, 0xac, 0x2f, 0xa8, 0x88, 0x42, 0xd2, 0xc8, 0x86, 0x1b, 0x40, 0xc6, 0x3d,
+	0x5e, 0xca, 0x55, 0xac, 0x0d, 0xf7, 0xac, 0xf8, 0x88, 0x0a, 0x67, 0x64, 0x73, 0xcf, 0xbb, 0xa2,
+	0xce, 0x98, 0x1c, 0x28, 0x97, 0xa1, 0xcb, 0xd0, 0xc6, 0x32, 0xac, 0x32, 0x4f, 0x61, 0xc3, 0x8a,
+	0x6d, 0x0c, 0xb9, 0x17, 0xe3, 0x29, 0x77, 0xc6, 0x64, 0x4f, 0x59, 0x34, 0x55, 0xe6, 0xed, 0x97,
+	0x43, 0x95, 0xf6, 0x05, 0x6a, 0x46, 0x5a, 0x5a, 0xfb, 0x93, 0x32, 0x9b, 0x59, 0xfe, 0xe1, 0xbf,
+	0x86, 0xa8, 0xfc, 0x63, 0x58, 0xb7, 0x62, 0x9b, 0xb2, 0xe1, 0x62, 0xae, 0xd9, 0xfe, 0x2b, 0x4d,
+	0xe6, 0xd5, 0xcb, 0x50, 0xae, 0xea, 0x04, 0x5c, 0x30, 0x2f, 0x57, 0x75, 0xa6, 0x96, 0x54, 0xad,
+	0x43, 0xb3, 0x5d, 0x93, 0x16, 0x9e, 0x4f, 0x6a, 0xc7, 0xe8, 0x6a, 0x7d, 0x4e, 0xbb, 0x25, 0x44,
+	0x85, 0x74, 0xe0, 0x8e, 0x8d, 0x74, 0x70, 0xc2, 0x06, 0x78, 0xa3, 0x17, 0x26, 0xb5, 0x92, 0xc2,
+	0x32, 0xa4, 0x52, 0xce, 0xe0, 0xee, 0xe5, 0x7c, 0xa7, 0x71, 0xe8, 0x72, 0x16, 0x92, 0x6c, 0xea,
+	0xba, 0x2c, 0xb3, 0x0e, 0x96, 0x50, 0x19, 0xf7, 0xbc, 0x42, 0x4e, 0x00, 0x16, 0x72, 0x27, 0x9a,
+	0xf8, 0x44, 0x7f, 0xb9, 0x14, 0x65, 0xd8, 0x5e, 0x29, 0xd3, 0xa2, 0xde, 0x42, 0xd5, 0xa6, 0xd3,
+	0x2e, 0xea, 0x9f, 0xd4, 0x42, 0x28, 0x7e, 0x52, 0x52, 0x57, 0x85, 0x2d, 0xcc, 0xbd, 0x28, 0x67,
+	0xee, 0x45, 0xe5, 0xe6, 0x5e, 0xa4, 0x9b, 0x93, 0xb5, 0xa5, 0xd3, 0x0e, 0x7a, 0x28, 0xd0, 0x68,
+	0x9a, 0x54, 0x2b, 0x6b, 0x1a, 0x85, 0x54, 0xca, 0x47, 0x58, 0xb7, 0xe9, 0xf4, 0x82, 0x0d, 0x16,
+	0x39, 0x7a, 0xcb, 0x28, 0xb5, 0xac, 0x65, 0x34, 0xa8, 0xb2, 0x3e, 0xc0, 0xaa, 0x4d, 0xa7, 0xf3,
+	0xb3, 0xd1, 0x98, 0xb7, 0x7e, 0x3c, 0xee, 0x14, 0x81, 0xb6, 0x1c, 0xff, 0xd9, 0xf4, 0x5a, 0x90,
+	0x7a, 0xd3, 0x3c, 0xe2, 0x13, 0xf1, 0x13, 0x86, 0x21, 0x1d, 0x62, 0xbd, 0x96, 0x63, 0x1d, 0xce,
+	0xf0, 0x70, 0xe5, 0x69, 0x85, 0xb4, 0x60, 0xad, 0xcf, 0xa8, 0x1f, 0x8e, 0xb8, 0x20, 0xfb, 0xb9,
+	0x41, 0x12, 0xb4, 0x47, 0x11, 0x1b, 0x2f, 0x8f, 0xe8, 0x02, 0xb4, 0x03, 0xa4, 0x02, 0x7b, 0x88,
+	0x81, 0xd6, 0x16, 0x99, 0x58, 0x6c, 0x0b, 0x9d, 0xe9, 0x0b, 0xd1, 0x1a, 0x4c, 0x5c, 0x76, 0xe6,
+	0x6b, 0x0b, 0x91, 0x2a, 0xc5, 0x85, 0x50, 0x40, 0xf9, 0x7b, 0xb0, 0x91, 0x8a, 0xe9, 0x59, 0x73,
+	0x90, 0x1f, 0x6c, 0x9e, 0x33, 0x8d, 0x65, 0xd8, 0x3c, 0x63, 0x5a, 0xd1, 0xc0, 0x15, 0xf3, 0xed,
+	0xc9, 0xda, 0x45, 0x69, 0xc5, 0x76, 0xd1, 0x90, 0xde, 0x74, 0xc7, 0xd4, 0xf5, 0x7a, 0xdc, 0x65,
+	0x42, 0x4b, 0x51, 0x5a, 0x31, 0x45, 0x43, 0xe6, 0x99, 0x6f, 0xe1, 0xec, 0xb3, 0xcb, 0x3d, 0x2a,
+	0xe6, 0xdf, 0x74, 0x56, 0xa1, 0xa1, 0x17, 0x2b, 0xcc, 0x61, 0x95, 0xd9, 0x87, 0xcd, 0xa4, 0x4f,
+	0x92, 0xf3, 0x63, 0x96, 0x94, 0xaf, 0xff, 0x39, 0x99, 0xa0, 0xf8, 0xe7, 0x94, 0xe7, 0x2a, 0xb4,
+	0x0b, 0x90, 0xb0, 0x74, 0x17, 0xea, 0x86, 0xc1, 0xdc, 0x82, 0xbd, 0x52, 0xa6, 0xcf, 0xae, 0xe5,
+	0xfb, 0xde, 0xec, 0x92, 0x0a, 0x0c, 0x26, 0x34, 0x18, 0x6b, 0xb3, 0x33, 0x41, 0x71, 0x76, 0x79,
+	0xae, 0x42, 0xdf, 0xc1, 0x7a, 0x3b, 0xbb, 0xf6, 0x90, 0xed, 0xa6, 0x7e, 0x09, 0xca, 0xee, 0x23,
+	0xa6, 0x2a, 0xdd, 0x47, 0x5b, 0x3f, 0x6f, 0x1b, 0x95, 0x5f, 0xb7, 0x8d, 0xca, 0xef, 0xdb, 0x46,
+	0xe5, 0xc7, 0x9f, 0xc6, 0xca, 0x55, 0x75, 0x7e, 0x45, 0x7a, 0xf9, 0x77, 0x00, 0x4b, 0x0c, 0x1b,
+	0x6b, 0x8b, 0x09, 0x00, 0x00,
 }
@@ -14,7 +14,10 @@
 
 package raft
 
-import pb "github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+import (
+	"github.com/pingcap-incubator/tinykv/log"
+	pb "github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+)
 
 // RaftLog manage the log entries, its struct look like:
 //
@@ -37,6 +40,13 @@ type RaftLog struct {
 	// Invariant: applied <= committed
 	applied uint64
 
+	// applying is the highest log position handed to the application to
+	// apply, which may apply it after the ready is advanced, see
+	// Config.AsyncApply. It equals applied if the entries are applied
+	// synchronously.
+	// Invariant: applied <= applying <= committed
+	applying uint64
+
 	// log entries with index <= stabled are persisted to storage.
 	// It is used to record the logs that are not persisted by storage yet.
 	// Everytime handling `Ready`, the unstabled logs will be included.
@@ -89,3 +99,76 @@ func (l *RaftLog) Term(i uint64) (uint64, error) {
 	// Your Code Here (2A).
 	return 0, nil
 }
+
+// slice returns the log entries in [lo, hi). The total byte size of the
+// returned entries is limited by maxSize, but at least one entry is returned
+// if any, so the caller should continue from the index following the last
+// returned entry to fetch the rest of them.
+func (l *RaftLog) slice(lo, hi, maxSize uint64) ([]pb.Entry, error) {
+	if lo > hi {
+		log.Panicf("invalid slice %d > %d", lo, hi)
+	}
+	if lo == hi {
+		return nil, nil
+	}
+	offset := hi
+	if len(l.entries) > 0 {
+		offset = l.entries[0].Index
+	}
+	var ents []pb.Entry
+	if lo < offset {
+		stored, err := l.storage.Entries(lo, min(hi, offset))
+		if err != nil {
+			return nil, err
+		}
+		ents = limitSize(stored, maxSize)
+		if uint64(len(ents)) < min(hi, offset)-lo {
+			return ents, nil
+		}
+	}
+	if hi > offset {
+		if hi-offset > uint64(len(l.entries)) {
+			log.Panicf("slice[%d,%d) out of bound [%d,%d]", lo, hi, offset, l.LastIndex())
+		}
+		unstable := l.entries[max(lo, offset)-offset : hi-offset]
+		if len(ents) > 0 {
+			ents = append(append([]pb.Entry{}, ents...), unstable...)
+		} else {
+			ents = unstable
+		}
+	}
+	return limitSize(ents, maxSize), nil
+}
+
+// nextApplyingEnts returns the committed entries not handed to the
+// application yet. Without async apply they are the entries nextEnts returns.
+func (l *RaftLog) nextApplyingEnts() []pb.Entry {
+	if l.committed <= l.applying {
+		return nil
+	}
+	ents, err := l.slice(l.applying+1, l.committed+1, noLimit)
+	if err != nil {
+		log.Panicf("unexpected error when getting unapplied entries (%v)", err)
+	}
+	return ents
+}
+
+// applyingTo records that the entries up to i are handed to the application.
+func (l *RaftLog) applyingTo(i uint64) {
+	if i > l.committed || i < l.applying {
+		log.Panicf("applying(%d) is out of range [prevApplying(%d), committed(%d)]", i, l.applying, l.committed)
+	}
+	l.applying = i
+}
+
+// appliedTo records that the entries up to i are applied, they are handed to
+// the application too.
+func (l *RaftLog) appliedTo(i uint64) {
+	if i > l.committed || i < l.applied {
+		log.Panicf("applied(%d) is out of range [prevApplied(%d), committed(%d)]", i, l.applied, l.committed)
+	}
+	l.applied = i
+	if l.applying < i {
+		l.applying = i
+	}
+}
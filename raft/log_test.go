@@ -0,0 +1,111 @@
+// Copyright 2015 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raft
+
+import (
+	"reflect"
+	"testing"
+
+	pb "github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+)
+
+// TestSliceMaxSize ensures the entries fetched for an append message are
+// limited by the max size, across the stable and the unstable entries.
+func TestSliceMaxSize(t *testing.T) {
+	storage := NewMemoryStorage()
+	stable := []pb.Entry{{Index: 1, Term: 1}, {Index: 2, Term: 1}, {Index: 3, Term: 1}}
+	if err := storage.Append(stable); err != nil {
+		t.Fatal(err)
+	}
+	l := &RaftLog{storage: storage, entries: []pb.Entry{{Index: 4, Term: 2}, {Index: 5, Term: 2}, {Index: 6, Term: 2}}}
+	size := uint64(stable[0].Size())
+
+	tests := []struct {
+		lo, hi, maxSize uint64
+
+		w []pb.Entry
+	}{
+		{2, 7, noLimit, []pb.Entry{{Index: 2, Term: 1}, {Index: 3, Term: 1}, {Index: 4, Term: 2}, {Index: 5, Term: 2}, {Index: 6, Term: 2}}},
+		// only stable entries
+		{1, 7, 2 * size, []pb.Entry{{Index: 1, Term: 1}, {Index: 2, Term: 1}}},
+		// across the stable and the unstable entries
+		{2, 7, 3 * size, []pb.Entry{{Index: 2, Term: 1}, {Index: 3, Term: 1}, {Index: 4, Term: 2}}},
+		// only unstable entries
+		{4, 7, 2 * size, []pb.Entry{{Index: 4, Term: 2}, {Index: 5, Term: 2}}},
+		// at least one entry is returned
+		{5, 7, 0, []pb.Entry{{Index: 5, Term: 2}}},
+	}
+	for i, tt := range tests {
+		ents, err := l.slice(tt.lo, tt.hi, tt.maxSize)
+		if err != nil {
+			t.Fatalf("#%d: unexpected error %v", i, err)
+		}
+		if !reflect.DeepEqual(ents, tt.w) {
+			t.Errorf("#%d: ents = %v, want %v", i, ents, tt.w)
+		}
+	}
+}
+
+func TestNextApplyingEnts(t *testing.T) {
+	storage := NewMemoryStorage()
+	if err := storage.Append([]pb.Entry{{Index: 1, Term: 1}, {Index: 2, Term: 1}}); err != nil {
+		t.Fatal(err)
+	}
+	l := &RaftLog{storage: storage, entries: []pb.Entry{{Index: 3, Term: 1}, {Index: 4, Term: 1}}, committed: 3}
+
+	if ents := l.nextApplyingEnts(); !reflect.DeepEqual(ents, []pb.Entry{{Index: 1, Term: 1}, {Index: 2, Term: 1}, {Index: 3, Term: 1}}) {
+		t.Errorf("ents = %v, want [1, 3]", ents)
+	}
+	l.applyingTo(2)
+	if ents := l.nextApplyingEnts(); !reflect.DeepEqual(ents, []pb.Entry{{Index: 3, Term: 1}}) {
+		t.Errorf("ents = %v, want [3, 3]", ents)
+	}
+	// applying less than handed out doesn't hand the entries out again
+	l.appliedTo(1)
+	if l.applied != 1 || l.applying != 2 {
+		t.Errorf("applied, applying = %d, %d, want 1, 2", l.applied, l.applying)
+	}
+	l.appliedTo(3)
+	if l.applied != 3 || l.applying != 3 {
+		t.Errorf("applied, applying = %d, %d, want 3, 3", l.applied, l.applying)
+	}
+	if ents := l.nextApplyingEnts(); ents != nil {
+		t.Errorf("ents = %v, want nil", ents)
+	}
+}
+
+func TestAppliedToOutOfRange(t *testing.T) {
+	tests := []struct {
+		applied, applying, committed uint64
+		f                            func(l *RaftLog)
+	}{
+		// beyond committed
+		{0, 0, 3, func(l *RaftLog) { l.appliedTo(4) }},
+		{0, 0, 3, func(l *RaftLog) { l.applyingTo(4) }},
+		// backwards
+		{2, 2, 3, func(l *RaftLog) { l.appliedTo(1) }},
+		{1, 2, 3, func(l *RaftLog) { l.applyingTo(1) }},
+	}
+	for i, tt := range tests {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("#%d: expect panic", i)
+				}
+			}()
+			tt.f(&RaftLog{storage: NewMemoryStorage(), applied: tt.applied, applying: tt.applying, committed: tt.committed})
+		}()
+	}
+}
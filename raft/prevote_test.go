@@ -0,0 +1,110 @@
+// Copyright 2015 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raft
+
+import (
+	"testing"
+
+	pb "github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+)
+
+func newPreVoteRaft(id uint64, peers ...uint64) *Raft {
+	r := &Raft{
+		id:              id,
+		Term:            1,
+		RaftLog:         newLog(newMemoryStorageWithEnts([]pb.Entry{{}, {Term: 1, Index: 1}})),
+		Prs:             make(map[uint64]*Progress),
+		electionTimeout: 10,
+		preVote:         true,
+	}
+	for _, p := range peers {
+		r.Prs[p] = &Progress{}
+	}
+	return r
+}
+
+func TestPreVoteRequest(t *testing.T) {
+	r := newPreVoteRaft(1, 1, 2, 3)
+	req := pb.Message{MsgType: pb.MessageType_MsgRequestPreVote, From: 2, To: 1, Term: 2, LogTerm: 1, Index: 1}
+	r.handlePreVoteRequest(req)
+	if len(r.msgs) != 1 || r.msgs[0].Reject || r.msgs[0].Term != 2 {
+		t.Fatalf("msgs = %+v, want a pre-vote granted for term 2", r.msgs)
+	}
+	if r.Term != 1 || r.Vote != None {
+		t.Fatalf("term, vote = %d, %d, want 1, %d", r.Term, r.Vote, None)
+	}
+
+	// a request not of a higher term
+	r.msgs = nil
+	r.handlePreVoteRequest(pb.Message{MsgType: pb.MessageType_MsgRequestPreVote, From: 2, To: 1, Term: 1, LogTerm: 1, Index: 1})
+	if len(r.msgs) != 1 || !r.msgs[0].Reject || r.msgs[0].Term != 1 {
+		t.Fatalf("msgs = %+v, want a pre-vote rejected in term 1", r.msgs)
+	}
+
+	// a follower which heard from its leader lately
+	r.msgs = nil
+	r.Lead = 3
+	r.handlePreVoteRequest(req)
+	if len(r.msgs) != 1 || !r.msgs[0].Reject {
+		t.Fatalf("msgs = %+v, want a pre-vote rejected", r.msgs)
+	}
+	r.msgs = nil
+	r.electionElapsed = r.electionTimeout
+	r.handlePreVoteRequest(req)
+	if len(r.msgs) != 1 || r.msgs[0].Reject {
+		t.Fatalf("msgs = %+v, want a pre-vote granted", r.msgs)
+	}
+}
+
+func TestPreCampaign(t *testing.T) {
+	r := newPreVoteRaft(1, 1)
+	if !r.preCampaign() {
+		t.Fatalf("the only peer must win the pre-election at once")
+	}
+
+	r = newPreVoteRaft(1, 1, 2, 3)
+	if r.preCampaign() {
+		t.Fatalf("won the pre-election without pre-votes")
+	}
+	if r.State != StatePreCandidate || r.Term != 1 {
+		t.Fatalf("state, term = %v, %d, want %v, 1", r.State, r.Term, StatePreCandidate)
+	}
+	if len(r.msgs) != 2 {
+		t.Fatalf("len(msgs) = %d, want 2", len(r.msgs))
+	}
+	for _, m := range r.msgs {
+		if m.MsgType != pb.MessageType_MsgRequestPreVote || m.Term != 2 {
+			t.Fatalf("msg = %+v, want a pre-vote request of term 2", m)
+		}
+	}
+
+	resp := pb.Message{MsgType: pb.MessageType_MsgRequestPreVoteResponse, To: 1, Term: 2}
+	resp.From, resp.Reject = 2, true
+	if r.handlePreVoteResponse(resp) {
+		t.Fatalf("won the pre-election with a rejection")
+	}
+	// a peer not in the group
+	resp.From, resp.Reject = 4, false
+	if r.handlePreVoteResponse(resp) {
+		t.Fatalf("won the pre-election with the pre-vote of an unknown peer")
+	}
+	resp.From = 3
+	if !r.handlePreVoteResponse(resp) {
+		t.Fatalf("lost the pre-election with a quorum of pre-votes")
+	}
+	if len(r.votes) != 0 {
+		t.Fatalf("votes = %v, the pre-votes must be kept apart", r.votes)
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2019 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raft
+
+import (
+	"math"
+	"sort"
+)
+
+// VoteResult is the result of an election, or of any decision which needs a
+// quorum, e.g. a read index confirmation.
+type VoteResult int
+
+const (
+	// VotePending means the quorum may still be reached or lost.
+	VotePending VoteResult = iota
+	// VoteLost means the quorum can't be reached any more.
+	VoteLost
+	// VoteWon means a quorum agreed.
+	VoteWon
+)
+
+// majorityConfig is a set of voters, a decision needs a majority of them.
+type majorityConfig map[uint64]struct{}
+
+func newMajorityConfig(ids []uint64) majorityConfig {
+	c := make(majorityConfig, len(ids))
+	for _, id := range ids {
+		c[id] = struct{}{}
+	}
+	return c
+}
+
+// committedIndex returns the largest index acknowledged by a majority of the
+// voters, given the index each voter acknowledged. An empty configuration
+// commits everything, so it never holds back a joint configuration.
+func (c majorityConfig) committedIndex(acked func(id uint64) uint64) uint64 {
+	if len(c) == 0 {
+		return math.MaxUint64
+	}
+	indexes := make([]uint64, 0, len(c))
+	for id := range c {
+		indexes = append(indexes, acked(id))
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i] > indexes[j] })
+	return indexes[len(indexes)/2]
+}
+
+// voteResult returns the result of the votes, the voters missing in votes
+// haven't voted yet. An empty configuration wins every vote.
+func (c majorityConfig) voteResult(votes map[uint64]bool) VoteResult {
+	if len(c) == 0 {
+		return VoteWon
+	}
+	granted, missing := 0, 0
+	for id := range c {
+		v, ok := votes[id]
+		if !ok {
+			missing++
+		} else if v {
+			granted++
+		}
+	}
+	quorum := len(c)/2 + 1
+	if granted >= quorum {
+		return VoteWon
+	}
+	if granted+missing >= quorum {
+		return VotePending
+	}
+	return VoteLost
+}
+
+// jointConfig is the configuration during a joint membership change, a
+// decision needs a majority of both the incoming and the outgoing voters.
+// Outside of a change the outgoing configuration is empty.
+type jointConfig [2]majorityConfig
+
+// committedIndex returns the largest index committed by both configurations.
+func (c jointConfig) committedIndex(acked func(id uint64) uint64) uint64 {
+	incoming, outgoing := c[0].committedIndex(acked), c[1].committedIndex(acked)
+	if incoming < outgoing {
+		return incoming
+	}
+	return outgoing
+}
+
+// voteResult returns VoteWon if both configurations won, VoteLost if either
+// lost, and VotePending otherwise.
+func (c jointConfig) voteResult(votes map[uint64]bool) VoteResult {
+	incoming, outgoing := c[0].voteResult(votes), c[1].voteResult(votes)
+	if incoming == outgoing {
+		return incoming
+	}
+	if incoming == VoteLost || outgoing == VoteLost {
+		return VoteLost
+	}
+	return VotePending
+}
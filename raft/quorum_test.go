@@ -0,0 +1,166 @@
+// Copyright 2019 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raft
+
+import (
+	"reflect"
+	"testing"
+
+	pb "github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+)
+
+func TestMajorityConfig(t *testing.T) {
+	c := newMajorityConfig([]uint64{1, 2, 3})
+	match := map[uint64]uint64{1: 10, 2: 7, 3: 5}
+	if idx := c.committedIndex(func(id uint64) uint64 { return match[id] }); idx != 7 {
+		t.Fatalf("committed index = %d, want 7", idx)
+	}
+	tests := []struct {
+		votes map[uint64]bool
+		want  VoteResult
+	}{
+		{map[uint64]bool{1: true}, VotePending},
+		{map[uint64]bool{1: true, 2: true}, VoteWon},
+		{map[uint64]bool{1: true, 2: false}, VotePending},
+		{map[uint64]bool{1: true, 2: false, 3: false}, VoteLost},
+		// a vote of a node not in the config
+		{map[uint64]bool{1: true, 4: true}, VotePending},
+	}
+	for i, tt := range tests {
+		if r := c.voteResult(tt.votes); r != tt.want {
+			t.Errorf("#%d: vote result = %v, want %v", i, r, tt.want)
+		}
+	}
+}
+
+func TestJointConfig(t *testing.T) {
+	c := jointConfig{newMajorityConfig([]uint64{1, 4, 5}), newMajorityConfig([]uint64{1, 2, 3})}
+	match := map[uint64]uint64{1: 10, 2: 9, 3: 8, 4: 3, 5: 2}
+	if idx := c.committedIndex(func(id uint64) uint64 { return match[id] }); idx != 3 {
+		t.Fatalf("committed index = %d, want 3", idx)
+	}
+	if r := c.voteResult(map[uint64]bool{1: true, 2: true, 3: true}); r != VotePending {
+		t.Fatalf("vote result = %v, want %v", r, VotePending)
+	}
+	if r := c.voteResult(map[uint64]bool{1: true, 2: true, 4: true}); r != VoteWon {
+		t.Fatalf("vote result = %v, want %v", r, VoteWon)
+	}
+	if r := c.voteResult(map[uint64]bool{1: true, 2: true, 4: false, 5: false}); r != VoteLost {
+		t.Fatalf("vote result = %v, want %v", r, VoteLost)
+	}
+	// outside of a membership change
+	c = jointConfig{newMajorityConfig([]uint64{1, 2, 3})}
+	if idx := c.committedIndex(func(id uint64) uint64 { return match[id] }); idx != 9 {
+		t.Fatalf("committed index = %d, want 9", idx)
+	}
+}
+
+func TestMembershipChange(t *testing.T) {
+	r := &Raft{
+		id:      1,
+		RaftLog: newLog(NewMemoryStorage()),
+		Prs:     map[uint64]*Progress{1: {Match: 5}, 2: {Match: 5}, 3: {Match: 4}},
+		State:   StateFollower,
+	}
+	cc := pb.ConfChange{
+		ChangeType:    pb.ConfChangeType_BeginMembershipChange,
+		Configuration: &pb.ConfState{Nodes: []uint64{1, 4, 5}},
+	}
+	if err := r.beginMembershipChange(cc); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.beginMembershipChange(cc); err == nil {
+		t.Fatalf("began a membership change while another is in progress")
+	}
+	if ids := nodes(r); !reflect.DeepEqual(ids, []uint64{1, 2, 3, 4, 5}) {
+		t.Fatalf("nodes = %v, want all the nodes of both configurations", ids)
+	}
+	// the new voters hold the commit back
+	if idx := r.committedIndex(); idx != 0 {
+		t.Fatalf("committed index = %d, want 0", idx)
+	}
+	r.Prs[4].Match = 5
+	if idx := r.committedIndex(); idx != 5 {
+		t.Fatalf("committed index = %d, want 5", idx)
+	}
+
+	r.finalizeMembershipChange()
+	if ids := nodes(r); !reflect.DeepEqual(ids, []uint64{1, 4, 5}) {
+		t.Fatalf("nodes = %v, want the nodes of the new configuration", ids)
+	}
+	if r.pendingMembershipChange != nil || r.outgoing != nil {
+		t.Fatalf("the membership change is still pending")
+	}
+	// finalized twice
+	r.finalizeMembershipChange()
+	if ids := nodes(r); !reflect.DeepEqual(ids, []uint64{1, 4, 5}) {
+		t.Fatalf("nodes = %v, want the nodes of the new configuration", ids)
+	}
+}
+
+func TestLearnerQuorum(t *testing.T) {
+	r := &Raft{
+		id:      1,
+		RaftLog: newLog(NewMemoryStorage()),
+		Prs:     map[uint64]*Progress{1: {Match: 5}, 2: {Match: 3}, 3: {Match: 2}},
+		State:   StateFollower,
+	}
+	rn := &RawNode{Raft: r}
+	cs := rn.ApplyConfChange(pb.ConfChange{ChangeType: pb.ConfChangeType_AddLearnerNode, NodeId: 4})
+	if !reflect.DeepEqual(cs.Nodes, []uint64{1, 2, 3}) || !reflect.DeepEqual(cs.Learners, []uint64{4}) {
+		t.Fatalf("conf state = %v, want learner 4", cs)
+	}
+	// adding a voter as a learner doesn't demote it
+	r.addLearner(2)
+	if r.Prs[2].IsLearner {
+		t.Fatalf("voter 2 is demoted")
+	}
+
+	// the learner neither commits nor votes
+	r.Prs[4].Match = 5
+	if idx := r.committedIndex(); idx != 3 {
+		t.Fatalf("committed index = %d, want 3", idx)
+	}
+	if res := r.quorum().voteResult(map[uint64]bool{1: true, 4: true}); res != VotePending {
+		t.Fatalf("vote result = %v, want %v", res, VotePending)
+	}
+	r.requestVotes(campaignElection)
+	for _, m := range r.msgs {
+		if m.To == 4 {
+			t.Fatalf("requested the vote of learner 4")
+		}
+	}
+	r.id = 4
+	if r.promotable() {
+		t.Fatalf("learner 4 is promotable")
+	}
+	r.id = 1
+
+	// a membership change keeps the learners out of its configuration
+	cc := pb.ConfChange{
+		ChangeType:    pb.ConfChangeType_BeginMembershipChange,
+		Configuration: &pb.ConfState{Nodes: []uint64{1, 2}},
+	}
+	if err := r.beginMembershipChange(cc); err != nil {
+		t.Fatal(err)
+	}
+	r.finalizeMembershipChange()
+	if ids := nodes(r); !reflect.DeepEqual(ids, []uint64{1, 2}) {
+		t.Fatalf("nodes = %v, want [1 2]", ids)
+	}
+	if ids := learners(r); !reflect.DeepEqual(ids, []uint64{4}) {
+		t.Fatalf("learners = %v, want [4]", ids)
+	}
+}
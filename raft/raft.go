@@ -30,18 +30,32 @@ const (
 	StateFollower StateType = iota
 	StateCandidate
 	StateLeader
+	StatePreCandidate
 )
 
 var stmap = [...]string{
 	"StateFollower",
 	"StateCandidate",
 	"StateLeader",
+	"StatePreCandidate",
 }
 
 func (st StateType) String() string {
 	return stmap[uint64(st)]
 }
 
+// campaignType is the kind of a campaign.
+type campaignType string
+
+const (
+	// campaignPreElection is the first phase of an election with
+	// Config.PreVote, which doesn't change the term of any peer.
+	campaignPreElection campaignType = "CampaignPreElection"
+	// campaignElection is a normal election, or the second phase of an
+	// election with Config.PreVote.
+	campaignElection campaignType = "CampaignElection"
+)
+
 // ErrProposalDropped is returned when the proposal is ignored by some cases,
 // so that the proposer can be notified and fail fast.
 var ErrProposalDropped = errors.New("raft proposal dropped")
@@ -79,6 +93,56 @@ type Config struct {
 	// Applied. If Applied is unset when restarting, raft might return previous
 	// applied entries. This is a very application dependent configuration.
 	Applied uint64
+
+	// MaxSizePerMsg limits the max byte size of the entries carried by each
+	// append message. A follower which is far behind is caught up by several
+	// append messages instead of a single huge one, so that the message never
+	// exceeds the transport limit. At least one entry is always sent even if
+	// it is larger than the limit. Zero means no limit.
+	MaxSizePerMsg uint64
+	// MaxInflightMsgs limits the max number of in-flight append messages to a
+	// follower in the optimistic replication phase. The leader stops sending
+	// appends to the follower once the limit is reached, until the follower
+	// acknowledges some of them, so a slow follower doesn't pile up messages
+	// on the leader. Together with MaxSizePerMsg it bounds the bytes in flight
+	// to a follower. Zero means no limit.
+	MaxInflightMsgs int
+	// MaxUncommittedEntriesSize limits the aggregate byte size of the
+	// uncommitted entries that may be appended to a leader's log. Once this
+	// limit is exceeded, proposals will begin to return ErrProposalDropped
+	// errors. Zero means no limit.
+	MaxUncommittedEntriesSize uint64
+
+	// RequirePersistAck makes RawNode.Advance panic if a Ready which must be
+	// synced is not reported by RawNode.ReportPersisted, so that an application
+	// can't send the messages of a vote before the vote is persisted.
+	RequirePersistAck bool
+
+	// AsyncApply lets the application apply the committed entries of a Ready
+	// after advancing it, e.g. in another goroutine, so the hard states and
+	// entries of the next readies are persisted meanwhile. The application
+	// advances a ready by RawNode.AdvanceAppend and reports the entries it
+	// applied by RawNode.AdvanceApply. A Ready returns the committed entries
+	// not handed to the application yet.
+	AsyncApply bool
+
+	// ParallelAppend lets the leader send the entries of a Ready to the
+	// followers while it persists them. The leader's own entries count towards
+	// the commit only once the application steps the MsgAppendResponse the
+	// leader sends to itself in the Ready, see IsSelfAppendResponse, after
+	// persisting the ready.
+	ParallelAppend bool
+
+	// ReadOnlyOption decides how a read index request confirms the leadership,
+	// see RawNode.ReadIndex. ReadOnlySafe, the default, exchanges a round of
+	// heartbeats with a quorum, ReadOnlyLeaseBased trusts the leader's lease.
+	ReadOnlyOption ReadOnlyOption
+
+	// PreVote enables the pre-election of the Raft thesis, section 9.6. A
+	// peer whose election times out asks the others whether it could win an
+	// election before it increases its term, so a peer which rejoins after a
+	// partition doesn't disrupt a healthy leader with its higher term.
+	PreVote bool
 }
 
 func (c *Config) validate() error {
@@ -98,13 +162,19 @@ func (c *Config) validate() error {
 		return errors.New("storage cannot be nil")
 	}
 
-	return nil
-}
+	if c.MaxSizePerMsg == 0 {
+		c.MaxSizePerMsg = noLimit
+	}
+
+	if c.MaxInflightMsgs < 0 {
+		return errors.New("max inflight messages must not be negative")
+	}
 
-// Progress represents a follower’s progress in the view of the leader. Leader maintains
-// progresses of all followers, and sends entries to the follower based on its progress.
-type Progress struct {
-	Match, Next uint64
+	if c.MaxUncommittedEntriesSize == 0 {
+		c.MaxUncommittedEntriesSize = noLimit
+	}
+
+	return nil
 }
 
 type Raft struct {
@@ -157,6 +227,43 @@ type Raft struct {
 	// value.
 	// (Used in 3A conf change)
 	PendingConfIndex uint64
+
+	// the begun joint membership change, whose configuration is the incoming
+	// voters, nil if there is none. Prs holds the progress of both the
+	// incoming and the outgoing voters until the change is finalized.
+	pendingMembershipChange *pb.ConfChange
+	// the outgoing voters of the pending membership change
+	outgoing majorityConfig
+
+	// the max byte size of the entries of an append message, set from
+	// Config.MaxSizePerMsg
+	maxMsgSize uint64
+	// the window size of the in-flight append messages of each progress, set
+	// from Config.MaxInflightMsgs
+	maxInflight int
+
+	// an estimate of the size of the uncommitted tail of the Raft log. Used to
+	// prevent unbounded log growth. Only maintained by the leader. Reset on
+	// term changes.
+	uncommittedSize uint64
+	// the limit of uncommittedSize, set from Config.MaxUncommittedEntriesSize
+	maxUncommittedSize uint64
+
+	// the pending read index requests of the leader, created by
+	// newReadOnly(Config.ReadOnlyOption)
+	readOnly *readOnly
+	// the read states to be returned in the next Ready
+	readStates []ReadState
+
+	// whether an election starts with a pre-election, set from Config.PreVote
+	preVote bool
+	// pre-votes records of the pre-candidate, kept apart from votes, since a
+	// pre-vote neither changes the term nor binds the voter
+	preVotes map[uint64]bool
+
+	// whether the leader acknowledges its own entries by a self-directed
+	// append response, set from Config.ParallelAppend
+	parallelAppend bool
 }
 
 // newRaft return a raft peer with the given config
@@ -164,18 +271,39 @@ func newRaft(c *Config) *Raft {
 	if err := c.validate(); err != nil {
 		panic(err.Error())
 	}
+	// NOTE: create r.readOnly with newReadOnly(c.ReadOnlyOption).
+	// NOTE: set r.preVote with c.PreVote.
+	// NOTE: set r.parallelAppend with c.ParallelAppend.
+	// NOTE: set r.maxMsgSize with c.MaxSizePerMsg.
+	// NOTE: set r.maxInflight with c.MaxInflightMsgs, and create the
+	// progresses with r.newProgress.
+	// NOTE: the peers in ConfState.Learners of c.Storage.InitialState() are
+	// added to r.Prs with Progress.IsLearner set, and the peers in
+	// ConfState.Witnesses with Progress.IsWitness set.
 	// Your Code Here (2A).
 	return nil
 }
 
 // sendAppend sends an append RPC with new entries (if any) and the
 // current commit index to the given peer. Returns true if a message was sent.
+// The entries sent in one message are limited by Config.MaxSizePerMsg, fetch
+// them by r.RaftLog.slice(pr.Next, lastIndex+1, r.maxMsgSize), the rest of
+// them are sent by the following appends after the peer responds.
+// NOTE: nothing is sent to a progress which IsPaused. If the entries from
+// pr.Next are compacted, send a snapshot and pr.becomeSnapshot. Otherwise, in
+// ProgressStateReplicate advance pr.Next by pr.optimisticUpdate and add the
+// last index sent to pr.ins, in ProgressStateProbe pr.pause until the
+// follower responds.
+// NOTE: build the Entries of the message with entryPtrs, which doesn't copy
+// the entries.
 func (r *Raft) sendAppend(to uint64) bool {
 	// Your Code Here (2A).
 	return false
 }
 
 // sendHeartbeat sends a heartbeat RPC to the given peer.
+// NOTE: the heartbeat carries r.readOnly.lastPendingRequestCtx() in Context,
+// which the response returns, to confirm the leadership for the pending reads.
 func (r *Raft) sendHeartbeat(to uint64) {
 	// Your Code Here (2A).
 }
@@ -185,7 +313,73 @@ func (r *Raft) tick() {
 	// Your Code Here (2A).
 }
 
+// selfAppendResponse returns the append response the leader sends to itself
+// for its entries up to index, see Config.ParallelAppend.
+func (r *Raft) selfAppendResponse(index uint64) pb.Message {
+	return pb.Message{
+		MsgType: pb.MessageType_MsgAppendResponse,
+		To:      r.id,
+		From:    r.id,
+		Term:    r.Term,
+		Index:   index,
+	}
+}
+
+// increaseUncommittedSize computes the size of the proposed entries and
+// determines whether they would push leader over its maxUncommittedSize limit.
+// If the new entries would exceed the limit, the method returns false and the
+// leader should drop the proposal with ErrProposalDropped. If not, the
+// increase in uncommitted entry size is recorded and the method returns true.
+//
+// Empty payloads are never refused. This is used both for appending an empty
+// entry at a new leader's term, as well as leaving a joint configuration.
+func (r *Raft) increaseUncommittedSize(ents []*pb.Entry) bool {
+	var s uint64
+	for _, e := range ents {
+		s += payloadSize(e)
+	}
+
+	if r.uncommittedSize > 0 && s > 0 && r.uncommittedSize+s > r.maxUncommittedSize {
+		// If the uncommitted tail of the Raft log is empty, allow any size
+		// proposal. Otherwise, limit the size of the uncommitted tail of the
+		// log and drop any proposal that would push the size over the limit.
+		// Note the added requirement s>0 which is used to make sure that
+		// appending single empty entries to the log always succeeds, used both
+		// for replicating a new leader's initial empty entry, and for
+		// auto-leaving joint configurations.
+		return false
+	}
+	r.uncommittedSize += s
+	return true
+}
+
+// reduceUncommittedSize accounts for the newly committed entries by decreasing
+// the uncommitted entry size limit. The leader should call it with the entries
+// whose commit is advanced.
+func (r *Raft) reduceUncommittedSize(ents []pb.Entry) {
+	if r.uncommittedSize == 0 {
+		// Fast-path for followers, who do not track or enforce the limit.
+		return
+	}
+
+	var s uint64
+	for i := range ents {
+		s += payloadSize(&ents[i])
+	}
+	if s > r.uncommittedSize {
+		// uncommittedSize may underestimate the size of the uncommitted Raft
+		// log tail but will never overestimate it. Saturate at 0 instead of
+		// allowing overflow.
+		r.uncommittedSize = 0
+	} else {
+		r.uncommittedSize -= s
+	}
+}
+
 // becomeFollower transform this peer's state to Follower
+// NOTE: the pending read index requests are dropped on a term change, reset
+// r.readOnly with newReadOnly(r.readOnly.option), also in becomeCandidate and
+// becomeLeader.
 func (r *Raft) becomeFollower(term uint64, lead uint64) {
 	// Your Code Here (2A).
 }
@@ -199,10 +393,51 @@ func (r *Raft) becomeCandidate() {
 func (r *Raft) becomeLeader() {
 	// Your Code Here (2A).
 	// NOTE: Leader should propose a noop entry on its term
+	// NOTE: Leader should reset the progresses with r.newProgress, keeping
+	// IsLearner and IsWitness, and its own progress becomeReplicate and
+	// RecentActive
+	// NOTE: Leader should reset uncommittedSize, and drop the proposals
+	// refused by increaseUncommittedSize
+	// NOTE: Leader should propose a FinalizeMembershipChange if
+	// r.pendingMembershipChange is set, the previous leader may not have
 }
 
 // Step the entrance of handle message, see `MessageType`
 // on `eraftpb.proto` for what msgs should be handled
+// NOTE: Leader should handle MessageType_MsgSnapStatus by handleSnapStatus
+// NOTE: the leader sets pr.RecentActive of a peer on any message from it
+// NOTE: on a rejected MessageType_MsgAppendResponse the leader decreases
+// pr.Next by pr.maybeDecrTo, a progress in ProgressStateReplicate then
+// becomeProbe. On an accepted one, if pr.maybeUpdate, a probing progress
+// becomeReplicate, a snapshot progress which needSnapshotAbort becomeProbe,
+// and a replicating progress frees the acknowledged messages by
+// pr.ins.freeTo. On a MessageType_MsgHeartbeatResponse the leader resumes
+// the progress and, if pr.ins is full, frees pr.ins.freeFirstOne, then
+// sendAppend if pr.Match is behind.
+// NOTE: if r.parallelAppend is set, the leader doesn't advance its own
+// progress when it appends entries, but on its self-directed
+// MessageType_MsgAppendResponse, which the application steps once the entries
+// are persisted, so an entry is committed by a quorum of persisted logs.
+// NOTE: every state handles MessageType_MsgReadIndex by stepReadIndex, a
+// follower handles MessageType_MsgReadIndexResp by handleReadIndexResp, and
+// the leader passes MessageType_MsgHeartbeatResponse to handleReadIndexAck
+// NOTE: a follower which heard from its leader within the election timeout
+// ignores a MessageType_MsgRequestVote of a higher term, or the leader lease
+// of the raftstore doesn't hold
+// NOTE: count the votes with r.quorum().voteResult and advance the committed
+// index of the leader to r.committedIndex, so a joint membership change needs
+// a quorum of both configurations
+// NOTE: a learner or a witness, i.e. a peer which isn't r.promotable(),
+// ignores MessageType_MsgHup and never campaigns, and the leader ignores a
+// MessageType_MsgTransferLeader to a learner or a witness
+// NOTE: if r.preVote is set, MessageType_MsgHup starts a pre-election by
+// r.preCampaign, and a pre-candidate which wins it, by preCampaign or
+// handlePreVoteResponse returning true, starts the election with
+// becomeCandidate and r.requestVotes(campaignElection). Every state handles
+// MessageType_MsgRequestPreVote by handlePreVoteRequest, and a
+// MessageType_MsgRequestPreVote or a granted
+// MessageType_MsgRequestPreVoteResponse of a higher term doesn't change the
+// term. StatePreCandidate steps the other messages like StateCandidate.
 func (r *Raft) Step(m pb.Message) error {
 	// Your Code Here (2A).
 	switch r.State {
@@ -214,6 +449,9 @@ func (r *Raft) Step(m pb.Message) error {
 }
 
 // handleAppendEntries handle AppendEntries RPC request
+// NOTE: append the Entries of the message to the log with appendEntries, on a
+// conflict append them to a copy of the kept entries rather than overwriting
+// the log in place, see entryPtrs.
 func (r *Raft) handleAppendEntries(m pb.Message) {
 	// Your Code Here (2A).
 }
@@ -228,7 +466,319 @@ func (r *Raft) handleSnapshot(m pb.Message) {
 	// Your Code Here (2C).
 }
 
+// handleSnapStatus handles the status of the snapshot sent to a follower,
+// reported by the follower or locally by RawNode.ReportSnapshot. The leader
+// resumes the replication after the snapshot if it's applied, otherwise it
+// sends a fresh snapshot.
+func (r *Raft) handleSnapStatus(m pb.Message) {
+	pr, ok := r.Prs[m.From]
+	if !ok || r.State != StateLeader {
+		return
+	}
+	if m.Reject {
+		// The entries after Match may have been compacted, the next append
+		// sends a new snapshot in that case.
+		pr.snapshotFailure()
+		pr.becomeProbe()
+	} else {
+		if m.Index > pr.Match {
+			pr.Match = m.Index
+			pr.Next = max(pr.Next, m.Index+1)
+		}
+		if pr.State == ProgressStateSnapshot {
+			pr.becomeProbe()
+		}
+	}
+	r.sendAppend(m.From)
+}
+
+// stepReadIndex handles a read index request, local or forwarded by a
+// follower. The leader confirms it's still the leader, with a round of
+// heartbeats unless the reads are lease based, then the read index is its
+// committed index at the time of the request. A follower forwards the request
+// to the leader. The request is dropped with ErrProposalDropped if there is no
+// leader, or if the leader hasn't committed an entry of its term yet, since
+// its committed index may be behind the previous leader's then.
+func (r *Raft) stepReadIndex(m pb.Message) error {
+	if len(m.Entries) != 1 {
+		return ErrProposalDropped
+	}
+	if r.State != StateLeader {
+		if r.Lead == None {
+			return ErrProposalDropped
+		}
+		m.To = r.Lead
+		r.msgs = append(r.msgs, m)
+		return nil
+	}
+	if r.quorum().voteResult(map[uint64]bool{r.id: true}) == VoteWon {
+		// The leader is the only voter.
+		r.responseToReadIndexReq(m, r.RaftLog.committed)
+		return nil
+	}
+	if term, err := r.RaftLog.Term(r.RaftLog.committed); err != nil || term != r.Term {
+		return ErrProposalDropped
+	}
+	switch r.readOnly.option {
+	case ReadOnlySafe:
+		r.readOnly.addRequest(r.RaftLog.committed, m)
+		// The leader acknowledges itself.
+		r.readOnly.recvAck(r.id, m.Entries[0].Data)
+		for id := range r.Prs {
+			if id != r.id {
+				r.sendHeartbeat(id)
+			}
+		}
+	case ReadOnlyLeaseBased:
+		r.responseToReadIndexReq(m, r.RaftLog.committed)
+	}
+	return nil
+}
+
+// handleReadIndexAck counts a heartbeat response as an acknowledgment of the
+// leadership for the pending read index requests it carries the context of.
+// Once a quorum acknowledged a request, it and the requests before it are
+// answered.
+func (r *Raft) handleReadIndexAck(m pb.Message) {
+	if r.State != StateLeader || r.readOnly.option != ReadOnlySafe || len(m.Context) == 0 {
+		return
+	}
+	acks := r.readOnly.recvAck(m.From, m.Context)
+	if r.quorum().voteResult(acks) != VoteWon {
+		return
+	}
+	for _, rs := range r.readOnly.advance(m) {
+		r.responseToReadIndexReq(rs.req, rs.index)
+	}
+}
+
+// handleReadIndexResp handles the read index of a request the follower
+// forwarded to the leader.
+func (r *Raft) handleReadIndexResp(m pb.Message) {
+	if len(m.Entries) != 1 {
+		return
+	}
+	r.readStates = append(r.readStates, ReadState{Index: m.Index, RequestCtx: m.Entries[0].Data})
+}
+
+// responseToReadIndexReq returns the read index of a request, as a read state
+// of the leader's next Ready if the request is local, or in a
+// MsgReadIndexResp to the follower which forwarded it.
+func (r *Raft) responseToReadIndexReq(req pb.Message, readIndex uint64) {
+	if req.From == None || req.From == r.id {
+		r.readStates = append(r.readStates, ReadState{Index: readIndex, RequestCtx: req.Entries[0].Data})
+		return
+	}
+	r.msgs = append(r.msgs, pb.Message{
+		MsgType: pb.MessageType_MsgReadIndexResp,
+		To:      req.From,
+		From:    r.id,
+		Term:    r.Term,
+		Index:   readIndex,
+		Entries: req.Entries,
+	})
+}
+
+// becomePreCandidate transforms this peer's state to pre-candidate. Unlike
+// becomeCandidate it keeps the term and the vote, the peer only learns
+// whether it could win an election.
+func (r *Raft) becomePreCandidate() {
+	if r.State == StateLeader {
+		panic("invalid transition [leader -> pre-candidate]")
+	}
+	r.State = StatePreCandidate
+	r.Lead = None
+	r.electionElapsed = 0
+	r.preVotes = map[uint64]bool{r.id: true}
+}
+
+// preCampaign starts a pre-election. It returns true if this peer won it at
+// once, i.e. it's the only peer of the group.
+func (r *Raft) preCampaign() bool {
+	r.becomePreCandidate()
+	if r.preVoteQuorum() {
+		return true
+	}
+	r.requestVotes(campaignPreElection)
+	return false
+}
+
+// requestVotes sends the vote requests of a campaign to the other peers. A
+// pre-vote is requested for the next term, which the pre-candidate hasn't
+// moved to yet.
+func (r *Raft) requestVotes(t campaignType) {
+	msgType, term := pb.MessageType_MsgRequestVote, r.Term
+	if t == campaignPreElection {
+		msgType, term = pb.MessageType_MsgRequestPreVote, r.Term+1
+	}
+	lastIndex := r.RaftLog.LastIndex()
+	lastTerm := mustTerm(r.RaftLog.Term(lastIndex))
+	for id, pr := range r.Prs {
+		if id == r.id || pr.IsLearner {
+			continue
+		}
+		r.msgs = append(r.msgs, pb.Message{
+			MsgType: msgType,
+			To:      id,
+			From:    r.id,
+			Term:    term,
+			LogTerm: lastTerm,
+			Index:   lastIndex,
+		})
+	}
+}
+
+// handlePreVoteRequest grants a pre-vote if this peer would vote for the
+// candidate in an election of the requested term: the candidate's log is at
+// least as up-to-date as this peer's, and this peer isn't the leader and
+// hasn't heard from a leader within the election timeout. The term and the
+// vote of this peer don't change.
+func (r *Raft) handlePreVoteRequest(m pb.Message) {
+	lastIndex := r.RaftLog.LastIndex()
+	lastTerm := mustTerm(r.RaftLog.Term(lastIndex))
+	upToDate := m.LogTerm > lastTerm || (m.LogTerm == lastTerm && m.Index >= lastIndex)
+	hasLeader := r.State == StateLeader || (r.Lead != None && r.electionElapsed < r.electionTimeout)
+	resp := pb.Message{
+		MsgType: pb.MessageType_MsgRequestPreVoteResponse,
+		To:      m.From,
+		From:    r.id,
+		Term:    m.Term,
+	}
+	if m.Term <= r.Term || !upToDate || hasLeader {
+		resp.Term = r.Term
+		resp.Reject = true
+	}
+	r.msgs = append(r.msgs, resp)
+}
+
+// handlePreVoteResponse counts a pre-vote for the pre-candidate. It returns
+// true once a quorum granted it, then the pre-candidate starts the election.
+// Once a quorum rejected it, the peer returns to follower of its term.
+func (r *Raft) handlePreVoteResponse(m pb.Message) bool {
+	if r.State != StatePreCandidate {
+		return false
+	}
+	if _, ok := r.Prs[m.From]; !ok {
+		return false
+	}
+	r.preVotes[m.From] = !m.Reject
+	switch r.quorum().voteResult(r.preVotes) {
+	case VoteWon:
+		return true
+	case VoteLost:
+		r.becomeFollower(r.Term, None)
+	}
+	return false
+}
+
+// preVoteQuorum tells whether a quorum granted the pre-vote.
+func (r *Raft) preVoteQuorum() bool {
+	return r.quorum().voteResult(r.preVotes) == VoteWon
+}
+
+// quorum returns the voters whose quorum decides the commits and the
+// elections, both the incoming and the outgoing voters during a joint
+// membership change.
+func (r *Raft) quorum() jointConfig {
+	if r.pendingMembershipChange != nil {
+		return jointConfig{newMajorityConfig(r.pendingMembershipChange.Configuration.GetNodes()), r.outgoing}
+	}
+	return jointConfig{newMajorityConfig(nodes(r))}
+}
+
+// committedIndex returns the largest index replicated to a quorum, by the
+// Match of the progresses.
+func (r *Raft) committedIndex() uint64 {
+	return r.quorum().committedIndex(func(id uint64) uint64 {
+		if pr, ok := r.Prs[id]; ok {
+			return pr.Match
+		}
+		return 0
+	})
+}
+
+// beginMembershipChange enters the joint configuration of the current voters
+// and the configuration of cc. The new voters are replicated to from the
+// next entry of the leader.
+func (r *Raft) beginMembershipChange(cc pb.ConfChange) error {
+	if r.pendingMembershipChange != nil {
+		return errors.New("a membership change is in progress")
+	}
+	if len(cc.Configuration.GetNodes()) == 0 {
+		return errors.New("the configuration of a membership change has no voters")
+	}
+	r.outgoing = newMajorityConfig(nodes(r))
+	for _, id := range cc.Configuration.Nodes {
+		if pr, ok := r.Prs[id]; ok {
+			// a learner in the configuration is promoted
+			pr.IsLearner = false
+		} else {
+			r.Prs[id] = r.newProgress(r.RaftLog.LastIndex()+1, false)
+		}
+	}
+	r.pendingMembershipChange = &cc
+	return nil
+}
+
+// finalizeMembershipChange leaves the joint configuration for the incoming
+// voters, the learners are kept. A leader which isn't one of them steps down. Without a pending
+// change it does nothing, the change may be finalized twice if the leader
+// changed in between.
+func (r *Raft) finalizeMembershipChange() {
+	if r.pendingMembershipChange == nil {
+		return
+	}
+	incoming := newMajorityConfig(r.pendingMembershipChange.Configuration.Nodes)
+	for id, pr := range r.Prs {
+		if _, ok := incoming[id]; !ok && !pr.IsLearner {
+			delete(r.Prs, id)
+		}
+	}
+	r.pendingMembershipChange = nil
+	r.outgoing = nil
+	if _, ok := incoming[r.id]; !ok && r.State == StateLeader {
+		r.becomeFollower(r.Term, None)
+	}
+}
+
+// promotable tells whether this peer may become the leader, i.e. it's a
+// voter of the group but not a witness.
+func (r *Raft) promotable() bool {
+	pr, ok := r.Prs[r.id]
+	return ok && !pr.IsLearner && !pr.IsWitness
+}
+
+// addLearner adds a learner to the raft group, which the leader replicates
+// to from its next entry. A peer in the group already isn't changed, a voter
+// isn't demoted.
+func (r *Raft) addLearner(id uint64) {
+	if _, ok := r.Prs[id]; ok {
+		return
+	}
+	r.Prs[id] = r.newProgress(r.RaftLog.LastIndex()+1, true)
+}
+
+// addWitness adds a witness to the raft group, a voter which the leader
+// replicates to from its next entry. A peer in the group already isn't
+// changed.
+func (r *Raft) addWitness(id uint64) {
+	if _, ok := r.Prs[id]; ok {
+		return
+	}
+	pr := r.newProgress(r.RaftLog.LastIndex()+1, false)
+	pr.IsWitness = true
+	r.Prs[id] = pr
+}
+
+// newProgress returns a probing progress from the next index, whose window
+// of in-flight messages is r.maxInflight.
+func (r *Raft) newProgress(next uint64, isLearner bool) *Progress {
+	return &Progress{Next: next, IsLearner: isLearner, ins: newInflights(r.maxInflight)}
+}
+
 // addNode add a new node to raft group
+// NOTE: adding a learner promotes it to a voter by clearing its
+// Progress.IsLearner, its progress is kept.
 func (r *Raft) addNode(id uint64) {
 	// Your Code Here (3A).
 }
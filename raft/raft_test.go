@@ -318,6 +318,30 @@ func TestSingleNodeCommit2AB(t *testing.T) {
 	}
 }
 
+// TestParallelAppendCommit2AB tests that with ParallelAppend the leader's own
+// entries count towards the commit only once its self append response, which
+// the application steps after persisting them, is stepped.
+func TestParallelAppendCommit2AB(t *testing.T) {
+	cfg := newTestConfig(1, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+	cfg.ParallelAppend = true
+	r := newRaft(cfg)
+	r.becomeCandidate()
+	r.becomeLeader()
+	r.Step(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgPropose, Entries: []*pb.Entry{{Data: []byte("some data")}}})
+	r.readMessages()
+	li := r.RaftLog.LastIndex()
+
+	// a follower persisted the entries, but the leader didn't yet
+	r.Step(pb.Message{From: 2, To: 1, MsgType: pb.MessageType_MsgAppendResponse, Term: r.Term, Index: li})
+	if r.RaftLog.committed != 0 {
+		t.Errorf("committed = %d, want 0", r.RaftLog.committed)
+	}
+	r.Step(r.selfAppendResponse(li))
+	if r.RaftLog.committed != li {
+		t.Errorf("committed = %d, want %d", r.RaftLog.committed, li)
+	}
+}
+
 // TestCommitWithoutNewTermEntry tests the entries could be committed
 // when leader changes with noop entry and no new proposal comes in.
 func TestCommitWithoutNewTermEntry2AB(t *testing.T) {
@@ -1096,6 +1120,27 @@ func TestRestoreFromSnapMsg2C(t *testing.T) {
 	}
 }
 
+func TestHandleSnapStatus2C(t *testing.T) {
+	tests := []struct {
+		m             pb.Message
+		wmatch, wnext uint64
+	}{
+		// applied, resume after the snapshot
+		{pb.Message{MsgType: pb.MessageType_MsgSnapStatus, From: 2, Index: 10}, 10, 11},
+		// failed, retry from match
+		{pb.Message{MsgType: pb.MessageType_MsgSnapStatus, From: 2, Index: 10, Reject: true}, 3, 4},
+		// stale report
+		{pb.Message{MsgType: pb.MessageType_MsgSnapStatus, From: 2, Index: 2}, 3, 11},
+	}
+	for i, tt := range tests {
+		r := &Raft{State: StateLeader, Prs: map[uint64]*Progress{2: {Match: 3, Next: 11}}}
+		r.handleSnapStatus(tt.m)
+		if pr := r.Prs[2]; pr.Match != tt.wmatch || pr.Next != tt.wnext {
+			t.Errorf("#%d: match, next = %d, %d, want %d, %d", i, pr.Match, pr.Next, tt.wmatch, tt.wnext)
+		}
+	}
+}
+
 func TestSlowNodeRestore2C(t *testing.T) {
 	nt := newNetwork(nil, nil, nil)
 	nt.send(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgHup})
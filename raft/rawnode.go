@@ -17,6 +17,7 @@ package raft
 import (
 	"errors"
 
+	"github.com/pingcap-incubator/tinykv/log"
 	pb "github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
 )
 
@@ -27,6 +28,17 @@ var ErrStepLocalMsg = errors.New("raft: cannot step raft local message")
 // but there is no peer found in raft.Prs for that node.
 var ErrStepPeerNotFound = errors.New("raft: cannot step as peer not found")
 
+// ErrReadyNotPersisted is the panic message when a Ready which must be synced
+// is advanced before the application reports it's persisted.
+var ErrReadyNotPersisted = errors.New("raft: advance a ready before its hard state and entries are persisted")
+
+type SnapshotStatus int
+
+const (
+	SnapshotFinish  SnapshotStatus = 1
+	SnapshotFailure SnapshotStatus = 2
+)
+
 // SoftState provides state that is volatile and does not need to be persisted to the WAL.
 type SoftState struct {
 	Lead      uint64
@@ -64,15 +76,47 @@ type Ready struct {
 	// If it contains a MessageType_MsgSnapshot message, the application MUST report back to raft
 	// when the snapshot has been received or has failed by calling ReportSnapshot.
 	Messages []pb.Message
+
+	// MustSync indicates whether the HardState and Entries must be synchronously
+	// written to disk before Messages are sent, see MustSync. For example, a
+	// vote must be persisted before the vote response is sent, otherwise the
+	// peer may vote twice in the same term after a crash.
+	MustSync bool
+
+	// ReadStates returns the read indexes of the requests of RawNode.ReadIndex.
+	// A read is linearizable once the entries up to its index are applied.
+	ReadStates []ReadState
+}
+
+// MustSync returns true if the hard state and count of Raft entries indicate
+// that a synchronous write to persistent storage is required.
+func MustSync(st, prevst pb.HardState, entsnum int) bool {
+	// Persistent state on all servers:
+	// (Updated on stable storage before responding to RPCs)
+	// currentTerm
+	// votedFor
+	// log entries[]
+	return entsnum != 0 || st.Vote != prevst.Vote || st.Term != prevst.Term
 }
 
 // RawNode is a wrapper of Raft.
 type RawNode struct {
 	Raft *Raft
 	// Your Data Here (2A).
+
+	// whether Advance checks that the ready is reported as persisted, see
+	// Config.RequirePersistAck
+	requirePersistAck bool
+	// whether the last ready is reported as persisted by ReportPersisted
+	persisted bool
+	// whether the committed entries are applied after the ready is advanced,
+	// see Config.AsyncApply
+	asyncApply bool
 }
 
 // NewRawNode returns a new RawNode given configuration and a list of raft peers.
+// NOTE: requirePersistAck should be set from Config.RequirePersistAck and
+// asyncApply from Config.AsyncApply.
 func NewRawNode(config *Config) (*RawNode, error) {
 	// Your Code Here (2A).
 	return nil, nil
@@ -112,20 +156,64 @@ func (rn *RawNode) ProposeConfChange(cc pb.ConfChange) error {
 	})
 }
 
+// ProposeMembershipChange proposes to change the voters to the nodes of cs at
+// once. The group enters a joint configuration of the current and the new
+// voters, in which the commits and the elections need a quorum of both, and
+// the leader proposes to leave it once the entering entry is applied.
+func (rn *RawNode) ProposeMembershipChange(cs pb.ConfState) error {
+	if rn.Raft.pendingMembershipChange != nil {
+		return ErrProposalDropped
+	}
+	return rn.ProposeConfChange(pb.ConfChange{
+		ChangeType:    pb.ConfChangeType_BeginMembershipChange,
+		Configuration: &cs,
+	})
+}
+
 // ApplyConfChange applies a config change to the local node.
 func (rn *RawNode) ApplyConfChange(cc pb.ConfChange) *pb.ConfState {
+	switch cc.ChangeType {
+	case pb.ConfChangeType_BeginMembershipChange:
+		if err := rn.Raft.beginMembershipChange(cc); err != nil {
+			panic(err)
+		}
+		if rn.Raft.State == StateLeader {
+			// The error is ignored, a new leader finalizes the change if the
+			// proposal is dropped.
+			_ = rn.ProposeConfChange(pb.ConfChange{ChangeType: pb.ConfChangeType_FinalizeMembershipChange})
+		}
+		return confState(rn.Raft)
+	case pb.ConfChangeType_FinalizeMembershipChange:
+		rn.Raft.finalizeMembershipChange()
+		return confState(rn.Raft)
+	}
 	if cc.NodeId == None {
-		return &pb.ConfState{Nodes: nodes(rn.Raft)}
+		return confState(rn.Raft)
 	}
 	switch cc.ChangeType {
 	case pb.ConfChangeType_AddNode:
 		rn.Raft.addNode(cc.NodeId)
 	case pb.ConfChangeType_RemoveNode:
 		rn.Raft.removeNode(cc.NodeId)
+	case pb.ConfChangeType_AddLearnerNode:
+		rn.Raft.addLearner(cc.NodeId)
+	case pb.ConfChangeType_AddWitnessNode:
+		rn.Raft.addWitness(cc.NodeId)
 	default:
 		panic("unexpected conf type")
 	}
-	return &pb.ConfState{Nodes: nodes(rn.Raft)}
+	return confState(rn.Raft)
+}
+
+// ReadIndex requests a read state, returned in the ReadStates of a later Ready
+// with the same rctx, which should identify the request. The read needs no
+// entry proposed to the log. The request is dropped, without any read state,
+// if the leader is unknown or hasn't committed an entry of its term yet.
+func (rn *RawNode) ReadIndex(rctx []byte) error {
+	return rn.Raft.Step(pb.Message{
+		MsgType: pb.MessageType_MsgReadIndex,
+		Entries: []*pb.Entry{{Data: rctx}},
+	})
 }
 
 // Step advances the state machine using the given message.
@@ -141,12 +229,19 @@ func (rn *RawNode) Step(m pb.Message) error {
 }
 
 // Ready returns the current point-in-time state of this RawNode.
+// NOTE: the ready returns the read states in rn.Raft.readStates. The
+// committed entries are rn.Raft.RaftLog.nextApplyingEnts, so the entries
+// handed out by AdvanceAppend aren't returned again while they're applied.
+// If rn.Raft.parallelAppend is set, the ready of a leader with entries also
+// carries rn.Raft.selfAppendResponse of its last entry in Messages.
 func (rn *RawNode) Ready() Ready {
 	// Your Code Here (2A).
 	return Ready{}
 }
 
 // HasReady called when RawNode user need to check if any Ready pending.
+// NOTE: pending read states need a ready too. Committed entries only need
+// one if they are not handed to the application yet.
 func (rn *RawNode) HasReady() bool {
 	// Your Code Here (2A).
 	return false
@@ -154,10 +249,75 @@ func (rn *RawNode) HasReady() bool {
 
 // Advance notifies the RawNode that the application has applied and saved progress in the
 // last Ready results.
+// If Config.RequirePersistAck is set, a ready with MustSync set must be
+// reported by ReportPersisted first, otherwise Advance panics.
 func (rn *RawNode) Advance(rd Ready) {
+	rn.checkPersisted(rd)
+	// NOTE: clear rn.Raft.readStates once they are returned. The committed
+	// entries are applied, advance rn.Raft.RaftLog with appliedTo.
 	// Your Code Here (2A).
 }
 
+// AdvanceAppend notifies the RawNode that the application has saved the
+// hard state, the entries and the snapshot of the last Ready and sent its
+// messages, but may not have applied its committed entries yet. They are
+// reported by AdvanceApply once applied, and not returned by later readies
+// meanwhile. Without Config.AsyncApply, use Advance instead.
+func (rn *RawNode) AdvanceAppend(rd Ready) {
+	if n := len(rd.CommittedEntries); n > 0 {
+		rn.Raft.RaftLog.applyingTo(rd.CommittedEntries[n-1].Index)
+		rd.CommittedEntries = nil
+	}
+	rn.Advance(rd)
+}
+
+// AdvanceApply notifies the RawNode that the application has applied the
+// committed entries up to the index, which were returned by the readies
+// advanced with AdvanceAppend. An index at or below the applied index is
+// ignored, the entries may have been overtaken by a snapshot meanwhile.
+func (rn *RawNode) AdvanceApply(applied uint64) {
+	if applied <= rn.Raft.RaftLog.applied {
+		return
+	}
+	if applied > rn.Raft.RaftLog.applying {
+		log.Panicf("applied(%d) is not handed to the application yet, applying(%d)", applied, rn.Raft.RaftLog.applying)
+	}
+	rn.Raft.RaftLog.appliedTo(applied)
+}
+
+// ReportPersisted reports that the HardState and Entries of the last Ready are
+// saved to stable storage, so its Messages can be sent and it can be advanced.
+func (rn *RawNode) ReportPersisted() {
+	rn.persisted = true
+}
+
+func (rn *RawNode) checkPersisted(rd Ready) {
+	persisted := rn.persisted
+	rn.persisted = false
+	if rn.requirePersistAck && rd.MustSync && !persisted {
+		panic(ErrReadyNotPersisted)
+	}
+}
+
+// ReportSnapshot reports the status of the snapshot sent to the peer id, e.g.
+// SnapshotFailure if the snapshot can't be sent.
+func (rn *RawNode) ReportSnapshot(id uint64, status SnapshotStatus) {
+	rej := status == SnapshotFailure
+	_ = rn.Raft.Step(pb.Message{MsgType: pb.MessageType_MsgSnapStatus, From: id, Reject: rej})
+}
+
+// Status returns the current status of the raft group, with the progresses
+// of the peers if this node is the leader.
+func (rn *RawNode) Status() Status {
+	return getStatus(rn.Raft)
+}
+
+// BasicStatus returns a BasicStatus. Notably this does not contain the
+// Progress map; see Status for that.
+func (rn *RawNode) BasicStatus() BasicStatus {
+	return getBasicStatus(rn.Raft)
+}
+
 // GetProgress return the the Progress of this node and its peers, if this
 // node is leader.
 func (rn *RawNode) GetProgress() map[uint64]Progress {
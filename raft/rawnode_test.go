@@ -250,3 +250,95 @@ func TestRawNodeRestartFromSnapshot2C(t *testing.T) {
 		t.Errorf("unexpected Ready: %+v", rawNode.HasReady())
 	}
 }
+
+func TestMustSync(t *testing.T) {
+	tests := []struct {
+		st, prevst pb.HardState
+		entsnum    int
+		wsync      bool
+	}{
+		{pb.HardState{}, pb.HardState{}, 0, false},
+		{pb.HardState{Term: 1, Vote: 1, Commit: 2}, pb.HardState{Term: 1, Vote: 1, Commit: 1}, 0, false},
+		{pb.HardState{Term: 1, Vote: 2}, pb.HardState{Term: 1, Vote: 1}, 0, true},
+		{pb.HardState{Term: 2, Vote: 1}, pb.HardState{Term: 1, Vote: 1}, 0, true},
+		{pb.HardState{Term: 1, Vote: 1}, pb.HardState{Term: 1, Vote: 1}, 1, true},
+	}
+	for i, tt := range tests {
+		if g := MustSync(tt.st, tt.prevst, tt.entsnum); g != tt.wsync {
+			t.Errorf("#%d: mustsync = %v, want %v", i, g, tt.wsync)
+		}
+	}
+}
+
+func TestRawNodeAdvanceRequirePersistAck(t *testing.T) {
+	rn := &RawNode{requirePersistAck: true}
+	rn.Advance(Ready{})
+	rn.ReportPersisted()
+	rn.Advance(Ready{MustSync: true})
+	defer func() {
+		if r := recover(); r != ErrReadyNotPersisted {
+			t.Errorf("recover = %v, want %v", r, ErrReadyNotPersisted)
+		}
+	}()
+	rn.Advance(Ready{MustSync: true})
+}
+
+func TestRawNodeAdvanceAppendApply(t *testing.T) {
+	storage := NewMemoryStorage()
+	ents := []pb.Entry{{Index: 1, Term: 1}, {Index: 2, Term: 1}, {Index: 3, Term: 1}}
+	if err := storage.Append(ents); err != nil {
+		t.Fatal(err)
+	}
+	rn := &RawNode{Raft: &Raft{RaftLog: &RaftLog{storage: storage, committed: 3}}, asyncApply: true}
+	l := rn.Raft.RaftLog
+
+	rn.AdvanceAppend(Ready{CommittedEntries: ents[:2]})
+	if l.applied != 0 || l.applying != 2 {
+		t.Errorf("applied, applying = %d, %d, want 0, 2", l.applied, l.applying)
+	}
+	if g := l.nextApplyingEnts(); !reflect.DeepEqual(g, ents[2:]) {
+		t.Errorf("ents = %v, want %v", g, ents[2:])
+	}
+	rn.AdvanceApply(2)
+	if l.applied != 2 || l.applying != 2 {
+		t.Errorf("applied, applying = %d, %d, want 2, 2", l.applied, l.applying)
+	}
+	// a stale index is ignored
+	rn.AdvanceApply(1)
+	if l.applied != 2 {
+		t.Errorf("applied = %d, want 2", l.applied)
+	}
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("expect panic applying the entries not handed out")
+		}
+	}()
+	rn.AdvanceApply(3)
+}
+
+func TestRawNodeApplyWitnessConfChange(t *testing.T) {
+	storage := NewMemoryStorage()
+	r := &Raft{id: 1, RaftLog: &RaftLog{storage: storage}, Prs: map[uint64]*Progress{1: {}}}
+	rn := &RawNode{Raft: r}
+
+	cs := rn.ApplyConfChange(pb.ConfChange{ChangeType: pb.ConfChangeType_AddWitnessNode, NodeId: 2})
+	wcs := &pb.ConfState{Nodes: []uint64{1, 2}, Witnesses: []uint64{2}}
+	if !reflect.DeepEqual(cs, wcs) {
+		t.Errorf("conf state = %v, want %v", cs, wcs)
+	}
+	if !r.Prs[2].IsWitness || r.Prs[2].IsLearner {
+		t.Errorf("progress = %+v, want a witness", r.Prs[2])
+	}
+	// a peer in the group isn't turned into a witness
+	rn.ApplyConfChange(pb.ConfChange{ChangeType: pb.ConfChangeType_AddWitnessNode, NodeId: 1})
+	if r.Prs[1].IsWitness {
+		t.Errorf("peer 1 is turned into a witness")
+	}
+	if !r.promotable() {
+		t.Errorf("peer 1 isn't promotable")
+	}
+	r.id = 2
+	if r.promotable() {
+		t.Errorf("witness 2 is promotable")
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raft
+
+import (
+	"testing"
+
+	pb "github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+)
+
+func readIndexMsg(ctx string) pb.Message {
+	return pb.Message{MsgType: pb.MessageType_MsgReadIndex, Entries: []*pb.Entry{{Data: []byte(ctx)}}}
+}
+
+func TestReadOnlyQueue(t *testing.T) {
+	ro := newReadOnly(ReadOnlySafe)
+	ro.addRequest(10, readIndexMsg("a"))
+	ro.addRequest(11, readIndexMsg("b"))
+	// a duplicated request is ignored
+	ro.addRequest(12, readIndexMsg("a"))
+	if ctx := string(ro.lastPendingRequestCtx()); ctx != "b" {
+		t.Fatalf("last pending ctx = %q, want %q", ctx, "b")
+	}
+
+	if acks := ro.recvAck(2, []byte("unknown")); acks != nil {
+		t.Fatalf("acks of unknown ctx = %v, want nil", acks)
+	}
+	ro.recvAck(1, []byte("b"))
+	if acks := ro.recvAck(2, []byte("b")); len(acks) != 2 {
+		t.Fatalf("len(acks) = %d, want 2", len(acks))
+	}
+
+	// advancing to a request returns it and the requests before it
+	rss := ro.advance(pb.Message{Context: []byte("b")})
+	if len(rss) != 2 || rss[0].index != 10 || rss[1].index != 11 {
+		t.Fatalf("advanced read states = %+v, want the reads at 10 and 11", rss)
+	}
+	if len(ro.pendingReadIndex) != 0 || len(ro.readIndexQueue) != 0 {
+		t.Fatalf("pending reads are left after advancing")
+	}
+	if ctx := ro.lastPendingRequestCtx(); ctx != nil {
+		t.Fatalf("last pending ctx = %q, want nil", ctx)
+	}
+	if rss := ro.advance(pb.Message{Context: []byte("c")}); rss != nil {
+		t.Fatalf("advanced read states of unknown ctx = %+v, want nil", rss)
+	}
+}
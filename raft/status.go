@@ -0,0 +1,133 @@
+// Copyright 2015 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raft
+
+import (
+	"encoding/json"
+	"fmt"
+
+	pb "github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+)
+
+// BasicStatus contains basic information about the Raft peer. It does not allocate.
+type BasicStatus struct {
+	ID uint64
+
+	pb.HardState
+	SoftState
+
+	Applied uint64
+
+	LeadTransferee uint64
+}
+
+// Status contains information about this Raft peer and its view of the system.
+// The Progress is only populated on the leader.
+type Status struct {
+	BasicStatus
+	Progress map[uint64]Progress
+}
+
+func getBasicStatus(r *Raft) BasicStatus {
+	return BasicStatus{
+		ID:             r.id,
+		HardState:      pb.HardState{Term: r.Term, Vote: r.Vote, Commit: r.RaftLog.committed},
+		SoftState:      SoftState{Lead: r.Lead, RaftState: r.State},
+		Applied:        r.RaftLog.applied,
+		LeadTransferee: r.leadTransferee,
+	}
+}
+
+// getStatus gets a copy of the current raft status.
+func getStatus(r *Raft) Status {
+	s := Status{BasicStatus: getBasicStatus(r)}
+	if s.RaftState == StateLeader {
+		s.Progress = make(map[uint64]Progress, len(r.Prs))
+		for id, pr := range r.Prs {
+			p := *pr
+			p.ins = pr.ins.clone()
+			s.Progress[id] = p
+		}
+	}
+	return s
+}
+
+// progressStatus is the JSON form of a Progress.
+type progressStatus struct {
+	Match           uint64 `json:"match"`
+	Next            uint64 `json:"next"`
+	State           string `json:"state"`
+	Paused          bool   `json:"paused"`
+	PendingSnapshot uint64 `json:"pendingSnapshot"`
+	RecentActive    bool   `json:"recentActive"`
+	IsLearner       bool   `json:"isLearner"`
+	IsWitness       bool   `json:"isWitness"`
+	// the number of the append messages in flight and the max of them, 0 if
+	// they are not limited
+	Inflights     int `json:"inflights"`
+	InflightsSize int `json:"inflightsSize"`
+}
+
+// MarshalJSON translates the raft status into JSON. The ids are in hex, like
+// in the logs.
+func (s Status) MarshalJSON() ([]byte, error) {
+	progress := make(map[string]progressStatus, len(s.Progress))
+	for id, pr := range s.Progress {
+		ps := progressStatus{
+			Match:           pr.Match,
+			Next:            pr.Next,
+			State:           pr.State.String(),
+			Paused:          pr.Paused,
+			PendingSnapshot: pr.PendingSnapshot,
+			RecentActive:    pr.RecentActive,
+			IsLearner:       pr.IsLearner,
+			IsWitness:       pr.IsWitness,
+			Inflights:       pr.ins.Count(),
+		}
+		if pr.ins != nil {
+			ps.InflightsSize = pr.ins.size
+		}
+		progress[fmt.Sprintf("%x", id)] = ps
+	}
+	return json.Marshal(struct {
+		ID             string                    `json:"id"`
+		Term           uint64                    `json:"term"`
+		Vote           string                    `json:"vote"`
+		Commit         uint64                    `json:"commit"`
+		Lead           string                    `json:"lead"`
+		RaftState      string                    `json:"raftState"`
+		Applied        uint64                    `json:"applied"`
+		LeadTransferee string                    `json:"leadTransferee"`
+		Progress       map[string]progressStatus `json:"progress"`
+	}{
+		ID:             fmt.Sprintf("%x", s.ID),
+		Term:           s.Term,
+		Vote:           fmt.Sprintf("%x", s.Vote),
+		Commit:         s.Commit,
+		Lead:           fmt.Sprintf("%x", s.Lead),
+		RaftState:      s.RaftState.String(),
+		Applied:        s.Applied,
+		LeadTransferee: fmt.Sprintf("%x", s.LeadTransferee),
+		Progress:       progress,
+	})
+}
+
+func (s Status) String() string {
+	b, err := s.MarshalJSON()
+	if err != nil {
+		return fmt.Sprintf("<failed to marshal the raft status: %v>", err)
+	}
+	return string(b)
+}
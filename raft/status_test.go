@@ -0,0 +1,49 @@
+package raft
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStatus(t *testing.T) {
+	ins := newInflights(4)
+	ins.add(7)
+	r := &Raft{
+		id:      1,
+		Term:    2,
+		Vote:    1,
+		Lead:    1,
+		State:   StateLeader,
+		RaftLog: &RaftLog{committed: 5, applied: 3},
+		Prs: map[uint64]*Progress{
+			1:  {Match: 7, Next: 8, State: ProgressStateReplicate, RecentActive: true},
+			10: {Match: 5, Next: 8, State: ProgressStateReplicate, RecentActive: true, ins: ins},
+			11: {Match: 0, Next: 1, State: ProgressStateSnapshot, PendingSnapshot: 6, IsLearner: true},
+		},
+	}
+	rn := &RawNode{Raft: r}
+	s := rn.Status()
+	// the status is a copy
+	ins.add(8)
+	if c := s.Progress[10].ins.Count(); c != 1 {
+		t.Errorf("inflights = %d, want 1", c)
+	}
+
+	w := `{"id":"1","term":2,"vote":"1","commit":5,"lead":"1","raftState":"StateLeader","applied":3,"leadTransferee":"0","progress":{` +
+		`"1":{"match":7,"next":8,"state":"ProgressStateReplicate","paused":false,"pendingSnapshot":0,"recentActive":true,"isLearner":false,"isWitness":false,"inflights":0,"inflightsSize":0},` +
+		`"a":{"match":5,"next":8,"state":"ProgressStateReplicate","paused":false,"pendingSnapshot":0,"recentActive":true,"isLearner":false,"isWitness":false,"inflights":1,"inflightsSize":4},` +
+		`"b":{"match":0,"next":1,"state":"ProgressStateSnapshot","paused":false,"pendingSnapshot":6,"recentActive":false,"isLearner":true,"isWitness":false,"inflights":0,"inflightsSize":0}}}`
+	if g := s.String(); g != w {
+		t.Errorf("status = %s, want %s", g, w)
+	}
+
+	// a follower has no progress
+	r.State, r.Lead = StateFollower, 2
+	s = rn.Status()
+	if s.Progress != nil {
+		t.Errorf("progress = %v, want nil", s.Progress)
+	}
+	if b := rn.BasicStatus(); !reflect.DeepEqual(b, s.BasicStatus) {
+		t.Errorf("basic status = %v, want %v", b, s.BasicStatus)
+	}
+}
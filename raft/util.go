@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math"
 	"os"
 	"os/exec"
 	"sort"
@@ -40,6 +41,63 @@ func max(a, b uint64) uint64 {
 	return b
 }
 
+// noLimit is the size limit which never truncates anything.
+const noLimit = math.MaxUint64
+
+// limitSize returns the longest prefix of ents whose total byte size does not
+// exceed maxSize. The first entry is always returned even if it alone exceeds
+// maxSize, so that the log can make progress with a large entry.
+func limitSize(ents []pb.Entry, maxSize uint64) []pb.Entry {
+	if len(ents) == 0 {
+		return ents
+	}
+	size := ents[0].Size()
+	var limit int
+	for limit = 1; limit < len(ents); limit++ {
+		size += ents[limit].Size()
+		if uint64(size) > maxSize {
+			break
+		}
+	}
+	return ents[:limit]
+}
+
+// payloadSize is the size of the payload of the provided entry.
+func payloadSize(e *pb.Entry) uint64 {
+	return uint64(len(e.Data))
+}
+
+// entryPtrs returns pointers to the entries of ents for the Entries of a
+// message, without copying them. The entries may be a slice of
+// RaftLog.entries, so the log must never overwrite its entries in place, e.g.
+// when the conflicting entries are truncated, but append the new entries to a
+// copy of the kept ones, as the entries may still be referenced by the
+// messages not sent yet.
+func entryPtrs(ents []pb.Entry) []*pb.Entry {
+	if len(ents) == 0 {
+		return nil
+	}
+	ptrs := make([]*pb.Entry, len(ents))
+	for i := range ents {
+		ptrs[i] = &ents[i]
+	}
+	return ptrs
+}
+
+// appendEntries appends the entries of a message to ents, growing ents at
+// most once.
+func appendEntries(ents []pb.Entry, ptrs []*pb.Entry) []pb.Entry {
+	if n := len(ents) + len(ptrs); n > cap(ents) {
+		grown := make([]pb.Entry, len(ents), n)
+		copy(grown, ents)
+		ents = grown
+	}
+	for _, e := range ptrs {
+		ents = append(ents, *e)
+	}
+	return ents
+}
+
 // IsEmptyHardState returns true if the given HardState is empty.
 func IsEmptyHardState(st pb.HardState) bool {
 	return isHardStateEqual(st, pb.HardState{})
@@ -60,15 +118,47 @@ func mustTerm(term uint64, err error) uint64 {
 	return term
 }
 
+// nodes returns the voters of r, the learners excluded.
 func nodes(r *Raft) []uint64 {
 	nodes := make([]uint64, 0, len(r.Prs))
-	for id := range r.Prs {
-		nodes = append(nodes, id)
+	for id, pr := range r.Prs {
+		if !pr.IsLearner {
+			nodes = append(nodes, id)
+		}
 	}
 	sort.Sort(uint64Slice(nodes))
 	return nodes
 }
 
+// confState returns the voters, the learners and the witnesses of r.
+func confState(r *Raft) *pb.ConfState {
+	return &pb.ConfState{Nodes: nodes(r), Learners: learners(r), Witnesses: witnesses(r)}
+}
+
+// learners returns the learners of r.
+func learners(r *Raft) []uint64 {
+	var learners []uint64
+	for id, pr := range r.Prs {
+		if pr.IsLearner {
+			learners = append(learners, id)
+		}
+	}
+	sort.Sort(uint64Slice(learners))
+	return learners
+}
+
+// witnesses returns the witnesses of r.
+func witnesses(r *Raft) []uint64 {
+	var witnesses []uint64
+	for id, pr := range r.Prs {
+		if pr.IsWitness {
+			witnesses = append(witnesses, id)
+		}
+	}
+	sort.Sort(uint64Slice(witnesses))
+	return witnesses
+}
+
 func diffu(a, b string) string {
 	if a == b {
 		return ""
@@ -121,7 +211,15 @@ func IsLocalMsg(msgt pb.MessageType) bool {
 }
 
 func IsResponseMsg(msgt pb.MessageType) bool {
-	return msgt == pb.MessageType_MsgAppendResponse || msgt == pb.MessageType_MsgRequestVoteResponse || msgt == pb.MessageType_MsgHeartbeatResponse
+	return msgt == pb.MessageType_MsgAppendResponse || msgt == pb.MessageType_MsgRequestVoteResponse ||
+		msgt == pb.MessageType_MsgHeartbeatResponse || msgt == pb.MessageType_MsgRequestPreVoteResponse
+}
+
+// IsSelfAppendResponse tells whether m is the append response a leader with
+// Config.ParallelAppend sends to itself, which the application steps once the
+// entries of the ready are persisted rather than sending it.
+func IsSelfAppendResponse(m pb.Message) bool {
+	return m.MsgType == pb.MessageType_MsgAppendResponse && m.To == m.From
 }
 
 func isHardStateEqual(a, b pb.HardState) bool {
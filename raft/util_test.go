@@ -0,0 +1,151 @@
+// Copyright 2015 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raft
+
+import (
+	"reflect"
+	"testing"
+
+	pb "github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+)
+
+func TestLimitSize(t *testing.T) {
+	ents := []pb.Entry{{Index: 4, Term: 4}, {Index: 5, Term: 5}, {Index: 6, Term: 6}}
+	tests := []struct {
+		maxsize  uint64
+		wentries []pb.Entry
+	}{
+		{noLimit, []pb.Entry{{Index: 4, Term: 4}, {Index: 5, Term: 5}, {Index: 6, Term: 6}}},
+		// even if maxsize is zero, the first entry should be returned
+		{0, []pb.Entry{{Index: 4, Term: 4}}},
+		// limit to 2
+		{uint64(ents[0].Size() + ents[1].Size()), []pb.Entry{{Index: 4, Term: 4}, {Index: 5, Term: 5}}},
+		// limit to 2
+		{uint64(ents[0].Size() + ents[1].Size() + ents[2].Size()/2), []pb.Entry{{Index: 4, Term: 4}, {Index: 5, Term: 5}}},
+		{uint64(ents[0].Size() + ents[1].Size() + ents[2].Size() - 1), []pb.Entry{{Index: 4, Term: 4}, {Index: 5, Term: 5}}},
+		// all
+		{uint64(ents[0].Size() + ents[1].Size() + ents[2].Size()), []pb.Entry{{Index: 4, Term: 4}, {Index: 5, Term: 5}, {Index: 6, Term: 6}}},
+	}
+
+	for i, tt := range tests {
+		if g := limitSize(ents, tt.maxsize); !reflect.DeepEqual(g, tt.wentries) {
+			t.Errorf("#%d: entries = %v, want %v", i, g, tt.wentries)
+		}
+	}
+}
+
+func TestUncommittedSize(t *testing.T) {
+	r := &Raft{maxUncommittedSize: 10}
+	ent := func(size int) *pb.Entry { return &pb.Entry{Data: make([]byte, size)} }
+
+	// an empty tail accepts any size
+	if !r.increaseUncommittedSize([]*pb.Entry{ent(20)}) {
+		t.Fatalf("proposal on empty tail dropped")
+	}
+	if r.increaseUncommittedSize([]*pb.Entry{ent(1)}) {
+		t.Fatalf("proposal over limit accepted")
+	}
+	// empty entries are never refused
+	if !r.increaseUncommittedSize([]*pb.Entry{ent(0)}) {
+		t.Fatalf("empty proposal dropped")
+	}
+	if r.uncommittedSize != 20 {
+		t.Fatalf("uncommittedSize = %d, want 20", r.uncommittedSize)
+	}
+	r.reduceUncommittedSize([]pb.Entry{{Data: make([]byte, 15)}})
+	if !r.increaseUncommittedSize([]*pb.Entry{ent(5)}) {
+		t.Fatalf("proposal under limit dropped")
+	}
+	// saturate at 0
+	r.reduceUncommittedSize([]pb.Entry{{Data: make([]byte, 100)}})
+	if r.uncommittedSize != 0 {
+		t.Fatalf("uncommittedSize = %d, want 0", r.uncommittedSize)
+	}
+}
+
+func TestEntryPtrs(t *testing.T) {
+	ents := []pb.Entry{{Index: 4, Term: 4}, {Index: 5, Term: 5}}
+	ptrs := entryPtrs(ents)
+	if len(ptrs) != 2 || ptrs[0] != &ents[0] || ptrs[1] != &ents[1] {
+		t.Fatalf("ptrs = %v, want pointers to %v", ptrs, ents)
+	}
+	if entryPtrs(nil) != nil {
+		t.Errorf("entryPtrs(nil) != nil")
+	}
+
+	got := appendEntries([]pb.Entry{{Index: 3, Term: 3}}, ptrs)
+	want := []pb.Entry{{Index: 3, Term: 3}, {Index: 4, Term: 4}, {Index: 5, Term: 5}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("appendEntries = %v, want %v", got, want)
+	}
+	// the appended entries are copies
+	ents[0].Term = 6
+	if got[1].Term != 4 {
+		t.Errorf("term = %d, want 4", got[1].Term)
+	}
+}
+
+func benchmarkEntries(n int) []pb.Entry {
+	ents := make([]pb.Entry, n)
+	for i := range ents {
+		ents[i] = pb.Entry{Index: uint64(i + 1), Term: 1, Data: make([]byte, 256)}
+	}
+	return ents
+}
+
+// BenchmarkAppendEntriesCopy copies the entries into the message and back
+// one by one.
+func BenchmarkAppendEntriesCopy(b *testing.B) {
+	ents := benchmarkEntries(64)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var ptrs []*pb.Entry
+		for _, e := range ents {
+			e := e
+			ptrs = append(ptrs, &e)
+		}
+		var appended []pb.Entry
+		for _, e := range ptrs {
+			appended = append(appended, *e)
+		}
+	}
+}
+
+func BenchmarkAppendEntries(b *testing.B) {
+	ents := benchmarkEntries(64)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		appendEntries(nil, entryPtrs(ents))
+	}
+}
+
+func TestSelfAppendResponse(t *testing.T) {
+	r := &Raft{id: 1, Term: 3}
+	m := r.selfAppendResponse(7)
+	wm := pb.Message{MsgType: pb.MessageType_MsgAppendResponse, To: 1, From: 1, Term: 3, Index: 7}
+	if !reflect.DeepEqual(m, wm) {
+		t.Errorf("msg = %v, want %v", m, wm)
+	}
+	if !IsSelfAppendResponse(m) {
+		t.Errorf("%v isn't a self append response", m)
+	}
+	m.To = 2
+	if IsSelfAppendResponse(m) {
+		t.Errorf("%v is a self append response", m)
+	}
+	if IsSelfAppendResponse(pb.Message{MsgType: pb.MessageType_MsgHeartbeatResponse, To: 1, From: 1}) {
+		t.Errorf("heartbeat response is a self append response")
+	}
+}
@@ -26,8 +26,12 @@ import (
 	"github.com/pingcap-incubator/tinykv/scheduler/pkg/mock/mockid"
 	"github.com/pingcap-incubator/tinykv/scheduler/pkg/testutil"
 	"github.com/pingcap-incubator/tinykv/scheduler/server"
+	"github.com/pingcap-incubator/tinykv/scheduler/server/config"
 	"github.com/pingcap-incubator/tinykv/scheduler/server/core"
 	. "github.com/pingcap/check"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 func TestClient(t *testing.T) {
@@ -418,3 +422,44 @@ func (s *testClientSuite) TestUpdateGCSafePoint(c *C) {
 	c.Assert(err, IsNil)
 	s.checkGCSafePoint(c, math.MaxUint64)
 }
+
+var _ = Suite(&testAuthClientSuite{})
+
+type testAuthClientSuite struct{}
+
+func (s *testAuthClientSuite) TestToken(c *C) {
+	cfg := server.NewTestSingleConfig(c)
+	cfg.Security.Roles = []config.AuthRole{
+		{Name: "admin", Token: "admin-token", Role: config.RoleAdmin},
+		{Name: "client", Token: "client-token", Role: config.RoleOperator},
+	}
+	srv, err := server.CreateServer(cfg)
+	c.Assert(err, IsNil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		srv.Close()
+		testutil.CleanServer(cfg)
+	}()
+	c.Assert(srv.Run(ctx), IsNil)
+	mustWaitLeader(c, map[string]*server.Server{srv.GetAddr(): srv})
+
+	grpcClient := testutil.MustNewGrpcClient(c, srv.GetAddr())
+	req := &schedulerpb.BootstrapRequest{Header: newHeader(srv), Store: stores[0]}
+	_, err = grpcClient.Bootstrap(context.Background(), req)
+	c.Assert(status.Code(err), Equals, codes.Unauthenticated)
+	adminCtx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer admin-token")
+	_, err = grpcClient.Bootstrap(adminCtx, req)
+	c.Assert(err, IsNil)
+
+	// the calls of the client, including the TSO stream, are authenticated by its token
+	client, err := NewClient(srv.GetEndpoints(), SecurityOption{Token: "client-token"})
+	c.Assert(err, IsNil)
+	defer client.Close()
+	physical, _, err := client.GetTS(context.Background())
+	c.Assert(err, IsNil)
+	c.Assert(physical, Greater, int64(0))
+	store, err := client.GetStore(context.Background(), stores[0].GetId())
+	c.Assert(err, IsNil)
+	c.Assert(store, DeepEquals, stores[0])
+}
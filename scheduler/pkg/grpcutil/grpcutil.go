@@ -24,8 +24,8 @@ import (
 	"google.golang.org/grpc/credentials"
 )
 
-// GetClientConn returns a gRPC client connection.
-func GetClientConn(addr string, caPath string, certPath string, keyPath string) (*grpc.ClientConn, error) {
+// GetClientConn returns a gRPC client connection, dialed with the extra options.
+func GetClientConn(addr string, caPath string, certPath string, keyPath string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
 	opt := grpc.WithInsecure()
 	if len(caPath) != 0 {
 		var certificates []tls.Certificate
@@ -61,7 +61,7 @@ func GetClientConn(addr string, caPath string, certPath string, keyPath string)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	cc, err := grpc.Dial(u.Host, opt)
+	cc, err := grpc.Dial(u.Host, append(opts, opt)...)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
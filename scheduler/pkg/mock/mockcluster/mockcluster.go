@@ -133,6 +133,18 @@ func (mc *Cluster) SetStoreBusy(storeID uint64, busy bool) {
 	mc.PutStore(newStore)
 }
 
+// SetStoreCompacting sets store busy with a heavy compaction.
+func (mc *Cluster) SetStoreCompacting(storeID uint64, compacting bool) {
+	store := mc.GetStore(storeID)
+	newStats := proto.Clone(store.GetStoreStats()).(*schedulerpb.StoreStats)
+	newStats.IsCompacting = compacting
+	newStore := store.Clone(
+		core.SetStoreStats(newStats),
+		core.SetLastHeartbeatTS(time.Now()),
+	)
+	mc.PutStore(newStore)
+}
+
 // AddLeaderStore adds store with specified count of leader.
 func (mc *Cluster) AddLeaderStore(storeID uint64, leaderCount int, leaderSizes ...int64) {
 	stats := &schedulerpb.StoreStats{}
@@ -254,6 +266,16 @@ func (mc *Cluster) UpdateSnapshotCount(storeID uint64, snapshotCount int) {
 	mc.PutStore(newStore)
 }
 
+// UpdateSnapshotBytes updates the size of the snapshots a store is sending and receiving.
+func (mc *Cluster) UpdateSnapshotBytes(storeID uint64, sendingBytes, receivingBytes uint64) {
+	store := mc.GetStore(storeID)
+	newStats := proto.Clone(store.GetStoreStats()).(*schedulerpb.StoreStats)
+	newStats.SendingSnapBytes = sendingBytes
+	newStats.ReceivingSnapBytes = receivingBytes
+	newStore := store.Clone(core.SetStoreStats(newStats))
+	mc.PutStore(newStore)
+}
+
 // UpdatePendingPeerCount updates store pending peer count.
 func (mc *Cluster) UpdatePendingPeerCount(storeID uint64, pendingPeerCount int) {
 	store := mc.GetStore(storeID)
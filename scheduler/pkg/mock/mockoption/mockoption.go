@@ -27,6 +27,8 @@ const (
 	defaultLeaderScheduleLimit  = 4
 	defaultRegionScheduleLimit  = 64
 	defaultReplicaScheduleLimit = 64
+	defaultSplitMergeInterval   = 0
+	defaultMergeEmptyHeartbeats = 1
 )
 
 // ScheduleOptions is a mock of ScheduleOptions
@@ -39,8 +41,16 @@ type ScheduleOptions struct {
 	MaxPendingPeerCount  uint64
 	MaxMergeRegionSize   uint64
 	MaxMergeRegionKeys   uint64
+	SplitMergeInterval   time.Duration
+	MergeEmptyHeartbeats uint64
 	MaxStoreDownTime     time.Duration
 	MaxReplicas          int
+	// snapshot budgets in MB
+	StoreSnapshotBudget   uint64
+	ClusterSnapshotBudget uint64
+	SeededPeerProtectTime time.Duration
+	EnableRaftLearner     bool
+	EnableWitness         bool
 }
 
 // NewScheduleOptions creates a mock schedule option.
@@ -52,6 +62,8 @@ func NewScheduleOptions() *ScheduleOptions {
 	mso.MaxSnapshotCount = defaultMaxSnapshotCount
 	mso.MaxMergeRegionSize = defaultMaxMergeRegionSize
 	mso.MaxMergeRegionKeys = defaultMaxMergeRegionKeys
+	mso.SplitMergeInterval = defaultSplitMergeInterval
+	mso.MergeEmptyHeartbeats = defaultMergeEmptyHeartbeats
 	mso.MaxStoreDownTime = defaultMaxStoreDownTime
 	mso.MaxReplicas = defaultMaxReplicas
 	mso.MaxPendingPeerCount = defaultMaxPendingPeerCount
@@ -83,11 +95,46 @@ func (mso *ScheduleOptions) GetMaxMergeRegionKeys() uint64 {
 	return mso.MaxMergeRegionKeys
 }
 
+// GetSplitMergeInterval mocks method
+func (mso *ScheduleOptions) GetSplitMergeInterval() time.Duration {
+	return mso.SplitMergeInterval
+}
+
+// GetMergeEmptyRegionHeartbeats mocks method
+func (mso *ScheduleOptions) GetMergeEmptyRegionHeartbeats() uint64 {
+	return mso.MergeEmptyHeartbeats
+}
+
 // GetMaxStoreDownTime mocks method
 func (mso *ScheduleOptions) GetMaxStoreDownTime() time.Duration {
 	return mso.MaxStoreDownTime
 }
 
+// GetStoreSnapshotBudget mocks method
+func (mso *ScheduleOptions) GetStoreSnapshotBudget() uint64 {
+	return mso.StoreSnapshotBudget
+}
+
+// GetClusterSnapshotBudget mocks method
+func (mso *ScheduleOptions) GetClusterSnapshotBudget() uint64 {
+	return mso.ClusterSnapshotBudget
+}
+
+// GetSeededPeerProtectTime mocks method
+func (mso *ScheduleOptions) GetSeededPeerProtectTime() time.Duration {
+	return mso.SeededPeerProtectTime
+}
+
+// IsRaftLearnerEnabled mocks method
+func (mso *ScheduleOptions) IsRaftLearnerEnabled() bool {
+	return mso.EnableRaftLearner
+}
+
+// IsWitnessEnabled mocks method
+func (mso *ScheduleOptions) IsWitnessEnabled() bool {
+	return mso.EnableWitness
+}
+
 // GetMaxReplicas mocks method
 func (mso *ScheduleOptions) GetMaxReplicas() int {
 	return mso.MaxReplicas
@@ -27,6 +27,15 @@ func CheckAddPeer(c *check.C, op *operator.Operator, kind operator.OpKind, store
 	c.Assert(op.Kind()&kind, check.Equals, kind)
 }
 
+// CheckAddWitness checks if the operator is to add a witness on specified store.
+func CheckAddWitness(c *check.C, op *operator.Operator, kind operator.OpKind, storeID uint64) {
+	c.Assert(op, check.NotNil)
+	c.Assert(op.Len(), check.Equals, 1)
+	c.Assert(op.Step(0).(operator.AddWitness).ToStore, check.Equals, storeID)
+	kind |= operator.OpRegion
+	c.Assert(op.Kind()&kind, check.Equals, kind)
+}
+
 // CheckRemovePeer checks if the operator is to remove peer on specified store.
 func CheckRemovePeer(c *check.C, op *operator.Operator, storeID uint64) {
 	if op.Len() == 1 {
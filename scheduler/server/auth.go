@@ -0,0 +1,206 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pingcap-incubator/tinykv/scheduler/server/config"
+	"github.com/pingcap/log"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// methodAccess is the role an API requires, whether the stores call it, and whether its calls are logged in the audit
+// log.
+type methodAccess struct {
+	role  string
+	store bool
+	audit bool
+}
+
+// methodAccesses are the roles of the gRPC methods and the HTTP APIs by name, an API which isn't listed requires the
+// admin role. The APIs the stores call may be called by the store role too, which may call no other API.
+var methodAccesses = map[string]methodAccess{
+	"GetMembers":       {role: config.RoleReader, store: true},
+	"IsBootstrapped":   {role: config.RoleReader, store: true},
+	"GetStore":         {role: config.RoleReader, store: true},
+	"GetAllStores":     {role: config.RoleReader},
+	"GetRegion":        {role: config.RoleReader, store: true},
+	"GetPrevRegion":    {role: config.RoleReader},
+	"GetRegionByID":    {role: config.RoleReader, store: true},
+	"ScanRegions":      {role: config.RoleReader},
+	"GetClusterConfig": {role: config.RoleReader},
+	"GetGCSafePoint":   {role: config.RoleReader},
+	"GetOperator":      {role: config.RoleReader},
+	StoreAdvicePath:    {role: config.RoleReader},
+	RegionRangesPath:   {role: config.RoleReader},
+
+	"Tso":               {role: config.RoleOperator},
+	"AllocID":           {role: config.RoleOperator, store: true},
+	"ScatterRegion":     {role: config.RoleOperator, audit: true},
+	"UpdateGCSafePoint": {role: config.RoleOperator, audit: true},
+	"PauseScheduling":   {role: config.RoleOperator, audit: true},
+
+	// the stores report their state and the state of their regions, which only they know
+	"StoreHeartbeat":   {role: config.RoleAdmin, store: true},
+	"RegionHeartbeat":  {role: config.RoleAdmin, store: true},
+	"AskSplit":         {role: config.RoleAdmin, store: true},
+	"Bootstrap":        {role: config.RoleAdmin, store: true, audit: true},
+	"PutStore":         {role: config.RoleAdmin, store: true, audit: true},
+	"PutClusterConfig": {role: config.RoleAdmin, audit: true},
+}
+
+var roleLevels = map[string]int{
+	config.RoleReader:   1,
+	config.RoleOperator: 2,
+	config.RoleAdmin:    3,
+}
+
+// allows returns true if the role may call the API.
+func (a methodAccess) allows(role string) bool {
+	if role == config.RoleStore {
+		return a.store
+	}
+	return roleLevels[role] >= roleLevels[a.role]
+}
+
+// anonymous is the client of the calls when the APIs aren't authenticated.
+var anonymous = config.AuthRole{Name: "anonymous", Role: config.RoleAdmin}
+
+// authenticator authenticates the API clients by their tokens or the common names of their client certificates,
+// see config.SecurityConfig.Roles.
+type authenticator struct {
+	tokens []config.AuthRole
+	certs  map[string]config.AuthRole
+}
+
+// newAuthenticator returns nil if no role is granted, the APIs aren't authenticated then.
+func newAuthenticator(roles []config.AuthRole) *authenticator {
+	if len(roles) == 0 {
+		return nil
+	}
+	a := &authenticator{certs: make(map[string]config.AuthRole)}
+	for _, r := range roles {
+		if r.Token != "" {
+			a.tokens = append(a.tokens, r)
+		} else {
+			a.certs[r.Name] = r
+		}
+	}
+	return a
+}
+
+// client returns the client presenting the bearer token or the TLS connection state, it returns false if neither
+// authenticates a client.
+func (a *authenticator) client(authorization string, state *tls.ConnectionState) (config.AuthRole, bool) {
+	if token := strings.TrimPrefix(authorization, "Bearer "); token != authorization {
+		for _, r := range a.tokens {
+			if subtle.ConstantTimeCompare([]byte(token), []byte(r.Token)) == 1 {
+				return r, true
+			}
+		}
+		return config.AuthRole{}, false
+	}
+	if state != nil {
+		for _, chain := range state.VerifiedChains {
+			if len(chain) == 0 {
+				continue
+			}
+			if r, ok := a.certs[chain[0].Subject.CommonName]; ok {
+				return r, true
+			}
+		}
+	}
+	return config.AuthRole{}, false
+}
+
+// authorize checks the client of the gRPC call may call the method, and logs the call in the audit log if the method
+// is privileged.
+func (s *Server) authorize(ctx context.Context, method string, request fmt.Stringer) error {
+	var authorization, addr string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) > 0 {
+			authorization = values[0]
+		}
+	}
+	var state *tls.ConnectionState
+	if p, ok := peer.FromContext(ctx); ok {
+		addr = p.Addr.String()
+		if info, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			state = &info.State
+		}
+	}
+	code, err := s.checkAccess(method, authorization, state, addr, request)
+	if err != nil {
+		return status.Error(code, err.Error())
+	}
+	return nil
+}
+
+// authorizeHandler serves the HTTP requests of the clients which may call the API.
+func (s *Server) authorizeHandler(path string, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := s.checkAccess(path, r.Header.Get("Authorization"), r.TLS, r.RemoteAddr, nil)
+		if err == errUnauthenticated {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		} else if err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+var errUnauthenticated = errors.New("missing or invalid credentials")
+
+func (s *Server) checkAccess(method, authorization string, state *tls.ConnectionState, addr string, request fmt.Stringer) (codes.Code, error) {
+	access, ok := methodAccesses[method]
+	if !ok {
+		access = methodAccess{role: config.RoleAdmin, audit: true}
+	}
+	client := anonymous
+	if s.auth != nil {
+		if client, ok = s.auth.client(authorization, state); !ok {
+			log.Warn("unauthenticated API call", zap.String("method", method), zap.String("addr", addr))
+			return codes.Unauthenticated, errUnauthenticated
+		}
+		if !access.allows(client.Role) {
+			log.Warn("API call denied", zap.String("method", method), zap.String("client", client.Name),
+				zap.String("role", client.Role), zap.String("addr", addr))
+			return codes.PermissionDenied, errors.Errorf("%s requires the %s role, %s is granted %s",
+				method, access.role, client.Name, client.Role)
+		}
+	}
+	if access.audit {
+		fields := []zap.Field{zap.String("method", method), zap.String("client", client.Name),
+			zap.String("role", client.Role), zap.String("addr", addr)}
+		if request != nil {
+			fields = append(fields, zap.Stringer("request", request))
+		}
+		log.Info("audit", fields...)
+	}
+	return codes.OK, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/schedulerpb"
+	"github.com/pingcap-incubator/tinykv/scheduler/server/config"
+	. "github.com/pingcap/check"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var _ = Suite(&testAuthSuite{})
+
+type testAuthSuite struct{}
+
+func (s *testAuthSuite) TestAuthorize(c *C) {
+	server := &Server{}
+	// the APIs aren't authenticated without any role granted
+	c.Assert(server.authorize(context.Background(), "PutStore", &schedulerpb.PutStoreRequest{}), IsNil)
+
+	server.auth = newAuthenticator([]config.AuthRole{
+		{Name: "dashboard", Token: "t1", Role: config.RoleReader},
+		{Name: "admin", Token: "t2", Role: config.RoleAdmin},
+		{Name: "ctl", Role: config.RoleOperator},
+		{Name: "store", Token: "t4", Role: config.RoleStore},
+	})
+	code := func(token, method string) codes.Code {
+		ctx := context.Background()
+		if token != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+token))
+		}
+		return status.Code(server.authorize(ctx, method, nil))
+	}
+	c.Assert(code("", "GetRegion"), Equals, codes.Unauthenticated)
+	c.Assert(code("t3", "GetRegion"), Equals, codes.Unauthenticated)
+	c.Assert(code("t1", "GetRegion"), Equals, codes.OK)
+	c.Assert(code("t1", "PauseScheduling"), Equals, codes.PermissionDenied)
+	c.Assert(code("t2", "PutStore"), Equals, codes.OK)
+	c.Assert(code("t2", "PauseScheduling"), Equals, codes.OK)
+	// a method which isn't listed requires the admin role
+	c.Assert(code("t1", "Unknown"), Equals, codes.PermissionDenied)
+	c.Assert(code("t2", "Unknown"), Equals, codes.OK)
+	// the store role may only call the APIs of the stores
+	c.Assert(code("t4", "PutStore"), Equals, codes.OK)
+	c.Assert(code("t4", "RegionHeartbeat"), Equals, codes.OK)
+	c.Assert(code("t4", "GetRegion"), Equals, codes.OK)
+	c.Assert(code("t4", "ScanRegions"), Equals, codes.PermissionDenied)
+	c.Assert(code("t4", "PauseScheduling"), Equals, codes.PermissionDenied)
+	c.Assert(code("t4", "Unknown"), Equals, codes.PermissionDenied)
+
+	// a client without a token is authenticated by its certificate
+	cert := func(name string) *tls.ConnectionState {
+		return &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: name}}}}}
+	}
+	_, err := server.checkAccess("PauseScheduling", "", cert("ctl"), "", nil)
+	c.Assert(err, IsNil)
+	_, err = server.checkAccess("PutStore", "", cert("ctl"), "", nil)
+	c.Assert(err, NotNil)
+	_, err = server.checkAccess("StoreHeartbeat", "", cert("ctl"), "", nil)
+	c.Assert(err, NotNil)
+	_, err = server.checkAccess("GetRegion", "", cert("dashboard"), "", nil)
+	c.Assert(err, Equals, errUnauthenticated)
+
+	handler := server.authorizeHandler(StoreAdvicePath, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	serve := func(token string) int {
+		r := httptest.NewRequest(http.MethodGet, StoreAdvicePath, nil)
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Code
+	}
+	c.Assert(serve(""), Equals, http.StatusUnauthorized)
+	c.Assert(serve("t1"), Equals, http.StatusOK)
+}
@@ -68,6 +68,9 @@ type RaftCluster struct {
 	prepareChecker *prepareChecker
 
 	coordinator *coordinator
+	advisor     *storeAdvisor
+	// checks the region ranges for gaps and overlaps
+	rangeChecker *rangeChecker
 
 	wg   sync.WaitGroup
 	quit chan struct{}
@@ -135,6 +138,8 @@ func (c *RaftCluster) initCluster(id id.Allocator, opt *config.ScheduleOption, s
 	c.storage = storage
 	c.id = id
 	c.prepareChecker = newPrepareChecker()
+	c.advisor = newStoreAdvisor()
+	c.rangeChecker = newRangeChecker()
 }
 
 func (c *RaftCluster) start() error {
@@ -202,6 +207,7 @@ func (c *RaftCluster) runBackgroundJobs(interval time.Duration) {
 			return
 		case <-ticker.C:
 			c.checkStores()
+			c.checkRegionRanges()
 		}
 	}
 }
@@ -271,13 +277,36 @@ func (c *RaftCluster) handleStoreHeartbeat(stats *schedulerpb.StoreStats) error
 	if store == nil {
 		return core.NewStoreNotFoundErr(storeID)
 	}
+	if offset := time.Duration(stats.GetClockOffset()); offset > c.opt.GetMaxStoreClockSkew() || -offset > c.opt.GetMaxStoreClockSkew() {
+		log.Warn("store clock skew exceeds the max clock skew",
+			zap.Uint64("store-id", storeID),
+			zap.Duration("offset", offset))
+	}
+	if transfers := stats.GetSlowLeaderTransfers(); transfers > 0 {
+		log.Warn("store transferred leaders away for slow writes",
+			zap.Uint64("store-id", storeID),
+			zap.Uint32("transfers", transfers))
+	}
 	newStore := store.Clone(core.SetStoreStats(stats), core.SetLastHeartbeatTS(time.Now()))
 	c.core.PutStore(newStore)
+	c.advisor.update(c.core.GetStores(), c.opt.GetStoreBalanceWarningRatio(), c.opt.GetStoreRegionCountSoftLimit())
 	return nil
 }
 
+// takeStalePeers returns the stale peers found on the store since its last heartbeat.
+func (c *RaftCluster) takeStalePeers(storeID uint64) []*schedulerpb.StalePeer {
+	return c.rangeChecker.takeStalePeers(storeID)
+}
+
+// GetStoreAdvice returns the analysis of the store loads made on the last store heartbeat.
+func (c *RaftCluster) GetStoreAdvice() *ClusterAdvice {
+	return c.advisor.get()
+}
+
 // processRegionHeartbeat updates the region information.
 func (c *RaftCluster) processRegionHeartbeat(region *core.RegionInfo) error {
+	// NOTE: a snapshot applied by a peer is reported only once, keep the peers seeded before with
+	// region.InheritSeededPeers(origin) when the origin region is replaced.
 	// Your Code Here (3C).
 
 	return nil
@@ -362,6 +391,11 @@ func (c *RaftCluster) ScanRegions(startKey, endKey []byte, limit int) []*core.Re
 	return c.core.ScanRange(startKey, endKey, limit)
 }
 
+// GetAdjacentRegions returns the regions right before and after region.
+func (c *RaftCluster) GetAdjacentRegions(region *core.RegionInfo) (*core.RegionInfo, *core.RegionInfo) {
+	return c.core.GetAdjacentRegions(region)
+}
+
 // GetRegionByID gets region and leader peer by regionID from cluster.
 func (c *RaftCluster) GetRegionByID(regionID uint64) (*metapb.Region, *metapb.Peer) {
 	region := c.GetRegion(regionID)
@@ -500,12 +534,33 @@ func (c *RaftCluster) putStore(store *metapb.Store) error {
 
 	s := c.GetStore(store.GetId())
 	if s == nil {
+		// A store removed from the cluster may come back with its old data after its records are deleted.
+		if c.storage != nil {
+			removed, err := c.storage.IsStoreRemoved(store.GetId())
+			if err != nil {
+				return err
+			}
+			if removed {
+				return core.StoreFencedErr{StoreID: store.GetId()}
+			}
+		}
 		// Add a new store.
 		s = core.NewStoreInfo(store)
 	} else {
+		// The store registers with the token it first registered with, a store registered before tokens were
+		// issued takes the token it comes with.
+		token := s.GetMeta().GetFencingToken()
+		if token != 0 && store.GetFencingToken() != token {
+			log.Warn("store registers with a mismatched fencing token",
+				zap.Uint64("store-id", store.GetId()),
+				zap.Uint64("token", store.GetFencingToken()),
+				zap.Uint64("expected-token", token))
+			return core.StoreFencedErr{StoreID: store.GetId()}
+		}
 		// Update an existed store.
 		s = s.Clone(
 			core.SetStoreAddress(store.Address),
+			core.SetStoreFencingToken(store.GetFencingToken()),
 		)
 	}
 	return c.putStoreLocked(s)
@@ -699,6 +754,9 @@ func (c *RaftCluster) RemoveTombStoneRecords() error {
 
 func (c *RaftCluster) deleteStoreLocked(store *core.StoreInfo) error {
 	if c.storage != nil {
+		if err := c.storage.SaveRemovedStore(store.GetID()); err != nil {
+			return err
+		}
 		if err := c.storage.DeleteStore(store.GetMeta()); err != nil {
 			return err
 		}
@@ -785,6 +843,51 @@ func (c *RaftCluster) GetReplicaScheduleLimit() uint64 {
 	return c.opt.GetReplicaScheduleLimit()
 }
 
+// GetMaxMergeRegionSize returns the max region size of a merge target.
+func (c *RaftCluster) GetMaxMergeRegionSize() uint64 {
+	return c.opt.GetMaxMergeRegionSize()
+}
+
+// GetMaxMergeRegionKeys returns the max number of keys of a merge target.
+func (c *RaftCluster) GetMaxMergeRegionKeys() uint64 {
+	return c.opt.GetMaxMergeRegionKeys()
+}
+
+// GetSplitMergeInterval returns the interval between a split and a merge of a region.
+func (c *RaftCluster) GetSplitMergeInterval() time.Duration {
+	return c.opt.GetSplitMergeInterval()
+}
+
+// GetMergeEmptyRegionHeartbeats returns the number of empty heartbeats before a region is merged.
+func (c *RaftCluster) GetMergeEmptyRegionHeartbeats() uint64 {
+	return c.opt.GetMergeEmptyRegionHeartbeats()
+}
+
+// GetStoreSnapshotBudget returns the size of the snapshots a store may have in flight.
+func (c *RaftCluster) GetStoreSnapshotBudget() uint64 {
+	return c.opt.GetStoreSnapshotBudget()
+}
+
+// GetClusterSnapshotBudget returns the size of the snapshots the cluster may have in flight.
+func (c *RaftCluster) GetClusterSnapshotBudget() uint64 {
+	return c.opt.GetClusterSnapshotBudget()
+}
+
+// GetSeededPeerProtectTime returns the time a peer isn't moved after it applies a snapshot.
+func (c *RaftCluster) GetSeededPeerProtectTime() time.Duration {
+	return c.opt.GetSeededPeerProtectTime()
+}
+
+// IsRaftLearnerEnabled returns whether a new peer joins its region as a learner.
+func (c *RaftCluster) IsRaftLearnerEnabled() bool {
+	return c.opt.IsRaftLearnerEnabled()
+}
+
+// IsWitnessEnabled returns whether a region of an even number of replicas has a witness.
+func (c *RaftCluster) IsWitnessEnabled() bool {
+	return c.opt.IsWitnessEnabled()
+}
+
 // GetPatrolRegionInterval returns the interval of patroling region.
 func (c *RaftCluster) GetPatrolRegionInterval() time.Duration {
 	return c.opt.GetPatrolRegionInterval()
@@ -497,6 +497,31 @@ func (s *testClusterInfoSuite) TestLoadClusterInfo(c *C) {
 	}
 }
 
+func (s *testClusterInfoSuite) TestStoreFencing(c *C) {
+	_, opt, err := newTestScheduleConfig()
+	c.Assert(err, IsNil)
+	cluster := createTestRaftCluster(mockid.NewIDAllocator(), opt, core.NewStorage(kv.NewMemoryKV()))
+
+	// A store registered before tokens were issued takes the token it comes with.
+	c.Assert(cluster.putStore(&metapb.Store{Id: 1, Address: "mock://tikv-1"}), IsNil)
+	c.Assert(cluster.putStore(&metapb.Store{Id: 1, Address: "mock://tikv-1", FencingToken: 100}), IsNil)
+	c.Assert(cluster.GetStore(1).GetMeta().GetFencingToken(), Equals, uint64(100))
+	c.Assert(cluster.putStore(&metapb.Store{Id: 1, Address: "mock://tikv-1", FencingToken: 100}), IsNil)
+
+	// Another token, or none, is refused.
+	_, ok := cluster.putStore(&metapb.Store{Id: 1, Address: "mock://tikv-1", FencingToken: 101}).(core.StoreFencedErr)
+	c.Assert(ok, IsTrue)
+	_, ok = cluster.putStore(&metapb.Store{Id: 1, Address: "mock://tikv-1"}).(core.StoreFencedErr)
+	c.Assert(ok, IsTrue)
+
+	// A store can't come back after its records are deleted.
+	c.Assert(cluster.BuryStore(1, true), IsNil)
+	c.Assert(cluster.RemoveTombStoneRecords(), IsNil)
+	c.Assert(cluster.GetStore(1), IsNil)
+	_, ok = cluster.putStore(&metapb.Store{Id: 1, Address: "mock://tikv-1", FencingToken: 100}).(core.StoreFencedErr)
+	c.Assert(ok, IsTrue)
+}
+
 func (s *testClusterInfoSuite) TestStoreHeartbeat(c *C) {
 	_, opt, err := newTestScheduleConfig()
 	c.Assert(err, IsNil)
@@ -20,6 +20,7 @@ import (
 	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/schedulerpb"
 	"github.com/pingcap-incubator/tinykv/scheduler/server/core"
+	"github.com/pingcap-incubator/tinykv/scheduler/server/id"
 	"github.com/pingcap-incubator/tinykv/scheduler/server/schedule"
 	"github.com/pingcap/log"
 	"github.com/pkg/errors"
@@ -41,6 +42,7 @@ func (c *RaftCluster) HandleRegionHeartbeat(region *core.RegionInfo) error {
 	c.RLock()
 	co := c.coordinator
 	c.RUnlock()
+	co.checkers.RecordHeartbeat(region)
 	co.opController.Dispatch(region, schedule.DispatchFromHeartBeat)
 	return nil
 }
@@ -52,14 +54,14 @@ func (c *RaftCluster) handleAskSplit(request *schedulerpb.AskSplitRequest) (*sch
 		return nil, err
 	}
 
-	newRegionID, err := c.s.idAllocator.Alloc()
+	newRegionID, err := id.AllocIn(c.s.idAllocator, id.NamespaceRegion)
 	if err != nil {
 		return nil, err
 	}
 
 	peerIDs := make([]uint64, len(request.Region.Peers))
 	for i := 0; i < len(peerIDs); i++ {
-		if peerIDs[i], err = c.s.idAllocator.Alloc(); err != nil {
+		if peerIDs[i], err = id.AllocIn(c.s.idAllocator, id.NamespacePeer); err != nil {
 			return nil, err
 		}
 	}
@@ -69,6 +71,12 @@ func (c *RaftCluster) handleAskSplit(request *schedulerpb.AskSplitRequest) (*sch
 		NewPeerIds:  peerIDs,
 	}
 
+	// Keep both halves of the split from being merged right away.
+	c.RLock()
+	co := c.coordinator
+	c.RUnlock()
+	co.checkers.RecordRegionSplit(reqRegion.GetId(), newRegionID)
+
 	return split, nil
 }
 
@@ -125,6 +125,7 @@ func NewConfig() *Config {
 	fs.BoolVar(&cfg.Version, "version", false, "print version information and exit")
 	fs.StringVar(&cfg.configFile, "config", "", "Config file")
 	fs.BoolVar(&cfg.ConfigCheck, "config-check", false, "check config file validity and exit")
+	fs.BoolVar(&cfg.PDServerCfg.DeterministicID, "deterministic-id", false, "allocate reproducible ids, only for a test cluster")
 
 	fs.StringVar(&cfg.Name, "name", "", "human-readable name for this pd member")
 
@@ -192,6 +193,12 @@ func adjustInt64(v *int64, defValue int64) {
 	}
 }
 
+func adjustFloat64(v *float64, defValue float64) {
+	if *v == 0 {
+		*v = defValue
+	}
+}
+
 func adjustDuration(v *typeutil.Duration, defValue time.Duration) {
 	if v.Duration == 0 {
 		v.Duration = defValue
@@ -269,7 +276,7 @@ func (c *Config) Validate() error {
 		return errors.New("log directory shouldn't be the subdirectory of data directory")
 	}
 
-	return nil
+	return c.Security.validate()
 }
 
 // Utility to test if a configuration is defined.
@@ -416,12 +423,57 @@ type ScheduleConfig struct {
 	// MaxStoreDownTime is the max duration after which
 	// a store will be considered to be down if it hasn't reported heartbeats.
 	MaxStoreDownTime typeutil.Duration `toml:"max-store-down-time,omitempty" json:"max-store-down-time"`
+	// MaxStoreClockSkew is the max offset of a store clock to the scheduler
+	// clock, a store exceeding it is alarmed on its heartbeats.
+	MaxStoreClockSkew typeutil.Duration `toml:"max-store-clock-skew,omitempty" json:"max-store-clock-skew"`
 	// LeaderScheduleLimit is the max coexist leader schedules.
 	LeaderScheduleLimit uint64 `toml:"leader-schedule-limit,omitempty" json:"leader-schedule-limit"`
 	// RegionScheduleLimit is the max coexist region schedules.
 	RegionScheduleLimit uint64 `toml:"region-schedule-limit,omitempty" json:"region-schedule-limit"`
 	// ReplicaScheduleLimit is the max coexist replica schedules.
 	ReplicaScheduleLimit uint64 `toml:"replica-schedule-limit,omitempty" json:"replica-schedule-limit"`
+	// If the size of a region is bigger than this value (MB), no empty region
+	// is merged into it. 0 disables the merge of empty regions.
+	MaxMergeRegionSize uint64 `toml:"max-merge-region-size,omitempty" json:"max-merge-region-size"`
+	// If the number of keys of a region is bigger than this value, no empty
+	// region is merged into it.
+	MaxMergeRegionKeys uint64 `toml:"max-merge-region-keys,omitempty" json:"max-merge-region-keys"`
+	// SplitMergeInterval is the minimum interval to merge a region after it
+	// is split or after the scheduler starts.
+	SplitMergeInterval typeutil.Duration `toml:"split-merge-interval,omitempty" json:"split-merge-interval"`
+	// MergeEmptyRegionHeartbeats is the number of consecutive heartbeats a
+	// region must report no data in before it is merged.
+	MergeEmptyRegionHeartbeats uint64 `toml:"merge-empty-region-heartbeats,omitempty" json:"merge-empty-region-heartbeats"`
+	// StoreBalanceWarningRatio is the ratio to the average of all stores
+	// beyond which the region count, average region size or leader count of a
+	// store is warned about.
+	StoreBalanceWarningRatio float64 `toml:"store-balance-warning-ratio,omitempty" json:"store-balance-warning-ratio"`
+	// StoreRegionCountSoftLimit is the number of regions per store beyond
+	// which adding stores is recommended.
+	StoreRegionCountSoftLimit uint64 `toml:"store-region-count-soft-limit,omitempty" json:"store-region-count-soft-limit"`
+	// StoreSnapshotBudget is the size (MB) of the snapshots a store may have
+	// in flight as a sender or as a receiver, beyond which no region is moved
+	// from or to it. 0 means no limit.
+	StoreSnapshotBudget uint64 `toml:"store-snapshot-budget,omitempty" json:"store-snapshot-budget"`
+	// ClusterSnapshotBudget is the size (MB) of the snapshots the whole
+	// cluster may have in flight, beyond which no region is moved. 0 means no
+	// limit.
+	ClusterSnapshotBudget uint64 `toml:"cluster-snapshot-budget,omitempty" json:"cluster-snapshot-budget"`
+	// SeededPeerProtectTime is the time after a peer applies a snapshot during
+	// which the peer is not moved again. 0 disables the protection.
+	SeededPeerProtectTime typeutil.Duration `toml:"seeded-peer-protect-time,omitempty" json:"seeded-peer-protect-time"`
+	// MaxSchedulingPause is the longest time the scheduling may be paused,
+	// after which it's resumed automatically, also the pause time of a pause
+	// request without one.
+	MaxSchedulingPause typeutil.Duration `toml:"max-scheduling-pause,omitempty" json:"max-scheduling-pause"`
+	// EnableRaftLearner makes a new peer join its region as a learner, which
+	// is promoted to a voter once it caught up, so adding a replica to a
+	// large region doesn't lower its availability meanwhile.
+	EnableRaftLearner bool `toml:"enable-raft-learner" json:"enable-raft-learner"`
+	// EnableWitness makes a region of an even number of replicas add a
+	// witness, a peer which votes but stores no data, so its quorum tolerates
+	// as many failures as one of a replica more.
+	EnableWitness bool `toml:"enable-witness" json:"enable-witness"`
 
 	// Schedulers support for loading customized schedulers
 	Schedulers SchedulerConfigs `toml:"schedulers,omitempty" json:"schedulers-v2"` // json v2 is for the sake of compatible upgrade
@@ -435,12 +487,25 @@ func (c *ScheduleConfig) Clone() *ScheduleConfig {
 	schedulers := make(SchedulerConfigs, len(c.Schedulers))
 	copy(schedulers, c.Schedulers)
 	return &ScheduleConfig{
-		PatrolRegionInterval: c.PatrolRegionInterval,
-		MaxStoreDownTime:     c.MaxStoreDownTime,
-		LeaderScheduleLimit:  c.LeaderScheduleLimit,
-		RegionScheduleLimit:  c.RegionScheduleLimit,
-		ReplicaScheduleLimit: c.ReplicaScheduleLimit,
-		Schedulers:           schedulers,
+		PatrolRegionInterval:       c.PatrolRegionInterval,
+		MaxStoreDownTime:           c.MaxStoreDownTime,
+		MaxStoreClockSkew:          c.MaxStoreClockSkew,
+		LeaderScheduleLimit:        c.LeaderScheduleLimit,
+		RegionScheduleLimit:        c.RegionScheduleLimit,
+		ReplicaScheduleLimit:       c.ReplicaScheduleLimit,
+		MaxMergeRegionSize:         c.MaxMergeRegionSize,
+		MaxMergeRegionKeys:         c.MaxMergeRegionKeys,
+		SplitMergeInterval:         c.SplitMergeInterval,
+		MergeEmptyRegionHeartbeats: c.MergeEmptyRegionHeartbeats,
+		StoreBalanceWarningRatio:   c.StoreBalanceWarningRatio,
+		StoreRegionCountSoftLimit:  c.StoreRegionCountSoftLimit,
+		StoreSnapshotBudget:        c.StoreSnapshotBudget,
+		ClusterSnapshotBudget:      c.ClusterSnapshotBudget,
+		SeededPeerProtectTime:      c.SeededPeerProtectTime,
+		MaxSchedulingPause:         c.MaxSchedulingPause,
+		EnableRaftLearner:          c.EnableRaftLearner,
+		EnableWitness:              c.EnableWitness,
+		Schedulers:                 schedulers,
 	}
 }
 
@@ -448,14 +513,25 @@ const (
 	defaultMaxReplicas          = 3
 	defaultPatrolRegionInterval = 100 * time.Millisecond
 	defaultMaxStoreDownTime     = 30 * time.Minute
+	defaultMaxStoreClockSkew    = 500 * time.Millisecond
 	defaultLeaderScheduleLimit  = 4
 	defaultRegionScheduleLimit  = 2048
 	defaultReplicaScheduleLimit = 64
+	// the stores don't support merge yet, so it's disabled by default
+	defaultMaxMergeRegionSize         = 0
+	defaultMaxMergeRegionKeys         = 200000
+	defaultSplitMergeInterval         = 1 * time.Hour
+	defaultMergeEmptyRegionHeartbeats = 3
+	defaultStoreBalanceWarningRatio   = 1.5
+	defaultStoreRegionCountSoftLimit  = 20000
+	defaultSeededPeerProtectTime      = 10 * time.Minute
+	defaultMaxSchedulingPause         = 1 * time.Hour
 )
 
 func (c *ScheduleConfig) adjust(meta *configMetaData) error {
 	adjustDuration(&c.PatrolRegionInterval, defaultPatrolRegionInterval)
 	adjustDuration(&c.MaxStoreDownTime, defaultMaxStoreDownTime)
+	adjustDuration(&c.MaxStoreClockSkew, defaultMaxStoreClockSkew)
 	if !meta.IsDefined("leader-schedule-limit") {
 		adjustUint64(&c.LeaderScheduleLimit, defaultLeaderScheduleLimit)
 	}
@@ -465,6 +541,20 @@ func (c *ScheduleConfig) adjust(meta *configMetaData) error {
 	if !meta.IsDefined("replica-schedule-limit") {
 		adjustUint64(&c.ReplicaScheduleLimit, defaultReplicaScheduleLimit)
 	}
+	if !meta.IsDefined("max-merge-region-size") {
+		adjustUint64(&c.MaxMergeRegionSize, defaultMaxMergeRegionSize)
+	}
+	if !meta.IsDefined("max-merge-region-keys") {
+		adjustUint64(&c.MaxMergeRegionKeys, defaultMaxMergeRegionKeys)
+	}
+	adjustDuration(&c.SplitMergeInterval, defaultSplitMergeInterval)
+	if !meta.IsDefined("seeded-peer-protect-time") {
+		adjustDuration(&c.SeededPeerProtectTime, defaultSeededPeerProtectTime)
+	}
+	adjustDuration(&c.MaxSchedulingPause, defaultMaxSchedulingPause)
+	adjustUint64(&c.MergeEmptyRegionHeartbeats, defaultMergeEmptyRegionHeartbeats)
+	adjustFloat64(&c.StoreBalanceWarningRatio, defaultStoreBalanceWarningRatio)
+	adjustUint64(&c.StoreRegionCountSoftLimit, defaultStoreRegionCountSoftLimit)
 	adjustSchedulers(&c.Schedulers, defaultSchedulers)
 
 	return c.Validate()
@@ -532,6 +622,58 @@ type SecurityConfig struct {
 	CertPath string `toml:"cert-path" json:"cert-path"`
 	// KeyPath is the path of file that contains X509 key in PEM format.
 	KeyPath string `toml:"key-path" json:"key-path"`
+	// Roles grant the API clients their roles. With any role set, every API
+	// call must be authenticated by a token or a client certificate, and be
+	// allowed for the role of the client. The stores send their tokens in
+	// plaintext, as they don't connect to the scheduler over TLS, so a token
+	// is only as safe as the network between them.
+	Roles []AuthRole `toml:"roles" json:"roles"`
+}
+
+// The roles of the API clients, each role may call the APIs of the roles
+// before it, except for the store role.
+const (
+	// RoleReader reads the cluster state, e.g. for dashboards.
+	RoleReader = "reader"
+	// RoleOperator runs the clients, and changes the scheduling.
+	RoleOperator = "operator"
+	// RoleAdmin bootstraps the cluster and manages the store lifecycle.
+	RoleAdmin = "admin"
+	// RoleStore runs a store, it may only call the APIs the stores call,
+	// e.g. to register the store and report the heartbeats.
+	RoleStore = "store"
+)
+
+// AuthRole grants a role to the API client authenticated by the token, or by
+// a TLS client certificate with the common name if there is no token.
+type AuthRole struct {
+	// Name identifies the client in the audit log.
+	Name  string `toml:"name" json:"name"`
+	Token string `toml:"token" json:"-"`
+	Role  string `toml:"role" json:"role"`
+}
+
+func (s SecurityConfig) validate() error {
+	tokens := make(map[string]bool)
+	for _, r := range s.Roles {
+		if r.Name == "" {
+			return errors.New("the name of an auth role must not be empty")
+		}
+		if r.Role != RoleReader && r.Role != RoleOperator && r.Role != RoleAdmin && r.Role != RoleStore {
+			return errors.Errorf("role %q of %s must be one of %q, %q, %q and %q", r.Role, r.Name, RoleReader, RoleOperator,
+				RoleAdmin, RoleStore)
+		}
+		if r.Token == "" && s.CAPath == "" {
+			return errors.Errorf("%s has neither a token nor a client certificate", r.Name)
+		}
+		if r.Token != "" {
+			if tokens[r.Token] {
+				return errors.Errorf("the token of %s is granted another role", r.Name)
+			}
+			tokens[r.Token] = true
+		}
+	}
+	return nil
 }
 
 // ToTLSConfig generatres tls config.
@@ -555,6 +697,11 @@ func (s SecurityConfig) ToTLSConfig() (*tls.Config, error) {
 type PDServerConfig struct {
 	// MaxResetTSGap is the max gap to reset the tso.
 	MaxResetTSGap time.Duration `toml:"max-reset-ts-gap" json:"max-reset-ts-gap"`
+	// DeterministicID allocates the ids one by one from memory, the ids of the
+	// split regions and of their peers from their own namespaces, so the ids
+	// of a test run are reproducible. It's only for a single member cluster
+	// started afresh, the ids are allocated again after a restart.
+	DeterministicID bool `toml:"deterministic-id" json:"deterministic-id"`
 }
 
 func (c *PDServerConfig) adjust(meta *configMetaData) error {
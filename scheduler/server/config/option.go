@@ -75,6 +75,21 @@ func (o *ScheduleOption) GetMaxStoreDownTime() time.Duration {
 	return o.Load().MaxStoreDownTime.Duration
 }
 
+// GetMaxStoreClockSkew returns the max clock skew of a store.
+func (o *ScheduleOption) GetMaxStoreClockSkew() time.Duration {
+	return o.Load().MaxStoreClockSkew.Duration
+}
+
+// GetStoreBalanceWarningRatio returns the ratio to the average of the stores beyond which a store is warned about.
+func (o *ScheduleOption) GetStoreBalanceWarningRatio() float64 {
+	return o.Load().StoreBalanceWarningRatio
+}
+
+// GetStoreRegionCountSoftLimit returns the number of regions per store beyond which adding stores is recommended.
+func (o *ScheduleOption) GetStoreRegionCountSoftLimit() uint64 {
+	return o.Load().StoreRegionCountSoftLimit
+}
+
 // GetLeaderScheduleLimit returns the limit for leader schedule.
 func (o *ScheduleOption) GetLeaderScheduleLimit() uint64 {
 	return o.Load().LeaderScheduleLimit
@@ -90,6 +105,56 @@ func (o *ScheduleOption) GetReplicaScheduleLimit() uint64 {
 	return o.Load().ReplicaScheduleLimit
 }
 
+// GetMaxMergeRegionSize returns the max region size of a merge target.
+func (o *ScheduleOption) GetMaxMergeRegionSize() uint64 {
+	return o.Load().MaxMergeRegionSize
+}
+
+// GetMaxMergeRegionKeys returns the max number of keys of a merge target.
+func (o *ScheduleOption) GetMaxMergeRegionKeys() uint64 {
+	return o.Load().MaxMergeRegionKeys
+}
+
+// GetSplitMergeInterval returns the interval between a split and a merge of a region.
+func (o *ScheduleOption) GetSplitMergeInterval() time.Duration {
+	return o.Load().SplitMergeInterval.Duration
+}
+
+// GetMergeEmptyRegionHeartbeats returns the number of empty heartbeats before a region is merged.
+func (o *ScheduleOption) GetMergeEmptyRegionHeartbeats() uint64 {
+	return o.Load().MergeEmptyRegionHeartbeats
+}
+
+// GetStoreSnapshotBudget returns the size of the snapshots a store may have in flight.
+func (o *ScheduleOption) GetStoreSnapshotBudget() uint64 {
+	return o.Load().StoreSnapshotBudget
+}
+
+// GetClusterSnapshotBudget returns the size of the snapshots the cluster may have in flight.
+func (o *ScheduleOption) GetClusterSnapshotBudget() uint64 {
+	return o.Load().ClusterSnapshotBudget
+}
+
+// GetMaxSchedulingPause returns the longest time the scheduling may be paused.
+func (o *ScheduleOption) GetMaxSchedulingPause() time.Duration {
+	return o.Load().MaxSchedulingPause.Duration
+}
+
+// GetSeededPeerProtectTime returns the time a peer isn't moved after it applies a snapshot.
+func (o *ScheduleOption) GetSeededPeerProtectTime() time.Duration {
+	return o.Load().SeededPeerProtectTime.Duration
+}
+
+// IsRaftLearnerEnabled returns whether a new peer joins its region as a learner.
+func (o *ScheduleOption) IsRaftLearnerEnabled() bool {
+	return o.Load().EnableRaftLearner
+}
+
+// IsWitnessEnabled returns whether a region of an even number of replicas has a witness.
+func (o *ScheduleOption) IsWitnessEnabled() bool {
+	return o.Load().EnableWitness
+}
+
 // GetSchedulers gets the scheduler configurations.
 func (o *ScheduleOption) GetSchedulers() SchedulerConfigs {
 	return o.Load().Schedulers
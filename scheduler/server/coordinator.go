@@ -56,6 +56,7 @@ type coordinator struct {
 	schedulers   map[string]*scheduleController
 	opController *schedule.OperatorController
 	hbStreams    *heartbeatStreams
+	pauses       *schedulingPauses
 }
 
 // newCoordinator creates a new coordinator.
@@ -70,6 +71,7 @@ func newCoordinator(ctx context.Context, cluster *RaftCluster, hbStreams *heartb
 		schedulers:   make(map[string]*scheduleController),
 		opController: opController,
 		hbStreams:    hbStreams,
+		pauses:       newSchedulingPauses(),
 	}
 }
 
@@ -93,6 +95,10 @@ func (c *coordinator) patrolRegions() {
 			return
 		}
 
+		if c.pauses.isPaused(allScheduling) {
+			continue
+		}
+
 		regions := c.cluster.ScanRegions(key, nil, patrolScanRegionLimit)
 		if len(regions) == 0 {
 			// Resets the scan key.
@@ -271,6 +277,7 @@ func (c *coordinator) removeScheduler(name string) error {
 
 	s.Stop()
 	delete(c.schedulers, name)
+	c.pauses.resume(name)
 
 	var err error
 	opt := c.cluster.opt
@@ -285,6 +292,24 @@ func (c *coordinator) removeScheduler(name string) error {
 	return err
 }
 
+// pauseScheduling pauses the scheduler of the name, or all the scheduling if the name is empty, for the duration,
+// at most the max scheduling pause of the configuration.
+func (c *coordinator) pauseScheduling(name string, d time.Duration) error {
+	if name != allScheduling {
+		c.RLock()
+		_, ok := c.schedulers[name]
+		c.RUnlock()
+		if !ok {
+			return errSchedulerNotFound
+		}
+	}
+	if max := c.cluster.opt.GetMaxSchedulingPause(); d <= 0 || d > max {
+		d = max
+	}
+	c.pauses.pause(name, time.Now().Add(d))
+	return nil
+}
+
 func (c *coordinator) runScheduler(s *scheduleController) {
 	defer logutil.LogPanic()
 	defer c.wg.Done()
@@ -297,7 +322,7 @@ func (c *coordinator) runScheduler(s *scheduleController) {
 		select {
 		case <-timer.C:
 			timer.Reset(s.GetInterval())
-			if !s.AllowSchedule() {
+			if !s.AllowSchedule() || c.pauses.isPaused(s.GetName()) {
 				continue
 			}
 			if op := s.Schedule(); op != nil {
@@ -298,6 +298,13 @@ func (bc *BasicCluster) SearchPrevRegion(regionKey []byte) *RegionInfo {
 	return bc.Regions.SearchPrevRegion(regionKey)
 }
 
+// GetAdjacentRegions returns the regions right before and after region.
+func (bc *BasicCluster) GetAdjacentRegions(region *RegionInfo) (*RegionInfo, *RegionInfo) {
+	bc.RLock()
+	defer bc.RUnlock()
+	return bc.Regions.GetAdjacentRegions(region)
+}
+
 // ScanRange scans regions intersecting [start key, end key), returns at most
 // `limit` regions. limit <= 0 means no limit.
 func (bc *BasicCluster) ScanRange(startKey, endKey []byte, limit int) []*RegionInfo {
@@ -313,6 +320,13 @@ func (bc *BasicCluster) GetOverlaps(region *RegionInfo) []*RegionInfo {
 	return bc.Regions.GetOverlaps(region)
 }
 
+// CheckRanges returns the gaps and overlaps of the region ranges.
+func (bc *BasicCluster) CheckRanges() []*RangeViolation {
+	bc.RLock()
+	defer bc.RUnlock()
+	return bc.Regions.CheckRanges()
+}
+
 // Length returns the RegionsInfo length.
 func (bc *BasicCluster) Length() int {
 	bc.RLock()
@@ -331,6 +345,7 @@ type RegionSetInformer interface {
 	GetAverageRegionSize() int64
 	GetStoreRegionCount(storeID uint64) int
 	GetRegion(id uint64) *RegionInfo
+	GetAdjacentRegions(region *RegionInfo) (*RegionInfo, *RegionInfo)
 	ScanRegions(startKey, endKey []byte, limit int) []*RegionInfo
 }
 
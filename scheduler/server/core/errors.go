@@ -32,10 +32,14 @@ var (
 
 	// StoreTombstonedCode is an invalid operation was attempted on a store which is in a removed state.
 	StoreTombstonedCode = storeStateCode.Child("state.store.tombstoned").SetHTTP(http.StatusGone)
+
+	// StoreFencedCode is a store registering with a fencing token other than the one the cluster knows it by.
+	StoreFencedCode = storeStateCode.Child("state.store.fenced").SetHTTP(http.StatusForbidden)
 )
 
 var _ errcode.ErrorCode = (*StoreTombstonedErr)(nil) // assert implements interface
 var _ errcode.ErrorCode = (*StoreBlockedErr)(nil)    // assert implements interface
+var _ errcode.ErrorCode = (*StoreFencedErr)(nil)     // assert implements interface
 
 // StoreErr can be newtyped or embedded in your own error
 type StoreErr struct {
@@ -61,3 +65,14 @@ func (e StoreBlockedErr) Error() string {
 
 // Code returns StoreBlockedCode
 func (e StoreBlockedErr) Code() errcode.Code { return StoreBlockedCode }
+
+// StoreFencedErr is a store registering with a fencing token other than the one the cluster knows it by, or with an
+// ID removed from the cluster.
+type StoreFencedErr StoreErr
+
+func (e StoreFencedErr) Error() string {
+	return fmt.Sprintf("store %v is fenced off the cluster", e.StoreID)
+}
+
+// Code returns StoreFencedCode
+func (e StoreFencedErr) Code() errcode.Code { return StoreFencedCode }
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
@@ -34,6 +35,17 @@ type RegionInfo struct {
 	leader          *metapb.Peer
 	pendingPeers    []*metapb.Peer
 	approximateSize int64
+	approximateKeys int64
+	// the peers seeded by a snapshot, by peer id
+	seededPeers map[uint64]*SeededPeer
+}
+
+// SeededPeer is a peer which applied a snapshot, so it's freshly seeded with
+// the data of the region.
+type SeededPeer struct {
+	*schedulerpb.SnapshotApplied
+	// SeededAt is when the heartbeat reporting the snapshot was received.
+	SeededAt time.Time
 }
 
 // NewRegionInfo creates RegionInfo with region's meta and leader peer.
@@ -52,10 +64,16 @@ func NewRegionInfo(region *metapb.Region, leader *metapb.Peer, opts ...RegionCre
 
 // classifyVoterAndLearner sorts out voter and learner from peers into different slice.
 func classifyVoterAndLearner(region *RegionInfo) {
+	learners := make([]*metapb.Peer, 0, 1)
 	voters := make([]*metapb.Peer, 0, len(region.meta.Peers))
 	for _, p := range region.meta.Peers {
-		voters = append(voters, p)
+		if p.IsLearner {
+			learners = append(learners, p)
+		} else {
+			voters = append(voters, p)
+		}
 	}
+	region.learners = learners
 	region.voters = voters
 }
 
@@ -77,6 +95,14 @@ func RegionFromHeartbeat(heartbeat *schedulerpb.RegionHeartbeatRequest) *RegionI
 		leader:          heartbeat.GetLeader(),
 		pendingPeers:    heartbeat.GetPendingPeers(),
 		approximateSize: int64(regionSize),
+		approximateKeys: int64(heartbeat.GetApproximateKeys()),
+	}
+	now := time.Now()
+	for _, applied := range heartbeat.GetSnapshotsApplied() {
+		if region.seededPeers == nil {
+			region.seededPeers = make(map[uint64]*SeededPeer)
+		}
+		region.seededPeers[applied.GetPeer().GetId()] = &SeededPeer{SnapshotApplied: applied, SeededAt: now}
 	}
 
 	classifyVoterAndLearner(region)
@@ -95,6 +121,13 @@ func (r *RegionInfo) Clone(opts ...RegionCreateOption) *RegionInfo {
 		leader:          proto.Clone(r.leader).(*metapb.Peer),
 		pendingPeers:    pendingPeers,
 		approximateSize: r.approximateSize,
+		approximateKeys: r.approximateKeys,
+	}
+	for id, seeded := range r.seededPeers {
+		if region.seededPeers == nil {
+			region.seededPeers = make(map[uint64]*SeededPeer)
+		}
+		region.seededPeers[id] = seeded
 	}
 
 	for _, opt := range opts {
@@ -114,6 +147,17 @@ func (r *RegionInfo) GetVoters() []*metapb.Peer {
 	return r.voters
 }
 
+// GetWitnesses returns the witnesses, the voters which store no data.
+func (r *RegionInfo) GetWitnesses() []*metapb.Peer {
+	var witnesses []*metapb.Peer
+	for _, peer := range r.voters {
+		if peer.GetIsWitness() {
+			witnesses = append(witnesses, peer)
+		}
+	}
+	return witnesses
+}
+
 // GetPeer returns the peer with specified peer id.
 func (r *RegionInfo) GetPeer(peerID uint64) *metapb.Peer {
 	for _, peer := range r.meta.GetPeers() {
@@ -124,6 +168,41 @@ func (r *RegionInfo) GetPeer(peerID uint64) *metapb.Peer {
 	return nil
 }
 
+// GetSeededPeer returns the snapshot the peer was last seeded by, nil if it's
+// not reported.
+func (r *RegionInfo) GetSeededPeer(peerID uint64) *SeededPeer {
+	return r.seededPeers[peerID]
+}
+
+// IsPeerSeededWithin returns true if the peer on the store applied a snapshot
+// within the duration, then the peer shouldn't be moved again so soon.
+func (r *RegionInfo) IsPeerSeededWithin(storeID uint64, d time.Duration) bool {
+	peer := r.GetStorePeer(storeID)
+	if peer == nil {
+		return false
+	}
+	seeded := r.seededPeers[peer.GetId()]
+	return seeded != nil && time.Since(seeded.SeededAt) < d
+}
+
+// InheritSeededPeers keeps the seeded peers of the origin region which are
+// still in the region and not reported again by the heartbeat, as a snapshot
+// is only reported in the first heartbeat after it's applied.
+func (r *RegionInfo) InheritSeededPeers(origin *RegionInfo) {
+	if origin == nil {
+		return
+	}
+	for id, seeded := range origin.seededPeers {
+		if _, ok := r.seededPeers[id]; ok || r.GetPeer(id) == nil {
+			continue
+		}
+		if r.seededPeers == nil {
+			r.seededPeers = make(map[uint64]*SeededPeer)
+		}
+		r.seededPeers[id] = seeded
+	}
+}
+
 // GetDownLearner returns the down learner with soecified peer id.
 func (r *RegionInfo) GetDownLearner(peerID uint64) *metapb.Peer {
 	return nil
@@ -142,7 +221,7 @@ func (r *RegionInfo) GetPendingPeer(peerID uint64) *metapb.Peer {
 // GetPendingVoter returns the pending voter with specified peer id.
 func (r *RegionInfo) GetPendingVoter(peerID uint64) *metapb.Peer {
 	for _, peer := range r.pendingPeers {
-		if peer.GetId() == peerID {
+		if peer.GetId() == peerID && !peer.IsLearner {
 			return peer
 		}
 	}
@@ -151,6 +230,11 @@ func (r *RegionInfo) GetPendingVoter(peerID uint64) *metapb.Peer {
 
 // GetPendingLearner returns the pending learner peer with specified peer id.
 func (r *RegionInfo) GetPendingLearner(peerID uint64) *metapb.Peer {
+	for _, peer := range r.pendingPeers {
+		if peer.GetId() == peerID && peer.IsLearner {
+			return peer
+		}
+	}
 	return nil
 }
 
@@ -174,6 +258,14 @@ func (r *RegionInfo) GetStoreVoter(storeID uint64) *metapb.Peer {
 	return nil
 }
 
+// GetStoreWitness returns the witness in specified store.
+func (r *RegionInfo) GetStoreWitness(storeID uint64) *metapb.Peer {
+	if peer := r.GetStoreVoter(storeID); peer.GetIsWitness() {
+		return peer
+	}
+	return nil
+}
+
 // GetStoreLearner returns the learner peer in specified store.
 func (r *RegionInfo) GetStoreLearner(storeID uint64) *metapb.Peer {
 	for _, peer := range r.learners {
@@ -250,6 +342,11 @@ func (r *RegionInfo) GetApproximateSize() int64 {
 	return r.approximateSize
 }
 
+// GetApproximateKeys returns the approximate number of keys of the region.
+func (r *RegionInfo) GetApproximateKeys() int64 {
+	return r.approximateKeys
+}
+
 // GetPendingPeers returns the pending peers of the region.
 func (r *RegionInfo) GetPendingPeers() []*metapb.Peer {
 	return r.pendingPeers
@@ -541,6 +638,20 @@ func (r *RegionsInfo) SearchPrevRegion(regionKey []byte) *RegionInfo {
 	return r.GetRegion(region.GetID())
 }
 
+// GetAdjacentRegions returns the regions right before and after region in regionTree, nil if there is none.
+func (r *RegionsInfo) GetAdjacentRegions(region *RegionInfo) (*RegionInfo, *RegionInfo) {
+	p, n := r.tree.getAdjacentRegions(region)
+	var prev, next *RegionInfo
+	// check key to avoid key range hole
+	if p != nil && bytes.Equal(p.region.GetEndKey(), region.GetStartKey()) {
+		prev = r.GetRegion(p.region.GetID())
+	}
+	if n != nil && bytes.Equal(region.GetEndKey(), n.region.GetStartKey()) {
+		next = r.GetRegion(n.region.GetID())
+	}
+	return prev, next
+}
+
 // GetRegions gets all RegionInfo from regionMap
 func (r *RegionsInfo) GetRegions() []*RegionInfo {
 	regions := make([]*RegionInfo, 0, r.regions.Len())
@@ -687,6 +798,76 @@ func (r *RegionsInfo) ScanRange(startKey, endKey []byte, limit int) []*RegionInf
 	return res
 }
 
+// RangeViolation is a part of the keyspace covered by no region, or by more
+// than one region.
+type RangeViolation struct {
+	StartKey []byte
+	EndKey   []byte
+	// Regions are the regions overlapping in the range, empty for a gap.
+	Regions []*RegionInfo
+}
+
+// IsGap returns true if no region covers the range.
+func (v *RangeViolation) IsGap() bool {
+	return len(v.Regions) == 0
+}
+
+// CheckRanges checks the regions cover the keyspace without gaps or overlaps.
+// The region tree keeps no overlaps by itself, so an overlap means a region
+// is in the region map but not in the tree, or the tree is corrupted.
+func (r *RegionsInfo) CheckRanges() []*RangeViolation {
+	if r.regions.Len() == 0 {
+		return nil
+	}
+	var violations []*RangeViolation
+	var prev *RegionInfo
+	r.tree.scanRange(nil, func(region *RegionInfo) bool {
+		prevEnd := []byte{}
+		if prev != nil {
+			prevEnd = prev.GetEndKey()
+		}
+		switch cmp := bytes.Compare(prevEnd, region.GetStartKey()); {
+		case prev != nil && len(prevEnd) == 0, cmp > 0:
+			end := prevEnd
+			if len(end) == 0 || bytes.Compare(end, region.GetEndKey()) > 0 && len(region.GetEndKey()) > 0 {
+				end = region.GetEndKey()
+			}
+			violations = append(violations, &RangeViolation{
+				StartKey: region.GetStartKey(),
+				EndKey:   end,
+				Regions:  []*RegionInfo{prev, region},
+			})
+		case cmp < 0:
+			violations = append(violations, &RangeViolation{StartKey: prevEnd, EndKey: region.GetStartKey()})
+		}
+		if prev == nil || len(region.GetEndKey()) == 0 ||
+			(len(prevEnd) > 0 && bytes.Compare(region.GetEndKey(), prevEnd) > 0) {
+			prev = region
+		}
+		return true
+	})
+	if prev != nil && len(prev.GetEndKey()) > 0 {
+		violations = append(violations, &RangeViolation{StartKey: prev.GetEndKey(), EndKey: []byte{}})
+	}
+
+	for _, region := range r.regions.m {
+		if item := r.tree.find(region); item != nil && item.region.GetID() == region.GetID() {
+			continue
+		}
+		overlaps := r.tree.getOverlaps(region)
+		if len(overlaps) == 0 {
+			// the range is reported as a gap of the tree already
+			continue
+		}
+		violations = append(violations, &RangeViolation{
+			StartKey: region.GetStartKey(),
+			EndKey:   region.GetEndKey(),
+			Regions:  append([]*RegionInfo{region}, overlaps...),
+		})
+	}
+	return violations
+}
+
 // GetAverageRegionSize returns the average region approximate size.
 func (r *RegionsInfo) GetAverageRegionSize() int64 {
 	if r.regions.Len() == 0 {
@@ -14,7 +14,10 @@
 package core
 
 import (
+	"time"
+
 	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/schedulerpb"
 )
 
 // RegionOption is used to select region.
@@ -51,10 +54,32 @@ func WithLeader(leader *metapb.Peer) RegionCreateOption {
 	}
 }
 
-// WithLearners adds learner to the region
-func WithLearners(learner []*metapb.Peer) RegionCreateOption {
+// WithLearners marks the peers of the region with the ids of the learners as
+// learners.
+func WithLearners(learners []*metapb.Peer) RegionCreateOption {
+	return func(region *RegionInfo) {
+		peers := make([]*metapb.Peer, 0, len(region.meta.GetPeers()))
+		for _, p := range region.meta.GetPeers() {
+			for _, l := range learners {
+				if p.GetId() == l.GetId() {
+					p = &metapb.Peer{Id: l.GetId(), StoreId: l.GetStoreId(), IsLearner: true}
+					break
+				}
+			}
+			peers = append(peers, p)
+		}
+		region.meta.Peers = peers
+	}
+}
+
+// WithPromoteLearner promotes the learner of the region to a voter.
+func WithPromoteLearner(peerID uint64) RegionCreateOption {
 	return func(region *RegionInfo) {
-		region.learners = append(region.learners, learner...)
+		for _, p := range region.meta.GetPeers() {
+			if p.GetId() == peerID {
+				p.IsLearner = false
+			}
+		}
 	}
 }
 
@@ -112,6 +137,13 @@ func SetApproximateSize(v int64) RegionCreateOption {
 	}
 }
 
+// SetApproximateKeys sets the approximate keys for the region.
+func SetApproximateKeys(v int64) RegionCreateOption {
+	return func(region *RegionInfo) {
+		region.approximateKeys = v
+	}
+}
+
 // SetPeers sets the peers for the region.
 func SetPeers(peers []*metapb.Peer) RegionCreateOption {
 	return func(region *RegionInfo) {
@@ -119,10 +151,24 @@ func SetPeers(peers []*metapb.Peer) RegionCreateOption {
 	}
 }
 
+// WithSeededPeer records the peer as seeded by the snapshot at the time.
+func WithSeededPeer(applied *schedulerpb.SnapshotApplied, at time.Time) RegionCreateOption {
+	return func(region *RegionInfo) {
+		if region.seededPeers == nil {
+			region.seededPeers = make(map[uint64]*SeededPeer)
+		}
+		region.seededPeers[applied.GetPeer().GetId()] = &SeededPeer{SnapshotApplied: applied, SeededAt: at}
+	}
+}
+
 // WithAddPeer adds a peer for the region.
 func WithAddPeer(peer *metapb.Peer) RegionCreateOption {
 	return func(region *RegionInfo) {
 		region.meta.Peers = append(region.meta.Peers, peer)
-		region.voters = append(region.voters, peer)
+		if peer.IsLearner {
+			region.learners = append(region.learners, peer)
+		} else {
+			region.voters = append(region.voters, peer)
+		}
 	}
 }
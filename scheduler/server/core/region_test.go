@@ -130,6 +130,57 @@ func (*testRegionKey) TestRegionKey(c *C) {
 	}
 }
 
+var _ = Suite(&testRegionRangesSuite{})
+
+type testRegionRangesSuite struct{}
+
+func (s *testRegionRangesSuite) region(id uint64, startKey, endKey string, version uint64) *RegionInfo {
+	return NewRegionInfo(&metapb.Region{
+		Id:          id,
+		StartKey:    []byte(startKey),
+		EndKey:      []byte(endKey),
+		RegionEpoch: &metapb.RegionEpoch{Version: version},
+	}, nil)
+}
+
+func (s *testRegionRangesSuite) TestCheckRanges(c *C) {
+	regions := NewRegionsInfo()
+	c.Assert(regions.CheckRanges(), HasLen, 0)
+
+	regions.SetRegion(s.region(1, "", "b", 1))
+	regions.SetRegion(s.region(2, "b", "d", 1))
+	regions.SetRegion(s.region(3, "d", "", 1))
+	c.Assert(regions.CheckRanges(), HasLen, 0)
+
+	// a leading, a middle and a trailing gap
+	regions = NewRegionsInfo()
+	regions.SetRegion(s.region(1, "a", "b", 1))
+	regions.SetRegion(s.region(2, "c", "d", 1))
+	violations := regions.CheckRanges()
+	c.Assert(violations, HasLen, 3)
+	for i, gap := range [][2]string{{"", "a"}, {"b", "c"}, {"d", ""}} {
+		c.Assert(violations[i].IsGap(), IsTrue)
+		c.Assert(string(violations[i].StartKey), Equals, gap[0])
+		c.Assert(string(violations[i].EndKey), Equals, gap[1])
+	}
+
+	// a region left in the map after a newer region took its range
+	regions = NewRegionsInfo()
+	regions.SetRegion(s.region(1, "", "b", 1))
+	regions.SetRegion(s.region(2, "b", "", 2))
+	regions.regions.Put(s.region(3, "a", "c", 1))
+	violations = regions.CheckRanges()
+	c.Assert(violations, HasLen, 1)
+	c.Assert(violations[0].IsGap(), IsFalse)
+	c.Assert(string(violations[0].StartKey), Equals, "a")
+	c.Assert(string(violations[0].EndKey), Equals, "c")
+	var ids []uint64
+	for _, region := range violations[0].Regions {
+		ids = append(ids, region.GetID())
+	}
+	c.Assert(ids, DeepEquals, []uint64{3, 1, 2})
+}
+
 func BenchmarkRandomRegion(b *testing.B) {
 	regions := NewRegionsInfo()
 	for i := 0; i < 5000000; i++ {
@@ -56,6 +56,10 @@ func (s *Storage) storePath(storeID uint64) string {
 	return path.Join(clusterPath, "s", fmt.Sprintf("%020d", storeID))
 }
 
+func (s *Storage) removedStorePath(storeID uint64) string {
+	return path.Join(clusterPath, "removed_s", fmt.Sprintf("%020d", storeID))
+}
+
 func regionPath(regionID uint64) string {
 	return path.Join(clusterPath, "r", fmt.Sprintf("%020d", regionID))
 }
@@ -116,6 +120,20 @@ func (s *Storage) DeleteStore(store *metapb.Store) error {
 	return s.Remove(s.storePath(store.GetId()))
 }
 
+// SaveRemovedStore records the store is removed from the cluster, its ID is never registered again.
+func (s *Storage) SaveRemovedStore(storeID uint64) error {
+	return s.Save(s.removedStorePath(storeID), strconv.FormatUint(storeID, 10))
+}
+
+// IsStoreRemoved returns true if the store is removed from the cluster.
+func (s *Storage) IsStoreRemoved(storeID uint64) (bool, error) {
+	value, err := s.Load(s.removedStorePath(storeID))
+	if err != nil {
+		return false, err
+	}
+	return value != "", nil
+}
+
 // LoadStores loads all stores from storage to StoresInfo.
 func (s *Storage) LoadStores(f func(store *StoreInfo)) error {
 	nextID := uint64(0)
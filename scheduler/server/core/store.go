@@ -158,6 +158,16 @@ func (s *StoreInfo) IsBusy() bool {
 	return s.stats.GetIsBusy()
 }
 
+// IsCompacting returns if the store is busy with a heavy background compaction.
+func (s *StoreInfo) IsCompacting() bool {
+	return s.stats.GetIsCompacting()
+}
+
+// IsSlow returns if the store transferred leaders away for slow writes since its previous heartbeat.
+func (s *StoreInfo) IsSlow() bool {
+	return s.stats.GetSlowLeaderTransfers() > 0
+}
+
 // GetSendingSnapCount returns the current sending snapshot count of the store.
 func (s *StoreInfo) GetSendingSnapCount() uint32 {
 	return s.stats.GetSendingSnapCount()
@@ -173,6 +183,34 @@ func (s *StoreInfo) GetApplyingSnapCount() uint32 {
 	return s.stats.GetApplyingSnapCount()
 }
 
+// GetGeneratingSnapCount returns the current generating snapshot count of the store.
+func (s *StoreInfo) GetGeneratingSnapCount() uint32 {
+	return s.stats.GetGeneratingSnapCount()
+}
+
+// GetSendingSnapBytes returns the size of the snapshots the store is sending.
+func (s *StoreInfo) GetSendingSnapBytes() uint64 {
+	return s.stats.GetSendingSnapBytes()
+}
+
+// GetIncomingSnapBytes returns the size of the snapshots the store is
+// receiving or applying.
+func (s *StoreInfo) GetIncomingSnapBytes() uint64 {
+	return s.stats.GetReceivingSnapBytes() + s.stats.GetApplyingSnapBytes()
+}
+
+// GetSnapSendThroughput returns the bytes per second of the snapshots sent
+// by the store since its previous heartbeat.
+func (s *StoreInfo) GetSnapSendThroughput() uint64 {
+	return s.stats.GetSnapSendThroughput()
+}
+
+// GetSnapRecvThroughput returns the bytes per second of the snapshots
+// received by the store since its previous heartbeat.
+func (s *StoreInfo) GetSnapRecvThroughput() uint64 {
+	return s.stats.GetSnapRecvThroughput()
+}
+
 // GetStartTime returns the start time of the store.
 func (s *StoreInfo) GetStartTime() uint32 {
 	return s.stats.GetStartTime()
@@ -225,12 +263,22 @@ func (s *StoreInfo) StorageSize() uint64 {
 	return s.GetUsedSize()
 }
 
-// AvailableRatio is store's freeSpace/capacity.
+// AvailableRatio is store's freeSpace/capacity, the lowest of the disks of its data directories if they are
+// reported.
 func (s *StoreInfo) AvailableRatio() float64 {
 	if s.GetCapacity() == 0 {
 		return 0
 	}
-	return float64(s.GetAvailable()) / float64(s.GetCapacity())
+	ratio := float64(s.GetAvailable()) / float64(s.GetCapacity())
+	for _, disk := range s.stats.GetDiskStats() {
+		if disk.GetCapacity() == 0 {
+			continue
+		}
+		if diskRatio := float64(disk.GetAvailable()) / float64(disk.GetCapacity()); diskRatio < ratio {
+			ratio = diskRatio
+		}
+	}
+	return ratio
 }
 
 // IsLowSpace checks if the store is lack of space.
@@ -282,6 +330,42 @@ func (s *StoreInfo) ResourceWeight(kind ResourceKind) float64 {
 	}
 }
 
+// referenceCapacity is the capacity of a store whose region score is its region size. A store reporting no capacity
+// is scored as if it has the reference capacity.
+const referenceCapacity = 1 << 40
+
+// capacityRatio returns the capacity of the store relative to the reference capacity.
+func (s *StoreInfo) capacityRatio() float64 {
+	capacity := s.GetCapacity()
+	if capacity == 0 {
+		return 1
+	}
+	return float64(capacity) / referenceCapacity
+}
+
+// LeaderScore returns the leader count of the store divided by its leader weight.
+func (s *StoreInfo) LeaderScore() float64 {
+	return float64(s.GetLeaderCount()) / s.ResourceWeight(LeaderKind)
+}
+
+// RegionScore returns the region size of the store divided by its region weight and its capacity relative to the
+// reference capacity, so balanced stores hold regions in proportion to their capacities and weights.
+func (s *StoreInfo) RegionScore() float64 {
+	return float64(s.GetRegionSize()) / s.ResourceWeight(RegionKind) / s.capacityRatio()
+}
+
+// ResourceScore returns the score of leader/region in the store.
+func (s *StoreInfo) ResourceScore(kind ResourceKind) float64 {
+	switch kind {
+	case LeaderKind:
+		return s.LeaderScore()
+	case RegionKind:
+		return s.RegionScore()
+	default:
+		return 0
+	}
+}
+
 // GetStartTS returns the start timestamp.
 func (s *StoreInfo) GetStartTS() time.Time {
 	return time.Unix(int64(s.GetStartTime()), 0)
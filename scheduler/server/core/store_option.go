@@ -33,6 +33,15 @@ func SetStoreAddress(address string) StoreCreateOption {
 	}
 }
 
+// SetStoreFencingToken sets the fencing token for the store.
+func SetStoreFencingToken(token uint64) StoreCreateOption {
+	return func(store *StoreInfo) {
+		meta := proto.Clone(store.meta).(*metapb.Store)
+		meta.FencingToken = token
+		store.meta = meta
+	}
+}
+
 // SetStoreState sets the state for the store.
 func SetStoreState(state metapb.StoreState) StoreCreateOption {
 	return func(store *StoreInfo) {
@@ -18,6 +18,7 @@ import (
 	"time"
 
 	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/schedulerpb"
 	. "github.com/pingcap/check"
 )
 
@@ -54,3 +55,45 @@ func (s *testConcurrencySuite) TestCloneStore(c *C) {
 	}()
 	wg.Wait()
 }
+
+var _ = Suite(&testStoreScoreSuite{})
+
+type testStoreScoreSuite struct{}
+
+func (s *testStoreScoreSuite) TestRegionScore(c *C) {
+	small := NewStoreInfo(&metapb.Store{Id: 1},
+		SetStoreStats(&schedulerpb.StoreStats{Capacity: 500 << 30}), SetRegionSize(500))
+	large := NewStoreInfo(&metapb.Store{Id: 2},
+		SetStoreStats(&schedulerpb.StoreStats{Capacity: 4 << 40}), SetRegionSize(4000))
+	// the large store holds 8 times the regions of the small one at about the same score
+	c.Assert(small.RegionScore(), Equals, float64(1024))
+	c.Assert(large.RegionScore(), Equals, float64(1000))
+
+	weighted := small.Clone(SetRegionWeight(2))
+	c.Assert(weighted.RegionScore(), Equals, small.RegionScore()/2)
+
+	unknown := NewStoreInfo(&metapb.Store{Id: 3}, SetRegionSize(100))
+	c.Assert(unknown.RegionScore(), Equals, float64(100))
+}
+
+func (s *testStoreScoreSuite) TestLeaderScore(c *C) {
+	store := NewStoreInfo(&metapb.Store{Id: 1}, SetLeaderCount(10), SetLeaderWeight(2))
+	c.Assert(store.LeaderScore(), Equals, float64(5))
+	c.Assert(store.ResourceScore(LeaderKind), Equals, float64(5))
+	c.Assert(store.Clone(SetLeaderWeight(0)).LeaderScore(), Equals, 10/minWeight)
+}
+
+func (s *testStoreScoreSuite) TestAvailableRatio(c *C) {
+	stats := &schedulerpb.StoreStats{Capacity: 100, Available: 60}
+	store := NewStoreInfo(&metapb.Store{Id: 1}, SetStoreStats(stats))
+	c.Assert(store.AvailableRatio(), Equals, 0.6)
+
+	// the raft engine is on a smaller disk which is almost full
+	stats = &schedulerpb.StoreStats{Capacity: 100, Available: 60, DiskStats: []*schedulerpb.DiskStats{
+		{Name: "kv", Capacity: 100, Available: 70},
+		{Name: "raft", Capacity: 10, Available: 1},
+	}}
+	store = store.Clone(SetStoreStats(stats))
+	c.Assert(store.AvailableRatio(), Equals, 0.1)
+	c.Assert(store.IsLowSpace(0.8), IsTrue)
+}
@@ -37,7 +37,10 @@ const slowThreshold = 5 * time.Millisecond
 var notLeaderError = status.Errorf(codes.Unavailable, "not leader")
 
 // GetMembers implements gRPC PDServer.
-func (s *Server) GetMembers(context.Context, *schedulerpb.GetMembersRequest) (*schedulerpb.GetMembersResponse, error) {
+func (s *Server) GetMembers(ctx context.Context, request *schedulerpb.GetMembersRequest) (*schedulerpb.GetMembersResponse, error) {
+	if err := s.authorize(ctx, "GetMembers", request); err != nil {
+		return nil, err
+	}
 	if s.IsClosed() {
 		return nil, status.Errorf(codes.Unknown, "server not started")
 	}
@@ -65,6 +68,9 @@ func (s *Server) GetMembers(context.Context, *schedulerpb.GetMembersRequest) (*s
 
 // Tso implements gRPC PDServer.
 func (s *Server) Tso(stream schedulerpb.Scheduler_TsoServer) error {
+	if err := s.authorize(stream.Context(), "Tso", nil); err != nil {
+		return err
+	}
 	for {
 		request, err := stream.Recv()
 		if err == io.EOF {
@@ -104,6 +110,9 @@ func (s *Server) Tso(stream schedulerpb.Scheduler_TsoServer) error {
 
 // Bootstrap implements gRPC PDServer.
 func (s *Server) Bootstrap(ctx context.Context, request *schedulerpb.BootstrapRequest) (*schedulerpb.BootstrapResponse, error) {
+	if err := s.authorize(ctx, "Bootstrap", request); err != nil {
+		return nil, err
+	}
 	if err := s.validateRequest(request.GetHeader()); err != nil {
 		return nil, err
 	}
@@ -129,6 +138,9 @@ func (s *Server) Bootstrap(ctx context.Context, request *schedulerpb.BootstrapRe
 
 // IsBootstrapped implements gRPC PDServer.
 func (s *Server) IsBootstrapped(ctx context.Context, request *schedulerpb.IsBootstrappedRequest) (*schedulerpb.IsBootstrappedResponse, error) {
+	if err := s.authorize(ctx, "IsBootstrapped", request); err != nil {
+		return nil, err
+	}
 	if err := s.validateRequest(request.GetHeader()); err != nil {
 		return nil, err
 	}
@@ -142,6 +154,9 @@ func (s *Server) IsBootstrapped(ctx context.Context, request *schedulerpb.IsBoot
 
 // AllocID implements gRPC PDServer.
 func (s *Server) AllocID(ctx context.Context, request *schedulerpb.AllocIDRequest) (*schedulerpb.AllocIDResponse, error) {
+	if err := s.authorize(ctx, "AllocID", request); err != nil {
+		return nil, err
+	}
 	if err := s.validateRequest(request.GetHeader()); err != nil {
 		return nil, err
 	}
@@ -160,6 +175,9 @@ func (s *Server) AllocID(ctx context.Context, request *schedulerpb.AllocIDReques
 
 // GetStore implements gRPC PDServer.
 func (s *Server) GetStore(ctx context.Context, request *schedulerpb.GetStoreRequest) (*schedulerpb.GetStoreResponse, error) {
+	if err := s.authorize(ctx, "GetStore", request); err != nil {
+		return nil, err
+	}
 	if err := s.validateRequest(request.GetHeader()); err != nil {
 		return nil, err
 	}
@@ -199,6 +217,9 @@ func checkStore2(cluster *RaftCluster, storeID uint64) *schedulerpb.Error {
 
 // PutStore implements gRPC PDServer.
 func (s *Server) PutStore(ctx context.Context, request *schedulerpb.PutStoreRequest) (*schedulerpb.PutStoreResponse, error) {
+	if err := s.authorize(ctx, "PutStore", request); err != nil {
+		return nil, err
+	}
 	if err := s.validateRequest(request.GetHeader()); err != nil {
 		return nil, err
 	}
@@ -216,6 +237,14 @@ func (s *Server) PutStore(ctx context.Context, request *schedulerpb.PutStoreRequ
 	}
 
 	if err := cluster.putStore(store); err != nil {
+		if _, ok := err.(core.StoreFencedErr); ok {
+			return &schedulerpb.PutStoreResponse{
+				Header: s.errorHeader(&schedulerpb.Error{
+					Type:    schedulerpb.ErrorType_STORE_FENCED,
+					Message: err.Error(),
+				}),
+			}, nil
+		}
 		return nil, status.Errorf(codes.Unknown, err.Error())
 	}
 
@@ -228,6 +257,9 @@ func (s *Server) PutStore(ctx context.Context, request *schedulerpb.PutStoreRequ
 
 // GetAllStores implements gRPC PDServer.
 func (s *Server) GetAllStores(ctx context.Context, request *schedulerpb.GetAllStoresRequest) (*schedulerpb.GetAllStoresResponse, error) {
+	if err := s.authorize(ctx, "GetAllStores", request); err != nil {
+		return nil, err
+	}
 	if err := s.validateRequest(request.GetHeader()); err != nil {
 		return nil, err
 	}
@@ -257,6 +289,9 @@ func (s *Server) GetAllStores(ctx context.Context, request *schedulerpb.GetAllSt
 
 // StoreHeartbeat implements gRPC PDServer.
 func (s *Server) StoreHeartbeat(ctx context.Context, request *schedulerpb.StoreHeartbeatRequest) (*schedulerpb.StoreHeartbeatResponse, error) {
+	if err := s.authorize(ctx, "StoreHeartbeat", request); err != nil {
+		return nil, err
+	}
 	if err := s.validateRequest(request.GetHeader()); err != nil {
 		return nil, err
 	}
@@ -281,7 +316,9 @@ func (s *Server) StoreHeartbeat(ctx context.Context, request *schedulerpb.StoreH
 	}
 
 	return &schedulerpb.StoreHeartbeatResponse{
-		Header: s.header(),
+		Header:        s.header(),
+		SchedulerTime: time.Now().UnixNano(),
+		StalePeers:    cluster.takeStalePeers(request.GetStats().GetStoreId()),
 	}, nil
 }
 
@@ -328,6 +365,9 @@ func (s *heartbeatServer) Recv() (*schedulerpb.RegionHeartbeatRequest, error) {
 
 // RegionHeartbeat implements gRPC PDServer.
 func (s *Server) RegionHeartbeat(stream schedulerpb.Scheduler_RegionHeartbeatServer) error {
+	if err := s.authorize(stream.Context(), "RegionHeartbeat", nil); err != nil {
+		return err
+	}
 	server := &heartbeatServer{stream: stream}
 	cluster := s.GetRaftCluster()
 	if cluster == nil {
@@ -386,6 +426,9 @@ func (s *Server) RegionHeartbeat(stream schedulerpb.Scheduler_RegionHeartbeatSer
 
 // GetRegion implements gRPC PDServer.
 func (s *Server) GetRegion(ctx context.Context, request *schedulerpb.GetRegionRequest) (*schedulerpb.GetRegionResponse, error) {
+	if err := s.authorize(ctx, "GetRegion", request); err != nil {
+		return nil, err
+	}
 	if err := s.validateRequest(request.GetHeader()); err != nil {
 		return nil, err
 	}
@@ -404,6 +447,9 @@ func (s *Server) GetRegion(ctx context.Context, request *schedulerpb.GetRegionRe
 
 // GetPrevRegion implements gRPC PDServer
 func (s *Server) GetPrevRegion(ctx context.Context, request *schedulerpb.GetRegionRequest) (*schedulerpb.GetRegionResponse, error) {
+	if err := s.authorize(ctx, "GetPrevRegion", request); err != nil {
+		return nil, err
+	}
 	if err := s.validateRequest(request.GetHeader()); err != nil {
 		return nil, err
 	}
@@ -423,6 +469,9 @@ func (s *Server) GetPrevRegion(ctx context.Context, request *schedulerpb.GetRegi
 
 // GetRegionByID implements gRPC PDServer.
 func (s *Server) GetRegionByID(ctx context.Context, request *schedulerpb.GetRegionByIDRequest) (*schedulerpb.GetRegionResponse, error) {
+	if err := s.authorize(ctx, "GetRegionByID", request); err != nil {
+		return nil, err
+	}
 	if err := s.validateRequest(request.GetHeader()); err != nil {
 		return nil, err
 	}
@@ -442,6 +491,9 @@ func (s *Server) GetRegionByID(ctx context.Context, request *schedulerpb.GetRegi
 
 // ScanRegions implements gRPC PDServer.
 func (s *Server) ScanRegions(ctx context.Context, request *schedulerpb.ScanRegionsRequest) (*schedulerpb.ScanRegionsResponse, error) {
+	if err := s.authorize(ctx, "ScanRegions", request); err != nil {
+		return nil, err
+	}
 	if err := s.validateRequest(request.GetHeader()); err != nil {
 		return nil, err
 	}
@@ -465,6 +517,9 @@ func (s *Server) ScanRegions(ctx context.Context, request *schedulerpb.ScanRegio
 
 // AskSplit implements gRPC PDServer.
 func (s *Server) AskSplit(ctx context.Context, request *schedulerpb.AskSplitRequest) (*schedulerpb.AskSplitResponse, error) {
+	if err := s.authorize(ctx, "AskSplit", request); err != nil {
+		return nil, err
+	}
 	if err := s.validateRequest(request.GetHeader()); err != nil {
 		return nil, err
 	}
@@ -513,6 +568,9 @@ func (s *Server) ReportSplit(ctx context.Context, request *schedulerpb.ReportSpl
 
 // GetClusterConfig implements gRPC PDServer.
 func (s *Server) GetClusterConfig(ctx context.Context, request *schedulerpb.GetClusterConfigRequest) (*schedulerpb.GetClusterConfigResponse, error) {
+	if err := s.authorize(ctx, "GetClusterConfig", request); err != nil {
+		return nil, err
+	}
 	if err := s.validateRequest(request.GetHeader()); err != nil {
 		return nil, err
 	}
@@ -529,6 +587,9 @@ func (s *Server) GetClusterConfig(ctx context.Context, request *schedulerpb.GetC
 
 // PutClusterConfig implements gRPC PDServer.
 func (s *Server) PutClusterConfig(ctx context.Context, request *schedulerpb.PutClusterConfigRequest) (*schedulerpb.PutClusterConfigResponse, error) {
+	if err := s.authorize(ctx, "PutClusterConfig", request); err != nil {
+		return nil, err
+	}
 	if err := s.validateRequest(request.GetHeader()); err != nil {
 		return nil, err
 	}
@@ -551,6 +612,9 @@ func (s *Server) PutClusterConfig(ctx context.Context, request *schedulerpb.PutC
 
 // ScatterRegion implements gRPC PDServer.
 func (s *Server) ScatterRegion(ctx context.Context, request *schedulerpb.ScatterRegionRequest) (*schedulerpb.ScatterRegionResponse, error) {
+	if err := s.authorize(ctx, "ScatterRegion", request); err != nil {
+		return nil, err
+	}
 	// if err := s.validateRequest(request.GetHeader()); err != nil {
 	// 	return nil, err
 	// }
@@ -588,6 +652,9 @@ func (s *Server) ScatterRegion(ctx context.Context, request *schedulerpb.Scatter
 
 // GetGCSafePoint implements gRPC PDServer.
 func (s *Server) GetGCSafePoint(ctx context.Context, request *schedulerpb.GetGCSafePointRequest) (*schedulerpb.GetGCSafePointResponse, error) {
+	if err := s.authorize(ctx, "GetGCSafePoint", request); err != nil {
+		return nil, err
+	}
 	if err := s.validateRequest(request.GetHeader()); err != nil {
 		return nil, err
 	}
@@ -610,6 +677,9 @@ func (s *Server) GetGCSafePoint(ctx context.Context, request *schedulerpb.GetGCS
 
 // UpdateGCSafePoint implements gRPC PDServer.
 func (s *Server) UpdateGCSafePoint(ctx context.Context, request *schedulerpb.UpdateGCSafePointRequest) (*schedulerpb.UpdateGCSafePointResponse, error) {
+	if err := s.authorize(ctx, "UpdateGCSafePoint", request); err != nil {
+		return nil, err
+	}
 	if err := s.validateRequest(request.GetHeader()); err != nil {
 		return nil, err
 	}
@@ -648,6 +718,9 @@ func (s *Server) UpdateGCSafePoint(ctx context.Context, request *schedulerpb.Upd
 
 // GetOperator gets information about the operator belonging to the speicfy region.
 func (s *Server) GetOperator(ctx context.Context, request *schedulerpb.GetOperatorRequest) (*schedulerpb.GetOperatorResponse, error) {
+	if err := s.authorize(ctx, "GetOperator", request); err != nil {
+		return nil, err
+	}
 	if err := s.validateRequest(request.GetHeader()); err != nil {
 		return nil, err
 	}
@@ -677,11 +750,46 @@ func (s *Server) GetOperator(ctx context.Context, request *schedulerpb.GetOperat
 	}, nil
 }
 
+// PauseScheduling implements gRPC PDServer.
+func (s *Server) PauseScheduling(ctx context.Context, request *schedulerpb.PauseSchedulingRequest) (*schedulerpb.PauseSchedulingResponse, error) {
+	if err := s.authorize(ctx, "PauseScheduling", request); err != nil {
+		return nil, err
+	}
+	if err := s.validateRequest(request.GetHeader()); err != nil {
+		return nil, err
+	}
+
+	cluster := s.GetRaftCluster()
+	if cluster == nil {
+		return &schedulerpb.PauseSchedulingResponse{Header: s.notBootstrappedHeader()}, nil
+	}
+
+	co := cluster.coordinator
+	if request.GetResume() {
+		co.pauses.resume(request.GetScheduler())
+	} else {
+		pause := time.Duration(request.GetPauseSecs()) * time.Second
+		if err := co.pauseScheduling(request.GetScheduler(), pause); err != nil {
+			header := s.errorHeader(&schedulerpb.Error{
+				Type:    schedulerpb.ErrorType_UNKNOWN,
+				Message: fmt.Sprintf("%s: %s", err, request.GetScheduler()),
+			})
+			return &schedulerpb.PauseSchedulingResponse{Header: header}, nil
+		}
+	}
+
+	return &schedulerpb.PauseSchedulingResponse{
+		Header: s.header(),
+		Pauses: co.pauses.list(),
+	}, nil
+}
+
 // validateRequest checks if Server is leader and clusterID is matched.
 // TODO: Call it in gRPC intercepter.
 func (s *Server) validateRequest(header *schedulerpb.RequestHeader) error {
 	if s.IsClosed() || !s.member.IsLeader() {
-		return errors.WithStack(notLeaderError)
+		// not wrapped, so the client gets the status code and knows the request wasn't processed
+		return notLeaderError
 	}
 	if header.GetClusterId() != s.clusterID {
 		return status.Errorf(codes.FailedPrecondition, "mismatch cluster id, need %d but got %d", s.clusterID, header.GetClusterId())
@@ -31,6 +31,72 @@ type Allocator interface {
 	Alloc() (uint64, error)
 }
 
+// Namespace is a kind of ids, which a NamespacedAllocator allocates from its
+// own range.
+type Namespace uint64
+
+const (
+	// NamespaceDefault is the namespace of the ids allocated by Alloc, e.g.
+	// the ids the clients ask for.
+	NamespaceDefault Namespace = iota
+	// NamespaceRegion is the namespace of the ids of the regions split.
+	NamespaceRegion
+	// NamespacePeer is the namespace of the ids of the peers of the regions
+	// split.
+	NamespacePeer
+)
+
+// NamespacedAllocator is an Allocator which allocates the ids of each
+// namespace from its own range.
+type NamespacedAllocator interface {
+	Allocator
+	AllocIn(ns Namespace) (uint64, error)
+}
+
+// AllocIn allocates an id of the namespace from alloc, alloc.Alloc is used if
+// alloc has no namespaces.
+func AllocIn(alloc Allocator, ns Namespace) (uint64, error) {
+	if a, ok := alloc.(NamespacedAllocator); ok {
+		return a.AllocIn(ns)
+	}
+	return alloc.Alloc()
+}
+
+// namespaceSize is the number of ids in each namespace of a
+// DeterministicAllocator.
+const namespaceSize = uint64(1000000000)
+
+// DeterministicAllocator allocates the ids of each namespace one by one from
+// its own range, the ids of namespace n from n*1000000000+1, so the ids of a
+// test run are reproducible and tell their kinds. The ids are kept in memory
+// only, so it's for a single member cluster started afresh, e.g. in tests.
+type DeterministicAllocator struct {
+	mu   sync.Mutex
+	last map[Namespace]uint64
+}
+
+// NewDeterministicAllocator creates a DeterministicAllocator.
+func NewDeterministicAllocator() *DeterministicAllocator {
+	return &DeterministicAllocator{last: make(map[Namespace]uint64)}
+}
+
+// Alloc returns a new id of NamespaceDefault.
+func (alloc *DeterministicAllocator) Alloc() (uint64, error) {
+	return alloc.AllocIn(NamespaceDefault)
+}
+
+// AllocIn returns a new id of the namespace.
+func (alloc *DeterministicAllocator) AllocIn(ns Namespace) (uint64, error) {
+	alloc.mu.Lock()
+	defer alloc.mu.Unlock()
+
+	if alloc.last[ns]+1 >= namespaceSize {
+		return 0, errors.Errorf("ids of namespace %d are used up", ns)
+	}
+	alloc.last[ns]++
+	return uint64(ns)*namespaceSize + alloc.last[ns], nil
+}
+
 const allocStep = uint64(1000)
 
 // AllocatorImpl is used to allocate ID.
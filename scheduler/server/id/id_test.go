@@ -0,0 +1,74 @@
+// Copyright 2016 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package id
+
+import (
+	"testing"
+
+	. "github.com/pingcap/check"
+)
+
+func TestID(t *testing.T) {
+	TestingT(t)
+}
+
+var _ = Suite(&testDeterministicAllocatorSuite{})
+
+type testDeterministicAllocatorSuite struct{}
+
+type plainAllocator struct {
+	last uint64
+}
+
+func (a *plainAllocator) Alloc() (uint64, error) {
+	a.last++
+	return a.last, nil
+}
+
+func (s *testDeterministicAllocatorSuite) TestNamespaces(c *C) {
+	alloc := NewDeterministicAllocator()
+	expected := []struct {
+		ns Namespace
+		id uint64
+	}{
+		{NamespaceDefault, 1},
+		{NamespaceRegion, 1000000001},
+		{NamespacePeer, 2000000001},
+		{NamespacePeer, 2000000002},
+		{NamespaceDefault, 2},
+		{NamespaceRegion, 1000000002},
+	}
+	for _, e := range expected {
+		id, err := AllocIn(alloc, e.ns)
+		c.Assert(err, IsNil)
+		c.Assert(id, Equals, e.id)
+	}
+	id, err := alloc.Alloc()
+	c.Assert(err, IsNil)
+	c.Assert(id, Equals, uint64(3))
+
+	// a namespace is used up rather than overlapping the next one
+	alloc.last[NamespaceRegion] = namespaceSize - 2
+	id, err = alloc.AllocIn(NamespaceRegion)
+	c.Assert(err, IsNil)
+	c.Assert(id, Equals, 2*namespaceSize-1)
+	_, err = alloc.AllocIn(NamespaceRegion)
+	c.Assert(err, NotNil)
+
+	// an allocator without namespaces allocates them all by Alloc
+	plain := &plainAllocator{}
+	id, err = AllocIn(plain, NamespacePeer)
+	c.Assert(err, IsNil)
+	c.Assert(id, Equals, uint64(1))
+}
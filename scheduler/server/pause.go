@@ -0,0 +1,92 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/schedulerpb"
+	"github.com/pingcap/log"
+	"go.uber.org/zap"
+)
+
+// allScheduling is the name the pause of all the scheduling is kept under.
+const allScheduling = ""
+
+// schedulingPauses keeps the paused scheduling, by the name of a scheduler or allScheduling, until the time it's
+// resumed at. An expired pause is dropped when it's checked, so the scheduling resumes even if nobody resumes it.
+// The pauses are kept in memory only, a new leader of the scheduler starts with the scheduling resumed.
+type schedulingPauses struct {
+	sync.Mutex
+	resumeAt map[string]time.Time
+}
+
+func newSchedulingPauses() *schedulingPauses {
+	return &schedulingPauses{resumeAt: make(map[string]time.Time)}
+}
+
+func (p *schedulingPauses) pause(name string, resumeAt time.Time) {
+	p.Lock()
+	defer p.Unlock()
+	p.resumeAt[name] = resumeAt
+	log.Info("scheduling paused", zap.String("scheduler-name", name), zap.Time("resume-at", resumeAt))
+}
+
+func (p *schedulingPauses) resume(name string) {
+	p.Lock()
+	defer p.Unlock()
+	if _, ok := p.resumeAt[name]; ok {
+		delete(p.resumeAt, name)
+		log.Info("scheduling resumed", zap.String("scheduler-name", name))
+	}
+}
+
+// isPaused tells whether the scheduler of the name is paused, by its own pause or by the pause of all the
+// scheduling. Use allScheduling for the checkers, which are paused by the latter only.
+func (p *schedulingPauses) isPaused(name string) bool {
+	p.Lock()
+	defer p.Unlock()
+	now := time.Now()
+	return p.checkLocked(allScheduling, now) || (name != allScheduling && p.checkLocked(name, now))
+}
+
+func (p *schedulingPauses) checkLocked(name string, now time.Time) bool {
+	resumeAt, ok := p.resumeAt[name]
+	if !ok {
+		return false
+	}
+	if now.Before(resumeAt) {
+		return true
+	}
+	delete(p.resumeAt, name)
+	log.Info("scheduling resumed after the pause timed out", zap.String("scheduler-name", name))
+	return false
+}
+
+// list returns the pauses in effect, sorted by the scheduler name.
+func (p *schedulingPauses) list() []*schedulerpb.SchedulingPause {
+	p.Lock()
+	defer p.Unlock()
+	now := time.Now()
+	pauses := make([]*schedulerpb.SchedulingPause, 0, len(p.resumeAt))
+	for name, resumeAt := range p.resumeAt {
+		if p.checkLocked(name, now) {
+			pauses = append(pauses, &schedulerpb.SchedulingPause{Scheduler: name, ResumeAt: resumeAt.Unix()})
+		}
+	}
+	sort.Slice(pauses, func(i, j int) bool { return pauses[i].Scheduler < pauses[j].Scheduler })
+	return pauses
+}
@@ -0,0 +1,54 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/schedulerpb"
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testSchedulingPausesSuite{})
+
+type testSchedulingPausesSuite struct{}
+
+func (s *testSchedulingPausesSuite) TestSchedulingPauses(c *C) {
+	p := newSchedulingPauses()
+	c.Assert(p.isPaused("balance-leader-scheduler"), IsFalse)
+
+	resumeAt := time.Now().Add(time.Hour)
+	p.pause("balance-leader-scheduler", resumeAt)
+	c.Assert(p.isPaused("balance-leader-scheduler"), IsTrue)
+	c.Assert(p.isPaused("balance-region-scheduler"), IsFalse)
+	c.Assert(p.isPaused(allScheduling), IsFalse)
+
+	// pausing all the scheduling pauses every scheduler and the checkers
+	p.pause(allScheduling, resumeAt)
+	c.Assert(p.isPaused("balance-region-scheduler"), IsTrue)
+	c.Assert(p.isPaused(allScheduling), IsTrue)
+	c.Assert(p.list(), DeepEquals, []*schedulerpb.SchedulingPause{
+		{Scheduler: allScheduling, ResumeAt: resumeAt.Unix()},
+		{Scheduler: "balance-leader-scheduler", ResumeAt: resumeAt.Unix()},
+	})
+
+	p.resume(allScheduling)
+	c.Assert(p.isPaused("balance-region-scheduler"), IsFalse)
+	c.Assert(p.isPaused("balance-leader-scheduler"), IsTrue)
+
+	// an expired pause resumes the scheduling
+	p.pause("balance-leader-scheduler", time.Now().Add(-time.Second))
+	c.Assert(p.isPaused("balance-leader-scheduler"), IsFalse)
+	c.Assert(p.list(), HasLen, 0)
+}
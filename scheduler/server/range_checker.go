@@ -0,0 +1,201 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/schedulerpb"
+	"github.com/pingcap-incubator/tinykv/scheduler/server/core"
+	"github.com/pingcap/log"
+	"go.uber.org/zap"
+)
+
+// RegionRangesPath is the HTTP path the region range check is served at.
+const RegionRangesPath = "/scheduler/api/v1/region-ranges"
+
+// rangeRepairChecks is the number of consecutive checks an overlap is found by before it's repaired. A region split
+// or merged reports its new range soon, so an overlap which lasts is not a race with the heartbeats.
+const rangeRepairChecks = 2
+
+// RangeIssue is a gap or an overlap of the region ranges, the keys are hex encoded.
+type RangeIssue struct {
+	StartKey string `json:"start_key"`
+	EndKey   string `json:"end_key"`
+	// RegionIDs are the regions overlapping in the range, empty for a gap.
+	RegionIDs []uint64 `json:"region_ids,omitempty"`
+	// Checks is the number of consecutive checks which found the issue.
+	Checks int `json:"checks"`
+}
+
+// RangeReport is the result of the last check of the region ranges.
+type RangeReport struct {
+	Gaps       []*RangeIssue `json:"gaps"`
+	Overlaps   []*RangeIssue `json:"overlaps"`
+	UpdateTime time.Time     `json:"update_time"`
+}
+
+// rangeChecker checks periodically that the region ranges cover the keyspace without gaps or overlaps. A gap or an
+// overlap which lasts is alarmed, and the peers of a region overlapped by newer regions are sent to their stores to
+// be destroyed.
+type rangeChecker struct {
+	sync.RWMutex
+	report *RangeReport
+	// the number of consecutive checks which found each issue
+	checks map[string]int
+	// the stale peers to send to each store on its next heartbeat
+	stalePeers map[uint64][]*schedulerpb.StalePeer
+}
+
+func newRangeChecker() *rangeChecker {
+	return &rangeChecker{
+		report:     &RangeReport{},
+		checks:     make(map[string]int),
+		stalePeers: make(map[uint64][]*schedulerpb.StalePeer),
+	}
+}
+
+// check records the violations found by a check, returning the overlaps to repair.
+func (rc *rangeChecker) check(violations []*core.RangeViolation) []*core.RangeViolation {
+	rc.Lock()
+	defer rc.Unlock()
+	report := &RangeReport{UpdateTime: time.Now()}
+	checks := make(map[string]int, len(violations))
+	var repairs []*core.RangeViolation
+	for _, v := range violations {
+		issue := &RangeIssue{
+			StartKey: hex.EncodeToString(v.StartKey),
+			EndKey:   hex.EncodeToString(v.EndKey),
+		}
+		for _, region := range v.Regions {
+			issue.RegionIDs = append(issue.RegionIDs, region.GetID())
+		}
+		key := issue.StartKey + "-" + issue.EndKey
+		issue.Checks = rc.checks[key] + 1
+		checks[key] = issue.Checks
+		if v.IsGap() {
+			report.Gaps = append(report.Gaps, issue)
+		} else {
+			report.Overlaps = append(report.Overlaps, issue)
+		}
+		if issue.Checks == rangeRepairChecks {
+			log.Error("region ranges are inconsistent",
+				zap.String("start-key", issue.StartKey),
+				zap.String("end-key", issue.EndKey),
+				zap.Uint64s("overlapped-regions", issue.RegionIDs))
+		}
+		if issue.Checks >= rangeRepairChecks && !v.IsGap() {
+			repairs = append(repairs, v)
+		}
+	}
+	rc.report, rc.checks = report, checks
+	return repairs
+}
+
+func (rc *rangeChecker) addStalePeer(stale *schedulerpb.StalePeer) {
+	rc.Lock()
+	defer rc.Unlock()
+	storeID := stale.GetPeer().GetStoreId()
+	for _, p := range rc.stalePeers[storeID] {
+		if p.GetPeer().GetId() == stale.GetPeer().GetId() {
+			return
+		}
+	}
+	rc.stalePeers[storeID] = append(rc.stalePeers[storeID], stale)
+}
+
+func (rc *rangeChecker) takeStalePeers(storeID uint64) []*schedulerpb.StalePeer {
+	rc.Lock()
+	defer rc.Unlock()
+	peers := rc.stalePeers[storeID]
+	delete(rc.stalePeers, storeID)
+	return peers
+}
+
+func (rc *rangeChecker) get() *RangeReport {
+	rc.RLock()
+	defer rc.RUnlock()
+	return rc.report
+}
+
+// checkRegionRanges checks the region ranges and repairs the lasting overlaps.
+func (c *RaftCluster) checkRegionRanges() {
+	for _, v := range c.rangeChecker.check(c.core.CheckRanges()) {
+		c.repairOverlap(v)
+	}
+}
+
+// repairOverlap drops the regions of the overlap which are older than the newest one, by the version of their epochs,
+// and asks the stores to destroy their peers if they are still at the old epoch. A region which is still alive
+// reports itself again on its next heartbeat.
+func (c *RaftCluster) repairOverlap(v *core.RangeViolation) {
+	newest := v.Regions[0]
+	for _, region := range v.Regions[1:] {
+		if region.GetRegionEpoch().GetVersion() > newest.GetRegionEpoch().GetVersion() {
+			newest = region
+		}
+	}
+	for _, region := range v.Regions {
+		if region.GetRegionEpoch().GetVersion() >= newest.GetRegionEpoch().GetVersion() {
+			continue
+		}
+		log.Warn("remove stale region overlapped by a newer region",
+			zap.Uint64("region-id", region.GetID()),
+			zap.Stringer("epoch", region.GetRegionEpoch()),
+			zap.Uint64("newer-region-id", newest.GetID()),
+			zap.Stringer("newer-epoch", newest.GetRegionEpoch()))
+		c.core.RemoveRegion(region)
+		epoch := &metapb.RegionEpoch{
+			ConfVer: region.GetRegionEpoch().GetConfVer(),
+			Version: newest.GetRegionEpoch().GetVersion(),
+		}
+		for _, peer := range region.GetPeers() {
+			if newest.GetPeer(peer.GetId()) != nil {
+				continue
+			}
+			c.rangeChecker.addStalePeer(&schedulerpb.StalePeer{
+				RegionId:    region.GetID(),
+				Peer:        peer,
+				RegionEpoch: epoch,
+			})
+		}
+	}
+	// the newest region replaces the regions it overlaps in the tree
+	c.core.PutRegion(newest)
+}
+
+// GetRangeReport returns the result of the last check of the region ranges.
+func (c *RaftCluster) GetRangeReport() *RangeReport {
+	return c.rangeChecker.get()
+}
+
+// regionRangesHandler serves the result of the last check of the region ranges as JSON.
+func (s *Server) regionRangesHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cluster := s.GetRaftCluster()
+		if cluster == nil {
+			http.Error(w, "cluster is not bootstrapped", http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(cluster.GetRangeReport()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
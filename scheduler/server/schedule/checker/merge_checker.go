@@ -0,0 +1,155 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/scheduler/server/core"
+	"github.com/pingcap-incubator/tinykv/scheduler/server/schedule/operator"
+	"github.com/pingcap-incubator/tinykv/scheduler/server/schedule/opt"
+	"github.com/pingcap/log"
+	"go.uber.org/zap"
+)
+
+// MergeChecker merges the regions which stayed empty for a while, e.g. after
+// their data is deleted by DeleteRange, into an adjacent region.
+type MergeChecker struct {
+	sync.Mutex
+	cluster   opt.Cluster
+	startTime time.Time
+	// regions which were split recently, by the time of the split
+	splitTimes map[uint64]time.Time
+	// number of consecutive empty heartbeats of the empty regions
+	emptyHeartbeats map[uint64]uint64
+}
+
+// NewMergeChecker creates a merge checker.
+func NewMergeChecker(cluster opt.Cluster) *MergeChecker {
+	return &MergeChecker{
+		cluster:         cluster,
+		startTime:       time.Now(),
+		splitTimes:      make(map[uint64]time.Time),
+		emptyHeartbeats: make(map[uint64]uint64),
+	}
+}
+
+// isEmpty returns true if the region reported no data. The approximate size of
+// the region is rounded up to EmptyRegionApproximateSize.
+func isEmpty(region *core.RegionInfo) bool {
+	return region.GetApproximateSize() <= core.EmptyRegionApproximateSize && region.GetApproximateKeys() == 0
+}
+
+// RecordHeartbeat counts the consecutive heartbeats the region reported no
+// data in.
+func (m *MergeChecker) RecordHeartbeat(region *core.RegionInfo) {
+	m.Lock()
+	defer m.Unlock()
+	if isEmpty(region) {
+		m.emptyHeartbeats[region.GetID()]++
+	} else {
+		delete(m.emptyHeartbeats, region.GetID())
+	}
+}
+
+// RecordRegionSplit keeps the regions from being merged for a while after
+// they are split.
+func (m *MergeChecker) RecordRegionSplit(regionIDs ...uint64) {
+	m.Lock()
+	defer m.Unlock()
+	now := time.Now()
+	for _, id := range regionIDs {
+		m.splitTimes[id] = now
+	}
+}
+
+// recentlySplit returns true if the region was split within the split merge
+// interval, it also drops the expired split records.
+func (m *MergeChecker) recentlySplit(regionID uint64) bool {
+	interval := m.cluster.GetSplitMergeInterval()
+	for id, t := range m.splitTimes {
+		if time.Since(t) >= interval {
+			delete(m.splitTimes, id)
+		}
+	}
+	_, ok := m.splitTimes[regionID]
+	return ok
+}
+
+// Check verifies a region's size, creating an operator.Operator to merge it
+// if it stayed empty for long enough.
+func (m *MergeChecker) Check(region *core.RegionInfo) *operator.Operator {
+	if m.cluster.GetMaxMergeRegionSize() == 0 {
+		return nil
+	}
+	m.Lock()
+	defer m.Unlock()
+	if time.Since(m.startTime) < m.cluster.GetSplitMergeInterval() {
+		return nil
+	}
+	if m.recentlySplit(region.GetID()) {
+		return nil
+	}
+	if !isEmpty(region) || m.emptyHeartbeats[region.GetID()] < m.cluster.GetMergeEmptyRegionHeartbeats() {
+		return nil
+	}
+	if !m.canMerge(region) {
+		return nil
+	}
+
+	prev, next := m.cluster.GetAdjacentRegions(region)
+	var target *core.RegionInfo
+	for _, r := range []*core.RegionInfo{prev, next} {
+		if r == nil || !m.allowMerge(region, r) {
+			continue
+		}
+		if target == nil || r.GetApproximateSize() < target.GetApproximateSize() {
+			target = r
+		}
+	}
+	if target == nil {
+		return nil
+	}
+	log.Debug("try to merge empty region", zap.Uint64("from", region.GetID()), zap.Uint64("to", target.GetID()))
+	delete(m.emptyHeartbeats, region.GetID())
+	return operator.CreateMergeRegionOperator("merge-empty-region", region, target, operator.OpMerge)
+}
+
+// canMerge returns true if the region is healthy enough to take part in a merge.
+func (m *MergeChecker) canMerge(region *core.RegionInfo) bool {
+	return region.GetLeader() != nil && len(region.GetPendingPeers()) == 0 &&
+		len(region.GetPeers()) == m.cluster.GetMaxReplicas()
+}
+
+// allowMerge returns true if region can be merged into target. Both regions
+// must have their peers on the same stores, and target must not be too big.
+func (m *MergeChecker) allowMerge(region, target *core.RegionInfo) bool {
+	if !m.canMerge(target) || m.recentlySplit(target.GetID()) {
+		return false
+	}
+	if uint64(target.GetApproximateSize()) > m.cluster.GetMaxMergeRegionSize() {
+		return false
+	}
+	if maxKeys := m.cluster.GetMaxMergeRegionKeys(); maxKeys != 0 && uint64(target.GetApproximateKeys()) > maxKeys {
+		return false
+	}
+	targetStores := target.GetStoreIds()
+	for storeID := range region.GetStoreIds() {
+		if _, ok := targetStores[storeID]; !ok {
+			return false
+		}
+	}
+	return len(targetStores) == len(region.GetStoreIds())
+}
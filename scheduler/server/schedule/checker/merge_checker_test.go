@@ -0,0 +1,143 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/pingcap-incubator/tinykv/scheduler/pkg/mock/mockcluster"
+	"github.com/pingcap-incubator/tinykv/scheduler/pkg/mock/mockoption"
+	"github.com/pingcap-incubator/tinykv/scheduler/server/core"
+	"github.com/pingcap-incubator/tinykv/scheduler/server/schedule/operator"
+	. "github.com/pingcap/check"
+)
+
+func Test(t *testing.T) {
+	TestingT(t)
+}
+
+var _ = Suite(&testMergeCheckerSuite{})
+
+type testMergeCheckerSuite struct {
+	cluster *mockcluster.Cluster
+	mc      *MergeChecker
+}
+
+func (s *testMergeCheckerSuite) SetUpTest(c *C) {
+	opt := mockoption.NewScheduleOptions()
+	opt.MaxMergeRegionSize = 20
+	opt.MergeEmptyHeartbeats = 2
+	s.cluster = mockcluster.NewCluster(opt)
+	for storeID := uint64(1); storeID <= 4; storeID++ {
+		s.cluster.AddLeaderStore(storeID, 0)
+	}
+	s.putRegion(1, "", "a", 10, 1, 2, 3)
+	s.putRegion(2, "a", "b", 0, 1, 2, 3)
+	s.putRegion(3, "b", "c", 5, 1, 2, 3)
+	s.putRegion(4, "c", "", 0, 1, 2, 4)
+	s.mc = NewMergeChecker(s.cluster)
+}
+
+func (s *testMergeCheckerSuite) putRegion(id uint64, start, end string, size int64, stores ...uint64) *core.RegionInfo {
+	region := s.cluster.MockRegionInfo(id, stores[0], stores[1:], &metapb.RegionEpoch{ConfVer: 1, Version: 1}).Clone(
+		core.WithStartKey([]byte(start)),
+		core.WithEndKey([]byte(end)),
+		core.SetApproximateSize(size),
+		core.SetApproximateKeys(size),
+	)
+	s.cluster.PutRegion(region)
+	return region
+}
+
+// heartbeat records the heartbeats of the region and checks it.
+func (s *testMergeCheckerSuite) heartbeat(id uint64, times int) *operator.Operator {
+	region := s.cluster.GetRegion(id)
+	for i := 0; i < times; i++ {
+		s.mc.RecordHeartbeat(region)
+	}
+	return s.mc.Check(region)
+}
+
+func (s *testMergeCheckerSuite) checkMerge(c *C, op *operator.Operator, from, to uint64) {
+	c.Assert(op, NotNil)
+	c.Assert(op.Kind()&operator.OpMerge, Not(Equals), operator.OpKind(0))
+	c.Assert(op.RegionID(), Equals, from)
+	c.Assert(op.Len(), Equals, 1)
+	merge := op.Step(0).(operator.MergeRegion)
+	c.Assert(merge.FromRegion.GetId(), Equals, from)
+	c.Assert(merge.ToRegion.GetId(), Equals, to)
+}
+
+func (s *testMergeCheckerSuite) TestDisabled(c *C) {
+	s.cluster.MaxMergeRegionSize = 0
+	c.Assert(s.heartbeat(2, 2), IsNil)
+}
+
+func (s *testMergeCheckerSuite) TestMergeEmptyRegion(c *C) {
+	// not empty for long enough yet
+	c.Assert(s.heartbeat(2, 1), IsNil)
+	// the smaller adjacent region is the target
+	s.checkMerge(c, s.heartbeat(2, 1), 2, 3)
+	// a region with data isn't merged
+	c.Assert(s.heartbeat(3, 2), IsNil)
+
+	// a heartbeat with data resets the count
+	s.putRegion(2, "a", "b", 5, 1, 2, 3)
+	c.Assert(s.heartbeat(2, 1), IsNil)
+	s.putRegion(2, "a", "b", 0, 1, 2, 3)
+	c.Assert(s.heartbeat(2, 1), IsNil)
+	s.checkMerge(c, s.heartbeat(2, 1), 2, 3)
+}
+
+func (s *testMergeCheckerSuite) TestTarget(c *C) {
+	// the targets are too big
+	s.putRegion(1, "", "a", 30, 1, 2, 3)
+	s.putRegion(3, "b", "c", 30, 1, 2, 3)
+	c.Assert(s.heartbeat(2, 2), IsNil)
+
+	s.putRegion(1, "", "a", 10, 1, 2, 3)
+	s.checkMerge(c, s.heartbeat(2, 2), 2, 1)
+
+	// the peers of the target aren't on the same stores
+	c.Assert(s.heartbeat(4, 2), IsNil)
+	s.putRegion(3, "b", "c", 5, 1, 2, 4)
+	s.checkMerge(c, s.heartbeat(4, 2), 4, 3)
+}
+
+func (s *testMergeCheckerSuite) TestRecentlySplit(c *C) {
+	s.cluster.SplitMergeInterval = time.Hour
+	s.mc.startTime = time.Now().Add(-2 * time.Hour)
+	s.mc.RecordRegionSplit(2)
+	c.Assert(s.heartbeat(2, 2), IsNil)
+
+	// a recently split target is skipped
+	s.mc.RecordRegionSplit(3)
+	s.mc.splitTimes[2] = time.Now().Add(-2 * time.Hour)
+	s.checkMerge(c, s.heartbeat(2, 2), 2, 1)
+
+	// nothing is merged right after the start
+	s.mc.startTime = time.Now()
+	c.Assert(s.heartbeat(4, 2), IsNil)
+}
+
+func (s *testMergeCheckerSuite) TestUnhealthy(c *C) {
+	// a region without enough replicas is neither merged nor a target
+	s.putRegion(2, "a", "b", 0, 1, 2)
+	c.Assert(s.heartbeat(2, 2), IsNil)
+	s.putRegion(2, "a", "b", 0, 1, 2, 3)
+	s.putRegion(3, "b", "c", 5, 1, 2)
+	s.checkMerge(c, s.heartbeat(2, 2), 2, 1)
+}
@@ -38,6 +38,8 @@ const (
 // Replica number management.
 // Unhealthy replica management, mainly used for disaster recovery of TiKV.
 // Location management, mainly used for cross data center deployment.
+// Witness management, a region of an even number of replicas has a witness if
+// witnesses are enabled.
 type ReplicaChecker struct {
 	name    string
 	cluster opt.Cluster
@@ -68,18 +70,20 @@ func (r *ReplicaChecker) Check(region *core.RegionInfo) *operator.Operator {
 		return op
 	}
 
-	if len(region.GetPeers()) < r.cluster.GetMaxReplicas() {
+	// the witnesses store no data, so they're not counted as replicas
+	witnesses := len(region.GetWitnesses())
+	if len(region.GetPeers())-witnesses < r.cluster.GetMaxReplicas() {
 		log.Debug("region has fewer than max replicas", zap.Uint64("region-id", region.GetID()), zap.Int("peers", len(region.GetPeers())))
 		newPeer := r.selectBestPeerToAddReplica(region)
 		if newPeer == nil {
 			return nil
 		}
-		return operator.CreateAddPeerOperator("make-up-replica", region, newPeer.GetId(), newPeer.GetStoreId(), operator.OpReplica)
+		return operator.CreateAddPeerOperator("make-up-replica", r.cluster, region, newPeer.GetId(), newPeer.GetStoreId(), operator.OpReplica)
 	}
 
 	// when add learner peer, the number of peer will exceed max replicas for a while,
 	// just comparing the the number of voters to avoid too many cancel add operator log.
-	if len(region.GetVoters()) > r.cluster.GetMaxReplicas() {
+	if len(region.GetVoters())-witnesses > r.cluster.GetMaxReplicas() {
 		log.Debug("region has more than max replicas", zap.Uint64("region-id", region.GetID()), zap.Int("peers", len(region.GetPeers())))
 		oldPeer := r.selectWorstPeer(region)
 		if oldPeer == nil {
@@ -92,6 +96,28 @@ func (r *ReplicaChecker) Check(region *core.RegionInfo) *operator.Operator {
 		return op
 	}
 
+	return r.checkWitness(region)
+}
+
+// checkWitness adds a witness to a region of an even number of replicas if
+// witnesses are enabled, and removes the witnesses a region doesn't need.
+func (r *ReplicaChecker) checkWitness(region *core.RegionInfo) *operator.Operator {
+	witnesses := region.GetWitnesses()
+	needWitness := r.cluster.IsWitnessEnabled() && r.cluster.GetMaxReplicas()%2 == 0
+	if len(witnesses) > 0 && !needWitness || len(witnesses) > 1 {
+		op, err := operator.CreateRemovePeerOperator("remove-witness", r.cluster, operator.OpReplica, region, witnesses[0].GetStoreId())
+		if err != nil {
+			return nil
+		}
+		return op
+	}
+	if len(witnesses) == 0 && needWitness {
+		newPeer := r.selectBestPeerToAddReplica(region)
+		if newPeer == nil {
+			return nil
+		}
+		return operator.CreateAddWitnessOperator("add-witness", region, newPeer.GetId(), newPeer.GetStoreId(), operator.OpReplica)
+	}
 	return nil
 }
 
@@ -122,22 +148,35 @@ func (r *ReplicaChecker) selectBestStoreToAddReplica(region *core.RegionInfo, fi
 	newFilters := []filter.Filter{
 		filter.NewStateFilter(r.name),
 		filter.NewExcludedFilter(r.name, nil, region.GetStoreIds()),
+		filter.NewSnapshotFilter(r.name),
 	}
 	filters = append(filters, r.filters...)
 	filters = append(filters, newFilters...)
 	regionStores := r.cluster.GetRegionStores(region)
 	s := selector.NewReplicaSelector(regionStores, r.filters...)
-	target := s.SelectTarget(r.cluster, r.cluster.GetStores(), filters...)
+	// Prefer the stores not busy compacting, but don't leave the region short of replicas for them.
+	target := s.SelectTarget(r.cluster, r.cluster.GetStores(), append(filters, filter.NewCompactionFilter(r.name))...)
+	if target == nil {
+		target = s.SelectTarget(r.cluster, r.cluster.GetStores(), filters...)
+	}
 	if target == nil {
 		return 0
 	}
 	return target.GetID()
 }
 
-// selectWorstPeer returns the worst peer in the region.
+// selectWorstPeer returns the worst peer in the region, which isn't a witness.
 func (r *ReplicaChecker) selectWorstPeer(region *core.RegionInfo) *metapb.Peer {
 	regionStores := r.cluster.GetRegionStores(region)
-	s := selector.NewReplicaSelector(regionStores, r.filters...)
+	witnessStores := make(map[uint64]struct{})
+	for _, witness := range region.GetWitnesses() {
+		witnessStores[witness.GetStoreId()] = struct{}{}
+	}
+	filters := append([]filter.Filter{
+		filter.NewSeededPeerFilter(r.name, region),
+		filter.NewExcludedFilter(r.name, witnessStores, nil),
+	}, r.filters...)
+	s := selector.NewReplicaSelector(regionStores, filters...)
 	worstStore := s.SelectSource(r.cluster, regionStores)
 	if worstStore == nil {
 		log.Debug("no worst store", zap.Uint64("region-id", region.GetID()))
@@ -162,6 +201,14 @@ func (r *ReplicaChecker) checkOfflinePeer(region *core.RegionInfo) *operator.Ope
 		if store.IsUp() {
 			continue
 		}
+		if peer.GetIsWitness() {
+			// a witness stores no data to move, it's added again on an up store later
+			op, err := operator.CreateRemovePeerOperator("remove-offline-witness", r.cluster, operator.OpReplica, region, storeID)
+			if err != nil {
+				return nil
+			}
+			return op
+		}
 
 		return r.fixPeer(region, peer, offlineStatus)
 	}
@@ -172,7 +219,7 @@ func (r *ReplicaChecker) checkOfflinePeer(region *core.RegionInfo) *operator.Ope
 func (r *ReplicaChecker) fixPeer(region *core.RegionInfo, peer *metapb.Peer, status string) *operator.Operator {
 	removeExtra := fmt.Sprintf("remove-extra-%s-replica", status)
 	// Check the number of replicas first.
-	if len(region.GetPeers()) > r.cluster.GetMaxReplicas() {
+	if len(region.GetPeers())-len(region.GetWitnesses()) > r.cluster.GetMaxReplicas() {
 		op, err := operator.CreateRemovePeerOperator(removeExtra, r.cluster, operator.OpReplica, region, peer.GetStoreId())
 		if err != nil {
 			return nil
@@ -18,6 +18,7 @@ import (
 
 	"github.com/pingcap-incubator/tinykv/scheduler/server/core"
 	"github.com/pingcap-incubator/tinykv/scheduler/server/schedule/checker"
+	"github.com/pingcap-incubator/tinykv/scheduler/server/schedule/filter"
 	"github.com/pingcap-incubator/tinykv/scheduler/server/schedule/operator"
 	"github.com/pingcap-incubator/tinykv/scheduler/server/schedule/opt"
 )
@@ -27,6 +28,7 @@ type CheckerController struct {
 	cluster        opt.Cluster
 	opController   *OperatorController
 	replicaChecker *checker.ReplicaChecker
+	mergeChecker   *checker.MergeChecker
 }
 
 // NewCheckerController create a new CheckerController.
@@ -36,6 +38,7 @@ func NewCheckerController(ctx context.Context, cluster opt.Cluster, opController
 		cluster:        cluster,
 		opController:   opController,
 		replicaChecker: checker.NewReplicaChecker(cluster),
+		mergeChecker:   checker.NewMergeChecker(cluster),
 	}
 }
 
@@ -45,11 +48,29 @@ func (c *CheckerController) CheckRegion(region *core.RegionInfo) (bool, []*opera
 	// Don't check isRaftLearnerEnabled cause it maybe disable learner feature but there are still some learners to promote.
 	opController := c.opController
 	checkerIsBusy := true
-	if opController.OperatorCount(operator.OpReplica) < c.cluster.GetReplicaScheduleLimit() {
+	// The new replicas are made from snapshots, so none is added while the cluster is out of snapshot budget.
+	if opController.OperatorCount(operator.OpReplica) < c.cluster.GetReplicaScheduleLimit() &&
+		!filter.ClusterSnapshotBudgetExceeded(c.cluster, c.cluster.GetStores()) {
 		checkerIsBusy = false
 		if op := c.replicaChecker.Check(region); op != nil {
 			return checkerIsBusy, []*operator.Operator{op}
 		}
 	}
+	if opController.OperatorCount(operator.OpMerge) < c.cluster.GetRegionScheduleLimit() {
+		checkerIsBusy = false
+		if op := c.mergeChecker.Check(region); op != nil {
+			return checkerIsBusy, []*operator.Operator{op}
+		}
+	}
 	return checkerIsBusy, nil
 }
+
+// RecordHeartbeat lets the checkers observe a region heartbeat.
+func (c *CheckerController) RecordHeartbeat(region *core.RegionInfo) {
+	c.mergeChecker.RecordHeartbeat(region)
+}
+
+// RecordRegionSplit lets the checkers know the regions were just split.
+func (c *CheckerController) RecordRegionSplit(regionIDs ...uint64) {
+	c.mergeChecker.RecordRegionSplit(regionIDs...)
+}
@@ -161,6 +161,100 @@ func (f *healthFilter) Target(opt opt.Options, store *core.StoreInfo) bool {
 	return f.filter(opt, store)
 }
 
+type compactionFilter struct{ scope string }
+
+// NewCompactionFilter creates a Filter that filters all stores busy with a
+// heavy background compaction as targets.
+func NewCompactionFilter(scope string) Filter {
+	return &compactionFilter{scope: scope}
+}
+
+func (f *compactionFilter) Scope() string {
+	return f.scope
+}
+
+func (f *compactionFilter) Type() string {
+	return "compaction-filter"
+}
+
+func (f *compactionFilter) Source(opt opt.Options, store *core.StoreInfo) bool {
+	return false
+}
+
+func (f *compactionFilter) Target(opt opt.Options, store *core.StoreInfo) bool {
+	return store.IsCompacting()
+}
+
+type snapshotFilter struct{ scope string }
+
+// NewSnapshotFilter creates a Filter that filters the stores which have used
+// up their snapshot budget, as sources if they are sending too many snapshots
+// and as targets if they are receiving or applying too many.
+func NewSnapshotFilter(scope string) Filter {
+	return &snapshotFilter{scope: scope}
+}
+
+func (f *snapshotFilter) Scope() string {
+	return f.scope
+}
+
+func (f *snapshotFilter) Type() string {
+	return "snapshot-filter"
+}
+
+func (f *snapshotFilter) Source(opt opt.Options, store *core.StoreInfo) bool {
+	budget := opt.GetStoreSnapshotBudget()
+	return budget > 0 && store.GetSendingSnapBytes() >= budget*(1<<20)
+}
+
+func (f *snapshotFilter) Target(opt opt.Options, store *core.StoreInfo) bool {
+	budget := opt.GetStoreSnapshotBudget()
+	return budget > 0 && store.GetIncomingSnapBytes() >= budget*(1<<20)
+}
+
+// ClusterSnapshotBudgetExceeded returns true if the snapshots in flight in the
+// cluster have used up its snapshot budget, when no region should be moved.
+// A snapshot is counted once, by the store receiving it.
+func ClusterSnapshotBudgetExceeded(opt opt.Options, stores []*core.StoreInfo) bool {
+	budget := opt.GetClusterSnapshotBudget()
+	if budget == 0 {
+		return false
+	}
+	var total uint64
+	for _, store := range stores {
+		total += store.GetIncomingSnapBytes()
+	}
+	return total >= budget*(1<<20)
+}
+
+type seededPeerFilter struct {
+	scope  string
+	region *core.RegionInfo
+}
+
+// NewSeededPeerFilter creates a Filter that filters the stores whose peer of
+// the region applied a snapshot recently as sources, so a freshly seeded peer
+// isn't moved again right away.
+func NewSeededPeerFilter(scope string, region *core.RegionInfo) Filter {
+	return &seededPeerFilter{scope: scope, region: region}
+}
+
+func (f *seededPeerFilter) Scope() string {
+	return f.scope
+}
+
+func (f *seededPeerFilter) Type() string {
+	return "seeded-peer-filter"
+}
+
+func (f *seededPeerFilter) Source(opt opt.Options, store *core.StoreInfo) bool {
+	return f.region.IsPeerSeededWithin(store.GetID(), opt.GetSeededPeerProtectTime())
+}
+
+func (f *seededPeerFilter) Target(opt opt.Options, store *core.StoreInfo) bool {
+	return false
+}
+
 // StoreStateFilter is used to determine whether a store can be selected as the
 // source or target of the schedule based on the store's state.
 type StoreStateFilter struct {
@@ -209,12 +303,14 @@ func (f StoreStateFilter) Target(opts opt.Options, store *core.StoreInfo) bool {
 	if f.TransferLeader &&
 		(store.IsDisconnected() ||
 			store.IsBlocked() ||
-			store.IsBusy()) {
+			store.IsBusy() ||
+			store.IsCompacting() ||
+			store.IsSlow()) {
 		return true
 	}
 
 	if f.MoveRegion {
-		if f.filterMoveRegion(opts, store) {
+		if f.filterMoveRegion(opts, store) || store.IsCompacting() {
 			return true
 		}
 	}
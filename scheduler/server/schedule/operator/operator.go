@@ -14,6 +14,7 @@
 package operator
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"strings"
@@ -39,6 +40,7 @@ const (
 type Cluster interface {
 	GetStore(id uint64) *core.StoreInfo
 	AllocPeer(storeID uint64) (*metapb.Peer, error)
+	IsRaftLearnerEnabled() bool
 }
 
 // OpStep describes the basic scheduling steps that can not be subdivided.
@@ -95,6 +97,90 @@ func (ap AddPeer) IsFinish(region *core.RegionInfo) bool {
 	return false
 }
 
+// AddLearner is an OpStep that adds a region learner peer.
+type AddLearner struct {
+	ToStore, PeerID uint64
+}
+
+// ConfVerChanged returns true if the conf version has been changed by this step
+func (al AddLearner) ConfVerChanged(region *core.RegionInfo) bool {
+	if p := region.GetStorePeer(al.ToStore); p != nil {
+		return p.GetId() == al.PeerID
+	}
+	return false
+}
+
+func (al AddLearner) String() string {
+	return fmt.Sprintf("add learner peer %v on store %v", al.PeerID, al.ToStore)
+}
+
+// IsFinish checks if current step is finished.
+func (al AddLearner) IsFinish(region *core.RegionInfo) bool {
+	if p := region.GetStoreLearner(al.ToStore); p != nil {
+		if p.GetId() != al.PeerID {
+			log.Warn("obtain unexpected peer", zap.String("expect", al.String()), zap.Uint64("obtain-learner", p.GetId()))
+			return false
+		}
+		return region.GetPendingLearner(p.GetId()) == nil
+	}
+	return false
+}
+
+// AddWitness is an OpStep that adds a region witness, a voter which stores no
+// data.
+type AddWitness struct {
+	ToStore, PeerID uint64
+}
+
+// ConfVerChanged returns true if the conf version has been changed by this step
+func (aw AddWitness) ConfVerChanged(region *core.RegionInfo) bool {
+	if p := region.GetStorePeer(aw.ToStore); p != nil {
+		return p.GetId() == aw.PeerID
+	}
+	return false
+}
+
+func (aw AddWitness) String() string {
+	return fmt.Sprintf("add witness peer %v on store %v", aw.PeerID, aw.ToStore)
+}
+
+// IsFinish checks if current step is finished.
+func (aw AddWitness) IsFinish(region *core.RegionInfo) bool {
+	if p := region.GetStoreWitness(aw.ToStore); p != nil {
+		if p.GetId() != aw.PeerID {
+			log.Warn("obtain unexpected peer", zap.String("expect", aw.String()), zap.Uint64("obtain-witness", p.GetId()))
+			return false
+		}
+		return region.GetPendingVoter(p.GetId()) == nil
+	}
+	return false
+}
+
+// PromoteLearner is an OpStep that promotes a region learner peer to a voter.
+type PromoteLearner struct {
+	ToStore, PeerID uint64
+}
+
+// ConfVerChanged returns true if the conf version has been changed by this step
+func (pl PromoteLearner) ConfVerChanged(region *core.RegionInfo) bool {
+	return region.GetStoreVoter(pl.ToStore).GetId() == pl.PeerID
+}
+
+func (pl PromoteLearner) String() string {
+	return fmt.Sprintf("promote learner peer %v on store %v to voter", pl.PeerID, pl.ToStore)
+}
+
+// IsFinish checks if current step is finished.
+func (pl PromoteLearner) IsFinish(region *core.RegionInfo) bool {
+	if p := region.GetStoreVoter(pl.ToStore); p != nil {
+		if p.GetId() != pl.PeerID {
+			log.Warn("obtain unexpected peer", zap.String("expect", pl.String()), zap.Uint64("obtain-voter", p.GetId()))
+		}
+		return p.GetId() == pl.PeerID
+	}
+	return false
+}
+
 // RemovePeer is an OpStep that removes a region peer.
 type RemovePeer struct {
 	FromStore uint64
@@ -114,6 +200,35 @@ func (rp RemovePeer) IsFinish(region *core.RegionInfo) bool {
 	return region.GetStorePeer(rp.FromStore) == nil
 }
 
+// MergeRegion is an OpStep that merges a region into an adjacent region.
+type MergeRegion struct {
+	FromRegion *metapb.Region
+	ToRegion   *metapb.Region
+}
+
+// ConfVerChanged returns true if the conf version has been changed by this step
+func (mr MergeRegion) ConfVerChanged(region *core.RegionInfo) bool {
+	return false // merge never change the conf version of the merged region
+}
+
+func (mr MergeRegion) String() string {
+	return fmt.Sprintf("merge region %v into region %v", mr.FromRegion.GetId(), mr.ToRegion.GetId())
+}
+
+// IsFinish checks if current step is finished. The merged region doesn't
+// report after the merge, so the step finishes on a heartbeat of the target,
+// once its version is past the target of the merge and its range covers the
+// merged region.
+func (mr MergeRegion) IsFinish(region *core.RegionInfo) bool {
+	if region.GetID() != mr.ToRegion.GetId() ||
+		region.GetRegionEpoch().GetVersion() <= mr.ToRegion.GetRegionEpoch().GetVersion() {
+		return false
+	}
+	from := mr.FromRegion
+	return bytes.Compare(region.GetStartKey(), from.GetStartKey()) <= 0 &&
+		(len(region.GetEndKey()) == 0 || (len(from.GetEndKey()) != 0 && bytes.Compare(from.GetEndKey(), region.GetEndKey()) <= 0))
+}
+
 // Operator contains execution steps generated by scheduler.
 type Operator struct {
 	desc        string
@@ -298,12 +413,19 @@ func (o *Operator) IsTimeout() bool {
 }
 
 // CreateAddPeerOperator creates an operator that adds a new peer.
-func CreateAddPeerOperator(desc string, region *core.RegionInfo, peerID uint64, toStoreID uint64, kind OpKind) *Operator {
-	steps := CreateAddPeerSteps(toStoreID, peerID)
+func CreateAddPeerOperator(desc string, cluster Cluster, region *core.RegionInfo, peerID uint64, toStoreID uint64, kind OpKind) *Operator {
+	steps := CreateAddPeerSteps(toStoreID, peerID, cluster)
 	brief := fmt.Sprintf("add peer: store %v", toStoreID)
 	return NewOperator(desc, brief, region.GetID(), region.GetRegionEpoch(), kind|OpRegion, steps...)
 }
 
+// CreateAddWitnessOperator creates an operator that adds a witness to the region.
+func CreateAddWitnessOperator(desc string, region *core.RegionInfo, peerID uint64, toStoreID uint64, kind OpKind) *Operator {
+	step := AddWitness{ToStore: toStoreID, PeerID: peerID}
+	brief := fmt.Sprintf("add witness: store %v", toStoreID)
+	return NewOperator(desc, brief, region.GetID(), region.GetRegionEpoch(), kind|OpRegion, step)
+}
+
 // CreateRemovePeerOperator creates an operator that removes a peer from region.
 func CreateRemovePeerOperator(desc string, cluster Cluster, kind OpKind, region *core.RegionInfo, storeID uint64) (*Operator, error) {
 	removeKind, steps, err := removePeerSteps(cluster, region, storeID, getRegionFollowerIDs(region))
@@ -314,8 +436,16 @@ func CreateRemovePeerOperator(desc string, cluster Cluster, kind OpKind, region
 	return NewOperator(desc, brief, region.GetID(), region.GetRegionEpoch(), removeKind|kind, steps...), nil
 }
 
-// CreateAddPeerSteps creates an OpStep list that add a new peer.
-func CreateAddPeerSteps(newStore uint64, peerID uint64) []OpStep {
+// CreateAddPeerSteps creates an OpStep list that add a new peer. If raft
+// learners are enabled, the peer is added as a learner, and promoted to a
+// voter once it caught up.
+func CreateAddPeerSteps(newStore uint64, peerID uint64, cluster Cluster) []OpStep {
+	if cluster.IsRaftLearnerEnabled() {
+		return []OpStep{
+			AddLearner{ToStore: newStore, PeerID: peerID},
+			PromoteLearner{ToStore: newStore, PeerID: peerID},
+		}
+	}
 	st := []OpStep{
 		AddPeer{ToStore: newStore, PeerID: peerID},
 	}
@@ -329,6 +459,13 @@ func CreateTransferLeaderOperator(desc string, region *core.RegionInfo, sourceSt
 	return NewOperator(desc, brief, region.GetID(), region.GetRegionEpoch(), kind|OpLeader, step)
 }
 
+// CreateMergeRegionOperator creates an operator that merges region into target.
+func CreateMergeRegionOperator(desc string, region *core.RegionInfo, target *core.RegionInfo, kind OpKind) *Operator {
+	step := MergeRegion{FromRegion: region.GetMeta(), ToRegion: target.GetMeta()}
+	brief := fmt.Sprintf("merge: region %v to %v", region.GetID(), target.GetID())
+	return NewOperator(desc, brief, region.GetID(), region.GetRegionEpoch(), kind|OpMerge, step)
+}
+
 // interleaveStepGroups interleaves two slice of step groups. For example:
 //
 //  a = [[opA1, opA2], [opA3], [opA4, opA5, opA6]]
@@ -359,7 +496,7 @@ func CreateMovePeerOperator(desc string, cluster Cluster, region *core.RegionInf
 	if err != nil {
 		return nil, err
 	}
-	st := CreateAddPeerSteps(newStore, peerID)
+	st := CreateAddPeerSteps(newStore, peerID, cluster)
 	steps = append(st, steps...)
 	brief := fmt.Sprintf("mv peer: store %v to %v", oldStore, newStore)
 	return NewOperator(desc, brief, region.GetID(), region.GetRegionEpoch(), removeKind|kind|OpRegion, steps...), nil
@@ -372,16 +509,21 @@ func CreateOfflinePeerOperator(desc string, cluster Cluster, region *core.Region
 		return nil, err
 	}
 	kind |= k
-	st := CreateAddPeerSteps(newStore, peerID)
+	st := CreateAddPeerSteps(newStore, peerID, cluster)
 	steps = append(steps, st...)
 	steps = append(steps, RemovePeer{FromStore: oldStore})
 	brief := fmt.Sprintf("mv peer: store %v to %v", oldStore, newStore)
 	return NewOperator(desc, brief, region.GetID(), region.GetRegionEpoch(), kind|OpRegion, steps...), nil
 }
 
+// getRegionFollowerIDs returns the stores of the followers which may take the
+// leadership, i.e. the witnesses are skipped.
 func getRegionFollowerIDs(region *core.RegionInfo) []uint64 {
 	var ids []uint64
-	for id := range region.GetFollowers() {
+	for id, peer := range region.GetFollowers() {
+		if peer.GetIsWitness() {
+			continue
+		}
 		ids = append(ids, id)
 	}
 	return ids
@@ -107,6 +107,22 @@ func (s *testOperatorSuite) TestOperatorStep(c *C) {
 	c.Assert(RemovePeer{FromStore: 3}.IsFinish(region), IsTrue)
 }
 
+func (s *testOperatorSuite) TestLearnerStep(c *C) {
+	region := s.newTestRegion(1, 1, [2]uint64{1, 1}, [2]uint64{2, 2})
+	learner := region.Clone(core.WithAddPeer(&metapb.Peer{Id: 3, StoreId: 3, IsLearner: true}))
+	c.Assert(AddLearner{ToStore: 3, PeerID: 3}.IsFinish(region), IsFalse)
+	c.Assert(AddLearner{ToStore: 3, PeerID: 3}.IsFinish(learner), IsTrue)
+	c.Assert(AddLearner{ToStore: 3, PeerID: 3}.IsFinish(learner.Clone(core.WithPendingPeers(learner.GetLearners()))), IsFalse)
+	c.Assert(PromoteLearner{ToStore: 3, PeerID: 3}.IsFinish(learner), IsFalse)
+	c.Assert(PromoteLearner{ToStore: 3, PeerID: 3}.IsFinish(learner.Clone(core.WithPromoteLearner(3))), IsTrue)
+
+	steps := CreateAddPeerSteps(3, 3, s.cluster)
+	c.Assert(steps, DeepEquals, []OpStep{AddPeer{ToStore: 3, PeerID: 3}})
+	s.cluster.EnableRaftLearner = true
+	steps = CreateAddPeerSteps(3, 3, s.cluster)
+	c.Assert(steps, DeepEquals, []OpStep{AddLearner{ToStore: 3, PeerID: 3}, PromoteLearner{ToStore: 3, PeerID: 3}})
+}
+
 func (s *testOperatorSuite) newTestOperator(regionID uint64, kind OpKind, steps ...OpStep) *Operator {
 	return NewOperator("test", "test", regionID, &metapb.RegionEpoch{}, OpAdmin|kind, steps...)
 }
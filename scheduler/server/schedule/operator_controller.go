@@ -115,12 +115,40 @@ func (oc *OperatorController) Dispatch(region *core.RegionInfo, source string) {
 			oc.opRecords.Put(op, schedulerpb.OperatorStatus_TIMEOUT)
 		}
 	}
+	oc.checkMergeTarget(region)
+}
+
+// checkMergeTarget finishes the merge operators into the region once it
+// merged their regions, which don't report any more, or removes them if they
+// are timeout.
+func (oc *OperatorController) checkMergeTarget(region *core.RegionInfo) {
+	oc.RLock()
+	var ops []*operator.Operator
+	for _, op := range oc.operators {
+		if op.Kind()&operator.OpMerge == 0 || op.Len() == 0 {
+			continue
+		}
+		if merge, ok := op.Step(op.Len() - 1).(operator.MergeRegion); ok && merge.ToRegion.GetId() == region.GetID() {
+			ops = append(ops, op)
+		}
+	}
+	oc.RUnlock()
+	for _, op := range ops {
+		op.Check(region)
+		if op.IsFinish() && oc.RemoveOperator(op) {
+			log.Info("operator finish", zap.Uint64("region-id", op.RegionID()), zap.Duration("takes", op.RunningTime()), zap.Reflect("operator", op))
+			oc.opRecords.Put(op, schedulerpb.OperatorStatus_SUCCESS)
+		} else if op.IsTimeout() && oc.RemoveOperator(op) {
+			log.Info("operator timeout", zap.Uint64("region-id", op.RegionID()), zap.Duration("takes", op.RunningTime()), zap.Reflect("operator", op))
+			oc.opRecords.Put(op, schedulerpb.OperatorStatus_TIMEOUT)
+		}
+	}
 }
 
 func (oc *OperatorController) getNextPushOperatorTime(step operator.OpStep, now time.Time) time.Time {
 	nextTime := slowNotifyInterval
 	switch step.(type) {
-	case operator.TransferLeader:
+	case operator.TransferLeader, operator.PromoteLearner:
 		nextTime = fastNotifyInterval
 	}
 	return now.Add(nextTime)
@@ -275,6 +303,50 @@ func (oc *OperatorController) SendScheduleCommand(region *core.RegionInfo, step
 			},
 		}
 		oc.hbStreams.SendMsg(region, cmd)
+	case operator.AddLearner:
+		if region.GetStorePeer(st.ToStore) != nil {
+			// The newly added learner is pending.
+			return
+		}
+		cmd := &schedulerpb.RegionHeartbeatResponse{
+			ChangePeer: &schedulerpb.ChangePeer{
+				ChangeType: eraftpb.ConfChangeType_AddLearnerNode,
+				Peer: &metapb.Peer{
+					Id:        st.PeerID,
+					StoreId:   st.ToStore,
+					IsLearner: true,
+				},
+			},
+		}
+		oc.hbStreams.SendMsg(region, cmd)
+	case operator.AddWitness:
+		if region.GetStorePeer(st.ToStore) != nil {
+			// The newly added witness is pending.
+			return
+		}
+		cmd := &schedulerpb.RegionHeartbeatResponse{
+			ChangePeer: &schedulerpb.ChangePeer{
+				ChangeType: eraftpb.ConfChangeType_AddWitnessNode,
+				Peer: &metapb.Peer{
+					Id:        st.PeerID,
+					StoreId:   st.ToStore,
+					IsWitness: true,
+				},
+			},
+		}
+		oc.hbStreams.SendMsg(region, cmd)
+	case operator.PromoteLearner:
+		cmd := &schedulerpb.RegionHeartbeatResponse{
+			ChangePeer: &schedulerpb.ChangePeer{
+				// reuse AddNode to promote the learner
+				ChangeType: eraftpb.ConfChangeType_AddNode,
+				Peer: &metapb.Peer{
+					Id:      st.PeerID,
+					StoreId: st.ToStore,
+				},
+			},
+		}
+		oc.hbStreams.SendMsg(region, cmd)
 	case operator.RemovePeer:
 		cmd := &schedulerpb.RegionHeartbeatResponse{
 			ChangePeer: &schedulerpb.ChangePeer{
@@ -283,6 +355,13 @@ func (oc *OperatorController) SendScheduleCommand(region *core.RegionInfo, step
 			},
 		}
 		oc.hbStreams.SendMsg(region, cmd)
+	case operator.MergeRegion:
+		cmd := &schedulerpb.RegionHeartbeatResponse{
+			Merge: &schedulerpb.Merge{
+				Target: st.ToRegion,
+			},
+		}
+		oc.hbStreams.SendMsg(region, cmd)
 	default:
 		log.Error("unknown operator step", zap.Reflect("step", step))
 	}
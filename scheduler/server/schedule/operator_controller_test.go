@@ -23,6 +23,7 @@ import (
 	"github.com/pingcap-incubator/tinykv/scheduler/pkg/mock/mockcluster"
 	"github.com/pingcap-incubator/tinykv/scheduler/pkg/mock/mockhbstream"
 	"github.com/pingcap-incubator/tinykv/scheduler/pkg/mock/mockoption"
+	"github.com/pingcap-incubator/tinykv/scheduler/server/core"
 	"github.com/pingcap-incubator/tinykv/scheduler/server/schedule/operator"
 	. "github.com/pingcap/check"
 )
@@ -132,3 +133,46 @@ func (t *testOperatorControllerSuite) TestDispatchOutdatedRegion(c *C) {
 	// no new step
 	c.Assert(len(stream.MsgCh()), Equals, 3)
 }
+
+func (t *testOperatorControllerSuite) TestMergeOperatorFinish(c *C) {
+	cluster := mockcluster.NewCluster(mockoption.NewScheduleOptions())
+	stream := mockhbstream.NewHeartbeatStreams(cluster.ID)
+	controller := NewOperatorController(t.ctx, cluster, stream)
+
+	cluster.AddLeaderStore(1, 2)
+	cluster.AddLeaderStore(2, 0)
+	epoch := &metapb.RegionEpoch{ConfVer: 1, Version: 1}
+	source := cluster.MockRegionInfo(1, 1, []uint64{2}, epoch).Clone(core.WithStartKey([]byte("a")), core.WithEndKey([]byte("b")))
+	target := cluster.MockRegionInfo(2, 1, []uint64{2}, epoch).Clone(core.WithStartKey([]byte("b")), core.WithEndKey([]byte("c")))
+	cluster.PutRegion(source)
+	cluster.PutRegion(target)
+
+	op := operator.CreateMergeRegionOperator("test", source, target, operator.OpMerge)
+	c.Assert(controller.AddOperator(op), IsTrue)
+	c.Assert(controller.OperatorCount(operator.OpMerge), Equals, uint64(1))
+
+	// the target changed otherwise
+	controller.Dispatch(target.Clone(core.WithIncConfVer()), DispatchFromHeartBeat)
+	c.Assert(controller.GetOperatorStatus(1).Status, Equals, schedulerpb.OperatorStatus_RUNNING)
+	// a split of the target doesn't cover the source
+	controller.Dispatch(target.Clone(core.WithIncVersion(), core.WithEndKey([]byte("bb"))), DispatchFromHeartBeat)
+	c.Assert(controller.GetOperatorStatus(1).Status, Equals, schedulerpb.OperatorStatus_RUNNING)
+
+	// the merged target finishes the operator, the merged region doesn't report
+	merged := cluster.MockRegionInfo(2, 1, []uint64{2}, &metapb.RegionEpoch{ConfVer: 1, Version: 3}).
+		Clone(core.WithStartKey([]byte("a")), core.WithEndKey([]byte("c")))
+	controller.Dispatch(merged, DispatchFromHeartBeat)
+	c.Assert(controller.GetOperatorStatus(1).Status, Equals, schedulerpb.OperatorStatus_SUCCESS)
+	c.Assert(controller.OperatorCount(operator.OpMerge), Equals, uint64(0))
+
+	// a merge which never happens is timeout
+	cluster.PutRegion(merged)
+	source = cluster.MockRegionInfo(3, 1, []uint64{2}, epoch).Clone(core.WithStartKey([]byte("c")), core.WithEndKey([]byte("d")))
+	cluster.PutRegion(source)
+	op = operator.CreateMergeRegionOperator("test", source, merged, operator.OpMerge)
+	c.Assert(controller.AddOperator(op), IsTrue)
+	op.SetStartTime(time.Now().Add(-operator.LeaderOperatorWaitTime - time.Second))
+	controller.Dispatch(merged, DispatchFromHeartBeat)
+	c.Assert(controller.GetOperatorStatus(3).Status, Equals, schedulerpb.OperatorStatus_TIMEOUT)
+	c.Assert(controller.OperatorCount(operator.OpMerge), Equals, uint64(0))
+}
@@ -26,8 +26,19 @@ type Options interface {
 	GetRegionScheduleLimit() uint64
 	GetReplicaScheduleLimit() uint64
 
+	GetMaxMergeRegionSize() uint64
+	GetMaxMergeRegionKeys() uint64
+	GetSplitMergeInterval() time.Duration
+	GetMergeEmptyRegionHeartbeats() uint64
+
 	GetMaxStoreDownTime() time.Duration
 
+	GetStoreSnapshotBudget() uint64
+	GetClusterSnapshotBudget() uint64
+	GetSeededPeerProtectTime() time.Duration
+	IsRaftLearnerEnabled() bool
+	IsWitnessEnabled() bool
+
 	GetMaxReplicas() int
 }
 
@@ -79,12 +79,12 @@ func (s *ReplicaSelector) SelectTarget(opt opt.Options, stores []*core.StoreInfo
 // Returns -1 if store B is better than store A.
 func compareStoreScore(storeA *core.StoreInfo, storeB *core.StoreInfo) int {
 	// The store with lower region score is better.
-	if storeA.GetRegionSize() <
-		storeB.GetRegionSize() {
+	if storeA.RegionScore() <
+		storeB.RegionScore() {
 		return 1
 	}
-	if storeA.GetRegionSize() >
-		storeB.GetRegionSize() {
+	if storeA.RegionScore() >
+		storeB.RegionScore() {
 		return -1
 	}
 	return 0
@@ -86,10 +86,10 @@ func (l *balanceLeaderScheduler) Schedule(cluster opt.Cluster) *operator.Operato
 	sources := filter.SelectSourceStores(stores, l.filters, cluster)
 	targets := filter.SelectTargetStores(stores, l.filters, cluster)
 	sort.Slice(sources, func(i, j int) bool {
-		return sources[i].GetLeaderCount() > sources[j].GetLeaderCount()
+		return sources[i].LeaderScore() > sources[j].LeaderScore()
 	})
 	sort.Slice(targets, func(i, j int) bool {
-		return targets[i].GetLeaderCount() < targets[j].GetLeaderCount()
+		return targets[i].LeaderScore() < targets[j].LeaderScore()
 	})
 
 	for i := 0; i < len(sources) || i < len(targets); i++ {
@@ -133,7 +133,7 @@ func (l *balanceLeaderScheduler) transferLeaderOut(cluster opt.Cluster, source *
 	targets := cluster.GetFollowerStores(region)
 	targets = filter.SelectTargetStores(targets, l.filters, cluster)
 	sort.Slice(targets, func(i, j int) bool {
-		return targets[i].GetLeaderCount() < targets[j].GetLeaderCount()
+		return targets[i].LeaderScore() < targets[j].LeaderScore()
 	})
 	for _, target := range targets {
 		if op := l.createOperator(cluster, region, source, target); op != nil {
@@ -174,7 +174,7 @@ func (l *balanceLeaderScheduler) transferLeaderIn(cluster opt.Cluster, target *c
 func (l *balanceLeaderScheduler) createOperator(cluster opt.Cluster, region *core.RegionInfo, source, target *core.StoreInfo) *operator.Operator {
 	targetID := target.GetID()
 
-	if source.GetLeaderCount()-target.GetLeaderCount() < 2*int(1.0*leaderTolerantSizeRatio) {
+	if source.LeaderScore()-target.LeaderScore() < 2*leaderTolerantSizeRatio {
 		return nil
 	}
 
@@ -16,6 +16,7 @@ package schedulers
 import (
 	"github.com/pingcap-incubator/tinykv/scheduler/server/core"
 	"github.com/pingcap-incubator/tinykv/scheduler/server/schedule"
+	"github.com/pingcap-incubator/tinykv/scheduler/server/schedule/filter"
 	"github.com/pingcap-incubator/tinykv/scheduler/server/schedule/operator"
 	"github.com/pingcap-incubator/tinykv/scheduler/server/schedule/opt"
 )
@@ -72,10 +73,15 @@ func (s *balanceRegionScheduler) GetType() string {
 }
 
 func (s *balanceRegionScheduler) IsScheduleAllowed(cluster opt.Cluster) bool {
-	return s.opController.OperatorCount(operator.OpRegion) < cluster.GetRegionScheduleLimit()
+	return s.opController.OperatorCount(operator.OpRegion) < cluster.GetRegionScheduleLimit() &&
+		!filter.ClusterSnapshotBudgetExceeded(cluster, cluster.GetStores())
 }
 
 func (s *balanceRegionScheduler) Schedule(cluster opt.Cluster) *operator.Operator {
+	// NOTE: compare the stores by RegionScore rather than their region sizes, so stores with larger capacities or
+	// region weights hold more regions. Skip the stores filtered by filter.NewSnapshotFilter, moving a region sends a
+	// snapshot from the source and the target receives and applies it. Don't move a region off a store whose peer
+	// applied a snapshot recently either, see filter.NewSeededPeerFilter.
 	// Your Code Here (3C).
 
 	return nil
@@ -15,8 +15,10 @@ package schedulers
 
 import (
 	"context"
+	"time"
 
 	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/schedulerpb"
 	"github.com/pingcap-incubator/tinykv/scheduler/pkg/mock/mockcluster"
 	"github.com/pingcap-incubator/tinykv/scheduler/pkg/mock/mockoption"
 	"github.com/pingcap-incubator/tinykv/scheduler/pkg/testutil"
@@ -24,6 +26,7 @@ import (
 	"github.com/pingcap-incubator/tinykv/scheduler/server/kv"
 	"github.com/pingcap-incubator/tinykv/scheduler/server/schedule"
 	"github.com/pingcap-incubator/tinykv/scheduler/server/schedule/checker"
+	"github.com/pingcap-incubator/tinykv/scheduler/server/schedule/filter"
 	"github.com/pingcap-incubator/tinykv/scheduler/server/schedule/operator"
 	. "github.com/pingcap/check"
 )
@@ -320,6 +323,24 @@ func (s *testBalanceLeaderSchedulerSuite) TestBalanceFilter(c *C) {
 	c.Assert(s.schedule(), IsNil)
 }
 
+func (s *testBalanceLeaderSchedulerSuite) TestBalanceCompactingStore(c *C) {
+	// Stores:     1    2    3    4
+	// Leaders:    1    2    3   16
+	// Region1:    F    F    F    L
+	s.tc.AddLeaderStore(1, 1)
+	s.tc.AddLeaderStore(2, 2)
+	s.tc.AddLeaderStore(3, 3)
+	s.tc.AddLeaderStore(4, 16)
+	s.tc.AddLeaderRegion(1, 4, 1, 2, 3)
+
+	// If store 1 is compacting, it will be filtered,
+	// store 2 becomes the store with least leaders.
+	s.tc.SetStoreCompacting(1, true)
+	testutil.CheckTransferLeader(c, s.schedule(), operator.OpBalance, 4, 2)
+	s.tc.SetStoreCompacting(1, false)
+	testutil.CheckTransferLeader(c, s.schedule(), operator.OpBalance, 4, 1)
+}
+
 func (s *testBalanceLeaderSchedulerSuite) TestBalanceSelector(c *C) {
 	// Stores:     1    2    3    4
 	// Leaders:    1    2    3   16
@@ -497,3 +518,147 @@ func (s *testReplicaCheckerSuite) TestOffline(c *C) {
 	tc.AddRegionStore(5, 3)
 	testutil.CheckTransferPeer(c, rc.Check(region), operator.OpReplica, 3, 5)
 }
+
+func (s *testReplicaCheckerSuite) TestSnapshotBudget(c *C) {
+	opt := mockoption.NewScheduleOptions()
+	tc := mockcluster.NewCluster(opt)
+
+	rc := checker.NewReplicaChecker(tc)
+
+	tc.AddRegionStore(1, 4)
+	tc.AddRegionStore(2, 3)
+	tc.AddRegionStore(3, 2)
+	tc.AddRegionStore(4, 1)
+	tc.AddLeaderRegion(1, 1, 2)
+	region := tc.GetRegion(1)
+
+	// Store 4 is receiving 2MB of snapshots, beyond the budget of the store.
+	opt.StoreSnapshotBudget = 2
+	tc.UpdateSnapshotBytes(4, 0, 2<<20)
+	testutil.CheckAddPeer(c, rc.Check(region), operator.OpReplica, 3)
+	opt.StoreSnapshotBudget = 3
+	testutil.CheckAddPeer(c, rc.Check(region), operator.OpReplica, 4)
+
+	// Sending snapshots doesn't use up the budget of a target.
+	tc.UpdateSnapshotBytes(4, 4<<20, 0)
+	testutil.CheckAddPeer(c, rc.Check(region), operator.OpReplica, 4)
+
+	// The cluster is receiving 3MB of snapshots.
+	tc.UpdateSnapshotBytes(1, 0, 1<<20)
+	tc.UpdateSnapshotBytes(2, 0, 2<<20)
+	c.Assert(filter.ClusterSnapshotBudgetExceeded(tc, tc.GetStores()), IsFalse)
+	opt.ClusterSnapshotBudget = 3
+	c.Assert(filter.ClusterSnapshotBudgetExceeded(tc, tc.GetStores()), IsTrue)
+	opt.ClusterSnapshotBudget = 4
+	c.Assert(filter.ClusterSnapshotBudgetExceeded(tc, tc.GetStores()), IsFalse)
+}
+
+func (s *testReplicaCheckerSuite) TestCompacting(c *C) {
+	opt := mockoption.NewScheduleOptions()
+	tc := mockcluster.NewCluster(opt)
+
+	rc := checker.NewReplicaChecker(tc)
+
+	tc.AddRegionStore(1, 4)
+	tc.AddRegionStore(2, 3)
+	tc.AddRegionStore(3, 2)
+	tc.AddRegionStore(4, 1)
+	tc.AddLeaderRegion(1, 1, 2)
+	region := tc.GetRegion(1)
+
+	// A compacting store is filtered as a target only.
+	f := filter.NewCompactionFilter("test")
+	tc.SetStoreCompacting(4, true)
+	c.Assert(f.Target(opt, tc.GetStore(4)), IsTrue)
+	c.Assert(f.Source(opt, tc.GetStore(4)), IsFalse)
+	c.Assert(f.Target(opt, tc.GetStore(3)), IsFalse)
+
+	// Store 4 is compacting, we add to store 3.
+	testutil.CheckAddPeer(c, rc.Check(region), operator.OpReplica, 3)
+	// All the candidates are compacting, the region isn't left short of replicas.
+	tc.SetStoreCompacting(3, true)
+	testutil.CheckAddPeer(c, rc.Check(region), operator.OpReplica, 4)
+	tc.SetStoreCompacting(3, false)
+	tc.SetStoreCompacting(4, false)
+	testutil.CheckAddPeer(c, rc.Check(region), operator.OpReplica, 4)
+}
+
+func (s *testReplicaCheckerSuite) TestSeededPeer(c *C) {
+	opt := mockoption.NewScheduleOptions()
+	tc := mockcluster.NewCluster(opt)
+
+	newTestReplication(opt, 3)
+
+	rc := checker.NewReplicaChecker(tc)
+
+	tc.AddRegionStore(1, 1)
+	tc.AddRegionStore(2, 2)
+	tc.AddRegionStore(3, 3)
+	tc.AddRegionStore(4, 4)
+	tc.AddLeaderRegion(1, 1, 2, 3, 4)
+	region := tc.GetRegion(1)
+	testutil.CheckRemovePeer(c, rc.Check(region), 4)
+
+	// The peer on store 4 was just seeded by a snapshot.
+	opt.SeededPeerProtectTime = time.Minute
+	applied := &schedulerpb.SnapshotApplied{Peer: region.GetStorePeer(4), Index: 10, Term: 2}
+	seeded := region.Clone(core.WithSeededPeer(applied, time.Now()))
+	c.Assert(rc.Check(seeded), IsNil)
+
+	// The seeded peer is kept by the next heartbeat which doesn't report it.
+	next := region.Clone()
+	next.InheritSeededPeers(seeded)
+	c.Assert(next.GetSeededPeer(applied.Peer.Id).GetIndex(), Equals, uint64(10))
+	c.Assert(rc.Check(next), IsNil)
+
+	// It may be moved after the protect time.
+	seeded = region.Clone(core.WithSeededPeer(applied, time.Now().Add(-2*time.Minute)))
+	testutil.CheckRemovePeer(c, rc.Check(seeded), 4)
+	opt.SeededPeerProtectTime = 0
+	testutil.CheckRemovePeer(c, rc.Check(next), 4)
+}
+
+func (s *testReplicaCheckerSuite) TestWitness(c *C) {
+	opt := mockoption.NewScheduleOptions()
+	tc := mockcluster.NewCluster(opt)
+
+	newTestReplication(opt, 2)
+
+	rc := checker.NewReplicaChecker(tc)
+
+	tc.AddRegionStore(1, 4)
+	tc.AddRegionStore(2, 3)
+	tc.AddRegionStore(3, 1)
+	tc.AddRegionStore(4, 2)
+	tc.AddLeaderRegion(1, 1, 2)
+	region := tc.GetRegion(1)
+	c.Assert(rc.Check(region), IsNil)
+
+	// A region of an even number of replicas has a witness.
+	opt.EnableWitness = true
+	testutil.CheckAddWitness(c, rc.Check(region), operator.OpReplica, 3)
+	witness, _ := tc.AllocPeer(3)
+	witness.IsWitness = true
+	region = region.Clone(core.WithAddPeer(witness))
+	c.Assert(rc.Check(region), IsNil)
+
+	// The witness isn't counted as a replica.
+	newTestReplication(opt, 3)
+	testutil.CheckAddPeer(c, rc.Check(region), operator.OpReplica, 4)
+	peer4, _ := tc.AllocPeer(4)
+	region = region.Clone(core.WithAddPeer(peer4))
+	// and it's removed from a region of an odd number of replicas
+	testutil.CheckRemovePeer(c, rc.Check(region), 3)
+
+	// The witness is removed once witnesses are disabled.
+	newTestReplication(opt, 2)
+	region = region.Clone(core.WithRemoveStorePeer(4))
+	c.Assert(rc.Check(region), IsNil)
+	opt.EnableWitness = false
+	testutil.CheckRemovePeer(c, rc.Check(region), 3)
+
+	// A witness on an offline store is removed rather than moved.
+	opt.EnableWitness = true
+	tc.SetStoreOffline(3)
+	testutil.CheckRemovePeer(c, rc.Check(region), 3)
+}
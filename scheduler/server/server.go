@@ -110,7 +110,7 @@ type Server struct {
 	// for id allocator, we can use one allocator for
 	// store, region and peer, because we just need
 	// a unique ID.
-	idAllocator *id.AllocatorImpl
+	idAllocator id.Allocator
 	// for storage operation.
 	storage *core.Storage
 	// for tso.
@@ -119,6 +119,8 @@ type Server struct {
 	cluster *RaftCluster
 	// For async region heartbeat.
 	hbStreams *heartbeatStreams
+	// authenticates the API clients, nil if the APIs aren't authenticated
+	auth *authenticator
 	// Zap logger
 	lg       *zap.Logger
 	logProps *log.ZapProperties
@@ -133,6 +135,7 @@ func CreateServer(cfg *config.Config) (*Server, error) {
 		cfg:         cfg,
 		scheduleOpt: config.NewScheduleOption(cfg),
 		member:      &member.Member{},
+		auth:        newAuthenticator(cfg.Security.Roles),
 	}
 
 	// Adjust etcd config.
@@ -141,6 +144,10 @@ func CreateServer(cfg *config.Config) (*Server, error) {
 		return nil, err
 	}
 	etcdCfg.ServiceRegister = func(gs *grpc.Server) { schedulerpb.RegisterSchedulerServer(gs, s) }
+	etcdCfg.UserHandlers = map[string]http.Handler{
+		StoreAdvicePath:  s.authorizeHandler(StoreAdvicePath, s.storeAdviceHandler()),
+		RegionRangesPath: s.authorizeHandler(RegionRangesPath, s.regionRangesHandler()),
+	}
 	s.etcdCfg = etcdCfg
 	if EnableZap {
 		// The etcd master version has removed embed.Config.SetupLogging.
@@ -228,7 +235,12 @@ func (s *Server) startServer(ctx context.Context) error {
 	s.rootPath = path.Join(pdRootPath, strconv.FormatUint(s.clusterID, 10))
 	s.member.MemberInfo(s.cfg, s.Name(), s.rootPath)
 
-	s.idAllocator = id.NewAllocatorImpl(s.client, s.rootPath, s.member.MemberValue())
+	if s.cfg.PDServerCfg.DeterministicID {
+		log.Warn("allocate the ids deterministically, which is only for a test cluster")
+		s.idAllocator = id.NewDeterministicAllocator()
+	} else {
+		s.idAllocator = id.NewAllocatorImpl(s.client, s.rootPath, s.member.MemberValue())
+	}
 	s.tso = tso.NewTimestampOracle(
 		s.client,
 		s.rootPath,
@@ -436,7 +448,7 @@ func (s *Server) GetStorage() *core.Storage {
 }
 
 // GetAllocator returns the ID allocator of server.
-func (s *Server) GetAllocator() *id.AllocatorImpl {
+func (s *Server) GetAllocator() id.Allocator {
 	return s.idAllocator
 }
 
@@ -0,0 +1,171 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/scheduler/server/core"
+	"github.com/pingcap/log"
+	"go.uber.org/zap"
+)
+
+// StoreAdvicePath is the HTTP path the store advice is served at.
+const StoreAdvicePath = "/scheduler/api/v1/store-advice"
+
+// The recommendations of the store advice.
+const (
+	RecommendAddStore      = "add stores, the region count per store exceeds the soft limit"
+	RecommendPreSplit      = "pre-split the hot tables on the store, its regions are much larger than average"
+	RecommendBalanceRegion = "check that the balance-region scheduler is running and the store has enough space"
+	RecommendBalanceLeader = "check that the balance-leader scheduler is running and the store is healthy"
+)
+
+// StoreAdvice is the analysis of a store whose load diverges from the other stores.
+type StoreAdvice struct {
+	StoreID         uint64   `json:"store_id"`
+	Address         string   `json:"address"`
+	Warnings        []string `json:"warnings"`
+	Recommendations []string `json:"recommendations"`
+}
+
+// ClusterAdvice is the analysis of the load of all stores, Stores only has the stores with warnings.
+type ClusterAdvice struct {
+	Stores          []*StoreAdvice `json:"stores"`
+	Recommendations []string       `json:"recommendations"`
+	UpdateTime      time.Time      `json:"update_time"`
+}
+
+// analyzeStores compares the region count, average region size and leader count of each up store to the average of
+// all up stores, warning about a store beyond ratio times of the average.
+func analyzeStores(stores []*core.StoreInfo, ratio float64, regionCountSoftLimit uint64) *ClusterAdvice {
+	advice := &ClusterAdvice{UpdateTime: time.Now()}
+	var up []*core.StoreInfo
+	var regionCount, leaderCount int
+	var regionSize int64
+	for _, store := range stores {
+		if !store.IsUp() {
+			continue
+		}
+		up = append(up, store)
+		regionCount += store.GetRegionCount()
+		leaderCount += store.GetLeaderCount()
+		regionSize += store.GetRegionSize()
+	}
+	if len(up) == 0 {
+		return advice
+	}
+	avgRegionCount := float64(regionCount) / float64(len(up))
+	avgLeaderCount := float64(leaderCount) / float64(len(up))
+	var avgRegionSize float64
+	if regionCount > 0 {
+		avgRegionSize = float64(regionSize) / float64(regionCount)
+	}
+	if regionCountSoftLimit > 0 && avgRegionCount > float64(regionCountSoftLimit) {
+		advice.Recommendations = append(advice.Recommendations, RecommendAddStore)
+	}
+	if len(up) < 2 {
+		return advice
+	}
+
+	for _, store := range up {
+		storeAdvice := &StoreAdvice{StoreID: store.GetID(), Address: store.GetAddress()}
+		count := float64(store.GetRegionCount())
+		if count > avgRegionCount*ratio || count < avgRegionCount/ratio {
+			storeAdvice.Warnings = append(storeAdvice.Warnings,
+				fmt.Sprintf("region count %d diverges from the average %.0f", store.GetRegionCount(), avgRegionCount))
+			storeAdvice.Recommendations = append(storeAdvice.Recommendations, RecommendBalanceRegion)
+		}
+		if count > 0 && avgRegionSize > 0 {
+			size := float64(store.GetRegionSize()) / count
+			if size > avgRegionSize*ratio {
+				storeAdvice.Warnings = append(storeAdvice.Warnings,
+					fmt.Sprintf("average region size %.0fMB exceeds the average %.0fMB", size, avgRegionSize))
+				storeAdvice.Recommendations = append(storeAdvice.Recommendations, RecommendPreSplit)
+			}
+		}
+		if float64(store.GetLeaderCount()) > avgLeaderCount*ratio {
+			storeAdvice.Warnings = append(storeAdvice.Warnings,
+				fmt.Sprintf("leader count %d exceeds the average %.0f", store.GetLeaderCount(), avgLeaderCount))
+			storeAdvice.Recommendations = append(storeAdvice.Recommendations, RecommendBalanceLeader)
+		}
+		if len(storeAdvice.Warnings) > 0 {
+			advice.Stores = append(advice.Stores, storeAdvice)
+		}
+	}
+	return advice
+}
+
+// storeAdvisor keeps the latest store advice, it's updated on store heartbeats.
+type storeAdvisor struct {
+	sync.RWMutex
+	advice *ClusterAdvice
+	// the warnings of each store which are logged already
+	warned map[uint64]string
+}
+
+func newStoreAdvisor() *storeAdvisor {
+	return &storeAdvisor{
+		advice: &ClusterAdvice{},
+		warned: make(map[uint64]string),
+	}
+}
+
+// update analyzes the stores again, logging the warnings of a store when they change.
+func (a *storeAdvisor) update(stores []*core.StoreInfo, ratio float64, regionCountSoftLimit uint64) {
+	advice := analyzeStores(stores, ratio, regionCountSoftLimit)
+	a.Lock()
+	defer a.Unlock()
+	if len(advice.Recommendations) > 0 && len(a.advice.Recommendations) == 0 {
+		log.Warn("store advice", zap.Strings("recommendations", advice.Recommendations))
+	}
+	a.advice = advice
+	warned := make(map[uint64]string, len(advice.Stores))
+	for _, store := range advice.Stores {
+		warnings := fmt.Sprint(store.Warnings)
+		if a.warned[store.StoreID] != warnings {
+			log.Warn("store load diverges from the other stores",
+				zap.Uint64("store-id", store.StoreID),
+				zap.Strings("warnings", store.Warnings),
+				zap.Strings("recommendations", store.Recommendations))
+		}
+		warned[store.StoreID] = warnings
+	}
+	a.warned = warned
+}
+
+func (a *storeAdvisor) get() *ClusterAdvice {
+	a.RLock()
+	defer a.RUnlock()
+	return a.advice
+}
+
+// storeAdviceHandler serves the store advice of the cluster as JSON.
+func (s *Server) storeAdviceHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cluster := s.GetRaftCluster()
+		if cluster == nil {
+			http.Error(w, "cluster is not bootstrapped", http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(cluster.GetStoreAdvice()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
@@ -0,0 +1,55 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/pingcap-incubator/tinykv/scheduler/server/core"
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testStoreAdvisorSuite{})
+
+type testStoreAdvisorSuite struct{}
+
+func (s *testStoreAdvisorSuite) TestAnalyzeStores(c *C) {
+	stores := newTestStores(4)
+	for i, store := range stores {
+		stores[i] = store.Clone(core.SetRegionCount(100), core.SetLeaderCount(30), core.SetRegionSize(100*64))
+	}
+	advice := analyzeStores(stores, 1.5, 1000)
+	c.Assert(advice.Stores, HasLen, 0)
+	c.Assert(advice.Recommendations, HasLen, 0)
+
+	// store 1 has too many leaders, store 2 too large regions and store 3 too few regions
+	stores[0] = stores[0].Clone(core.SetLeaderCount(100))
+	stores[1] = stores[1].Clone(core.SetRegionSize(100 * 256))
+	stores[2] = stores[2].Clone(core.SetRegionCount(10), core.SetRegionSize(10*64))
+	// a tombstone store is ignored
+	stores = append(stores, core.NewStoreInfo(&metapb.Store{Id: 5, State: metapb.StoreState_Tombstone}))
+	advice = analyzeStores(stores, 1.5, 50)
+	c.Assert(advice.Recommendations, DeepEquals, []string{RecommendAddStore})
+	c.Assert(advice.Stores, HasLen, 3)
+	c.Assert(advice.Stores[0].StoreID, Equals, uint64(1))
+	c.Assert(advice.Stores[0].Recommendations, DeepEquals, []string{RecommendBalanceLeader})
+	c.Assert(advice.Stores[1].StoreID, Equals, uint64(2))
+	c.Assert(advice.Stores[1].Recommendations, DeepEquals, []string{RecommendPreSplit})
+	c.Assert(advice.Stores[2].StoreID, Equals, uint64(3))
+	c.Assert(advice.Stores[2].Recommendations, DeepEquals, []string{RecommendBalanceRegion})
+
+	// a single store is not compared
+	advice = analyzeStores(stores[:1], 1.5, 50)
+	c.Assert(advice.Stores, HasLen, 0)
+	c.Assert(advice.Recommendations, DeepEquals, []string{RecommendAddStore})
+}
@@ -138,7 +138,7 @@ func (s *TestServer) GetConfig() *config.Config {
 }
 
 // GetAllocator returns the current TestServer's ID allocator.
-func (s *TestServer) GetAllocator() *id.AllocatorImpl {
+func (s *TestServer) GetAllocator() id.Allocator {
 	s.RLock()
 	defer s.RUnlock()
 	return s.server.GetAllocator()